@@ -0,0 +1,226 @@
+package openapi
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type archiveOptions struct {
+	root string
+}
+
+// ArchiveOption is a type for the options of ReadZip and ReadTar functions.
+type ArchiveOption func(*archiveOptions)
+
+// ArchiveRoot sets the path of the root spec in the archive, e.g. `api/openapi.yaml`.
+//
+// By default, the root spec is the shallowest `openapi.yaml`, `openapi.yml` or `openapi.json` file,
+// or the shallowest YAML or JSON file having the `openapi` field if there are no such files.
+func ArchiveRoot(name string) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.root = name
+	}
+}
+
+// Archive is a spec split into several files loaded from a zip or tar archive,
+// e.g. exported by a design tool, without unpacking it to the disk.
+//
+// The relative references of the files are resolved against their paths in the archive, so the spec can be
+// validated using Documents option, if all the documents are valid OpenAPI documents,
+// or bundled into a single document, which works with any referenced files:
+//
+//	archive, err := openapi.ReadZip(r, size)
+//	...
+//	validator, err := openapi.NewValidator(archive.Spec, openapi.Documents(archive.Documents))
+//	...
+//	spec, err := archive.Bundle()
+type Archive struct {
+	// Spec is the root spec.
+	Spec *Extendable[OpenAPI]
+	// Documents are the other OpenAPI documents of the archive, i.e. the YAML or JSON files having
+	// the `openapi`, `paths` or `components` fields, keyed by their paths relative to the root spec,
+	// e.g. `schemas/pets.yaml` or `../common.yaml`, as expected by Documents option.
+	Documents map[string]*Extendable[OpenAPI]
+
+	root  string
+	files map[string][]byte
+}
+
+// ReadZip loads the spec from the zip archive of the given size.
+func ReadZip(r io.ReaderAt, size int64, opts ...ArchiveOption) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w of zip archive: %w", ErrInvalidFormat, err)
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("opening %q failed: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %q failed: %w", f.Name, err)
+		}
+		files[cleanArchivePath(f.Name)] = data
+	}
+	return newArchive(files, opts)
+}
+
+// ReadTar loads the spec from the tar archive, the gzip-compressed archives are detected automatically.
+func ReadTar(r io.Reader, opts ...ArchiveOption) (*Archive, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w of gzip archive: %w", ErrInvalidFormat, err)
+		}
+		defer gr.Close()
+		r = gr
+	} else {
+		r = br
+	}
+	tr := tar.NewReader(r)
+	files := make(map[string][]byte)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w of tar archive: %w", ErrInvalidFormat, err)
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q failed: %w", h.Name, err)
+		}
+		files[cleanArchivePath(h.Name)] = data
+	}
+	return newArchive(files, opts)
+}
+
+func newArchive(files map[string][]byte, opts []ArchiveOption) (*Archive, error) {
+	options := &archiveOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	a := &Archive{files: files, Documents: make(map[string]*Extendable[OpenAPI])}
+	if options.root != "" {
+		a.root = cleanArchivePath(options.root)
+		if _, ok := files[a.root]; !ok {
+			return nil, fmt.Errorf("root spec %q: %w", options.root, fs.ErrNotExist)
+		}
+	}
+
+	// the files are parsed in the order of their paths, so the root spec is found deterministically
+	names := sortedKeys(files)
+	slices.SortStableFunc(names, func(a, b string) int {
+		return strings.Count(a, "/") - strings.Count(b, "/")
+	})
+	docs := make(map[string]*Extendable[OpenAPI])
+	var fallback string
+	for _, name := range names {
+		switch path.Ext(name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		var fields map[string]any
+		if yaml.Unmarshal(files[name], &fields) != nil {
+			continue
+		}
+		_, hasVersion := fields["openapi"]
+		_, hasPaths := fields["paths"]
+		_, hasComponents := fields["components"]
+		if !hasVersion && !hasPaths && !hasComponents {
+			continue
+		}
+		var doc *Extendable[OpenAPI]
+		if err := Unmarshal(files[name], &doc); err != nil {
+			return nil, fmt.Errorf("unmarshaling %q failed: %w", name, err)
+		}
+		docs[name] = doc
+		if a.root != "" {
+			continue
+		}
+		switch base := path.Base(name); {
+		case base == "openapi.yaml" || base == "openapi.yml" || base == "openapi.json":
+			a.root = name
+		case hasVersion && fallback == "":
+			fallback = name
+		}
+	}
+	if a.root == "" {
+		a.root = fallback
+	}
+	if a.root == "" {
+		return nil, fmt.Errorf("root spec: %w", fs.ErrNotExist)
+	}
+	a.Spec = docs[a.root]
+	if a.Spec == nil {
+		return nil, fmt.Errorf("%w of root spec %q: expected OpenAPI document", ErrInvalidFormat, a.root)
+	}
+	dir := path.Dir(a.root)
+	for name, doc := range docs {
+		if name != a.root {
+			a.Documents[relArchivePath(dir, name)] = doc
+		}
+	}
+	return a, nil
+}
+
+// Root returns the path of the root spec in the archive.
+func (a *Archive) Root() string {
+	return a.root
+}
+
+// ReadFile returns the content of the file by its path in the archive, e.g. `api/schemas/pet.yaml`.
+func (a *Archive) ReadFile(name string) ([]byte, error) {
+	data, ok := a.files[cleanArchivePath(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return data, nil
+}
+
+// Bundle bundles the root spec with the files of the archive it references, see Bundle function;
+// the options can override the base and the loader of the files.
+func (a *Archive) Bundle(opts ...BundleOption) (*Extendable[OpenAPI], error) {
+	return Bundle(a.Spec, append([]BundleOption{BundleBase(a.root), BundleLoader(a.ReadFile)}, opts...)...)
+}
+
+// cleanArchivePath converts the path of an archive entry into the form used by the archive, e.g. `api/openapi.yaml`.
+func cleanArchivePath(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(name, `\`, "/")), "/")
+}
+
+// relArchivePath returns the path of the file relative to the directory, e.g. `../common.yaml`.
+func relArchivePath(dir, name string) string {
+	if dir == "." {
+		return name
+	}
+	dirParts, nameParts := strings.Split(dir, "/"), strings.Split(name, "/")
+	i := 0
+	for i < len(dirParts) && i < len(nameParts)-1 && dirParts[i] == nameParts[i] {
+		i++
+	}
+	return strings.Repeat("../", len(dirParts)-i) + strings.Join(nameParts[i:], "/")
+}
@@ -0,0 +1,141 @@
+package openapi_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+var testArchiveFiles = map[string]string{
+	"export/api/openapi.yaml": `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pets.yaml#/components/schemas/Pet'
+`,
+	"export/api/schemas/pets.yaml": `
+openapi: 3.1.1
+info:
+  title: Pets Schemas
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`,
+	"export/README.md": "# Pets",
+}
+
+func newTestZip(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return bytes.NewReader(b.Bytes())
+}
+
+func newTestTarGz(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	var b bytes.Buffer
+	gw := gzip.NewWriter(&b)
+	w := tar.NewWriter(gw)
+	for name, content := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, gw.Close())
+	return &b
+}
+
+func TestReadZip(t *testing.T) {
+	r := newTestZip(t, testArchiveFiles)
+	archive, err := openapi.ReadZip(r, r.Size())
+	require.NoError(t, err)
+	require.Equal(t, "export/api/openapi.yaml", archive.Root())
+	require.Equal(t, "Pets", archive.Spec.Spec.Info.Spec.Title)
+	require.Len(t, archive.Documents, 1)
+	require.Contains(t, archive.Documents, "schemas/pets.yaml")
+
+	validator, err := openapi.NewValidator(archive.Spec, openapi.Documents(archive.Documents))
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.ErrorContains(t, validator.ValidateData(
+		"/paths/~1pets/get/responses/200/content/application~1json/schema",
+		map[string]any{"name": 1},
+	), "want string")
+
+	bundled, err := archive.Bundle()
+	require.NoError(t, err)
+	require.Contains(t, bundled.Spec.Components.Spec.Schemas, "Pet")
+
+	data, err := archive.ReadFile("/export/README.md")
+	require.NoError(t, err)
+	require.Equal(t, "# Pets", string(data))
+	_, err = archive.ReadFile("missing.yaml")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestReadTar(t *testing.T) {
+	t.Run("gzip", func(t *testing.T) {
+		archive, err := openapi.ReadTar(newTestTarGz(t, testArchiveFiles))
+		require.NoError(t, err)
+		require.Equal(t, "export/api/openapi.yaml", archive.Root())
+		require.Contains(t, archive.Documents, "schemas/pets.yaml")
+	})
+
+	t.Run("root", func(t *testing.T) {
+		files := map[string]string{
+			"main.yaml":   testArchiveFiles["export/api/openapi.yaml"],
+			"common.yaml": "components:\n  schemas:\n    Pet:\n      type: object\n",
+		}
+		_, err := openapi.ReadTar(newTestTarGz(t, files), openapi.ArchiveRoot("other.yaml"))
+		require.ErrorIs(t, err, fs.ErrNotExist)
+
+		archive, err := openapi.ReadTar(newTestTarGz(t, files))
+		require.NoError(t, err)
+		require.Equal(t, "main.yaml", archive.Root())
+
+		archive, err = openapi.ReadTar(newTestTarGz(t, files), openapi.ArchiveRoot("./common.yaml"))
+		require.NoError(t, err)
+		require.Equal(t, "common.yaml", archive.Root())
+		require.Contains(t, archive.Documents, "main.yaml")
+	})
+
+	t.Run("no spec", func(t *testing.T) {
+		_, err := openapi.ReadTar(newTestTarGz(t, map[string]string{"README.md": "# Pets"}))
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := openapi.ReadTar(bytes.NewReader([]byte{0x1f, 0x8b, 0}))
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	})
+}
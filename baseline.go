@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Baseline is the list of the known validation errors and lint issues of a spec,
+// so only the new ones are reported, e.g. to adopt the validation or the lint rules for a legacy spec gradually:
+//
+//	// record the current findings
+//	err := openapi.NewBaseline(validator.ValidateSpec(), validator.Lint()...).Write(f)
+//	// report the new findings only
+//	baseline, err := openapi.ReadBaseline(f)
+//	err = baseline.Filter(validator.ValidateSpec())
+//	issues := baseline.FilterIssues(validator.Lint())
+//
+// The findings are matched by the message ID (the rule ID for the lint issues) and the location,
+// so the changes of the texts of the messages do not produce new findings.
+type Baseline struct {
+	Entries []*BaselineEntry `json:"entries" yaml:"entries"`
+}
+
+// BaselineEntry is a known finding.
+type BaselineEntry struct {
+	ID       MessageID `json:"id" yaml:"id"`
+	Location Location  `json:"location" yaml:"location"`
+	// Message is the text of the finding for the readers of the baseline file, it is not used for matching.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+type baselineKey struct {
+	id       MessageID
+	location Location
+}
+
+// NewBaseline creates the baseline with the validation errors of the given error and the given lint issues,
+// sorted by the location and the ID.
+func NewBaseline(err error, issues ...*LintIssue) *Baseline {
+	b := &Baseline{}
+	seen := make(map[baselineKey]bool)
+	add := func(e *BaselineEntry) {
+		key := baselineKey{id: e.ID, location: e.Location}
+		if !seen[key] {
+			seen[key] = true
+			b.Entries = append(b.Entries, e)
+		}
+	}
+	for _, m := range LocalizeErrors(err, nil) {
+		add(baselineEntry(m))
+	}
+	for _, issue := range issues {
+		add(&BaselineEntry{ID: MessageID(issue.Rule), Location: issue.Location, Message: issue.Message})
+	}
+	slices.SortFunc(b.Entries, func(a, b *BaselineEntry) int {
+		if c := cmp.Compare(a.Location, b.Location); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return b
+}
+
+func baselineEntry(m *Message) *BaselineEntry {
+	e := &BaselineEntry{ID: m.ID, Location: m.Location, Message: m.Text}
+	var issue *LintIssue
+	if errors.As(m.Err, &issue) {
+		e.Location, e.Message = issue.Location, issue.Message
+	}
+	return e
+}
+
+// ReadBaseline reads the baseline written by Write method.
+func ReadBaseline(r io.Reader) (*Baseline, error) {
+	var b Baseline
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("%w of baseline: %w", ErrInvalidFormat, err)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline as an indented JSON.
+func (b *Baseline) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+func (b *Baseline) keys() map[baselineKey]bool {
+	keys := make(map[baselineKey]bool, len(b.Entries))
+	for _, e := range b.Entries {
+		keys[baselineKey{id: e.ID, location: e.Location}] = true
+	}
+	return keys
+}
+
+// Filter returns the validation errors of the given error not recorded in the baseline, nil if all are known.
+func (b *Baseline) Filter(err error) error {
+	keys := b.keys()
+	var errs []error
+	for _, m := range LocalizeErrors(err, nil) {
+		e := baselineEntry(m)
+		if !keys[baselineKey{id: e.ID, location: e.Location}] {
+			errs = append(errs, m.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FilterIssues returns the lint issues not recorded in the baseline.
+func (b *Baseline) FilterIssues(issues []*LintIssue) []*LintIssue {
+	keys := b.keys()
+	var filtered []*LintIssue
+	for _, issue := range issues {
+		if !keys[baselineKey{id: MessageID(issue.Rule), location: issue.Location}] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
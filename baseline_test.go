@@ -0,0 +1,77 @@
+package openapi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestBaseline(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testLintSpec), &spec))
+	validator, err := openapi.NewValidator(spec,
+		openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityError),
+		openapi.WithRules(testDescriptionRule{}),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, openapi.NewBaseline(validator.ValidateSpec(), validator.Lint()...).Write(&buf))
+	require.JSONEq(t, `{"entries": [
+		{"id": "mutating-operations-require-auth", "location": "/paths/~1pets/delete", "message": "DELETE operation does not require authentication"},
+		{"id": "operation-summary", "location": "/paths/~1pets/get/summary", "message": "missing summary"},
+		{"id": "mutating-operations-require-auth", "location": "/paths/~1pets~1{id}/put/security/1", "message": "PUT operation allows anonymous access"},
+		{"id": "mutating-operations-require-auth", "location": "/paths/~1public~1feedback/post", "message": "POST operation does not require authentication"}
+	]}`, buf.String())
+
+	baseline, err := openapi.ReadBaseline(&buf)
+	require.NoError(t, err)
+	require.NoError(t, baseline.Filter(validator.ValidateSpec()))
+	require.Empty(t, baseline.FilterIssues(validator.Lint()))
+
+	// new findings
+	spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Put = spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Delete
+	defer func() { spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Put = nil }()
+	validator, err = validator.Clone()
+	require.NoError(t, err)
+	err = baseline.Filter(validator.ValidateSpec())
+	require.EqualError(t, err, "/paths/~1pets/put: rule violation: PUT operation does not require authentication (mutating-operations-require-auth)")
+	issues := baseline.FilterIssues(validator.Lint())
+	require.Len(t, issues, 1)
+	require.Equal(t, openapi.Location("/paths/~1pets/put"), issues[0].Location)
+
+	_, err = openapi.ReadBaseline(bytes.NewBufferString("["))
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+}
+
+func TestValidator_Lint_Ignore(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testLintSpec), &spec))
+	pets := spec.Spec.Paths.Spec.Paths["/pets"]
+	pets.Spec.Spec.Delete.AddExt(openapi.LintIgnoreExt, openapi.MutatingOperationsRequireAuthRuleID)
+	pets.Spec.AddExt(openapi.LintIgnoreExt, []any{"operation-summary", "unknown"})
+	spec.Spec.Paths.Spec.Paths["/public/feedback"].Spec.Spec.Post.AddExt(openapi.LintIgnoreExt, "operation-summary")
+
+	validator, err := openapi.NewValidator(spec,
+		openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityError),
+		openapi.WithRules(testDescriptionRule{}),
+	)
+	require.NoError(t, err)
+	var locations []openapi.Location
+	for _, issue := range validator.Lint() {
+		locations = append(locations, issue.Location)
+	}
+	require.Equal(t, []openapi.Location{
+		"/paths/~1pets~1{id}/put/security/1",
+		"/paths/~1public~1feedback/post",
+	}, locations)
+
+	pets.Spec.AddExt(openapi.LintIgnoreExt, 42)
+	err = validator.ValidateSpec()
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	require.ErrorContains(t, err, "/paths/~1pets/x-lint-ignore: invalid format of x-lint-ignore")
+}
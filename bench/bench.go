@@ -0,0 +1,390 @@
+// Package bench provides the fixtures and the helpers to benchmark the specs,
+// so the performance of the changes can be measured and the regressions caught.
+//
+// Example:
+//
+//	func BenchmarkKubernetes(b *testing.B) {
+//		bench.Run(b, bench.Kubernetes())
+//	}
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/sv-tools/openapi"
+)
+
+const (
+	// PetstoreResources is the number of the resources of Petstore fixture.
+	PetstoreResources = 2
+	// KubernetesResources is the number of the resources of Kubernetes fixture,
+	// about a thousand operations and six hundred schemas like the API of a Kubernetes cluster.
+	KubernetesResources = 150
+)
+
+// Fixture is a spec to benchmark.
+type Fixture struct {
+	// Name is the name of the fixture, used as the name of the sub-benchmarks by Run.
+	Name string
+	// Spec is the unmarshaled spec.
+	Spec *openapi.Extendable[openapi.OpenAPI]
+	// JSON is the spec encoded as JSON.
+	JSON []byte
+	// DataLocation is the location of the schema of the first resource.
+	DataLocation string
+	// Data is a valid value of the schema located at DataLocation.
+	Data any
+}
+
+// Petstore returns the fixture of a small spec with a couple of resources.
+func Petstore() *Fixture {
+	return Generate("Petstore", PetstoreResources)
+}
+
+// Kubernetes returns the fixture of a large spec comparable with the API of a Kubernetes cluster.
+func Kubernetes() *Fixture {
+	return Generate("Kubernetes", KubernetesResources)
+}
+
+// Fixtures returns all predefined fixtures from the smallest to the largest.
+func Fixtures() []*Fixture {
+	return []*Fixture{Petstore(), Kubernetes()}
+}
+
+// Generate returns the fixture of a spec with the given number of the resources in the style of Kubernetes API:
+// each resource has six operations, the collection and the item paths, and four schemas,
+// the resource, its spec, its list, and its status conditions; the schemas of the metadata and the status are shared.
+// The generated spec is valid.
+func Generate(name string, resources int) *Fixture {
+	paths := make(map[string]any, resources*2)
+	tags := make([]any, resources)
+	schemas := map[string]any{
+		"ObjectMeta": objectMetaSchema(),
+		"Status":     statusSchema(),
+	}
+	for i := 0; i < resources; i++ {
+		kind := fmt.Sprintf("Kind%d", i)
+		collection := fmt.Sprintf("/apis/group%d.example.com/v1/namespaces/{namespace}/kind%ds", i, i)
+		paths[collection] = collectionPath(kind)
+		paths[collection+"/{name}"] = itemPath(kind)
+		schemas[kind] = resourceSchema(kind)
+		schemas[kind+"Spec"] = resourceSpecSchema()
+		schemas[kind+"List"] = resourceListSchema(kind)
+		schemas[kind+"Condition"] = conditionSchema()
+		tags[i] = map[string]any{"name": kind}
+	}
+	doc := map[string]any{
+		"openapi": "3.1.1",
+		"info": map[string]any{
+			"title":   name,
+			"version": "1.0.0",
+		},
+		"servers": []any{map[string]any{"url": "https://api.example.com"}},
+		"tags":    tags,
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"parameters": map[string]any{
+				"namespace": pathParameter("namespace"),
+				"name":      pathParameter("name"),
+				"limit": map[string]any{
+					"name":   "limit",
+					"in":     "query",
+					"schema": map[string]any{"type": "integer", "minimum": 1, "maximum": 500},
+				},
+				"continue": map[string]any{
+					"name":   "continue",
+					"in":     "query",
+					"schema": map[string]any{"type": "string"},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	if err := json.Unmarshal(data, &spec); err != nil {
+		panic(err)
+	}
+	return &Fixture{
+		Name:         name,
+		Spec:         spec,
+		JSON:         data,
+		DataLocation: "#/components/schemas/Kind0",
+		Data: map[string]any{
+			"apiVersion": "group0.example.com/v1",
+			"kind":       "Kind0",
+			"metadata": map[string]any{
+				"name":              "example",
+				"namespace":         "default",
+				"labels":            map[string]any{"app": "example"},
+				"creationTimestamp": "2024-01-02T03:04:05Z",
+			},
+			"spec": map[string]any{
+				"replicas": 3,
+				"selector": map[string]any{"app": "example"},
+				"containers": []any{
+					map[string]any{"name": "app", "image": "example:1.0", "ports": []any{8080, 8443}},
+					map[string]any{"name": "sidecar", "image": "proxy:2.0"},
+				},
+			},
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": "2024-01-02T03:04:05Z"},
+				},
+			},
+		},
+	}
+}
+
+// Run runs all benchmarks of the fixture as the sub-benchmarks.
+func Run(b *testing.B, f *Fixture) {
+	b.Run(f.Name+"/Unmarshal", func(b *testing.B) { Unmarshal(b, f) })
+	b.Run(f.Name+"/Marshal", func(b *testing.B) { Marshal(b, f) })
+	b.Run(f.Name+"/ValidateSpec", func(b *testing.B) { ValidateSpec(b, f) })
+	b.Run(f.Name+"/ValidateData", func(b *testing.B) { ValidateData(b, f) })
+}
+
+// Unmarshal benchmarks unmarshaling the spec of the fixture from JSON.
+func Unmarshal(b *testing.B, f *Fixture) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(f.JSON)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		if err := json.Unmarshal(f.JSON, &spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// Marshal benchmarks marshaling the spec of the fixture to JSON.
+func Marshal(b *testing.B, f *Fixture) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(f.JSON)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(f.Spec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ValidateSpec benchmarks validating the spec of the fixture, the validator is created once.
+func ValidateSpec(b *testing.B, f *Fixture) {
+	validator, err := openapi.NewValidator(f.Spec)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validator.ValidateSpec(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ValidateData benchmarks validating the data of the fixture, the schema is compiled before the benchmark.
+func ValidateData(b *testing.B, f *Fixture) {
+	validator, err := openapi.NewValidator(f.Spec)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := validator.ValidateData(f.DataLocation, f.Data); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validator.ValidateData(f.DataLocation, f.Data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func content(schema any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+func pathParameter(name string) map[string]any {
+	return map[string]any{
+		"name":     name,
+		"in":       "path",
+		"required": true,
+		"schema":   map[string]any{"type": "string", "pattern": "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"},
+	}
+}
+
+func paramRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/parameters/" + name}
+}
+
+func responses(code string, description string, schema any) map[string]any {
+	return map[string]any{
+		code: map[string]any{"description": description, "content": content(schema)},
+		"default": map[string]any{
+			"description": "Error",
+			"content":     content(ref("Status")),
+		},
+	}
+}
+
+func collectionPath(kind string) map[string]any {
+	return map[string]any{
+		"parameters": []any{paramRef("namespace")},
+		"get": map[string]any{
+			"operationId": "list" + kind,
+			"tags":        []any{kind},
+			"parameters":  []any{paramRef("limit"), paramRef("continue")},
+			"responses":   responses("200", "OK", ref(kind+"List")),
+		},
+		"post": map[string]any{
+			"operationId": "create" + kind,
+			"tags":        []any{kind},
+			"requestBody": map[string]any{"required": true, "content": content(ref(kind))},
+			"responses":   responses("201", "Created", ref(kind)),
+		},
+	}
+}
+
+func itemPath(kind string) map[string]any {
+	return map[string]any{
+		"parameters": []any{paramRef("namespace"), paramRef("name")},
+		"get": map[string]any{
+			"operationId": "read" + kind,
+			"tags":        []any{kind},
+			"responses":   responses("200", "OK", ref(kind)),
+		},
+		"put": map[string]any{
+			"operationId": "replace" + kind,
+			"tags":        []any{kind},
+			"requestBody": map[string]any{"required": true, "content": content(ref(kind))},
+			"responses":   responses("200", "OK", ref(kind)),
+		},
+		"patch": map[string]any{
+			"operationId": "patch" + kind,
+			"tags":        []any{kind},
+			"requestBody": map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/merge-patch+json": map[string]any{"schema": map[string]any{"type": "object"}},
+				},
+			},
+			"responses": responses("200", "OK", ref(kind)),
+		},
+		"delete": map[string]any{
+			"operationId": "delete" + kind,
+			"tags":        []any{kind},
+			"responses":   responses("200", "OK", ref("Status")),
+		},
+	}
+}
+
+func objectMetaSchema() map[string]any {
+	labels := map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+		"properties": map[string]any{
+			"name":              map[string]any{"type": "string", "maxLength": 253},
+			"namespace":         map[string]any{"type": "string", "maxLength": 63},
+			"labels":            labels,
+			"annotations":       labels,
+			"resourceVersion":   map[string]any{"type": "string"},
+			"creationTimestamp": map[string]any{"type": "string", "format": "date-time"},
+		},
+	}
+}
+
+func statusSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"code":    map[string]any{"type": "integer"},
+			"message": map[string]any{"type": "string"},
+			"reason":  map[string]any{"type": "string", "enum": []any{"NotFound", "AlreadyExists", "Conflict", "Invalid"}},
+		},
+	}
+}
+
+func resourceSchema(kind string) map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"apiVersion", "kind", "metadata"},
+		"properties": map[string]any{
+			"apiVersion": map[string]any{"type": "string"},
+			"kind":       map[string]any{"type": "string", "enum": []any{kind}},
+			"metadata":   ref("ObjectMeta"),
+			"spec":       ref(kind + "Spec"),
+			"status": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"conditions": map[string]any{"type": "array", "items": ref(kind + "Condition")},
+				},
+			},
+		},
+	}
+}
+
+func resourceSpecSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"replicas": map[string]any{"type": "integer", "minimum": 0},
+			"selector": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"containers": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":     "object",
+					"required": []any{"name", "image"},
+					"properties": map[string]any{
+						"name":  map[string]any{"type": "string", "pattern": "^[a-z][-a-z0-9]*$"},
+						"image": map[string]any{"type": "string"},
+						"ports": map[string]any{
+							"type":  "array",
+							"items": map[string]any{"type": "integer", "minimum": 1, "maximum": 65535},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceListSchema(kind string) map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"items"},
+		"properties": map[string]any{
+			"metadata": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"continue": map[string]any{"type": "string"},
+				},
+			},
+			"items": map[string]any{"type": "array", "items": ref(kind)},
+		},
+	}
+}
+
+func conditionSchema() map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []any{"type", "status"},
+		"properties": map[string]any{
+			"type":               map[string]any{"type": "string"},
+			"status":             map[string]any{"type": "string", "enum": []any{"True", "False", "Unknown"}},
+			"lastTransitionTime": map[string]any{"type": "string", "format": "date-time"},
+			"message":            map[string]any{"type": "string"},
+		},
+	}
+}
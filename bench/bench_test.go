@@ -0,0 +1,80 @@
+package bench_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/bench"
+)
+
+func BenchmarkFixtures(b *testing.B) {
+	for _, f := range bench.Fixtures() {
+		bench.Run(b, f)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	for _, f := range bench.Fixtures() {
+		t.Run(f.Name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(f.Spec)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateSpec())
+			require.NoError(t, validator.ValidateData(f.DataLocation, f.Data))
+		})
+	}
+	require.Len(t, bench.Kubernetes().Spec.Spec.Paths.Spec.Paths, bench.KubernetesResources*2)
+}
+
+// TestAllocationBudget fails if the number of the allocations of an operation exceeds its budget,
+// the budgets are about 1.5 times the measured numbers, update them when the allocations are reduced.
+func TestAllocationBudget(t *testing.T) {
+	f := bench.Petstore()
+	validator, err := openapi.NewValidator(f.Spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateData(f.DataLocation, f.Data))
+
+	for _, tt := range []struct {
+		name   string
+		budget float64
+		run    func()
+	}{
+		{
+			name:   "Unmarshal",
+			budget: 7500,
+			run: func() {
+				var spec *openapi.Extendable[openapi.OpenAPI]
+				_ = json.Unmarshal(f.JSON, &spec)
+			},
+		},
+		{
+			name:   "Marshal",
+			budget: 6500,
+			run: func() {
+				_, _ = json.Marshal(f.Spec)
+			},
+		},
+		{
+			name:   "ValidateSpec",
+			budget: 1500,
+			run: func() {
+				_ = validator.ValidateSpec()
+			},
+		},
+		{
+			name:   "ValidateData",
+			budget: 250,
+			run: func() {
+				_ = validator.ValidateData(f.DataLocation, f.Data)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(10, tt.run)
+			t.Logf("%s: %.0f allocs", tt.name, allocs)
+			require.LessOrEqual(t, allocs, tt.budget, "allocations exceed the budget")
+		})
+	}
+}
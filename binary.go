@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OctetStreamMediaType is the media type of the arbitrary binary data.
+const OctetStreamMediaType = "application/octet-stream"
+
+// NewBinarySchema creates a schema of the binary content with the given media type, e.g. an uploaded file,
+// in OpenAPI v3.1 style: `contentMediaType` without `type`, since the content is not a JSON string.
+// It replaces `type: string, format: binary` of OpenAPI v3.0.
+//
+// https://spec.openapis.org/oas/v3.1.1#migrating-binary-descriptions-from-oas-3-0
+func NewBinarySchema(mediaType string) *RefOrSpec[Schema] {
+	return NewSchemaBuilder().ContentMediaType(mediaType).Build()
+}
+
+// AddBinaryProperty adds the property of the binary content with the given media type, see NewBinarySchema,
+// e.g. a file part of `multipart/form-data` request body.
+func (b *SchemaBulder) AddBinaryProperty(name string, mediaType string) *SchemaBulder {
+	return b.AddProperty(name, NewBinarySchema(mediaType))
+}
+
+// BinaryRequest sets the required request body with the binary content of the given media type,
+// e.g. `application/octet-stream` or `image/png`.
+// The media type has no schema, since the content is described by the media type itself.
+func (b *OperationBuilder) BinaryRequest(mediaType string) *OperationBuilder {
+	b.spec.Spec.RequestBody = NewRequestBodyBuilder().
+		Required(true).
+		AddContent(mediaType, NewMediaTypeBuilder().Build()).
+		Build()
+	return b
+}
+
+// BinaryFormatRuleID is the ID of BinaryFormatRule.
+const BinaryFormatRuleID = "binary-format"
+
+// BinaryFormatRule is a lint rule reporting the schemas using `type: string, format: binary` of OpenAPI v3.0
+// to describe the binary content, which should be migrated to `contentMediaType`, see NewBinarySchema.
+type BinaryFormatRule struct{}
+
+// ID implements Rule interface.
+func (r *BinaryFormatRule) ID() string {
+	return BinaryFormatRuleID
+}
+
+// Description implements Rule interface.
+func (r *BinaryFormatRule) Description() string {
+	return "the binary content must be described by `contentMediaType` instead of `type: string, format: binary`"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *BinaryFormatRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+// Check implements Rule interface.
+func (r *BinaryFormatRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	doc, err := specJSONValue(spec)
+	if err != nil {
+		return
+	}
+	r.checkJSON(doc, report)
+}
+
+func (r *BinaryFormatRule) checkJSON(doc any, report func(location string, message string)) {
+	walkBinaryFormat(doc, make(locPath, 0, 16), report)
+}
+
+// dataKeywords are the keywords holding the data instead of the schemas.
+var dataKeywords = []string{"example", "examples", "default", "const", "enum", "value"}
+
+func walkBinaryFormat(v any, path locPath, report func(location string, message string)) {
+	switch t := v.(type) {
+	case map[string]any:
+		if t["format"] == "binary" && isStringType(t["type"]) {
+			report(path.join("format"), "use `contentMediaType` instead of `type: string, format: binary`, "+
+				"or omit the schema of a binary request or response body")
+		}
+		keys := pooledSortedKeys(t)
+		for _, k := range *keys {
+			if strings.HasPrefix(k, ExtensionPrefix) || slices.Contains(dataKeywords, k) {
+				continue
+			}
+			walkBinaryFormat(t[k], append(path, k), report)
+		}
+		releaseKeys(keys)
+	case []any:
+		for i, item := range t {
+			walkBinaryFormat(item, append(path, strconv.Itoa(i)), report)
+		}
+	}
+}
+
+// isStringType reports whether the given value of `type` keyword contains `string`.
+func isStringType(v any) bool {
+	switch t := v.(type) {
+	case string:
+		return t == StringType
+	case []any:
+		return slices.Contains(t, any(StringType))
+	}
+	return false
+}
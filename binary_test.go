@@ -0,0 +1,73 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestBinaryContent(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Uploads").Version("1.0.0").Build()).
+		AddPath("/files", openapi.NewPathItemBuilder().
+			Put(openapi.NewOperationBuilder().
+				BinaryRequest(openapi.OctetStreamMediaType).
+				Build()).
+			Post(openapi.NewOperationBuilder().
+				RequestBody(openapi.NewRequestBodyBuilder().
+					AddContent("multipart/form-data", openapi.NewMediaTypeBuilder().
+						Schema(openapi.NewSchemaBuilder().
+							Type(openapi.ObjectType).
+							AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+							AddBinaryProperty("file", "image/png").
+							Build()).
+						Build()).
+					Build()).
+				Build()).
+			Build()).
+		Build()
+	item := spec.Spec.Paths.Spec.Paths["/files"].Spec.Spec
+	for _, op := range []*openapi.Extendable[openapi.Operation]{item.Put, item.Post} {
+		op.Spec.Responses = openapi.NewExtendable(&openapi.Responses{
+			Response: map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]]{
+				"204": openapi.NewResponseBuilder().Description("Uploaded").Build(),
+			},
+		})
+	}
+
+	data, err := yaml.Marshal(item.Put.Spec.RequestBody)
+	require.NoError(t, err)
+	require.YAMLEq(t, `
+content:
+  application/octet-stream: {}
+required: true
+`, string(data))
+	file := item.Post.Spec.RequestBody.Spec.Spec.Content["multipart/form-data"].Spec.Schema.Spec.Properties["file"]
+	require.Equal(t, "image/png", file.Spec.ContentMediaType)
+	require.Nil(t, file.Spec.Type)
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.Empty(t, validator.Lint())
+
+	t.Run("migration warning", func(t *testing.T) {
+		file.Spec.Type = openapi.NewSingleOrArray(openapi.StringType)
+		file.Spec.Format = "binary"
+		defer func() {
+			file.Spec.Type = nil
+			file.Spec.Format = ""
+		}()
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+		issues := validator.Lint()
+		require.Len(t, issues, 1)
+		require.Equal(t, openapi.BinaryFormatRuleID, issues[0].Rule)
+		require.Equal(t, openapi.SeverityWarning, issues[0].Severity)
+		require.Equal(t, openapi.Location("/paths/~1files/post/requestBody/content/multipart~1form-data/schema/properties/file/format"), issues[0].Location)
+	})
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrBodyTooLarge        = errors.New("body too large")
+	ErrJSONTooDeep         = errors.New("JSON nesting too deep")
+	ErrJSONTooManyElements = errors.New("JSON has too many elements")
+)
+
+// readBody reads the body respecting the MaxBodySize option.
+// The contentLength is checked before reading, so the bodies declared too large are not read at all;
+// -1 means the length is unknown.
+func (v *Validator) readBody(body io.Reader, contentLength int64) ([]byte, error) {
+	limit := v.opts.maxBodySize
+	if limit <= 0 {
+		return io.ReadAll(body)
+	}
+	if contentLength > limit {
+		return nil, fmt.Errorf("%d bytes exceeds the limit of %d bytes: %w", contentLength, limit, ErrBodyTooLarge)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return data, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("exceeds the limit of %d bytes: %w", limit, ErrBodyTooLarge)
+	}
+	return data, nil
+}
+
+// checkJSONLimits scans the JSON data token by token, without materializing the value,
+// and checks it against the MaxJSONDepth and MaxJSONElements options.
+func (v *Validator) checkJSONLimits(data []byte) error {
+	return v.newJSONLimiter().check(data, 0)
+}
+
+// jsonLimiter checks the JSON values against the MaxJSONDepth and MaxJSONElements options.
+// The elements are counted across all checked values, so the elements of a stream can be checked one by one.
+type jsonLimiter struct {
+	maxDepth    int
+	maxElements int
+	elements    int
+}
+
+func (v *Validator) newJSONLimiter() *jsonLimiter {
+	return &jsonLimiter{maxDepth: v.opts.maxJSONDepth, maxElements: v.opts.maxJSONElements}
+}
+
+// check scans the JSON data nested into the containers of the given depth.
+func (l *jsonLimiter) check(data []byte, depth int) error {
+	if l.maxDepth <= 0 && l.maxElements <= 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var (
+		// isObject tracks the kind of the open containers to skip the keys of the objects
+		isObject []bool
+		isKey    bool
+	)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			// the syntax errors are reported by the parser
+			return nil
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			depth--
+			isObject = isObject[:len(isObject)-1]
+			isKey = len(isObject) > 0 && isObject[len(isObject)-1]
+			continue
+		}
+		if isKey {
+			isKey = false
+			continue
+		}
+		l.elements++
+		if l.maxElements > 0 && l.elements > l.maxElements {
+			return fmt.Errorf("more than %d elements: %w", l.maxElements, ErrJSONTooManyElements)
+		}
+		if d, ok := tok.(json.Delim); ok {
+			depth++
+			if l.maxDepth > 0 && depth > l.maxDepth {
+				return fmt.Errorf("more than %d levels: %w", l.maxDepth, ErrJSONTooDeep)
+			}
+			isObject = append(isObject, d == '{')
+			isKey = d == '{'
+			continue
+		}
+		isKey = len(isObject) > 0 && isObject[len(isObject)-1]
+	}
+}
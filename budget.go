@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// TimeoutExt is the name of the extension holding the time budget of the operations,
+	// either a duration string, like `1.5s`, or a number of seconds.
+	// The extension of an operation overrides the one of its path item, which overrides the one of the document.
+	//
+	// Example:
+	//
+	//	get:
+	//	  x-timeout: 500ms
+	TimeoutExt = "x-timeout"
+	// RateLimitExt is the name of the extension holding the rate limit of the operations, see RateLimit.
+	// The extension of an operation overrides the one of its path item, which overrides the one of the document.
+	//
+	// Example:
+	//
+	//	post:
+	//	  x-rate-limit:
+	//	    requests: 100
+	//	    period: 1m
+	//	    burst: 10
+	RateLimitExt = "x-rate-limit"
+)
+
+// RateLimit is the value of the `x-rate-limit` extension.
+type RateLimit struct {
+	// Requests is the number of the requests allowed per the period.
+	Requests int `json:"requests" yaml:"requests"`
+	// Period is the duration string, `1s` if empty.
+	Period string `json:"period,omitempty" yaml:"period,omitempty"`
+	// Burst is the number of the requests allowed to exceed the rate at once.
+	Burst int `json:"burst,omitempty" yaml:"burst,omitempty"`
+}
+
+// Interval returns the duration of the period of the rate limit.
+func (o *RateLimit) Interval() (time.Duration, error) {
+	if o.Period == "" {
+		return time.Second, nil
+	}
+	d, err := time.ParseDuration(o.Period)
+	if err != nil {
+		return 0, fmt.Errorf("%w of period: %w", ErrInvalidFormat, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%w: period must be positive, but got %s", ErrInvalidValue, o.Period)
+	}
+	return d, nil
+}
+
+// Timeout sets the time budget of the operation.
+func (b *OperationBuilder) Timeout(d time.Duration) *OperationBuilder {
+	b.spec.AddExt(TimeoutExt, d.String())
+	return b
+}
+
+// RateLimit sets the rate limit of the operation.
+func (b *OperationBuilder) RateLimit(limit RateLimit) *OperationBuilder {
+	b.spec.AddExt(RateLimitExt, &limit)
+	return b
+}
+
+// TimeoutOf returns the time budget of the given object, usually an operation.
+// The ok is false if the timeout is not set, and an error is returned if its value is invalid.
+func TimeoutOf[T any](o *Extendable[T]) (d time.Duration, ok bool, err error) {
+	if o == nil {
+		return 0, false, nil
+	}
+	v, ok := o.Extensions[TimeoutExt]
+	if !ok {
+		return 0, false, nil
+	}
+	d, err = parseTimeout(v)
+	return d, true, err
+}
+
+func parseTimeout(v any) (time.Duration, error) {
+	var d time.Duration
+	switch t := extensionValue(v).(type) {
+	case string:
+		var err error
+		d, err = time.ParseDuration(t)
+		if err != nil {
+			return 0, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, TimeoutExt, err)
+		}
+	case int:
+		d = time.Duration(t) * time.Second
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return 0, fmt.Errorf("%w of %s: %v", ErrInvalidValue, TimeoutExt, t)
+		}
+		d = time.Duration(t * float64(time.Second))
+	default:
+		return 0, fmt.Errorf("%w of %s, expected duration string or number of seconds, but got %T", ErrInvalidFormat, TimeoutExt, v)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%w of %s: must be positive, but got %v", ErrInvalidValue, TimeoutExt, v)
+	}
+	return d, nil
+}
+
+// RateLimitOf returns the rate limit of the given object, usually an operation.
+// The ok is false if the rate limit is not set, and an error is returned if its value is invalid.
+func RateLimitOf[T any](o *Extendable[T]) (limit *RateLimit, ok bool, err error) {
+	if o == nil {
+		return nil, false, nil
+	}
+	v, ok := o.Extensions[RateLimitExt]
+	if !ok {
+		return nil, false, nil
+	}
+	limit, err = parseRateLimit(v)
+	return limit, true, err
+}
+
+func parseRateLimit(v any) (*RateLimit, error) {
+	var limit RateLimit
+	switch t := v.(type) {
+	case *RateLimit:
+		limit = *t
+	case RateLimit:
+		limit = t
+	default:
+		// the value decoded from JSON or YAML is a map
+		if err := decodeExtension(v, &limit); err != nil {
+			return nil, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, RateLimitExt, err)
+		}
+	}
+	if limit.Requests <= 0 {
+		return nil, fmt.Errorf("%w of %s: requests must be positive, but got %d", ErrInvalidValue, RateLimitExt, limit.Requests)
+	}
+	if limit.Burst < 0 {
+		return nil, fmt.Errorf("%w of %s: burst must not be negative, but got %d", ErrInvalidValue, RateLimitExt, limit.Burst)
+	}
+	if _, err := limit.Interval(); err != nil {
+		return nil, fmt.Errorf("%s: %w", RateLimitExt, err)
+	}
+	return &limit, nil
+}
+
+// Budget holds the time budget and the rate limit of an operation.
+type Budget struct {
+	// Location is the JSON Pointer of the operation, e.g. `/paths/~1pets/get`.
+	Location string
+	// Timeout is zero if the operation has no time budget.
+	Timeout time.Duration
+	// RateLimit is nil if the operation is not rate limited.
+	RateLimit *RateLimit
+}
+
+// Budget returns the budget of the operation matching the given HTTP request,
+// the extensions of the operation override the ones of the path item and the document.
+// An error wrapping ErrOperationNotFound is returned if no operation matches the request.
+func (v *Validator) Budget(r *http.Request) (*Budget, error) {
+	match, err := v.matchRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	budget := &Budget{Location: match.location}
+	var errs []error
+	for _, src := range []struct {
+		location   string
+		extensions map[string]any
+	}{
+		{location: "", extensions: v.spec.Extensions},
+		{location: strings.TrimSuffix(match.location, "/"+strings.ToLower(r.Method)), extensions: match.pathItem.Extensions},
+		{location: match.location, extensions: match.operation.Extensions},
+	} {
+		if value, ok := src.extensions[TimeoutExt]; ok {
+			if budget.Timeout, err = parseTimeout(value); err != nil {
+				errs = append(errs, newValidationError(joinLoc(src.location, TimeoutExt), err))
+			}
+		}
+		if value, ok := src.extensions[RateLimitExt]; ok {
+			if budget.RateLimit, err = parseRateLimit(value); err != nil {
+				errs = append(errs, newValidationError(joinLoc(src.location, RateLimitExt), err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// BudgetHook enforces the budgets of the operations, it is called by BudgetMiddleware for each request
+// matching an operation of the spec and must call the next handler to continue the request processing.
+type BudgetHook interface {
+	ServeBudget(w http.ResponseWriter, r *http.Request, budget *Budget, next http.Handler)
+}
+
+// BudgetHookFunc is a function implementing BudgetHook interface.
+type BudgetHookFunc func(w http.ResponseWriter, r *http.Request, budget *Budget, next http.Handler)
+
+// ServeBudget implements BudgetHook interface.
+func (f BudgetHookFunc) ServeBudget(w http.ResponseWriter, r *http.Request, budget *Budget, next http.Handler) {
+	f(w, r, budget, next)
+}
+
+// TimeoutHook returns the hook setting the deadline of the context of the request to the time budget of the operation.
+func TimeoutHook() BudgetHook {
+	return BudgetHookFunc(func(w http.ResponseWriter, r *http.Request, budget *Budget, next http.Handler) {
+		if budget.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(r.Context(), budget.Timeout)
+			defer cancel()
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// BudgetMiddleware returns the net/http middleware calling the given hooks in order with the budget of the operation
+// matching each request. The requests not matching any operation are passed to the next handler as is,
+// and the invalid budgets are reported by the onError function, if given, or with the internal server error status.
+func (v *Validator) BudgetMiddleware(onError func(w http.ResponseWriter, r *http.Request, err error), hooks ...BudgetHook) func(http.Handler) http.Handler {
+	if onError == nil {
+		onError = func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			budget, err := v.Budget(r)
+			if errors.Is(err, ErrOperationNotFound) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err != nil {
+				onError(w, r, err)
+				return
+			}
+			h := next
+			for i := len(hooks) - 1; i >= 0; i-- {
+				hook, inner := hooks[i], h
+				h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					hook.ServeBudget(w, r, budget, inner)
+				})
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
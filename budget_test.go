@@ -0,0 +1,211 @@
+package openapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testBudgetSpec = `
+openapi: 3.1.1
+info:
+  title: Budget
+  version: 1.0.0
+x-timeout: 10s
+paths:
+  /pets:
+    x-rate-limit:
+      requests: 100
+      period: 1m
+    get:
+      x-timeout: 1.5
+      responses:
+        '200':
+          description: OK
+    post:
+      x-rate-limit:
+        requests: 10
+        burst: 2
+      responses:
+        '201':
+          description: Created
+  /broken:
+    get:
+      x-timeout: soon
+      x-rate-limit:
+        requests: 0
+      responses:
+        '200':
+          description: OK
+`
+
+func TestValidator_Budget(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testBudgetSpec), &spec))
+	v, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		name     string
+		method   string
+		path     string
+		expected *openapi.Budget
+		err      error
+	}{
+		{
+			name:   "operation timeout and path item rate limit",
+			method: http.MethodGet,
+			path:   "/pets",
+			expected: &openapi.Budget{
+				Location:  "/paths/~1pets/get",
+				Timeout:   1500 * time.Millisecond,
+				RateLimit: &openapi.RateLimit{Requests: 100, Period: "1m"},
+			},
+		},
+		{
+			name:   "document timeout and operation rate limit",
+			method: http.MethodPost,
+			path:   "/pets",
+			expected: &openapi.Budget{
+				Location:  "/paths/~1pets/post",
+				Timeout:   10 * time.Second,
+				RateLimit: &openapi.RateLimit{Requests: 10, Burst: 2},
+			},
+		},
+		{
+			name:   "invalid",
+			method: http.MethodGet,
+			path:   "/broken",
+			err:    openapi.ErrInvalidFormat,
+		},
+		{
+			name:   "not found",
+			method: http.MethodGet,
+			path:   "/unknown",
+			err:    openapi.ErrOperationNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := v.Budget(httptest.NewRequest(tt.method, tt.path, nil))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, budget)
+		})
+	}
+
+	err = v.ValidateSpec()
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	require.ErrorContains(t, err, "/paths/~1broken/get/x-timeout")
+	require.ErrorContains(t, err, "/paths/~1broken/get/x-rate-limit")
+}
+
+func TestOperationBuilder_Budget(t *testing.T) {
+	op := openapi.NewOperationBuilder().
+		Timeout(2 * time.Second).
+		RateLimit(openapi.RateLimit{Requests: 5, Period: "1h"}).
+		Build()
+
+	timeout, ok, err := openapi.TimeoutOf(op)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, timeout)
+
+	limit, ok, err := openapi.RateLimitOf(op)
+	require.NoError(t, err)
+	require.True(t, ok)
+	interval, err := limit.Interval()
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, interval)
+
+	_, ok, err = openapi.TimeoutOf(openapi.NewOperationBuilder().Build())
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestValidator_BudgetMiddleware(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testBudgetSpec), &spec))
+	v, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	var (
+		calls    []string
+		deadline time.Time
+	)
+	record := func(name string) openapi.BudgetHook {
+		return openapi.BudgetHookFunc(func(w http.ResponseWriter, r *http.Request, budget *openapi.Budget, next http.Handler) {
+			calls = append(calls, name+":"+budget.Location)
+			next.ServeHTTP(w, r)
+		})
+	}
+	limiter := openapi.BudgetHookFunc(func(w http.ResponseWriter, r *http.Request, budget *openapi.Budget, next http.Handler) {
+		if budget.RateLimit != nil && budget.RateLimit.Requests < 50 {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+	handler := v.BudgetMiddleware(nil, record("first"), openapi.TimeoutHook(), limiter, record("second"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deadline, _ = r.Context().Deadline()
+			calls = append(calls, "handler")
+		}),
+	)
+
+	for _, tt := range []struct {
+		name     string
+		method   string
+		path     string
+		status   int
+		calls    []string
+		deadline bool
+	}{
+		{
+			name:     "allowed",
+			method:   http.MethodGet,
+			path:     "/pets",
+			status:   http.StatusOK,
+			calls:    []string{"first:/paths/~1pets/get", "second:/paths/~1pets/get", "handler"},
+			deadline: true,
+		},
+		{
+			name:   "limited",
+			method: http.MethodPost,
+			path:   "/pets",
+			status: http.StatusTooManyRequests,
+			calls:  []string{"first:/paths/~1pets/post"},
+		},
+		{
+			name:   "unknown operation",
+			method: http.MethodGet,
+			path:   "/unknown",
+			status: http.StatusOK,
+			calls:  []string{"handler"},
+		},
+		{
+			name:   "invalid budget",
+			method: http.MethodGet,
+			path:   "/broken",
+			status: http.StatusInternalServerError,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, deadline = nil, time.Time{}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, tt.path, nil).WithContext(context.Background())
+			handler.ServeHTTP(w, r)
+			require.Equal(t, tt.status, w.Code)
+			require.Equal(t, tt.calls, calls)
+			require.Equal(t, tt.deadline, !deadline.IsZero())
+		})
+	}
+}
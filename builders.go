@@ -0,0 +1,6 @@
+package openapi
+
+// The builders of the spec objects, e.g. NewSchemaBuilder or NewOperationBuilder, are generated
+// from the struct definitions, so every field has a setter and every map or slice has an `Add` method;
+// the methods doing more than setting a field, e.g. OpenAPIBuilder.AddSchemaFor, are written next to the objects.
+//go:generate go run ./internal/buildergen -output builders_gen.go
@@ -0,0 +1,2411 @@
+// Code generated by internal/buildergen; DO NOT EDIT.
+
+package openapi
+
+// CallbackBuilder builds Callback object.
+type CallbackBuilder struct {
+	spec *RefOrSpec[Extendable[Callback]]
+}
+
+// Build returns the built object.
+func (b *CallbackBuilder) Build() *RefOrSpec[Extendable[Callback]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *CallbackBuilder) Extensions(v map[string]any) *CallbackBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *CallbackBuilder) AddExt(name string, value any) *CallbackBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Paths sets Callback.Paths.
+func (b *CallbackBuilder) Paths(v map[string]*RefOrSpec[Extendable[PathItem]]) *CallbackBuilder {
+	b.spec.Spec.Spec.Paths = v
+	return b
+}
+
+// AddPathItem adds the value by the name to Callback.Paths.
+func (b *CallbackBuilder) AddPathItem(name string, value *RefOrSpec[Extendable[PathItem]]) *CallbackBuilder {
+	if b.spec.Spec.Spec.Paths == nil {
+		b.spec.Spec.Spec.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]], 1)
+	}
+	b.spec.Spec.Spec.Paths[name] = value
+	return b
+}
+
+// ComponentsBuilder builds Components object.
+type ComponentsBuilder struct {
+	spec *Extendable[Components]
+}
+
+// NewComponentsBuilder creates ComponentsBuilder with the empty object.
+func NewComponentsBuilder() *ComponentsBuilder {
+	return &ComponentsBuilder{
+		spec: NewExtendable[Components](&Components{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ComponentsBuilder) Build() *Extendable[Components] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ComponentsBuilder) Extensions(v map[string]any) *ComponentsBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ComponentsBuilder) AddExt(name string, value any) *ComponentsBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Schemas sets Components.Schemas.
+func (b *ComponentsBuilder) Schemas(v map[string]*RefOrSpec[Schema]) *ComponentsBuilder {
+	b.spec.Spec.Schemas = v
+	return b
+}
+
+// AddSchema adds the value by the name to Components.Schemas.
+func (b *ComponentsBuilder) AddSchema(name string, value *RefOrSpec[Schema]) *ComponentsBuilder {
+	if b.spec.Spec.Schemas == nil {
+		b.spec.Spec.Schemas = make(map[string]*RefOrSpec[Schema], 1)
+	}
+	b.spec.Spec.Schemas[name] = value
+	return b
+}
+
+// Responses sets Components.Responses.
+func (b *ComponentsBuilder) Responses(v map[string]*RefOrSpec[Extendable[Response]]) *ComponentsBuilder {
+	b.spec.Spec.Responses = v
+	return b
+}
+
+// AddResponse adds the value by the name to Components.Responses.
+func (b *ComponentsBuilder) AddResponse(name string, value *RefOrSpec[Extendable[Response]]) *ComponentsBuilder {
+	if b.spec.Spec.Responses == nil {
+		b.spec.Spec.Responses = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+	}
+	b.spec.Spec.Responses[name] = value
+	return b
+}
+
+// Parameters sets Components.Parameters.
+func (b *ComponentsBuilder) Parameters(v map[string]*RefOrSpec[Extendable[Parameter]]) *ComponentsBuilder {
+	b.spec.Spec.Parameters = v
+	return b
+}
+
+// AddParameter adds the value by the name to Components.Parameters.
+func (b *ComponentsBuilder) AddParameter(name string, value *RefOrSpec[Extendable[Parameter]]) *ComponentsBuilder {
+	if b.spec.Spec.Parameters == nil {
+		b.spec.Spec.Parameters = make(map[string]*RefOrSpec[Extendable[Parameter]], 1)
+	}
+	b.spec.Spec.Parameters[name] = value
+	return b
+}
+
+// Examples sets Components.Examples.
+func (b *ComponentsBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *ComponentsBuilder {
+	b.spec.Spec.Examples = v
+	return b
+}
+
+// AddExample adds the value by the name to Components.Examples.
+func (b *ComponentsBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *ComponentsBuilder {
+	if b.spec.Spec.Examples == nil {
+		b.spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	}
+	b.spec.Spec.Examples[name] = value
+	return b
+}
+
+// RequestBodies sets Components.RequestBodies.
+func (b *ComponentsBuilder) RequestBodies(v map[string]*RefOrSpec[Extendable[RequestBody]]) *ComponentsBuilder {
+	b.spec.Spec.RequestBodies = v
+	return b
+}
+
+// AddRequestBody adds the value by the name to Components.RequestBodies.
+func (b *ComponentsBuilder) AddRequestBody(name string, value *RefOrSpec[Extendable[RequestBody]]) *ComponentsBuilder {
+	if b.spec.Spec.RequestBodies == nil {
+		b.spec.Spec.RequestBodies = make(map[string]*RefOrSpec[Extendable[RequestBody]], 1)
+	}
+	b.spec.Spec.RequestBodies[name] = value
+	return b
+}
+
+// Headers sets Components.Headers.
+func (b *ComponentsBuilder) Headers(v map[string]*RefOrSpec[Extendable[Header]]) *ComponentsBuilder {
+	b.spec.Spec.Headers = v
+	return b
+}
+
+// AddHeader adds the value by the name to Components.Headers.
+func (b *ComponentsBuilder) AddHeader(name string, value *RefOrSpec[Extendable[Header]]) *ComponentsBuilder {
+	if b.spec.Spec.Headers == nil {
+		b.spec.Spec.Headers = make(map[string]*RefOrSpec[Extendable[Header]], 1)
+	}
+	b.spec.Spec.Headers[name] = value
+	return b
+}
+
+// SecuritySchemes sets Components.SecuritySchemes.
+func (b *ComponentsBuilder) SecuritySchemes(v map[string]*RefOrSpec[Extendable[SecurityScheme]]) *ComponentsBuilder {
+	b.spec.Spec.SecuritySchemes = v
+	return b
+}
+
+// AddSecurityScheme adds the value by the name to Components.SecuritySchemes.
+func (b *ComponentsBuilder) AddSecurityScheme(name string, value *RefOrSpec[Extendable[SecurityScheme]]) *ComponentsBuilder {
+	if b.spec.Spec.SecuritySchemes == nil {
+		b.spec.Spec.SecuritySchemes = make(map[string]*RefOrSpec[Extendable[SecurityScheme]], 1)
+	}
+	b.spec.Spec.SecuritySchemes[name] = value
+	return b
+}
+
+// Links sets Components.Links.
+func (b *ComponentsBuilder) Links(v map[string]*RefOrSpec[Extendable[Link]]) *ComponentsBuilder {
+	b.spec.Spec.Links = v
+	return b
+}
+
+// AddLink adds the value by the name to Components.Links.
+func (b *ComponentsBuilder) AddLink(name string, value *RefOrSpec[Extendable[Link]]) *ComponentsBuilder {
+	if b.spec.Spec.Links == nil {
+		b.spec.Spec.Links = make(map[string]*RefOrSpec[Extendable[Link]], 1)
+	}
+	b.spec.Spec.Links[name] = value
+	return b
+}
+
+// Callbacks sets Components.Callbacks.
+func (b *ComponentsBuilder) Callbacks(v map[string]*RefOrSpec[Extendable[Callback]]) *ComponentsBuilder {
+	b.spec.Spec.Callbacks = v
+	return b
+}
+
+// AddCallback adds the value by the name to Components.Callbacks.
+func (b *ComponentsBuilder) AddCallback(name string, value *RefOrSpec[Extendable[Callback]]) *ComponentsBuilder {
+	if b.spec.Spec.Callbacks == nil {
+		b.spec.Spec.Callbacks = make(map[string]*RefOrSpec[Extendable[Callback]], 1)
+	}
+	b.spec.Spec.Callbacks[name] = value
+	return b
+}
+
+// Paths sets Components.Paths.
+func (b *ComponentsBuilder) Paths(v map[string]*RefOrSpec[Extendable[PathItem]]) *ComponentsBuilder {
+	b.spec.Spec.Paths = v
+	return b
+}
+
+// AddPath adds the value by the name to Components.Paths.
+func (b *ComponentsBuilder) AddPath(name string, value *RefOrSpec[Extendable[PathItem]]) *ComponentsBuilder {
+	if b.spec.Spec.Paths == nil {
+		b.spec.Spec.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]], 1)
+	}
+	b.spec.Spec.Paths[name] = value
+	return b
+}
+
+// ContactBuilder builds Contact object.
+type ContactBuilder struct {
+	spec *Extendable[Contact]
+}
+
+// NewContactBuilder creates ContactBuilder with the empty object.
+func NewContactBuilder() *ContactBuilder {
+	return &ContactBuilder{
+		spec: NewExtendable[Contact](&Contact{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ContactBuilder) Build() *Extendable[Contact] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ContactBuilder) Extensions(v map[string]any) *ContactBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ContactBuilder) AddExt(name string, value any) *ContactBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Name sets Contact.Name.
+func (b *ContactBuilder) Name(v string) *ContactBuilder {
+	b.spec.Spec.Name = v
+	return b
+}
+
+// URL sets Contact.URL.
+func (b *ContactBuilder) URL(v string) *ContactBuilder {
+	b.spec.Spec.URL = v
+	return b
+}
+
+// Email sets Contact.Email.
+func (b *ContactBuilder) Email(v string) *ContactBuilder {
+	b.spec.Spec.Email = v
+	return b
+}
+
+// DiscriminatorBuilder builds Discriminator object.
+type DiscriminatorBuilder struct {
+	spec *Discriminator
+}
+
+// NewDiscriminatorBuilder creates DiscriminatorBuilder with the empty object.
+func NewDiscriminatorBuilder() *DiscriminatorBuilder {
+	return &DiscriminatorBuilder{
+		spec: &Discriminator{},
+	}
+}
+
+// Build returns the built object.
+func (b *DiscriminatorBuilder) Build() *Discriminator {
+	return b.spec
+}
+
+// Mapping sets Discriminator.Mapping.
+func (b *DiscriminatorBuilder) Mapping(v map[string]string) *DiscriminatorBuilder {
+	b.spec.Mapping = v
+	return b
+}
+
+// AddMapping adds the value by the name to Discriminator.Mapping.
+func (b *DiscriminatorBuilder) AddMapping(name string, value string) *DiscriminatorBuilder {
+	if b.spec.Mapping == nil {
+		b.spec.Mapping = make(map[string]string, 1)
+	}
+	b.spec.Mapping[name] = value
+	return b
+}
+
+// PropertyName sets Discriminator.PropertyName.
+func (b *DiscriminatorBuilder) PropertyName(v string) *DiscriminatorBuilder {
+	b.spec.PropertyName = v
+	return b
+}
+
+// EncodingBuilder builds Encoding object.
+type EncodingBuilder struct {
+	spec *Extendable[Encoding]
+}
+
+// NewEncodingBuilder creates EncodingBuilder with the empty object.
+func NewEncodingBuilder() *EncodingBuilder {
+	return &EncodingBuilder{
+		spec: NewExtendable[Encoding](&Encoding{}),
+	}
+}
+
+// Build returns the built object.
+func (b *EncodingBuilder) Build() *Extendable[Encoding] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *EncodingBuilder) Extensions(v map[string]any) *EncodingBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *EncodingBuilder) AddExt(name string, value any) *EncodingBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// ContentType sets Encoding.ContentType.
+func (b *EncodingBuilder) ContentType(v string) *EncodingBuilder {
+	b.spec.Spec.ContentType = v
+	return b
+}
+
+// Headers sets Encoding.Headers.
+func (b *EncodingBuilder) Headers(v map[string]*RefOrSpec[Extendable[Header]]) *EncodingBuilder {
+	b.spec.Spec.Headers = v
+	return b
+}
+
+// AddHeader adds the value by the name to Encoding.Headers.
+func (b *EncodingBuilder) AddHeader(name string, value *RefOrSpec[Extendable[Header]]) *EncodingBuilder {
+	if b.spec.Spec.Headers == nil {
+		b.spec.Spec.Headers = make(map[string]*RefOrSpec[Extendable[Header]], 1)
+	}
+	b.spec.Spec.Headers[name] = value
+	return b
+}
+
+// Style sets Encoding.Style.
+func (b *EncodingBuilder) Style(v string) *EncodingBuilder {
+	b.spec.Spec.Style = v
+	return b
+}
+
+// Explode sets Encoding.Explode.
+func (b *EncodingBuilder) Explode(v bool) *EncodingBuilder {
+	b.spec.Spec.Explode = &v
+	return b
+}
+
+// AllowReserved sets Encoding.AllowReserved.
+func (b *EncodingBuilder) AllowReserved(v bool) *EncodingBuilder {
+	b.spec.Spec.AllowReserved = v
+	return b
+}
+
+// ExampleBuilder builds Example object.
+type ExampleBuilder struct {
+	spec *RefOrSpec[Extendable[Example]]
+}
+
+// NewExampleBuilder creates ExampleBuilder with the empty object.
+func NewExampleBuilder() *ExampleBuilder {
+	return &ExampleBuilder{
+		spec: NewRefOrExtSpec[Example](&Example{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ExampleBuilder) Build() *RefOrSpec[Extendable[Example]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ExampleBuilder) Extensions(v map[string]any) *ExampleBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ExampleBuilder) AddExt(name string, value any) *ExampleBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Summary sets Example.Summary.
+func (b *ExampleBuilder) Summary(v string) *ExampleBuilder {
+	b.spec.Spec.Spec.Summary = v
+	return b
+}
+
+// Description sets Example.Description.
+func (b *ExampleBuilder) Description(v string) *ExampleBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Value sets Example.Value.
+func (b *ExampleBuilder) Value(v any) *ExampleBuilder {
+	b.spec.Spec.Spec.Value = v
+	return b
+}
+
+// ExternalValue sets Example.ExternalValue.
+func (b *ExampleBuilder) ExternalValue(v string) *ExampleBuilder {
+	b.spec.Spec.Spec.ExternalValue = v
+	return b
+}
+
+// ExternalDocsBuilder builds ExternalDocs object.
+type ExternalDocsBuilder struct {
+	spec *Extendable[ExternalDocs]
+}
+
+// NewExternalDocsBuilder creates ExternalDocsBuilder with the empty object.
+func NewExternalDocsBuilder() *ExternalDocsBuilder {
+	return &ExternalDocsBuilder{
+		spec: NewExtendable[ExternalDocs](&ExternalDocs{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ExternalDocsBuilder) Build() *Extendable[ExternalDocs] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ExternalDocsBuilder) Extensions(v map[string]any) *ExternalDocsBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ExternalDocsBuilder) AddExt(name string, value any) *ExternalDocsBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Description sets ExternalDocs.Description.
+func (b *ExternalDocsBuilder) Description(v string) *ExternalDocsBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// URL sets ExternalDocs.URL.
+func (b *ExternalDocsBuilder) URL(v string) *ExternalDocsBuilder {
+	b.spec.Spec.URL = v
+	return b
+}
+
+// HeaderBuilder builds Header object.
+type HeaderBuilder struct {
+	spec *RefOrSpec[Extendable[Header]]
+}
+
+// NewHeaderBuilder creates HeaderBuilder with the empty object.
+func NewHeaderBuilder() *HeaderBuilder {
+	return &HeaderBuilder{
+		spec: NewRefOrExtSpec[Header](&Header{}),
+	}
+}
+
+// Build returns the built object.
+func (b *HeaderBuilder) Build() *RefOrSpec[Extendable[Header]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *HeaderBuilder) Extensions(v map[string]any) *HeaderBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *HeaderBuilder) AddExt(name string, value any) *HeaderBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Schema sets Header.Schema.
+func (b *HeaderBuilder) Schema(v *RefOrSpec[Schema]) *HeaderBuilder {
+	b.spec.Spec.Spec.Schema = v
+	return b
+}
+
+// Content sets Header.Content.
+func (b *HeaderBuilder) Content(v map[string]*Extendable[MediaType]) *HeaderBuilder {
+	b.spec.Spec.Spec.Content = v
+	return b
+}
+
+// AddContent adds the value by the name to Header.Content.
+func (b *HeaderBuilder) AddContent(name string, value *Extendable[MediaType]) *HeaderBuilder {
+	if b.spec.Spec.Spec.Content == nil {
+		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
+	}
+	b.spec.Spec.Spec.Content[name] = value
+	return b
+}
+
+// Description sets Header.Description.
+func (b *HeaderBuilder) Description(v string) *HeaderBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Style sets Header.Style.
+func (b *HeaderBuilder) Style(v string) *HeaderBuilder {
+	b.spec.Spec.Spec.Style = v
+	return b
+}
+
+// Explode sets Header.Explode.
+func (b *HeaderBuilder) Explode(v bool) *HeaderBuilder {
+	b.spec.Spec.Spec.Explode = &v
+	return b
+}
+
+// Required sets Header.Required.
+func (b *HeaderBuilder) Required(v bool) *HeaderBuilder {
+	b.spec.Spec.Spec.Required = v
+	return b
+}
+
+// Deprecated sets Header.Deprecated.
+func (b *HeaderBuilder) Deprecated(v bool) *HeaderBuilder {
+	b.spec.Spec.Spec.Deprecated = v
+	return b
+}
+
+// Example sets Header.Example.
+func (b *HeaderBuilder) Example(v any) *HeaderBuilder {
+	b.spec.Spec.Spec.Example = v
+	return b
+}
+
+// Examples sets Header.Examples.
+func (b *HeaderBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *HeaderBuilder {
+	b.spec.Spec.Spec.Examples = v
+	return b
+}
+
+// AddExample adds the value by the name to Header.Examples.
+func (b *HeaderBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *HeaderBuilder {
+	if b.spec.Spec.Spec.Examples == nil {
+		b.spec.Spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	}
+	b.spec.Spec.Spec.Examples[name] = value
+	return b
+}
+
+// InfoBuilder builds Info object.
+type InfoBuilder struct {
+	spec *Extendable[Info]
+}
+
+// NewInfoBuilder creates InfoBuilder with the empty object.
+func NewInfoBuilder() *InfoBuilder {
+	return &InfoBuilder{
+		spec: NewExtendable[Info](&Info{}),
+	}
+}
+
+// Build returns the built object.
+func (b *InfoBuilder) Build() *Extendable[Info] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *InfoBuilder) Extensions(v map[string]any) *InfoBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *InfoBuilder) AddExt(name string, value any) *InfoBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Title sets Info.Title.
+func (b *InfoBuilder) Title(v string) *InfoBuilder {
+	b.spec.Spec.Title = v
+	return b
+}
+
+// Summary sets Info.Summary.
+func (b *InfoBuilder) Summary(v string) *InfoBuilder {
+	b.spec.Spec.Summary = v
+	return b
+}
+
+// Description sets Info.Description.
+func (b *InfoBuilder) Description(v string) *InfoBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// TermsOfService sets Info.TermsOfService.
+func (b *InfoBuilder) TermsOfService(v string) *InfoBuilder {
+	b.spec.Spec.TermsOfService = v
+	return b
+}
+
+// Contact sets Info.Contact.
+func (b *InfoBuilder) Contact(v *Extendable[Contact]) *InfoBuilder {
+	b.spec.Spec.Contact = v
+	return b
+}
+
+// License sets Info.License.
+func (b *InfoBuilder) License(v *Extendable[License]) *InfoBuilder {
+	b.spec.Spec.License = v
+	return b
+}
+
+// Version sets Info.Version.
+func (b *InfoBuilder) Version(v string) *InfoBuilder {
+	b.spec.Spec.Version = v
+	return b
+}
+
+// LicenseBuilder builds License object.
+type LicenseBuilder struct {
+	spec *Extendable[License]
+}
+
+// NewLicenseBuilder creates LicenseBuilder with the empty object.
+func NewLicenseBuilder() *LicenseBuilder {
+	return &LicenseBuilder{
+		spec: NewExtendable[License](&License{}),
+	}
+}
+
+// Build returns the built object.
+func (b *LicenseBuilder) Build() *Extendable[License] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *LicenseBuilder) Extensions(v map[string]any) *LicenseBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *LicenseBuilder) AddExt(name string, value any) *LicenseBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Name sets License.Name.
+func (b *LicenseBuilder) Name(v string) *LicenseBuilder {
+	b.spec.Spec.Name = v
+	return b
+}
+
+// Identifier sets License.Identifier.
+func (b *LicenseBuilder) Identifier(v string) *LicenseBuilder {
+	b.spec.Spec.Identifier = v
+	return b
+}
+
+// URL sets License.URL.
+func (b *LicenseBuilder) URL(v string) *LicenseBuilder {
+	b.spec.Spec.URL = v
+	return b
+}
+
+// LinkBuilder builds Link object.
+type LinkBuilder struct {
+	spec *RefOrSpec[Extendable[Link]]
+}
+
+// NewLinkBuilder creates LinkBuilder with the empty object.
+func NewLinkBuilder() *LinkBuilder {
+	return &LinkBuilder{
+		spec: NewRefOrExtSpec[Link](&Link{}),
+	}
+}
+
+// Build returns the built object.
+func (b *LinkBuilder) Build() *RefOrSpec[Extendable[Link]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *LinkBuilder) Extensions(v map[string]any) *LinkBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *LinkBuilder) AddExt(name string, value any) *LinkBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// RequestBody sets Link.RequestBody.
+func (b *LinkBuilder) RequestBody(v any) *LinkBuilder {
+	b.spec.Spec.Spec.RequestBody = v
+	return b
+}
+
+// Parameters sets Link.Parameters.
+func (b *LinkBuilder) Parameters(v map[string]any) *LinkBuilder {
+	b.spec.Spec.Spec.Parameters = v
+	return b
+}
+
+// AddParameter adds the value by the name to Link.Parameters.
+func (b *LinkBuilder) AddParameter(name string, value any) *LinkBuilder {
+	if b.spec.Spec.Spec.Parameters == nil {
+		b.spec.Spec.Spec.Parameters = make(map[string]any, 1)
+	}
+	b.spec.Spec.Spec.Parameters[name] = value
+	return b
+}
+
+// Server sets Link.Server.
+func (b *LinkBuilder) Server(v *Extendable[Server]) *LinkBuilder {
+	b.spec.Spec.Spec.Server = v
+	return b
+}
+
+// OperationRef sets Link.OperationRef.
+func (b *LinkBuilder) OperationRef(v string) *LinkBuilder {
+	b.spec.Spec.Spec.OperationRef = v
+	return b
+}
+
+// OperationID sets Link.OperationID.
+func (b *LinkBuilder) OperationID(v string) *LinkBuilder {
+	b.spec.Spec.Spec.OperationID = v
+	return b
+}
+
+// Description sets Link.Description.
+func (b *LinkBuilder) Description(v string) *LinkBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// MediaTypeBuilder builds MediaType object.
+type MediaTypeBuilder struct {
+	spec *Extendable[MediaType]
+}
+
+// NewMediaTypeBuilder creates MediaTypeBuilder with the empty object.
+func NewMediaTypeBuilder() *MediaTypeBuilder {
+	return &MediaTypeBuilder{
+		spec: NewExtendable[MediaType](&MediaType{}),
+	}
+}
+
+// Build returns the built object.
+func (b *MediaTypeBuilder) Build() *Extendable[MediaType] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *MediaTypeBuilder) Extensions(v map[string]any) *MediaTypeBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *MediaTypeBuilder) AddExt(name string, value any) *MediaTypeBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Schema sets MediaType.Schema.
+func (b *MediaTypeBuilder) Schema(v *RefOrSpec[Schema]) *MediaTypeBuilder {
+	b.spec.Spec.Schema = v
+	return b
+}
+
+// Example sets MediaType.Example.
+func (b *MediaTypeBuilder) Example(v any) *MediaTypeBuilder {
+	b.spec.Spec.Example = v
+	return b
+}
+
+// Examples sets MediaType.Examples.
+func (b *MediaTypeBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *MediaTypeBuilder {
+	b.spec.Spec.Examples = v
+	return b
+}
+
+// AddExample adds the value by the name to MediaType.Examples.
+func (b *MediaTypeBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *MediaTypeBuilder {
+	if b.spec.Spec.Examples == nil {
+		b.spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	}
+	b.spec.Spec.Examples[name] = value
+	return b
+}
+
+// Encoding sets MediaType.Encoding.
+func (b *MediaTypeBuilder) Encoding(v map[string]*Extendable[Encoding]) *MediaTypeBuilder {
+	b.spec.Spec.Encoding = v
+	return b
+}
+
+// AddEncoding adds the value by the name to MediaType.Encoding.
+func (b *MediaTypeBuilder) AddEncoding(name string, value *Extendable[Encoding]) *MediaTypeBuilder {
+	if b.spec.Spec.Encoding == nil {
+		b.spec.Spec.Encoding = make(map[string]*Extendable[Encoding], 1)
+	}
+	b.spec.Spec.Encoding[name] = value
+	return b
+}
+
+// OAuthFlowBuilder builds OAuthFlow object.
+type OAuthFlowBuilder struct {
+	spec *Extendable[OAuthFlow]
+}
+
+// NewOAuthFlowBuilder creates OAuthFlowBuilder with the empty object.
+func NewOAuthFlowBuilder() *OAuthFlowBuilder {
+	return &OAuthFlowBuilder{
+		spec: NewExtendable[OAuthFlow](&OAuthFlow{}),
+	}
+}
+
+// Build returns the built object.
+func (b *OAuthFlowBuilder) Build() *Extendable[OAuthFlow] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *OAuthFlowBuilder) Extensions(v map[string]any) *OAuthFlowBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *OAuthFlowBuilder) AddExt(name string, value any) *OAuthFlowBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Scopes sets OAuthFlow.Scopes.
+func (b *OAuthFlowBuilder) Scopes(v map[string]string) *OAuthFlowBuilder {
+	b.spec.Spec.Scopes = v
+	return b
+}
+
+// AddScope adds the value by the name to OAuthFlow.Scopes.
+func (b *OAuthFlowBuilder) AddScope(name string, value string) *OAuthFlowBuilder {
+	if b.spec.Spec.Scopes == nil {
+		b.spec.Spec.Scopes = make(map[string]string, 1)
+	}
+	b.spec.Spec.Scopes[name] = value
+	return b
+}
+
+// AuthorizationURL sets OAuthFlow.AuthorizationURL.
+func (b *OAuthFlowBuilder) AuthorizationURL(v string) *OAuthFlowBuilder {
+	b.spec.Spec.AuthorizationURL = v
+	return b
+}
+
+// TokenURL sets OAuthFlow.TokenURL.
+func (b *OAuthFlowBuilder) TokenURL(v string) *OAuthFlowBuilder {
+	b.spec.Spec.TokenURL = v
+	return b
+}
+
+// RefreshURL sets OAuthFlow.RefreshURL.
+func (b *OAuthFlowBuilder) RefreshURL(v string) *OAuthFlowBuilder {
+	b.spec.Spec.RefreshURL = v
+	return b
+}
+
+// OAuthFlowsBuilder builds OAuthFlows object.
+type OAuthFlowsBuilder struct {
+	spec *Extendable[OAuthFlows]
+}
+
+// NewOAuthFlowsBuilder creates OAuthFlowsBuilder with the empty object.
+func NewOAuthFlowsBuilder() *OAuthFlowsBuilder {
+	return &OAuthFlowsBuilder{
+		spec: NewExtendable[OAuthFlows](&OAuthFlows{}),
+	}
+}
+
+// Build returns the built object.
+func (b *OAuthFlowsBuilder) Build() *Extendable[OAuthFlows] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *OAuthFlowsBuilder) Extensions(v map[string]any) *OAuthFlowsBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *OAuthFlowsBuilder) AddExt(name string, value any) *OAuthFlowsBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Implicit sets OAuthFlows.Implicit.
+func (b *OAuthFlowsBuilder) Implicit(v *Extendable[OAuthFlow]) *OAuthFlowsBuilder {
+	b.spec.Spec.Implicit = v
+	return b
+}
+
+// Password sets OAuthFlows.Password.
+func (b *OAuthFlowsBuilder) Password(v *Extendable[OAuthFlow]) *OAuthFlowsBuilder {
+	b.spec.Spec.Password = v
+	return b
+}
+
+// ClientCredentials sets OAuthFlows.ClientCredentials.
+func (b *OAuthFlowsBuilder) ClientCredentials(v *Extendable[OAuthFlow]) *OAuthFlowsBuilder {
+	b.spec.Spec.ClientCredentials = v
+	return b
+}
+
+// AuthorizationCode sets OAuthFlows.AuthorizationCode.
+func (b *OAuthFlowsBuilder) AuthorizationCode(v *Extendable[OAuthFlow]) *OAuthFlowsBuilder {
+	b.spec.Spec.AuthorizationCode = v
+	return b
+}
+
+// OpenAPIBuilder builds OpenAPI object.
+type OpenAPIBuilder struct {
+	spec *Extendable[OpenAPI]
+}
+
+// Build returns the built object.
+func (b *OpenAPIBuilder) Build() *Extendable[OpenAPI] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *OpenAPIBuilder) Extensions(v map[string]any) *OpenAPIBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *OpenAPIBuilder) AddExt(name string, value any) *OpenAPIBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Components sets OpenAPI.Components.
+func (b *OpenAPIBuilder) Components(v *Extendable[Components]) *OpenAPIBuilder {
+	b.spec.Spec.Components = v
+	return b
+}
+
+// Info sets OpenAPI.Info.
+func (b *OpenAPIBuilder) Info(v *Extendable[Info]) *OpenAPIBuilder {
+	b.spec.Spec.Info = v
+	return b
+}
+
+// ExternalDocs sets OpenAPI.ExternalDocs.
+func (b *OpenAPIBuilder) ExternalDocs(v *Extendable[ExternalDocs]) *OpenAPIBuilder {
+	b.spec.Spec.ExternalDocs = v
+	return b
+}
+
+// Paths sets OpenAPI.Paths.
+func (b *OpenAPIBuilder) Paths(v *Extendable[Paths]) *OpenAPIBuilder {
+	b.spec.Spec.Paths = v
+	return b
+}
+
+// WebHooks sets OpenAPI.WebHooks.
+func (b *OpenAPIBuilder) WebHooks(v Webhooks) *OpenAPIBuilder {
+	b.spec.Spec.WebHooks = v
+	return b
+}
+
+// JsonSchemaDialect sets OpenAPI.JsonSchemaDialect.
+func (b *OpenAPIBuilder) JsonSchemaDialect(v string) *OpenAPIBuilder {
+	b.spec.Spec.JsonSchemaDialect = v
+	return b
+}
+
+// OpenAPI sets OpenAPI.OpenAPI.
+func (b *OpenAPIBuilder) OpenAPI(v string) *OpenAPIBuilder {
+	b.spec.Spec.OpenAPI = v
+	return b
+}
+
+// Security sets OpenAPI.Security.
+func (b *OpenAPIBuilder) Security(v ...SecurityRequirement) *OpenAPIBuilder {
+	b.spec.Spec.Security = v
+	return b
+}
+
+// AddSecurity appends the values to OpenAPI.Security.
+func (b *OpenAPIBuilder) AddSecurity(v ...SecurityRequirement) *OpenAPIBuilder {
+	b.spec.Spec.Security = append(b.spec.Spec.Security, v...)
+	return b
+}
+
+// Tags sets OpenAPI.Tags.
+func (b *OpenAPIBuilder) Tags(v ...*Extendable[Tag]) *OpenAPIBuilder {
+	b.spec.Spec.Tags = v
+	return b
+}
+
+// AddTags appends the values to OpenAPI.Tags.
+func (b *OpenAPIBuilder) AddTags(v ...*Extendable[Tag]) *OpenAPIBuilder {
+	b.spec.Spec.Tags = append(b.spec.Spec.Tags, v...)
+	return b
+}
+
+// Servers sets OpenAPI.Servers.
+func (b *OpenAPIBuilder) Servers(v ...*Extendable[Server]) *OpenAPIBuilder {
+	b.spec.Spec.Servers = v
+	return b
+}
+
+// AddServers appends the values to OpenAPI.Servers.
+func (b *OpenAPIBuilder) AddServers(v ...*Extendable[Server]) *OpenAPIBuilder {
+	b.spec.Spec.Servers = append(b.spec.Spec.Servers, v...)
+	return b
+}
+
+// OperationBuilder builds Operation object.
+type OperationBuilder struct {
+	spec *Extendable[Operation]
+}
+
+// NewOperationBuilder creates OperationBuilder with the empty object.
+func NewOperationBuilder() *OperationBuilder {
+	return &OperationBuilder{
+		spec: NewExtendable[Operation](&Operation{}),
+	}
+}
+
+// Build returns the built object.
+func (b *OperationBuilder) Build() *Extendable[Operation] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *OperationBuilder) Extensions(v map[string]any) *OperationBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *OperationBuilder) AddExt(name string, value any) *OperationBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// RequestBody sets Operation.RequestBody.
+func (b *OperationBuilder) RequestBody(v *RefOrSpec[Extendable[RequestBody]]) *OperationBuilder {
+	b.spec.Spec.RequestBody = v
+	return b
+}
+
+// Responses sets Operation.Responses.
+func (b *OperationBuilder) Responses(v *Extendable[Responses]) *OperationBuilder {
+	b.spec.Spec.Responses = v
+	return b
+}
+
+// Callbacks sets Operation.Callbacks.
+func (b *OperationBuilder) Callbacks(v map[string]*RefOrSpec[Extendable[Callback]]) *OperationBuilder {
+	b.spec.Spec.Callbacks = v
+	return b
+}
+
+// AddCallback adds the value by the name to Operation.Callbacks.
+func (b *OperationBuilder) AddCallback(name string, value *RefOrSpec[Extendable[Callback]]) *OperationBuilder {
+	if b.spec.Spec.Callbacks == nil {
+		b.spec.Spec.Callbacks = make(map[string]*RefOrSpec[Extendable[Callback]], 1)
+	}
+	b.spec.Spec.Callbacks[name] = value
+	return b
+}
+
+// ExternalDocs sets Operation.ExternalDocs.
+func (b *OperationBuilder) ExternalDocs(v *Extendable[ExternalDocs]) *OperationBuilder {
+	b.spec.Spec.ExternalDocs = v
+	return b
+}
+
+// OperationID sets Operation.OperationID.
+func (b *OperationBuilder) OperationID(v string) *OperationBuilder {
+	b.spec.Spec.OperationID = v
+	return b
+}
+
+// Summary sets Operation.Summary.
+func (b *OperationBuilder) Summary(v string) *OperationBuilder {
+	b.spec.Spec.Summary = v
+	return b
+}
+
+// Description sets Operation.Description.
+func (b *OperationBuilder) Description(v string) *OperationBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// Parameters sets Operation.Parameters.
+func (b *OperationBuilder) Parameters(v ...*RefOrSpec[Extendable[Parameter]]) *OperationBuilder {
+	b.spec.Spec.Parameters = v
+	return b
+}
+
+// AddParameters appends the values to Operation.Parameters.
+func (b *OperationBuilder) AddParameters(v ...*RefOrSpec[Extendable[Parameter]]) *OperationBuilder {
+	b.spec.Spec.Parameters = append(b.spec.Spec.Parameters, v...)
+	return b
+}
+
+// Tags sets Operation.Tags.
+func (b *OperationBuilder) Tags(v ...string) *OperationBuilder {
+	b.spec.Spec.Tags = v
+	return b
+}
+
+// AddTags appends the values to Operation.Tags.
+func (b *OperationBuilder) AddTags(v ...string) *OperationBuilder {
+	b.spec.Spec.Tags = append(b.spec.Spec.Tags, v...)
+	return b
+}
+
+// Security sets Operation.Security.
+func (b *OperationBuilder) Security(v ...SecurityRequirement) *OperationBuilder {
+	b.spec.Spec.Security = v
+	return b
+}
+
+// AddSecurity appends the values to Operation.Security.
+func (b *OperationBuilder) AddSecurity(v ...SecurityRequirement) *OperationBuilder {
+	b.spec.Spec.Security = append(b.spec.Spec.Security, v...)
+	return b
+}
+
+// Servers sets Operation.Servers.
+func (b *OperationBuilder) Servers(v ...*Extendable[Server]) *OperationBuilder {
+	b.spec.Spec.Servers = v
+	return b
+}
+
+// AddServers appends the values to Operation.Servers.
+func (b *OperationBuilder) AddServers(v ...*Extendable[Server]) *OperationBuilder {
+	b.spec.Spec.Servers = append(b.spec.Spec.Servers, v...)
+	return b
+}
+
+// Deprecated sets Operation.Deprecated.
+func (b *OperationBuilder) Deprecated(v bool) *OperationBuilder {
+	b.spec.Spec.Deprecated = v
+	return b
+}
+
+// ParameterBuilder builds Parameter object.
+type ParameterBuilder struct {
+	spec *RefOrSpec[Extendable[Parameter]]
+}
+
+// NewParameterBuilder creates ParameterBuilder with the empty object.
+func NewParameterBuilder() *ParameterBuilder {
+	return &ParameterBuilder{
+		spec: NewRefOrExtSpec[Parameter](&Parameter{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ParameterBuilder) Build() *RefOrSpec[Extendable[Parameter]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ParameterBuilder) Extensions(v map[string]any) *ParameterBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ParameterBuilder) AddExt(name string, value any) *ParameterBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Example sets Parameter.Example.
+func (b *ParameterBuilder) Example(v any) *ParameterBuilder {
+	b.spec.Spec.Spec.Example = v
+	return b
+}
+
+// Content sets Parameter.Content.
+func (b *ParameterBuilder) Content(v map[string]*Extendable[MediaType]) *ParameterBuilder {
+	b.spec.Spec.Spec.Content = v
+	return b
+}
+
+// AddContent adds the value by the name to Parameter.Content.
+func (b *ParameterBuilder) AddContent(name string, value *Extendable[MediaType]) *ParameterBuilder {
+	if b.spec.Spec.Spec.Content == nil {
+		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
+	}
+	b.spec.Spec.Spec.Content[name] = value
+	return b
+}
+
+// Examples sets Parameter.Examples.
+func (b *ParameterBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *ParameterBuilder {
+	b.spec.Spec.Spec.Examples = v
+	return b
+}
+
+// AddExample adds the value by the name to Parameter.Examples.
+func (b *ParameterBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *ParameterBuilder {
+	if b.spec.Spec.Spec.Examples == nil {
+		b.spec.Spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	}
+	b.spec.Spec.Spec.Examples[name] = value
+	return b
+}
+
+// Schema sets Parameter.Schema.
+func (b *ParameterBuilder) Schema(v *RefOrSpec[Schema]) *ParameterBuilder {
+	b.spec.Spec.Spec.Schema = v
+	return b
+}
+
+// In sets Parameter.In.
+func (b *ParameterBuilder) In(v string) *ParameterBuilder {
+	b.spec.Spec.Spec.In = v
+	return b
+}
+
+// Description sets Parameter.Description.
+func (b *ParameterBuilder) Description(v string) *ParameterBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Style sets Parameter.Style.
+func (b *ParameterBuilder) Style(v string) *ParameterBuilder {
+	b.spec.Spec.Spec.Style = v
+	return b
+}
+
+// Name sets Parameter.Name.
+func (b *ParameterBuilder) Name(v string) *ParameterBuilder {
+	b.spec.Spec.Spec.Name = v
+	return b
+}
+
+// Explode sets Parameter.Explode.
+func (b *ParameterBuilder) Explode(v bool) *ParameterBuilder {
+	b.spec.Spec.Spec.Explode = &v
+	return b
+}
+
+// AllowReserved sets Parameter.AllowReserved.
+func (b *ParameterBuilder) AllowReserved(v bool) *ParameterBuilder {
+	b.spec.Spec.Spec.AllowReserved = v
+	return b
+}
+
+// AllowEmptyValue sets Parameter.AllowEmptyValue.
+func (b *ParameterBuilder) AllowEmptyValue(v bool) *ParameterBuilder {
+	b.spec.Spec.Spec.AllowEmptyValue = v
+	return b
+}
+
+// Deprecated sets Parameter.Deprecated.
+func (b *ParameterBuilder) Deprecated(v bool) *ParameterBuilder {
+	b.spec.Spec.Spec.Deprecated = v
+	return b
+}
+
+// Required sets Parameter.Required.
+func (b *ParameterBuilder) Required(v bool) *ParameterBuilder {
+	b.spec.Spec.Spec.Required = v
+	return b
+}
+
+// PathItemBuilder builds PathItem object.
+type PathItemBuilder struct {
+	spec *RefOrSpec[Extendable[PathItem]]
+}
+
+// NewPathItemBuilder creates PathItemBuilder with the empty object.
+func NewPathItemBuilder() *PathItemBuilder {
+	return &PathItemBuilder{
+		spec: NewRefOrExtSpec[PathItem](&PathItem{}),
+	}
+}
+
+// Build returns the built object.
+func (b *PathItemBuilder) Build() *RefOrSpec[Extendable[PathItem]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *PathItemBuilder) Extensions(v map[string]any) *PathItemBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *PathItemBuilder) AddExt(name string, value any) *PathItemBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Summary sets PathItem.Summary.
+func (b *PathItemBuilder) Summary(v string) *PathItemBuilder {
+	b.spec.Spec.Spec.Summary = v
+	return b
+}
+
+// Description sets PathItem.Description.
+func (b *PathItemBuilder) Description(v string) *PathItemBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Get sets PathItem.Get.
+func (b *PathItemBuilder) Get(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Get = v
+	return b
+}
+
+// Put sets PathItem.Put.
+func (b *PathItemBuilder) Put(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Put = v
+	return b
+}
+
+// Post sets PathItem.Post.
+func (b *PathItemBuilder) Post(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Post = v
+	return b
+}
+
+// Delete sets PathItem.Delete.
+func (b *PathItemBuilder) Delete(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Delete = v
+	return b
+}
+
+// Options sets PathItem.Options.
+func (b *PathItemBuilder) Options(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Options = v
+	return b
+}
+
+// Head sets PathItem.Head.
+func (b *PathItemBuilder) Head(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Head = v
+	return b
+}
+
+// Patch sets PathItem.Patch.
+func (b *PathItemBuilder) Patch(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Patch = v
+	return b
+}
+
+// Trace sets PathItem.Trace.
+func (b *PathItemBuilder) Trace(v *Extendable[Operation]) *PathItemBuilder {
+	b.spec.Spec.Spec.Trace = v
+	return b
+}
+
+// Servers sets PathItem.Servers.
+func (b *PathItemBuilder) Servers(v ...*Extendable[Server]) *PathItemBuilder {
+	b.spec.Spec.Spec.Servers = v
+	return b
+}
+
+// AddServers appends the values to PathItem.Servers.
+func (b *PathItemBuilder) AddServers(v ...*Extendable[Server]) *PathItemBuilder {
+	b.spec.Spec.Spec.Servers = append(b.spec.Spec.Spec.Servers, v...)
+	return b
+}
+
+// Parameters sets PathItem.Parameters.
+func (b *PathItemBuilder) Parameters(v ...*RefOrSpec[Extendable[Parameter]]) *PathItemBuilder {
+	b.spec.Spec.Spec.Parameters = v
+	return b
+}
+
+// AddParameters appends the values to PathItem.Parameters.
+func (b *PathItemBuilder) AddParameters(v ...*RefOrSpec[Extendable[Parameter]]) *PathItemBuilder {
+	b.spec.Spec.Spec.Parameters = append(b.spec.Spec.Spec.Parameters, v...)
+	return b
+}
+
+// PathsBuilder builds Paths object.
+type PathsBuilder struct {
+	spec *Extendable[Paths]
+}
+
+// NewPathsBuilder creates PathsBuilder with the empty object.
+func NewPathsBuilder() *PathsBuilder {
+	return &PathsBuilder{
+		spec: NewExtendable[Paths](&Paths{}),
+	}
+}
+
+// Build returns the built object.
+func (b *PathsBuilder) Build() *Extendable[Paths] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *PathsBuilder) Extensions(v map[string]any) *PathsBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *PathsBuilder) AddExt(name string, value any) *PathsBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Paths sets Paths.Paths.
+func (b *PathsBuilder) Paths(v map[string]*RefOrSpec[Extendable[PathItem]]) *PathsBuilder {
+	b.spec.Spec.Paths = v
+	return b
+}
+
+// AddPath adds the value by the name to Paths.Paths.
+func (b *PathsBuilder) AddPath(name string, value *RefOrSpec[Extendable[PathItem]]) *PathsBuilder {
+	if b.spec.Spec.Paths == nil {
+		b.spec.Spec.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]], 1)
+	}
+	b.spec.Spec.Paths[name] = value
+	return b
+}
+
+// RequestBodyBuilder builds RequestBody object.
+type RequestBodyBuilder struct {
+	spec *RefOrSpec[Extendable[RequestBody]]
+}
+
+// NewRequestBodyBuilder creates RequestBodyBuilder with the empty object.
+func NewRequestBodyBuilder() *RequestBodyBuilder {
+	return &RequestBodyBuilder{
+		spec: NewRefOrExtSpec[RequestBody](&RequestBody{}),
+	}
+}
+
+// Build returns the built object.
+func (b *RequestBodyBuilder) Build() *RefOrSpec[Extendable[RequestBody]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *RequestBodyBuilder) Extensions(v map[string]any) *RequestBodyBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *RequestBodyBuilder) AddExt(name string, value any) *RequestBodyBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Content sets RequestBody.Content.
+func (b *RequestBodyBuilder) Content(v map[string]*Extendable[MediaType]) *RequestBodyBuilder {
+	b.spec.Spec.Spec.Content = v
+	return b
+}
+
+// AddContent adds the value by the name to RequestBody.Content.
+func (b *RequestBodyBuilder) AddContent(name string, value *Extendable[MediaType]) *RequestBodyBuilder {
+	if b.spec.Spec.Spec.Content == nil {
+		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
+	}
+	b.spec.Spec.Spec.Content[name] = value
+	return b
+}
+
+// Description sets RequestBody.Description.
+func (b *RequestBodyBuilder) Description(v string) *RequestBodyBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Required sets RequestBody.Required.
+func (b *RequestBodyBuilder) Required(v bool) *RequestBodyBuilder {
+	b.spec.Spec.Spec.Required = v
+	return b
+}
+
+// ResponseBuilder builds Response object.
+type ResponseBuilder struct {
+	spec *RefOrSpec[Extendable[Response]]
+}
+
+// NewResponseBuilder creates ResponseBuilder with the empty object.
+func NewResponseBuilder() *ResponseBuilder {
+	return &ResponseBuilder{
+		spec: NewRefOrExtSpec[Response](&Response{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ResponseBuilder) Build() *RefOrSpec[Extendable[Response]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ResponseBuilder) Extensions(v map[string]any) *ResponseBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ResponseBuilder) AddExt(name string, value any) *ResponseBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Headers sets Response.Headers.
+func (b *ResponseBuilder) Headers(v map[string]*RefOrSpec[Extendable[Header]]) *ResponseBuilder {
+	b.spec.Spec.Spec.Headers = v
+	return b
+}
+
+// AddHeader adds the value by the name to Response.Headers.
+func (b *ResponseBuilder) AddHeader(name string, value *RefOrSpec[Extendable[Header]]) *ResponseBuilder {
+	if b.spec.Spec.Spec.Headers == nil {
+		b.spec.Spec.Spec.Headers = make(map[string]*RefOrSpec[Extendable[Header]], 1)
+	}
+	b.spec.Spec.Spec.Headers[name] = value
+	return b
+}
+
+// Content sets Response.Content.
+func (b *ResponseBuilder) Content(v map[string]*Extendable[MediaType]) *ResponseBuilder {
+	b.spec.Spec.Spec.Content = v
+	return b
+}
+
+// AddContent adds the value by the name to Response.Content.
+func (b *ResponseBuilder) AddContent(name string, value *Extendable[MediaType]) *ResponseBuilder {
+	if b.spec.Spec.Spec.Content == nil {
+		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
+	}
+	b.spec.Spec.Spec.Content[name] = value
+	return b
+}
+
+// Links sets Response.Links.
+func (b *ResponseBuilder) Links(v map[string]*RefOrSpec[Extendable[Link]]) *ResponseBuilder {
+	b.spec.Spec.Spec.Links = v
+	return b
+}
+
+// AddLink adds the value by the name to Response.Links.
+func (b *ResponseBuilder) AddLink(name string, value *RefOrSpec[Extendable[Link]]) *ResponseBuilder {
+	if b.spec.Spec.Spec.Links == nil {
+		b.spec.Spec.Spec.Links = make(map[string]*RefOrSpec[Extendable[Link]], 1)
+	}
+	b.spec.Spec.Spec.Links[name] = value
+	return b
+}
+
+// Description sets Response.Description.
+func (b *ResponseBuilder) Description(v string) *ResponseBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// ResponsesBuilder builds Responses object.
+type ResponsesBuilder struct {
+	spec *RefOrSpec[Extendable[Responses]]
+}
+
+// NewResponsesBuilder creates ResponsesBuilder with the empty object.
+func NewResponsesBuilder() *ResponsesBuilder {
+	return &ResponsesBuilder{
+		spec: NewRefOrExtSpec[Responses](&Responses{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ResponsesBuilder) Build() *RefOrSpec[Extendable[Responses]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ResponsesBuilder) Extensions(v map[string]any) *ResponsesBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ResponsesBuilder) AddExt(name string, value any) *ResponsesBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Default sets Responses.Default.
+func (b *ResponsesBuilder) Default(v *RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
+	b.spec.Spec.Spec.Default = v
+	return b
+}
+
+// Response sets Responses.Response.
+func (b *ResponsesBuilder) Response(v map[string]*RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
+	b.spec.Spec.Spec.Response = v
+	return b
+}
+
+// AddResponse adds the value by the name to Responses.Response.
+func (b *ResponsesBuilder) AddResponse(name string, value *RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
+	if b.spec.Spec.Spec.Response == nil {
+		b.spec.Spec.Spec.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+	}
+	b.spec.Spec.Spec.Response[name] = value
+	return b
+}
+
+// SchemaBulder builds Schema object.
+type SchemaBulder struct {
+	spec *RefOrSpec[Schema]
+}
+
+// Build returns the built object.
+func (b *SchemaBulder) Build() *RefOrSpec[Schema] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *SchemaBulder) Extensions(v map[string]any) *SchemaBulder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *SchemaBulder) AddExt(name string, value any) *SchemaBulder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Schema sets Schema.Schema.
+func (b *SchemaBulder) Schema(v string) *SchemaBulder {
+	b.spec.Spec.Schema = v
+	return b
+}
+
+// ID sets Schema.ID.
+func (b *SchemaBulder) ID(v string) *SchemaBulder {
+	b.spec.Spec.ID = v
+	return b
+}
+
+// Ref sets Schema.Ref.
+func (b *SchemaBulder) Ref(v string) *SchemaBulder {
+	b.spec.Spec.Ref = v
+	return b
+}
+
+// Defs sets Schema.Defs.
+func (b *SchemaBulder) Defs(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Defs = v
+	return b
+}
+
+// AddDef adds the value by the name to Schema.Defs.
+func (b *SchemaBulder) AddDef(name string, value *RefOrSpec[Schema]) *SchemaBulder {
+	if b.spec.Spec.Defs == nil {
+		b.spec.Spec.Defs = make(map[string]*RefOrSpec[Schema], 1)
+	}
+	b.spec.Spec.Defs[name] = value
+	return b
+}
+
+// DynamicRef sets Schema.DynamicRef.
+func (b *SchemaBulder) DynamicRef(v string) *SchemaBulder {
+	b.spec.Spec.DynamicRef = v
+	return b
+}
+
+// Vocabulary sets Schema.Vocabulary.
+func (b *SchemaBulder) Vocabulary(v map[string]bool) *SchemaBulder {
+	b.spec.Spec.Vocabulary = v
+	return b
+}
+
+// AddVocabulary adds the value by the name to Schema.Vocabulary.
+func (b *SchemaBulder) AddVocabulary(name string, value bool) *SchemaBulder {
+	if b.spec.Spec.Vocabulary == nil {
+		b.spec.Spec.Vocabulary = make(map[string]bool, 1)
+	}
+	b.spec.Spec.Vocabulary[name] = value
+	return b
+}
+
+// DynamicAnchor sets Schema.DynamicAnchor.
+func (b *SchemaBulder) DynamicAnchor(v string) *SchemaBulder {
+	b.spec.Spec.DynamicAnchor = v
+	return b
+}
+
+// Default sets Schema.Default.
+func (b *SchemaBulder) Default(v any) *SchemaBulder {
+	b.spec.Spec.Default = v
+	return b
+}
+
+// Title sets Schema.Title.
+func (b *SchemaBulder) Title(v string) *SchemaBulder {
+	b.spec.Spec.Title = v
+	return b
+}
+
+// Description sets Schema.Description.
+func (b *SchemaBulder) Description(v string) *SchemaBulder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// Const sets Schema.Const.
+func (b *SchemaBulder) Const(v any) *SchemaBulder {
+	b.spec.Spec.Const = v
+	return b
+}
+
+// Comment sets Schema.Comment.
+func (b *SchemaBulder) Comment(v string) *SchemaBulder {
+	b.spec.Spec.Comment = v
+	return b
+}
+
+// Enum sets Schema.Enum.
+func (b *SchemaBulder) Enum(v ...any) *SchemaBulder {
+	b.spec.Spec.Enum = v
+	return b
+}
+
+// AddEnum appends the values to Schema.Enum.
+func (b *SchemaBulder) AddEnum(v ...any) *SchemaBulder {
+	b.spec.Spec.Enum = append(b.spec.Spec.Enum, v...)
+	return b
+}
+
+// Examples sets Schema.Examples.
+func (b *SchemaBulder) Examples(v ...any) *SchemaBulder {
+	b.spec.Spec.Examples = v
+	return b
+}
+
+// AddExamples appends the values to Schema.Examples.
+func (b *SchemaBulder) AddExamples(v ...any) *SchemaBulder {
+	b.spec.Spec.Examples = append(b.spec.Spec.Examples, v...)
+	return b
+}
+
+// ReadOnly sets Schema.ReadOnly.
+func (b *SchemaBulder) ReadOnly(v bool) *SchemaBulder {
+	b.spec.Spec.ReadOnly = v
+	return b
+}
+
+// WriteOnly sets Schema.WriteOnly.
+func (b *SchemaBulder) WriteOnly(v bool) *SchemaBulder {
+	b.spec.Spec.WriteOnly = v
+	return b
+}
+
+// Deprecated sets Schema.Deprecated.
+func (b *SchemaBulder) Deprecated(v bool) *SchemaBulder {
+	b.spec.Spec.Deprecated = v
+	return b
+}
+
+// ContentSchema sets Schema.ContentSchema.
+func (b *SchemaBulder) ContentSchema(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.ContentSchema = v
+	return b
+}
+
+// ContentMediaType sets Schema.ContentMediaType.
+func (b *SchemaBulder) ContentMediaType(v string) *SchemaBulder {
+	b.spec.Spec.ContentMediaType = v
+	return b
+}
+
+// ContentEncoding sets Schema.ContentEncoding.
+func (b *SchemaBulder) ContentEncoding(v string) *SchemaBulder {
+	b.spec.Spec.ContentEncoding = v
+	return b
+}
+
+// Not sets Schema.Not.
+func (b *SchemaBulder) Not(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Not = v
+	return b
+}
+
+// AllOf sets Schema.AllOf.
+func (b *SchemaBulder) AllOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.AllOf = v
+	return b
+}
+
+// AddAllOf appends the values to Schema.AllOf.
+func (b *SchemaBulder) AddAllOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.AllOf = append(b.spec.Spec.AllOf, v...)
+	return b
+}
+
+// AnyOf sets Schema.AnyOf.
+func (b *SchemaBulder) AnyOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.AnyOf = v
+	return b
+}
+
+// AddAnyOf appends the values to Schema.AnyOf.
+func (b *SchemaBulder) AddAnyOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.AnyOf = append(b.spec.Spec.AnyOf, v...)
+	return b
+}
+
+// OneOf sets Schema.OneOf.
+func (b *SchemaBulder) OneOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.OneOf = v
+	return b
+}
+
+// AddOneOf appends the values to Schema.OneOf.
+func (b *SchemaBulder) AddOneOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.OneOf = append(b.spec.Spec.OneOf, v...)
+	return b
+}
+
+// DependentRequired sets Schema.DependentRequired.
+func (b *SchemaBulder) DependentRequired(v map[string][]string) *SchemaBulder {
+	b.spec.Spec.DependentRequired = v
+	return b
+}
+
+// AddDependentRequired adds the value by the name to Schema.DependentRequired.
+func (b *SchemaBulder) AddDependentRequired(name string, value ...string) *SchemaBulder {
+	if b.spec.Spec.DependentRequired == nil {
+		b.spec.Spec.DependentRequired = make(map[string][]string, 1)
+	}
+	b.spec.Spec.DependentRequired[name] = append(b.spec.Spec.DependentRequired[name], value...)
+	return b
+}
+
+// DependentSchemas sets Schema.DependentSchemas.
+func (b *SchemaBulder) DependentSchemas(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.DependentSchemas = v
+	return b
+}
+
+// AddDependentSchema adds the value by the name to Schema.DependentSchemas.
+func (b *SchemaBulder) AddDependentSchema(name string, value *RefOrSpec[Schema]) *SchemaBulder {
+	if b.spec.Spec.DependentSchemas == nil {
+		b.spec.Spec.DependentSchemas = make(map[string]*RefOrSpec[Schema], 1)
+	}
+	b.spec.Spec.DependentSchemas[name] = value
+	return b
+}
+
+// If sets Schema.If.
+func (b *SchemaBulder) If(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.If = v
+	return b
+}
+
+// Then sets Schema.Then.
+func (b *SchemaBulder) Then(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Then = v
+	return b
+}
+
+// Else sets Schema.Else.
+func (b *SchemaBulder) Else(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Else = v
+	return b
+}
+
+// MultipleOf sets Schema.MultipleOf.
+func (b *SchemaBulder) MultipleOf(v Number) *SchemaBulder {
+	b.spec.Spec.MultipleOf = &v
+	return b
+}
+
+// Minimum sets Schema.Minimum.
+func (b *SchemaBulder) Minimum(v Number) *SchemaBulder {
+	b.spec.Spec.Minimum = &v
+	return b
+}
+
+// ExclusiveMinimum sets Schema.ExclusiveMinimum.
+func (b *SchemaBulder) ExclusiveMinimum(v Number) *SchemaBulder {
+	b.spec.Spec.ExclusiveMinimum = &v
+	return b
+}
+
+// Maximum sets Schema.Maximum.
+func (b *SchemaBulder) Maximum(v Number) *SchemaBulder {
+	b.spec.Spec.Maximum = &v
+	return b
+}
+
+// ExclusiveMaximum sets Schema.ExclusiveMaximum.
+func (b *SchemaBulder) ExclusiveMaximum(v Number) *SchemaBulder {
+	b.spec.Spec.ExclusiveMaximum = &v
+	return b
+}
+
+// MinLength sets Schema.MinLength.
+func (b *SchemaBulder) MinLength(v int) *SchemaBulder {
+	b.spec.Spec.MinLength = &v
+	return b
+}
+
+// MaxLength sets Schema.MaxLength.
+func (b *SchemaBulder) MaxLength(v int) *SchemaBulder {
+	b.spec.Spec.MaxLength = &v
+	return b
+}
+
+// Pattern sets Schema.Pattern.
+func (b *SchemaBulder) Pattern(v string) *SchemaBulder {
+	b.spec.Spec.Pattern = v
+	return b
+}
+
+// Format sets Schema.Format.
+func (b *SchemaBulder) Format(v string) *SchemaBulder {
+	b.spec.Spec.Format = v
+	return b
+}
+
+// Items sets Schema.Items.
+func (b *SchemaBulder) Items(v *BoolOrSchema) *SchemaBulder {
+	b.spec.Spec.Items = v
+	return b
+}
+
+// MaxItems sets Schema.MaxItems.
+func (b *SchemaBulder) MaxItems(v int) *SchemaBulder {
+	b.spec.Spec.MaxItems = &v
+	return b
+}
+
+// UnevaluatedItems sets Schema.UnevaluatedItems.
+func (b *SchemaBulder) UnevaluatedItems(v *BoolOrSchema) *SchemaBulder {
+	b.spec.Spec.UnevaluatedItems = v
+	return b
+}
+
+// Contains sets Schema.Contains.
+func (b *SchemaBulder) Contains(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Contains = v
+	return b
+}
+
+// MinContains sets Schema.MinContains.
+func (b *SchemaBulder) MinContains(v int) *SchemaBulder {
+	b.spec.Spec.MinContains = &v
+	return b
+}
+
+// MaxContains sets Schema.MaxContains.
+func (b *SchemaBulder) MaxContains(v int) *SchemaBulder {
+	b.spec.Spec.MaxContains = &v
+	return b
+}
+
+// MinItems sets Schema.MinItems.
+func (b *SchemaBulder) MinItems(v int) *SchemaBulder {
+	b.spec.Spec.MinItems = &v
+	return b
+}
+
+// UniqueItems sets Schema.UniqueItems.
+func (b *SchemaBulder) UniqueItems(v bool) *SchemaBulder {
+	b.spec.Spec.UniqueItems = &v
+	return b
+}
+
+// PrefixItems sets Schema.PrefixItems.
+func (b *SchemaBulder) PrefixItems(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.PrefixItems = v
+	return b
+}
+
+// AddPrefixItems appends the values to Schema.PrefixItems.
+func (b *SchemaBulder) AddPrefixItems(v ...*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.PrefixItems = append(b.spec.Spec.PrefixItems, v...)
+	return b
+}
+
+// Properties sets Schema.Properties.
+func (b *SchemaBulder) Properties(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.Properties = v
+	return b
+}
+
+// AddProperty adds the value by the name to Schema.Properties.
+func (b *SchemaBulder) AddProperty(name string, value *RefOrSpec[Schema]) *SchemaBulder {
+	if b.spec.Spec.Properties == nil {
+		b.spec.Spec.Properties = make(map[string]*RefOrSpec[Schema], 1)
+	}
+	b.spec.Spec.Properties[name] = value
+	return b
+}
+
+// PatternProperties sets Schema.PatternProperties.
+func (b *SchemaBulder) PatternProperties(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.PatternProperties = v
+	return b
+}
+
+// AddPatternProperty adds the value by the name to Schema.PatternProperties.
+func (b *SchemaBulder) AddPatternProperty(name string, value *RefOrSpec[Schema]) *SchemaBulder {
+	if b.spec.Spec.PatternProperties == nil {
+		b.spec.Spec.PatternProperties = make(map[string]*RefOrSpec[Schema], 1)
+	}
+	b.spec.Spec.PatternProperties[name] = value
+	return b
+}
+
+// AdditionalProperties sets Schema.AdditionalProperties.
+func (b *SchemaBulder) AdditionalProperties(v *BoolOrSchema) *SchemaBulder {
+	b.spec.Spec.AdditionalProperties = v
+	return b
+}
+
+// UnevaluatedProperties sets Schema.UnevaluatedProperties.
+func (b *SchemaBulder) UnevaluatedProperties(v *BoolOrSchema) *SchemaBulder {
+	b.spec.Spec.UnevaluatedProperties = v
+	return b
+}
+
+// PropertyNames sets Schema.PropertyNames.
+func (b *SchemaBulder) PropertyNames(v *RefOrSpec[Schema]) *SchemaBulder {
+	b.spec.Spec.PropertyNames = v
+	return b
+}
+
+// MinProperties sets Schema.MinProperties.
+func (b *SchemaBulder) MinProperties(v int) *SchemaBulder {
+	b.spec.Spec.MinProperties = &v
+	return b
+}
+
+// MaxProperties sets Schema.MaxProperties.
+func (b *SchemaBulder) MaxProperties(v int) *SchemaBulder {
+	b.spec.Spec.MaxProperties = &v
+	return b
+}
+
+// Required sets Schema.Required.
+func (b *SchemaBulder) Required(v ...string) *SchemaBulder {
+	b.spec.Spec.Required = v
+	return b
+}
+
+// AddRequired appends the values to Schema.Required.
+func (b *SchemaBulder) AddRequired(v ...string) *SchemaBulder {
+	b.spec.Spec.Required = append(b.spec.Spec.Required, v...)
+	return b
+}
+
+// Discriminator sets Schema.Discriminator.
+func (b *SchemaBulder) Discriminator(v *Discriminator) *SchemaBulder {
+	b.spec.Spec.Discriminator = v
+	return b
+}
+
+// XML sets Schema.XML.
+func (b *SchemaBulder) XML(v *Extendable[XML]) *SchemaBulder {
+	b.spec.Spec.XML = v
+	return b
+}
+
+// ExternalDocs sets Schema.ExternalDocs.
+func (b *SchemaBulder) ExternalDocs(v *Extendable[ExternalDocs]) *SchemaBulder {
+	b.spec.Spec.ExternalDocs = v
+	return b
+}
+
+// Example sets Schema.Example.
+func (b *SchemaBulder) Example(v any) *SchemaBulder {
+	b.spec.Spec.Example = v
+	return b
+}
+
+// SecuritySchemeBuilder builds SecurityScheme object.
+type SecuritySchemeBuilder struct {
+	spec *RefOrSpec[Extendable[SecurityScheme]]
+}
+
+// NewSecuritySchemeBuilder creates SecuritySchemeBuilder with the empty object.
+func NewSecuritySchemeBuilder() *SecuritySchemeBuilder {
+	return &SecuritySchemeBuilder{
+		spec: NewRefOrExtSpec[SecurityScheme](&SecurityScheme{}),
+	}
+}
+
+// Build returns the built object.
+func (b *SecuritySchemeBuilder) Build() *RefOrSpec[Extendable[SecurityScheme]] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *SecuritySchemeBuilder) Extensions(v map[string]any) *SecuritySchemeBuilder {
+	b.spec.Spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *SecuritySchemeBuilder) AddExt(name string, value any) *SecuritySchemeBuilder {
+	b.spec.Spec.AddExt(name, value)
+	return b
+}
+
+// Type sets SecurityScheme.Type.
+func (b *SecuritySchemeBuilder) Type(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.Type = v
+	return b
+}
+
+// Description sets SecurityScheme.Description.
+func (b *SecuritySchemeBuilder) Description(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.Description = v
+	return b
+}
+
+// Name sets SecurityScheme.Name.
+func (b *SecuritySchemeBuilder) Name(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.Name = v
+	return b
+}
+
+// In sets SecurityScheme.In.
+func (b *SecuritySchemeBuilder) In(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.In = v
+	return b
+}
+
+// Scheme sets SecurityScheme.Scheme.
+func (b *SecuritySchemeBuilder) Scheme(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.Scheme = v
+	return b
+}
+
+// BearerFormat sets SecurityScheme.BearerFormat.
+func (b *SecuritySchemeBuilder) BearerFormat(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.BearerFormat = v
+	return b
+}
+
+// Flows sets SecurityScheme.Flows.
+func (b *SecuritySchemeBuilder) Flows(v *Extendable[OAuthFlows]) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.Flows = v
+	return b
+}
+
+// OpenIDConnectURL sets SecurityScheme.OpenIDConnectURL.
+func (b *SecuritySchemeBuilder) OpenIDConnectURL(v string) *SecuritySchemeBuilder {
+	b.spec.Spec.Spec.OpenIDConnectURL = v
+	return b
+}
+
+// ServerBuilder builds Server object.
+type ServerBuilder struct {
+	spec *Extendable[Server]
+}
+
+// NewServerBuilder creates ServerBuilder with the empty object.
+func NewServerBuilder() *ServerBuilder {
+	return &ServerBuilder{
+		spec: NewExtendable[Server](&Server{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ServerBuilder) Build() *Extendable[Server] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ServerBuilder) Extensions(v map[string]any) *ServerBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ServerBuilder) AddExt(name string, value any) *ServerBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Variables sets Server.Variables.
+func (b *ServerBuilder) Variables(v map[string]*Extendable[ServerVariable]) *ServerBuilder {
+	b.spec.Spec.Variables = v
+	return b
+}
+
+// AddVariable adds the value by the name to Server.Variables.
+func (b *ServerBuilder) AddVariable(name string, value *Extendable[ServerVariable]) *ServerBuilder {
+	if b.spec.Spec.Variables == nil {
+		b.spec.Spec.Variables = make(map[string]*Extendable[ServerVariable], 1)
+	}
+	b.spec.Spec.Variables[name] = value
+	return b
+}
+
+// URL sets Server.URL.
+func (b *ServerBuilder) URL(v string) *ServerBuilder {
+	b.spec.Spec.URL = v
+	return b
+}
+
+// Description sets Server.Description.
+func (b *ServerBuilder) Description(v string) *ServerBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// ServerVariableBuilder builds ServerVariable object.
+type ServerVariableBuilder struct {
+	spec *Extendable[ServerVariable]
+}
+
+// NewServerVariableBuilder creates ServerVariableBuilder with the empty object.
+func NewServerVariableBuilder() *ServerVariableBuilder {
+	return &ServerVariableBuilder{
+		spec: NewExtendable[ServerVariable](&ServerVariable{}),
+	}
+}
+
+// Build returns the built object.
+func (b *ServerVariableBuilder) Build() *Extendable[ServerVariable] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *ServerVariableBuilder) Extensions(v map[string]any) *ServerVariableBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *ServerVariableBuilder) AddExt(name string, value any) *ServerVariableBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Default sets ServerVariable.Default.
+func (b *ServerVariableBuilder) Default(v string) *ServerVariableBuilder {
+	b.spec.Spec.Default = v
+	return b
+}
+
+// Description sets ServerVariable.Description.
+func (b *ServerVariableBuilder) Description(v string) *ServerVariableBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// Enum sets ServerVariable.Enum.
+func (b *ServerVariableBuilder) Enum(v ...string) *ServerVariableBuilder {
+	b.spec.Spec.Enum = v
+	return b
+}
+
+// AddEnum appends the values to ServerVariable.Enum.
+func (b *ServerVariableBuilder) AddEnum(v ...string) *ServerVariableBuilder {
+	b.spec.Spec.Enum = append(b.spec.Spec.Enum, v...)
+	return b
+}
+
+// TagBuilder builds Tag object.
+type TagBuilder struct {
+	spec *Extendable[Tag]
+}
+
+// NewTagBuilder creates TagBuilder with the empty object.
+func NewTagBuilder() *TagBuilder {
+	return &TagBuilder{
+		spec: NewExtendable[Tag](&Tag{}),
+	}
+}
+
+// Build returns the built object.
+func (b *TagBuilder) Build() *Extendable[Tag] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *TagBuilder) Extensions(v map[string]any) *TagBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *TagBuilder) AddExt(name string, value any) *TagBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// ExternalDocs sets Tag.ExternalDocs.
+func (b *TagBuilder) ExternalDocs(v *Extendable[ExternalDocs]) *TagBuilder {
+	b.spec.Spec.ExternalDocs = v
+	return b
+}
+
+// Name sets Tag.Name.
+func (b *TagBuilder) Name(v string) *TagBuilder {
+	b.spec.Spec.Name = v
+	return b
+}
+
+// Description sets Tag.Description.
+func (b *TagBuilder) Description(v string) *TagBuilder {
+	b.spec.Spec.Description = v
+	return b
+}
+
+// XMLBuilder builds XML object.
+type XMLBuilder struct {
+	spec *Extendable[XML]
+}
+
+// NewXMLBuilder creates XMLBuilder with the empty object.
+func NewXMLBuilder() *XMLBuilder {
+	return &XMLBuilder{
+		spec: NewExtendable[XML](&XML{}),
+	}
+}
+
+// Build returns the built object.
+func (b *XMLBuilder) Build() *Extendable[XML] {
+	return b.spec
+}
+
+// Extensions sets the extensions of the object.
+func (b *XMLBuilder) Extensions(v map[string]any) *XMLBuilder {
+	b.spec.Extensions = v
+	return b
+}
+
+// AddExt sets the extension, see Extendable.AddExt.
+func (b *XMLBuilder) AddExt(name string, value any) *XMLBuilder {
+	b.spec.AddExt(name, value)
+	return b
+}
+
+// Name sets XML.Name.
+func (b *XMLBuilder) Name(v string) *XMLBuilder {
+	b.spec.Spec.Name = v
+	return b
+}
+
+// Namespace sets XML.Namespace.
+func (b *XMLBuilder) Namespace(v string) *XMLBuilder {
+	b.spec.Spec.Namespace = v
+	return b
+}
+
+// Prefix sets XML.Prefix.
+func (b *XMLBuilder) Prefix(v string) *XMLBuilder {
+	b.spec.Spec.Prefix = v
+	return b
+}
+
+// Attribute sets XML.Attribute.
+func (b *XMLBuilder) Attribute(v bool) *XMLBuilder {
+	b.spec.Spec.Attribute = v
+	return b
+}
+
+// Wrapped sets XML.Wrapped.
+func (b *XMLBuilder) Wrapped(v bool) *XMLBuilder {
+	b.spec.Spec.Wrapped = v
+	return b
+}
@@ -0,0 +1,36 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestGeneratedBuilders(t *testing.T) {
+	components := openapi.NewComponentsBuilder().
+		AddSchema("Pet", openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()).
+		AddExt("x-owner", "pets").
+		Build()
+	require.Len(t, components.Spec.Schemas, 1)
+	require.Equal(t, "pets", components.Extensions["x-owner"])
+
+	paths := openapi.NewPathsBuilder().
+		AddPath("/pets", openapi.NewPathItemBuilder().Summary("pets").Build()).
+		Build()
+	require.Equal(t, "pets", paths.Spec.Paths["/pets"].Spec.Spec.Summary)
+
+	encoding := openapi.NewEncodingBuilder().
+		AddHeader("X-Rate-Limit", openapi.NewHeaderBuilder().Description("limit").Build()).
+		Build()
+	require.Equal(t, "limit", encoding.Spec.Headers["X-Rate-Limit"].Spec.Spec.Description)
+
+	schema := openapi.NewSchemaBuilder().
+		MinLength(1).
+		AddRequired("id").
+		AddRequired("name").
+		Build()
+	require.Equal(t, 1, *schema.Spec.MinLength)
+	require.Equal(t, []string{"id", "name"}, schema.Spec.Required)
+}
@@ -0,0 +1,309 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type bundleOptions struct {
+	base   string
+	loader func(name string) ([]byte, error)
+}
+
+// BundleOption is a type for the options of Bundle.
+type BundleOption func(*bundleOptions)
+
+// BundleBase sets the path or URL of the bundled spec, the relative references are resolved against it.
+// The default is `openapi.yaml`, so the references are resolved against the current directory.
+func BundleBase(name string) BundleOption {
+	return func(o *bundleOptions) {
+		o.base = name
+	}
+}
+
+// BundleFS sets the file system to load the referenced documents from.
+func BundleFS(fsys fs.FS) BundleOption {
+	return BundleLoader(func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, name)
+	})
+}
+
+// BundleLoader sets the function to load the referenced documents by their paths or URLs,
+// e.g. to download them over HTTP. The default loader reads the files from the operating system.
+func BundleLoader(f func(name string) ([]byte, error)) BundleOption {
+	return func(o *bundleOptions) {
+		o.loader = f
+	}
+}
+
+// Bundle returns a self-contained copy of the given spec with all external references inlined into the components,
+// e.g. to publish a spec assembled from many files.
+//
+// A reference to another document, like `pets.yaml#/components/schemas/Pet` or `schemas/pet.yaml`,
+// is loaded, added to the components of the type expected at the place of the reference,
+// and the reference is replaced by the local one, like `#/components/schemas/Pet`.
+// The references of the loaded objects are resolved against their documents and bundled the same way.
+// The name of a new component is the last segment of the reference, or the file name without extension
+// if the whole document is referenced; a numeric suffix is added to avoid the collisions, like `Pet_2`.
+// The same referenced object is added once.
+// The given spec is not modified.
+func Bundle(spec *Extendable[OpenAPI], opts ...BundleOption) (*Extendable[OpenAPI], error) {
+	if spec == nil || spec.Spec == nil {
+		return spec, nil
+	}
+	options := &bundleOptions{
+		base:   "openapi.yaml",
+		loader: os.ReadFile,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if u, err := url.Parse(options.base); err != nil || !u.IsAbs() {
+		options.base = path.Clean(options.base)
+	}
+	doc := copySpec(spec)
+	b := &bundler{
+		options: options,
+		spec:    doc,
+		docs:    make(map[string]any),
+		refs:    make(map[string]string),
+		taken:   make(map[string]map[string]bool),
+	}
+	visited := make(map[uintptr]map[reflect.Type]bool)
+	walkSpecValue(reflect.ValueOf(doc), b.visitor(options.base), visited)
+	// the loaded objects are added to the components after the walk, so they are visited with their own documents
+	for len(b.pending) > 0 && b.err == nil {
+		c := b.pending[0]
+		b.pending = b.pending[1:]
+		b.components[c.typ].SetMapIndex(reflect.ValueOf(c.name), c.value)
+		walkSpecValue(c.value, b.visitor(c.document), visited)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return doc, nil
+}
+
+// bundledComponent is an object loaded from another document to be added to the components.
+type bundledComponent struct {
+	typ      string
+	name     string
+	value    reflect.Value
+	document string
+}
+
+type bundler struct {
+	options *bundleOptions
+	spec    *Extendable[OpenAPI]
+	// components are the settable maps of the components of the bundled spec by their types
+	components map[string]reflect.Value
+	// docs are the loaded documents in the form of decoded JSON by their paths
+	docs map[string]any
+	// refs are the local references of the bundled objects by their absolute references
+	refs map[string]string
+	// taken are the names of the pending components by their types
+	taken   map[string]map[string]bool
+	pending []*bundledComponent
+	err     error
+}
+
+// bundleable is implemented by RefOrSpec to bundle its reference.
+type bundleable interface {
+	bundle(b *bundler, document string)
+}
+
+func (o *RefOrSpec[T]) bundle(b *bundler, document string) {
+	if o.Ref != nil {
+		o.Ref.Ref = bundleRef[T](b, document, o.Ref.Ref)
+	}
+}
+
+// visitor returns the visitor for walkSpecValue bundling the references of the objects from the given document.
+func (b *bundler) visitor(document string) func(reflect.Value) {
+	return func(v reflect.Value) {
+		if b.err != nil {
+			return
+		}
+		switch t := v.Interface().(type) {
+		case bundleable:
+			t.bundle(b, document)
+		case *Schema:
+			if t.Ref != "" {
+				t.Ref = bundleRef[Schema](b, document, t.Ref)
+			}
+		case *Discriminator:
+			for k, ref := range t.Mapping {
+				if !strings.Contains(ref, "/") {
+					if document == b.options.base {
+						continue
+					}
+					// the name of a schema in another document
+					ref = joinLoc("#", "components", "schemas", ref)
+				}
+				t.Mapping[k] = bundleRef[Schema](b, document, ref)
+			}
+		}
+	}
+}
+
+// bundleRef loads the object referenced from the given document and returns its local reference.
+// In case of an error, it is stored in the bundler and the reference is returned as is.
+func bundleRef[T any](b *bundler, document, ref string) string {
+	name, fragment := b.resolve(document, ref)
+	if name == b.options.base {
+		return fragment
+	}
+	key := name + fragment
+	if local, ok := b.refs[key]; ok {
+		return local
+	}
+	obj := new(RefOrSpec[T])
+	if err := b.load(name, fragment, obj); err != nil {
+		b.err = fmt.Errorf("%w: bundling %q failed: %w", ErrUnresolvedRef, key, err)
+		return ref
+	}
+	typ, ok := b.componentType(reflect.TypeOf(obj))
+	if !ok {
+		b.err = fmt.Errorf("%w: bundling %q failed: the components can not contain %T", ErrUnresolvedRef, key, obj.Spec)
+		return ref
+	}
+	component := b.componentName(typ, bundledComponentName(name, fragment))
+	local := joinLoc("#", "components", typ, component)
+	b.refs[key] = local
+	b.pending = append(b.pending, &bundledComponent{
+		typ:      typ,
+		name:     component,
+		value:    reflect.ValueOf(obj),
+		document: name,
+	})
+	return local
+}
+
+// resolve returns the path or URL of the document and the fragment of the reference from the given document.
+func (b *bundler) resolve(document, ref string) (name, fragment string) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	fragment = "#" + fragment
+	if file == "" {
+		return document, fragment
+	}
+	if u, err := url.Parse(file); err == nil && u.IsAbs() {
+		return file, fragment
+	}
+	if u, err := url.Parse(document); err == nil && u.IsAbs() {
+		if r, err := u.Parse(file); err == nil {
+			return r.String(), fragment
+		}
+	}
+	return resolveDocumentRef(document, ref)
+}
+
+// load decodes the object at the fragment of the document into the given value.
+func (b *bundler) load(name, fragment string, obj any) error {
+	doc, ok := b.docs[name]
+	if !ok {
+		data, err := b.options.loader(name)
+		if err != nil {
+			return err
+		}
+		if isJSON(data) {
+			err = json.Unmarshal(data, &doc)
+		} else {
+			err = yaml.Unmarshal(data, &doc)
+			doc = normalizeYAMLValue(doc)
+		}
+		if err != nil {
+			return fmt.Errorf("parsing document %q failed: %w", name, err)
+		}
+		b.docs[name] = doc
+	}
+	loc, err := ParseJSONPointer(fragment)
+	if err != nil {
+		return err
+	}
+	value := doc
+	for _, segment := range loc.Segments() {
+		switch t := value.(type) {
+		case map[string]any:
+			value, ok = t[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			ok = err == nil && i >= 0 && i < len(t)
+			if ok {
+				value = t[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			return errors.New("object not found")
+		}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, obj)
+}
+
+// componentType returns the type of the components, like `schemas`, holding the objects of the given type.
+func (b *bundler) componentType(t reflect.Type) (string, bool) {
+	if b.components == nil {
+		if b.spec.Spec.Components == nil {
+			b.spec.Spec.Components = NewExtendable(&Components{})
+		}
+		b.components = componentMaps(b.spec.Spec.Components.Spec)
+	}
+	for typ, f := range b.components {
+		if f.Type().Elem() == t {
+			if f.IsNil() {
+				f.Set(reflect.MakeMap(f.Type()))
+			}
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+// componentName returns the given name or the name with a numeric suffix not used by the components of the type.
+func (b *bundler) componentName(typ, name string) string {
+	if b.taken[typ] == nil {
+		b.taken[typ] = make(map[string]bool)
+	}
+	f := b.components[typ]
+	unique := name
+	for i := 2; b.taken[typ][unique] || f.MapIndex(reflect.ValueOf(unique)).IsValid(); i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	b.taken[typ][unique] = true
+	return unique
+}
+
+var invalidComponentNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+
+// bundledComponentName returns the name of the component for the object at the fragment of the document:
+// the last segment of the fragment or the file name without extension.
+func bundledComponentName(document, fragment string) string {
+	name := path.Base(document)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	if loc, err := ParseJSONPointer(fragment); err == nil {
+		if segments := loc.Segments(); len(segments) > 0 {
+			name = segments[len(segments)-1]
+		}
+	}
+	name = invalidComponentNameChars.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "Component"
+	}
+	return name
+}
@@ -0,0 +1,149 @@
+package openapi_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testBundleSpec = `
+openapi: 3.1.1
+info:
+  title: Shop
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'teams/pets.yaml#/components/parameters/Limit'
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: 'teams/pets.yaml#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            $ref: 'common/error.json'
+  schemas:
+    Pet:
+      type: string
+`
+
+const testBundlePets = `
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag:
+          $ref: '#/components/schemas/Tag'
+        error:
+          $ref: '../common/error.json'
+        parent:
+          $ref: '#/components/schemas/Pet'
+    Tag:
+      type: string
+`
+
+const testBundleError = `{
+  "type": "object",
+  "properties": {
+    "code": {"type": "integer"},
+    "tag": {"$ref": "../teams/pets.yaml#/components/schemas/Tag"}
+  }
+}`
+
+func TestBundle(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testBundleSpec), &spec))
+	fsys := fstest.MapFS{
+		"api/teams/pets.yaml":   {Data: []byte(testBundlePets)},
+		"api/common/error.json": {Data: []byte(testBundleError)},
+	}
+
+	bundled, err := openapi.Bundle(spec, openapi.BundleFS(fsys), openapi.BundleBase("api/openapi.yaml"))
+	require.NoError(t, err)
+
+	// the own Pet schema of the spec is not used, the bundled one is renamed to avoid the collision
+	validator, err := openapi.NewValidator(bundled, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	op := bundled.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec
+	require.Equal(t, "#/components/parameters/Limit", op.Parameters[0].Ref.Ref)
+	require.Equal(t, "#/components/schemas/Pet_2",
+		op.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema.Spec.Items.Schema.Ref.Ref)
+	require.Equal(t, "#/components/responses/Error", op.Responses.Spec.Default.Ref.Ref)
+
+	c := bundled.Spec.Components.Spec
+	require.Equal(t, "#/components/schemas/error",
+		c.Responses["Error"].Spec.Spec.Content["application/json"].Spec.Schema.Ref.Ref)
+	require.Len(t, c.Schemas, 4)
+	require.Equal(t, openapi.StringType, (*c.Schemas["Pet"].Spec.Type)[0])
+	pet := c.Schemas["Pet_2"].Spec.Properties
+	require.Equal(t, "#/components/schemas/Tag", pet["tag"].Ref.Ref)
+	require.Equal(t, "#/components/schemas/error", pet["error"].Ref.Ref)
+	require.Equal(t, "#/components/schemas/Pet_2", pet["parent"].Ref.Ref)
+	require.Equal(t, "#/components/schemas/Tag", c.Schemas["error"].Spec.Properties["tag"].Ref.Ref)
+	require.Equal(t, "limit", c.Parameters["Limit"].Spec.Spec.Name)
+
+	// the given spec is not modified
+	require.Equal(t, "teams/pets.yaml#/components/parameters/Limit",
+		spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Parameters[0].Ref.Ref)
+}
+
+func TestBundle_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ref  string
+		err  string
+	}{
+		{
+			name: "missing document",
+			ref:  "missing.yaml#/components/schemas/Pet",
+			err:  `bundling "missing.yaml#/components/schemas/Pet" failed: open missing.yaml: file does not exist`,
+		},
+		{
+			name: "missing object",
+			ref:  "pets.yaml#/components/schemas/Missing",
+			err:  `bundling "pets.yaml#/components/schemas/Missing" failed: object not found`,
+		},
+		{
+			name: "invalid pointer",
+			ref:  "pets.yaml#components",
+			err:  "JSON Pointer must start with `/`",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("t").Version("v").Build()).
+				Build()
+			spec.Spec.Components = openapi.NewComponents()
+			spec.Spec.Components.Spec.Add("Pet", openapi.NewRefOrSpec[openapi.Schema](tt.ref))
+			_, err := openapi.Bundle(spec, openapi.BundleFS(fstest.MapFS{"pets.yaml": {Data: []byte(testBundlePets)}}))
+			require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -68,10 +68,6 @@ func (o *Callback) Add(expression string, item *RefOrSpec[Extendable[PathItem]])
 	return o
 }
 
-type CallbackBuilder struct {
-	spec *RefOrSpec[Extendable[Callback]]
-}
-
 func NewCallbackBuilder() *CallbackBuilder {
 	return &CallbackBuilder{
 		spec: NewRefOrExtSpec[Callback](&Callback{
@@ -79,27 +75,3 @@ func NewCallbackBuilder() *CallbackBuilder {
 		}),
 	}
 }
-
-func (b *CallbackBuilder) Build() *RefOrSpec[Extendable[Callback]] {
-	return b.spec
-}
-
-func (b *CallbackBuilder) Extensions(v map[string]any) *CallbackBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *CallbackBuilder) AddExt(name string, value any) *CallbackBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *CallbackBuilder) Paths(paths map[string]*RefOrSpec[Extendable[PathItem]]) *CallbackBuilder {
-	b.spec.Spec.Spec.Paths = paths
-	return b
-}
-
-func (b *CallbackBuilder) AddPathItem(expression string, item *RefOrSpec[Extendable[PathItem]]) *CallbackBuilder {
-	b.spec.Spec.Spec.Add(expression, item)
-	return b
-}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MarshalCanonical marshals the given spec, or any of its objects, into JSON with byte-for-byte stable output,
+// e.g. for the golden files of the tests:
+//
+//   - the keys of the objects are sorted by their bytes;
+//   - the values are indented with two spaces and the output ends with a new line;
+//   - the integers are written as is, and the other numbers in the shortest form,
+//     using the exponent for the values less than 1e-6 or greater than or equal to 1e21, like JavaScript does;
+//   - the strings are escaped minimally: only the quote, the backslash, and the control characters are escaped,
+//     the HTML characters and the non-ASCII characters are not.
+//
+// The output does not depend on the order of the fields of the structs or on the version of Go.
+func MarshalCanonical(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, raw, 0); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v any, depth int) error {
+	switch t := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(t))
+	case string:
+		writeCanonicalString(buf, t)
+	case json.Number:
+		s, err := canonicalNumber(t)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case []any:
+		if len(t) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range t {
+			writeIndent(buf, depth+1)
+			if err := writeCanonical(buf, item, depth+1); err != nil {
+				return err
+			}
+			if i < len(t)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		writeIndent(buf, depth)
+		buf.WriteByte(']')
+	case map[string]any:
+		if len(t) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		buf.WriteString("{\n")
+		for i, k := range keys {
+			writeIndent(buf, depth+1)
+			writeCanonicalString(buf, k)
+			buf.WriteString(": ")
+			if err := writeCanonical(buf, t[k], depth+1); err != nil {
+				return err
+			}
+			if i < len(keys)-1 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('\n')
+		}
+		writeIndent(buf, depth)
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("%w: unexpected JSON value of type %T", ErrInvalidValue, v)
+	}
+	return nil
+}
+
+func writeIndent(buf *bytes.Buffer, depth int) {
+	buf.WriteString(strings.Repeat("  ", depth))
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"':
+			buf.WriteString(`\"`)
+		case r == '\\':
+			buf.WriteString(`\\`)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r == '\b':
+			buf.WriteString(`\b`)
+		case r == '\f':
+			buf.WriteString(`\f`)
+		case r < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// canonicalNumber formats the number in the shortest form.
+func canonicalNumber(n json.Number) (string, error) {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		// the integers are kept as is to not lose the precision of the big values
+		if i, ok := new(big.Int).SetString(s, 10); ok {
+			return i.String(), nil
+		}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return "", fmt.Errorf("%w: number %s: %w", ErrInvalidValue, s, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	return strconv.FormatFloat(f, 'e', -1, 64), nil
+}
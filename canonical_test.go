@@ -0,0 +1,57 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestMarshalCanonical(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{name: "null", value: nil, expected: "null\n"},
+		{name: "empty", value: map[string]any{"a": []any{}, "b": map[string]any{}}, expected: "{\n  \"a\": [],\n  \"b\": {}\n}\n"},
+		{
+			name:     "strings",
+			value:    "<a href=\"x\">ü</a>\t\\\x01",
+			expected: "\"<a href=\\\"x\\\">ü</a>\\t\\\\\\u0001\"\n",
+		},
+		{
+			name: "numbers",
+			value: []any{
+				json.Number("12345678901234567890123"), json.Number("1.0"), json.Number("-0.0"), json.Number("1.5e3"),
+				json.Number("0.000001"), json.Number("1e-7"), json.Number("1e21"), 0.1,
+			},
+			expected: "[\n  12345678901234567890123,\n  1,\n  0,\n  1500,\n  0.000001,\n  1e-07,\n  1e+21,\n  0.1\n]\n",
+		},
+		{
+			name:     "sorted keys",
+			value:    map[string]any{"b": 1, "a": map[string]any{"d": true, "c": nil}},
+			expected: "{\n  \"a\": {\n    \"c\": null,\n    \"d\": true\n  },\n  \"b\": 1\n}\n",
+		},
+		{
+			name: "spec",
+			value: openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("A & B").Version("1.0.0").Build()).
+				AddExt("x-b", 2).
+				AddExt("x-a", 1.25).
+				Build(),
+			expected: "{\n  \"info\": {\n    \"title\": \"A & B\",\n    \"version\": \"1.0.0\"\n  },\n  \"jsonSchemaDialect\": \"https://spec.openapis.org/oas/3.1/dialect/base\",\n  \"openapi\": \"3.1.1\",\n  \"x-a\": 1.25,\n  \"x-b\": 2\n}\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := openapi.MarshalCanonical(tt.value)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, string(data))
+		})
+	}
+
+	_, err := openapi.MarshalCanonical(func() {})
+	require.Error(t, err)
+}
@@ -0,0 +1,358 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HTTPExchange is a recorded pair of an HTTP request and its response.
+//
+// EXPERIMENTAL: the type and the functions working with it can be changed in the future versions.
+type HTTPExchange struct {
+	RequestHeader  http.Header
+	ResponseHeader http.Header
+	// The HTTP method of the request, e.g. GET.
+	Method string
+	// The full or relative URL of the request including the query string, e.g. https://example.com/pets?limit=10.
+	URL          string
+	RequestBody  []byte
+	ResponseBody []byte
+	StatusCode   int
+}
+
+type harLog struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method   string         `json:"method"`
+				URL      string         `json:"url"`
+				Headers  []harNameValue `json:"headers"`
+				PostData *struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Status  int            `json:"status"`
+				Headers []harNameValue `json:"headers"`
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func harHeaders(values []harNameValue) http.Header {
+	h := make(http.Header, len(values))
+	for _, v := range values {
+		h.Add(v.Name, v.Value)
+	}
+	return h
+}
+
+// ReadHAR reads the HTTP Archive (HAR) 1.2 log and returns the recorded exchanges.
+//
+// EXPERIMENTAL: the function can be changed in the future versions.
+func ReadHAR(r io.Reader) ([]*HTTPExchange, error) {
+	var har harLog
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("decoding HAR failed: %w", err)
+	}
+	exchanges := make([]*HTTPExchange, 0, len(har.Log.Entries))
+	for i, e := range har.Log.Entries {
+		ex := &HTTPExchange{
+			Method:         e.Request.Method,
+			URL:            e.Request.URL,
+			RequestHeader:  harHeaders(e.Request.Headers),
+			StatusCode:     e.Response.Status,
+			ResponseHeader: harHeaders(e.Response.Headers),
+			ResponseBody:   []byte(e.Response.Content.Text),
+		}
+		if e.Request.PostData != nil {
+			ex.RequestBody = []byte(e.Request.PostData.Text)
+			if ex.RequestHeader.Get("Content-Type") == "" && e.Request.PostData.MimeType != "" {
+				ex.RequestHeader.Set("Content-Type", e.Request.PostData.MimeType)
+			}
+		}
+		if e.Response.Content.Encoding == "base64" {
+			data, err := base64.StdEncoding.DecodeString(e.Response.Content.Text)
+			if err != nil {
+				return nil, fmt.Errorf("decoding response content of entry %d failed: %w", i, err)
+			}
+			ex.ResponseBody = data
+		}
+		if ex.ResponseHeader.Get("Content-Type") == "" && e.Response.Content.MimeType != "" {
+			ex.ResponseHeader.Set("Content-Type", e.Response.Content.MimeType)
+		}
+		exchanges = append(exchanges, ex)
+	}
+	return exchanges, nil
+}
+
+// templatePath replaces the path segments looking like identifiers (numbers or UUIDs) with path parameters.
+func templatePath(path string) string {
+	parts := strings.Split(path, "/")
+	used := make(map[string]int)
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		if _, err := strconv.ParseInt(p, 10, 64); err != nil && !uuidPattern.MatchString(p) {
+			continue
+		}
+		name := "id"
+		if i > 0 && parts[i-1] != "" && !strings.HasPrefix(parts[i-1], "{") {
+			name = strings.TrimSuffix(parts[i-1], "s") + "Id"
+		}
+		used[name]++
+		if n := used[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+		parts[i] = "{" + name + "}"
+	}
+	return strings.Join(parts, "/")
+}
+
+// scalarValue converts the raw parameter value to the most specific JSON type.
+func scalarValue(v string) any {
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return float64(i)
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b
+	}
+	return v
+}
+
+type paramKey struct {
+	name string
+	in   string
+}
+
+type operationSamples struct {
+	params    map[paramKey]*schemaInferrer
+	requests  map[string]*schemaInferrer
+	responses map[int]map[string]*schemaInferrer
+}
+
+func addBodySample(m map[string]*schemaInferrer, header http.Header, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+	inferrer, ok := m[mediaType]
+	if !ok {
+		inferrer = newSchemaInferrer(nil)
+		m[mediaType] = inferrer
+	}
+	if isJSONMediaType(mediaType) {
+		var v any
+		if json.Unmarshal(body, &v) == nil {
+			inferrer.add(v)
+			return
+		}
+	}
+	inferrer.add(string(body))
+}
+
+// InferFromExchanges extends the given specification by the operations, parameters, request bodies and responses
+// inferred from the recorded HTTP exchanges.
+// The existing paths are reused if a request path matches a templated path,
+// otherwise new path is created by replacing the segments looking like identifiers (numbers and UUIDs) with path parameters.
+// The existing parameters, request bodies and responses are kept as is, only missing ones are added.
+//
+// EXPERIMENTAL: the function can be changed in the future versions.
+func InferFromExchanges(spec *Extendable[OpenAPI], exchanges ...*HTTPExchange) error {
+	if spec == nil || spec.Spec == nil {
+		return errors.New("spec is required")
+	}
+	if spec.Spec.Paths == nil {
+		spec.Spec.Paths = NewPaths()
+	}
+	paths := spec.Spec.Paths.Spec
+	if paths.Paths == nil {
+		paths.Paths = make(map[string]*RefOrSpec[Extendable[PathItem]])
+	}
+
+	samples := make(map[string]map[string]*operationSamples)
+	for i, ex := range exchanges {
+		u, err := url.Parse(ex.URL)
+		if err != nil {
+			return fmt.Errorf("parsing url of exchange %d failed: %w", i, err)
+		}
+		method := strings.ToLower(ex.Method)
+		if method == "" {
+			method = "get"
+		}
+
+		template, pathParams := findPathTemplate(paths, u.Path)
+		byMethod, ok := samples[template]
+		if !ok {
+			byMethod = make(map[string]*operationSamples)
+			samples[template] = byMethod
+		}
+		s, ok := byMethod[method]
+		if !ok {
+			s = &operationSamples{
+				params:    make(map[paramKey]*schemaInferrer),
+				requests:  make(map[string]*schemaInferrer),
+				responses: make(map[int]map[string]*schemaInferrer),
+			}
+			byMethod[method] = s
+		}
+		for name, value := range pathParams {
+			s.addParam(paramKey{name: name, in: InPath}, []string{value})
+		}
+		for name, values := range u.Query() {
+			s.addParam(paramKey{name: name, in: InQuery}, values)
+		}
+		addBodySample(s.requests, ex.RequestHeader, ex.RequestBody)
+		if ex.StatusCode > 0 {
+			r, ok := s.responses[ex.StatusCode]
+			if !ok {
+				r = make(map[string]*schemaInferrer)
+				s.responses[ex.StatusCode] = r
+			}
+			addBodySample(r, ex.ResponseHeader, ex.ResponseBody)
+		}
+	}
+
+	for template, byMethod := range samples {
+		item, ok := paths.Paths[template]
+		if !ok {
+			item = NewPathItemBuilder().Build()
+			paths.Paths[template] = item
+		}
+		if item.Spec == nil {
+			return fmt.Errorf("path %q: extending referenced path items is not supported", template)
+		}
+		for method, s := range byMethod {
+			op := item.Spec.Spec.operation(method)
+			if op == nil {
+				op = NewOperationBuilder().Build()
+				if !item.Spec.Spec.setOperation(method, op) {
+					return fmt.Errorf("path %q: unsupported method %q", template, method)
+				}
+			}
+			s.apply(op.Spec)
+		}
+	}
+	return nil
+}
+
+func (s *operationSamples) addParam(key paramKey, values []string) {
+	inferrer, ok := s.params[key]
+	if !ok {
+		inferrer = newSchemaInferrer(nil)
+		s.params[key] = inferrer
+	}
+	if len(values) == 1 {
+		inferrer.add(scalarValue(values[0]))
+		return
+	}
+	items := make([]any, len(values))
+	for i, v := range values {
+		items[i] = scalarValue(v)
+	}
+	inferrer.add(items)
+}
+
+func (s *operationSamples) apply(op *Operation) {
+	existing := make(map[paramKey]bool, len(op.Parameters))
+	for _, p := range op.Parameters {
+		if p.Spec != nil {
+			existing[paramKey{name: p.Spec.Spec.Name, in: p.Spec.Spec.In}] = true
+		}
+	}
+	keys := make([]paramKey, 0, len(s.params))
+	for k := range s.params {
+		if !existing[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].in != keys[j].in {
+			return keys[i].in < keys[j].in
+		}
+		return keys[i].name < keys[j].name
+	})
+	for _, k := range keys {
+		op.Parameters = append(op.Parameters, NewParameterBuilder().
+			Name(k.name).
+			In(k.in).
+			Required(k.in == InPath).
+			Schema(s.params[k].subSchema()).
+			Build(),
+		)
+	}
+
+	if op.RequestBody == nil && len(s.requests) > 0 {
+		b := NewRequestBodyBuilder()
+		for mediaType, inferrer := range s.requests {
+			b.AddContent(mediaType, NewMediaTypeBuilder().Schema(inferrer.subSchema()).Build())
+		}
+		op.RequestBody = b.Build()
+	}
+
+	if len(s.responses) > 0 && op.Responses == nil {
+		op.Responses = NewExtendable(&Responses{})
+	}
+	for code, content := range s.responses {
+		key := strconv.Itoa(code)
+		if _, ok := op.Responses.Spec.Response[key]; ok {
+			continue
+		}
+		b := NewResponseBuilder().Description(http.StatusText(code))
+		for mediaType, inferrer := range content {
+			b.AddContent(mediaType, NewMediaTypeBuilder().Schema(inferrer.subSchema()).Build())
+		}
+		if op.Responses.Spec.Response == nil {
+			op.Responses.Spec.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+		}
+		op.Responses.Spec.Response[key] = b.Build()
+	}
+}
+
+// findPathTemplate returns the existing templated path matching the given path or creates new template.
+// The concrete paths have precedence over the templated ones.
+func findPathTemplate(paths *Paths, path string) (string, map[string]string) {
+	if _, ok := paths.Paths[path]; ok {
+		return path, nil
+	}
+	templates := make([]string, 0, len(paths.Paths))
+	for k := range paths.Paths {
+		templates = append(templates, k)
+	}
+	sort.Strings(templates)
+	for _, t := range templates {
+		if params, ok := matchPathTemplate(t, path); ok {
+			return t, params
+		}
+	}
+	template := templatePath(path)
+	params, _ := matchPathTemplate(template, path)
+	return template, params
+}
@@ -0,0 +1,85 @@
+package openapi_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testHAR = `{
+  "log": {
+    "entries": [
+      {
+        "request": {"method": "GET", "url": "https://example.com/pets/42?verbose=true", "headers": []},
+        "response": {
+          "status": 200,
+          "headers": [{"name": "Content-Type", "value": "application/json"}],
+          "content": {"mimeType": "application/json", "text": "{\"id\": 42, \"name\": \"Rex\", \"tag\": \"dog\"}"}
+        }
+      },
+      {
+        "request": {"method": "GET", "url": "https://example.com/pets/43", "headers": []},
+        "response": {
+          "status": 200,
+          "headers": [{"name": "Content-Type", "value": "application/json"}],
+          "content": {"mimeType": "application/json", "text": "{\"id\": 43, \"name\": \"Tom\"}"}
+        }
+      },
+      {
+        "request": {
+          "method": "POST",
+          "url": "https://example.com/pets",
+          "headers": [],
+          "postData": {"mimeType": "application/json", "text": "{\"name\": \"Rex\"}"}
+        },
+        "response": {"status": 201, "headers": [], "content": {"mimeType": "application/json", "text": "{\"id\": 44, \"name\": \"Rex\"}"}}
+      }
+    ]
+  }
+}`
+
+func TestInferFromExchanges(t *testing.T) {
+	exchanges, err := openapi.ReadHAR(strings.NewReader(testHAR))
+	require.NoError(t, err)
+	require.Len(t, exchanges, 3)
+
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Pets").Version("1.0.0").Build()).
+		Build()
+	require.NoError(t, openapi.InferFromExchanges(spec, exchanges...))
+
+	paths := spec.Spec.Paths.Spec.Paths
+	require.Len(t, paths, 2)
+
+	get := paths["/pets/{petId}"].Spec.Spec.Get
+	require.NotNil(t, get)
+	require.Len(t, get.Spec.Parameters, 2)
+	require.Equal(t, "petId", get.Spec.Parameters[0].Spec.Spec.Name)
+	require.True(t, get.Spec.Parameters[0].Spec.Spec.Required)
+	require.Equal(t, "verbose", get.Spec.Parameters[1].Spec.Spec.Name)
+	schema := get.Spec.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema.Spec
+	require.Equal(t, []string{"id", "name"}, schema.Required)
+	require.Len(t, schema.Properties, 3)
+
+	post := paths["/pets"].Spec.Spec.Post
+	require.NotNil(t, post)
+	require.NotNil(t, post.Spec.RequestBody)
+	require.Equal(t, http.StatusText(http.StatusCreated), post.Spec.Responses.Spec.Response["201"].Spec.Spec.Description)
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	// second pass reuses the existing templated path and keeps existing definitions
+	require.NoError(t, openapi.InferFromExchanges(spec, &openapi.HTTPExchange{
+		Method:     http.MethodGet,
+		URL:        "/pets/100",
+		StatusCode: http.StatusNotFound,
+	}))
+	require.Len(t, paths, 2)
+	require.Len(t, get.Spec.Responses.Spec.Response, 2)
+}
@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// requestParamExpr matches the runtime expressions of the path and query parameters of the request,
+// e.g. `$request.path.id`.
+var requestParamExpr = regexp.MustCompile(`\$request\.(path|query)\.([^{}\s]+)`)
+
+// CloneOperation returns a deep copy of the given operation with the path and query parameters renamed,
+// e.g. when the operations of a new version of an API are generated from the existing ones.
+// The renames map the old names of the parameters to the new ones.
+//
+// Besides the inline parameters, the runtime expressions referencing the renamed parameters are updated too,
+// e.g. `$request.path.id` in the links of the responses and in the keys of the callbacks.
+// The referenced parameters are copied as is, so they must be inlined first to be renamed.
+// Use RenamePathTemplate to rename the parameters of the path of the cloned operation.
+//
+// An error is returned if the renamed parameters conflict with the existing ones.
+func CloneOperation(op *Extendable[Operation], renames map[string]string) (*Extendable[Operation], error) {
+	if op == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(op)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling operation failed: %w", err)
+	}
+	var clone *Extendable[Operation]
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("unmarshaling operation failed: %w", err)
+	}
+	if len(renames) == 0 || clone.Spec == nil {
+		return clone, nil
+	}
+
+	o := clone.Spec
+	seen := make(map[string]bool, len(o.Parameters))
+	for i, ref := range o.Parameters {
+		if ref == nil || ref.Spec == nil || ref.Spec.Spec == nil {
+			continue
+		}
+		p := ref.Spec.Spec
+		if newName, ok := renames[p.Name]; ok && (p.In == InPath || p.In == InQuery) {
+			p.Name = newName
+		}
+		key := parameterKey(p.In, p.Name)
+		if seen[key] {
+			return nil, newValidationError(joinLoc("parameters", i), "%w: parameter '%s' in %s is duplicated after renaming",
+				ErrInvalidValue, p.Name, p.In)
+		}
+		seen[key] = true
+	}
+	if o.Responses != nil && o.Responses.Spec != nil {
+		renameLinkParams(o.Responses.Spec.Default, renames)
+		for _, resp := range o.Responses.Spec.Response {
+			renameLinkParams(resp, renames)
+		}
+	}
+	for _, ref := range o.Callbacks {
+		if ref == nil || ref.Spec == nil || ref.Spec.Spec == nil {
+			continue
+		}
+		paths := make(map[string]*RefOrSpec[Extendable[PathItem]], len(ref.Spec.Spec.Paths))
+		for expr, item := range ref.Spec.Spec.Paths {
+			paths[renameRequestParams(expr, renames)] = item
+		}
+		ref.Spec.Spec.Paths = paths
+	}
+	return clone, nil
+}
+
+// RenamePathTemplate renames the parameters of the path template, e.g. `/pets/{id}` becomes `/pets/{petId}`
+// for the `id` to `petId` rename.
+func RenamePathTemplate(path string, renames map[string]string) string {
+	return pathTemplateParam.ReplaceAllStringFunc(path, func(m string) string {
+		if newName, ok := renames[m[1:len(m)-1]]; ok {
+			return "{" + newName + "}"
+		}
+		return m
+	})
+}
+
+// renameLinkParams updates the runtime expressions of the links of the inline response.
+func renameLinkParams(resp *RefOrSpec[Extendable[Response]], renames map[string]string) {
+	if resp == nil || resp.Spec == nil || resp.Spec.Spec == nil {
+		return
+	}
+	for _, link := range resp.Spec.Spec.Links {
+		if link == nil || link.Spec == nil || link.Spec.Spec == nil {
+			continue
+		}
+		l := link.Spec.Spec
+		for k, v := range l.Parameters {
+			if s, ok := v.(string); ok {
+				l.Parameters[k] = renameRequestParams(s, renames)
+			}
+		}
+		if s, ok := l.RequestBody.(string); ok {
+			l.RequestBody = renameRequestParams(s, renames)
+		}
+	}
+}
+
+// renameRequestParams renames the path and query parameters in the runtime expressions of the given string.
+func renameRequestParams(s string, renames map[string]string) string {
+	return requestParamExpr.ReplaceAllStringFunc(s, func(m string) string {
+		sub := requestParamExpr.FindStringSubmatch(m)
+		if newName, ok := renames[sub[2]]; ok {
+			return "$request." + sub[1] + "." + newName
+		}
+		return m
+	})
+}
@@ -0,0 +1,99 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testCloneOperation = `
+operationId: getPet
+parameters:
+  - name: id
+    in: path
+    required: true
+    schema:
+      type: integer
+  - name: id
+    in: header
+    schema:
+      type: string
+  - name: fields
+    in: query
+    schema:
+      type: string
+  - $ref: '#/components/parameters/Limit'
+  - name: sort
+    in: query
+    schema:
+      type: string
+responses:
+  '200':
+    description: pet
+    links:
+      owner:
+        operationId: getOwner
+        parameters:
+          petId: $request.path.id
+          static: 42
+        requestBody: '{"fields": "{$request.query.fields}"}'
+callbacks:
+  onChange:
+    '{$request.query.fields}/changes':
+      post:
+        responses:
+          '200':
+            description: ok
+`
+
+func TestCloneOperation(t *testing.T) {
+	var op *openapi.Extendable[openapi.Operation]
+	require.NoError(t, yaml.Unmarshal([]byte(testCloneOperation), &op))
+
+	clone, err := openapi.CloneOperation(op, map[string]string{"id": "petId", "fields": "select", "limit": "size"})
+	require.NoError(t, err)
+
+	params := clone.Spec.Parameters
+	require.Equal(t, "petId", params[0].Spec.Spec.Name)
+	require.Equal(t, "id", params[1].Spec.Spec.Name, "header parameters are not renamed")
+	require.Equal(t, "select", params[2].Spec.Spec.Name)
+	require.Equal(t, "#/components/parameters/Limit", params[3].Ref.Ref)
+
+	link := clone.Spec.Responses.Spec.Response["200"].Spec.Spec.Links["owner"].Spec.Spec
+	require.Equal(t, "$request.path.petId", link.Parameters["petId"])
+	require.EqualValues(t, 42, link.Parameters["static"])
+	require.Equal(t, `{"fields": "{$request.query.select}"}`, link.RequestBody)
+	require.Contains(t, clone.Spec.Callbacks["onChange"].Spec.Spec.Paths, "{$request.query.select}/changes")
+
+	// the original operation is not modified
+	require.Equal(t, "id", op.Spec.Parameters[0].Spec.Spec.Name)
+	require.Equal(t, "$request.path.id", op.Spec.Responses.Spec.Response["200"].Spec.Spec.Links["owner"].Spec.Spec.Parameters["petId"])
+	require.Contains(t, op.Spec.Callbacks["onChange"].Spec.Spec.Paths, "{$request.query.fields}/changes")
+
+	_, err = openapi.CloneOperation(op, map[string]string{"fields": "id"})
+	require.NoError(t, err, "different locations")
+
+	_, err = openapi.CloneOperation(op, map[string]string{"fields": "sort"})
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	require.ErrorContains(t, err, "parameters/4")
+}
+
+func TestRenamePathTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		path     string
+		renames  map[string]string
+		expected string
+	}{
+		{path: "/pets/{id}", renames: map[string]string{"id": "petId"}, expected: "/pets/{petId}"},
+		{path: "/pets/{id}/toys/{toy}", renames: map[string]string{"toy": "toyId"}, expected: "/pets/{id}/toys/{toyId}"},
+		{path: "/pets/id", renames: map[string]string{"id": "petId"}, expected: "/pets/id"},
+		{path: "/pets/{id}", expected: "/pets/{id}"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.expected, openapi.RenamePathTemplate(tt.path, tt.renames))
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CodeSamplesExt is the name of the extension holding the code samples of the operations,
+// it is understood by many documentation portals.
+//
+// Example:
+//
+//	get:
+//	  x-codeSamples:
+//	    - lang: Shell
+//	      label: curl
+//	      source: curl -X GET 'https://api.example.com/pets'
+const CodeSamplesExt = "x-codeSamples"
+
+// CodeSample is an item of the `x-codeSamples` extension.
+type CodeSample struct {
+	// Lang is the language of the sample, used for the syntax highlighting.
+	Lang string `json:"lang" yaml:"lang"`
+	// Label is the name of the sample, the language is used if empty.
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+	// Source is the code of the sample.
+	Source string `json:"source" yaml:"source"`
+}
+
+// CodeSamples sets the code samples of the operation.
+func (b *OperationBuilder) CodeSamples(samples ...*CodeSample) *OperationBuilder {
+	b.spec.AddExt(CodeSamplesExt, samples)
+	return b
+}
+
+// CodeSamplesOf returns the code samples of the given object, usually an operation.
+// The ok is false if the code samples are not set, and an error is returned if their value is invalid.
+func CodeSamplesOf[T any](o *Extendable[T]) (samples []*CodeSample, ok bool, err error) {
+	if o == nil {
+		return nil, false, nil
+	}
+	v, ok := o.Extensions[CodeSamplesExt]
+	if !ok {
+		return nil, false, nil
+	}
+	samples, err = parseCodeSamples(v)
+	return samples, true, err
+}
+
+func parseCodeSamples(v any) ([]*CodeSample, error) {
+	var samples []*CodeSample
+	switch t := v.(type) {
+	case []*CodeSample:
+		samples = t
+	default:
+		// the value decoded from JSON or YAML is a list of maps
+		if err := decodeExtension(v, &samples); err != nil {
+			return nil, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, CodeSamplesExt, err)
+		}
+	}
+	for i, s := range samples {
+		if s == nil || s.Lang == "" || s.Source == "" {
+			return nil, fmt.Errorf("%w of %s: lang and source of sample %d are required", ErrInvalidValue, CodeSamplesExt, i)
+		}
+	}
+	return samples, nil
+}
+
+// GenerateCodeSamples returns the curl and Go samples calling the operation with the given path and method.
+//
+// The URL is the first server of the operation, of its path item or of the spec, with the default values
+// of the variables, or `http://localhost` if there are no servers.
+// The required parameters and the parameters with the examples are included, as well as the request body,
+// preferably of `application/json` media type.
+// The values are taken from the examples of the parameters and media types, then from the examples,
+// the default, the const or the enum of the schemas; the required values without them are the placeholders
+// based on the schema type.
+func GenerateCodeSamples(spec *Extendable[OpenAPI], path, method string) ([]*CodeSample, error) {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrOperationNotFound)
+	}
+	ref := spec.Spec.Paths.Spec.Paths[path]
+	if ref == nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrOperationNotFound)
+	}
+	item, err := ref.GetSpec(spec.Spec.Components)
+	if err != nil {
+		return nil, err
+	}
+	op := item.Spec.operation(method)
+	if op == nil || op.Spec == nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrOperationNotFound)
+	}
+	req, err := newSampleRequest(spec, path, item.Spec, strings.ToUpper(method), op.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	return []*CodeSample{
+		{Lang: "Shell", Label: "curl", Source: req.curl()},
+		{Lang: "Go", Source: req.goSource()},
+	}, nil
+}
+
+// PopulateCodeSamples sets the generated code samples, see GenerateCodeSamples,
+// for the operations of the paths without `x-codeSamples` extension.
+func PopulateCodeSamples(spec *Extendable[OpenAPI]) error {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return nil
+	}
+	items := spec.Spec.Paths.Spec.Paths
+	for _, path := range sortedKeys(items) {
+		item, err := items[path].GetSpec(spec.Spec.Components)
+		if err != nil {
+			return err
+		}
+		for _, method := range pathItemMethods {
+			op := item.Spec.operation(method)
+			if op == nil || op.Spec == nil {
+				continue
+			}
+			if _, ok := op.Extensions[CodeSamplesExt]; ok {
+				continue
+			}
+			samples, err := GenerateCodeSamples(spec, path, method)
+			if err != nil {
+				return err
+			}
+			op.AddExt(CodeSamplesExt, samples)
+		}
+	}
+	return nil
+}
+
+// sampleRequest is the HTTP request of the code samples.
+type sampleRequest struct {
+	method  string
+	url     string
+	headers [][2]string
+	body    string
+}
+
+func newSampleRequest(spec *Extendable[OpenAPI], path string, item *PathItem, method string, op *Operation) (*sampleRequest, error) {
+	components := spec.Spec.Components
+	req := &sampleRequest{method: method}
+
+	servers := op.Servers
+	if len(servers) == 0 {
+		servers = item.Servers
+	}
+	if len(servers) == 0 {
+		servers = spec.Spec.Servers
+	}
+	server := "http://localhost"
+	if len(servers) > 0 && servers[0] != nil && servers[0].Spec != nil {
+		u, err := servers[0].Spec.ResolveURL(nil)
+		if err != nil {
+			return nil, err
+		}
+		server = strings.TrimSuffix(u, "/")
+	}
+
+	// the parameters of the operation override the ones of the path item
+	params := make(map[string]*Parameter)
+	var keys []string
+	for _, list := range [][]*RefOrSpec[Extendable[Parameter]]{item.Parameters, op.Parameters} {
+		for _, ref := range list {
+			p, err := ref.GetSpec(components)
+			if err != nil {
+				return nil, err
+			}
+			key := parameterKey(p.Spec.In, p.Spec.Name)
+			if _, ok := params[key]; !ok {
+				keys = append(keys, key)
+			}
+			params[key] = p.Spec
+		}
+	}
+	var query, cookies []string
+	for _, key := range keys {
+		p := params[key]
+		value, ok := parameterSample(p, components)
+		if !ok {
+			continue
+		}
+		s, err := EncodeParameter(p, value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+		switch p.In {
+		case InPath:
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", s)
+		case InQuery:
+			query = append(query, s)
+		case InHeader:
+			req.headers = append(req.headers, [2]string{p.Name, s})
+		case InCookie:
+			cookies = append(cookies, s)
+		}
+	}
+	req.url = server + path
+	if len(query) > 0 {
+		req.url += "?" + strings.Join(query, "&")
+	}
+	if len(cookies) > 0 {
+		req.headers = append(req.headers, [2]string{"Cookie", strings.Join(cookies, "; ")})
+	}
+
+	if op.RequestBody != nil {
+		body, err := op.RequestBody.GetSpec(components)
+		if err != nil {
+			return nil, err
+		}
+		if mediaType := sampleMediaType(body.Spec.Content); mediaType != "" {
+			mt := body.Spec.Content[mediaType]
+			value, ok := mediaTypeSample(mt.Spec, components)
+			if !ok && isJSONMediaType(mediaType) {
+				value, ok = map[string]any{}, true
+			}
+			if ok {
+				req.headers = append(req.headers, [2]string{"Content-Type", mediaType})
+				if s, isString := value.(string); isString && !isJSONMediaType(mediaType) {
+					req.body = s
+				} else {
+					data, err := json.Marshal(value)
+					if err != nil {
+						return nil, fmt.Errorf("request body: %w", err)
+					}
+					req.body = string(data)
+				}
+			}
+		}
+	}
+	return req, nil
+}
+
+// sampleMediaType returns `application/json` or the first of the JSON media types or the first of other media types.
+func sampleMediaType(content map[string]*Extendable[MediaType]) string {
+	if mt := content["application/json"]; mt != nil && mt.Spec != nil {
+		return "application/json"
+	}
+	keys := sortedKeys(content)
+	if i := slices.IndexFunc(keys, isJSONMediaType); i >= 0 {
+		return keys[i]
+	}
+	for _, k := range keys {
+		if content[k] != nil && content[k].Spec != nil {
+			return k
+		}
+	}
+	return ""
+}
+
+// parameterSample returns the sample value of the parameter, ok is false for an optional parameter without examples.
+func parameterSample(p *Parameter, components *Extendable[Components]) (any, bool) {
+	if value, ok := exampleSample(p.Example, p.Examples, components); ok {
+		return value, true
+	}
+	var schema *Schema
+	if p.Schema != nil {
+		schema, _ = p.Schema.GetSpec(components)
+	}
+	if value, ok := schemaSample(schema); ok {
+		return value, true
+	}
+	if !p.Required {
+		return nil, false
+	}
+	return placeholderSample(p.Name, schema), true
+}
+
+func mediaTypeSample(mt *MediaType, components *Extendable[Components]) (any, bool) {
+	if value, ok := exampleSample(mt.Example, mt.Examples, components); ok {
+		return value, true
+	}
+	if mt.Schema == nil {
+		return nil, false
+	}
+	schema, _ := mt.Schema.GetSpec(components)
+	return schemaSample(schema)
+}
+
+// exampleSample returns the example or the value of the first of the examples by name.
+func exampleSample(example any, examples map[string]*RefOrSpec[Extendable[Example]], components *Extendable[Components]) (any, bool) {
+	if example != nil {
+		return example, true
+	}
+	for _, name := range sortedKeys(examples) {
+		e, err := examples[name].GetSpec(components)
+		if err == nil && e.Spec != nil && e.Spec.Value != nil {
+			return e.Spec.Value, true
+		}
+	}
+	return nil, false
+}
+
+// schemaSample returns the first of the examples, the default or the first of the enum values of the schema.
+func schemaSample(schema *Schema) (any, bool) {
+	switch {
+	case schema == nil:
+		return nil, false
+	case len(schema.Examples) > 0:
+		return schema.Examples[0], true
+	case schema.Default != nil:
+		return schema.Default, true
+	case schema.Const != nil:
+		return schema.Const, true
+	case len(schema.Enum) > 0:
+		return schema.Enum[0], true
+	}
+	return nil, false
+}
+
+// placeholderSample returns the value of the schema type: 1 for numbers, true for booleans,
+// and the given name otherwise.
+func placeholderSample(name string, schema *Schema) any {
+	if schema != nil {
+		switch schemaType(schema) {
+		case IntegerType, NumberType:
+			return 1
+		case BooleanType:
+			return true
+		case ArrayType:
+			return []any{name}
+		}
+	}
+	return name
+}
+
+func (r *sampleRequest) curl() string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+	lines := []string{"curl -X " + r.method + " " + quote(r.url)}
+	for _, h := range r.headers {
+		lines = append(lines, "  -H "+quote(h[0]+": "+h[1]))
+	}
+	if r.body != "" {
+		lines = append(lines, "  -d "+quote(r.body))
+	}
+	return strings.Join(lines, " \\\n")
+}
+
+func (r *sampleRequest) goSource() string {
+	var sb strings.Builder
+	body := "nil"
+	if r.body != "" {
+		literal := strconv.Quote(r.body)
+		if !strings.Contains(r.body, "`") && strconv.CanBackquote(r.body) {
+			literal = "`" + r.body + "`"
+		}
+		sb.WriteString("body := strings.NewReader(" + literal + ")\n")
+		body = "body"
+	}
+	method := strconv.Quote(r.method)
+	if m := goHTTPMethods[r.method]; m != "" {
+		method = "http." + m
+	}
+	sb.WriteString("req, err := http.NewRequest(" + method + ", " + strconv.Quote(r.url) + ", " + body + ")\n")
+	sb.WriteString("if err != nil {\n\tpanic(err)\n}\n")
+	for _, h := range r.headers {
+		sb.WriteString("req.Header.Set(" + strconv.Quote(h[0]) + ", " + strconv.Quote(h[1]) + ")\n")
+	}
+	sb.WriteString("resp, err := http.DefaultClient.Do(req)\n")
+	sb.WriteString("if err != nil {\n\tpanic(err)\n}\n")
+	sb.WriteString("defer resp.Body.Close()\n")
+	return sb.String()
+}
+
+// goHTTPMethods are the names of the constants of net/http package for the methods.
+var goHTTPMethods = map[string]string{
+	http.MethodGet:     "MethodGet",
+	http.MethodHead:    "MethodHead",
+	http.MethodPost:    "MethodPost",
+	http.MethodPut:     "MethodPut",
+	http.MethodPatch:   "MethodPatch",
+	http.MethodDelete:  "MethodDelete",
+	http.MethodOptions: "MethodOptions",
+	http.MethodTrace:   "MethodTrace",
+}
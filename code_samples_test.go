@@ -0,0 +1,235 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testCodeSamplesSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://{env}.example.com/v1
+    variables:
+      env:
+        default: api
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 20
+        - name: tag
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+    post:
+      parameters:
+        - $ref: '#/components/parameters/RequestID'
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+            examples:
+              dog:
+                value:
+                  name: Rex
+      responses:
+        "201":
+          description: Created
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    delete:
+      x-codeSamples:
+        - lang: Shell
+          source: curl -X DELETE https://api.example.com/v1/pets/1
+      responses:
+        "204":
+          description: Deleted
+components:
+  parameters:
+    RequestID:
+      name: X-Request-ID
+      in: header
+      required: true
+      example: it's-42
+      schema:
+        type: string
+`
+
+func TestGenerateCodeSamples(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testCodeSamplesSpec), &spec))
+
+	for _, tt := range []struct {
+		name   string
+		path   string
+		method string
+		curl   string
+		golang string
+	}{
+		{
+			name:   "optional parameters with defaults",
+			path:   "/pets",
+			method: http.MethodGet,
+			curl:   "curl -X GET 'https://api.example.com/v1/pets?limit=20'",
+			golang: "req, err := http.NewRequest(http.MethodGet, \"https://api.example.com/v1/pets?limit=20\", nil)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"resp, err := http.DefaultClient.Do(req)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"defer resp.Body.Close()\n",
+		},
+		{
+			name:   "header and body",
+			path:   "/pets",
+			method: "post",
+			curl: "curl -X POST 'https://api.example.com/v1/pets' \\\n" +
+				"  -H 'X-Request-ID: it'\\''s-42' \\\n" +
+				"  -H 'Content-Type: application/json' \\\n" +
+				"  -d '{\"name\":\"Rex\"}'",
+			golang: "body := strings.NewReader(`{\"name\":\"Rex\"}`)\n" +
+				"req, err := http.NewRequest(http.MethodPost, \"https://api.example.com/v1/pets\", body)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"req.Header.Set(\"X-Request-ID\", \"it's-42\")\n" +
+				"req.Header.Set(\"Content-Type\", \"application/json\")\n" +
+				"resp, err := http.DefaultClient.Do(req)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"defer resp.Body.Close()\n",
+		},
+		{
+			name:   "path parameter placeholder",
+			path:   "/pets/{id}",
+			method: http.MethodDelete,
+			curl:   "curl -X DELETE 'https://api.example.com/v1/pets/1'",
+			golang: "req, err := http.NewRequest(http.MethodDelete, \"https://api.example.com/v1/pets/1\", nil)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"resp, err := http.DefaultClient.Do(req)\n" +
+				"if err != nil {\n\tpanic(err)\n}\n" +
+				"defer resp.Body.Close()\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := openapi.GenerateCodeSamples(spec, tt.path, tt.method)
+			require.NoError(t, err)
+			require.Equal(t, []*openapi.CodeSample{
+				{Lang: "Shell", Label: "curl", Source: tt.curl},
+				{Lang: "Go", Source: tt.golang},
+			}, samples)
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := openapi.GenerateCodeSamples(spec, "/pets", http.MethodPut)
+		require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+		_, err = openapi.GenerateCodeSamples(spec, "/owners", http.MethodGet)
+		require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+	})
+}
+
+func TestPopulateCodeSamples(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testCodeSamplesSpec), &spec))
+	require.NoError(t, openapi.PopulateCodeSamples(spec))
+
+	pets := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec
+	samples, ok, err := openapi.CodeSamplesOf(pets.Post)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, samples, 2)
+	require.Equal(t, "Go", samples[1].Lang)
+
+	// the existing samples are kept
+	deletion := spec.Spec.Paths.Spec.Paths["/pets/{id}"].Spec.Spec.Delete
+	samples, ok, err = openapi.CodeSamplesOf(deletion)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []*openapi.CodeSample{
+		{Lang: "Shell", Source: "curl -X DELETE https://api.example.com/v1/pets/1"},
+	}, samples)
+
+	// the generated samples survive the round trip
+	data, err := yaml.Marshal(spec)
+	require.NoError(t, err)
+	var decoded *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal(data, &decoded))
+	samples, ok, err = openapi.CodeSamplesOf(decoded.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "curl -X GET 'https://api.example.com/v1/pets?limit=20'", samples[0].Source)
+}
+
+func TestCodeSamplesOf(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		value    any
+		expected []*openapi.CodeSample
+		err      error
+	}{
+		{
+			name:     "typed",
+			value:    []*openapi.CodeSample{{Lang: "Go", Source: "x"}},
+			expected: []*openapi.CodeSample{{Lang: "Go", Source: "x"}},
+		},
+		{
+			name:     "decoded",
+			value:    []any{map[string]any{"lang": "Go", "label": "net/http", "source": "x"}},
+			expected: []*openapi.CodeSample{{Lang: "Go", Label: "net/http", Source: "x"}},
+		},
+		{
+			name:  "invalid format",
+			value: "curl",
+			err:   openapi.ErrInvalidFormat,
+		},
+		{
+			name:  "missing source",
+			value: []any{map[string]any{"lang": "Go"}},
+			err:   openapi.ErrInvalidValue,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			op := openapi.NewOperationBuilder().Build()
+			op.AddExt(openapi.CodeSamplesExt, tt.value)
+			samples, ok, err := openapi.CodeSamplesOf(op)
+			require.True(t, ok)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, samples)
+		})
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		_, ok, err := openapi.CodeSamplesOf(openapi.NewOperationBuilder().Build())
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("builder", func(t *testing.T) {
+		op := openapi.NewOperationBuilder().CodeSamples(&openapi.CodeSample{Lang: "Go", Source: "x"}).Build()
+		samples, ok, err := openapi.CodeSamplesOf(op)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []*openapi.CodeSample{{Lang: "Go", Source: "x"}}, samples)
+	})
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// hasAdditionalProperties reports whether the additional properties of the object schema are allowed explicitly,
+// so they are kept in a map field of the generated struct.
+func hasAdditionalProperties(schema *openapi.Schema) bool {
+	return schema.AdditionalProperties != nil && (schema.AdditionalProperties.Allowed || schema.AdditionalProperties.Schema != nil)
+}
+
+// genAdditionalPropertiesMethods generates the JSON methods of the struct splitting the known and unknown properties,
+// the same way as the Extendable type of the openapi package does for the extensions.
+func (g *generator) genAdditionalPropertiesMethods(buf *bytes.Buffer, name, field, typ string, known []string) {
+	g.imports["encoding/json"] = true
+	g.imports["fmt"] = true
+
+	buf.WriteString("// MarshalJSON implements json.Marshaler interface.\n")
+	fmt.Fprintf(buf, "func (o %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(buf, "\ttype plain %s\n", name)
+	fmt.Fprintf(buf, "\traw := make(map[string]json.RawMessage, len(o.%s))\n", field)
+	fmt.Fprintf(buf, "\tfor name, value := range o.%s {\n", field)
+	buf.WriteString("\t\tdata, err := json.Marshal(value)\n\t\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"%s.%s.%%s: %%w\", name, err)\n\t\t}\n\t\traw[name] = data\n\t}\n", name, field)
+	buf.WriteString("\tfields, err := json.Marshal(plain(o))\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	buf.WriteString("\t// the known properties take precedence over the additional ones with the same names\n")
+	buf.WriteString("\tif err := json.Unmarshal(fields, &raw); err != nil {\n\t\treturn nil, err\n\t}\n")
+	buf.WriteString("\treturn json.Marshal(raw)\n}\n\n")
+
+	quoted := make([]string, len(known))
+	for i, prop := range known {
+		quoted[i] = strconv.Quote(prop)
+	}
+	buf.WriteString("// UnmarshalJSON implements json.Unmarshaler interface.\n")
+	fmt.Fprintf(buf, "func (o *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(buf, "\ttype plain %s\n", name)
+	buf.WriteString("\tif err := json.Unmarshal(data, (*plain)(o)); err != nil {\n\t\treturn err\n\t}\n")
+	buf.WriteString("\tvar raw map[string]json.RawMessage\n\tif err := json.Unmarshal(data, &raw); err != nil {\n\t\treturn err\n\t}\n")
+	fmt.Fprintf(buf, "\tfor _, name := range []string{%s} {\n\t\tdelete(raw, name)\n\t}\n", strings.Join(quoted, ", "))
+	fmt.Fprintf(buf, "\to.%s = nil\n\tif len(raw) > 0 {\n\t\to.%s = make(map[string]%s, len(raw))\n\t}\n", field, field, typ)
+	buf.WriteString("\tfor name, value := range raw {\n")
+	fmt.Fprintf(buf, "\t\tvar v %s\n\t\tif err := json.Unmarshal(value, &v); err != nil {\n", typ)
+	fmt.Fprintf(buf, "\t\t\treturn fmt.Errorf(\"%s.%s.%%s: %%w\", name, err)\n\t\t}\n", name, field)
+	fmt.Fprintf(buf, "\t\to.%s[name] = v\n\t}\n\treturn nil\n}\n", field)
+}
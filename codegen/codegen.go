@@ -0,0 +1,352 @@
+// Package codegen generates Go types for the schemas of an OpenAPI specification.
+package codegen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sv-tools/openapi"
+)
+
+const schemasRefPrefix = "#/components/schemas/"
+
+type options struct {
+	packageName string
+	unions      UnionStrategy
+}
+
+// Option is a type for code generation options.
+type Option func(*options)
+
+// PackageName is a code generation option to set the name of the package of the generated file, `api` by default.
+func PackageName(name string) Option {
+	return func(o *options) {
+		o.packageName = name
+	}
+}
+
+// Generate generates the Go types for the schemas of the components of the given spec
+// and returns the formatted source code of a Go file.
+//
+// The types are mapped as follows:
+//
+//   - the objects with properties become the structs, the optional and nullable fields are pointers,
+//     the additional properties, if allowed explicitly, are kept in a map field;
+//   - the schemas with the string or integer enums become the typed enums, see EnumVarNamesExt;
+//   - the `oneOf` and `anyOf` schemas become the unions, see UnionStrategy;
+//   - the arrays become the slices and the other objects become the maps;
+//   - the references to the schemas of the components become the names of their types;
+//   - the inline objects, enums, and unions become the separate types named after their parents;
+//   - everything else becomes `any`.
+func Generate(spec *openapi.Extendable[openapi.OpenAPI], opts ...Option) ([]byte, error) {
+	o := &options{
+		packageName: "api",
+		unions:      UnionRaw,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !o.unions.isValid() {
+		return nil, fmt.Errorf("invalid union strategy: %q", o.unions)
+	}
+	g := &generator{
+		opts:    o,
+		imports: make(map[string]bool),
+		names:   make(map[string]bool),
+	}
+	var schemas map[string]*openapi.RefOrSpec[openapi.Schema]
+	if spec != nil && spec.Spec != nil && spec.Spec.Components != nil && spec.Spec.Components.Spec != nil {
+		schemas = spec.Spec.Components.Spec.Schemas
+	}
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+		// the types of the components are reserved before the inline types are named
+		g.names[goName(name)] = true
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		g.genType(goName(name), schemas[name])
+	}
+	if err := errors.Join(g.errs...); err != nil {
+		return nil, err
+	}
+	return g.source()
+}
+
+type generator struct {
+	opts    *options
+	imports map[string]bool
+	names   map[string]bool
+	decls   []string
+	errs    []error
+}
+
+// source returns the formatted source code of the generated declarations.
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by github.com/sv-tools/openapi/codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.opts.packageName)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		slices.Sort(imports)
+		buf.WriteString("import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&buf, "\t%q\n", imp)
+		}
+		buf.WriteString(")\n\n")
+	}
+	for _, decl := range g.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// uniqueName returns the given name or the name with a numeric suffix, if the name is already used.
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+// genType generates the declaration of the named type for the given schema.
+func (g *generator) genType(name string, ref *openapi.RefOrSpec[openapi.Schema]) {
+	// the slot is reserved, so the declarations of the inline types follow the declaration of their parent
+	idx := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	var buf bytes.Buffer
+	switch {
+	case ref == nil || ref.Spec == nil:
+		writeDoc(&buf, name, "")
+		fmt.Fprintf(&buf, "type %s = %s\n", name, g.typeExpr(name, ref))
+	case isEnum(ref.Spec):
+		g.genEnum(&buf, name, ref.Spec)
+	case isUnion(ref.Spec):
+		g.genUnion(&buf, name, ref.Spec)
+	case isStruct(ref.Spec):
+		g.genStruct(&buf, name, ref.Spec)
+	default:
+		writeDoc(&buf, name, ref.Spec.Description)
+		fmt.Fprintf(&buf, "type %s %s\n", name, g.baseType(name, ref.Spec))
+	}
+	g.decls[idx] = buf.String()
+}
+
+// genStruct generates the struct for the object schema with properties.
+func (g *generator) genStruct(buf *bytes.Buffer, name string, schema *openapi.Schema) {
+	writeDoc(buf, name, schema.Description)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	fields := make(map[string]bool, len(schema.Properties))
+	for _, prop := range schema.OrderedProperties() {
+		field := goName(prop)
+		for i := 2; fields[field]; i++ {
+			field = goName(prop) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		ref := schema.Properties[prop]
+		typ := g.typeExpr(name+field, ref)
+		required := slices.Contains(schema.Required, prop)
+		if (!required || isNullable(ref)) && !isReferenceType(typ) {
+			typ = "*" + typ
+		}
+		tag := prop
+		if !required {
+			tag += ",omitempty"
+		}
+		if ref != nil && ref.Spec != nil {
+			writeComment(buf, "\t", ref.Spec.Description)
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+	if !hasAdditionalProperties(schema) {
+		buf.WriteString("}\n")
+		return
+	}
+	field := "AdditionalProperties"
+	for i := 2; fields[field]; i++ {
+		field = "AdditionalProperties" + strconv.Itoa(i)
+	}
+	typ := g.typeExpr(name+"Value", schema.AdditionalProperties.Schema)
+	buf.WriteString("\t// The properties not defined by the schema.\n")
+	fmt.Fprintf(buf, "\t%s map[string]%s `json:\"-\"`\n}\n\n", field, typ)
+	g.genAdditionalPropertiesMethods(buf, name, field, typ, schema.OrderedProperties())
+}
+
+// typeExpr returns the type expression for the given schema used as a field, an item, or a value,
+// the inline objects, enums, and unions are generated as separate types with the given name.
+func (g *generator) typeExpr(name string, ref *openapi.RefOrSpec[openapi.Schema]) string {
+	if ref == nil {
+		return "any"
+	}
+	if ref.Ref != nil {
+		if target, ok := strings.CutPrefix(ref.Ref.Ref, schemasRefPrefix); ok {
+			return goName(target)
+		}
+		return "any"
+	}
+	if ref.Spec == nil {
+		return "any"
+	}
+	if target, ok := strings.CutPrefix(ref.Spec.Ref, schemasRefPrefix); ok {
+		// the sibling keywords of the reference only narrow the referenced type
+		return goName(target)
+	}
+	if isEnum(ref.Spec) || isUnion(ref.Spec) || isStruct(ref.Spec) {
+		name = g.uniqueName(name)
+		g.genType(name, ref)
+		return name
+	}
+	return g.baseType(name, ref.Spec)
+}
+
+// baseType returns the type expression for the given schema by its type and format.
+func (g *generator) baseType(name string, schema *openapi.Schema) string {
+	switch schemaType(schema) {
+	case openapi.StringType:
+		switch schema.Format {
+		case openapi.DateTimeFormat:
+			g.imports["time"] = true
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case openapi.IntegerType:
+		if schema.Format == openapi.Int32Format {
+			return "int32"
+		}
+		return "int64"
+	case openapi.NumberType:
+		if schema.Format == openapi.FloatFormat {
+			return "float32"
+		}
+		return "float64"
+	case openapi.BooleanType:
+		return "bool"
+	case openapi.ArrayType:
+		var items *openapi.RefOrSpec[openapi.Schema]
+		if schema.Items != nil {
+			items = schema.Items.Schema
+		}
+		return "[]" + g.typeExpr(name+"Item", items)
+	case openapi.ObjectType:
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + g.typeExpr(name+"Value", schema.AdditionalProperties.Schema)
+		}
+		return "map[string]any"
+	}
+	return "any"
+}
+
+// schemaType returns the single non-null type of the schema or an empty string.
+func schemaType(schema *openapi.Schema) string {
+	if schema.Type == nil {
+		if len(schema.Properties) > 0 {
+			return openapi.ObjectType
+		}
+		return ""
+	}
+	var typ string
+	for _, t := range *schema.Type {
+		if t == openapi.NullType {
+			continue
+		}
+		if typ != "" {
+			return ""
+		}
+		typ = t
+	}
+	return typ
+}
+
+func isStruct(schema *openapi.Schema) bool {
+	return schemaType(schema) == openapi.ObjectType && len(schema.Properties) > 0
+}
+
+func isNullable(ref *openapi.RefOrSpec[openapi.Schema]) bool {
+	return ref != nil && ref.Spec != nil && ref.Spec.Type != nil && slices.Contains(*ref.Spec.Type, openapi.NullType)
+}
+
+// isReferenceType reports whether the zero value of the type is nil, so it does not need to be a pointer.
+func isReferenceType(typ string) bool {
+	return typ == "any" || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "*")
+}
+
+func writeDoc(buf *bytes.Buffer, name, description string) {
+	if description == "" {
+		fmt.Fprintf(buf, "// %s is generated from the OpenAPI schema.\n", name)
+		return
+	}
+	writeComment(buf, "", name+" is "+lowerFirst(description))
+}
+
+func writeComment(buf *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(buf, "%s// %s\n", indent, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 1 && unicode.IsUpper(r[0]) && !unicode.IsUpper(r[1]) {
+		r[0] = unicode.ToLower(r[0])
+	}
+	return string(r)
+}
+
+var initialisms = map[string]string{
+	"api":  "API",
+	"http": "HTTP",
+	"id":   "ID",
+	"ip":   "IP",
+	"json": "JSON",
+	"uri":  "URI",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+// goName returns the exported Go identifier for the given name, e.g. `UserID` for `user_id`.
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if s, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(s)
+			continue
+		}
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	s := b.String()
+	if s == "" {
+		return "Value"
+	}
+	if unicode.IsDigit([]rune(s)[0]) {
+		return "N" + s
+	}
+	return s
+}
@@ -0,0 +1,159 @@
+package codegen_test
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/codegen"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal(data, &spec))
+
+			src, err := codegen.Generate(spec, codegen.PackageName(name))
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", name+".go.golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, src, 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(src))
+		})
+	}
+}
+
+func TestGenerate_Empty(t *testing.T) {
+	src, err := codegen.Generate(nil)
+	require.NoError(t, err)
+	require.Equal(t, "// Code generated by github.com/sv-tools/openapi/codegen. DO NOT EDIT.\n\npackage api\n", string(src))
+}
+
+func TestGenerate_InvalidUnionStrategy(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ext  any
+		opts []codegen.Option
+		err  string
+	}{
+		{
+			name: "option",
+			opts: []codegen.Option{codegen.Unions("pointers")},
+			err:  `invalid union strategy: "pointers"`,
+		},
+		{
+			name: "extension",
+			ext:  "pointers",
+			err:  "Value: invalid value of x-go-union extension: pointers",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := openapi.NewSchemaBuilder().
+				OneOf(
+					openapi.NewSchemaBuilder().Type(openapi.StringType).Build(),
+					openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build(),
+				).
+				Build()
+			if tt.ext != nil {
+				schema.Spec.AddExt(codegen.UnionStrategyExt, tt.ext)
+			}
+			spec := openapi.NewOpenAPIBuilder().AddComponent("value", schema).Build()
+
+			_, err := codegen.Generate(spec, tt.opts...)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+const roundTripMain = `package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func marshal(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func main() {
+	var metrics Metrics
+	if err := json.Unmarshal([]byte(` + "`" + `{"name":"cpu","AdditionalProperties":true,"load":0.5,"max":2}` + "`" + `), &metrics); err != nil {
+		panic(err)
+	}
+	fmt.Println(metrics.Name, *metrics.AdditionalProperties, metrics.AdditionalProperties2)
+	fmt.Println(marshal(metrics))
+
+	var doc Document
+	if err := json.Unmarshal([]byte(` + "`" + `{"title":"t","meta":{"version":2,"a":{"value":"x"}},"extra":[1,"b"]}` + "`" + `), &doc); err != nil {
+		panic(err)
+	}
+	fmt.Println(*doc.Title, *doc.Meta.Version, *doc.Meta.AdditionalProperties["a"].Value, doc.AdditionalProperties)
+	fmt.Println(marshal(doc))
+
+	// the known properties take precedence over the additional ones
+	fmt.Println(marshal(Metrics{Name: "cpu", AdditionalProperties2: map[string]float64{"name": 1, "load": 0.5}}))
+	fmt.Println(marshal(Metrics{Name: "cpu"}))
+}
+`
+
+func TestGenerate_AdditionalPropertiesRoundTrip(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated code")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is not available")
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "additional.yaml"))
+	require.NoError(t, err)
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+	src, err := codegen.Generate(spec, codegen.PackageName("main"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module roundtrip\n\ngo 1.21\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.go"), src, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(roundTripMain), 0o644))
+
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.Equal(t, strings.Join([]string{
+		"cpu true map[load:0.5 max:2]",
+		`{"AdditionalProperties":true,"load":0.5,"max":2,"name":"cpu"}`,
+		"t 2 x map[extra:[1 b]]",
+		`{"extra":[1,"b"],"meta":{"a":{"value":"x"},"version":2},"title":"t"}`,
+		`{"load":0.5,"name":"cpu"}`,
+		`{"name":"cpu"}`,
+	}, "\n")+"\n", string(out))
+}
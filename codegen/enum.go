@@ -0,0 +1,192 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// EnumVarNamesExt is the name of the schema extension holding the names of the constants of the enum values,
+// in the same order as the values.
+// The names are prefixed with the name of the type, by default the names are made of the values.
+//
+// Example:
+//
+//	type: integer
+//	enum: [1, 2]
+//	x-enum-varnames: [Low, High]
+const EnumVarNamesExt = "x-enum-varnames"
+
+// isEnum reports whether the schema is a string or integer enum, which is generated as a typed enum.
+// The enums of other types are generated as the plain base types.
+func isEnum(schema *openapi.Schema) bool {
+	if len(schema.Enum) == 0 {
+		return false
+	}
+	switch enumType(schema) {
+	case openapi.StringType, openapi.IntegerType:
+		return true
+	}
+	return false
+}
+
+// enumType returns the type of the enum by its schema type or by its values.
+func enumType(schema *openapi.Schema) string {
+	if typ := schemaType(schema); typ != "" {
+		for _, v := range schema.Enum {
+			if !enumValueOfType(typ, v) {
+				return ""
+			}
+		}
+		return typ
+	}
+	for _, typ := range []string{openapi.StringType, openapi.IntegerType} {
+		matched := true
+		for _, v := range schema.Enum {
+			if !enumValueOfType(typ, v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return typ
+		}
+	}
+	return ""
+}
+
+func enumValueOfType(typ string, v any) bool {
+	switch typ {
+	case openapi.StringType:
+		_, ok := v.(string)
+		return ok
+	case openapi.IntegerType:
+		_, ok := enumInt(v)
+		return ok
+	}
+	return false
+}
+
+// enumInt returns the integer value of the enum value decoded from JSON or YAML.
+func enumInt(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint64:
+		if n > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case float64:
+		if n != math.Trunc(n) || n < math.MinInt64 || n > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+type enumValue struct {
+	name    string
+	literal string
+}
+
+// enumValues returns the names of the constants and the Go literals of the enum values.
+func enumValues(name string, schema *openapi.Schema) []enumValue {
+	var varNames []string
+	if list, ok := schema.GetExt(EnumVarNamesExt).([]any); ok && len(list) == len(schema.Enum) {
+		for _, v := range list {
+			if s, ok := v.(string); ok {
+				varNames = append(varNames, name+goName(s))
+			}
+		}
+	}
+	if len(varNames) != len(schema.Enum) {
+		varNames = nil
+	}
+
+	used := make(map[string]bool, len(schema.Enum))
+	values := make([]enumValue, 0, len(schema.Enum))
+	for i, v := range schema.Enum {
+		var val enumValue
+		if s, ok := v.(string); ok {
+			val.name = name + "Empty"
+			if s != "" {
+				val.name = name + goName(s)
+			}
+			val.literal = strconv.Quote(s)
+		} else {
+			n, _ := enumInt(v)
+			val.name = name + strings.Replace(strconv.FormatInt(n, 10), "-", "Minus", 1)
+			val.literal = strconv.FormatInt(n, 10)
+		}
+		if varNames != nil {
+			val.name = varNames[i]
+		}
+		unique := val.name
+		for j := 2; used[unique]; j++ {
+			unique = val.name + strconv.Itoa(j)
+		}
+		used[unique] = true
+		val.name = unique
+		values = append(values, val)
+	}
+	return values
+}
+
+// genEnum generates the typed enum with the constants of its values, the `All<Type>Values` function,
+// and the methods validating the values on JSON marshalling and unmarshalling.
+func (g *generator) genEnum(buf *bytes.Buffer, name string, schema *openapi.Schema) {
+	g.imports["encoding/json"] = true
+	g.imports["fmt"] = true
+
+	base, verb, str := "string", "%q", "string(v)"
+	if enumType(schema) == openapi.IntegerType {
+		g.imports["strconv"] = true
+		base, verb, str = g.baseType(name, &openapi.Schema{Type: openapi.NewSingleOrArray(openapi.IntegerType), Format: schema.Format}), "%d", "strconv.FormatInt(int64(v), 10)"
+	}
+	values := enumValues(name, schema)
+
+	writeDoc(buf, name, schema.Description)
+	fmt.Fprintf(buf, "type %s %s\n\n", name, base)
+
+	fmt.Fprintf(buf, "// The values of %s.\nconst (\n", name)
+	for _, v := range values {
+		fmt.Fprintf(buf, "\t%s %s = %s\n", v.name, name, v.literal)
+	}
+	buf.WriteString(")\n\n")
+
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.name
+	}
+	fmt.Fprintf(buf, "// All%[1]sValues returns all values of %[1]s.\n", name)
+	fmt.Fprintf(buf, "func All%[1]sValues() []%[1]s {\n\treturn []%[1]s{%[2]s}\n}\n\n", name, strings.Join(names, ", "))
+
+	buf.WriteString("// String implements fmt.Stringer interface.\n")
+	fmt.Fprintf(buf, "func (v %s) String() string {\n\treturn %s\n}\n\n", name, str)
+
+	fmt.Fprintf(buf, "// IsValid reports whether the value is one of the values of %s.\n", name)
+	fmt.Fprintf(buf, "func (v %s) IsValid() bool {\n\tswitch v {\n\tcase %s:\n\t\treturn true\n\t}\n\treturn false\n}\n\n", name, strings.Join(names, ", "))
+
+	buf.WriteString("// MarshalJSON implements json.Marshaler interface.\n")
+	fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(buf, "\tif !v.IsValid() {\n\t\treturn nil, fmt.Errorf(\"invalid value of %s: %s\", %s(v))\n\t}\n", name, verb, base)
+	fmt.Fprintf(buf, "\treturn json.Marshal(%s(v))\n}\n\n", base)
+
+	buf.WriteString("// UnmarshalJSON implements json.Unmarshaler interface.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(buf, "\tvar value %s\n\tif err := json.Unmarshal(data, &value); err != nil {\n\t\treturn err\n\t}\n", base)
+	fmt.Fprintf(buf, "\tif !%s(value).IsValid() {\n\t\treturn fmt.Errorf(\"invalid value of %s: %s\", value)\n\t}\n", name, name, verb)
+	fmt.Fprintf(buf, "\t*v = %s(value)\n\treturn nil\n}\n", name)
+}
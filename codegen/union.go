@@ -0,0 +1,275 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// UnionStrategy is a representation of the `oneOf` and `anyOf` schemas in the generated Go types.
+type UnionStrategy string
+
+const (
+	// UnionRaw represents a union as json.RawMessage, so the value is passed through as is.
+	UnionRaw UnionStrategy = "raw"
+	// UnionStruct represents a union as a struct with a pointer field per variant,
+	// the fields of all variants matching the value are set on unmarshalling,
+	// or the field of the variant selected by the discriminator.
+	UnionStruct UnionStrategy = "struct"
+	// UnionInterface represents a union as a struct holding a value of an interface implemented by the variants,
+	// the first variant matching the value is used on unmarshalling, or the variant selected by the discriminator.
+	UnionInterface UnionStrategy = "interface"
+)
+
+// UnionStrategyExt is the name of the schema extension selecting the union strategy for the schema,
+// overriding the one set by the Unions option.
+//
+// Example:
+//
+//	Pet:
+//	  oneOf:
+//	    - $ref: '#/components/schemas/Cat'
+//	    - $ref: '#/components/schemas/Dog'
+//	  x-go-union: interface
+const UnionStrategyExt = "x-go-union"
+
+// Unions is a code generation option to set the union strategy for all schemas, UnionRaw by default.
+func Unions(strategy UnionStrategy) Option {
+	return func(o *options) {
+		o.unions = strategy
+	}
+}
+
+func (s UnionStrategy) isValid() bool {
+	switch s {
+	case UnionRaw, UnionStruct, UnionInterface:
+		return true
+	}
+	return false
+}
+
+// isUnion reports whether the schema is a `oneOf` or `anyOf` schema without own properties.
+func isUnion(schema *openapi.Schema) bool {
+	return len(schema.OneOf)+len(schema.AnyOf) > 0 && len(schema.Properties) == 0
+}
+
+type unionVariant struct {
+	// field is the name of the variant in the union struct.
+	field string
+	typ   string
+	// values are the values of the discriminator property selecting the variant.
+	values []string
+}
+
+// unionVariants returns the variants of the union, the inline variants are generated as separate types
+// if the named is true or if they are objects or enums.
+func (g *generator) unionVariants(name string, schema *openapi.Schema, named bool) []*unionVariant {
+	refs := append(slices.Clip(schema.OneOf), schema.AnyOf...)
+	variants := make([]*unionVariant, 0, len(refs))
+	fields := make(map[string]bool, len(refs))
+	byComponent := make(map[string]*unionVariant, len(refs))
+	for i, ref := range refs {
+		v := &unionVariant{field: variantName(i, ref)}
+		for j := 2; fields[v.field]; j++ {
+			v.field = variantName(i, ref) + strconv.Itoa(j)
+		}
+		fields[v.field] = true
+
+		if target, ok := componentName(ref); ok {
+			v.typ = goName(target)
+			byComponent[target] = v
+		} else if named && ref != nil && ref.Ref == nil {
+			v.typ = g.uniqueName(name + v.field)
+			g.genType(v.typ, ref)
+		} else {
+			v.typ = g.typeExpr(name+v.field, ref)
+		}
+		variants = append(variants, v)
+	}
+
+	if schema.Discriminator == nil {
+		return variants
+	}
+	mapped := make(map[*unionVariant]bool, len(schema.Discriminator.Mapping))
+	for _, value := range sortedKeys(schema.Discriminator.Mapping) {
+		target := strings.TrimPrefix(schema.Discriminator.Mapping[value], schemasRefPrefix)
+		if v, ok := byComponent[target]; ok {
+			v.values = append(v.values, value)
+			mapped[v] = true
+		}
+	}
+	// the variants without explicit mapping are selected by the names of their schemas
+	for target, v := range byComponent {
+		if !mapped[v] {
+			v.values = append(v.values, target)
+		}
+	}
+	return variants
+}
+
+// variantName returns the name of the variant by its reference, title, or type.
+func variantName(i int, ref *openapi.RefOrSpec[openapi.Schema]) string {
+	if target, ok := componentName(ref); ok {
+		return goName(target)
+	}
+	if ref != nil && ref.Spec != nil {
+		if ref.Spec.Title != "" {
+			return goName(ref.Spec.Title)
+		}
+		switch typ := schemaType(ref.Spec); typ {
+		case openapi.StringType, openapi.IntegerType, openapi.NumberType, openapi.BooleanType:
+			return goName(typ)
+		}
+	}
+	return "Variant" + strconv.Itoa(i+1)
+}
+
+// componentName returns the name of the schema of the components referenced by the given reference.
+func componentName(ref *openapi.RefOrSpec[openapi.Schema]) (string, bool) {
+	if ref == nil || ref.Ref == nil {
+		return "", false
+	}
+	return strings.CutPrefix(ref.Ref.Ref, schemasRefPrefix)
+}
+
+// unionStrategy returns the strategy selected by the extension of the schema or by the options.
+func (g *generator) unionStrategy(name string, schema *openapi.Schema) UnionStrategy {
+	v := schema.GetExt(UnionStrategyExt)
+	if v == nil {
+		return g.opts.unions
+	}
+	s, _ := v.(string)
+	strategy := UnionStrategy(s)
+	if !strategy.isValid() {
+		g.errs = append(g.errs, fmt.Errorf("%s: invalid value of %s extension: %v", name, UnionStrategyExt, v))
+		return g.opts.unions
+	}
+	return strategy
+}
+
+// genUnion generates the union type using the strategy selected for the schema.
+func (g *generator) genUnion(buf *bytes.Buffer, name string, schema *openapi.Schema) {
+	g.imports["encoding/json"] = true
+	switch g.unionStrategy(name, schema) {
+	case UnionStruct:
+		g.genUnionStruct(buf, name, schema)
+	case UnionInterface:
+		g.genUnionInterface(buf, name, schema)
+	default:
+		writeDoc(buf, name, schema.Description)
+		fmt.Fprintf(buf, "type %s = json.RawMessage\n", name)
+	}
+}
+
+func (g *generator) genUnionStruct(buf *bytes.Buffer, name string, schema *openapi.Schema) {
+	g.imports["fmt"] = true
+	variants := g.unionVariants(name, schema, false)
+
+	writeDoc(buf, name, schema.Description)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, v := range variants {
+		typ := v.typ
+		if !strings.HasPrefix(typ, "*") {
+			typ = "*" + typ
+		}
+		fmt.Fprintf(buf, "\t%s %s\n", v.field, typ)
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// MarshalJSON implements json.Marshaler interface, the first set variant is marshaled.\n")
+	fmt.Fprintf(buf, "func (u %s) MarshalJSON() ([]byte, error) {\n\tswitch {\n", name)
+	for _, v := range variants {
+		fmt.Fprintf(buf, "\tcase u.%[1]s != nil:\n\t\treturn json.Marshal(u.%[1]s)\n", v.field)
+	}
+	buf.WriteString("\t}\n\treturn []byte(\"null\"), nil\n}\n\n")
+
+	buf.WriteString("// UnmarshalJSON implements json.Unmarshaler interface.\n")
+	fmt.Fprintf(buf, "func (u *%[1]s) UnmarshalJSON(data []byte) error {\n\t*u = %[1]s{}\n", name)
+	if schema.Discriminator != nil {
+		writeDiscriminatorSwitch(buf, name, schema.Discriminator.PropertyName, variants, func(v *unionVariant) {
+			fmt.Fprintf(buf, "\t\treturn json.Unmarshal(data, &u.%s)\n", v.field)
+		})
+		buf.WriteString("}\n")
+		return
+	}
+	for _, v := range variants {
+		fmt.Fprintf(buf, "\tif err := json.Unmarshal(data, &u.%[1]s); err != nil {\n\t\tu.%[1]s = nil\n\t}\n", v.field)
+	}
+	conds := make([]string, len(variants))
+	for i, v := range variants {
+		conds[i] = "u." + v.field + " == nil"
+	}
+	fmt.Fprintf(buf, "\tif %s {\n\t\treturn fmt.Errorf(\"no variant of %s matches the value\")\n\t}\n\treturn nil\n}\n", strings.Join(conds, " && "), name)
+}
+
+func (g *generator) genUnionInterface(buf *bytes.Buffer, name string, schema *openapi.Schema) {
+	g.imports["fmt"] = true
+	variants := g.unionVariants(name, schema, true)
+	iface := g.uniqueName(name + "Value")
+	marker := "is" + iface
+
+	writeDoc(buf, name, schema.Description)
+	fmt.Fprintf(buf, "type %s struct {\n\tValue %s\n}\n\n", name, iface)
+
+	types := make([]string, len(variants))
+	for i, v := range variants {
+		types[i] = v.typ
+	}
+	fmt.Fprintf(buf, "// %s is implemented by the variants of %s: %s.\n", iface, name, strings.Join(types, ", "))
+	fmt.Fprintf(buf, "type %s interface {\n\t%s()\n}\n\n", iface, marker)
+	for _, v := range variants {
+		fmt.Fprintf(buf, "func (%s) %s() {}\n\n", v.typ, marker)
+	}
+
+	buf.WriteString("// MarshalJSON implements json.Marshaler interface.\n")
+	fmt.Fprintf(buf, "func (u %s) MarshalJSON() ([]byte, error) {\n\treturn json.Marshal(u.Value)\n}\n\n", name)
+
+	buf.WriteString("// UnmarshalJSON implements json.Unmarshaler interface.\n")
+	fmt.Fprintf(buf, "func (u *%s) UnmarshalJSON(data []byte) error {\n\tu.Value = nil\n", name)
+	if schema.Discriminator != nil {
+		writeDiscriminatorSwitch(buf, name, schema.Discriminator.PropertyName, variants, func(v *unionVariant) {
+			fmt.Fprintf(buf, "\t\tvar v %s\n\t\tif err := json.Unmarshal(data, &v); err != nil {\n\t\t\treturn err\n\t\t}\n\t\tu.Value = v\n\t\treturn nil\n", v.typ)
+		})
+		buf.WriteString("}\n")
+		return
+	}
+	for _, v := range variants {
+		fmt.Fprintf(buf, "\t{\n\t\tvar v %s\n\t\tif err := json.Unmarshal(data, &v); err == nil {\n\t\t\tu.Value = v\n\t\t\treturn nil\n\t\t}\n\t}\n", v.typ)
+	}
+	fmt.Fprintf(buf, "\treturn fmt.Errorf(\"no variant of %s matches the value\")\n}\n", name)
+}
+
+// writeDiscriminatorSwitch writes the body of UnmarshalJSON method selecting the variant by the discriminator property,
+// the variants without the discriminator values are never selected.
+func writeDiscriminatorSwitch(buf *bytes.Buffer, name, property string, variants []*unionVariant, body func(v *unionVariant)) {
+	fmt.Fprintf(buf, "\tvar d struct {\n\t\tValue string `json:%q`\n\t}\n", property)
+	buf.WriteString("\tif err := json.Unmarshal(data, &d); err != nil {\n\t\treturn err\n\t}\n")
+	buf.WriteString("\tswitch d.Value {\n")
+	for _, v := range variants {
+		if len(v.values) == 0 {
+			continue
+		}
+		values := slices.Clone(v.values)
+		slices.Sort(values)
+		for i, value := range values {
+			values[i] = strconv.Quote(value)
+		}
+		fmt.Fprintf(buf, "\tcase %s:\n", strings.Join(values, ", "))
+		body(v)
+	}
+	fmt.Fprintf(buf, "\t}\n\treturn fmt.Errorf(\"unknown %s of %s: %%q\", d.Value)\n", property, name)
+}
+
+// sortedKeys returns the sorted keys of the given map.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Comments are the comments of a YAML source of a spec by the locations of the commented objects.
+//
+// The comments are dropped when a spec is unmarshaled, Comments restores them when the spec is written back,
+// so the hand-written specs can be loaded, modified programmatically and saved without losing the annotations;
+// the comments of the removed objects are dropped.
+//
+// Example:
+//
+//	var spec *openapi.Extendable[openapi.OpenAPI]
+//	err := yaml.Unmarshal(data, &spec)
+//	... // modify the spec
+//	data, err = openapi.MarshalPreserved(spec, data)
+type Comments struct {
+	nodes map[string]*nodeComments
+	// document are the comments of the document itself, e.g. a license header
+	document nodeComments
+}
+
+// nodeComments are the comments of a YAML node and of its key, if the node is a value of a mapping.
+type nodeComments struct {
+	keyHead, keyLine, keyFoot string
+	head, line, foot          string
+}
+
+func (c *nodeComments) isEmpty() bool {
+	return *c == nodeComments{}
+}
+
+// NewComments parses the given YAML source of a spec and records its comments.
+func NewComments(data []byte) (*Comments, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w of source: %w", ErrInvalidFormat, err)
+	}
+	c := &Comments{
+		nodes:    make(map[string]*nodeComments),
+		document: nodeComments{head: doc.HeadComment, line: doc.LineComment, foot: doc.FootComment},
+	}
+	if len(doc.Content) > 0 {
+		c.add("", nil, doc.Content[0], 0)
+	}
+	return c, nil
+}
+
+// MarshalPreserved marshals the spec into the format of the given source, JSON or YAML,
+// keeping the order of the keys, see KeyOrder, and the comments of the YAML source, see Comments.
+func MarshalPreserved(spec *Extendable[OpenAPI], source []byte) ([]byte, error) {
+	order, err := NewKeyOrder(source)
+	if err != nil {
+		return nil, err
+	}
+	if order.json {
+		return order.Marshal(spec)
+	}
+	comments, err := NewComments(source)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := order.marshalNode(spec)
+	if err != nil {
+		return nil, err
+	}
+	comments.apply(doc)
+	return yaml.Marshal(doc)
+}
+
+// Marshal marshals the spec to YAML and sets the recorded comments to the objects with the same locations.
+func (c *Comments) Marshal(spec *Extendable[OpenAPI]) ([]byte, error) {
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec failed: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling spec failed: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return data, nil
+	}
+	c.apply(&doc)
+	return yaml.Marshal(&doc)
+}
+
+func (c *Comments) add(location string, key, node *yaml.Node, depth int) {
+	// the recursive aliases are limited the same way as by the source map
+	if depth > maxSourceMapDepth {
+		return
+	}
+	nc := nodeComments{head: node.HeadComment, line: node.LineComment, foot: node.FootComment}
+	if key != nil {
+		nc.keyHead, nc.keyLine, nc.keyFoot = key.HeadComment, key.LineComment, key.FootComment
+	}
+	if !nc.isEmpty() {
+		c.nodes[location] = &nc
+	}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.add(joinLoc(location, node.Content[i].Value), node.Content[i], node.Content[i+1], depth+1)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.add(joinLoc(location, i), nil, item, depth+1)
+		}
+	}
+}
+
+// apply sets the comments to the nodes of the given document.
+func (c *Comments) apply(doc *yaml.Node) {
+	doc.HeadComment, doc.LineComment, doc.FootComment = c.document.head, c.document.line, c.document.foot
+	if len(doc.Content) > 0 {
+		c.applyNode("", nil, doc.Content[0])
+	}
+}
+
+func (c *Comments) applyNode(location string, key, node *yaml.Node) {
+	if nc, ok := c.nodes[location]; ok {
+		node.HeadComment, node.LineComment, node.FootComment = nc.head, nc.line, nc.foot
+		if key != nil {
+			key.HeadComment, key.LineComment, key.FootComment = nc.keyHead, nc.keyLine, nc.keyFoot
+		}
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.applyNode(joinLoc(location, node.Content[i].Value), node.Content[i], node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.applyNode(joinLoc(location, i), nil, item)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testCommentsSpec = `# Copyright header
+
+openapi: 3.1.1
+info:
+    # the version is bumped by the release script
+    version: 1.0.0
+    title: Comments # shown in the portal
+paths:
+    # public endpoints
+    /pets:
+        get:
+            responses:
+                "200":
+                    description: OK
+components:
+    schemas:
+        Pet:
+            type: object
+            required:
+                - name # the only required field
+            properties:
+                name:
+                    type: string
+`
+
+func TestMarshalPreserved(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testCommentsSpec), &spec))
+
+	t.Run("round-trip", func(t *testing.T) {
+		data, err := openapi.MarshalPreserved(spec, []byte(testCommentsSpec))
+		require.NoError(t, err)
+		require.Equal(t, testCommentsSpec, string(data))
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testCommentsSpec), &spec))
+		spec.Spec.Info.Spec.Title = "Pets"
+		spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["age"] = openapi.NewRefOrSpec[openapi.Schema](
+			&openapi.Schema{Type: openapi.NewSingleOrArray(openapi.IntegerType)},
+		)
+		data, err := openapi.MarshalPreserved(spec, []byte(testCommentsSpec))
+		require.NoError(t, err)
+		require.Contains(t, string(data), "    title: Pets # shown in the portal\n")
+		require.Contains(t, string(data), "                - name # the only required field\n")
+		require.Contains(t, string(data), "                age:\n                    type: integer\n")
+	})
+
+	t.Run("comments only", func(t *testing.T) {
+		comments, err := openapi.NewComments([]byte(testCommentsSpec))
+		require.NoError(t, err)
+		data, err := comments.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "# Copyright header\n")
+		require.Contains(t, string(data), "    # public endpoints\n    /pets:\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		const source = `{"openapi":"3.1.1","info":{"version":"1.0.0","title":"JSON"}}`
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(source), &spec))
+		data, err := openapi.MarshalPreserved(spec, []byte(source))
+		require.NoError(t, err)
+		require.Equal(t, source, string(data))
+	})
+}
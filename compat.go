@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CompatConversion is a conversion of an OpenAPI 3.0 construct into OpenAPI 3.1 applied by LoadCompat.
+type CompatConversion struct {
+	// Location is the location of the converted object in the source, e.g. `/components/schemas/Pet`.
+	Location string `json:"location" yaml:"location"`
+	// Keyword is the converted keyword, e.g. `nullable`, see LoadCompat.
+	Keyword string `json:"keyword" yaml:"keyword"`
+}
+
+// String implements fmt.Stringer interface.
+func (c *CompatConversion) String() string {
+	return c.Location + ": " + c.Keyword
+}
+
+// LoadCompat parses the given YAML or JSON source of an OpenAPI 3.0 or 3.1 spec and returns the 3.1 spec,
+// so the legacy specs can be validated and used by the rest of the package.
+// The conversions applied to a 3.0 spec are returned in the order of the source:
+//
+//   - `openapi`: the version is set to 3.1.1;
+//   - `nullable`: `null` is added to `type` or `enum` of the schema, or the `$ref` is wrapped into `anyOf`
+//     with `type: 'null'`; the keyword is removed;
+//   - `exclusiveMinimum`, `exclusiveMaximum`: the boolean keywords are replaced with the numbers of
+//     `minimum` or `maximum`;
+//   - `example`: the example of the schema is moved into `examples` list;
+//   - `items`: the list of the schemas is renamed to `prefixItems` and `additionalItems` to `items`.
+//
+// A 3.1 spec is returned as is without conversions.
+// An error wrapping ErrUnsupportedVersion is returned for other versions.
+func LoadCompat(data []byte) (*Extendable[OpenAPI], []*CompatConversion, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("%w of source: %w", ErrInvalidFormat, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("%w of source: the spec must be an object", ErrInvalidFormat)
+	}
+	root := doc.Content[0]
+	var conversions []*CompatConversion
+	version := mappingValue(root, "openapi")
+	switch {
+	case version != nil && strings.HasPrefix(version.Value, "3.1."):
+	case version != nil && strings.HasPrefix(version.Value, "3.0."):
+		c := &compatConverter{}
+		version.Value = "3.1.1"
+		c.add("/openapi", "openapi")
+		c.walk("", root, 0)
+		conversions = c.conversions
+	default:
+		var v string
+		if version != nil {
+			v = version.Value
+		}
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, v)
+	}
+	var spec *Extendable[OpenAPI]
+	if err := root.Decode(&spec); err != nil {
+		return nil, nil, err
+	}
+	return spec, conversions, nil
+}
+
+// maxCompatDepth limits the depth of the walk, so the recursive aliases do not hang it.
+const maxCompatDepth = 1000
+
+type compatConverter struct {
+	conversions []*CompatConversion
+}
+
+func (c *compatConverter) add(location, keyword string) {
+	c.conversions = append(c.conversions, &CompatConversion{Location: location, Keyword: keyword})
+}
+
+// walk looks for the schemas in the objects of the spec.
+func (c *compatConverter) walk(location string, node *yaml.Node, depth int) {
+	if depth > maxCompatDepth {
+		return
+	}
+	node = resolveAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch {
+			case key == "schema":
+				c.schema(joinLoc(location, key), value, depth+1)
+			case key == "schemas" && location == "/components":
+				c.schemas(joinLoc(location, key), value, depth+1)
+			case key == "example" || key == "examples" || strings.HasPrefix(key, "x-"):
+				// the data and the extensions are not converted
+			default:
+				c.walk(joinLoc(location, key), value, depth+1)
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.walk(joinLoc(location, i), item, depth+1)
+		}
+	}
+}
+
+// schemas converts the schemas of the map.
+func (c *compatConverter) schemas(location string, node *yaml.Node, depth int) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		c.schema(joinLoc(location, node.Content[i].Value), node.Content[i+1], depth)
+	}
+}
+
+// schema converts the schema and its sub schemas.
+func (c *compatConverter) schema(location string, node *yaml.Node, depth int) {
+	if depth > maxCompatDepth {
+		return
+	}
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	if v := mappingValue(node, "nullable"); v != nil {
+		if v.Value == "true" {
+			c.nullable(node)
+		}
+		removeMappingKey(node, "nullable")
+		c.add(location, "nullable")
+	}
+	for _, k := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		v := mappingValue(node, k[0])
+		if v == nil || v.Tag != "!!bool" {
+			continue
+		}
+		limit := mappingValue(node, k[1])
+		removeMappingKey(node, k[0])
+		if v.Value == "true" && limit != nil {
+			removeMappingKey(node, k[1])
+			setMappingValue(node, k[0], limit)
+		}
+		c.add(location, k[0])
+	}
+	if v := mappingValue(node, "example"); v != nil {
+		removeMappingKey(node, "example")
+		examples := mappingValue(node, "examples")
+		if examples == nil {
+			setMappingValue(node, "examples", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{v}})
+		} else if examples.Kind == yaml.SequenceNode {
+			examples.Content = append(examples.Content, v)
+		}
+		c.add(location, "example")
+	}
+	if v := mappingValue(node, "items"); v != nil && resolveAlias(v).Kind == yaml.SequenceNode {
+		renameMappingKey(node, "items", "prefixItems")
+		renameMappingKey(node, "additionalItems", "items")
+		c.add(location, "items")
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch key {
+		case "items", "additionalProperties", "not", "additionalItems", "contains", "if", "then", "else",
+			"propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema":
+			c.schema(joinLoc(location, key), value, depth+1)
+		case "properties", "patternProperties", "dependentSchemas", "$defs":
+			c.schemas(joinLoc(location, key), value, depth+1)
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if value = resolveAlias(value); value.Kind == yaml.SequenceNode {
+				for j, item := range value.Content {
+					c.schema(joinLoc(location, key, j), item, depth+1)
+				}
+			}
+		}
+	}
+}
+
+// nullable adds null to the values permitted by the schema.
+func (c *compatConverter) nullable(node *yaml.Node) {
+	nullType := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: NullType, Style: yaml.SingleQuotedStyle}
+	if typ := mappingValue(node, "type"); typ != nil {
+		if typ.Kind == yaml.ScalarNode {
+			*typ = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle, Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: typ.Tag, Value: typ.Value}, nullType,
+			}}
+		} else if typ.Kind == yaml.SequenceNode && !sequenceContains(typ, NullType) {
+			typ.Content = append(typ.Content, nullType)
+		}
+	}
+	if enum := mappingValue(node, "enum"); enum != nil && enum.Kind == yaml.SequenceNode {
+		hasNull := false
+		for _, item := range enum.Content {
+			hasNull = hasNull || item.Tag == "!!null"
+		}
+		if !hasNull {
+			enum.Content = append(enum.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+		}
+	}
+	if ref := mappingValue(node, "$ref"); ref != nil && mappingValue(node, "type") == nil {
+		removeMappingKey(node, "$ref")
+		setMappingValue(node, "anyOf", &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{
+			{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "$ref"}, ref,
+			}},
+			{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "type"}, nullType,
+			}},
+		}})
+	}
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return node.Alias
+	}
+	return node
+}
+
+func sequenceContains(node *yaml.Node, value string) bool {
+	for _, item := range node.Content {
+		if item.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingValue returns the value of the given key of the mapping node, nil if there is no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of the given key of the mapping node, the new keys are appended.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func removeMappingKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func renameMappingKey(node *yaml.Node, key, newKey string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: newKey}
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testCompatSpec = `
+openapi: 3.0.3
+info:
+  title: Legacy
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 0
+            exclusiveMinimum: true
+            maximum: 100
+            exclusiveMaximum: false
+            example: 10
+          example: 20
+      responses:
+        200:
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+              example:
+                - name: Rex
+                  nullable: true
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+        status:
+          type: string
+          enum: [active, sold]
+          nullable: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+        point:
+          type: array
+          items:
+            - type: number
+            - type: number
+          additionalItems: false
+      required: [name]
+    Owner:
+      type: object
+      nullable: false
+      x-legacy:
+        nullable: true
+`
+
+func TestLoadCompat(t *testing.T) {
+	spec, conversions, err := openapi.LoadCompat([]byte(testCompatSpec))
+	require.NoError(t, err)
+
+	const (
+		limit = "/paths/~1pets/get/parameters/0/schema"
+		pet   = "/components/schemas/Pet"
+	)
+	keywords := make([]string, len(conversions))
+	for i, c := range conversions {
+		keywords[i] = c.String()
+	}
+	require.Equal(t, []string{
+		"/openapi: openapi",
+		limit + ": exclusiveMinimum",
+		limit + ": exclusiveMaximum",
+		limit + ": example",
+		pet + "/properties/name: nullable",
+		pet + "/properties/status: nullable",
+		pet + "/properties/owner: nullable",
+		pet + "/properties/point: items",
+		"/components/schemas/Owner: nullable",
+	}, keywords)
+
+	require.Equal(t, "3.1.1", spec.Spec.OpenAPI)
+	schema := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Parameters[0].Spec.Spec.Schema.Spec
+	require.Equal(t, "0", schema.ExclusiveMinimum.String())
+	require.Nil(t, schema.Minimum)
+	require.Equal(t, "100", schema.Maximum.String())
+	require.Nil(t, schema.ExclusiveMaximum)
+	require.Equal(t, []any{10}, schema.Examples)
+
+	props := spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties
+	require.Equal(t, []string{openapi.StringType, openapi.NullType}, []string(*props["name"].Spec.Type))
+	require.Equal(t, []any{"active", "sold", nil}, props["status"].Spec.Enum)
+	require.Len(t, props["owner"].Spec.AnyOf, 2)
+	require.Equal(t, "#/components/schemas/Owner", props["owner"].Spec.AnyOf[0].Ref.Ref)
+	require.Len(t, props["point"].Spec.PrefixItems, 2)
+	require.Nil(t, props["point"].Spec.Items.Schema)
+	require.False(t, props["point"].Spec.Items.Allowed)
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	const location = "/components/schemas/Pet"
+	require.NoError(t, validator.ValidateData(location, map[string]any{"name": nil, "owner": nil, "status": nil}))
+	require.Error(t, validator.ValidateData(location, map[string]any{"name": "Rex", "point": []any{1, 2, 3}}))
+}
+
+func TestLoadCompat_Versions(t *testing.T) {
+	spec, conversions, err := openapi.LoadCompat([]byte("openapi: 3.1.0\ninfo: {title: t, version: v}\ncomponents: {}\n"))
+	require.NoError(t, err)
+	require.Empty(t, conversions)
+	require.Equal(t, "3.1.0", spec.Spec.OpenAPI)
+
+	_, _, err = openapi.LoadCompat([]byte("swagger: '2.0'\n"))
+	require.ErrorIs(t, err, openapi.ErrUnsupportedVersion)
+
+	_, _, err = openapi.LoadCompat([]byte("- openapi\n"))
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+}
@@ -0,0 +1,270 @@
+package openapi
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// CheckCompatibility checks that the provider spec still satisfies the consumer spec,
+// which declares only the operations, parameters, and fields a client depends on (consumer-driven contract).
+//
+// For each operation of the consumer the provider must have the operation with the same method and path,
+// the names of the path parameters may differ. Then:
+//
+//   - each parameter sent by the consumer must be accepted by the provider, see CheckSubschema,
+//     and each required parameter of the provider must be sent by the consumer;
+//   - the request bodies of the consumer must be accepted by the provider for each media type,
+//     and the required request body of the provider must be sent by the consumer;
+//   - the responses of the provider must satisfy the responses of the consumer for each status code and media type,
+//     the `default` and the range responses, like `2XX`, are used if there is no response with the exact code.
+//
+// The errors wrap ErrIncompatible and are located in the consumer spec.
+func CheckCompatibility(provider, consumer *Extendable[OpenAPI]) error {
+	c := &compatibilityChecker{
+		providerComponents: provider.Spec.Components,
+		consumerComponents: consumer.Spec.Components,
+	}
+	providerPaths := make(map[string]string)
+	if provider.Spec.Paths != nil && provider.Spec.Paths.Spec != nil {
+		for path := range provider.Spec.Paths.Spec.Paths {
+			providerPaths[pathTemplateShape(path)] = path
+		}
+	}
+	forEachOperation(consumer, func(location, method string, op *Extendable[Operation]) {
+		if !strings.HasPrefix(location, "/paths/") {
+			// webhooks are called by the provider, so they are not a part of its contract
+			return
+		}
+		consumerPath := jsonPointerUnescaper.Replace(strings.Split(location, "/")[2])
+		providerPath, ok := providerPaths[pathTemplateShape(consumerPath)]
+		if !ok {
+			c.report(joinLoc("/paths", consumerPath), "%w: path is not provided", ErrOperationNotFound)
+			return
+		}
+		providerItem, err := provider.Spec.Paths.Spec.Paths[providerPath].GetSpec(c.providerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(location, err))
+			return
+		}
+		providerOp := providerItem.Spec.operation(method)
+		if providerOp == nil || providerOp.Spec == nil {
+			c.report(location, "%w: operation is not provided", ErrOperationNotFound)
+			return
+		}
+		consumerItem, err := consumer.Spec.Paths.Spec.Paths[consumerPath].GetSpec(c.consumerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(location, err))
+			return
+		}
+		renames := make(map[string]string)
+		providerNames := pathTemplateParams(providerPath)
+		for i, name := range pathTemplateParams(consumerPath) {
+			renames[name] = providerNames[i]
+		}
+		c.checkParameters(location, renames,
+			c.parameters(location, consumerItem.Spec, op.Spec, c.consumerComponents),
+			c.parameters(location, providerItem.Spec, providerOp.Spec, c.providerComponents),
+		)
+		c.checkRequestBody(location, op.Spec, providerOp.Spec)
+		c.checkResponses(location, op.Spec, providerOp.Spec)
+	})
+	return errors.Join(c.errs...)
+}
+
+type compatibilityChecker struct {
+	providerComponents *Extendable[Components]
+	consumerComponents *Extendable[Components]
+	errs               []error
+}
+
+func (c *compatibilityChecker) report(location string, format string, args ...any) {
+	c.errs = append(c.errs, newValidationError(location, "%w: "+format, append([]any{ErrIncompatible}, args...)...))
+}
+
+// checkSchema checks that the values of the sub schema are accepted by the super schema.
+func (c *compatibilityChecker) checkSchema(location string, sub *RefOrSpec[Schema], subComponents *Extendable[Components], super *RefOrSpec[Schema], superComponents *Extendable[Components]) {
+	checker := newSubschemaChecker(subComponents, superComponents)
+	checker.check(location, sub, super)
+	c.errs = append(c.errs, checker.errs...)
+}
+
+var pathTemplateParam = regexp.MustCompile(`{[^}/]*}`)
+
+// pathTemplateShape returns the path template without the names of the parameters, e.g. `/pets/{}`.
+func pathTemplateShape(template string) string {
+	return pathTemplateParam.ReplaceAllString(template, "{}")
+}
+
+func pathTemplateParams(template string) []string {
+	matches := pathTemplateParam.FindAllString(template, -1)
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m[1 : len(m)-1]
+	}
+	return names
+}
+
+type locatedParameter struct {
+	location string
+	spec     *Parameter
+}
+
+// parameters returns the parameters of the path item and the operation by the location and name,
+// the operation parameters override the path item parameters.
+func (c *compatibilityChecker) parameters(location string, item *PathItem, op *Operation, components *Extendable[Components]) map[string]*locatedParameter {
+	params := make(map[string]*locatedParameter)
+	itemLocation := location[:strings.LastIndexByte(location, '/')]
+	for _, src := range []struct {
+		location string
+		params   []*RefOrSpec[Extendable[Parameter]]
+	}{
+		{location: joinLoc(itemLocation, "parameters"), params: item.Parameters},
+		{location: joinLoc(location, "parameters"), params: op.Parameters},
+	} {
+		for i, ref := range src.params {
+			p, err := ref.GetSpec(components)
+			if err != nil {
+				c.errs = append(c.errs, newValidationError(joinLoc(src.location, i), err))
+				continue
+			}
+			params[parameterKey(p.Spec.In, p.Spec.Name)] = &locatedParameter{location: joinLoc(src.location, i), spec: p.Spec}
+		}
+	}
+	return params
+}
+
+func parameterKey(in, name string) string {
+	if in == InHeader {
+		name = strings.ToLower(name)
+	}
+	return in + ":" + name
+}
+
+func (c *compatibilityChecker) checkParameters(location string, renames map[string]string, consumer, provider map[string]*locatedParameter) {
+	sent := make(map[string]bool, len(consumer))
+	for _, key := range sortedKeys(consumer) {
+		p := consumer[key]
+		name := p.spec.Name
+		if p.spec.In == InPath {
+			if renamed, ok := renames[name]; ok {
+				name = renamed
+			}
+		}
+		providerKey := parameterKey(p.spec.In, name)
+		sent[providerKey] = true
+		pp, ok := provider[providerKey]
+		if !ok {
+			c.report(p.location, "%s parameter '%s' is not accepted", p.spec.In, p.spec.Name)
+			continue
+		}
+		c.checkSchema(joinLoc(p.location, "schema"), p.spec.Schema, c.consumerComponents, pp.spec.Schema, c.providerComponents)
+	}
+	for _, key := range sortedKeys(provider) {
+		if p := provider[key]; p.spec.Required && !sent[key] {
+			c.report(joinLoc(location, "parameters"), "required %s parameter '%s' is not sent", p.spec.In, p.spec.Name)
+		}
+	}
+}
+
+func (c *compatibilityChecker) checkRequestBody(location string, consumer, provider *Operation) {
+	location = joinLoc(location, "requestBody")
+	var consumerBody, providerBody *RequestBody
+	if consumer.RequestBody != nil {
+		body, err := consumer.RequestBody.GetSpec(c.consumerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(location, err))
+			return
+		}
+		consumerBody = body.Spec
+	}
+	if provider.RequestBody != nil {
+		body, err := provider.RequestBody.GetSpec(c.providerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(location, err))
+			return
+		}
+		providerBody = body.Spec
+	}
+	switch {
+	case consumerBody == nil:
+		if providerBody != nil && providerBody.Required {
+			c.report(location, "required request body is not sent")
+		}
+		return
+	case providerBody == nil:
+		c.report(location, "request body is not accepted")
+		return
+	}
+	for _, mediaType := range sortedKeys(consumerBody.Content) {
+		loc := joinLoc(location, "content", mediaType)
+		pm, ok := providerBody.Content[mediaType]
+		if !ok {
+			c.report(loc, "media type is not accepted")
+			continue
+		}
+		c.checkSchema(joinLoc(loc, "schema"), consumerBody.Content[mediaType].Spec.Schema, c.consumerComponents, pm.Spec.Schema, c.providerComponents)
+	}
+}
+
+func (c *compatibilityChecker) checkResponses(location string, consumer, provider *Operation) {
+	if consumer.Responses == nil || consumer.Responses.Spec == nil {
+		return
+	}
+	location = joinLoc(location, "responses")
+	codes := sortedKeys(consumer.Responses.Spec.Response)
+	if consumer.Responses.Spec.Default != nil {
+		codes = append(codes, "default")
+	}
+	for _, code := range codes {
+		loc := joinLoc(location, code)
+		ref := consumer.Responses.Spec.Default
+		if code != "default" {
+			ref = consumer.Responses.Spec.Response[code]
+		}
+		consumerResp, err := ref.GetSpec(c.consumerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(loc, err))
+			continue
+		}
+		providerRef := providerResponse(provider.Responses, code)
+		if providerRef == nil {
+			c.report(loc, "response is not provided")
+			continue
+		}
+		providerResp, err := providerRef.GetSpec(c.providerComponents)
+		if err != nil {
+			c.errs = append(c.errs, newValidationError(loc, err))
+			continue
+		}
+		for _, mediaType := range sortedKeys(consumerResp.Spec.Content) {
+			mloc := joinLoc(loc, "content", mediaType)
+			pm, ok := providerResp.Spec.Content[mediaType]
+			if !ok {
+				c.report(mloc, "media type is not provided")
+				continue
+			}
+			// the responses of the provider must be understood by the consumer
+			c.checkSchema(joinLoc(mloc, "schema"), pm.Spec.Schema, c.providerComponents, consumerResp.Spec.Content[mediaType].Spec.Schema, c.consumerComponents)
+		}
+	}
+}
+
+// providerResponse returns the response for the given status code, the range response, or the default response.
+func providerResponse(responses *Extendable[Responses], code string) *RefOrSpec[Extendable[Response]] {
+	if responses == nil || responses.Spec == nil {
+		return nil
+	}
+	if code == "default" {
+		return responses.Spec.Default
+	}
+	if r, ok := responses.Spec.Response[code]; ok {
+		return r
+	}
+	if len(code) == 3 {
+		if r, ok := responses.Spec.Response[code[:1]+"XX"]; ok {
+			return r
+		}
+	}
+	return responses.Spec.Default
+}
@@ -0,0 +1,295 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testProviderSpec = `
+openapi: 3.1.1
+info:
+  title: Provider
+  version: 2.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+        - name: fields
+          in: query
+          schema:
+            type: string
+            enum: [name, age]
+      responses:
+        '2XX':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  maxLength: 100
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        age:
+          type: integer
+          minimum: 0
+`
+
+func TestCheckCompatibility(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		consumer string
+		errs     []string
+	}{
+		{
+			name: "compatible",
+			consumer: `
+openapi: 3.1.1
+info:
+  title: Consumer
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: x-tenant
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+                  age:
+                    type: number
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  maxLength: 50
+      responses:
+        '201':
+          description: Created
+`,
+		},
+		{
+			name: "incompatible",
+			consumer: `
+openapi: 3.1.1
+info:
+  title: Consumer
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: fields
+          in: query
+          schema:
+            type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [owner]
+                properties:
+                  name:
+                    type: boolean
+        '404':
+          description: Not Found
+    delete:
+      responses:
+        '204':
+          description: Deleted
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+          application/xml:
+            schema:
+              type: object
+      responses:
+        '201':
+          description: Created
+  /owners:
+    get:
+      responses:
+        '200':
+          description: OK
+`,
+			errs: []string{
+				"/paths/~1owners: incompatible: operation not found: path is not provided",
+				"/paths/~1pets~1{id}/delete: incompatible: operation not found: operation is not provided",
+				"/paths/~1pets~1{id}/get/parameters/0/schema/type: incompatible: type 'string' is not one of [integer]",
+				"/paths/~1pets~1{id}/get/parameters/1/schema/enum: incompatible: any value is allowed, but expected one of [\"name\",\"age\"]",
+				"/paths/~1pets~1{id}/get/parameters/2: incompatible: query parameter 'limit' is not accepted",
+				"/paths/~1pets~1{id}/get/parameters: incompatible: required header parameter 'X-Tenant' is not sent",
+				"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/required: incompatible: property 'owner' is not required",
+				"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/name/type: incompatible: type 'string' is not one of [boolean]",
+				"/paths/~1pets~1{id}/get/responses/404: incompatible: response is not provided",
+				"/paths/~1pets/post/requestBody/content/application~1json/schema/required: incompatible: property 'name' is not required",
+				"/paths/~1pets/post/requestBody/content/application~1json/schema/properties/name/maxLength: incompatible: no limit, but expected 100",
+				"/paths/~1pets/post/requestBody/content/application~1xml: incompatible: media type is not accepted",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var provider, consumer *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(testProviderSpec), &provider))
+			require.NoError(t, yaml.Unmarshal([]byte(tt.consumer), &consumer))
+
+			err := openapi.CheckCompatibility(provider, consumer)
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, openapi.ErrIncompatible)
+			var messages []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				messages = append(messages, e.Error())
+			}
+			require.ElementsMatch(t, tt.errs, messages)
+		})
+	}
+}
+
+func TestCheckSubschema(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		sub   string
+		super string
+		err   string
+	}{
+		{
+			name:  "integer is number",
+			sub:   `{"type": "integer", "minimum": 1}`,
+			super: `{"type": "number", "minimum": 0}`,
+		},
+		{
+			name:  "minimum",
+			sub:   `{"type": "integer", "minimum": -1}`,
+			super: `{"type": "integer", "minimum": 0}`,
+			err:   "/minimum: incompatible: -1 is less than 0",
+		},
+		{
+			name:  "enum subset",
+			sub:   `{"enum": ["a"]}`,
+			super: `{"enum": ["a", "b"]}`,
+		},
+		{
+			name:  "enum",
+			sub:   `{"enum": ["a", "c"]}`,
+			super: `{"enum": ["a", "b"]}`,
+			err:   `/enum/1: incompatible: value "c" is not one of the allowed values`,
+		},
+		{
+			name:  "anyOf super",
+			sub:   `{"type": "string"}`,
+			super: `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`,
+		},
+		{
+			name:  "oneOf sub",
+			sub:   `{"oneOf": [{"type": "integer"}, {"type": "boolean"}]}`,
+			super: `{"type": "integer"}`,
+			err:   "/oneOf/1/type: incompatible: type 'boolean' is not one of [integer]",
+		},
+		{
+			name:  "closed object",
+			sub:   `{"type": "object", "properties": {"a": {}, "b": {}}, "additionalProperties": false}`,
+			super: `{"type": "object", "properties": {"a": {}}, "additionalProperties": false}`,
+			err:   "/properties/b: incompatible: property is not allowed",
+		},
+		{
+			name:  "items",
+			sub:   `{"type": "array", "items": {"type": "string", "format": "uuid"}}`,
+			super: `{"type": "array", "items": {"type": "string", "format": "uuid"}, "maxItems": 10}`,
+			err:   "/maxItems: incompatible: no limit, but expected 10",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var sub, super *openapi.RefOrSpec[openapi.Schema]
+			require.NoError(t, yaml.Unmarshal([]byte(tt.sub), &sub))
+			require.NoError(t, yaml.Unmarshal([]byte(tt.super), &super))
+			err := openapi.CheckSubschema(sub, super, nil)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The headers of the conditional requests, RFC 9110.
+const (
+	// ETagHeader is the name of the response header holding the entity tag of the representation.
+	ETagHeader = "ETag"
+	// IfMatchHeader is the name of the request header making the request conditional on the entity tag,
+	// it prevents the lost updates of the resource.
+	IfMatchHeader = "If-Match"
+	// IfNoneMatchHeader is the name of the request header making the request conditional on the entity tag
+	// not matching, it is used to revalidate the cached representation.
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// ETag declares the required `ETag` header of the response.
+func (b *ResponseBuilder) ETag() *ResponseBuilder {
+	return b.AddHeader(ETagHeader, NewHeaderBuilder().
+		Description("The entity tag of the representation").
+		Required(true).
+		Schema(NewSchemaBuilder().Type(StringType).Build()).
+		Build())
+}
+
+// RequireIfMatch adds the required `If-Match` header parameter and `412 Precondition Failed` response,
+// unless the response is already declared.
+func (b *OperationBuilder) RequireIfMatch() *OperationBuilder {
+	b.AddParameters(NewParameterBuilder().
+		Name(IfMatchHeader).
+		In(InHeader).
+		Description("The entity tag of the modified resource").
+		Required(true).
+		Schema(NewSchemaBuilder().Type(StringType).Build()).
+		Build())
+	b.addStatusResponse(http.StatusPreconditionFailed)
+	return b
+}
+
+// IfNoneMatch adds the optional `If-None-Match` header parameter and `304 Not Modified` response,
+// unless the response is already declared.
+func (b *OperationBuilder) IfNoneMatch() *OperationBuilder {
+	b.AddParameters(NewParameterBuilder().
+		Name(IfNoneMatchHeader).
+		In(InHeader).
+		Description("The entity tags of the cached representations").
+		Schema(NewSchemaBuilder().Type(StringType).Build()).
+		Build())
+	b.addStatusResponse(http.StatusNotModified)
+	return b
+}
+
+func (b *OperationBuilder) addStatusResponse(status int) {
+	if b.spec.Spec.Responses == nil {
+		b.spec.Spec.Responses = NewExtendable(&Responses{})
+	}
+	responses := b.spec.Spec.Responses.Spec
+	if responses.Response == nil {
+		responses.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+	}
+	code := strconv.Itoa(status)
+	if _, ok := responses.Response[code]; !ok {
+		responses.Response[code] = NewResponseBuilder().Description(http.StatusText(status)).Build()
+	}
+}
+
+// ConditionalRequestsRuleID is the ID of ConditionalRequestsRule.
+const ConditionalRequestsRuleID = "conditional-requests"
+
+// ConditionalRequestsRule is a governance rule checking the conventions of the conditional requests:
+// the successful responses of GET operations must expose `ETag` header,
+// and PUT, PATCH and DELETE operations must require `If-Match` header parameter,
+// declared by the operation or by its path item.
+// The GET operations switching the protocol, see IsUpgradeOperation, are not checked.
+//
+// The rule is disabled by default, it can be enabled using RuleSeverity option and configured using WithRules option:
+//
+//	openapi.NewValidator(spec,
+//		openapi.WithRules(&openapi.ConditionalRequestsRule{ExemptPaths: []string{"/search"}}),
+//		openapi.RuleSeverity(openapi.ConditionalRequestsRuleID, openapi.SeverityWarning),
+//	)
+type ConditionalRequestsRule struct {
+	// ExemptPaths are the paths or the patterns of the paths (see path.Match) not checked by the rule.
+	ExemptPaths []string
+}
+
+// NewConditionalRequestsRule creates the rule with the given exempt paths.
+func NewConditionalRequestsRule(exemptPaths ...string) *ConditionalRequestsRule {
+	return &ConditionalRequestsRule{ExemptPaths: exemptPaths}
+}
+
+// ID implements Rule interface.
+func (r *ConditionalRequestsRule) ID() string {
+	return ConditionalRequestsRuleID
+}
+
+// Description implements Rule interface.
+func (r *ConditionalRequestsRule) Description() string {
+	return "successful responses of GET operations must expose ETag header, PUT, PATCH and DELETE operations must require If-Match header"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *ConditionalRequestsRule) DefaultSeverity() Severity {
+	return SeverityOff
+}
+
+// Options implements ConfigurableRule interface.
+func (r *ConditionalRequestsRule) Options() []RuleOption {
+	exemptPaths := r.ExemptPaths
+	if exemptPaths == nil {
+		exemptPaths = []string{}
+	}
+	return []RuleOption{
+		{
+			Name:        "exemptPaths",
+			Description: "the paths or the patterns of the paths, like `/search/*`, not checked by the rule",
+			Schema: NewSchemaBuilder().
+				Type(ArrayType).
+				Items(NewBoolOrSchema(NewSchemaBuilder().Type(StringType).Build())).
+				Default(exemptPaths).
+				Build(),
+		},
+	}
+}
+
+// Configure implements ConfigurableRule interface.
+func (r *ConditionalRequestsRule) Configure(options map[string]any) error {
+	var cfg struct {
+		ExemptPaths []string `json:"exemptPaths"`
+	}
+	if err := decodeRuleOptions(options, &cfg); err != nil {
+		return err
+	}
+	r.ExemptPaths = cfg.ExemptPaths
+	return nil
+}
+
+// Check implements Rule interface.
+func (r *ConditionalRequestsRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	components := spec.Spec.Components
+	items := spec.Spec.Paths.Spec.Paths
+	for _, p := range sortedKeys(items) {
+		if matchPathPatterns(r.ExemptPaths, p) {
+			continue
+		}
+		item := items[p]
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			continue
+		}
+		if op := item.Spec.Spec.Get; op != nil && op.Spec != nil && op.Spec.Responses != nil && op.Spec.Responses.Spec != nil &&
+			!IsUpgradeOperation(op.Spec) {
+			loc := joinLoc("/paths", p, "get", "responses")
+			responses := op.Spec.Responses.Spec.Response
+			for _, code := range sortedKeys(responses) {
+				if !strings.HasPrefix(code, "2") {
+					continue
+				}
+				response, err := responses[code].GetSpec(components)
+				if err != nil || response.Spec == nil {
+					continue
+				}
+				if !hasHeader(response.Spec.Headers, ETagHeader) {
+					report(joinLoc(loc, code), "successful response of GET operation does not expose "+ETagHeader+" header")
+				}
+			}
+		}
+		for _, method := range []string{http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			op := item.Spec.Spec.operation(method)
+			if op == nil || op.Spec == nil {
+				continue
+			}
+			loc := joinLoc("/paths", p, strings.ToLower(method))
+			if param, _ := headerParameter(components, IfMatchHeader, loc, op.Spec, item.Spec.Spec); param == nil || !param.Required {
+				report(loc, method+" operation does not require "+IfMatchHeader+" header")
+			}
+		}
+	}
+}
+
+// hasHeader reports whether the headers contain the given one, the name is case-insensitive.
+func hasHeader(headers map[string]*RefOrSpec[Extendable[Header]], name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerParameter returns the header parameter with the given name, case-insensitive, and its location.
+// The parameters of the operation at the given location override the ones of its path item.
+func headerParameter(components *Extendable[Components], name, location string, op *Operation, item *PathItem) (*Parameter, string) {
+	find := func(location string, params []*RefOrSpec[Extendable[Parameter]]) (*Parameter, string) {
+		for i, ref := range params {
+			if ref == nil {
+				continue
+			}
+			param, err := ref.GetSpec(components)
+			if err != nil || param.Spec == nil {
+				continue
+			}
+			if param.Spec.In == InHeader && strings.EqualFold(param.Spec.Name, name) {
+				return param.Spec, ref.getLocationOrRef(joinLoc(location, "parameters", i))
+			}
+		}
+		return nil, ""
+	}
+	if param, loc := find(location, op.Parameters); param != nil {
+		return param, loc
+	}
+	return find(string(Location(location).Parent()), item.Parameters)
+}
@@ -0,0 +1,153 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestConditionalRequestsBuilders(t *testing.T) {
+	pet := openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()
+	id := openapi.NewParameterBuilder().Name("id").In(openapi.InPath).Required(true).
+		Schema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+		Build()
+	get := openapi.NewOperationBuilder().IfNoneMatch().Build()
+	get.Spec.Responses.Spec.Response["200"] = openapi.NewResponseBuilder().
+		Description("pet").
+		AddContent("application/json", openapi.NewMediaTypeBuilder().Schema(pet).Build()).
+		ETag().
+		Build()
+	put := openapi.NewOperationBuilder().RequireIfMatch().JSONResponseFrom("204", struct{}{}).Build()
+	del := openapi.NewOperationBuilder().RequireIfMatch().Build()
+
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Conditional").Version("1.0.0").Build()).
+		AddPath("/pets/{id}", openapi.NewPathItemBuilder().
+			Parameters(id).
+			Get(get).
+			Put(put).
+			Delete(del).
+			Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec, openapi.RuleSeverity(openapi.ConditionalRequestsRuleID, openapi.SeverityWarning))
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.Empty(t, validator.Lint())
+
+	require.Equal(t, openapi.IfNoneMatchHeader, get.Spec.Parameters[0].Spec.Spec.Name)
+	require.False(t, get.Spec.Parameters[0].Spec.Spec.Required)
+	require.Equal(t, "Not Modified", get.Spec.Responses.Spec.Response["304"].Spec.Spec.Description)
+	require.True(t, get.Spec.Responses.Spec.Response["200"].Spec.Spec.Headers[openapi.ETagHeader].Spec.Spec.Required)
+
+	require.Equal(t, openapi.IfMatchHeader, put.Spec.Parameters[0].Spec.Spec.Name)
+	require.Equal(t, openapi.InHeader, put.Spec.Parameters[0].Spec.Spec.In)
+	require.True(t, put.Spec.Parameters[0].Spec.Spec.Required)
+	require.Equal(t, "Precondition Failed", put.Spec.Responses.Spec.Response["412"].Spec.Spec.Description)
+	require.Contains(t, put.Spec.Responses.Spec.Response, "204")
+
+	// the declared responses are kept
+	custom := openapi.NewResponseBuilder().Description("stale").Build()
+	b := openapi.NewOperationBuilder()
+	b.Build().Spec.Responses = openapi.NewExtendable(&openapi.Responses{
+		Response: map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]]{"412": custom},
+	})
+	op := b.RequireIfMatch().Build()
+	require.Same(t, custom, op.Spec.Responses.Spec.Response["412"])
+}
+
+const testConditionalRequestsRuleSpec = `
+openapi: 3.1.1
+info:
+  title: Conditional
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        '200':
+          description: pet
+        '304':
+          description: Not Modified
+    put:
+      parameters:
+        - name: If-Match
+          in: header
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Updated
+    delete:
+      parameters:
+        - name: If-Match
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+  /owners:
+    parameters:
+      - name: if-match
+        in: header
+        required: true
+        schema:
+          type: string
+    patch:
+      responses:
+        '204':
+          description: Updated
+  /search:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestConditionalRequestsRule(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		rule     *openapi.ConditionalRequestsRule
+		expected []string
+	}{
+		{
+			name: "all paths",
+			rule: openapi.NewConditionalRequestsRule(),
+			expected: []string{
+				"/paths/~1pets~1{id}/delete: DELETE operation does not require If-Match header",
+				"/paths/~1pets~1{id}/get/responses/200: successful response of GET operation does not expose ETag header",
+				"/paths/~1pets~1{id}/put: PUT operation does not require If-Match header",
+				"/paths/~1search/get/responses/200: successful response of GET operation does not expose ETag header",
+			},
+		},
+		{
+			name: "exempt paths",
+			rule: openapi.NewConditionalRequestsRule("/search", "/pets/*", "/owners"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(testConditionalRequestsRuleSpec), &spec))
+			validator, err := openapi.NewValidator(spec,
+				openapi.WithRules(tt.rule),
+				openapi.RuleSeverity(openapi.ConditionalRequestsRuleID, openapi.SeverityWarning),
+			)
+			require.NoError(t, err)
+			var messages []string
+			for _, issue := range validator.Lint() {
+				messages = append(messages, issue.Location.String()+": "+issue.Message)
+			}
+			require.Equal(t, tt.expected, messages)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package openapi
+
+// ApplyDefaults returns a copy of the given value with the `default` values of the schema filled in
+// for the missing properties, e.g. to mock the responses or to document the effective payloads.
+// The value is in the form of the decoded JSON: map[string]any for objects and []any for arrays.
+//
+// The defaults are applied recursively: to the properties of the objects, to the items of the arrays,
+// and to the filled in default values themselves. The referenced schemas and `allOf` branches are applied too,
+// while `oneOf`, `anyOf` and the conditional schemas are ignored since their branch is unknown.
+// If the value itself is nil, the default of the schema is returned, while the explicit null values of
+// the properties and the items are kept.
+// The given value and the defaults of the schema are not modified.
+func ApplyDefaults(schema *RefOrSpec[Schema], components *Extendable[Components], value any) any {
+	return applyDefaults(schema, components, copyJSONValue(value), make(map[*Schema]bool))
+}
+
+// applyDefaults fills in the defaults into the value in place,
+// the path contains the schemas applied to the value to stop at the recursive references.
+func applyDefaults(ref *RefOrSpec[Schema], components *Extendable[Components], value any, path map[*Schema]bool) any {
+	if ref == nil {
+		return value
+	}
+	s, err := ref.GetSpec(components)
+	if err != nil || s == nil || s.Bool != nil || path[s] {
+		return value
+	}
+	path[s] = true
+	defer delete(path, s)
+
+	if value == nil && s.Default != nil {
+		value = copyJSONValue(s.Default)
+	}
+	for _, branch := range schemaAllOf(s) {
+		value = applyDefaults(branch, components, value, path)
+	}
+	// the given properties and items are new values, so the schemas applied to the parent are not recursive for them,
+	// while the filled in defaults keep the path to stop at the recursive defaults
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range sortedKeys(s.Properties) {
+			prop, ok := v[name]
+			switch {
+			case !ok:
+				if d := applyDefaults(s.Properties[name], components, nil, path); d != nil {
+					v[name] = d
+				}
+			case prop != nil:
+				v[name] = applyDefaults(s.Properties[name], components, prop, make(map[*Schema]bool))
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if item == nil {
+				continue
+			}
+			switch {
+			case i < len(s.PrefixItems):
+				v[i] = applyDefaults(s.PrefixItems[i], components, item, make(map[*Schema]bool))
+			case s.Items != nil:
+				v[i] = applyDefaults(s.Items.Schema, components, item, make(map[*Schema]bool))
+			}
+		}
+	}
+	return value
+}
+
+func copyJSONValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, item := range v {
+			m[k] = copyJSONValue(item)
+		}
+		return m
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = copyJSONValue(item)
+		}
+		return items
+	}
+	return value
+}
@@ -0,0 +1,133 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testDefaultsComponents = `
+schemas:
+  Pet:
+    allOf:
+      - $ref: '#/components/schemas/Named'
+    type: object
+    properties:
+      status:
+        type: string
+        default: available
+      tags:
+        type: array
+        items:
+          $ref: '#/components/schemas/Tag'
+      owner:
+        $ref: '#/components/schemas/Owner'
+      parent:
+        $ref: '#/components/schemas/Pet'
+  Named:
+    type: object
+    properties:
+      name:
+        type: string
+        default: unnamed
+  Tag:
+    type: object
+    properties:
+      color:
+        type: string
+        default: red
+  Owner:
+    type: object
+    default: {}
+    properties:
+      active:
+        type: boolean
+        default: true
+      next:
+        $ref: '#/components/schemas/Owner'
+`
+
+func TestApplyDefaults(t *testing.T) {
+	var components *openapi.Extendable[openapi.Components]
+	require.NoError(t, yaml.Unmarshal([]byte(testDefaultsComponents), &components))
+	pet := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Pet")
+
+	for _, tt := range []struct {
+		name     string
+		schema   *openapi.RefOrSpec[openapi.Schema]
+		value    any
+		expected any
+	}{
+		{
+			name:   "empty object",
+			schema: pet,
+			value:  map[string]any{},
+			expected: map[string]any{
+				"name":   "unnamed",
+				"status": "available",
+				"owner":  map[string]any{"active": true},
+			},
+		},
+		{
+			name:   "partial object",
+			schema: pet,
+			value: map[string]any{
+				"name":   "Rex",
+				"status": nil,
+				"tags":   []any{map[string]any{}, map[string]any{"color": "blue"}, nil},
+				"owner":  map[string]any{"active": false, "next": map[string]any{}},
+				"parent": map[string]any{"name": "Max"},
+			},
+			expected: map[string]any{
+				"name":   "Rex",
+				"status": nil,
+				"tags":   []any{map[string]any{"color": "red"}, map[string]any{"color": "blue"}, nil},
+				"owner":  map[string]any{"active": false, "next": map[string]any{"active": true}},
+				"parent": map[string]any{
+					"name":   "Max",
+					"status": "available",
+					"owner":  map[string]any{"active": true},
+				},
+			},
+		},
+		{
+			name:     "nil value",
+			schema:   openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Owner"),
+			expected: map[string]any{"active": true},
+		},
+		{
+			name:     "not an object",
+			schema:   pet,
+			value:    "Rex",
+			expected: "Rex",
+		},
+		{
+			name:     "unresolved ref",
+			schema:   openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Missing"),
+			value:    map[string]any{},
+			expected: map[string]any{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, openapi.ApplyDefaults(tt.schema, components, tt.value))
+		})
+	}
+}
+
+func TestApplyDefaults_DoesNotModifyValue(t *testing.T) {
+	var components *openapi.Extendable[openapi.Components]
+	require.NoError(t, yaml.Unmarshal([]byte(testDefaultsComponents), &components))
+	owner := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Owner")
+
+	value := map[string]any{"next": map[string]any{}}
+	result := openapi.ApplyDefaults(owner, components, value)
+	require.Equal(t, map[string]any{"next": map[string]any{}}, value)
+	require.Equal(t, map[string]any{"active": true, "next": map[string]any{"active": true}}, result)
+
+	// the default of the schema is copied
+	result.(map[string]any)["active"] = false
+	require.Equal(t, map[string]any{}, components.Spec.Schemas["Owner"].Spec.Default)
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// oasDialectPrefix is the prefix of the OpenAPI dialects, e.g. the default `https://spec.openapis.org/oas/3.1/dialect/base`.
+const oasDialectPrefix = "spec.openapis.org/oas/3.1/dialect/"
+
+// draftOf returns the JSON Schema draft of the given dialect, e.g. the value of `jsonSchemaDialect` or `$schema`,
+// and reports whether the dialect is known.
+// The OpenAPI dialects and the unknown ones are based on the draft 2020-12.
+func draftOf(dialect string) (*jsonschema.Draft, bool) {
+	u := strings.TrimSuffix(dialect, "#")
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	switch u {
+	case "json-schema.org/draft-04/schema":
+		return jsonschema.Draft4, true
+	case "json-schema.org/draft-06/schema":
+		return jsonschema.Draft6, true
+	case "json-schema.org/draft-07/schema":
+		return jsonschema.Draft7, true
+	case "json-schema.org/draft/2019-09/schema":
+		return jsonschema.Draft2019, true
+	case "json-schema.org/draft/2020-12/schema":
+		return jsonschema.Draft2020, true
+	}
+	return jsonschema.Draft2020, strings.HasPrefix(u, oasDialectPrefix)
+}
+
+// draftAt returns the draft of the schema at the given location of the JSON representation of the spec.
+//
+// The nearest `$schema` keyword of the schema or of its parents takes precedence over the `jsonSchemaDialect` of the spec.
+// The draft of the validated schema is used for the schemas referenced by it as well.
+func (v *Validator) draftAt(doc any, location string) *jsonschema.Draft {
+	var dialect string
+	if v.spec != nil && v.spec.Spec != nil {
+		dialect = v.spec.Spec.JsonSchemaDialect
+	}
+	for _, segment := range Location(location).Segments() {
+		switch t := doc.(type) {
+		case map[string]any:
+			if s, ok := t["$schema"].(string); ok {
+				dialect = s
+			}
+			doc = t[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(t) {
+				draft, _ := draftOf(dialect)
+				return draft
+			}
+			doc = t[i]
+		default:
+			draft, _ := draftOf(dialect)
+			return draft
+		}
+	}
+	if m, ok := doc.(map[string]any); ok {
+		if s, ok := m["$schema"].(string); ok {
+			dialect = s
+		}
+	}
+	draft, _ := draftOf(dialect)
+	return draft
+}
+
+// compilerFor returns the compiler using the given draft for the schemas without `$schema` keyword,
+// the compilers of the drafts other than 2020-12 are created on demand.
+// The caller must hold the mutex of the cache.
+func (c *schemaCache) compilerFor(draft *jsonschema.Draft) (*jsonschema.Compiler, error) {
+	if draft == jsonschema.Draft2020 {
+		return c.compiler, nil
+	}
+	if compiler, ok := c.dialects[draft]; ok {
+		return compiler, nil
+	}
+	compiler := jsonschema.NewCompiler()
+	registerFormats(compiler)
+	if err := compiler.AddResource(specPrefix, c.doc); err != nil {
+		return nil, fmt.Errorf("adding spec to compiler failed: %w", err)
+	}
+	for _, f := range c.updateCompiler {
+		f(compiler)
+	}
+	// the dialect declared by the spec takes precedence
+	compiler.DefaultDraft(draft)
+	if c.dialects == nil {
+		c.dialects = make(map[*jsonschema.Draft]*jsonschema.Compiler)
+	}
+	c.dialects[draft] = compiler
+	return compiler, nil
+}
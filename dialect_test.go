@@ -0,0 +1,113 @@
+package openapi_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestValidator_ValidateData_Dialect(t *testing.T) {
+	const specTemplate = `
+openapi: 3.1.1
+info:
+  title: Dialects
+  version: 1.0.0
+components:
+  schemas:
+    Payment:
+      type: object
+      # the dependentRequired keyword is introduced by the draft 2019-09
+      dependentRequired:
+        card: [billing]
+    Latest:
+      $schema: https://json-schema.org/draft/2020-12/schema
+      type: object
+      dependentRequired:
+        card: [billing]
+    Legacy:
+      $schema: http://json-schema.org/draft-07/schema#
+      type: object
+      properties:
+        payment:
+          type: object
+          dependentRequired:
+            card: [billing]
+`
+	for _, tt := range []struct {
+		name    string
+		dialect string
+		invalid []string
+	}{
+		{
+			name:    "default",
+			invalid: []string{"/components/schemas/Payment", "/components/schemas/Latest"},
+		},
+		{
+			name:    "draft-07",
+			dialect: "http://json-schema.org/draft-07/schema#",
+			invalid: []string{"/components/schemas/Latest"},
+		},
+		{
+			name:    "oas",
+			dialect: "https://spec.openapis.org/oas/3.1/dialect/base",
+			invalid: []string{"/components/schemas/Payment", "/components/schemas/Latest"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(specTemplate), &spec))
+			spec.Spec.JsonSchemaDialect = tt.dialect
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateSpec())
+
+			data := map[string]any{"card": "1234"}
+			for _, location := range []string{
+				"/components/schemas/Payment",
+				"/components/schemas/Latest",
+				"/components/schemas/Legacy/properties/payment",
+			} {
+				err := validator.ValidateData(location, data)
+				if slices.Contains(tt.invalid, location) {
+					require.Error(t, err, location)
+				} else {
+					require.NoError(t, err, location)
+				}
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateSpec_Dialect(t *testing.T) {
+	for _, tt := range []struct {
+		dialect string
+		valid   bool
+	}{
+		{dialect: "https://json-schema.org/draft/2020-12/schema", valid: true},
+		{dialect: "https://json-schema.org/draft/2019-09/schema", valid: true},
+		{dialect: "http://json-schema.org/draft-07/schema#", valid: true},
+		{dialect: "http://json-schema.org/draft-04/schema#", valid: true},
+		{dialect: "https://spec.openapis.org/oas/3.1/dialect/base", valid: true},
+		{dialect: "https://example.com/custom/schema", valid: false},
+	} {
+		t.Run(tt.dialect, func(t *testing.T) {
+			components := openapi.NewComponents()
+			components.Spec.Add("Pet", openapi.NewSchemaBuilder().Schema(tt.dialect).Type(openapi.ObjectType).Build())
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("Dialects").Version("1.0.0").Build()).
+				Components(components).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			if tt.valid {
+				require.NoError(t, validator.ValidateSpec())
+			} else {
+				require.ErrorIs(t, validator.ValidateSpec(), openapi.ErrUnsupportedVersion)
+			}
+		})
+	}
+}
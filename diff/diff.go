@@ -0,0 +1,856 @@
+// Package diff compares two versions of an OpenAPI specification and detects the breaking changes,
+// e.g. to gate the releases of an API in CI.
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sv-tools/openapi"
+)
+
+// ChangeKind is a kind of the change between two versions of a spec.
+type ChangeKind string
+
+// The kinds of the changes, the added and removed objects, and the changes of their properties.
+const (
+	PathAdded                    ChangeKind = "path-added"
+	PathRemoved                  ChangeKind = "path-removed"
+	OperationAdded               ChangeKind = "operation-added"
+	OperationRemoved             ChangeKind = "operation-removed"
+	OperationBecameDeprecated    ChangeKind = "operation-became-deprecated"
+	ParameterAdded               ChangeKind = "parameter-added"
+	ParameterRemoved             ChangeKind = "parameter-removed"
+	ParameterBecameRequired      ChangeKind = "parameter-became-required"
+	ParameterBecameOptional      ChangeKind = "parameter-became-optional"
+	ParameterBecameDeprecated    ChangeKind = "parameter-became-deprecated"
+	RequestBodyAdded             ChangeKind = "request-body-added"
+	RequestBodyRemoved           ChangeKind = "request-body-removed"
+	RequestBodyBecameRequired    ChangeKind = "request-body-became-required"
+	RequestBodyBecameOptional    ChangeKind = "request-body-became-optional"
+	ResponseAdded                ChangeKind = "response-added"
+	ResponseRemoved              ChangeKind = "response-removed"
+	MediaTypeAdded               ChangeKind = "media-type-added"
+	MediaTypeRemoved             ChangeKind = "media-type-removed"
+	SchemaAdded                  ChangeKind = "schema-added"
+	SchemaRemoved                ChangeKind = "schema-removed"
+	TypeChanged                  ChangeKind = "type-changed"
+	FormatChanged                ChangeKind = "format-changed"
+	EnumNarrowed                 ChangeKind = "enum-narrowed"
+	EnumWidened                  ChangeKind = "enum-widened"
+	RequiredPropertyAdded        ChangeKind = "required-property-added"
+	RequiredPropertyRemoved      ChangeKind = "required-property-removed"
+	PropertyAdded                ChangeKind = "property-added"
+	PropertyRemoved              ChangeKind = "property-removed"
+	AdditionalPropertiesNarrowed ChangeKind = "additional-properties-narrowed"
+	AdditionalPropertiesWidened  ChangeKind = "additional-properties-widened"
+	CompositionNarrowed          ChangeKind = "composition-narrowed"
+	CompositionWidened           ChangeKind = "composition-widened"
+	LimitNarrowed                ChangeKind = "limit-narrowed"
+	LimitWidened                 ChangeKind = "limit-widened"
+	PatternChanged               ChangeKind = "pattern-changed"
+	SecurityRequirementAdded     ChangeKind = "security-requirement-added"
+	SecurityRequirementRemoved   ChangeKind = "security-requirement-removed"
+	SunsetAdded                  ChangeKind = "sunset-added"
+	SunsetChanged                ChangeKind = "sunset-changed"
+	SunsetRemoved                ChangeKind = "sunset-removed"
+)
+
+// Change is a difference between two versions of a spec.
+type Change struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind `json:"kind"`
+	// Location is the location of the changed object in the new spec, or in the old spec if it is removed.
+	// The changes of the schemas are located at the places of their usage,
+	// e.g. `/paths/~1pets/post/requestBody/content/application~1json/schema/properties/name`,
+	// the references are followed.
+	Location openapi.Location `json:"location"`
+	// Message is the description of the change.
+	Message string `json:"message"`
+	// Breaking reports whether the change may break the existing clients.
+	Breaking bool `json:"breaking"`
+}
+
+// String implements fmt.Stringer interface.
+func (c *Change) String() string {
+	if c.Breaking {
+		return fmt.Sprintf("%s: %s (breaking)", c.Location, c.Message)
+	}
+	return fmt.Sprintf("%s: %s", c.Location, c.Message)
+}
+
+// ChangeSet is the list of the changes in the order of the paths, the methods, and the components.
+type ChangeSet []*Change
+
+// BreakingChanges returns the breaking changes only.
+func (s ChangeSet) BreakingChanges() ChangeSet {
+	var res ChangeSet
+	for _, c := range s {
+		if c.Breaking {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Diff compares the old and the new versions of a spec and returns the changes.
+//
+// The paths are matched regardless of the names of the path parameters, so `/pets/{id}` and `/pets/{petId}` are the same path.
+// The schemas of the parameters, the request bodies, and the responses are compared depending on the direction of the data:
+// narrowing a request schema, like removing an enum value, adding a required property, or removing a type, is breaking,
+// so as widening a response schema, like adding an enum value or making a property optional.
+// The `type`, `format`, `enum`, `required`, `properties`, `additionalProperties`, `items`, `pattern` keywords,
+// the limits, like `minimum`, `maxLength`, or `minItems`, and the subschemas of `allOf`, `anyOf`, and `oneOf`,
+// matched by their positions, are compared; the other keywords are not.
+// Removing a path, an operation, a media type, or a successful response,
+// and adding a required parameter or request body are breaking as well.
+// The effective security requirements of the operations are compared as the alternatives:
+// removing an alternative, including the anonymous access, is breaking, adding one is not.
+// The operations and the parameters becoming deprecated, and the changes of their sunset dates
+// (see openapi.SunsetExt) are reported as non-breaking changes, so they can be included in the changelogs.
+//
+// An error is returned if a reference can not be resolved, the changes found so far are returned along with it.
+func Diff(oldSpec, newSpec *openapi.Extendable[openapi.OpenAPI]) (ChangeSet, error) {
+	d := &differ{
+		oldComponents: components(oldSpec),
+		newComponents: components(newSpec),
+		oldSecurity:   security(oldSpec),
+		newSecurity:   security(newSpec),
+	}
+	d.diffPaths(paths(oldSpec), paths(newSpec))
+	d.diffComponentSchemas()
+	return d.changes, errors.Join(d.errs...)
+}
+
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+type differ struct {
+	oldComponents *openapi.Extendable[openapi.Components]
+	newComponents *openapi.Extendable[openapi.Components]
+	// oldSecurity and newSecurity are the top-level security requirements of the specs
+	oldSecurity []openapi.SecurityRequirement
+	newSecurity []openapi.SecurityRequirement
+	changes     ChangeSet
+	errs        []error
+}
+
+func (d *differ) add(kind ChangeKind, loc openapi.Location, breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, &Change{
+		Kind:     kind,
+		Location: loc,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (d *differ) fail(loc openapi.Location, err error) {
+	d.errs = append(d.errs, fmt.Errorf("%s: %w", loc, err))
+}
+
+func components(spec *openapi.Extendable[openapi.OpenAPI]) *openapi.Extendable[openapi.Components] {
+	if spec == nil || spec.Spec == nil {
+		return nil
+	}
+	return spec.Spec.Components
+}
+
+func security(spec *openapi.Extendable[openapi.OpenAPI]) []openapi.SecurityRequirement {
+	if spec == nil || spec.Spec == nil {
+		return nil
+	}
+	return spec.Spec.Security
+}
+
+func paths(spec *openapi.Extendable[openapi.OpenAPI]) map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.PathItem]] {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return nil
+	}
+	return spec.Spec.Paths.Spec.Paths
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]*}`)
+
+// pathShape returns the path template without the names of the parameters.
+func pathShape(template string) string {
+	return pathParam.ReplaceAllString(template, "{}")
+}
+
+// pathParams returns the names of the parameters of the path template in the order of their appearance.
+func pathParams(template string) []string {
+	matches := pathParam.FindAllString(template, -1)
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m[1 : len(m)-1]
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (d *differ) diffPaths(oldPaths, newPaths map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.PathItem]]) {
+	oldShapes := make(map[string]string, len(oldPaths))
+	for p := range oldPaths {
+		oldShapes[pathShape(p)] = p
+	}
+	newShapes := make(map[string]string, len(newPaths))
+	for p := range newPaths {
+		newShapes[pathShape(p)] = p
+	}
+	shapes := sortedKeys(oldShapes)
+	for shape := range newShapes {
+		if _, ok := oldShapes[shape]; !ok {
+			shapes = append(shapes, shape)
+		}
+	}
+	slices.SortFunc(shapes, func(a, b string) int {
+		return strings.Compare(pathOf(a, newShapes, oldShapes), pathOf(b, newShapes, oldShapes))
+	})
+	for _, shape := range shapes {
+		oldPath, inOld := oldShapes[shape]
+		newPath, inNew := newShapes[shape]
+		switch {
+		case !inNew:
+			d.add(PathRemoved, openapi.NewLocation("paths", oldPath), true, "path %q removed", oldPath)
+		case !inOld:
+			d.add(PathAdded, openapi.NewLocation("paths", newPath), false, "path %q added", newPath)
+		default:
+			d.diffPathItem(openapi.NewLocation("paths", newPath), oldPath, newPath, oldPaths[oldPath], newPaths[newPath])
+		}
+	}
+}
+
+func pathOf(shape string, newShapes, oldShapes map[string]string) string {
+	if p, ok := newShapes[shape]; ok {
+		return p
+	}
+	return oldShapes[shape]
+}
+
+// operations returns the operations of the path item by the methods in lower case.
+func operations(item *openapi.PathItem) map[string]*openapi.Extendable[openapi.Operation] {
+	ops := map[string]*openapi.Extendable[openapi.Operation]{
+		"get":     item.Get,
+		"put":     item.Put,
+		"post":    item.Post,
+		"delete":  item.Delete,
+		"options": item.Options,
+		"head":    item.Head,
+		"patch":   item.Patch,
+		"trace":   item.Trace,
+	}
+	for method, op := range ops {
+		if op == nil || op.Spec == nil {
+			delete(ops, method)
+		}
+	}
+	return ops
+}
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+func (d *differ) diffPathItem(loc openapi.Location, oldPath, newPath string, oldRef, newRef *openapi.RefOrSpec[openapi.Extendable[openapi.PathItem]]) {
+	oldItem, err := oldRef.GetSpec(d.oldComponents)
+	if err != nil {
+		d.fail(openapi.NewLocation("paths", oldPath), err)
+		return
+	}
+	newItem, err := newRef.GetSpec(d.newComponents)
+	if err != nil {
+		d.fail(loc, err)
+		return
+	}
+	renames := make(map[string]string)
+	newNames := pathParams(newPath)
+	for i, name := range pathParams(oldPath) {
+		renames[name] = newNames[i]
+	}
+	oldOps := operations(oldItem.Spec)
+	newOps := operations(newItem.Spec)
+	for _, method := range methods {
+		oldOp, inOld := oldOps[method]
+		newOp, inNew := newOps[method]
+		switch {
+		case inOld && !inNew:
+			d.add(OperationRemoved, openapi.NewLocation("paths", oldPath, method), true, "operation %s %s removed", strings.ToUpper(method), oldPath)
+		case !inOld && inNew:
+			d.add(OperationAdded, loc.Join(method), false, "operation %s %s added", strings.ToUpper(method), newPath)
+		case inOld && inNew:
+			opLoc := loc.Join(method)
+			oldLoc := openapi.NewLocation("paths", oldPath)
+			if !oldOp.Spec.Deprecated && newOp.Spec.Deprecated {
+				d.add(OperationBecameDeprecated, opLoc, false, "operation %s %s became deprecated", strings.ToUpper(method), newPath)
+			}
+			diffSunset(d, opLoc, oldOp, newOp)
+			d.diffSecurity(opLoc, oldOp.Spec, newOp.Spec)
+			d.diffParameters(
+				d.parameters(oldLoc, oldLoc.Join(method), oldItem.Spec, oldOp.Spec, d.oldComponents, renames),
+				d.parameters(loc, opLoc, newItem.Spec, newOp.Spec, d.newComponents, nil),
+			)
+			d.diffRequestBody(opLoc, oldOp.Spec.RequestBody, newOp.Spec.RequestBody)
+			d.diffResponses(opLoc, oldOp.Spec.Responses, newOp.Spec.Responses)
+		}
+	}
+}
+
+type locatedParameter struct {
+	location openapi.Location
+	ext      *openapi.Extendable[openapi.Parameter]
+	spec     *openapi.Parameter
+}
+
+// parameters returns the resolved parameters of the operation including the parameters of the path item, by their keys.
+// The names of the path parameters are replaced using the renames, if given.
+func (d *differ) parameters(
+	pathLoc, opLoc openapi.Location,
+	item *openapi.PathItem,
+	op *openapi.Operation,
+	c *openapi.Extendable[openapi.Components],
+	renames map[string]string,
+) map[string]*locatedParameter {
+	params := make(map[string]*locatedParameter)
+	collect := func(loc openapi.Location, list []*openapi.RefOrSpec[openapi.Extendable[openapi.Parameter]]) {
+		for i, ref := range list {
+			pLoc := loc.Join("parameters", i)
+			p, err := ref.GetSpec(c)
+			if err != nil {
+				d.fail(pLoc, err)
+				continue
+			}
+			name := p.Spec.Name
+			if p.Spec.In == openapi.InPath && renames != nil {
+				if renamed, ok := renames[name]; ok {
+					name = renamed
+				}
+			}
+			params[parameterKey(p.Spec.In, name)] = &locatedParameter{location: pLoc, ext: p, spec: p.Spec}
+		}
+	}
+	collect(pathLoc, item.Parameters)
+	// the parameters of the operation override the parameters of the path item
+	collect(opLoc, op.Parameters)
+	return params
+}
+
+// parameterKey returns the unique key of the parameter, the names of the headers are case-insensitive.
+func parameterKey(in, name string) string {
+	if in == openapi.InHeader {
+		name = strings.ToLower(name)
+	}
+	return in + ":" + name
+}
+
+func (d *differ) diffParameters(oldParams, newParams map[string]*locatedParameter) {
+	for _, key := range sortedKeys(oldParams) {
+		oldParam := oldParams[key]
+		newParam, ok := newParams[key]
+		if !ok {
+			d.add(ParameterRemoved, oldParam.location, false, "%s parameter %q removed", oldParam.spec.In, oldParam.spec.Name)
+			continue
+		}
+		switch {
+		case !oldParam.spec.Required && newParam.spec.Required:
+			d.add(ParameterBecameRequired, newParam.location, true, "%s parameter %q became required", newParam.spec.In, newParam.spec.Name)
+		case oldParam.spec.Required && !newParam.spec.Required:
+			d.add(ParameterBecameOptional, newParam.location, false, "%s parameter %q became optional", newParam.spec.In, newParam.spec.Name)
+		}
+		if !oldParam.spec.Deprecated && newParam.spec.Deprecated {
+			d.add(ParameterBecameDeprecated, newParam.location, false, "%s parameter %q became deprecated", newParam.spec.In, newParam.spec.Name)
+		}
+		diffSunset(d, newParam.location, oldParam.ext, newParam.ext)
+		d.diffSchema(newParam.location.Join("schema"), oldParam.spec.Schema, newParam.spec.Schema, request, nil)
+		d.diffContent(newParam.location, oldParam.location, oldParam.spec.Content, newParam.spec.Content, request)
+	}
+	for _, key := range sortedKeys(newParams) {
+		if _, ok := oldParams[key]; ok {
+			continue
+		}
+		p := newParams[key]
+		msg := "%s parameter %q added"
+		if p.spec.Required {
+			msg = "required %s parameter %q added"
+		}
+		d.add(ParameterAdded, p.location, p.spec.Required, msg, p.spec.In, p.spec.Name)
+	}
+}
+
+// diffSecurity compares the effective security requirements of the operations, each requirement is an alternative,
+// so removing one breaks the clients using it, while adding one does not.
+func (d *differ) diffSecurity(opLoc openapi.Location, oldOp, newOp *openapi.Operation) {
+	loc := opLoc.Join("security")
+	oldAlternatives := securityAlternatives(oldOp.Security, d.oldSecurity)
+	newAlternatives := securityAlternatives(newOp.Security, d.newSecurity)
+	for _, alt := range sortedKeys(oldAlternatives) {
+		if !newAlternatives[alt] {
+			d.add(SecurityRequirementRemoved, loc, true, "security requirement %s removed", alt)
+		}
+	}
+	for _, alt := range sortedKeys(newAlternatives) {
+		if !oldAlternatives[alt] {
+			d.add(SecurityRequirementAdded, loc, false, "security requirement %s added", alt)
+		}
+	}
+}
+
+// securityAlternatives returns the set of the security requirements of the operation,
+// the top-level ones are used if the operation has no requirements,
+// and the empty requirement means the anonymous access.
+func securityAlternatives(opSecurity, topSecurity []openapi.SecurityRequirement) map[string]bool {
+	security := opSecurity
+	if security == nil {
+		security = topSecurity
+	}
+	if len(security) == 0 {
+		return map[string]bool{"anonymous": true}
+	}
+	res := make(map[string]bool, len(security))
+	for _, req := range security {
+		if len(req) == 0 {
+			res["anonymous"] = true
+			continue
+		}
+		schemes := make([]string, 0, len(req))
+		for _, name := range sortedKeys(req) {
+			scopes := slices.Clone(req[name])
+			slices.Sort(scopes)
+			if len(scopes) > 0 {
+				name += "[" + strings.Join(scopes, ",") + "]"
+			}
+			schemes = append(schemes, name)
+		}
+		res[strings.Join(schemes, " and ")] = true
+	}
+	return res
+}
+
+// diffSunset compares the sunset dates of the object, the invalid dates are reported as errors.
+func diffSunset[T any](d *differ, loc openapi.Location, oldObj, newObj *openapi.Extendable[T]) {
+	loc = loc.Join(openapi.SunsetExt)
+	oldSunset, inOld, err := openapi.SunsetOf(oldObj)
+	if err != nil {
+		d.fail(loc, err)
+		return
+	}
+	newSunset, inNew, err := openapi.SunsetOf(newObj)
+	if err != nil {
+		d.fail(loc, err)
+		return
+	}
+	switch {
+	case !inOld && inNew:
+		d.add(SunsetAdded, loc, false, "sunset %s added", newSunset.UTC().Format(time.RFC3339))
+	case inOld && !inNew:
+		d.add(SunsetRemoved, loc, false, "sunset %s removed", oldSunset.UTC().Format(time.RFC3339))
+	case inOld && inNew && !oldSunset.Equal(newSunset):
+		d.add(SunsetChanged, loc, false, "sunset changed from %s to %s", oldSunset.UTC().Format(time.RFC3339), newSunset.UTC().Format(time.RFC3339))
+	}
+}
+
+func (d *differ) diffRequestBody(opLoc openapi.Location, oldRef, newRef *openapi.RefOrSpec[openapi.Extendable[openapi.RequestBody]]) {
+	loc := opLoc.Join("requestBody")
+	var oldBody, newBody *openapi.RequestBody
+	if oldRef != nil {
+		b, err := oldRef.GetSpec(d.oldComponents)
+		if err != nil {
+			d.fail(loc, err)
+			return
+		}
+		oldBody = b.Spec
+	}
+	if newRef != nil {
+		b, err := newRef.GetSpec(d.newComponents)
+		if err != nil {
+			d.fail(loc, err)
+			return
+		}
+		newBody = b.Spec
+	}
+	switch {
+	case oldBody == nil && newBody == nil:
+	case oldBody == nil:
+		msg := "request body added"
+		if newBody.Required {
+			msg = "required request body added"
+		}
+		d.add(RequestBodyAdded, loc, newBody.Required, msg)
+	case newBody == nil:
+		d.add(RequestBodyRemoved, loc, false, "request body removed")
+	default:
+		switch {
+		case !oldBody.Required && newBody.Required:
+			d.add(RequestBodyBecameRequired, loc, true, "request body became required")
+		case oldBody.Required && !newBody.Required:
+			d.add(RequestBodyBecameOptional, loc, false, "request body became optional")
+		}
+		d.diffContent(loc, loc, oldBody.Content, newBody.Content, request)
+	}
+}
+
+func (d *differ) diffResponses(opLoc openapi.Location, oldResponses, newResponses *openapi.Extendable[openapi.Responses]) {
+	loc := opLoc.Join("responses")
+	oldCodes := responses(oldResponses)
+	newCodes := responses(newResponses)
+	for _, code := range sortedKeys(oldCodes) {
+		if _, ok := newCodes[code]; !ok {
+			d.add(ResponseRemoved, loc.Join(code), strings.HasPrefix(code, "2"), "response %q removed", code)
+			continue
+		}
+		oldResponse, err := oldCodes[code].GetSpec(d.oldComponents)
+		if err != nil {
+			d.fail(loc.Join(code), err)
+			continue
+		}
+		newResponse, err := newCodes[code].GetSpec(d.newComponents)
+		if err != nil {
+			d.fail(loc.Join(code), err)
+			continue
+		}
+		d.diffContent(loc.Join(code), loc.Join(code), oldResponse.Spec.Content, newResponse.Spec.Content, response)
+	}
+	for _, code := range sortedKeys(newCodes) {
+		if _, ok := oldCodes[code]; !ok {
+			d.add(ResponseAdded, loc.Join(code), false, "response %q added", code)
+		}
+	}
+}
+
+// responses returns the responses by the status codes including `default`.
+func responses(r *openapi.Extendable[openapi.Responses]) map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]] {
+	res := make(map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]])
+	if r == nil || r.Spec == nil {
+		return res
+	}
+	for code, v := range r.Spec.Response {
+		res[code] = v
+	}
+	if r.Spec.Default != nil {
+		res["default"] = r.Spec.Default
+	}
+	return res
+}
+
+func (d *differ) diffContent(loc, oldLoc openapi.Location, oldContent, newContent map[string]*openapi.Extendable[openapi.MediaType], dir direction) {
+	for _, mediaType := range sortedKeys(oldContent) {
+		newMedia, ok := newContent[mediaType]
+		if !ok {
+			d.add(MediaTypeRemoved, oldLoc.Join("content", mediaType), true, "media type %q removed", mediaType)
+			continue
+		}
+		oldMedia := oldContent[mediaType]
+		if oldMedia == nil || oldMedia.Spec == nil || newMedia == nil || newMedia.Spec == nil {
+			continue
+		}
+		d.diffSchema(loc.Join("content", mediaType, "schema"), oldMedia.Spec.Schema, newMedia.Spec.Schema, dir, nil)
+	}
+	for _, mediaType := range sortedKeys(newContent) {
+		if _, ok := oldContent[mediaType]; !ok {
+			d.add(MediaTypeAdded, loc.Join("content", mediaType), false, "media type %q added", mediaType)
+		}
+	}
+}
+
+// diffSchema compares the schemas used in the given direction,
+// the stack holds the pairs of the compared parent schemas to stop on the recursive references.
+func (d *differ) diffSchema(loc openapi.Location, oldRef, newRef *openapi.RefOrSpec[openapi.Schema], dir direction, stack map[[2]*openapi.Schema]bool) {
+	if oldRef == nil || newRef == nil {
+		return
+	}
+	oldSchema, err := oldRef.GetSpec(d.oldComponents)
+	if err != nil {
+		d.fail(loc, err)
+		return
+	}
+	newSchema, err := newRef.GetSpec(d.newComponents)
+	if err != nil {
+		d.fail(loc, err)
+		return
+	}
+	pair := [2]*openapi.Schema{oldSchema, newSchema}
+	if stack[pair] {
+		return
+	}
+	if stack == nil {
+		stack = make(map[[2]*openapi.Schema]bool)
+	}
+	stack[pair] = true
+	defer delete(stack, pair)
+
+	d.diffTypes(loc, oldSchema, newSchema, dir)
+	if oldSchema.Format != newSchema.Format {
+		breaking := dir == request && newSchema.Format != "" || dir == response && oldSchema.Format != ""
+		d.add(FormatChanged, loc, breaking, "format changed from %q to %q", oldSchema.Format, newSchema.Format)
+	}
+	d.diffEnum(loc, oldSchema.Enum, newSchema.Enum, dir)
+
+	d.diffLimit(loc, "minimum", oldSchema.Minimum, newSchema.Minimum, true, dir)
+	d.diffLimit(loc, "exclusiveMinimum", oldSchema.ExclusiveMinimum, newSchema.ExclusiveMinimum, true, dir)
+	d.diffLimit(loc, "maximum", oldSchema.Maximum, newSchema.Maximum, false, dir)
+	d.diffLimit(loc, "exclusiveMaximum", oldSchema.ExclusiveMaximum, newSchema.ExclusiveMaximum, false, dir)
+	d.diffLimit(loc, "minLength", intNumber(oldSchema.MinLength), intNumber(newSchema.MinLength), true, dir)
+	d.diffLimit(loc, "maxLength", intNumber(oldSchema.MaxLength), intNumber(newSchema.MaxLength), false, dir)
+	d.diffLimit(loc, "minItems", intNumber(oldSchema.MinItems), intNumber(newSchema.MinItems), true, dir)
+	d.diffLimit(loc, "maxItems", intNumber(oldSchema.MaxItems), intNumber(newSchema.MaxItems), false, dir)
+	d.diffPattern(loc, oldSchema.Pattern, newSchema.Pattern, dir)
+
+	for _, name := range newSchema.Required {
+		if !slices.Contains(oldSchema.Required, name) {
+			d.add(RequiredPropertyAdded, loc, dir == request, "property %q became required", name)
+		}
+	}
+	for _, name := range oldSchema.Required {
+		if !slices.Contains(newSchema.Required, name) {
+			d.add(RequiredPropertyRemoved, loc, dir == response, "property %q became optional", name)
+		}
+	}
+
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		newProp, ok := newSchema.Properties[name]
+		if !ok {
+			d.add(PropertyRemoved, loc.Join("properties", name), dir == response, "property %q removed", name)
+			continue
+		}
+		d.diffSchema(loc.Join("properties", name), oldSchema.Properties[name], newProp, dir, stack)
+	}
+	for _, name := range sortedKeys(newSchema.Properties) {
+		if _, ok := oldSchema.Properties[name]; !ok {
+			d.add(PropertyAdded, loc.Join("properties", name), false, "property %q added", name)
+		}
+	}
+
+	d.diffAdditionalProperties(loc.Join("additionalProperties"), oldSchema.AdditionalProperties, newSchema.AdditionalProperties, dir, stack)
+
+	if oldSchema.Items != nil && newSchema.Items != nil {
+		d.diffSchema(loc.Join("items"), oldSchema.Items.Schema, newSchema.Items.Schema, dir, stack)
+	}
+
+	d.diffSubschemas(loc, "allOf", oldSchema.AllOf, newSchema.AllOf, dir, stack)
+	d.diffSubschemas(loc, "anyOf", oldSchema.AnyOf, newSchema.AnyOf, dir, stack)
+	d.diffSubschemas(loc, "oneOf", oldSchema.OneOf, newSchema.OneOf, dir, stack)
+}
+
+// diffLimit compares a lower or an upper limit of the schemas, e.g. `minimum` or `maxItems`:
+// adding a limit or moving it inwards narrows the schema, removing a limit or moving it outwards widens it.
+func (d *differ) diffLimit(loc openapi.Location, keyword string, oldLimit, newLimit *openapi.Number, lower bool, dir direction) {
+	var narrowed bool
+	var msg string
+	switch {
+	case oldLimit == nil && newLimit == nil:
+		return
+	case oldLimit == nil:
+		narrowed = true
+		msg = fmt.Sprintf("%s %s added", keyword, *newLimit)
+	case newLimit == nil:
+		msg = fmt.Sprintf("%s %s removed", keyword, *oldLimit)
+	default:
+		c := newLimit.Cmp(*oldLimit)
+		if c == 0 {
+			return
+		}
+		narrowed = c > 0 == lower
+		msg = fmt.Sprintf("%s changed from %s to %s", keyword, *oldLimit, *newLimit)
+	}
+	if narrowed {
+		d.add(LimitNarrowed, loc, dir == request, "%s", msg)
+	} else {
+		d.add(LimitWidened, loc, dir == response, "%s", msg)
+	}
+}
+
+func intNumber(v *int) *openapi.Number {
+	if v == nil {
+		return nil
+	}
+	n := openapi.IntNumber(int64(*v))
+	return &n
+}
+
+// diffPattern compares the patterns of the schemas, a changed pattern can both narrow and widen the schema.
+func (d *differ) diffPattern(loc openapi.Location, oldPattern, newPattern string, dir direction) {
+	switch {
+	case oldPattern == newPattern:
+	case oldPattern == "":
+		d.add(PatternChanged, loc, dir == request, "pattern %q added", newPattern)
+	case newPattern == "":
+		d.add(PatternChanged, loc, dir == response, "pattern %q removed", oldPattern)
+	default:
+		d.add(PatternChanged, loc, true, "pattern changed from %q to %q", oldPattern, newPattern)
+	}
+}
+
+// diffAdditionalProperties compares the additionalProperties keywords, no keyword allows any properties.
+func (d *differ) diffAdditionalProperties(loc openapi.Location, oldValue, newValue *openapi.BoolOrSchema, dir direction, stack map[[2]*openapi.Schema]bool) {
+	oldAllowed, newAllowed := allowsProperties(oldValue), allowsProperties(newValue)
+	switch {
+	case oldAllowed && !newAllowed:
+		d.add(AdditionalPropertiesNarrowed, loc, dir == request, "additional properties disallowed")
+	case !oldAllowed && newAllowed:
+		d.add(AdditionalPropertiesWidened, loc, dir == response, "additional properties allowed")
+	case oldAllowed && newAllowed:
+		oldSchema, newSchema := propertiesSchema(oldValue), propertiesSchema(newValue)
+		switch {
+		case oldSchema == nil && newSchema != nil:
+			d.add(AdditionalPropertiesNarrowed, loc, dir == request, "schema of additional properties added")
+		case oldSchema != nil && newSchema == nil:
+			d.add(AdditionalPropertiesWidened, loc, dir == response, "schema of additional properties removed")
+		case oldSchema != nil && newSchema != nil:
+			d.diffSchema(loc, oldSchema, newSchema, dir, stack)
+		}
+	}
+}
+
+func allowsProperties(v *openapi.BoolOrSchema) bool {
+	return v == nil || v.Allowed || v.Schema != nil
+}
+
+func propertiesSchema(v *openapi.BoolOrSchema) *openapi.RefOrSpec[openapi.Schema] {
+	if v == nil {
+		return nil
+	}
+	return v.Schema
+}
+
+// diffSubschemas compares the subschemas of `allOf`, `anyOf`, or `oneOf` keyword by their positions:
+// a new subschema of `allOf` narrows the schema, while a new alternative of `anyOf` or `oneOf` widens it,
+// unless the keyword is added.
+func (d *differ) diffSubschemas(loc openapi.Location, keyword string, oldList, newList []*openapi.RefOrSpec[openapi.Schema], dir direction, stack map[[2]*openapi.Schema]bool) {
+	for i := 0; i < len(oldList) && i < len(newList); i++ {
+		d.diffSchema(loc.Join(keyword, i), oldList[i], newList[i], dir, stack)
+	}
+	for i := len(newList); i < len(oldList); i++ {
+		d.addComposition(loc.Join(keyword, i), keyword != "allOf" && len(newList) > 0, dir, "%s subschema %d removed", keyword, i)
+	}
+	for i := len(oldList); i < len(newList); i++ {
+		d.addComposition(loc.Join(keyword, i), keyword == "allOf" || len(oldList) == 0, dir, "%s subschema %d added", keyword, i)
+	}
+}
+
+func (d *differ) addComposition(loc openapi.Location, narrowed bool, dir direction, format string, args ...any) {
+	if narrowed {
+		d.add(CompositionNarrowed, loc, dir == request, format, args...)
+	} else {
+		d.add(CompositionWidened, loc, dir == response, format, args...)
+	}
+}
+
+// diffTypes compares the types of the schemas, no types means any type and `number` includes `integer`.
+func (d *differ) diffTypes(loc openapi.Location, oldSchema, newSchema *openapi.Schema, dir direction) {
+	oldTypes := schemaTypes(oldSchema)
+	newTypes := schemaTypes(newSchema)
+	if slices.Equal(oldTypes, newTypes) {
+		return
+	}
+	narrowed := len(newTypes) > 0 && (len(oldTypes) == 0 || !coversTypes(newTypes, oldTypes))
+	widened := len(oldTypes) > 0 && (len(newTypes) == 0 || !coversTypes(oldTypes, newTypes))
+	if !narrowed && !widened {
+		return
+	}
+	breaking := dir == request && narrowed || dir == response && widened
+	d.add(TypeChanged, loc, breaking, "type changed from %s to %s", typesString(oldTypes), typesString(newTypes))
+}
+
+func schemaTypes(schema *openapi.Schema) []string {
+	if schema.Type == nil {
+		return nil
+	}
+	types := slices.Clone(*schema.Type)
+	slices.Sort(types)
+	return slices.Compact(types)
+}
+
+// coversTypes reports whether the types include all the given types.
+func coversTypes(types, other []string) bool {
+	for _, t := range other {
+		if !slices.Contains(types, t) && !(t == openapi.IntegerType && slices.Contains(types, openapi.NumberType)) {
+			return false
+		}
+	}
+	return true
+}
+
+func typesString(types []string) string {
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, "|")
+}
+
+func (d *differ) diffEnum(loc openapi.Location, oldEnum, newEnum []any, dir direction) {
+	switch {
+	case len(oldEnum) == 0 && len(newEnum) == 0:
+		return
+	case len(oldEnum) == 0:
+		d.add(EnumNarrowed, loc, dir == request, "enum added")
+		return
+	case len(newEnum) == 0:
+		d.add(EnumWidened, loc, dir == response, "enum removed")
+		return
+	}
+	oldValues := enumValues(oldEnum)
+	newValues := enumValues(newEnum)
+	var removed, added []string
+	for _, v := range sortedKeys(oldValues) {
+		if !newValues[v] {
+			removed = append(removed, v)
+		}
+	}
+	for _, v := range sortedKeys(newValues) {
+		if !oldValues[v] {
+			added = append(added, v)
+		}
+	}
+	if len(removed) > 0 {
+		d.add(EnumNarrowed, loc, dir == request, "enum values removed: %s", strings.Join(removed, ", "))
+	}
+	if len(added) > 0 {
+		d.add(EnumWidened, loc, dir == response, "enum values added: %s", strings.Join(added, ", "))
+	}
+}
+
+// enumValues returns the set of the JSON representations of the values.
+func enumValues(values []any) map[string]bool {
+	res := make(map[string]bool, len(values))
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprint(v))
+		}
+		res[string(data)] = true
+	}
+	return res
+}
+
+func (d *differ) diffComponentSchemas() {
+	var oldSchemas, newSchemas map[string]*openapi.RefOrSpec[openapi.Schema]
+	if d.oldComponents != nil && d.oldComponents.Spec != nil {
+		oldSchemas = d.oldComponents.Spec.Schemas
+	}
+	if d.newComponents != nil && d.newComponents.Spec != nil {
+		newSchemas = d.newComponents.Spec.Schemas
+	}
+	for _, name := range sortedKeys(oldSchemas) {
+		if _, ok := newSchemas[name]; !ok {
+			d.add(SchemaRemoved, openapi.NewLocation("components", "schemas", name), false, "schema %q removed", name)
+		}
+	}
+	for _, name := range sortedKeys(newSchemas) {
+		if _, ok := oldSchemas[name]; !ok {
+			d.add(SchemaAdded, openapi.NewLocation("components", "schemas", name), false, "schema %q added", name)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package diff_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/diff"
+)
+
+func loadSpec(t *testing.T, name string) *openapi.Extendable[openapi.OpenAPI] {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+	return spec
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := diff.Diff(loadSpec(t, "old.yaml"), loadSpec(t, "new.yaml"))
+	require.NoError(t, err)
+
+	actual := make([]string, len(changes))
+	for i, c := range changes {
+		actual[i] = c.String()
+	}
+	require.Equal(t, []string{
+		`/paths/~1owners: path "/owners" added`,
+		`/paths/~1pets/get: operation GET /pets became deprecated`,
+		`/paths/~1pets/get/x-sunset: sunset 2031-01-01T00:00:00Z added`,
+		`/paths/~1pets/get/parameters/1: header parameter "X-Request-ID" removed`,
+		`/paths/~1pets/get/parameters/0: query parameter "limit" became required (breaking)`,
+		`/paths/~1pets/get/parameters/0: query parameter "limit" became deprecated`,
+		`/paths/~1pets/get/parameters/1: query parameter "offset" added`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/items: property "kind" became optional (breaking)`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/age: type changed from integer to number (breaking)`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/kind: enum values added: "bird" (breaking)`,
+		`/paths/~1pets/get/responses/200/content/application~1xml: media type "application/xml" removed (breaking)`,
+		`/paths/~1pets/get/responses/400: response "400" added`,
+		`/paths/~1pets/post/x-sunset: sunset changed from 2030-01-01T00:00:00Z to 2030-06-01T00:00:00Z`,
+		`/paths/~1pets/post/requestBody: request body became required (breaking)`,
+		`/paths/~1pets/post/requestBody/content/application~1json/schema: property "kind" became required (breaking)`,
+		`/paths/~1pets/post/requestBody/content/application~1json/schema/properties/kind: enum values removed: "fish" (breaking)`,
+		`/paths/~1pets/post/requestBody/content/application~1json/schema/properties/name: format changed from "" to "email" (breaking)`,
+		`/paths/~1pets/post/requestBody/content/application~1json/schema/properties/weight: type changed from integer to number`,
+		`/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema: property "kind" became optional (breaking)`,
+		`/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/properties/age: type changed from integer to number (breaking)`,
+		`/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema/properties/kind: enum values added: "bird" (breaking)`,
+		`/paths/~1pets~1{id}/delete: operation DELETE /pets/{id} removed (breaking)`,
+		`/paths/~1stores: path "/stores" removed (breaking)`,
+		`/components/schemas/Store: schema "Store" removed`,
+		`/components/schemas/Owner: schema "Owner" added`,
+	}, actual)
+
+	breaking := changes.BreakingChanges()
+	require.Len(t, breaking, 14)
+	for _, c := range breaking {
+		require.True(t, c.Breaking)
+	}
+}
+
+func TestDiff_Same(t *testing.T) {
+	spec := loadSpec(t, "old.yaml")
+	changes, err := diff.Diff(spec, spec)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	changes, err = diff.Diff(nil, openapi.NewOpenAPIBuilder().Build())
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestDiff_Reversed(t *testing.T) {
+	changes, err := diff.Diff(loadSpec(t, "new.yaml"), loadSpec(t, "old.yaml"))
+	require.NoError(t, err)
+	actual := make([]string, len(changes))
+	for i, c := range changes {
+		actual[i] = c.String()
+	}
+	for _, expected := range []string{
+		`/paths/~1owners: path "/owners" removed (breaking)`,
+		// the narrowed request
+		`/paths/~1pets/post/requestBody/content/application~1json/schema/properties/weight: type changed from number to integer (breaking)`,
+		`/paths/~1pets/post/requestBody/content/application~1json/schema: property "kind" became optional`,
+		// the narrowed response
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/items: property "kind" became required`,
+		`/paths/~1pets/get/responses/200/content/application~1json/schema/items/properties/kind: enum values removed: "bird"`,
+		// the sunset is removed, but the deprecation is not reported in reverse
+		`/paths/~1pets/get/x-sunset: sunset 2031-01-01T00:00:00Z removed`,
+		// not a successful response
+		`/paths/~1pets/get/responses/400: response "400" removed`,
+	} {
+		require.Contains(t, actual, expected)
+	}
+}
+
+func TestDiff_InvalidSunset(t *testing.T) {
+	spec := loadSpec(t, "new.yaml")
+	broken := loadSpec(t, "new.yaml")
+	broken.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.AddExt(openapi.SunsetExt, "tomorrow")
+	_, err := diff.Diff(spec, broken)
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	require.ErrorContains(t, err, "/paths/~1pets/get/x-sunset")
+}
+
+func TestDiff_UnresolvedRef(t *testing.T) {
+	spec := loadSpec(t, "new.yaml")
+	broken := loadSpec(t, "new.yaml")
+	delete(broken.Spec.Components.Spec.Schemas, "NewPet")
+	_, err := diff.Diff(spec, broken)
+	require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	require.ErrorContains(t, err, "/paths/~1pets/post/requestBody/content/application~1json/schema")
+}
+
+const schemaSpec = `
+openapi: 3.1.0
+info: {title: pets, version: "1"}
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: %[1]s
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema: %[1]s
+`
+
+func diffStrings(t *testing.T, oldYAML, newYAML string) []string {
+	t.Helper()
+	var oldSpec, newSpec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(oldYAML), &oldSpec))
+	require.NoError(t, yaml.Unmarshal([]byte(newYAML), &newSpec))
+	changes, err := diff.Diff(oldSpec, newSpec)
+	require.NoError(t, err)
+	actual := make([]string, len(changes))
+	for i, c := range changes {
+		actual[i] = c.String()
+	}
+	return actual
+}
+
+func TestDiff_SchemaKeywords(t *testing.T) {
+	const (
+		req  = "/paths/~1pets/post/requestBody/content/application~1json/schema"
+		resp = "/paths/~1pets/post/responses/200/content/application~1json/schema"
+	)
+	for _, tt := range []struct {
+		name      string
+		oldSchema string
+		newSchema string
+		expected  []string
+	}{
+		{
+			name:      "allOf added",
+			oldSchema: `{allOf: [{type: object}]}`,
+			newSchema: `{allOf: [{type: object}, {required: [name]}]}`,
+			expected: []string{
+				req + "/allOf/1: allOf subschema 1 added (breaking)",
+				resp + "/allOf/1: allOf subschema 1 added",
+			},
+		},
+		{
+			name:      "oneOf removed",
+			oldSchema: `{oneOf: [{type: string}, {type: integer}]}`,
+			newSchema: `{oneOf: [{type: string}]}`,
+			expected: []string{
+				req + "/oneOf/1: oneOf subschema 1 removed (breaking)",
+				resp + "/oneOf/1: oneOf subschema 1 removed",
+			},
+		},
+		{
+			name:      "anyOf widened",
+			oldSchema: `{anyOf: [{type: string}]}`,
+			newSchema: `{anyOf: [{type: string}, {type: integer}]}`,
+			expected: []string{
+				req + "/anyOf/1: anyOf subschema 1 added",
+				resp + "/anyOf/1: anyOf subschema 1 added (breaking)",
+			},
+		},
+		{
+			name:      "anyOf subschema narrowed",
+			oldSchema: `{anyOf: [{type: string}]}`,
+			newSchema: `{anyOf: [{type: string, maxLength: 10}]}`,
+			expected: []string{
+				req + "/anyOf/0: maxLength 10 added (breaking)",
+				resp + "/anyOf/0: maxLength 10 added",
+			},
+		},
+		{
+			name:      "additionalProperties disallowed",
+			oldSchema: `{type: object}`,
+			newSchema: `{type: object, additionalProperties: false}`,
+			expected: []string{
+				req + "/additionalProperties: additional properties disallowed (breaking)",
+				resp + "/additionalProperties: additional properties disallowed",
+			},
+		},
+		{
+			name:      "additionalProperties schema narrowed",
+			oldSchema: `{type: object, additionalProperties: {type: [string, integer]}}`,
+			newSchema: `{type: object, additionalProperties: {type: string}}`,
+			expected: []string{
+				req + "/additionalProperties: type changed from integer|string to string (breaking)",
+				resp + "/additionalProperties: type changed from integer|string to string",
+			},
+		},
+		{
+			name:      "minimum increased",
+			oldSchema: `{type: integer, minimum: 1}`,
+			newSchema: `{type: integer, minimum: 5}`,
+			expected: []string{
+				req + ": minimum changed from 1 to 5 (breaking)",
+				resp + ": minimum changed from 1 to 5",
+			},
+		},
+		{
+			name:      "maximum removed",
+			oldSchema: `{type: number, maximum: 10.5}`,
+			newSchema: `{type: number}`,
+			expected: []string{
+				req + ": maximum 10.5 removed",
+				resp + ": maximum 10.5 removed (breaking)",
+			},
+		},
+		{
+			name:      "minLength added",
+			oldSchema: `{type: string}`,
+			newSchema: `{type: string, minLength: 1}`,
+			expected: []string{
+				req + ": minLength 1 added (breaking)",
+				resp + ": minLength 1 added",
+			},
+		},
+		{
+			name:      "maxLength increased",
+			oldSchema: `{type: string, maxLength: 10}`,
+			newSchema: `{type: string, maxLength: 20}`,
+			expected: []string{
+				req + ": maxLength changed from 10 to 20",
+				resp + ": maxLength changed from 10 to 20 (breaking)",
+			},
+		},
+		{
+			name:      "pattern changed",
+			oldSchema: `{type: string, pattern: "^[a-z]+$"}`,
+			newSchema: `{type: string, pattern: "^[a-z0-9]+$"}`,
+			expected: []string{
+				req + `: pattern changed from "^[a-z]+$" to "^[a-z0-9]+$" (breaking)`,
+				resp + `: pattern changed from "^[a-z]+$" to "^[a-z0-9]+$" (breaking)`,
+			},
+		},
+		{
+			name:      "pattern added",
+			oldSchema: `{type: string}`,
+			newSchema: `{type: string, pattern: "^[a-z]+$"}`,
+			expected: []string{
+				req + `: pattern "^[a-z]+$" added (breaking)`,
+				resp + `: pattern "^[a-z]+$" added`,
+			},
+		},
+		{
+			name:      "minItems decreased",
+			oldSchema: `{type: array, minItems: 2}`,
+			newSchema: `{type: array, minItems: 1}`,
+			expected: []string{
+				req + ": minItems changed from 2 to 1",
+				resp + ": minItems changed from 2 to 1 (breaking)",
+			},
+		},
+		{
+			name:      "maxItems added",
+			oldSchema: `{type: array}`,
+			newSchema: `{type: array, maxItems: 100}`,
+			expected: []string{
+				req + ": maxItems 100 added (breaking)",
+				resp + ": maxItems 100 added",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := diffStrings(t, fmt.Sprintf(schemaSpec, tt.oldSchema), fmt.Sprintf(schemaSpec, tt.newSchema))
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+const securitySpec = `
+openapi: 3.1.0
+info: {title: pets, version: "1"}
+security: %s
+paths:
+  /pets:
+    get:
+      security: %s
+      responses:
+        "200":
+          description: ok
+`
+
+func TestDiff_Security(t *testing.T) {
+	const loc = "/paths/~1pets/get/security"
+	for _, tt := range []struct {
+		name        string
+		oldSecurity [2]string
+		newSecurity [2]string
+		expected    []string
+	}{
+		{
+			name:        "same effective requirements",
+			oldSecurity: [2]string{`[{apiKey: []}]`, `null`},
+			newSecurity: [2]string{`[]`, `[{apiKey: []}]`},
+			expected:    []string{},
+		},
+		{
+			name:        "alternative added",
+			oldSecurity: [2]string{`null`, `[{apiKey: []}]`},
+			newSecurity: [2]string{`null`, `[{apiKey: []}, {oauth2: [write, read]}]`},
+			expected: []string{
+				loc + ": security requirement oauth2[read,write] added",
+			},
+		},
+		{
+			name:        "alternative removed",
+			oldSecurity: [2]string{`[{apiKey: []}, {basic: []}]`, `null`},
+			newSecurity: [2]string{`[{apiKey: []}]`, `null`},
+			expected: []string{
+				loc + ": security requirement basic removed (breaking)",
+			},
+		},
+		{
+			name:        "scopes changed",
+			oldSecurity: [2]string{`null`, `[{apiKey: [], oauth2: [read]}]`},
+			newSecurity: [2]string{`null`, `[{apiKey: [], oauth2: [read, write]}]`},
+			expected: []string{
+				loc + ": security requirement apiKey and oauth2[read] removed (breaking)",
+				loc + ": security requirement apiKey and oauth2[read,write] added",
+			},
+		},
+		{
+			name:        "security required",
+			oldSecurity: [2]string{`null`, `null`},
+			newSecurity: [2]string{`[{apiKey: []}]`, `null`},
+			expected: []string{
+				loc + ": security requirement anonymous removed (breaking)",
+				loc + ": security requirement apiKey added",
+			},
+		},
+		{
+			name:        "anonymous access allowed",
+			oldSecurity: [2]string{`[{apiKey: []}]`, `null`},
+			newSecurity: [2]string{`[{apiKey: []}]`, `[{apiKey: []}, {}]`},
+			expected: []string{
+				loc + ": security requirement anonymous added",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := diffStrings(t,
+				fmt.Sprintf(securitySpec, tt.oldSecurity[0], tt.oldSecurity[1]),
+				fmt.Sprintf(securitySpec, tt.newSecurity[0], tt.newSecurity[1]),
+			)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -40,35 +40,3 @@ func (o *Discriminator) validateSpec(location string, validator *Validator) []*v
 	}
 	return errs
 }
-
-type DiscriminatorBuilder struct {
-	spec *Discriminator
-}
-
-func NewDiscriminatorBuilder() *DiscriminatorBuilder {
-	return &DiscriminatorBuilder{
-		spec: &Discriminator{},
-	}
-}
-
-func (b *DiscriminatorBuilder) Build() *Discriminator {
-	return b.spec
-}
-
-func (b *DiscriminatorBuilder) Mapping(v map[string]string) *DiscriminatorBuilder {
-	b.spec.Mapping = v
-	return b
-}
-
-func (b *DiscriminatorBuilder) AddMapping(name, value string) *DiscriminatorBuilder {
-	if b.spec.Mapping == nil {
-		b.spec.Mapping = make(map[string]string, 1)
-	}
-	b.spec.Mapping[name] = value
-	return b
-}
-
-func (b *DiscriminatorBuilder) PropertyName(v string) *DiscriminatorBuilder {
-	b.spec.PropertyName = v
-	return b
-}
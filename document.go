@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Document is a thread-safe holder of the specification and its validator.
+//
+// The specification returned by the Document must be treated as read-only, the changes are made
+// using Update method on a copy of the specification (copy-on-write), which is validated and atomically
+// swapped with the current one, so the long-running services can use the specification and the validator
+// while an updater hot-reloads it.
+type Document struct {
+	mu        sync.RWMutex
+	spec      *Extendable[OpenAPI]
+	validator *Validator
+
+	// updateMu serializes the updates, so the concurrent updates are not lost
+	updateMu sync.Mutex
+	opts     []ValidationOption
+}
+
+// NewDocument validates the given specification using the given options and creates a Document.
+func NewDocument(spec *Extendable[OpenAPI], opts ...ValidationOption) (*Document, error) {
+	validator, err := newValidSpecValidator(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		spec:      spec,
+		validator: validator,
+		opts:      opts,
+	}, nil
+}
+
+// Spec returns the current specification, it must not be modified.
+func (d *Document) Spec() *Extendable[OpenAPI] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.spec
+}
+
+// Validator returns the validator of the current specification.
+func (d *Document) Validator() *Validator {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.validator
+}
+
+// Snapshot returns the current specification and its validator consistently.
+func (d *Document) Snapshot() (*Extendable[OpenAPI], *Validator) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.spec, d.validator
+}
+
+// Update calls the given function with a deep copy of the current specification,
+// validates the modified copy and swaps it with the current specification.
+// The current specification is kept if the function or the validation fails.
+func (d *Document) Update(f func(spec *Extendable[OpenAPI]) error) error {
+	d.updateMu.Lock()
+	defer d.updateMu.Unlock()
+
+	spec := copySpec(d.Spec())
+	if err := f(spec); err != nil {
+		return err
+	}
+	return d.swap(spec)
+}
+
+// Swap validates the given specification and replaces the current specification with it.
+// The previous specification is returned.
+func (d *Document) Swap(spec *Extendable[OpenAPI]) (*Extendable[OpenAPI], error) {
+	d.updateMu.Lock()
+	defer d.updateMu.Unlock()
+
+	old := d.Spec()
+	if err := d.swap(spec); err != nil {
+		return nil, err
+	}
+	return old, nil
+}
+
+func (d *Document) swap(spec *Extendable[OpenAPI]) error {
+	validator, err := newValidSpecValidator(spec, d.opts)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.spec = spec
+	d.validator = validator
+	return nil
+}
+
+func newValidSpecValidator(spec *Extendable[OpenAPI], opts []ValidationOption) (*Validator, error) {
+	validator, err := NewValidator(spec, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateSpec(); err != nil {
+		return nil, err
+	}
+	return validator, nil
+}
+
+// copySpec creates a deep copy of the specification.
+//
+// The copy is made field by field instead of marshaling, since the JSON representation loses the difference
+// between nil and empty values, e.g. the `security: []` of an operation, which opts out of the security.
+func copySpec(spec *Extendable[OpenAPI]) *Extendable[OpenAPI] {
+	newSpec, _ := deepCopy(reflect.ValueOf(spec), make(map[copyKey]reflect.Value)).Interface().(*Extendable[OpenAPI])
+	return newSpec
+}
+
+type copyKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopy returns a deep copy of the value, the objects shared by several pointers are copied once.
+func deepCopy(v reflect.Value, seen map[copyKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return c
+	case reflect.Struct:
+		// the unexported fields are copied as is
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,115 @@
+package openapi_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestDocument(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Test").Version("1.0.0").Build()).
+		AddComponent("ID", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		Build()
+	doc, err := openapi.NewDocument(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.Same(t, spec, doc.Spec())
+	require.NoError(t, doc.Validator().ValidateData("/components/schemas/ID", 1))
+
+	t.Run("update", func(t *testing.T) {
+		require.NoError(t, doc.Update(func(spec *openapi.Extendable[openapi.OpenAPI]) error {
+			spec.Spec.Components.Spec.Add("ID", openapi.NewSchemaBuilder().Type(openapi.StringType).Build())
+			return nil
+		}))
+		// copy-on-write
+		require.Equal(t, openapi.NewSingleOrArray(openapi.IntegerType), spec.Spec.Components.Spec.Schemas["ID"].Spec.Type)
+		require.NotSame(t, spec, doc.Spec())
+		s, v := doc.Snapshot()
+		require.Equal(t, openapi.NewSingleOrArray(openapi.StringType), s.Spec.Components.Spec.Schemas["ID"].Spec.Type)
+		require.NoError(t, v.ValidateData("/components/schemas/ID", "foo"))
+	})
+
+	t.Run("update failed", func(t *testing.T) {
+		current := doc.Spec()
+		require.ErrorContains(t, doc.Update(func(spec *openapi.Extendable[openapi.OpenAPI]) error {
+			return errors.New("foo")
+		}), "foo")
+		require.Error(t, doc.Update(func(spec *openapi.Extendable[openapi.OpenAPI]) error {
+			spec.Spec.Info = nil
+			return nil
+		}))
+		require.Same(t, current, doc.Spec())
+	})
+
+	t.Run("update keeps empty security", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(`
+openapi: 3.1.1
+info:
+  title: Test
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /health:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`), &spec))
+		doc, err := openapi.NewDocument(spec)
+		require.NoError(t, err)
+		require.NoError(t, doc.Update(func(spec *openapi.Extendable[openapi.OpenAPI]) error {
+			return nil
+		}))
+		s := doc.Spec()
+		require.NotSame(t, spec, s)
+		op := s.Spec.Paths.Spec.Paths["/health"].Spec.Spec.Get
+		require.NotNil(t, op.Spec.Security)
+		security, err := openapi.EffectiveSecurity(op, s)
+		require.NoError(t, err)
+		require.Empty(t, security)
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		current := doc.Spec()
+		old, err := doc.Swap(spec)
+		require.NoError(t, err)
+		require.Same(t, current, old)
+		require.Same(t, spec, doc.Spec())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				require.NoError(t, doc.Update(func(spec *openapi.Extendable[openapi.OpenAPI]) error {
+					spec.Spec.Components.Spec.Add(fmt.Sprintf("Name%d", i), openapi.NewSchemaBuilder().Type(openapi.StringType).Build())
+					return nil
+				}))
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, v := doc.Snapshot()
+				require.NoError(t, v.ValidateData("/components/schemas/ID", 1))
+			}()
+		}
+		wg.Wait()
+		require.Len(t, doc.Spec().Spec.Components.Spec.Schemas, 11)
+	})
+}
@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// documentSet is the spec split into several files, see Documents option.
+type documentSet struct {
+	docs map[string]*Extendable[OpenAPI]
+	// json are the JSON representations of the documents registered in the compilers
+	json map[string]any
+}
+
+// newDocumentSet marshals the documents given by Documents option and registers them in the compilers
+// next to the spec, so the schemas can reference each other.
+func newDocumentSet(options *validationOptions) (*documentSet, error) {
+	if len(options.documents) == 0 {
+		return nil, nil
+	}
+	set := &documentSet{
+		docs: options.documents,
+		json: make(map[string]any, len(options.documents)),
+	}
+	for name, doc := range options.documents {
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("%w: document %q must be located in the directory of the spec", ErrInvalidValue, name)
+		}
+		value, err := marshalToJSONValue(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %q: %w", name, err)
+		}
+		set.json[name] = value
+	}
+	// the documents are registered before the other resources, so the documents given to Clone
+	// replace the ones of the original validator, which cannot be added again with the same URLs
+	options.updateCompiler = append([]func(*jsonschema.Compiler){func(c *jsonschema.Compiler) {
+		for name, value := range set.json {
+			// the URLs are valid, so the resources are always added unless they are registered already
+			_ = c.AddResource(documentURL(name), value)
+		}
+	}}, options.updateCompiler...)
+	return set, nil
+}
+
+// documentURL returns the URL of the document in the compilers, relative to the URL of the spec.
+func documentURL(name string) string {
+	return specPrefix + (&url.URL{Path: "/" + name}).EscapedPath()
+}
+
+// resolveDocumentRef returns the path of the document and the fragment of the given ref
+// relative to the document with the given path.
+func resolveDocumentRef(base, ref string) (name, fragment string) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	if file == "" {
+		return base, "#" + fragment
+	}
+	if unescaped, err := url.PathUnescape(file); err == nil {
+		file = unescaped
+	}
+	return path.Join(path.Dir(base), file), "#" + fragment
+}
+
+// validateDocumentRef checks that the ref to another document resolves to the component of the expected type.
+func validateDocumentRef[T any](location, ref string, validator *Validator) []*validationError {
+	name, fragment := resolveDocumentRef(validator.document, ref)
+	if validator.documentRefs[name+fragment] {
+		return nil
+	}
+	validator.documentRefs[name+fragment] = true
+	if _, err := documentSpec[T](validator.documents, name, fragment); err != nil {
+		return []*validationError{newValidationError(location, err)}
+	}
+	return nil
+}
+
+// documentSpec returns the component of the document, following the refs of the component.
+func documentSpec[T any](set *documentSet, name, fragment string) (*T, error) {
+	visited := make(visitedObjects)
+	for {
+		key := name + fragment
+		if visited[key] {
+			return nil, fmt.Errorf("%w: cycle ref %q detected; all visited refs: %s", ErrUnresolvedRef, key, visited)
+		}
+		visited[key] = true
+		doc := set.docs[name]
+		if doc == nil || doc.Spec == nil {
+			return nil, fmt.Errorf("%w: document %q not found", ErrUnresolvedRef, name)
+		}
+		if !strings.HasPrefix(fragment, "#/components/") {
+			return nil, fmt.Errorf("%w: only the components of the documents can be referenced, but got %q", ErrUnresolvedRef, key)
+		}
+		if doc.Spec.Components == nil || doc.Spec.Components.Spec == nil {
+			return nil, fmt.Errorf("%w: %q not found", ErrUnresolvedRef, key)
+		}
+		component, err := componentByRef(doc.Spec.Components, fragment)
+		if err != nil {
+			return nil, fmt.Errorf("document %q: %w", name, err)
+		}
+		obj, ok := component.(*RefOrSpec[T])
+		if !ok {
+			return nil, fmt.Errorf("%w: expected spec of type %T, but got %T", ErrUnresolvedRef, RefOrSpec[T]{}, component)
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("%w: %q not found", ErrUnresolvedRef, key)
+		}
+		if obj.Spec != nil {
+			return obj.Spec, nil
+		}
+		name, fragment = resolveDocumentRef(name, obj.Ref.Ref)
+	}
+}
+
+// validateDocuments validates the documents given by Documents option in place,
+// the locations of the errors are prefixed with the paths of the documents.
+func (v *Validator) validateDocuments() []*validationError {
+	if v.documents == nil {
+		return nil
+	}
+	var errs []*validationError
+	validators := make(map[string]*Validator, len(v.documents.docs))
+	for _, name := range sortedKeys(v.documents.docs) {
+		doc := v.documents.docs[name]
+		if doc == nil {
+			continue
+		}
+		// the unused components are checked once all documents are validated
+		opts := *v.opts
+		opts.allowUnusedComponents = true
+		validator := &Validator{
+			spec:              doc,
+			opts:              &opts,
+			visited:           make(visitedObjects),
+			linkToOperationID: make(map[string]string),
+			documents:         v.documents,
+			document:          name,
+			documentRefs:      v.documentRefs,
+		}
+		validator.cache.Store(v.cache.Load())
+		validators[name] = validator
+		for _, e := range doc.validateSpec("", validator) {
+			e.location = name + "#" + e.location
+			errs = append(errs, e)
+		}
+	}
+	if v.opts.allowUnusedComponents {
+		return errs
+	}
+	for _, name := range sortedKeys(validators) {
+		validator := validators[name]
+		c := validator.spec.Spec.Components
+		if c == nil || c.Spec == nil {
+			continue
+		}
+		fields := componentMaps(c.Spec)
+		for _, typ := range sortedKeys(fields) {
+			keys := fields[typ].MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			for _, k := range keys {
+				ref := joinLoc("#", "components", typ, k.String())
+				if !validator.visited[ref] && !v.documentRefs[name+ref] {
+					errs = append(errs, newValidationError(name+ref, ErrUnused))
+				}
+			}
+		}
+	}
+	return errs
+}
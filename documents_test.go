@@ -0,0 +1,214 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const (
+	testDocumentsRoot = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: 'schemas/pets.yaml#/components/schemas/Pet'
+`
+	testDocumentsPets = `
+openapi: 3.1.1
+info:
+  title: Pets schemas
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          example: Rex
+        tag:
+          $ref: '#/components/schemas/Tag'
+        owner:
+          $ref: '../common/owner.yaml#/components/schemas/Owner'
+      required: [name]
+    Tag:
+      type: string
+`
+	testDocumentsOwner = `
+openapi: 3.1.1
+info:
+  title: Common schemas
+  version: 1.0.0
+components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        id:
+          type: integer
+`
+)
+
+func TestDocuments(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		root  string
+		pets  string
+		owner string
+		err   []string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "missing component",
+			root: strings.Replace(testDocumentsRoot, "schemas/Pet", "schemas/Cat", 1),
+			err: []string{
+				"/paths/~1pets/get/responses/200/content/application~1json/schema/items: unresolved reference: \"schemas/pets.yaml#/components/schemas/Cat\" not found",
+				"schemas/pets.yaml#/components/schemas/Pet: unused",
+			},
+		},
+		{
+			name: "missing document",
+			root: strings.Replace(testDocumentsRoot, "schemas/pets.yaml", "pets.yaml", 1),
+			err:  []string{"unresolved reference: document \"pets.yaml\" not found"},
+		},
+		{
+			name: "invalid example in document",
+			pets: strings.Replace(testDocumentsPets, "example: Rex", "example: 42", 1),
+			err:  []string{"schemas/pets.yaml#/components/schemas/Pet/properties/name/example: invalid data"},
+		},
+		{
+			name: "unresolved ref in document",
+			pets: strings.Replace(testDocumentsPets, "schemas/Owner", "schemas/User", 1),
+			err: []string{
+				"schemas/pets.yaml#/components/schemas/Pet/properties/owner: unresolved reference: \"common/owner.yaml#/components/schemas/User\" not found",
+				"common/owner.yaml#/components/schemas/Owner: unused",
+			},
+		},
+		{
+			name:  "unused component of document",
+			owner: testDocumentsOwner + "    Unused:\n      type: string\n",
+			err:   []string{"common/owner.yaml#/components/schemas/Unused: unused"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			root, pets, owner := tt.root, tt.pets, tt.owner
+			if root == "" {
+				root = testDocumentsRoot
+			}
+			if pets == "" {
+				pets = testDocumentsPets
+			}
+			if owner == "" {
+				owner = testDocumentsOwner
+			}
+			var rootDoc, petsDoc, ownerDoc *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(root), &rootDoc))
+			require.NoError(t, yaml.Unmarshal([]byte(pets), &petsDoc))
+			require.NoError(t, yaml.Unmarshal([]byte(owner), &ownerDoc))
+			validator, err := openapi.NewValidator(rootDoc, openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+				"schemas/pets.yaml":   petsDoc,
+				"./common/owner.yaml": ownerDoc,
+			}))
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if len(tt.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, e := range tt.err {
+				require.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
+
+func TestDocuments_ValidateData(t *testing.T) {
+	var root, pets, owner *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsRoot), &root))
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsPets), &pets))
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsOwner), &owner))
+	validator, err := openapi.NewValidator(root, openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"schemas/pets.yaml":   pets,
+		"./common/owner.yaml": owner,
+	}))
+	require.NoError(t, err)
+	const location = "/paths/~1pets/get/responses/200/content/application~1json/schema"
+
+	require.NoError(t, validator.ValidateData(location, []any{map[string]any{"name": "Rex", "tag": "dog", "owner": map[string]any{"id": 1}}}))
+	require.ErrorContains(t, validator.ValidateData(location, []any{map[string]any{"name": "Rex", "owner": map[string]any{"id": "1"}}}), "/0/owner/id")
+	require.ErrorContains(t, validator.ValidateData(location, []any{map[string]any{"tag": "dog"}}), "missing property 'name'")
+}
+
+func TestDocuments_OutsideOfSpecDirectory(t *testing.T) {
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsOwner), &doc))
+	_, err := openapi.NewValidator(doc, openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"../owner.yaml": doc,
+	}))
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+}
+
+func TestDocuments_NotGiven(t *testing.T) {
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsRoot), &doc))
+	validator, err := openapi.NewValidator(doc)
+	require.NoError(t, err)
+	require.ErrorIs(t, validator.ValidateSpec(), openapi.ErrUnresolvedRef)
+}
+
+func TestDocuments_Clone(t *testing.T) {
+	var root, pets, owner, newOwner *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsRoot), &root))
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsPets), &pets))
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsOwner), &owner))
+	require.NoError(t, yaml.Unmarshal([]byte(strings.Replace(testDocumentsOwner, "type: integer", "type: string", 1)), &newOwner))
+	const location = "/paths/~1pets/get/responses/200/content/application~1json/schema"
+	intOwner := []any{map[string]any{"name": "Rex", "owner": map[string]any{"id": 1}}}
+	stringOwner := []any{map[string]any{"name": "Rex", "owner": map[string]any{"id": "1"}}}
+
+	validator, err := openapi.NewValidator(root, openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"schemas/pets.yaml": pets,
+	}))
+	require.NoError(t, err)
+	require.ErrorIs(t, validator.ValidateSpec(), openapi.ErrUnresolvedRef)
+
+	clone, err := validator.Clone(openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"common/owner.yaml": owner,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, clone.ValidateSpec())
+	require.NoError(t, clone.ValidateData(location, intOwner))
+	require.Error(t, clone.ValidateData(location, stringOwner))
+
+	// the document given to the clone replaces the one of the original validator
+	other, err := clone.Clone(openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"common/owner.yaml": newOwner,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, other.ValidateData(location, stringOwner))
+	require.Error(t, other.ValidateData(location, intOwner))
+
+	// the original validators are not changed
+	require.NoError(t, clone.ValidateData(location, intOwner))
+	require.Error(t, clone.ValidateData(location, stringOwner))
+	require.ErrorIs(t, validator.ValidateSpec(), openapi.ErrUnresolvedRef)
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// anchorNameRe is the syntax of the plain name fragments used by `$anchor` and `$dynamicAnchor` keywords.
+//
+// https://json-schema.org/draft/2020-12/json-schema-core#name-defining-location-independe
+var anchorNameRe = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9._]*$`)
+
+// dynamicAnchorsOf returns the names of all `$dynamicAnchor` keywords of the given value.
+func dynamicAnchorsOf(v any) map[string]bool {
+	anchors := make(map[string]bool)
+	walkSpec(reflect.ValueOf(v), func(v reflect.Value) {
+		if s, ok := v.Interface().(*Schema); ok && s.DynamicAnchor != "" {
+			anchors[s.DynamicAnchor] = true
+		}
+	})
+	return anchors
+}
+
+// findDynamicAnchor returns the name of the schema component having the given `$dynamicAnchor`
+// and the schema declaring it; the components are checked in the order of their names.
+func findDynamicAnchor(components *Extendable[Components], name string) (string, *Schema) {
+	if components == nil || components.Spec == nil {
+		return "", nil
+	}
+	for _, component := range sortedKeys(components.Spec.Schemas) {
+		var found *Schema
+		walkSpec(reflect.ValueOf(components.Spec.Schemas[component]), func(v reflect.Value) {
+			if s, ok := v.Interface().(*Schema); ok && found == nil && s.DynamicAnchor == name {
+				found = s
+			}
+		})
+		if found != nil {
+			return component, found
+		}
+	}
+	return "", nil
+}
+
+// resolveDynamicRef returns the schema referenced by the given `$dynamicRef`.
+//
+// The document is a single schema resource, so the plain name fragment (`#name`) is resolved
+// to the `$dynamicAnchor` of the schema components with the same name,
+// and the JSON Pointer fragment is resolved like `$ref`.
+func resolveDynamicRef(ref string, components *Extendable[Components]) (*RefOrSpec[Schema], error) {
+	name, ok := strings.CutPrefix(ref, "#")
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: loading outside of the document is not implemented for the dynamic ref %q", ErrUnresolvedRef, ref)
+	case strings.HasPrefix(name, "/"):
+		target := NewRefOrSpec[Schema](ref)
+		if _, err := target.GetSpec(components); err != nil {
+			return nil, err
+		}
+		return target, nil
+	}
+	if _, spec := findDynamicAnchor(components, name); spec != nil {
+		return NewRefOrSpec[Schema](spec), nil
+	}
+	return nil, fmt.Errorf("%w: no $dynamicAnchor %q found for the dynamic ref %q", ErrUnresolvedRef, name, ref)
+}
+
+// validateDynamicKeywords checks the syntax of `$dynamicAnchor` and that `$dynamicRef` has a matching anchor.
+func (o *Schema) validateDynamicKeywords(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	if o.DynamicAnchor != "" && !anchorNameRe.MatchString(o.DynamicAnchor) {
+		errs = append(errs, newValidationError(joinLoc(location, "$dynamicAnchor"), "%w: %q must start with a letter or `_` followed by letters, digits, `-`, `_`, or `.`", ErrInvalidFormat, o.DynamicAnchor))
+	}
+	if o.DynamicRef == "" {
+		return errs
+	}
+	name, ok := strings.CutPrefix(o.DynamicRef, "#")
+	switch {
+	case !ok:
+		// the references to other documents are not checked
+	case strings.HasPrefix(name, "/"):
+		errs = append(errs, NewRefOrSpec[Schema](o.DynamicRef).validateSpec(joinLoc(location, "$dynamicRef"), validator)...)
+	case !validator.hasDynamicAnchor(name):
+		errs = append(errs, newValidationError(joinLoc(location, "$dynamicRef"), "%w: no $dynamicAnchor %q in scope", ErrUnresolvedRef, name))
+	default:
+		// the component declaring the anchor is used
+		if component, _ := findDynamicAnchor(validator.spec.Spec.Components, name); component != "" {
+			validator.visited[joinLoc("#", "components", "schemas", component)] = true
+		}
+	}
+	return errs
+}
+
+// hasDynamicAnchor reports whether the spec has a `$dynamicAnchor` with the given name,
+// the anchors are collected once per ValidateSpec call.
+func (v *Validator) hasDynamicAnchor(name string) bool {
+	if v.dynamicAnchors == nil {
+		v.dynamicAnchors = dynamicAnchorsOf(v.spec)
+	}
+	return v.dynamicAnchors[name]
+}
@@ -0,0 +1,100 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testDynamicRefSpec = `
+openapi: 3.1.1
+info:
+  title: Dynamic refs
+  version: 1.0.0
+paths:
+  /trees:
+    get:
+      tags: [trees]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $dynamicRef: '#node'
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+`
+
+func TestDynamicRef(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDynamicRefSpec), &spec))
+	components := spec.Spec.Components
+	tree := components.Spec.Schemas["Tree"]
+	require.Equal(t, "node", tree.Spec.DynamicAnchor)
+
+	data, err := yaml.Marshal(tree)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "$dynamicAnchor: node\n")
+
+	t.Run("validate", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec, openapi.AllowUndefinedTagsInOperation())
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+		require.NoError(t, validator.ValidateData("/components/schemas/Tree", map[string]any{
+			"children": []any{map[string]any{"children": []any{}}},
+		}))
+		require.Error(t, validator.ValidateData("/components/schemas/Tree", map[string]any{"children": []any{1}}))
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		items := tree.Spec.Properties["children"].Spec.Items.Schema
+		resolved, err := openapi.ResolveSchema(items, components)
+		require.NoError(t, err)
+		require.Empty(t, resolved.DynamicRef)
+		require.Len(t, resolved.AllOf, 1)
+		require.Same(t, tree.Spec, resolved.AllOf[0].Spec)
+
+		missing := openapi.NewSchemaBuilder().DynamicRef("#missing").Build()
+		_, err = openapi.ResolveSchema(missing, components)
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		docs, err := openapi.SplitByTags(spec)
+		require.NoError(t, err)
+		require.Contains(t, docs["trees"].Spec.Components.Spec.Schemas, "Tree")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		components := openapi.NewComponents()
+		components.Spec.Add("Bad", openapi.NewSchemaBuilder().DynamicAnchor("1bad").Build())
+		components.Spec.Add("Dangling", openapi.NewSchemaBuilder().DynamicRef("#missing").Build())
+		components.Spec.Add("Pointer", openapi.NewSchemaBuilder().DynamicRef("#/components/schemas/Missing").Build())
+		spec := openapi.NewOpenAPIBuilder().
+			Info(openapi.NewInfoBuilder().Title("Dynamic refs").Version("1.0.0").Build()).
+			Components(components).
+			Build()
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		err = validator.ValidateSpec()
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+		require.ErrorContains(t, err, `/components/schemas/Bad/$dynamicAnchor: invalid format: "1bad"`)
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+		require.ErrorContains(t, err, `/components/schemas/Dangling/$dynamicRef: unresolved reference: no $dynamicAnchor "missing" in scope`)
+		require.ErrorContains(t, err, `/components/schemas/Pointer/$dynamicRef: unresolved reference`)
+	})
+}
@@ -62,9 +62,10 @@ type Encoding struct {
 	// For other types of properties this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
+	// The nil value means the default one, so the explicit false value is kept.
 	// This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded or multipart/form-data.
 	// If a value is explicitly defined, then the value of contentType (implicit or explicit) SHALL be ignored.
-	Explode bool `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Explode *bool `json:"explode,omitempty" yaml:"explode,omitempty"`
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by [RFC3986]
 	//   :/?#[]@!$&'()*+,;=
 	// to be included without percent-encoding.
@@ -85,45 +86,14 @@ func (o *Encoding) validateSpec(location string, validator *Validator) []*valida
 	switch o.Style {
 	case "", StyleForm, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject:
 	default:
-		errs = append(errs, newValidationError(joinLoc(location, "style"), "invalid value, expected one of [%s, %s, %s, %s], but got '%s'", StyleForm, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject, o.Style))
+		errs = append(errs, newValidationError(joinLoc(location, "style"), "%w, expected one of [%s, %s, %s, %s], but got '%s'", ErrInvalidValue, StyleForm, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject, o.Style))
 	}
 	return errs
 }
 
-type EncodingBuilder struct {
-	spec *Extendable[Encoding]
-}
-
-func NewEncodingBuilder() *EncodingBuilder {
-	return &EncodingBuilder{
-		spec: NewExtendable[Encoding](&Encoding{}),
-	}
-}
-
-func (b *EncodingBuilder) Build() *Extendable[Encoding] {
-	return b.spec
-}
-
-func (b *EncodingBuilder) Extensions(v map[string]any) *EncodingBuilder {
-	b.spec.Extensions = v
-	return b
-}
-
-func (b *EncodingBuilder) AddExt(name string, value any) *EncodingBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *EncodingBuilder) ContentType(v string) *EncodingBuilder {
-	b.spec.Spec.ContentType = v
-	return b
-}
-
-func (b *EncodingBuilder) Headers(v map[string]*RefOrSpec[Extendable[Header]]) *EncodingBuilder {
-	b.spec.Spec.Headers = v
-	return b
-}
-
+// Header adds the header by the name.
+//
+// Deprecated: use AddHeader, like the other builders.
 func (b *EncodingBuilder) Header(name string, value *RefOrSpec[Extendable[Header]]) *EncodingBuilder {
 	if b.spec.Spec.Headers == nil {
 		b.spec.Spec.Headers = make(map[string]*RefOrSpec[Extendable[Header]], 1)
@@ -131,18 +101,3 @@ func (b *EncodingBuilder) Header(name string, value *RefOrSpec[Extendable[Header
 	b.spec.Spec.Headers[name] = value
 	return b
 }
-
-func (b *EncodingBuilder) Style(v string) *EncodingBuilder {
-	b.spec.Spec.Style = v
-	return b
-}
-
-func (b *EncodingBuilder) Explode(v bool) *EncodingBuilder {
-	b.spec.Spec.Explode = v
-	return b
-}
-
-func (b *EncodingBuilder) AllowReserved(v bool) *EncodingBuilder {
-	b.spec.Spec.AllowReserved = v
-	return b
-}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EnvironmentExt is the name of the extension holding the name of the environment of a server.
+const EnvironmentExt = "x-environment"
+
+// Environments is a set of named environments (e.g. dev, stage, prod) and their servers in the declaration order.
+//
+// Example:
+//
+//	envs := openapi.NewEnvironments().
+//		Add("dev", openapi.NewServerBuilder().URL("https://dev.example.com/v1").Build()).
+//		Add("prod", openapi.NewServerBuilder().URL("https://{region}.example.com/v1").
+//			AddVariable("region", openapi.NewServerVariableBuilder().Default("eu").Enum("eu", "us").Build()).
+//			Build())
+//	envs.Apply(spec)
+//	...
+//	server := openapi.ServerForEnvironment(spec.Spec.Servers, os.Getenv("ENV"))
+type Environments struct {
+	names   []string
+	servers []*Extendable[Server]
+}
+
+// NewEnvironments creates an empty set of environments.
+func NewEnvironments() *Environments {
+	return &Environments{}
+}
+
+// Add adds the environment with the given name, or replaces its server if the environment already exists.
+// The name of the environment is stored in the `x-environment` extension of the server.
+func (e *Environments) Add(name string, server *Extendable[Server]) *Environments {
+	server.AddExt(EnvironmentExt, name)
+	if i := slices.Index(e.names, name); i >= 0 {
+		e.servers[i] = server
+		return e
+	}
+	e.names = append(e.names, name)
+	e.servers = append(e.servers, server)
+	return e
+}
+
+// Names returns the names of the environments in the declaration order.
+func (e *Environments) Names() []string {
+	return slices.Clone(e.names)
+}
+
+// Servers returns the servers of the environments in the declaration order.
+func (e *Environments) Servers() []*Extendable[Server] {
+	return slices.Clone(e.servers)
+}
+
+// Apply replaces the servers of the given spec with the servers of the environments.
+func (e *Environments) Apply(spec *Extendable[OpenAPI]) {
+	spec.Spec.Servers = e.Servers()
+}
+
+// ApplyToOperation replaces the alternative servers of the given operation with the servers of the environments.
+func (e *Environments) ApplyToOperation(op *Extendable[Operation]) {
+	op.Spec.Servers = e.Servers()
+}
+
+// ServerForEnvironment returns the server of the given environment or nil if there is no such server.
+func ServerForEnvironment(servers []*Extendable[Server], name string) *Extendable[Server] {
+	for _, s := range servers {
+		if s != nil && s.GetExt(EnvironmentExt) == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// ResolveURL returns the URL of the server with the variables substituted by the given values
+// or by the default values of the variables.
+// An error is returned if a value is not one of the enum values of the variable or the variable is not defined.
+func (o *Server) ResolveURL(values map[string]string) (string, error) {
+	for name := range values {
+		if _, ok := o.Variables[name]; !ok {
+			return "", fmt.Errorf("%w: variable %q is not defined", ErrInvalidValue, name)
+		}
+	}
+	oldnew := make([]string, 0, len(o.Variables)*2)
+	for name, v := range o.Variables {
+		value, ok := values[name]
+		if !ok && v.Spec != nil {
+			value = v.Spec.Default
+		}
+		if v.Spec != nil && len(v.Spec.Enum) > 0 && !slices.Contains(v.Spec.Enum, value) {
+			return "", fmt.Errorf("%w: value %q of variable %q, expected one of %v", ErrInvalidValue, value, name, v.Spec.Enum)
+		}
+		oldnew = append(oldnew, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(o.URL), nil
+}
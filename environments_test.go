@@ -0,0 +1,91 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestEnvironments(t *testing.T) {
+	envs := openapi.NewEnvironments().
+		Add("dev", openapi.NewServerBuilder().URL("https://dev.example.com/v1").Build()).
+		Add("prod", openapi.NewServerBuilder().
+			URL("https://{region}.example.com/v1").
+			AddVariable("region", openapi.NewServerVariableBuilder().Default("eu").Enum("eu", "us").Build()).
+			Build())
+	require.Equal(t, []string{"dev", "prod"}, envs.Names())
+
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Test").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().JSONResponseFrom("200", "").Build()).
+			Build()).
+		Build()
+	envs.Apply(spec)
+	require.Len(t, spec.Spec.Servers, 2)
+
+	op := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get
+	openapi.NewEnvironments().
+		Add("local", openapi.NewServerBuilder().URL("http://localhost:8080/v1").Build()).
+		ApplyToOperation(op)
+	require.Len(t, op.Spec.Servers, 1)
+
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	// the environments survive the serialization
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	var loaded *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &loaded))
+
+	require.Nil(t, openapi.ServerForEnvironment(loaded.Spec.Servers, "stage"))
+	dev := openapi.ServerForEnvironment(loaded.Spec.Servers, "dev")
+	require.NotNil(t, dev)
+	require.Equal(t, "https://dev.example.com/v1", dev.Spec.URL)
+	prod := openapi.ServerForEnvironment(loaded.Spec.Servers, "prod")
+	require.NotNil(t, prod)
+	u, err := prod.Spec.ResolveURL(nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://eu.example.com/v1", u)
+
+	t.Run("replace", func(t *testing.T) {
+		envs.Add("dev", openapi.NewServerBuilder().URL("https://dev2.example.com").Build())
+		require.Equal(t, []string{"dev", "prod"}, envs.Names())
+		require.Equal(t, "https://dev2.example.com", envs.Servers()[0].Spec.URL)
+	})
+}
+
+func TestServer_ResolveURL(t *testing.T) {
+	server := openapi.NewServerBuilder().
+		URL("https://{region}.example.com:{port}/v1").
+		AddVariable("region", openapi.NewServerVariableBuilder().Default("eu").Enum("eu", "us").Build()).
+		AddVariable("port", openapi.NewServerVariableBuilder().Default("443").Build()).
+		Build()
+
+	for _, tt := range []struct {
+		name     string
+		values   map[string]string
+		expected string
+		err      string
+	}{
+		{name: "defaults", expected: "https://eu.example.com:443/v1"},
+		{name: "values", values: map[string]string{"region": "us", "port": "8443"}, expected: "https://us.example.com:8443/v1"},
+		{name: "not in enum", values: map[string]string{"region": "asia"}, err: `invalid value: value "asia" of variable "region"`},
+		{name: "unknown variable", values: map[string]string{"zone": "a"}, err: `invalid value: variable "zone" is not defined`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := server.Spec.ResolveURL(tt.values)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, u)
+		})
+	}
+}
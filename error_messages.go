@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrorMessageExt is the name of the schema extension overriding the messages of the data validation errors,
+// e.g. to return the domain-specific messages by an API.
+// The value is the message for any failed keyword of the schema or the messages by the keywords.
+// The messages of the not listed keywords and of the nested schemas are not changed.
+//
+// Example:
+//
+//	sku:
+//	  type: string
+//	  pattern: ^[A-Z]{8}$
+//	  x-error-message: SKU must be 8 uppercase characters
+//	tags:
+//	  type: array
+//	  maxItems: 5
+//	  x-error-message:
+//	    maxItems: no more than 5 tags are allowed
+//
+// If any message is overridden, ValidateData returns ViolationsError listing all violations,
+// see Violations, with the overridden messages.
+const ErrorMessageExt = "x-error-message"
+
+// ErrorMessage sets the message of the data validation errors for any failed keyword of the schema,
+// see ErrorMessageExt.
+func (b *SchemaBulder) ErrorMessage(msg string) *SchemaBulder {
+	b.spec.Spec.AddExt(ErrorMessageExt, msg)
+	return b
+}
+
+// ErrorMessages sets the messages of the data validation errors by the keywords of the schema,
+// see ErrorMessageExt.
+func (b *SchemaBulder) ErrorMessages(messages map[string]string) *SchemaBulder {
+	b.spec.Spec.AddExt(ErrorMessageExt, messages)
+	return b
+}
+
+// errorMessage returns the message of ErrorMessageExt extension of the failed schema for the violation.
+func (v *Validator) errorMessage(violation *Violation) (string, bool) {
+	base, fragment, _ := strings.Cut(violation.schemaURL, "#")
+	var doc any
+	switch {
+	case base == specPrefix:
+		doc = v.cache.Load().doc
+	case v.documents != nil && strings.HasPrefix(base, specPrefix+"/"):
+		name, err := url.PathUnescape(strings.TrimPrefix(base, specPrefix+"/"))
+		if err != nil {
+			return "", false
+		}
+		doc = v.documents.json[name]
+	}
+	for _, segment := range Location(fragment).Segments() {
+		switch t := doc.(type) {
+		case map[string]any:
+			doc = t[segment]
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(t) {
+				return "", false
+			}
+			doc = t[i]
+		default:
+			return "", false
+		}
+	}
+	schema, _ := doc.(map[string]any)
+	switch msg := schema[ErrorMessageExt].(type) {
+	case string:
+		return msg, msg != ""
+	case map[string]any:
+		s, ok := msg[violation.Keyword].(string)
+		return s, ok && s != ""
+	}
+	return "", false
+}
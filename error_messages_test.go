@@ -0,0 +1,152 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testErrorMessagesSpec = `
+openapi: 3.1.1
+info:
+  title: Products
+  version: 1.0.0
+paths:
+  /products:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Product'
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Product:
+      type: object
+      required: [sku]
+      x-error-message:
+        required: SKU is required
+      properties:
+        sku:
+          type: string
+          pattern: ^[A-Z]{8}$
+          x-error-message: SKU must be 8 uppercase characters
+        tags:
+          type: array
+          maxItems: 2
+          items:
+            type: string
+          x-error-message:
+            maxItems: no more than 2 tags are allowed
+        price:
+          type: number
+          minimum: 0
+`
+
+func TestErrorMessageExt(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testErrorMessagesSpec), &spec))
+	validator, err := openapi.NewValidator(spec, openapi.ValidateStringDataAsJSON())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		data     string
+		location openapi.Location
+		message  string
+	}{
+		{name: "any keyword", data: `{"sku": "abc"}`, location: "/sku", message: "SKU must be 8 uppercase characters"},
+		{name: "type", data: `{"sku": 42}`, location: "/sku", message: "SKU must be 8 uppercase characters"},
+		{name: "by keyword", data: `{"sku": "ABCDEFGH", "tags": ["a", "b", "c"]}`, location: "/tags", message: "no more than 2 tags are allowed"},
+		{name: "root", data: `{}`, location: "", message: "SKU is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateData("#/components/schemas/Product", tt.data)
+			var violationsErr *openapi.ViolationsError
+			require.ErrorAs(t, err, &violationsErr)
+			require.Equal(t, openapi.MsgSchemaViolation, openapi.MessageIDOf(err))
+			require.Len(t, violationsErr.Violations, 1)
+			require.Equal(t, tt.location, violationsErr.Violations[0].Location)
+			require.Equal(t, tt.message, violationsErr.Violations[0].Message)
+			require.Contains(t, err.Error(), tt.message)
+			require.Equal(t, violationsErr.Violations, openapi.Violations(err, 0))
+		})
+	}
+
+	t.Run("not listed keyword", func(t *testing.T) {
+		err := validator.ValidateData("#/components/schemas/Product", `{"sku": "ABCDEFGH", "tags": [1]}`)
+		require.Error(t, err)
+		violations := openapi.Violations(err, 0)
+		require.Len(t, violations, 1)
+		require.Equal(t, openapi.Location("/tags/0"), violations[0].Location)
+		require.NotContains(t, violations[0].Message, "tags are allowed")
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		err := validator.ValidateData("#/components/schemas/Product", `{"sku": "abc", "price": -1}`)
+		require.ErrorContains(t, err, "/sku: SKU must be 8 uppercase characters")
+		require.Len(t, openapi.Violations(err, 0), 2)
+	})
+
+	t.Run("no overrides", func(t *testing.T) {
+		err := validator.ValidateData("#/components/schemas/Product", `{"sku": "ABCDEFGH", "price": -1}`)
+		require.Error(t, err)
+		var violationsErr *openapi.ViolationsError
+		require.NotErrorAs(t, err, &violationsErr)
+	})
+}
+
+func TestErrorMessageExt_MaxViolations(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testErrorMessagesSpec), &spec))
+	validator, err := openapi.NewValidator(spec, openapi.ValidateStringDataAsJSON(), openapi.MaxViolations(1))
+	require.NoError(t, err)
+	err = validator.ValidateData("#/components/schemas/Product", `{"sku": "abc", "tags": ["a", "b", "c"], "price": -1}`)
+	var violationsErr *openapi.ViolationsError
+	require.ErrorAs(t, err, &violationsErr)
+	require.Len(t, violationsErr.Violations, 1)
+	require.Equal(t, 3, violationsErr.Total)
+}
+
+func TestErrorMessageExt_Request(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testErrorMessagesSpec), &spec))
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"sku": "abc"}`))
+	r.Header.Set("Content-Type", "application/json")
+	err = validator.ValidateHTTPRequest(r)
+	require.ErrorContains(t, err, "SKU must be 8 uppercase characters")
+}
+
+func TestSchemaBulder_ErrorMessage(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().
+		Type(openapi.StringType).
+		Pattern(`^[A-Z]{8}$`).
+		ErrorMessage("SKU must be 8 uppercase characters").
+		Build()
+	require.Equal(t, "SKU must be 8 uppercase characters", schema.Spec.Extensions[openapi.ErrorMessageExt])
+
+	messages := map[string]string{"maxItems": "no more than 2 tags are allowed"}
+	schema = openapi.NewSchemaBuilder().Type(openapi.ArrayType).MaxItems(2).ErrorMessages(messages).Build()
+	require.Equal(t, messages, schema.Spec.Extensions[openapi.ErrorMessageExt])
+
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Products").Version("1.0.0").Build()).
+		Components(openapi.NewComponents()).
+		Build()
+	spec.Spec.Components.Spec.Add("Tags", schema)
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	err = validator.ValidateData("#/components/schemas/Tags", []any{"a", "b", "c"})
+	require.EqualError(t, err, "no more than 2 tags are allowed")
+}
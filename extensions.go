@@ -55,7 +55,7 @@ func (o *Extendable[T]) AddExt(name string, value any) *Extendable[T] {
 	return o
 }
 
-// GetExt returns the extension value by name.
+// GetExt returns the extension value by name, the raw values (see KeepRawExtensions) are decoded.
 // The `x-` prefix will be added automatically to given name.
 func (o *Extendable[T]) GetExt(name string) any {
 	if o.Extensions == nil {
@@ -64,13 +64,101 @@ func (o *Extendable[T]) GetExt(name string) any {
 	if !strings.HasPrefix(name, ExtensionPrefix) {
 		name = ExtensionPrefix + name
 	}
-	return o.Extensions[name]
+	return extensionValue(o.Extensions[name])
+}
+
+// DecodeExt decodes the extension value into the given pointer, like json.Unmarshal does,
+// and reports whether the extension exists.
+// The `x-` prefix will be added automatically to given name.
+func (o *Extendable[T]) DecodeExt(name string, v any) (bool, error) {
+	if !strings.HasPrefix(name, ExtensionPrefix) {
+		name = ExtensionPrefix + name
+	}
+	value, ok := o.Extensions[name]
+	if !ok {
+		return false, nil
+	}
+	if err := decodeExtension(value, v); err != nil {
+		return true, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, name, err)
+	}
+	return true, nil
+}
+
+// ErrExtensionNotFound is returned by GetExtAs function if the object has no extension with the given name.
+var ErrExtensionNotFound = errors.New("extension not found")
+
+// Extensible is implemented by the objects having the extensions, i.e. Extendable and Schema.
+type Extensible interface {
+	GetExt(name string) any
+	DecodeExt(name string, v any) (bool, error)
+	extensionHolder
+}
+
+// GetExtAs returns the extension value by name as the given type, so no type assertions are needed.
+// The value is returned as is if it has the type already, e.g. set by AddExt method,
+// otherwise it is decoded like json.Unmarshal does, e.g. a map into a struct or a float64 into an int.
+// The `x-` prefix will be added automatically to given name.
+//
+// Example:
+//
+//	limit, err := openapi.GetExtAs[int](op, "rate-limit")
+func GetExtAs[T any](obj Extensible, name string) (T, error) {
+	var v T
+	if !strings.HasPrefix(name, ExtensionPrefix) {
+		name = ExtensionPrefix + name
+	}
+	value, ok := obj.extensions()[name]
+	if !ok {
+		return v, fmt.Errorf("%s: %w", name, ErrExtensionNotFound)
+	}
+	if t, ok := value.(T); ok {
+		return t, nil
+	}
+	if err := decodeExtension(value, &v); err != nil {
+		return v, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, name, err)
+	}
+	return v, nil
+}
+
+// DecodeExtensions decodes all extensions of the object into the given pointer to a struct,
+// the extensions are mapped onto the fields by the json tags, like json.Unmarshal does.
+//
+// Example:
+//
+//	var ext struct {
+//		Internal bool   `json:"x-internal"`
+//		Owner    string `json:"x-owner"`
+//	}
+//	err := openapi.DecodeExtensions(op, &ext)
+func DecodeExtensions(obj Extensible, v any) error {
+	exts := obj.extensions()
+	values := make(map[string]any, len(exts))
+	for name, value := range exts {
+		if node, ok := value.(*yaml.Node); ok {
+			// the raw JSON values are marshaled as is
+			values[name] = extensionValue(node)
+		} else {
+			values[name] = value
+		}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("marshaling extensions failed: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w of extensions: %w", ErrInvalidFormat, err)
+	}
+	return nil
 }
 
 // MarshalJSON implements json.Marshaler interface.
 func (o *Extendable[T]) MarshalJSON() ([]byte, error) {
 	var raw map[string]json.RawMessage
-	exts, err := json.Marshal(&o.Extensions)
+	extensions, err := marshalableExtensions(o.Extensions, false)
+	if err != nil {
+		return nil, fmt.Errorf("%T.Extensions.%w", o.Spec, err)
+	}
+	exts, err := json.Marshal(&extensions)
 	if err != nil {
 		return nil, fmt.Errorf("%T.Extensions: %w", o.Spec, err)
 	}
@@ -121,8 +209,13 @@ func (o *Extendable[T]) UnmarshalJSON(data []byte) error {
 
 // MarshalYAML implements yaml.Marshaler interface.
 func (o *Extendable[T]) MarshalYAML() (any, error) {
-	var raw map[string]any
-	exts, err := yaml.Marshal(&o.Extensions)
+	// the nodes are used to keep the raw extensions and the precision of the numbers
+	var raw map[string]yaml.Node
+	extensions, err := marshalableExtensions(o.Extensions, true)
+	if err != nil {
+		return nil, fmt.Errorf("%T.Extensions.%w", o.Spec, err)
+	}
+	exts, err := yaml.Marshal(&extensions)
 	if err != nil {
 		return nil, fmt.Errorf("%T.Extensions: %w", o.Spec, err)
 	}
@@ -171,7 +264,34 @@ func (o *Extendable[T]) validateSpec(location string, validator *Validator) []*v
 		if spec, ok := any(o.Spec).(validatable); ok {
 			errs = append(errs, spec.validateSpec(location, validator)...)
 		} else {
-			errs = append(errs, newValidationError(location, fmt.Errorf("unsupported spec type: %T", o.Spec)))
+			errs = append(errs, newValidationError(location, fmt.Errorf("%w: unsupported spec type: %T", ErrInvalidValue, o.Spec)))
+		}
+	}
+	if v, ok := o.Extensions[SunsetExt]; ok {
+		if _, err := parseSunset(v); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, SunsetExt), err))
+		}
+	}
+	if v, ok := o.Extensions[TimeoutExt]; ok {
+		if _, err := parseTimeout(v); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, TimeoutExt), err))
+		}
+	}
+	if v, ok := o.Extensions[RateLimitExt]; ok {
+		if _, err := parseRateLimit(v); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, RateLimitExt), err))
+		}
+	}
+	if v, ok := o.Extensions[ItemSchemaExt]; ok {
+		if schema, err := parseItemSchema(v); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, ItemSchemaExt), err))
+		} else {
+			errs = append(errs, schema.validateSpec(joinLoc(location, ItemSchemaExt), validator)...)
+		}
+	}
+	if v, ok := o.Extensions[LintIgnoreExt]; ok {
+		if _, err := parseLintIgnore(v); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, LintIgnoreExt), err))
 		}
 	}
 	if validator.opts.allowExtensionNameWithoutPrefix {
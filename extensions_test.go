@@ -104,3 +104,75 @@ func TestExtendable_WithExt(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExtAs(t *testing.T) {
+	const data = `
+openapi: 3.1.1
+info:
+  title: Extensions
+  version: 1.0.0
+  x-limit: 10
+  x-owner:
+    name: pets
+    emails: [pets@example.com]
+components:
+  schemas:
+    Pet:
+      type: object
+      x-internal: true
+`
+	type owner struct {
+		Name   string   `json:"name"`
+		Emails []string `json:"emails"`
+	}
+	for _, opts := range map[string][]openapi.UnmarshalOption{
+		"decoded": nil,
+		"raw":     {openapi.KeepRawExtensions()},
+	} {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.Unmarshal([]byte(data), &spec, opts...))
+		info := spec.Spec.Info
+
+		limit, err := openapi.GetExtAs[int](info, "limit")
+		require.NoError(t, err)
+		require.Equal(t, 10, limit)
+
+		o, err := openapi.GetExtAs[owner](info, "x-owner")
+		require.NoError(t, err)
+		require.Equal(t, owner{Name: "pets", Emails: []string{"pets@example.com"}}, o)
+
+		internal, err := openapi.GetExtAs[bool](spec.Spec.Components.Spec.Schemas["Pet"].Spec, "internal")
+		require.NoError(t, err)
+		require.True(t, internal)
+
+		_, err = openapi.GetExtAs[string](info, "missing")
+		require.ErrorIs(t, err, openapi.ErrExtensionNotFound)
+
+		_, err = openapi.GetExtAs[[]string](info, "limit")
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+
+		var exts struct {
+			Limit   int    `json:"x-limit"`
+			Owner   *owner `json:"x-owner"`
+			Missing string `json:"x-missing"`
+		}
+		require.NoError(t, openapi.DecodeExtensions(info, &exts))
+		require.Equal(t, 10, exts.Limit)
+		require.Equal(t, "pets", exts.Owner.Name)
+		require.Empty(t, exts.Missing)
+
+		var wrong struct {
+			Limit string `json:"x-limit"`
+		}
+		require.ErrorIs(t, openapi.DecodeExtensions(info, &wrong), openapi.ErrInvalidFormat)
+	}
+
+	t.Run("as is", func(t *testing.T) {
+		type custom struct{ ch chan int }
+		value := custom{ch: make(chan int)}
+		ext := openapi.NewExtendable(&openapi.Info{}).AddExt("custom", value)
+		got, err := openapi.GetExtAs[custom](ext, "custom")
+		require.NoError(t, err)
+		require.Equal(t, value, got)
+	})
+}
@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GitHubAnnotations formats the validation errors and the lint issues as the workflow commands of GitHub Actions,
+// e.g. `::error file=openapi.yaml,line=12,col=5,title=required::/paths/~1pets/get/responses: required`,
+// so they are shown inline on the pull requests.
+type GitHubAnnotations struct {
+	// File is the path of the spec relative to the root of the repository.
+	File string
+	// Source is the source map of the spec used to find the lines of the problems, see NewSourceMap.
+	// The annotations are reported for the whole file if nil.
+	Source *SourceMap
+	// Catalog translates the messages, see LocalizeErrors; the original texts are used if nil.
+	Catalog MessageCatalog
+}
+
+// Write writes an annotation for each validation error of the given error and for each given lint issue.
+// The lint issues are reported as errors, warnings, or notices according to their severities,
+// the other validation errors are reported as errors.
+//
+// Note that ValidateSpec method returns the lint issues with SeverityError too,
+// so they must not be given twice.
+func (a *GitHubAnnotations) Write(w io.Writer, err error, issues ...*LintIssue) error {
+	var errIssues []*LintIssue
+	for _, m := range LocalizeErrors(err, a.Catalog) {
+		var issue *LintIssue
+		if errors.As(m.Err, &issue) {
+			errIssues = append(errIssues, issue)
+			continue
+		}
+		if e := a.write(w, "error", m.ID, m.Location, m.Text); e != nil {
+			return e
+		}
+	}
+	for _, issue := range append(errIssues, issues...) {
+		text := issue.Message
+		if a.Catalog != nil {
+			if t, ok := a.Catalog.Message(MessageID(issue.Rule), issue.Location, issue); ok {
+				text = t
+			}
+		}
+		if e := a.write(w, githubLevel(issue.Severity), MessageID(issue.Rule), issue.Location, text); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func (a *GitHubAnnotations) write(w io.Writer, level string, id MessageID, location Location, text string) error {
+	props := []string{"file=" + githubPropertyEscaper.Replace(a.File)}
+	if pos, ok := a.Source.Position(location); ok {
+		props = append(props, fmt.Sprintf("line=%d", pos.Line), fmt.Sprintf("col=%d", pos.Column))
+	}
+	props = append(props, "title="+githubPropertyEscaper.Replace(string(id)))
+	if location != "" {
+		text = location.String() + ": " + text
+	}
+	_, err := fmt.Fprintf(w, "::%s %s::%s\n", level, strings.Join(props, ","), githubDataEscaper.Replace(text))
+	return err
+}
+
+func githubLevel(s Severity) string {
+	switch {
+	case s >= SeverityError:
+		return "error"
+	case s == SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
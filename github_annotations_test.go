@@ -0,0 +1,86 @@
+package openapi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestGitHubAnnotations(t *testing.T) {
+	data := []byte(testLintSpec)
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+	source, err := openapi.NewSourceMap(data)
+	require.NoError(t, err)
+
+	validator, err := openapi.NewValidator(spec,
+		openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityError),
+		openapi.WithRules(testDescriptionRule{}),
+	)
+	require.NoError(t, err)
+	var hints []*openapi.LintIssue
+	for _, issue := range validator.Lint() {
+		if issue.Severity < openapi.SeverityError {
+			hints = append(hints, issue)
+		}
+	}
+
+	var buf bytes.Buffer
+	a := &openapi.GitHubAnnotations{File: "api/openapi.yaml", Source: source}
+	require.NoError(t, a.Write(&buf, validator.ValidateSpec(), hints...))
+	require.Equal(t, `::error file=api/openapi.yaml,line=18,col=5,title=mutating-operations-require-auth::/paths/~1pets/delete: DELETE operation does not require authentication
+::error file=api/openapi.yaml,line=32,col=11,title=mutating-operations-require-auth::/paths/~1pets~1{id}/put/security/1: PUT operation allows anonymous access
+::error file=api/openapi.yaml,line=37,col=5,title=mutating-operations-require-auth::/paths/~1public~1feedback/post: POST operation does not require authentication
+::notice file=api/openapi.yaml,line=8,col=5,title=operation-summary::/paths/~1pets/get/summary: missing summary
+`, buf.String())
+
+	t.Run("without source", func(t *testing.T) {
+		buf.Reset()
+		a := &openapi.GitHubAnnotations{File: "a,b.yaml", Catalog: openapi.MapCatalog{openapi.MsgRequired: "missing value\nat {location}"}}
+		spec := &openapi.Extendable[openapi.OpenAPI]{Spec: &openapi.OpenAPI{OpenAPI: "3.1.1"}}
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.NoError(t, a.Write(&buf, validator.ValidateSpec()))
+		require.Equal(t, "::error file=a%2Cb.yaml,title=required::/info: missing value%0Aat /info\n"+
+			"::error file=a%2Cb.yaml,title=required::/paths||webhooks||components: missing value%0Aat /paths||webhooks||components\n",
+			buf.String())
+	})
+}
+
+func TestSourceMap_Position(t *testing.T) {
+	source, err := openapi.NewSourceMap([]byte(`{
+  "openapi": "3.1.1",
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "required": ["id"]}
+    }
+  }
+}`))
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		location openapi.Location
+		pos      openapi.Position
+	}{
+		{location: "", pos: openapi.Position{Line: 1, Column: 1}},
+		{location: "/openapi", pos: openapi.Position{Line: 2, Column: 3}},
+		{location: "#/components/schemas/Pet/type", pos: openapi.Position{Line: 5, Column: 15}},
+		{location: "/components/schemas/Pet/required/0", pos: openapi.Position{Line: 5, Column: 46}},
+		{location: "/components/schemas/Pet/properties/id", pos: openapi.Position{Line: 5, Column: 7}},
+	} {
+		t.Run(tt.location.String(), func(t *testing.T) {
+			pos, ok := source.Position(tt.location)
+			require.True(t, ok)
+			require.Equal(t, tt.pos, pos)
+		})
+	}
+
+	var empty *openapi.SourceMap
+	_, ok := empty.Position("/info")
+	require.False(t, ok)
+	_, err = openapi.NewSourceMap([]byte("{"))
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+}
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// The kinds of the nodes of DependencyGraph other than the types of the components, e.g. `schemas`.
+const (
+	GraphNodeOperation = "operation"
+	GraphNodeWebhook   = "webhook"
+)
+
+// GraphNode is a node of DependencyGraph, an operation or a component.
+type GraphNode struct {
+	// ID is the location of the object in form of JSON Pointer, e.g. `/components/schemas/Pet`.
+	ID string
+	// Kind is GraphNodeOperation, GraphNodeWebhook, or the type of the component, e.g. `schemas`.
+	Kind string
+	// Name is the name of the component or the method and the path of the operation, e.g. `GET /pets`.
+	Name string
+}
+
+// GraphEdge is a directed edge of DependencyGraph, the From node references the To node.
+type GraphEdge struct {
+	From string
+	To   string
+}
+
+// DependencyGraph is the graph of the references between the operations and the components of a spec,
+// e.g. to render it for architecture reviews or documentation using WriteDOT or WriteMermaid methods.
+type DependencyGraph struct {
+	// Nodes are the operations of the paths and the webhooks sorted by path and method,
+	// then all components sorted by type and name.
+	Nodes []GraphNode
+	// Edges are the unique references sorted by their nodes.
+	Edges []GraphEdge
+}
+
+// NewDependencyGraph builds the dependency graph of the given spec.
+//
+// An operation or a component depends on the components it references directly,
+// including the references of its inline objects, e.g. the properties of a schema.
+// The references by `$dynamicRef`, the discriminator mappings and the security requirements are included.
+// The unused components are included as the nodes without incoming edges.
+func NewDependencyGraph(spec *Extendable[OpenAPI]) *DependencyGraph {
+	g := &DependencyGraph{}
+	if spec == nil || spec.Spec == nil {
+		return g
+	}
+	c := spec.Spec.Components
+	var fields map[string]reflect.Value
+	if c != nil && c.Spec != nil {
+		fields = componentMaps(c.Spec)
+	}
+	edges := make(map[GraphEdge]bool)
+	addEdges := func(from string, v reflect.Value) {
+		walkSpec(v, componentRefsVisitor(c, func(typ, name string) {
+			if f, ok := fields[typ]; ok && f.MapIndex(reflect.ValueOf(name)).IsValid() {
+				edges[GraphEdge{From: from, To: joinLoc("/components", typ, name)}] = true
+			}
+		}))
+	}
+
+	forEachOperation(spec, func(location, method string, op *Extendable[Operation]) {
+		segments := Location(location).Segments()
+		kind := GraphNodeOperation
+		if segments[0] == "webhooks" {
+			kind = GraphNodeWebhook
+		}
+		g.Nodes = append(g.Nodes, GraphNode{ID: location, Kind: kind, Name: method + " " + segments[1]})
+		addEdges(location, reflect.ValueOf(op))
+	})
+	for _, typ := range sortedKeys(fields) {
+		f := fields[typ]
+		names := make([]string, 0, f.Len())
+		for _, k := range f.MapKeys() {
+			names = append(names, k.String())
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			id := joinLoc("/components", typ, name)
+			g.Nodes = append(g.Nodes, GraphNode{ID: id, Kind: typ, Name: name})
+			addEdges(id, f.MapIndex(reflect.ValueOf(name)))
+		}
+	}
+
+	g.Edges = make([]GraphEdge, 0, len(edges))
+	for e := range edges {
+		g.Edges = append(g.Edges, e)
+	}
+	slices.SortFunc(g.Edges, func(a, b GraphEdge) int {
+		if c := cmp.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.To, b.To)
+	})
+	return g
+}
+
+// WriteDOT writes the graph in Graphviz DOT language, the operations are rendered as boxes
+// and the components as ellipses.
+//
+//	openapi.NewDependencyGraph(spec).WriteDOT(os.Stdout)
+//	// dot -Tsvg graph.dot -o graph.svg
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph openapi {\n\trankdir=LR;\n")
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		if n.isOperation() {
+			shape = "box"
+		}
+		fmt.Fprintf(bw, "\t%s [label=%s, shape=%s];\n", dotQuote(n.ID), dotQuote(n.Name), shape)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// WriteMermaid writes the graph as Mermaid flowchart, the operations are rendered as rectangles
+// and the components as rounded rectangles.
+func (g *DependencyGraph) WriteMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("flowchart LR\n")
+	// the locations can't be used as the IDs of Mermaid nodes, so the indexes are used instead
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		open, closing := "(", ")"
+		if n.isOperation() {
+			open, closing = "[", "]"
+		}
+		fmt.Fprintf(bw, "\t%s%s%s%s\n", id, open, mermaidQuote(n.Name), closing)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "\t%s --> %s\n", ids[e.From], ids[e.To])
+	}
+	return bw.Flush()
+}
+
+func (n *GraphNode) isOperation() bool {
+	return n.Kind == GraphNodeOperation || n.Kind == GraphNodeWebhook
+}
+
+var (
+	dotEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", " ")
+)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + mermaidEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,126 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testGraphSpec = `
+openapi: 3.1.1
+info:
+  title: Graph
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      security:
+        - apiKey: []
+      responses:
+        '200':
+          $ref: '#/components/responses/Pets'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        parent:
+          $ref: '#/components/schemas/Pet'
+    Owner:
+      type: object
+    "Unused \"one\"":
+      type: string
+  responses:
+    Pets:
+      description: pets
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              $ref: '#/components/schemas/Pet'
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+`
+
+func TestNewDependencyGraph(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testGraphSpec), &spec))
+	g := openapi.NewDependencyGraph(spec)
+
+	require.Equal(t, []openapi.GraphNode{
+		{ID: "/paths/~1pets/get", Kind: openapi.GraphNodeOperation, Name: "GET /pets"},
+		{ID: "/paths/~1pets/post", Kind: openapi.GraphNodeOperation, Name: "POST /pets"},
+		{ID: "/webhooks/newPet/post", Kind: openapi.GraphNodeWebhook, Name: "POST newPet"},
+		{ID: "/components/responses/Pets", Kind: "responses", Name: "Pets"},
+		{ID: "/components/schemas/Owner", Kind: "schemas", Name: "Owner"},
+		{ID: "/components/schemas/Pet", Kind: "schemas", Name: "Pet"},
+		{ID: "/components/schemas/Unused \"one\"", Kind: "schemas", Name: "Unused \"one\""},
+		{ID: "/components/securitySchemes/apiKey", Kind: "securitySchemes", Name: "apiKey"},
+	}, g.Nodes)
+	require.Equal(t, []openapi.GraphEdge{
+		{From: "/components/responses/Pets", To: "/components/schemas/Pet"},
+		{From: "/components/schemas/Pet", To: "/components/schemas/Owner"},
+		{From: "/components/schemas/Pet", To: "/components/schemas/Pet"},
+		{From: "/paths/~1pets/get", To: "/components/responses/Pets"},
+		{From: "/paths/~1pets/get", To: "/components/securitySchemes/apiKey"},
+		{From: "/paths/~1pets/post", To: "/components/schemas/Pet"},
+		{From: "/webhooks/newPet/post", To: "/components/schemas/Pet"},
+	}, g.Edges)
+}
+
+func TestDependencyGraph_Write(t *testing.T) {
+	g := &openapi.DependencyGraph{
+		Nodes: []openapi.GraphNode{
+			{ID: "/paths/~1pets/get", Kind: openapi.GraphNodeOperation, Name: "GET /pets"},
+			{ID: "/components/schemas/Pet", Kind: "schemas", Name: `Pet "v2"`},
+		},
+		Edges: []openapi.GraphEdge{
+			{From: "/paths/~1pets/get", To: "/components/schemas/Pet"},
+		},
+	}
+
+	var dot strings.Builder
+	require.NoError(t, g.WriteDOT(&dot))
+	require.Equal(t, `digraph openapi {
+	rankdir=LR;
+	"/paths/~1pets/get" [label="GET /pets", shape=box];
+	"/components/schemas/Pet" [label="Pet \"v2\"", shape=ellipse];
+	"/paths/~1pets/get" -> "/components/schemas/Pet";
+}
+`, dot.String())
+
+	var mermaid strings.Builder
+	require.NoError(t, g.WriteMermaid(&mermaid))
+	require.Equal(t, `flowchart LR
+	n0["GET /pets"]
+	n1("Pet #quot;v2#quot;")
+	n0 --> n1
+`, mermaid.String())
+}
@@ -29,13 +29,20 @@ type Header struct {
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
-	Explode bool `json:"explode,omitempty" yaml:"explode,omitempty"`
+	// The nil value means the default one, so the explicit false value is kept.
+	Explode *bool `json:"explode,omitempty" yaml:"explode,omitempty"`
 	// Determines whether this header is mandatory.
 	// The property MAY be included and its default value is false.
 	Required bool `json:"required,omitempty" yaml:"required,omitempty"`
 	// Specifies that a header is deprecated and SHOULD be transitioned out of usage.
 	// Default value is false.
 	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// Example of the header’s potential value.
+	// The example field is mutually exclusive of the examples field.
+	Example any `json:"example,omitempty" yaml:"example,omitempty"`
+	// Examples of the header’s potential value.
+	// The examples field is mutually exclusive of the example field.
+	Examples map[string]*RefOrSpec[Extendable[Example]] `json:"examples,omitempty" yaml:"examples,omitempty"`
 }
 
 func (o *Header) validateSpec(location string, validator *Validator) []*validationError {
@@ -43,10 +50,16 @@ func (o *Header) validateSpec(location string, validator *Validator) []*validati
 	if o.Schema != nil && o.Content != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "schema&content"), ErrMutuallyExclusive))
 	}
+	if o.Example != nil && len(o.Examples) > 0 {
+		errs = append(errs, newValidationError(joinLoc(location, "example&examples"), ErrMutuallyExclusive))
+	}
+	for k, v := range o.Examples {
+		errs = append(errs, v.validateSpec(joinLoc(location, "examples", k), validator)...)
+	}
 
 	if l := len(o.Content); l > 0 {
 		if l != 1 {
-			errs = append(errs, newValidationError(joinLoc(location, "content"), "must be only one item, but got '%d'", l))
+			errs = append(errs, newValidationError(joinLoc(location, "content"), "%w: must be only one item, but got '%d'", ErrOutOfRange, l))
 		}
 		for k, v := range o.Content {
 			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
@@ -59,75 +72,31 @@ func (o *Header) validateSpec(location string, validator *Validator) []*validati
 	switch o.Style {
 	case "", StyleSimple:
 	default:
-		errs = append(errs, newValidationError(joinLoc(location, "style"), "invalid value, expected one of [%s], but got '%s'", StyleSimple, o.Style))
+		errs = append(errs, newValidationError(joinLoc(location, "style"), "%w, expected one of [%s], but got '%s'", ErrInvalidValue, StyleSimple, o.Style))
 	}
 
-	return errs
-}
-
-type HeaderBuilder struct {
-	spec *RefOrSpec[Extendable[Header]]
-}
-
-func NewHeaderBuilder() *HeaderBuilder {
-	return &HeaderBuilder{
-		spec: NewRefOrExtSpec[Header](&Header{}),
+	// the examples of the headers with content are validated by the media types
+	if validator.opts.doNotValidateExamples || o.Schema == nil {
+		return errs
 	}
-}
-
-func (b *HeaderBuilder) Build() *RefOrSpec[Extendable[Header]] {
-	return b.spec
-}
-
-func (b *HeaderBuilder) Extensions(v map[string]any) *HeaderBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *HeaderBuilder) AddExt(name string, value any) *HeaderBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *HeaderBuilder) Schema(v *RefOrSpec[Schema]) *HeaderBuilder {
-	b.spec.Spec.Spec.Schema = v
-	return b
-}
-
-func (b *HeaderBuilder) Content(v map[string]*Extendable[MediaType]) *HeaderBuilder {
-	b.spec.Spec.Spec.Content = v
-	return b
-}
-
-func (b *HeaderBuilder) AddContent(name string, value *Extendable[MediaType]) *HeaderBuilder {
-	if b.spec.Spec.Spec.Content == nil {
-		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
+	schemaRef := o.Schema.getLocationOrRef(joinLoc(location, "schema"))
+	if o.Example != nil {
+		if e := validator.ValidateData(schemaRef, o.Example); e != nil {
+			errs = append(errs, newValidationError(joinLoc(location, "example"), "%w: %w", ErrInvalidData, e))
+		}
+	}
+	for k, v := range o.Examples {
+		example, err := v.GetSpec(validator.spec.Spec.Components)
+		if err != nil {
+			// do not add the error, because it is already validated earlier
+			continue
+		}
+		if value := example.Spec.Value; value != nil {
+			if e := validator.ValidateData(schemaRef, value); e != nil {
+				errs = append(errs, newValidationError(joinLoc(location, "examples", k), "%w: %w", ErrInvalidData, e))
+			}
+		}
 	}
-	b.spec.Spec.Spec.Content[name] = value
-	return b
-}
-
-func (b *HeaderBuilder) Description(v string) *HeaderBuilder {
-	b.spec.Spec.Spec.Description = v
-	return b
-}
-
-func (b *HeaderBuilder) Style(v string) *HeaderBuilder {
-	b.spec.Spec.Spec.Style = v
-	return b
-}
-
-func (b *HeaderBuilder) Explode(v bool) *HeaderBuilder {
-	b.spec.Spec.Spec.Explode = v
-	return b
-}
-
-func (b *HeaderBuilder) Required(v bool) *HeaderBuilder {
-	b.spec.Spec.Spec.Required = v
-	return b
-}
 
-func (b *HeaderBuilder) Deprecated(v bool) *HeaderBuilder {
-	b.spec.Spec.Spec.Deprecated = v
-	return b
+	return errs
 }
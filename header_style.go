@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// HeaderStyleRuleID is the ID of HeaderStyleRule.
+const HeaderStyleRuleID = "header-style"
+
+// HeaderStyleRule is a lint rule reporting the header parameters and the headers of responses and encodings
+// whose schemas can't be serialized using simple style, the only style of the headers:
+// the items of an array and the properties of an object must be primitive values.
+// Such headers should be described using `content` instead, e.g. a JSON value.
+type HeaderStyleRule struct{}
+
+// ID implements Rule interface.
+func (r *HeaderStyleRule) ID() string {
+	return HeaderStyleRuleID
+}
+
+// Description implements Rule interface.
+func (r *HeaderStyleRule) Description() string {
+	return "the headers must be serializable using simple style: arrays and objects of primitive values only"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *HeaderStyleRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+// Check implements Rule interface.
+func (r *HeaderStyleRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	doc, err := specJSONValue(spec)
+	if err != nil {
+		return
+	}
+	r.checkJSON(doc, report)
+}
+
+func (r *HeaderStyleRule) checkJSON(doc any, report func(location string, message string)) {
+	walkHeaderStyle(doc, doc, make(locPath, 0, 16), false, report)
+}
+
+// walkHeaderStyle walks the JSON representation of the spec, the header is true for the values of `headers` maps.
+func walkHeaderStyle(doc, v any, path locPath, header bool, report func(location string, message string)) {
+	switch t := v.(type) {
+	case map[string]any:
+		if schema, ok := t["schema"]; ok && (header || t["in"] == InHeader) {
+			if nested := nestedSimpleStyleValue(doc, schema); nested != "" {
+				report(path.join("schema"), "simple style of headers supports arrays and objects of primitive values only, but "+
+					nested+" is not primitive; use `content` instead")
+			}
+		}
+		keys := pooledSortedKeys(t)
+		for _, k := range *keys {
+			if strings.HasPrefix(k, ExtensionPrefix) || slices.Contains(dataKeywords, k) {
+				continue
+			}
+			if headers, ok := t[k].(map[string]any); ok && k == "headers" {
+				names := pooledSortedKeys(headers)
+				for _, name := range *names {
+					walkHeaderStyle(doc, headers[name], append(path, k, name), true, report)
+				}
+				releaseKeys(names)
+				continue
+			}
+			walkHeaderStyle(doc, t[k], append(path, k), false, report)
+		}
+		releaseKeys(keys)
+	case []any:
+		for i, item := range t {
+			walkHeaderStyle(doc, item, append(path, strconv.Itoa(i)), false, report)
+		}
+	}
+}
+
+// nestedSimpleStyleValue returns the description of the first array item or object property of the given schema
+// that is an array or an object, an empty string if the schema can be serialized using simple style.
+func nestedSimpleStyleValue(doc, schema any) string {
+	s := resolveJSONRef(doc, schema)
+	switch jsonSchemaType(s) {
+	case ArrayType:
+		if t := jsonSchemaType(resolveJSONRef(doc, s["items"])); t == ArrayType || t == ObjectType {
+			return "array item"
+		}
+	case ObjectType:
+		props, _ := s["properties"].(map[string]any)
+		for _, name := range sortedKeys(props) {
+			if t := jsonSchemaType(resolveJSONRef(doc, props[name])); t == ArrayType || t == ObjectType {
+				return "property '" + name + "'"
+			}
+		}
+	}
+	return ""
+}
+
+// resolveJSONRef follows the local `$ref` of the JSON representation of a schema within the given document,
+// the schema is returned as is if it has no such reference or the reference can't be resolved.
+func resolveJSONRef(doc, v any) map[string]any {
+	m, _ := v.(map[string]any)
+	// the number of the followed references is limited to stop at the cycles
+	for i := 0; i < 32 && m != nil; i++ {
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return m
+		}
+		target := doc
+		for _, segment := range Location(ref[1:]).Segments() {
+			obj, _ := target.(map[string]any)
+			target = obj[segment]
+		}
+		next, ok := target.(map[string]any)
+		if !ok {
+			return m
+		}
+		m = next
+	}
+	return m
+}
+
+// jsonSchemaType returns the main (not null) type of the JSON representation of a schema, see schemaType.
+func jsonSchemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		if t != NullType {
+			return t
+		}
+	case []any:
+		for _, item := range t {
+			if typ, ok := item.(string); ok && typ != NullType {
+				return typ
+			}
+		}
+	}
+	switch {
+	case s["properties"] != nil:
+		return ObjectType
+	case s["items"] != nil:
+		return ArrayType
+	}
+	return ""
+}
@@ -0,0 +1,165 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testHeaderStyleSpec = `
+openapi: 3.1.1
+info:
+  title: Headers
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: X-Filter
+          in: header
+          explode: true
+          schema:
+            $ref: '#/components/schemas/Filter'
+        - name: X-Pairs
+          in: header
+          schema:
+            type: object
+            properties:
+              a:
+                type: integer
+        - name: X-Name
+          in: header
+          schema:
+            type: string
+            enum: ["a, b"]
+        - name: X-Nested
+          in: header
+          schema:
+            type: array
+            items:
+              $ref: '#/components/schemas/Filter'
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Page:
+              schema:
+                type: object
+                properties:
+                  cursor:
+                    type: string
+            X-Tags:
+              schema:
+                type: object
+                properties:
+                  tags:
+                    type: array
+components:
+  schemas:
+    Filter:
+      type: object
+      properties:
+        name:
+          type: string
+        size:
+          type: integer
+      additionalProperties: false
+`
+
+func TestValidator_ValidateHTTPRequest_Headers(t *testing.T) {
+	validator := newTestRequestValidator(t, testHeaderStyleSpec)
+
+	for _, tt := range []struct {
+		name    string
+		headers http.Header
+		err     string
+	}{
+		{
+			name:    "array with spaces",
+			headers: http.Header{"X-Ids": {"1, 2 ,3"}},
+		},
+		{
+			name:    "array in several fields",
+			headers: http.Header{"X-Ids": {"1, 2", "3"}},
+		},
+		{
+			name:    "invalid array item",
+			headers: http.Header{"X-Ids": {"1", "x"}},
+			err:     "header/X-Ids: invalid data",
+		},
+		{
+			name:    "exploded object",
+			headers: http.Header{"X-Filter": {"name=a, size=5"}},
+		},
+		{
+			name:    "exploded object in several fields",
+			headers: http.Header{"X-Filter": {"name=a", "size=x"}},
+			err:     "header/X-Filter: invalid data",
+		},
+		{
+			name:    "object",
+			headers: http.Header{"X-Pairs": {"a, 1"}},
+		},
+		{
+			name:    "primitive is not split",
+			headers: http.Header{"X-Name": {"a, b"}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/items", nil)
+			r.Header = tt.headers
+			err := validator.ValidateHTTPRequest(r)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHeaderStyleRule(t *testing.T) {
+	validator := newTestRequestValidator(t, testHeaderStyleSpec)
+
+	var locations []openapi.Location
+	for _, issue := range validator.Lint() {
+		require.Equal(t, openapi.HeaderStyleRuleID, issue.Rule)
+		require.Equal(t, openapi.SeverityWarning, issue.Severity)
+		locations = append(locations, issue.Location)
+	}
+	require.Equal(t, []openapi.Location{
+		"/paths/~1items/get/parameters/4/schema",
+		"/paths/~1items/get/responses/200/headers/X-Tags/schema",
+	}, locations)
+
+	t.Run("lazy spec marshaling", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testHeaderStyleSpec), &spec))
+		lazy, err := openapi.NewValidator(spec, openapi.LazySpecMarshaling())
+		require.NoError(t, err)
+		require.Equal(t, validator.Lint(), lazy.Lint())
+	})
+}
+
+func TestEncodeParameter_HeaderRoundTrip(t *testing.T) {
+	param := &openapi.Parameter{Name: "X-Filter", In: openapi.InHeader, Explode: explode(true)}
+	s, err := openapi.EncodeParameter(param, map[string]any{"name": "a", "size": 5})
+	require.NoError(t, err)
+	require.Equal(t, "name=a,size=5", s)
+
+	validator := newTestRequestValidator(t, testHeaderStyleSpec)
+	r := httptest.NewRequest(http.MethodGet, "/items", nil)
+	r.Header.Set("X-Filter", s)
+	require.NoError(t, validator.ValidateHTTPRequest(r))
+}
@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// IdempotencyKeyHeader is the name of the request header holding the unique key of the request,
+// so the retries of a non-idempotent request are performed once.
+//
+// https://datatracker.ietf.org/doc/draft-ietf-httpapi-idempotency-key-header/
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKey adds the required `Idempotency-Key` header parameter with the string schema of uuid format.
+func (b *OperationBuilder) IdempotencyKey() *OperationBuilder {
+	return b.AddParameters(NewParameterBuilder().
+		Name(IdempotencyKeyHeader).
+		In(InHeader).
+		Description("The unique key of the request to retry it safely").
+		Required(true).
+		Schema(NewSchemaBuilder().Type(StringType).Format(UUIDFormat).Build()).
+		Build())
+}
+
+// IdempotencyKeyRuleID is the ID of IdempotencyKeyRule.
+const IdempotencyKeyRuleID = "idempotency-key"
+
+// IdempotencyKeyRule is a governance rule checking that POST and PATCH operations, which are not idempotent,
+// declare `Idempotency-Key` header parameter, by the operation or by its path item,
+// and that its schema is a string of uuid format.
+//
+// The rule is disabled by default, it can be enabled using RuleSeverity option and configured using WithRules option:
+//
+//	openapi.NewValidator(spec,
+//		openapi.WithRules(&openapi.IdempotencyKeyRule{ExemptPaths: []string{"/search"}}),
+//		openapi.RuleSeverity(openapi.IdempotencyKeyRuleID, openapi.SeverityWarning),
+//	)
+type IdempotencyKeyRule struct {
+	// ExemptPaths are the paths or the patterns of the paths (see path.Match) not checked by the rule.
+	ExemptPaths []string
+}
+
+// NewIdempotencyKeyRule creates the rule with the given exempt paths.
+func NewIdempotencyKeyRule(exemptPaths ...string) *IdempotencyKeyRule {
+	return &IdempotencyKeyRule{ExemptPaths: exemptPaths}
+}
+
+// ID implements Rule interface.
+func (r *IdempotencyKeyRule) ID() string {
+	return IdempotencyKeyRuleID
+}
+
+// Description implements Rule interface.
+func (r *IdempotencyKeyRule) Description() string {
+	return "POST and PATCH operations must declare Idempotency-Key header of string type and uuid format"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *IdempotencyKeyRule) DefaultSeverity() Severity {
+	return SeverityOff
+}
+
+// Options implements ConfigurableRule interface.
+func (r *IdempotencyKeyRule) Options() []RuleOption {
+	exemptPaths := r.ExemptPaths
+	if exemptPaths == nil {
+		exemptPaths = []string{}
+	}
+	return []RuleOption{
+		{
+			Name:        "exemptPaths",
+			Description: "the paths or the patterns of the paths, like `/search/*`, not checked by the rule",
+			Schema: NewSchemaBuilder().
+				Type(ArrayType).
+				Items(NewBoolOrSchema(NewSchemaBuilder().Type(StringType).Build())).
+				Default(exemptPaths).
+				Build(),
+		},
+	}
+}
+
+// Configure implements ConfigurableRule interface.
+func (r *IdempotencyKeyRule) Configure(options map[string]any) error {
+	var cfg struct {
+		ExemptPaths []string `json:"exemptPaths"`
+	}
+	if err := decodeRuleOptions(options, &cfg); err != nil {
+		return err
+	}
+	r.ExemptPaths = cfg.ExemptPaths
+	return nil
+}
+
+// Check implements Rule interface.
+func (r *IdempotencyKeyRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	components := spec.Spec.Components
+	items := spec.Spec.Paths.Spec.Paths
+	for _, p := range sortedKeys(items) {
+		if matchPathPatterns(r.ExemptPaths, p) {
+			continue
+		}
+		item := items[p]
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			continue
+		}
+		for _, method := range []string{http.MethodPost, http.MethodPatch} {
+			op := item.Spec.Spec.operation(method)
+			if op == nil || op.Spec == nil {
+				continue
+			}
+			loc := joinLoc("/paths", p, strings.ToLower(method))
+			param, paramLoc := headerParameter(components, IdempotencyKeyHeader, loc, op.Spec, item.Spec.Spec)
+			if param == nil {
+				report(loc, method+" operation does not declare "+IdempotencyKeyHeader+" header")
+				continue
+			}
+			if param.Schema == nil {
+				continue
+			}
+			schema, err := param.Schema.GetSpec(components)
+			if err == nil && (!slices.Contains(schemaTypes(schema), StringType) || schema.Format != UUIDFormat) {
+				report(joinLoc(paramLoc, "schema"), IdempotencyKeyHeader+" header must be a string of uuid format")
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestOperationBuilder_IdempotencyKey(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Idempotency").Version("1.0.0").Build()).
+		AddPath("/orders", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().JSONResponseFrom("200", []string{}).Build()).
+			Post(openapi.NewOperationBuilder().IdempotencyKey().JSONResponseFrom("201", struct{}{}).Build()).
+			Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec, openapi.RuleSeverity(openapi.IdempotencyKeyRuleID, openapi.SeverityWarning))
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.Empty(t, validator.Lint())
+
+	param := spec.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Post.Spec.Parameters[0].Spec.Spec
+	require.Equal(t, openapi.IdempotencyKeyHeader, param.Name)
+	require.Equal(t, openapi.InHeader, param.In)
+	require.True(t, param.Required)
+	require.Equal(t, openapi.UUIDFormat, param.Schema.Spec.Format)
+}
+
+const testIdempotencyKeyRuleSpec = `
+openapi: 3.1.1
+info:
+  title: Idempotency
+  version: 1.0.0
+paths:
+  /orders:
+    post:
+      parameters:
+        - name: Idempotency-Key
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        '201':
+          description: Created
+    patch:
+      responses:
+        '200':
+          description: OK
+  /payments:
+    parameters:
+      - name: idempotency-key
+        in: header
+        schema:
+          type: string
+          format: uuid
+    post:
+      responses:
+        '201':
+          description: Created
+  /search:
+    post:
+      responses:
+        '200':
+          description: OK
+`
+
+func TestIdempotencyKeyRule(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		rule     *openapi.IdempotencyKeyRule
+		expected []string
+	}{
+		{
+			name: "all paths",
+			rule: openapi.NewIdempotencyKeyRule(),
+			expected: []string{
+				"/paths/~1orders/patch: PATCH operation does not declare Idempotency-Key header",
+				"/paths/~1orders/post/parameters/0/schema: Idempotency-Key header must be a string of uuid format",
+				"/paths/~1search/post: POST operation does not declare Idempotency-Key header",
+			},
+		},
+		{
+			name: "exempt paths",
+			rule: openapi.NewIdempotencyKeyRule("/orders", "/search"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(testIdempotencyKeyRuleSpec), &spec))
+			validator, err := openapi.NewValidator(spec,
+				openapi.WithRules(tt.rule),
+				openapi.RuleSeverity(openapi.IdempotencyKeyRuleID, openapi.SeverityWarning),
+			)
+			require.NoError(t, err)
+			var messages []string
+			for _, issue := range validator.Lint() {
+				messages = append(messages, issue.Location.String()+": "+issue.Message)
+			}
+			require.Equal(t, tt.expected, messages)
+		})
+	}
+}
@@ -1,5 +1,11 @@
 package openapi
 
+import (
+	"path"
+	"runtime/debug"
+	"strings"
+)
+
 // Info provides metadata about the API.
 // The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.
 //
@@ -29,7 +35,7 @@ type Info struct {
 	// CommonMark syntax MAY be used for rich text representation.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// A URL to the Terms of Service for the API.
-	// This MUST be in the form of a URL.
+	// This MUST be in the form of an absolute URL.
 	TermsOfService string `json:"termsOfService,omitempty" yaml:"termsOfService,omitempty"`
 	// The contact information for the exposed API.
 	Contact *Extendable[Contact] `json:"contact,omitempty" yaml:"contact,omitempty"`
@@ -54,67 +60,63 @@ func (o *Info) validateSpec(location string, validator *Validator) []*validation
 	if o.License != nil {
 		errs = append(errs, o.License.validateSpec(joinLoc(location, "license"), validator)...)
 	}
-	if err := checkURL(o.TermsOfService); err != nil {
+	if err := checkAbsoluteURL(o.TermsOfService); err != nil {
 		errs = append(errs, newValidationError(joinLoc(location, "termsOfService"), err))
 	}
 	return errs
 }
 
-type InfoBuilder struct {
-	spec *Extendable[Info]
-}
-
-func NewInfoBuilder() *InfoBuilder {
-	return &InfoBuilder{
-		spec: NewExtendable[Info](&Info{}),
+// FromBuildInfo sets the title and the version from the build information of the running binary,
+// the fields already set are not changed.
+//
+// The title is the name of the main module, e.g. `openapi` for `github.com/sv-tools/openapi/v2`.
+// The version is the given value, e.g. a variable set by `-ldflags "-X main.version=v1.2.3"`,
+// otherwise the version of the main module, or the VCS revision for the development builds.
+func (b *InfoBuilder) FromBuildInfo(version string) *InfoBuilder {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		bi = &debug.BuildInfo{}
 	}
-}
-
-func (b *InfoBuilder) Build() *Extendable[Info] {
-	return b.spec
-}
-
-func (b *InfoBuilder) Extensions(v map[string]any) *InfoBuilder {
-	b.spec.Extensions = v
-	return b
-}
-
-func (b *InfoBuilder) AddExt(name string, value any) *InfoBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *InfoBuilder) Title(v string) *InfoBuilder {
-	b.spec.Spec.Title = v
-	return b
-}
-
-func (b *InfoBuilder) Summary(v string) *InfoBuilder {
-	b.spec.Spec.Summary = v
-	return b
-}
-
-func (b *InfoBuilder) Description(v string) *InfoBuilder {
-	b.spec.Spec.Description = v
-	return b
-}
-
-func (b *InfoBuilder) TermsOfService(v string) *InfoBuilder {
-	b.spec.Spec.TermsOfService = v
-	return b
-}
-
-func (b *InfoBuilder) Contact(v *Extendable[Contact]) *InfoBuilder {
-	b.spec.Spec.Contact = v
-	return b
-}
-
-func (b *InfoBuilder) License(v *Extendable[License]) *InfoBuilder {
-	b.spec.Spec.License = v
+	if b.spec.Spec.Title == "" {
+		b.spec.Spec.Title = moduleName(bi.Main.Path)
+	}
+	if b.spec.Spec.Version != "" {
+		return b
+	}
+	if version == "" && bi.Main.Version != "(devel)" {
+		version = bi.Main.Version
+	}
+	if version == "" {
+		var modified bool
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				version = s.Value
+			case "vcs.modified":
+				modified = s.Value == "true"
+			}
+		}
+		if len(version) > 12 {
+			version = version[:12]
+		}
+		if version != "" && modified {
+			version += "-dirty"
+		}
+	}
+	b.spec.Spec.Version = version
 	return b
 }
 
-func (b *InfoBuilder) Version(v string) *InfoBuilder {
-	b.spec.Spec.Version = v
-	return b
+// moduleName returns the last element of the module path without the major version suffix.
+func moduleName(modulePath string) string {
+	if modulePath == "" {
+		return ""
+	}
+	name := path.Base(modulePath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		if dir := path.Dir(modulePath); dir != "." {
+			name = path.Base(dir)
+		}
+	}
+	return name
 }
@@ -0,0 +1,87 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestInfoBuilder_FromBuildInfo(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		builder *openapi.InfoBuilder
+		version string
+		title   string
+		expVer  string
+	}{
+		{
+			name:    "version from ldflags",
+			builder: openapi.NewInfoBuilder(),
+			version: "v1.2.3",
+			title:   "openapi",
+			expVer:  "v1.2.3",
+		},
+		{
+			name:    "already set",
+			builder: openapi.NewInfoBuilder().Title("Pets").Version("v2.0.0"),
+			version: "v1.2.3",
+			title:   "Pets",
+			expVer:  "v2.0.0",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.builder.FromBuildInfo(tt.version).Build()
+			require.Equal(t, tt.title, info.Spec.Title)
+			require.Equal(t, tt.expVer, info.Spec.Version)
+		})
+	}
+}
+
+func TestInfo_TermsOfService(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		tos  string
+		err  string
+	}{
+		{name: "empty"},
+		{name: "absolute", tos: "https://example.com/terms"},
+		{name: "relative", tos: "/terms", err: `/info/termsOfService: invalid format of URL: "/terms" must be absolute`},
+		{name: "no host", tos: "terms:accepted", err: "/info/termsOfService: invalid format"},
+		{name: "invalid", tos: "http://[::1", err: "/info/termsOfService: invalid format"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("Test").Version("1.0.0").TermsOfService(tt.tos).License(openapi.NewMITLicense()).Build()).
+				Paths(openapi.NewPaths()).
+				Build()
+			validator, err := openapi.NewValidator(spec)
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewSPDXLicense(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		license  *openapi.Extendable[openapi.License]
+		expected openapi.License
+	}{
+		{name: "apache", license: openapi.NewApacheLicense(), expected: openapi.License{Name: "Apache 2.0", Identifier: "Apache-2.0"}},
+		{name: "mit", license: openapi.NewMITLicense(), expected: openapi.License{Name: "MIT", Identifier: "MIT"}},
+		{name: "known", license: openapi.NewSPDXLicense("BSD-3-Clause"), expected: openapi.License{Name: "BSD 3-Clause", Identifier: "BSD-3-Clause"}},
+		{name: "unknown", license: openapi.NewSPDXLicense("EUPL-1.2"), expected: openapi.License{Name: "EUPL-1.2", Identifier: "EUPL-1.2"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, *tt.license.Spec)
+		})
+	}
+}
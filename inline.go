@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type inlineOptions struct {
+	maxDepth int
+}
+
+// InlineOption is a type for the options of Inline.
+type InlineOption func(*inlineOptions)
+
+// InlineMaxDepth sets the maximum number of the nested references inlined into each other,
+// the deeper references are kept. The default is 0, which means no limit.
+func InlineMaxDepth(n int) InlineOption {
+	return func(o *inlineOptions) {
+		o.maxDepth = n
+	}
+}
+
+// Inline returns a copy of the given spec with the references to the components replaced by the copies of
+// the referenced objects, e.g. for the tools not supporting the references.
+// It is the opposite of Bundle.
+//
+// The references of the recursive objects, like a schema of a tree node, can not be inlined,
+// so the reference closing the cycle is kept, as well as the references deeper than InlineMaxDepth.
+// The components are pruned to the ones still referenced, so the document has no components and no references
+// if there are no cycles and no depth limit.
+// The `summary` and `description` of the references are not applied to the inlined objects.
+// The given spec is not modified.
+func Inline(spec *Extendable[OpenAPI], opts ...InlineOption) (*Extendable[OpenAPI], error) {
+	if spec == nil || spec.Spec == nil {
+		return spec, nil
+	}
+	options := &inlineOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	doc := copySpec(spec)
+	c := doc.Spec.Components
+	in := &inliner{options: options, components: c}
+	// the components are the source of the inlined objects, so they are inlined last, after the pruning
+	doc.Spec.Components = nil
+	in.walk(reflect.ValueOf(doc), nil, 0)
+	doc.Spec.Components = c
+	if in.err != nil {
+		return nil, in.err
+	}
+	if c == nil || c.Spec == nil {
+		return doc, nil
+	}
+	pruneComponents(doc)
+	if doc.Spec.Components == nil {
+		return doc, nil
+	}
+	for typ, f := range componentMaps(c.Spec) {
+		iter := f.MapRange()
+		for iter.Next() {
+			ref := joinLoc("#", "components", typ, iter.Key().String())
+			in.walk(iter.Value().Elem(), []string{ref}, 0)
+		}
+	}
+	if in.err != nil {
+		return nil, in.err
+	}
+	// the components referenced by the kept components only are inlined into them now
+	pruneComponents(doc)
+	return doc, nil
+}
+
+type inliner struct {
+	options    *inlineOptions
+	components *Extendable[Components]
+	err        error
+}
+
+// inlineable is implemented by RefOrSpec to inline its reference.
+type inlineable interface {
+	// inline replaces the reference by the copy of the referenced object and returns the reference,
+	// ok is false if the reference is kept.
+	inline(in *inliner, stack []string, depth int) (ref string, ok bool)
+}
+
+func (o *RefOrSpec[T]) inline(in *inliner, stack []string, depth int) (string, bool) {
+	if o.Ref == nil {
+		return "", true
+	}
+	ref := o.Ref.Ref
+	if !strings.HasPrefix(ref, componentsRefPrefix) || slices.Contains(stack, ref) ||
+		in.options.maxDepth > 0 && depth >= in.options.maxDepth {
+		return "", false
+	}
+	spec, err := o.GetSpec(in.components)
+	if err != nil {
+		in.err = fmt.Errorf("inlining %q failed: %w", ref, err)
+		return "", false
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		in.err = fmt.Errorf("inlining %q failed: %w", ref, err)
+		return "", false
+	}
+	var c *T
+	if err := json.Unmarshal(data, &c); err != nil {
+		in.err = fmt.Errorf("inlining %q failed: %w", ref, err)
+		return "", false
+	}
+	o.Ref, o.Spec = nil, c
+	return ref, true
+}
+
+// walk inlines the references of the given value, the stack contains the references inlined by the parents
+// to detect the cycles, and the depth is the number of the inlined parents.
+func (in *inliner) walk(v reflect.Value, stack []string, depth int) {
+	if in.err != nil {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		if t, ok := v.Interface().(inlineable); ok {
+			ref, ok := t.inline(in, stack, depth)
+			if !ok {
+				return
+			}
+			if ref != "" {
+				stack = append(slices.Clip(stack), ref)
+				depth++
+			}
+		}
+		in.walk(v.Elem(), stack, depth)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				in.walk(v.Field(i), stack, depth)
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			in.walk(iter.Value(), stack, depth)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			in.walk(v.Index(i), stack, depth)
+		}
+	}
+}
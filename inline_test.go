@@ -0,0 +1,160 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testInlineSpec = `
+openapi: 3.1.1
+info:
+  title: Inline
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          $ref: '#/components/responses/Pets'
+  /nodes:
+    get:
+      responses:
+        '200':
+          description: nodes
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        $ref: '#/components/schemas/Size'
+  responses:
+    Pets:
+      description: pets
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              $ref: '#/components/schemas/Pet'
+  schemas:
+    Size:
+      type: integer
+      minimum: 1
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        size:
+          $ref: '#/components/schemas/Size'
+    Node:
+      type: object
+      properties:
+        tag:
+          $ref: '#/components/schemas/Tag'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+    Tag:
+      type: string
+`
+
+func TestInline(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testInlineSpec), &spec))
+	original, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	inlined, err := openapi.Inline(spec)
+	require.NoError(t, err)
+
+	validator, err := openapi.NewValidator(inlined)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	op := inlined.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec
+	require.Nil(t, op.Parameters[0].Ref)
+	require.Equal(t, "limit", op.Parameters[0].Spec.Spec.Name)
+	require.Nil(t, op.Parameters[0].Spec.Spec.Schema.Ref)
+	require.Equal(t, openapi.IntegerType, (*op.Parameters[0].Spec.Spec.Schema.Spec.Type)[0])
+	pets := op.Responses.Spec.Response["200"]
+	require.Nil(t, pets.Ref)
+	pet := pets.Spec.Spec.Content["application/json"].Spec.Schema.Spec.Items.Schema
+	require.Nil(t, pet.Ref)
+	require.Nil(t, pet.Spec.Properties["size"].Ref)
+	require.Equal(t, "1", pet.Spec.Properties["size"].Spec.Minimum.String())
+
+	// the recursive schema is inlined once and the ref closing the cycle is kept
+	node := inlined.Spec.Paths.Spec.Paths["/nodes"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"].
+		Spec.Spec.Content["application/json"].Spec.Schema
+	require.Nil(t, node.Ref)
+	require.Nil(t, node.Spec.Properties["tag"].Ref)
+	require.Equal(t, "#/components/schemas/Node", node.Spec.Properties["children"].Spec.Items.Schema.Ref.Ref)
+	schemas := inlined.Spec.Components.Spec.Schemas
+	require.Len(t, schemas, 1)
+	require.Nil(t, schemas["Node"].Spec.Properties["tag"].Ref)
+	require.Equal(t, "#/components/schemas/Node", schemas["Node"].Spec.Properties["children"].Spec.Items.Schema.Ref.Ref)
+	require.Nil(t, inlined.Spec.Components.Spec.Parameters)
+	require.Nil(t, inlined.Spec.Components.Spec.Responses)
+
+	// the given spec is not modified
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+	require.JSONEq(t, string(original), string(data))
+}
+
+func TestInline_MaxDepth(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testInlineSpec), &spec))
+
+	inlined, err := openapi.Inline(spec, openapi.InlineMaxDepth(1))
+	require.NoError(t, err)
+	validator, err := openapi.NewValidator(inlined)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	op := inlined.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec
+	require.Equal(t, "limit", op.Parameters[0].Spec.Spec.Name)
+	require.Equal(t, "#/components/schemas/Size", op.Parameters[0].Spec.Spec.Schema.Ref.Ref)
+	require.Equal(t, "#/components/schemas/Pet",
+		op.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema.Spec.Items.Schema.Ref.Ref)
+	require.Equal(t, []string{"Node", "Pet", "Size", "Tag"}, sortedSchemaNames(inlined))
+}
+
+func TestInline_UnresolvedRef(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("t").Version("v").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().
+			Get(openapi.NewOperationBuilder().
+				AddParameters(openapi.NewRefOrExtSpec[openapi.Parameter]("#/components/parameters/Missing")).
+				Build()).
+			Build()).
+		Build()
+	spec.Spec.Components = openapi.NewComponents()
+	_, err := openapi.Inline(spec)
+	require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	require.ErrorContains(t, err, `inlining "#/components/parameters/Missing" failed`)
+}
+
+func sortedSchemaNames(spec *openapi.Extendable[openapi.OpenAPI]) []string {
+	var names []string
+	for name := range spec.Spec.Components.Spec.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,255 @@
+// Command buildergen generates the builders of the spec objects from their struct definitions.
+//
+// Every exported field of an object gets a setter named after the field;
+// the slices get the variadic setters and the `Add<Field>` methods appending the values,
+// and the maps get the `Add<Singular>` methods adding a value by its name.
+// The pointers to the scalar values, like `*int` or `*Number`, are set by the values.
+//
+// Usage:
+//
+//	go run ./internal/buildergen -output builders_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"slices"
+	"strings"
+)
+
+// wrapper is the way a builder holds its object.
+type wrapper int
+
+const (
+	// plain is `*T`.
+	plain wrapper = iota
+	// ext is `*Extendable[T]`.
+	ext
+	// ref is `*RefOrSpec[T]` for the objects having the extensions themselves, i.e. Schema.
+	ref
+	// refExt is `*RefOrSpec[Extendable[T]]`.
+	refExt
+)
+
+type builder struct {
+	// Type is the name of the object.
+	Type string
+	// Name is the name of the builder, `<Type>Builder` by default.
+	Name    string
+	Wrapper wrapper
+	// CustomConstructor means that the constructor is written by hand, e.g. to set the default values.
+	CustomConstructor bool
+	// Skip are the fields without the generated methods, they are written by hand if needed.
+	Skip []string
+	// Adders are the names of the methods adding the items of the maps, if they differ from `Add<Singular>`.
+	Adders map[string]string
+}
+
+// builders are the builders of the spec objects, the SecurityRequirement map is built by hand.
+var builders = []builder{
+	{Type: "Callback", Wrapper: refExt, CustomConstructor: true, Adders: map[string]string{"Paths": "AddPathItem"}},
+	{Type: "Components", Wrapper: ext},
+	{Type: "Contact", Wrapper: ext},
+	{Type: "Discriminator", Wrapper: plain},
+	{Type: "Encoding", Wrapper: ext},
+	{Type: "Example", Wrapper: refExt},
+	{Type: "ExternalDocs", Wrapper: ext},
+	{Type: "Header", Wrapper: refExt},
+	{Type: "Info", Wrapper: ext},
+	{Type: "License", Wrapper: ext},
+	{Type: "Link", Wrapper: refExt},
+	{Type: "MediaType", Wrapper: ext},
+	{Type: "OAuthFlow", Wrapper: ext},
+	{Type: "OAuthFlows", Wrapper: ext},
+	{Type: "OpenAPI", Wrapper: ext, CustomConstructor: true},
+	{Type: "Operation", Wrapper: ext},
+	{Type: "Parameter", Wrapper: refExt},
+	{Type: "PathItem", Wrapper: refExt},
+	{Type: "Paths", Wrapper: ext},
+	{Type: "RequestBody", Wrapper: refExt},
+	{Type: "Response", Wrapper: refExt},
+	{Type: "Responses", Wrapper: refExt},
+	// the misspelled name is kept for compatibility
+	{Type: "Schema", Name: "SchemaBulder", Wrapper: ref, CustomConstructor: true, Skip: []string{"Type", "Bool", "Extensions"}},
+	{Type: "SecurityScheme", Wrapper: refExt},
+	{Type: "Server", Wrapper: ext},
+	{Type: "ServerVariable", Wrapper: ext},
+	{Type: "Tag", Wrapper: ext},
+	{Type: "XML", Wrapper: ext},
+}
+
+func main() {
+	dir := flag.String("dir", ".", "the directory of the package")
+	output := flag.String("output", "builders_gen.go", "the output file")
+	flag.Parse()
+
+	src, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasSuffix(fi.Name(), "_gen.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	structs := make(map[string]*ast.StructType)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if ts, ok := n.(*ast.TypeSpec); ok {
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						structs[ts.Name.Name] = st
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	g := &generator{fset: fset, structs: structs}
+	g.printf("// Code generated by internal/buildergen; DO NOT EDIT.\n\npackage openapi\n")
+	for _, b := range builders {
+		st, ok := structs[b.Type]
+		if !ok {
+			return nil, fmt.Errorf("struct %s not found", b.Type)
+		}
+		g.builder(b, st)
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code failed: %w\n%s", err, g.buf.Bytes())
+	}
+	return src, nil
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	fset    *token.FileSet
+	structs map[string]*ast.StructType
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) expr(e ast.Expr) string {
+	var b bytes.Buffer
+	_ = printer.Fprint(&b, g.fset, e)
+	return b.String()
+}
+
+func (g *generator) builder(b builder, st *ast.StructType) {
+	name := b.Name
+	if name == "" {
+		name = b.Type + "Builder"
+	}
+	var specType, newSpec, obj, exts string
+	switch b.Wrapper {
+	case plain:
+		specType, newSpec, obj = "*"+b.Type, "&"+b.Type+"{}", "b.spec"
+	case ext:
+		specType = "*Extendable[" + b.Type + "]"
+		newSpec = "NewExtendable[" + b.Type + "](&" + b.Type + "{})"
+		obj, exts = "b.spec.Spec", "b.spec"
+	case ref:
+		specType = "*RefOrSpec[" + b.Type + "]"
+		newSpec = "NewRefOrSpec[" + b.Type + "](&" + b.Type + "{})"
+		obj, exts = "b.spec.Spec", "b.spec.Spec"
+	case refExt:
+		specType = "*RefOrSpec[Extendable[" + b.Type + "]]"
+		newSpec = "NewRefOrExtSpec[" + b.Type + "](&" + b.Type + "{})"
+		obj, exts = "b.spec.Spec.Spec", "b.spec.Spec"
+	}
+
+	g.printf("\n// %s builds %s object.\ntype %s struct {\n\tspec %s\n}\n", name, b.Type, name, specType)
+	if !b.CustomConstructor {
+		g.printf("\n// New%s creates %s with the empty object.\nfunc New%s() *%s {\n\treturn &%s{\n\t\tspec: %s,\n\t}\n}\n",
+			name, name, name, name, name, newSpec)
+	}
+	g.printf("\n// Build returns the built object.\nfunc (b *%s) Build() %s {\n\treturn b.spec\n}\n", name, specType)
+	if exts != "" {
+		g.printf("\n// Extensions sets the extensions of the object.\nfunc (b *%s) Extensions(v map[string]any) *%s {\n\t%s.Extensions = v\n\treturn b\n}\n",
+			name, name, exts)
+		g.printf("\n// AddExt sets the extension, see Extendable.AddExt.\nfunc (b *%s) AddExt(name string, value any) *%s {\n\t%s.AddExt(name, value)\n\treturn b\n}\n",
+			name, name, exts)
+	}
+
+	for _, field := range st.Fields.List {
+		typ := g.expr(field.Type)
+		for _, ident := range field.Names {
+			f := ident.Name
+			if !ident.IsExported() || slices.Contains(b.Skip, f) {
+				continue
+			}
+			target := obj + "." + f
+			switch t := field.Type.(type) {
+			case *ast.ArrayType:
+				elem := g.expr(t.Elt)
+				g.printf("\n// %s sets %s.%s.\nfunc (b *%s) %s(v ...%s) *%s {\n\t%s = v\n\treturn b\n}\n",
+					f, b.Type, f, name, f, elem, name, target)
+				g.printf("\n// Add%s appends the values to %s.%s.\nfunc (b *%s) Add%s(v ...%s) *%s {\n\t%s = append(%s, v...)\n\treturn b\n}\n",
+					f, b.Type, f, name, f, elem, name, target, target)
+			case *ast.MapType:
+				g.printf("\n// %s sets %s.%s.\nfunc (b *%s) %s(v %s) *%s {\n\t%s = v\n\treturn b\n}\n",
+					f, b.Type, f, name, f, typ, name, target)
+				adder := b.Adders[f]
+				if adder == "" {
+					adder = "Add" + singular(f)
+				}
+				value := g.expr(t.Value)
+				g.printf("\n// %s adds the value by the name to %s.%s.\n", adder, b.Type, f)
+				if elem, ok := t.Value.(*ast.ArrayType); ok {
+					g.printf("func (b *%s) %s(name string, value ...%s) *%s {\n", name, adder, g.expr(elem.Elt), name)
+					g.printf("\tif %s == nil {\n\t\t%s = make(%s, 1)\n\t}\n", target, target, typ)
+					g.printf("\t%s[name] = append(%s[name], value...)\n\treturn b\n}\n", target, target)
+				} else {
+					g.printf("func (b *%s) %s(name string, value %s) *%s {\n", name, adder, value, name)
+					g.printf("\tif %s == nil {\n\t\t%s = make(%s, 1)\n\t}\n", target, target, typ)
+					g.printf("\t%s[name] = value\n\treturn b\n}\n", target)
+				}
+			case *ast.StarExpr:
+				if id, ok := t.X.(*ast.Ident); ok && g.structs[id.Name] == nil {
+					// a scalar value, e.g. *int or *Number
+					g.printf("\n// %s sets %s.%s.\nfunc (b *%s) %s(v %s) *%s {\n\t%s = &v\n\treturn b\n}\n",
+						f, b.Type, f, name, f, id.Name, name, target)
+					continue
+				}
+				g.setter(b, name, f, typ, target)
+			default:
+				g.setter(b, name, f, typ, target)
+			}
+		}
+	}
+}
+
+func (g *generator) setter(b builder, name, f, typ, target string) {
+	g.printf("\n// %s sets %s.%s.\nfunc (b *%s) %s(v %s) *%s {\n\t%s = v\n\treturn b\n}\n",
+		f, b.Type, f, name, f, typ, name, target)
+}
+
+// singular returns the singular form of the name of a map field, e.g. `Property` for `Properties`.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
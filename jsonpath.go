@@ -0,0 +1,623 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// jsonPath is a parsed JSONPath query, RFC 9535, supporting:
+//   - the root identifier `$`;
+//   - the child segments: `.name`, `.*`, `['name']`, `[0]`, `[-1]`, `[*]`, `['a','b']`;
+//   - the descendant segments: `..name`, `..*`, `..[...]`;
+//   - the filter selectors: `[?@.name == 'value']`, `[?@.deprecated]`, `[?!@.x-internal]`,
+//     with `==`, `!=`, `<`, `<=`, `>`, `>=` comparisons of the singular queries and the literals,
+//     combined by `&&`, `||`, `!`, and the parentheses.
+//
+// The dot notation allows `-` in the names, so the extensions can be selected, e.g. `$.info.x-logo`.
+type jsonPath struct {
+	segments []*jsonPathSegment
+}
+
+type jsonPathSegment struct {
+	descendant bool
+	selectors  []*jsonPathSelector
+}
+
+type jsonPathSelector struct {
+	wildcard bool
+	name     *string
+	index    *int
+	filter   jsonPathExpr
+}
+
+// jsonPathNode is a node of the document selected by a query with its location as the list of the keys and the indexes.
+type jsonPathNode struct {
+	path  []any
+	value any
+}
+
+func parseJSONPath(query string) (*jsonPath, error) {
+	p := &jsonPathParser{query: query}
+	if !p.consume("$") {
+		return nil, p.errorf("must start with `$`")
+	}
+	segments, err := p.parseSegments(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.query) {
+		return nil, p.errorf("unexpected %q", p.query[p.pos:])
+	}
+	return &jsonPath{segments: segments}, nil
+}
+
+// query returns the nodes of the document selected by the path.
+func (q *jsonPath) query(doc any) []*jsonPathNode {
+	return applyJSONPathSegments(q.segments, []*jsonPathNode{{value: doc}}, doc)
+}
+
+func applyJSONPathSegments(segments []*jsonPathSegment, nodes []*jsonPathNode, root any) []*jsonPathNode {
+	for _, s := range segments {
+		var next []*jsonPathNode
+		for _, n := range nodes {
+			if s.descendant {
+				for _, d := range jsonPathDescendants(n) {
+					next = append(next, s.apply(d, root)...)
+				}
+			} else {
+				next = append(next, s.apply(n, root)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// jsonPathDescendants returns the node and all its descendants in the document order, the keys of the objects are sorted.
+func jsonPathDescendants(n *jsonPathNode) []*jsonPathNode {
+	res := []*jsonPathNode{n}
+	for _, c := range jsonPathChildren(n) {
+		res = append(res, jsonPathDescendants(c)...)
+	}
+	return res
+}
+
+func jsonPathChildren(n *jsonPathNode) []*jsonPathNode {
+	var res []*jsonPathNode
+	switch t := n.value.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			res = append(res, &jsonPathNode{path: jsonPathAppend(n.path, k), value: t[k]})
+		}
+	case []any:
+		for i, v := range t {
+			res = append(res, &jsonPathNode{path: jsonPathAppend(n.path, i), value: v})
+		}
+	}
+	return res
+}
+
+func jsonPathAppend(path []any, key any) []any {
+	return append(slices.Clip(path), key)
+}
+
+func (s *jsonPathSegment) apply(n *jsonPathNode, root any) []*jsonPathNode {
+	var res []*jsonPathNode
+	for _, sel := range s.selectors {
+		switch {
+		case sel.wildcard:
+			res = append(res, jsonPathChildren(n)...)
+		case sel.name != nil:
+			if m, ok := n.value.(map[string]any); ok {
+				if v, ok := m[*sel.name]; ok {
+					res = append(res, &jsonPathNode{path: jsonPathAppend(n.path, *sel.name), value: v})
+				}
+			}
+		case sel.index != nil:
+			if a, ok := n.value.([]any); ok {
+				i := *sel.index
+				if i < 0 {
+					i += len(a)
+				}
+				if i >= 0 && i < len(a) {
+					res = append(res, &jsonPathNode{path: jsonPathAppend(n.path, i), value: a[i]})
+				}
+			}
+		case sel.filter != nil:
+			for _, c := range jsonPathChildren(n) {
+				if sel.filter.test(c.value, root) {
+					res = append(res, c)
+				}
+			}
+		}
+	}
+	return res
+}
+
+type jsonPathParser struct {
+	query string
+	pos   int
+}
+
+func (p *jsonPathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: JSONPath %q at %d: %s", ErrInvalidFormat, p.query, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.query[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.query) && strings.ContainsRune(" \t\n\r", rune(p.query[p.pos])) {
+		p.pos++
+	}
+}
+
+// parseSegments parses the segments until the end of the query, or, in a filter, until a non-segment character.
+func (p *jsonPathParser) parseSegments(inFilter bool) ([]*jsonPathSegment, error) {
+	var segments []*jsonPathSegment
+	for p.pos < len(p.query) {
+		s := &jsonPathSegment{}
+		switch {
+		case p.consume(".."):
+			s.descendant = true
+			if p.pos < len(p.query) && p.query[p.pos] == '[' {
+				p.pos++
+				selectors, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				s.selectors = selectors
+			} else {
+				sel, err := p.parseDotSelector()
+				if err != nil {
+					return nil, err
+				}
+				s.selectors = []*jsonPathSelector{sel}
+			}
+		case p.consume("."):
+			sel, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			s.selectors = []*jsonPathSelector{sel}
+		case p.consume("["):
+			selectors, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			s.selectors = selectors
+		default:
+			if inFilter {
+				return segments, nil
+			}
+			return nil, p.errorf("unexpected %q", p.query[p.pos:])
+		}
+		segments = append(segments, s)
+	}
+	return segments, nil
+}
+
+func (p *jsonPathParser) parseDotSelector() (*jsonPathSelector, error) {
+	if p.consume("*") {
+		return &jsonPathSelector{wildcard: true}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.query) {
+		r, size := utf8.DecodeRuneInString(p.query[p.pos:])
+		if r != '_' && r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) && r < utf8.RuneSelf {
+			break
+		}
+		p.pos += size
+	}
+	if start == p.pos {
+		return nil, p.errorf("name expected")
+	}
+	name := p.query[start:p.pos]
+	return &jsonPathSelector{name: &name}, nil
+}
+
+// parseBracket parses the comma separated selectors after the opening bracket.
+func (p *jsonPathParser) parseBracket() ([]*jsonPathSelector, error) {
+	var selectors []*jsonPathSelector
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpaces()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("`,` or `]` expected")
+		}
+	}
+}
+
+func (p *jsonPathParser) parseSelector() (*jsonPathSelector, error) {
+	switch {
+	case p.consume("*"):
+		return &jsonPathSelector{wildcard: true}, nil
+	case p.consume("?"):
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathSelector{filter: expr}, nil
+	case p.pos < len(p.query) && (p.query[p.pos] == '\'' || p.query[p.pos] == '"'):
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathSelector{name: &s}, nil
+	}
+	start := p.pos
+	p.consume("-")
+	for p.pos < len(p.query) && p.query[p.pos] >= '0' && p.query[p.pos] <= '9' {
+		p.pos++
+	}
+	i, err := strconv.Atoi(p.query[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("selector expected")
+	}
+	return &jsonPathSelector{index: &i}, nil
+}
+
+// parseString parses the single or double quoted string.
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.query[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.query) {
+		c := p.query[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\' && p.pos+1 < len(p.query):
+			p.pos++
+			switch e := p.query[p.pos]; e {
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if p.pos+5 > len(p.query) {
+					return "", p.errorf("invalid escape")
+				}
+				r, err := strconv.ParseUint(p.query[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid escape")
+				}
+				sb.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// jsonPathExpr is a logical expression of a filter selector.
+type jsonPathExpr interface {
+	// test reports whether the expression is true for the current node.
+	test(current, root any) bool
+}
+
+type jsonPathOr []jsonPathExpr
+
+func (e jsonPathOr) test(current, root any) bool {
+	for _, x := range e {
+		if x.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonPathAnd []jsonPathExpr
+
+func (e jsonPathAnd) test(current, root any) bool {
+	for _, x := range e {
+		if !x.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+type jsonPathNot struct {
+	expr jsonPathExpr
+}
+
+func (e *jsonPathNot) test(current, root any) bool {
+	return !e.expr.test(current, root)
+}
+
+// jsonPathExists is the test of the existence of the nodes selected by the relative or the absolute query.
+type jsonPathExists struct {
+	query *jsonPathQuery
+}
+
+func (e *jsonPathExists) test(current, root any) bool {
+	return len(e.query.nodes(current, root)) > 0
+}
+
+type jsonPathQuery struct {
+	relative bool
+	segments []*jsonPathSegment
+}
+
+func (q *jsonPathQuery) nodes(current, root any) []*jsonPathNode {
+	start := root
+	if q.relative {
+		start = current
+	}
+	return applyJSONPathSegments(q.segments, []*jsonPathNode{{value: start}}, root)
+}
+
+// jsonPathComparable is an operand of a comparison, a literal or a singular query.
+type jsonPathComparable struct {
+	query   *jsonPathQuery
+	literal any
+}
+
+// value returns the value of the operand, ok is false if the query selects nothing.
+func (c *jsonPathComparable) value(current, root any) (any, bool) {
+	if c.query == nil {
+		return c.literal, true
+	}
+	nodes := c.query.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0].value, true
+}
+
+type jsonPathComparison struct {
+	op          string
+	left, right *jsonPathComparable
+}
+
+func (e *jsonPathComparison) test(current, root any) bool {
+	l, lok := e.left.value(current, root)
+	r, rok := e.right.value(current, root)
+	if !lok || !rok {
+		// nothing is equal to nothing only
+		switch e.op {
+		case "==":
+			return lok == rok
+		case "!=":
+			return lok != rok
+		case "<=", ">=":
+			return lok == rok
+		}
+		return false
+	}
+	switch e.op {
+	case "==":
+		return jsonPathEqual(l, r)
+	case "!=":
+		return !jsonPathEqual(l, r)
+	}
+	cmp, ok := jsonPathCompare(l, r)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func jsonPathNumber(v any) (float64, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil
+	case float64:
+		return t, true
+	case float32:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	}
+	return math.NaN(), false
+}
+
+func jsonPathEqual(a, b any) bool {
+	if x, ok := jsonPathNumber(a); ok {
+		y, ok := jsonPathNumber(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case map[string]any, []any:
+		y, err := json.Marshal(b)
+		if err != nil {
+			return false
+		}
+		z, err := json.Marshal(x)
+		return err == nil && string(y) == string(z)
+	}
+	return a == b
+}
+
+// jsonPathCompare compares the numbers or the strings, ok is false for the other values.
+func jsonPathCompare(a, b any) (int, bool) {
+	if x, ok := jsonPathNumber(a); ok {
+		y, ok := jsonPathNumber(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	x, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	y, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+func (p *jsonPathParser) parseOr() (jsonPathExpr, error) {
+	var or jsonPathOr
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, expr)
+		p.skipSpaces()
+		if !p.consume("||") {
+			break
+		}
+		p.skipSpaces()
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *jsonPathParser) parseAnd() (jsonPathExpr, error) {
+	var and jsonPathAnd
+	for {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+		p.skipSpaces()
+		if !p.consume("&&") {
+			break
+		}
+		p.skipSpaces()
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *jsonPathParser) parseUnary() (jsonPathExpr, error) {
+	p.skipSpaces()
+	if p.consume("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathNot{expr: expr}, nil
+	}
+	if p.consume("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, p.errorf("`)` expected")
+		}
+		return expr, nil
+	}
+	left, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			p.skipSpaces()
+			right, err := p.parseComparable()
+			if err != nil {
+				return nil, err
+			}
+			return &jsonPathComparison{op: op, left: left, right: right}, nil
+		}
+	}
+	if left.query == nil {
+		return nil, p.errorf("comparison expected")
+	}
+	return &jsonPathExists{query: left.query}, nil
+}
+
+func (p *jsonPathParser) parseComparable() (*jsonPathComparable, error) {
+	if p.pos >= len(p.query) {
+		return nil, p.errorf("operand expected")
+	}
+	switch c := p.query[p.pos]; {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments(true)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathComparable{query: &jsonPathQuery{relative: c == '@', segments: segments}}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathComparable{literal: s}, nil
+	case p.consume("true"):
+		return &jsonPathComparable{literal: true}, nil
+	case p.consume("false"):
+		return &jsonPathComparable{literal: false}, nil
+	case p.consume("null"):
+		return &jsonPathComparable{literal: nil}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.query) && strings.ContainsRune("+-0123456789.eE", rune(p.query[p.pos])) {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.query[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("operand expected")
+	}
+	return &jsonPathComparable{literal: f}, nil
+}
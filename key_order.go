@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyOrder is the order of the keys of the objects of a YAML or JSON source of a spec by their locations.
+//
+// The objects of the spec, e.g. the paths, the components or the properties of the schemas, are held by Go maps,
+// so the keys are sorted when the spec is marshaled; KeyOrder restores the original order,
+// so loading a spec and writing it back keeps the diffs review-friendly.
+//
+// Example:
+//
+//	order, err := openapi.NewKeyOrder(data)
+//	...
+//	var spec *openapi.Extendable[openapi.OpenAPI]
+//	err = yaml.Unmarshal(data, &spec)
+//	... // modify the spec
+//	data, err = order.Marshal(spec)
+type KeyOrder struct {
+	keys map[string]map[string]int
+	json bool
+}
+
+// NewKeyOrder parses the given YAML or JSON source of a spec and records the order of the keys of its objects.
+func NewKeyOrder(data []byte) (*KeyOrder, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w of source: %w", ErrInvalidFormat, err)
+	}
+	o := &KeyOrder{keys: make(map[string]map[string]int), json: isJSON(data)}
+	if len(doc.Content) > 0 {
+		o.add("", doc.Content[0], 0)
+	}
+	return o, nil
+}
+
+// MarshalOrdered marshals the spec into the format of the given source, JSON or YAML,
+// keeping the order of the keys of the source, see KeyOrder.
+func MarshalOrdered(spec *Extendable[OpenAPI], source []byte) ([]byte, error) {
+	o, err := NewKeyOrder(source)
+	if err != nil {
+		return nil, err
+	}
+	return o.Marshal(spec)
+}
+
+func (o *KeyOrder) add(location string, node *yaml.Node, depth int) {
+	// the recursive aliases are limited the same way as by the source map
+	if depth > maxSourceMapDepth {
+		return
+	}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		keys := make(map[string]int, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if _, ok := keys[key]; !ok {
+				keys[key] = len(keys)
+			}
+			o.add(joinLoc(location, key), node.Content[i+1], depth+1)
+		}
+		o.keys[location] = keys
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			o.add(joinLoc(location, i), item, depth+1)
+		}
+	}
+}
+
+// Marshal marshals the spec into the format of the source, JSON or YAML, and orders the keys of the objects
+// present in the source as in the source; the new keys follow them in the usual order.
+// The JSON is compact, like the one returned by json.Marshal.
+func (o *KeyOrder) Marshal(spec *Extendable[OpenAPI]) ([]byte, error) {
+	doc, err := o.marshalNode(spec)
+	if err != nil {
+		return nil, err
+	}
+	if !o.json {
+		return yaml.Marshal(doc)
+	}
+	var b bytes.Buffer
+	if err := writeJSONNode(&b, doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// marshalNode marshals the spec into the format of the source and returns the reordered document node.
+func (o *KeyOrder) marshalNode(spec *Extendable[OpenAPI]) (*yaml.Node, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if o.json {
+		data, err = json.Marshal(spec)
+	} else {
+		data, err = yaml.Marshal(spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec failed: %w", err)
+	}
+	// JSON is a subset of YAML, so both are reordered as YAML nodes
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling spec failed: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		// an empty document, e.g. `null`
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}}}
+	}
+	o.reorder("", doc.Content[0])
+	return &doc, nil
+}
+
+func (o *KeyOrder) reorder(location string, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if keys, ok := o.keys[location]; ok {
+			pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+			}
+			rank := func(key string) int {
+				if i, ok := keys[key]; ok {
+					return i
+				}
+				return len(keys)
+			}
+			slices.SortStableFunc(pairs, func(a, b [2]*yaml.Node) int {
+				return rank(a[0].Value) - rank(b[0].Value)
+			})
+			for i, p := range pairs {
+				node.Content[2*i], node.Content[2*i+1] = p[0], p[1]
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			o.reorder(joinLoc(location, node.Content[i].Value), node.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			o.reorder(joinLoc(location, i), item)
+		}
+	}
+}
+
+// writeJSONNode writes the node parsed from JSON back as JSON,
+// the quoted scalars are the strings and the others are written as is, e.g. numbers, booleans and nulls.
+func writeJSONNode(b *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		b.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeJSONNode(b, node.Content[i]); err != nil {
+				return err
+			}
+			b.WriteByte(':')
+			if err := writeJSONNode(b, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if err := writeJSONNode(b, item); err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+	case yaml.ScalarNode:
+		if node.Style&yaml.DoubleQuotedStyle == 0 {
+			b.WriteString(node.Value)
+			return nil
+		}
+		data, err := json.Marshal(node.Value)
+		if err != nil {
+			return fmt.Errorf("marshaling JSON string failed: %w", err)
+		}
+		b.Write(data)
+	default:
+		return fmt.Errorf("%w: unexpected YAML node kind %d in JSON", ErrInvalidFormat, node.Kind)
+	}
+	return nil
+}
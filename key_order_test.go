@@ -0,0 +1,97 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testKeyOrderSpec = `openapi: 3.1.1
+info:
+    version: 1.0.0
+    title: Key Order
+paths:
+    /pets:
+        post:
+            responses:
+                "201":
+                    description: Created
+        get:
+            responses:
+                "404":
+                    description: Not Found
+                "200":
+                    description: OK
+                    content:
+                        application/json:
+                            schema:
+                                $ref: '#/components/schemas/Pet'
+components:
+    schemas:
+        Pet:
+            type: object
+            properties:
+                name:
+                    type: string
+                id:
+                    type: integer
+            x-order: 1
+        Error:
+            type: object
+`
+
+func TestKeyOrder(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testKeyOrderSpec), &spec))
+		data, err := openapi.MarshalOrdered(spec, []byte(testKeyOrderSpec))
+		require.NoError(t, err)
+		require.Equal(t, testKeyOrderSpec, string(data))
+	})
+
+	t.Run("new keys", func(t *testing.T) {
+		order, err := openapi.NewKeyOrder([]byte(testKeyOrderSpec))
+		require.NoError(t, err)
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testKeyOrderSpec), &spec))
+		spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["age"] = openapi.NewRefOrSpec[openapi.Schema](
+			&openapi.Schema{Type: openapi.NewSingleOrArray(openapi.IntegerType)},
+		)
+		spec.Spec.Components.Spec.Schemas["Category"] = openapi.NewRefOrSpec[openapi.Schema](
+			&openapi.Schema{Type: openapi.NewSingleOrArray(openapi.ObjectType)},
+		)
+
+		data, err := order.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `            properties:
+                name:
+                    type: string
+                id:
+                    type: integer
+                age:
+                    type: integer
+`)
+		require.Contains(t, string(data), `        Error:
+            type: object
+        Category:
+            type: object
+`)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		const source = `{"openapi":"3.1.1","info":{"version":"1.0.0","title":"Key \u003cOrder\u003e"},"paths":{"/b":{"get":{"responses":{"200":{"description":"OK"}}}},"/a":{"get":{"deprecated":true,"responses":{"200":{"description":"OK"}}}}}}`
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(source), &spec))
+		data, err := openapi.MarshalOrdered(spec, []byte(source))
+		require.NoError(t, err)
+		require.Equal(t, source, string(data))
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := openapi.NewKeyOrder([]byte("{"))
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	})
+}
@@ -0,0 +1,92 @@
+package openapi
+
+import "strings"
+
+// KeywordGroups is a set of the groups of the validation keywords used by a schema, see Schema.KeywordGroups.
+type KeywordGroups uint16
+
+const (
+	// NumericKeywords are `multipleOf`, `minimum`, `exclusiveMinimum`, `maximum` and `exclusiveMaximum`.
+	NumericKeywords KeywordGroups = 1 << iota
+	// StringKeywords are `minLength`, `maxLength`, `pattern`, `contentMediaType`, `contentEncoding`
+	// and `contentSchema`.
+	StringKeywords
+	// ObjectKeywords are `properties`, `patternProperties`, `additionalProperties`, `unevaluatedProperties`,
+	// `propertyNames`, `minProperties`, `maxProperties`, `required`, `dependentRequired` and `dependentSchemas`.
+	ObjectKeywords
+	// ArrayKeywords are `items`, `prefixItems`, `unevaluatedItems`, `contains`, `minContains`, `maxContains`,
+	// `minItems`, `maxItems` and `uniqueItems`.
+	ArrayKeywords
+	// CompositionKeywords are `allOf`, `anyOf`, `oneOf` and `not`.
+	CompositionKeywords
+	// ConditionalKeywords are `if`, `then` and `else`.
+	ConditionalKeywords
+	// ReferenceKeywords are `$ref` and `$dynamicRef`.
+	ReferenceKeywords
+	// EnumKeywords are `enum` and `const`.
+	EnumKeywords
+)
+
+var keywordGroupsNames = []string{"numeric", "string", "object", "array", "composition", "conditional", "reference", "enum"}
+
+// Has reports whether all the given groups of keywords are in the set.
+func (k KeywordGroups) Has(groups KeywordGroups) bool {
+	return k&groups == groups
+}
+
+// String returns the names of the groups joined by `|`, e.g. `string|enum`.
+func (k KeywordGroups) String() string {
+	var names []string
+	for i, name := range keywordGroupsNames {
+		if k&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, "|")
+}
+
+// KeywordGroups returns the groups of the validation keywords used by the schema,
+// so the tools walking many schemas can select the interesting ones without checking every field
+// or marshaling the schema like Keywords does, e.g.:
+//
+//	if s.KeywordGroups().Has(openapi.ObjectKeywords) {
+//		// check properties
+//	}
+//
+// The annotations, like `title`, `format` or `default`, and the `type` keyword are not included.
+// The set is computed from the fields of the schema on each call, so it reflects the changes of the schema.
+func (o *Schema) KeywordGroups() KeywordGroups {
+	if o == nil {
+		return 0
+	}
+	var k KeywordGroups
+	if o.MultipleOf != nil || o.Minimum != nil || o.ExclusiveMinimum != nil || o.Maximum != nil || o.ExclusiveMaximum != nil {
+		k |= NumericKeywords
+	}
+	if o.MinLength != nil || o.MaxLength != nil || o.Pattern != "" ||
+		o.ContentMediaType != "" || o.ContentEncoding != "" || o.ContentSchema != nil {
+		k |= StringKeywords
+	}
+	if o.Properties != nil || o.PatternProperties != nil || o.AdditionalProperties != nil || o.UnevaluatedProperties != nil ||
+		o.PropertyNames != nil || o.MinProperties != nil || o.MaxProperties != nil || o.Required != nil ||
+		o.DependentRequired != nil || o.DependentSchemas != nil {
+		k |= ObjectKeywords
+	}
+	if o.Items != nil || o.PrefixItems != nil || o.UnevaluatedItems != nil || o.Contains != nil ||
+		o.MinContains != nil || o.MaxContains != nil || o.MinItems != nil || o.MaxItems != nil || o.UniqueItems != nil {
+		k |= ArrayKeywords
+	}
+	if o.AllOf != nil || o.AnyOf != nil || o.OneOf != nil || o.Not != nil {
+		k |= CompositionKeywords
+	}
+	if o.If != nil || o.Then != nil || o.Else != nil {
+		k |= ConditionalKeywords
+	}
+	if o.Ref != "" || o.DynamicRef != "" {
+		k |= ReferenceKeywords
+	}
+	if o.Enum != nil || o.Const != nil {
+		k |= EnumKeywords
+	}
+	return k
+}
@@ -0,0 +1,75 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestSchema_KeywordGroups(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		schema   string
+		expected openapi.KeywordGroups
+	}{
+		{
+			name:   "annotations only",
+			schema: `{type: string, format: uuid, title: ID, default: foo}`,
+		},
+		{
+			name:     "numeric",
+			schema:   `{type: integer, minimum: 1, maximum: 10}`,
+			expected: openapi.NumericKeywords,
+		},
+		{
+			name:     "string with enum",
+			schema:   `{type: string, pattern: '^[a-z]+$', enum: [a, b]}`,
+			expected: openapi.StringKeywords | openapi.EnumKeywords,
+		},
+		{
+			name:     "object",
+			schema:   `{type: object, required: [id], additionalProperties: false}`,
+			expected: openapi.ObjectKeywords,
+		},
+		{
+			name:     "array",
+			schema:   `{type: array, items: {type: string}, uniqueItems: true}`,
+			expected: openapi.ArrayKeywords,
+		},
+		{
+			name:     "composition and conditional",
+			schema:   `{oneOf: [{type: string}, {type: integer}], if: {type: string}, then: {minLength: 1}}`,
+			expected: openapi.CompositionKeywords | openapi.ConditionalKeywords,
+		},
+		{
+			name:     "reference",
+			schema:   `{$ref: '#/components/schemas/Pet', const: 1}`,
+			expected: openapi.ReferenceKeywords | openapi.EnumKeywords,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema openapi.Schema
+			require.NoError(t, yaml.Unmarshal([]byte(tt.schema), &schema))
+			require.Equal(t, tt.expected, schema.KeywordGroups())
+		})
+	}
+
+	t.Run("has and string", func(t *testing.T) {
+		k := openapi.StringKeywords | openapi.EnumKeywords
+		require.True(t, k.Has(openapi.StringKeywords))
+		require.True(t, k.Has(openapi.StringKeywords|openapi.EnumKeywords))
+		require.False(t, k.Has(openapi.StringKeywords|openapi.ObjectKeywords))
+		require.Equal(t, "string|enum", k.String())
+		require.Equal(t, "", openapi.KeywordGroups(0).String())
+	})
+
+	t.Run("nil and built", func(t *testing.T) {
+		var schema *openapi.Schema
+		require.Zero(t, schema.KeywordGroups())
+		built := openapi.NewSchemaBuilder().Type(openapi.StringType).Pattern("^a").Build()
+		require.Equal(t, openapi.StringKeywords, built.Spec.KeywordGroups())
+	})
+}
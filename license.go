@@ -35,41 +35,35 @@ func (o *License) validateSpec(location string, validator *Validator) []*validat
 	return errs
 }
 
-type LicenseBuilder struct {
-	spec *Extendable[License]
+// spdxLicenseNames holds the human-readable names of the popular licenses by their SPDX identifiers.
+var spdxLicenseNames = map[string]string{
+	"Apache-2.0":    "Apache 2.0",
+	"MIT":           "MIT",
+	"BSD-2-Clause":  "BSD 2-Clause",
+	"BSD-3-Clause":  "BSD 3-Clause",
+	"GPL-3.0-only":  "GNU GPL 3.0",
+	"LGPL-3.0-only": "GNU LGPL 3.0",
+	"MPL-2.0":       "Mozilla Public License 2.0",
+	"ISC":           "ISC",
+	"Unlicense":     "The Unlicense",
 }
 
-func NewLicenseBuilder() *LicenseBuilder {
-	return &LicenseBuilder{
-		spec: NewExtendable[License](&License{}),
+// NewSPDXLicense creates a License object with the given SPDX identifier and the name of the license,
+// the identifier is used as the name for the unknown licenses.
+func NewSPDXLicense(identifier string) *Extendable[License] {
+	name, ok := spdxLicenseNames[identifier]
+	if !ok {
+		name = identifier
 	}
+	return NewLicenseBuilder().Name(name).Identifier(identifier).Build()
 }
 
-func (b *LicenseBuilder) Build() *Extendable[License] {
-	return b.spec
+// NewApacheLicense creates a License object for the Apache 2.0 license.
+func NewApacheLicense() *Extendable[License] {
+	return NewSPDXLicense("Apache-2.0")
 }
 
-func (b *LicenseBuilder) Extensions(v map[string]any) *LicenseBuilder {
-	b.spec.Extensions = v
-	return b
-}
-
-func (b *LicenseBuilder) AddExt(name string, value any) *LicenseBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *LicenseBuilder) Name(v string) *LicenseBuilder {
-	b.spec.Spec.Name = v
-	return b
-}
-
-func (b *LicenseBuilder) Identifier(v string) *LicenseBuilder {
-	b.spec.Spec.Identifier = v
-	return b
-}
-
-func (b *LicenseBuilder) URL(v string) *LicenseBuilder {
-	b.spec.Spec.URL = v
-	return b
+// NewMITLicense creates a License object for the MIT license.
+func NewMITLicense() *Extendable[License] {
+	return NewSPDXLicense("MIT")
 }
@@ -101,65 +101,3 @@ func (o *Link) validateSpec(location string, validator *Validator) []*validation
 	}
 	return errs
 }
-
-type LinkBuilder struct {
-	spec *RefOrSpec[Extendable[Link]]
-}
-
-func NewLinkBuilder() *LinkBuilder {
-	return &LinkBuilder{
-		spec: NewRefOrExtSpec[Link](&Link{}),
-	}
-}
-
-func (b *LinkBuilder) Build() *RefOrSpec[Extendable[Link]] {
-	return b.spec
-}
-
-func (b *LinkBuilder) Extensions(v map[string]any) *LinkBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *LinkBuilder) AddExt(name string, value any) *LinkBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *LinkBuilder) RequestBody(v any) *LinkBuilder {
-	b.spec.Spec.Spec.RequestBody = v
-	return b
-}
-
-func (b *LinkBuilder) Parameters(v map[string]any) *LinkBuilder {
-	b.spec.Spec.Spec.Parameters = v
-	return b
-}
-
-func (b *LinkBuilder) AddParameter(name string, value any) *LinkBuilder {
-	if b.spec.Spec.Spec.Parameters == nil {
-		b.spec.Spec.Spec.Parameters = make(map[string]any, 1)
-	}
-	b.spec.Spec.Spec.Parameters[name] = value
-	return b
-}
-
-func (b *LinkBuilder) Server(v *Extendable[Server]) *LinkBuilder {
-	b.spec.Spec.Spec.Server = v
-	return b
-}
-
-func (b *LinkBuilder) OperationRef(v string) *LinkBuilder {
-	b.spec.Spec.Spec.OperationRef = v
-	return b
-}
-
-func (b *LinkBuilder) OperationID(v string) *LinkBuilder {
-	b.spec.Spec.Spec.OperationID = v
-	return b
-}
-
-func (b *LinkBuilder) Description(v string) *LinkBuilder {
-	b.spec.Spec.Spec.Description = v
-	return b
-}
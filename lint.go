@@ -0,0 +1,370 @@
+package openapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrRuleViolation is wrapped by the issues reported by the lint rules.
+var ErrRuleViolation = errors.New("rule violation")
+
+// Severity is the severity of the issues reported by a lint rule.
+type Severity int
+
+const (
+	// SeverityOff disables the rule.
+	SeverityOff Severity = iota
+	// SeverityHint is for the issues that are suggestions only.
+	SeverityHint
+	// SeverityWarning is for the issues that should be fixed, but do not fail the validation.
+	SeverityWarning
+	// SeverityError is for the issues that fail the validation of the spec.
+	SeverityError
+)
+
+var severityNames = [...]string{
+	SeverityOff:     "off",
+	SeverityHint:    "hint",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// String implements fmt.Stringer interface.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity returns the severity by its name, e.g. `warning`.
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return Severity(s), nil
+		}
+	}
+	return SeverityOff, fmt.Errorf("%w: severity '%s', expected one of %v", ErrInvalidValue, name, severityNames)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidValue, s)
+	}
+	return []byte(severityNames[s]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (s *Severity) UnmarshalText(text []byte) error {
+	v, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// LintIgnoreExt is the name of the extension suppressing the issues of the given lint rules
+// located in the object or in its children, the value is a rule ID or a list of rule IDs.
+//
+// Example:
+//
+//	post:
+//	  x-lint-ignore: mutating-operations-require-auth
+const LintIgnoreExt = "x-lint-ignore"
+
+// Rule is a lint rule checking the spec beyond the structural validation, e.g. the governance conventions.
+type Rule interface {
+	// ID returns the unique and stable identifier of the rule, e.g. `mutating-operations-require-auth`.
+	ID() string
+	// Description returns a short description of the rule.
+	Description() string
+	// DefaultSeverity returns the severity of the rule used unless it is changed by the RuleSeverity option.
+	DefaultSeverity() Severity
+	// Check checks the spec and calls the report function for each found issue.
+	Check(spec *Extendable[OpenAPI], report func(location string, message string))
+}
+
+// LintIssue is an issue found by a lint rule.
+type LintIssue struct {
+	// Rule is the ID of the rule.
+	Rule     string
+	Location Location
+	Message  string
+	Severity Severity
+}
+
+// Error implements error interface.
+func (i *LintIssue) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", i.Location, ErrRuleViolation, i.Message, i.Rule)
+}
+
+// Unwrap returns ErrRuleViolation, so the issues can be checked using errors.Is.
+func (i *LintIssue) Unwrap() error {
+	return ErrRuleViolation
+}
+
+// BuiltinRules returns the lint rules provided by the package with their default configuration.
+// The rules disabled by default can be enabled using the RuleSeverity option
+// or configured and enabled using the WithRules option.
+func BuiltinRules() []Rule {
+	return []Rule{
+		NewMutatingOperationsRequireAuthRule(),
+		&SunsetInFutureRule{},
+		&BinaryFormatRule{},
+		&HeaderStyleRule{},
+		&ScenarioCompletenessRule{},
+		&ConditionalRequestsRule{},
+		&PaginationLinksRule{},
+		&IdempotencyKeyRule{},
+	}
+}
+
+type ruleSeverity struct {
+	id       string
+	severity Severity
+	// paths are the paths or the patterns of the paths (see path.Match) the severity is applied to, all if empty.
+	paths []string
+}
+
+// appliesTo reports whether the severity is applied to the issue with the given location.
+func (s *ruleSeverity) appliesTo(location Location) bool {
+	if len(s.paths) == 0 {
+		return true
+	}
+	segments := location.Segments()
+	if len(segments) < 2 || segments[0] != "paths" {
+		return false
+	}
+	return matchPathPatterns(s.paths, segments[1])
+}
+
+// matchPathPatterns reports whether the given path is equal to or matches one of the patterns, see path.Match.
+func matchPathPatterns(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if pattern == p {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WithRules is a validation option to add the custom lint rules or to replace the built-in ones with the same IDs,
+// e.g. configured differently.
+func WithRules(rules ...Rule) ValidationOption {
+	return func(v *validationOptions) {
+		v.rules = append(v.rules, rules...)
+	}
+}
+
+// RuleSeverity is a validation option to change the severity of the lint rule with the given ID,
+// SeverityOff disables the rule.
+func RuleSeverity(id string, severity Severity) ValidationOption {
+	return func(v *validationOptions) {
+		v.ruleSeverities = append(v.ruleSeverities, ruleSeverity{id: id, severity: severity})
+	}
+}
+
+// RuleSeverityForPaths is a validation option to change the severity of the lint rule with the given ID
+// for the issues located in the given paths only, the paths can be the patterns, see path.Match.
+// The options are applied in order, so the later options override the earlier ones for the same issue.
+func RuleSeverityForPaths(id string, severity Severity, paths ...string) ValidationOption {
+	return func(v *validationOptions) {
+		v.ruleSeverities = append(v.ruleSeverities, ruleSeverity{id: id, severity: severity, paths: paths})
+	}
+}
+
+// Lint checks the spec using the enabled lint rules and returns the found issues sorted by location and rule ID.
+// The issues suppressed by the `x-lint-ignore` extension, see LintIgnoreExt, are not returned.
+//
+// The issues with SeverityError are also returned by ValidateSpec method.
+func (v *Validator) Lint() []*LintIssue {
+	var issues []*LintIssue
+	// the JSON representation of the spec is shared by the rules walking it and by the suppression of the issues,
+	// the one registered in the compiler is reused unless the spec is marshaled lazily
+	var (
+		doc    any
+		docErr error
+		loaded bool
+	)
+	jsonDoc := func() (any, error) {
+		if !loaded {
+			if c := v.cache.Load(); c != nil && !c.lazy {
+				doc = c.doc
+			} else {
+				doc, docErr = specJSONValue(v.spec)
+			}
+			loaded = true
+		}
+		return doc, docErr
+	}
+	for _, rule := range v.lintRules() {
+		var severities []*ruleSeverity
+		base, scoped := rule.DefaultSeverity(), SeverityOff
+		for i := range v.opts.ruleSeverities {
+			if s := &v.opts.ruleSeverities[i]; s.id == rule.ID() {
+				severities = append(severities, s)
+				if len(s.paths) == 0 {
+					base = s.severity
+				} else {
+					scoped = max(scoped, s.severity)
+				}
+			}
+		}
+		// the rule is checked if it is enabled for all or for some paths
+		if base <= SeverityOff && scoped <= SeverityOff {
+			continue
+		}
+		report := func(location string, message string) {
+			severity := rule.DefaultSeverity()
+			for _, s := range severities {
+				if s.appliesTo(Location(location)) {
+					severity = s.severity
+				}
+			}
+			if severity <= SeverityOff {
+				return
+			}
+			issues = append(issues, &LintIssue{
+				Rule:     rule.ID(),
+				Location: Location(location),
+				Message:  message,
+				Severity: severity,
+			})
+		}
+		if r, ok := rule.(jsonRule); ok {
+			if doc, err := jsonDoc(); err == nil {
+				r.checkJSON(doc, report)
+			}
+			continue
+		}
+		rule.Check(v.spec, report)
+	}
+	if len(issues) > 0 {
+		if doc, err := jsonDoc(); err == nil {
+			issues = suppressLintIssues(doc, issues)
+		}
+	}
+	slices.SortStableFunc(issues, func(a, b *LintIssue) int {
+		if c := cmp.Compare(a.Location, b.Location); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Rule, b.Rule)
+	})
+	return issues
+}
+
+// lintRules returns the built-in rules and the rules given by options, the later rules replace the earlier ones.
+func (v *Validator) lintRules() []Rule {
+	rules := BuiltinRules()
+	for _, rule := range v.opts.rules {
+		i := slices.IndexFunc(rules, func(r Rule) bool { return r.ID() == rule.ID() })
+		if i < 0 {
+			rules = append(rules, rule)
+		} else {
+			rules[i] = rule
+		}
+	}
+	return rules
+}
+
+// lintErrors returns the lint issues with SeverityError.
+func (v *Validator) lintErrors() []error {
+	var errs []error
+	for _, issue := range v.Lint() {
+		if issue.Severity >= SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+func parseLintIgnore(v any) ([]string, error) {
+	switch t := extensionValue(v).(type) {
+	case string:
+		return []string{t}, nil
+	case []string:
+		return t, nil
+	case []any:
+		ids := make([]string, len(t))
+		for i, id := range t {
+			s, ok := id.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w of %s, expected rule IDs, but got %T", ErrInvalidFormat, LintIgnoreExt, id)
+			}
+			ids[i] = s
+		}
+		return ids, nil
+	}
+	return nil, fmt.Errorf("%w of %s, expected rule ID or list of rule IDs, but got %T", ErrInvalidFormat, LintIgnoreExt, v)
+}
+
+// suppressLintIssues removes the issues suppressed by the `x-lint-ignore` extensions of the objects of the spec.
+// The spec is walked as raw JSON, since the extensions of all kinds of objects must be found by their locations.
+func suppressLintIssues(doc any, issues []*LintIssue) []*LintIssue {
+	ignores := make(map[Location][]string)
+	var walk func(path locPath, v any)
+	walk = func(path locPath, v any) {
+		switch t := v.(type) {
+		case map[string]any:
+			if ext, ok := t[LintIgnoreExt]; ok {
+				// the invalid values are reported by the validation of the spec
+				if ids, err := parseLintIgnore(ext); err == nil {
+					ignores[Location(path.String())] = ids
+				}
+			}
+			for k, item := range t {
+				walk(append(path, k), item)
+			}
+		case []any:
+			for i, item := range t {
+				walk(append(path, strconv.Itoa(i)), item)
+			}
+		}
+	}
+	walk(make(locPath, 0, 16), doc)
+	if len(ignores) == 0 {
+		return issues
+	}
+	return slices.DeleteFunc(issues, func(issue *LintIssue) bool {
+		l := Location(issue.Location.JSONPointer())
+		for {
+			if slices.Contains(ignores[l], issue.Rule) {
+				return true
+			}
+			if l == "" {
+				return false
+			}
+			l = l.Parent()
+		}
+	})
+}
+
+// jsonRule is implemented by the built-in rules walking the JSON representation of the spec,
+// so Lint marshals the spec once for all of them.
+type jsonRule interface {
+	checkJSON(doc any, report func(location string, message string))
+}
+
+// specJSONValue returns the JSON representation of the spec as the maps, the slices, and the primitive values.
+func specJSONValue(spec *Extendable[OpenAPI]) (any, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,46 @@
+// Package lint provides the ruleset checking the style of an OpenAPI specification beyond the structural validation,
+// like the naming of the operation IDs, the missing descriptions, or the missing 4xx responses,
+// on top of the rule engine of the openapi package, see openapi.Rule.
+//
+// The rules are enabled by WithRuleset option, so they are reported by Validator.Lint method
+// along with the built-in rules of the openapi package and the custom rules added by openapi.WithRules option:
+//
+//	validator, err := openapi.NewValidator(spec, lint.WithRuleset(), openapi.WithRules(myRule))
+//	...
+//	for _, issue := range validator.Lint() {
+//		fmt.Println(issue.Severity, issue)
+//	}
+//
+// The severities of the rules are changed by openapi.RuleSeverity option
+// or by the configuration loaded by openapi.LoadRuleConfig function given the rules of Ruleset function.
+package lint
+
+import (
+	"github.com/sv-tools/openapi"
+)
+
+// Ruleset returns the rules of the package with their default configuration.
+func Ruleset() []openapi.Rule {
+	return []openapi.Rule{
+		NewOperationIDNamingRule(),
+		&MissingDescriptionRule{},
+		&UnusedTagsRule{},
+		&Missing4xxResponseRule{},
+		&NoRefSiblingsRule{},
+	}
+}
+
+// WithRuleset is a validation option to add the rules of Ruleset function to the validator.
+func WithRuleset() openapi.ValidationOption {
+	return openapi.WithRules(Ruleset()...)
+}
+
+// Lint checks the spec using the rules of Ruleset function and the built-in rules of the openapi package,
+// the options can change the severities of the rules or add the custom rules.
+func Lint(spec *openapi.Extendable[openapi.OpenAPI], opts ...openapi.ValidationOption) ([]*openapi.LintIssue, error) {
+	validator, err := openapi.NewValidator(spec, append([]openapi.ValidationOption{WithRuleset()}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return validator.Lint(), nil
+}
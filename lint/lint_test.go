@@ -0,0 +1,141 @@
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/lint"
+)
+
+const testSpec = `
+openapi: 3.1.1
+info:
+  title: Lint
+  version: 1.0.0
+tags:
+  - name: pets
+    description: The pets.
+  - name: owners
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List the pets.
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        4XX:
+          description: Client error
+    post:
+      operationId: create_pet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+        '400':
+          description: Bad request
+  /pets/{id}:
+    delete:
+      summary: Delete the pet.
+      tags: [pets]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: The ID of the pet.
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      description: A pet.
+      properties:
+        name:
+          $ref: '#/components/schemas/Name'
+          maxLength: 10
+    Name:
+      type: string
+`
+
+func loadSpec(t *testing.T) *openapi.Extendable[openapi.OpenAPI] {
+	t.Helper()
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, openapi.Unmarshal([]byte(testSpec), &spec, openapi.KeepRefSiblings()))
+	return spec
+}
+
+func formatIssues(issues []*openapi.LintIssue) []string {
+	actual := make([]string, len(issues))
+	for i, issue := range issues {
+		actual[i] = issue.Severity.String() + " " + issue.Rule + " " + issue.Location.String() + ": " + issue.Message
+	}
+	return actual
+}
+
+func TestLint(t *testing.T) {
+	issues, err := lint.Lint(loadSpec(t))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`hint missing-description /components/schemas/Name: schema "Name" has no description`,
+		`warning no-ref-siblings /components/schemas/Pet/properties/name: $ref has sibling keywords: maxLength`,
+		`hint missing-description /paths/~1pets/get/parameters/0: query parameter "limit" has no description`,
+		`hint missing-description /paths/~1pets/post: operation has no summary or description`,
+		`warning operation-id-naming /paths/~1pets/post/operationId: operationId "create_pet" does not match pattern "^[a-z][a-zA-Z0-9]*$"`,
+		`warning operation-id-naming /paths/~1pets~1{id}/delete: operation has no operationId`,
+		`warning missing-4xx-response /paths/~1pets~1{id}/delete/responses: operation has no 4xx response`,
+		`hint missing-description /tags/1: tag "owners" has no description`,
+		`warning unused-tags /tags/1: tag "owners" is not used`,
+	}, formatIssues(issues))
+}
+
+func TestLint_Config(t *testing.T) {
+	opts, err := openapi.LoadRuleConfig(strings.NewReader(`
+rules:
+  missing-description: off
+  operation-id-naming:
+    severity: error
+    options:
+      pattern: '^[a-z][a-z_]*$'
+`), lint.Ruleset()...)
+	require.NoError(t, err)
+	validator, err := openapi.NewValidator(loadSpec(t), append(opts, openapi.RuleSeverity(lint.NoRefSiblingsRuleID, openapi.SeverityOff))...)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`error operation-id-naming /paths/~1pets/get/operationId: operationId "listPets" does not match pattern "^[a-z][a-z_]*$"`,
+		`error operation-id-naming /paths/~1pets~1{id}/delete: operation has no operationId`,
+		`warning missing-4xx-response /paths/~1pets~1{id}/delete/responses: operation has no 4xx response`,
+		`warning unused-tags /tags/1: tag "owners" is not used`,
+	}, formatIssues(validator.Lint()))
+
+	_, err = openapi.LoadRuleConfig(strings.NewReader(`
+rules:
+  operation-id-naming:
+    options:
+      pattern: '['
+`), lint.Ruleset()...)
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+}
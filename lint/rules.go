@@ -0,0 +1,290 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// The IDs of the rules of the package.
+const (
+	OperationIDNamingRuleID  = "operation-id-naming"
+	MissingDescriptionRuleID = "missing-description"
+	UnusedTagsRuleID         = "unused-tags"
+	Missing4xxResponseRuleID = "missing-4xx-response"
+	NoRefSiblingsRuleID      = "no-ref-siblings"
+)
+
+// DefaultOperationIDPattern is the default pattern of OperationIDNamingRule, the camel case, e.g. `listPets`.
+const DefaultOperationIDPattern = `^[a-z][a-zA-Z0-9]*$`
+
+// OperationIDNamingRule checks that every operation has an operationId matching the pattern, camel case by default.
+type OperationIDNamingRule struct {
+	// Pattern is the pattern of the operation IDs, DefaultOperationIDPattern is used if it is nil.
+	Pattern *regexp.Regexp
+}
+
+// NewOperationIDNamingRule creates the rule with DefaultOperationIDPattern.
+func NewOperationIDNamingRule() *OperationIDNamingRule {
+	return &OperationIDNamingRule{Pattern: regexp.MustCompile(DefaultOperationIDPattern)}
+}
+
+// ID implements openapi.Rule interface.
+func (r *OperationIDNamingRule) ID() string {
+	return OperationIDNamingRuleID
+}
+
+// Description implements openapi.Rule interface.
+func (r *OperationIDNamingRule) Description() string {
+	return "operations must have operationId matching the naming pattern"
+}
+
+// DefaultSeverity implements openapi.Rule interface.
+func (r *OperationIDNamingRule) DefaultSeverity() openapi.Severity {
+	return openapi.SeverityWarning
+}
+
+// Options implements openapi.ConfigurableRule interface.
+func (r *OperationIDNamingRule) Options() []openapi.RuleOption {
+	return []openapi.RuleOption{
+		{
+			Name:        "pattern",
+			Description: "the regular expression the operation IDs must match",
+			Schema: openapi.NewSchemaBuilder().
+				Type(openapi.StringType).
+				Format("regex").
+				Default(r.pattern().String()).
+				Build(),
+		},
+	}
+}
+
+// Configure implements openapi.ConfigurableRule interface.
+func (r *OperationIDNamingRule) Configure(options map[string]any) error {
+	var cfg struct {
+		Pattern string `json:"pattern"`
+	}
+	if err := decodeRuleOptions(options, &cfg); err != nil {
+		return err
+	}
+	if cfg.Pattern == "" {
+		r.Pattern = nil
+		return nil
+	}
+	pattern, err := regexp.Compile(cfg.Pattern)
+	if err != nil {
+		return fmt.Errorf("%w of pattern: %w", openapi.ErrInvalidValue, err)
+	}
+	r.Pattern = pattern
+	return nil
+}
+
+// Check implements openapi.Rule interface.
+func (r *OperationIDNamingRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(location string, message string)) {
+	pattern := r.pattern()
+	walk(spec, func(node *openapi.WalkNode) {
+		op, ok := node.Value.(*openapi.Operation)
+		if !ok {
+			return
+		}
+		switch {
+		case op.OperationID == "":
+			report(node.Location.String(), "operation has no operationId")
+		case !pattern.MatchString(op.OperationID):
+			report(node.Location.Join("operationId").String(),
+				fmt.Sprintf("operationId %q does not match pattern %q", op.OperationID, pattern))
+		}
+	})
+}
+
+func (r *OperationIDNamingRule) pattern() *regexp.Regexp {
+	if r.Pattern == nil {
+		return regexp.MustCompile(DefaultOperationIDPattern)
+	}
+	return r.Pattern
+}
+
+// MissingDescriptionRule checks that the operations have a summary or a description,
+// and the parameters, the tags, and the schemas of the components have a description.
+type MissingDescriptionRule struct{}
+
+// ID implements openapi.Rule interface.
+func (r *MissingDescriptionRule) ID() string {
+	return MissingDescriptionRuleID
+}
+
+// Description implements openapi.Rule interface.
+func (r *MissingDescriptionRule) Description() string {
+	return "operations, parameters, tags, and schemas of components must be described"
+}
+
+// DefaultSeverity implements openapi.Rule interface.
+func (r *MissingDescriptionRule) DefaultSeverity() openapi.Severity {
+	return openapi.SeverityHint
+}
+
+// Check implements openapi.Rule interface.
+func (r *MissingDescriptionRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(location string, message string)) {
+	walk(spec, func(node *openapi.WalkNode) {
+		switch v := node.Value.(type) {
+		case *openapi.Operation:
+			if v.Summary == "" && v.Description == "" {
+				report(node.Location.String(), "operation has no summary or description")
+			}
+		case *openapi.Parameter:
+			if v.Description == "" {
+				report(node.Location.String(), fmt.Sprintf("%s parameter %q has no description", v.In, v.Name))
+			}
+		case *openapi.Tag:
+			if v.Description == "" {
+				report(node.Location.String(), fmt.Sprintf("tag %q has no description", v.Name))
+			}
+		case *openapi.Schema:
+			segments := node.Location.Segments()
+			if len(segments) == 3 && segments[0] == "components" && segments[1] == "schemas" && v.Description == "" {
+				report(node.Location.String(), fmt.Sprintf("schema %q has no description", segments[2]))
+			}
+		}
+	})
+}
+
+// UnusedTagsRule checks that every tag declared at the top level of the spec is used by an operation.
+type UnusedTagsRule struct{}
+
+// ID implements openapi.Rule interface.
+func (r *UnusedTagsRule) ID() string {
+	return UnusedTagsRuleID
+}
+
+// Description implements openapi.Rule interface.
+func (r *UnusedTagsRule) Description() string {
+	return "declared tags must be used by operations"
+}
+
+// DefaultSeverity implements openapi.Rule interface.
+func (r *UnusedTagsRule) DefaultSeverity() openapi.Severity {
+	return openapi.SeverityWarning
+}
+
+// Check implements openapi.Rule interface.
+func (r *UnusedTagsRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(location string, message string)) {
+	if spec == nil || spec.Spec == nil || len(spec.Spec.Tags) == 0 {
+		return
+	}
+	used := make(map[string]bool)
+	walk(spec, func(node *openapi.WalkNode) {
+		if op, ok := node.Value.(*openapi.Operation); ok {
+			for _, tag := range op.Tags {
+				used[tag] = true
+			}
+		}
+	})
+	for i, tag := range spec.Spec.Tags {
+		if tag != nil && tag.Spec != nil && !used[tag.Spec.Name] {
+			report(openapi.NewLocation("tags", strconv.Itoa(i)).String(), fmt.Sprintf("tag %q is not used", tag.Spec.Name))
+		}
+	}
+}
+
+// Missing4xxResponseRule checks that every operation describes a client error response,
+// i.e. has a 4xx response, the `4XX` range, or the default response.
+type Missing4xxResponseRule struct{}
+
+// ID implements openapi.Rule interface.
+func (r *Missing4xxResponseRule) ID() string {
+	return Missing4xxResponseRuleID
+}
+
+// Description implements openapi.Rule interface.
+func (r *Missing4xxResponseRule) Description() string {
+	return "operations must describe a 4xx response"
+}
+
+// DefaultSeverity implements openapi.Rule interface.
+func (r *Missing4xxResponseRule) DefaultSeverity() openapi.Severity {
+	return openapi.SeverityWarning
+}
+
+// Check implements openapi.Rule interface.
+func (r *Missing4xxResponseRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(location string, message string)) {
+	walk(spec, func(node *openapi.WalkNode) {
+		op, ok := node.Value.(*openapi.Operation)
+		if !ok || op.Responses == nil || op.Responses.Spec == nil {
+			// the missing responses are reported by the validation of the spec
+			return
+		}
+		if op.Responses.Spec.Default != nil {
+			return
+		}
+		for code := range op.Responses.Spec.Response {
+			if strings.HasPrefix(code, "4") {
+				return
+			}
+		}
+		report(node.Location.Join("responses").String(), "operation has no 4xx response")
+	})
+}
+
+// NoRefSiblingsRule checks that the schemas having `$ref` have no sibling keywords other than `description`,
+// since they are ignored by the tools supporting OpenAPI v3.0 only, see openapi.KeepRefSiblings.
+type NoRefSiblingsRule struct{}
+
+// ID implements openapi.Rule interface.
+func (r *NoRefSiblingsRule) ID() string {
+	return NoRefSiblingsRuleID
+}
+
+// Description implements openapi.Rule interface.
+func (r *NoRefSiblingsRule) Description() string {
+	return "schemas with $ref must not have sibling keywords"
+}
+
+// DefaultSeverity implements openapi.Rule interface.
+func (r *NoRefSiblingsRule) DefaultSeverity() openapi.Severity {
+	return openapi.SeverityWarning
+}
+
+// Check implements openapi.Rule interface.
+func (r *NoRefSiblingsRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(location string, message string)) {
+	walk(spec, func(node *openapi.WalkNode) {
+		schema, ok := node.Value.(*openapi.Schema)
+		if !ok || schema.Ref == "" {
+			return
+		}
+		siblings := slices.DeleteFunc(schema.Keywords(), func(k string) bool {
+			return k == "$ref" || k == "description" || k == "summary"
+		})
+		if len(siblings) > 0 {
+			report(node.Location.String(), fmt.Sprintf("$ref has sibling keywords: %s", strings.Join(siblings, ", ")))
+		}
+	})
+}
+
+// walk calls the function for each object of the spec, see openapi.Walk.
+func walk(spec *openapi.Extendable[openapi.OpenAPI], fn func(node *openapi.WalkNode)) {
+	_ = openapi.Walk(spec, func(node *openapi.WalkNode) error {
+		fn(node)
+		return nil
+	})
+}
+
+// decodeRuleOptions decodes the options of a rule into the given struct with json tags,
+// an error is returned for an unknown option or for an invalid value.
+func decodeRuleOptions(options map[string]any, v any) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return fmt.Errorf("%w of rule options: %w", openapi.ErrInvalidFormat, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w of rule options: %w", openapi.ErrInvalidValue, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// MutatingOperationsRequireAuthRuleID is the ID of MutatingOperationsRequireAuthRule.
+const MutatingOperationsRequireAuthRuleID = "mutating-operations-require-auth"
+
+// MutatingOperationsRequireAuthRule is a governance rule checking that POST, PUT, PATCH and DELETE operations
+// require authentication, i.e. have at least one effective security requirement (see EffectiveSecurity)
+// and none of them is empty, since the empty one allows the anonymous access.
+//
+// The rule is disabled by default, it can be enabled using RuleSeverity option and configured using WithRules option:
+//
+//	openapi.NewValidator(spec,
+//		openapi.WithRules(&openapi.MutatingOperationsRequireAuthRule{ExemptPaths: []string{"/login", "/public/*"}}),
+//		openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityError),
+//	)
+type MutatingOperationsRequireAuthRule struct {
+	// ExemptPaths are the paths or the patterns of the paths (see path.Match) not checked by the rule.
+	ExemptPaths []string
+}
+
+// NewMutatingOperationsRequireAuthRule creates the rule with the given exempt paths.
+func NewMutatingOperationsRequireAuthRule(exemptPaths ...string) *MutatingOperationsRequireAuthRule {
+	return &MutatingOperationsRequireAuthRule{ExemptPaths: exemptPaths}
+}
+
+// ID implements Rule interface.
+func (r *MutatingOperationsRequireAuthRule) ID() string {
+	return MutatingOperationsRequireAuthRuleID
+}
+
+// Description implements Rule interface.
+func (r *MutatingOperationsRequireAuthRule) Description() string {
+	return "POST, PUT, PATCH and DELETE operations must require authentication"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *MutatingOperationsRequireAuthRule) DefaultSeverity() Severity {
+	return SeverityOff
+}
+
+// Options implements ConfigurableRule interface.
+func (r *MutatingOperationsRequireAuthRule) Options() []RuleOption {
+	exemptPaths := r.ExemptPaths
+	if exemptPaths == nil {
+		exemptPaths = []string{}
+	}
+	return []RuleOption{
+		{
+			Name:        "exemptPaths",
+			Description: "the paths or the patterns of the paths, like `/public/*`, not checked by the rule",
+			Schema: NewSchemaBuilder().
+				Type(ArrayType).
+				Items(NewBoolOrSchema(NewSchemaBuilder().Type(StringType).Build())).
+				Default(exemptPaths).
+				Build(),
+		},
+	}
+}
+
+// Configure implements ConfigurableRule interface.
+func (r *MutatingOperationsRequireAuthRule) Configure(options map[string]any) error {
+	var cfg struct {
+		ExemptPaths []string `json:"exemptPaths"`
+	}
+	if err := decodeRuleOptions(options, &cfg); err != nil {
+		return err
+	}
+	r.ExemptPaths = cfg.ExemptPaths
+	return nil
+}
+
+// Check implements Rule interface.
+func (r *MutatingOperationsRequireAuthRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	items := spec.Spec.Paths.Spec.Paths
+	for _, p := range sortedKeys(items) {
+		if r.isExempt(p) {
+			continue
+		}
+		item := items[p]
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			continue
+		}
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			op := item.Spec.Spec.operation(method)
+			if op == nil || op.Spec == nil {
+				continue
+			}
+			security := spec.Spec.Security
+			if op.Spec.Security != nil {
+				security = op.Spec.Security
+			}
+			loc := joinLoc("/paths", p, strings.ToLower(method))
+			if len(security) == 0 {
+				report(loc, method+" operation does not require authentication")
+				continue
+			}
+			for i, req := range security {
+				if len(req) == 0 {
+					location := loc
+					if op.Spec.Security != nil {
+						location = joinLoc(loc, "security", i)
+					}
+					report(location, method+" operation allows anonymous access")
+				}
+			}
+		}
+	}
+}
+
+func (r *MutatingOperationsRequireAuthRule) isExempt(p string) bool {
+	return matchPathPatterns(r.ExemptPaths, p)
+}
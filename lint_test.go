@@ -0,0 +1,136 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testLintSpec = `
+openapi: 3.1.1
+info:
+  title: Lint
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      security:
+        - apiKey: []
+      responses:
+        '201':
+          description: Created
+    delete:
+      responses:
+        '204':
+          description: Deleted
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      security:
+        - apiKey: []
+        - {}
+      responses:
+        '200':
+          description: OK
+  /public/feedback:
+    post:
+      responses:
+        '201':
+          description: Created
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+`
+
+type testDescriptionRule struct{}
+
+func (testDescriptionRule) ID() string                        { return "operation-summary" }
+func (testDescriptionRule) Description() string               { return "operations must have a summary" }
+func (testDescriptionRule) DefaultSeverity() openapi.Severity { return openapi.SeverityHint }
+func (testDescriptionRule) Check(spec *openapi.Extendable[openapi.OpenAPI], report func(string, string)) {
+	if spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Summary == "" {
+		report("/paths/~1pets/get/summary", "missing summary")
+	}
+}
+
+func TestValidator_Lint(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testLintSpec), &spec))
+
+	t.Run("disabled by default", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec)
+		require.NoError(t, err)
+		require.Empty(t, validator.Lint())
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("mutating operations require auth", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec,
+			openapi.WithRules(openapi.NewMutatingOperationsRequireAuthRule("/public/*")),
+			openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityError),
+		)
+		require.NoError(t, err)
+
+		issues := validator.Lint()
+		require.Len(t, issues, 2)
+		require.Equal(t, &openapi.LintIssue{
+			Rule:     openapi.MutatingOperationsRequireAuthRuleID,
+			Location: "/paths/~1pets/delete",
+			Message:  "DELETE operation does not require authentication",
+			Severity: openapi.SeverityError,
+		}, issues[0])
+		require.Equal(t, openapi.Location("/paths/~1pets~1{id}/put/security/1"), issues[1].Location)
+
+		err = validator.ValidateSpec()
+		require.ErrorIs(t, err, openapi.ErrRuleViolation)
+		require.ErrorContains(t, err, "/paths/~1pets/delete: rule violation: DELETE operation does not require authentication (mutating-operations-require-auth)")
+		require.Equal(t, openapi.MessageID(openapi.MutatingOperationsRequireAuthRuleID), openapi.MessageIDOf(err))
+		loc, ok := openapi.LocationOf(err)
+		require.True(t, ok)
+		require.Equal(t, openapi.Location("/paths/~1pets/delete"), loc)
+
+		t.Run("warning", func(t *testing.T) {
+			clone, err := validator.Clone(openapi.RuleSeverity(openapi.MutatingOperationsRequireAuthRuleID, openapi.SeverityWarning))
+			require.NoError(t, err)
+			require.Len(t, clone.Lint(), 2)
+			require.NoError(t, clone.ValidateSpec())
+			// the original validator is not changed
+			require.Error(t, validator.ValidateSpec())
+		})
+	})
+
+	t.Run("custom rule", func(t *testing.T) {
+		validator, err := openapi.NewValidator(spec, openapi.WithRules(testDescriptionRule{}))
+		require.NoError(t, err)
+		issues := validator.Lint()
+		require.Len(t, issues, 1)
+		require.Equal(t, openapi.SeverityHint, issues[0].Severity)
+		require.NoError(t, validator.ValidateSpec())
+	})
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []openapi.Severity{openapi.SeverityOff, openapi.SeverityHint, openapi.SeverityWarning, openapi.SeverityError} {
+		parsed, err := openapi.ParseSeverity(s.String())
+		require.NoError(t, err)
+		require.Equal(t, s, parsed)
+	}
+	_, err := openapi.ParseSeverity("fatal")
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	require.Equal(t, "Severity(10)", openapi.Severity(10).String())
+}
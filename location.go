@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Location is a location of a validation error in the specification or in the validated data,
+// e.g. `/paths/~1pets/get/parameters/0` or `query/limit`.
+//
+// The location uses JSON Pointer syntax: the segments are separated by `/` and escaped using `~0` and `~1`.
+// The locations of the objects loaded by a reference start with the reference, e.g. `#/components/schemas/Pet/type`.
+type Location string
+
+// NewLocation creates a location from the given (not escaped) segments.
+func NewLocation(segments ...string) Location {
+	if len(segments) == 0 {
+		return ""
+	}
+	parts := make([]any, len(segments))
+	for i, s := range segments {
+		parts[i] = s
+	}
+	return Location(joinLoc("", parts...))
+}
+
+// ParseJSONPointer converts the given JSON Pointer, optionally prefixed with `#` as an URI fragment, into a location.
+func ParseJSONPointer(pointer string) (Location, error) {
+	p := strings.TrimPrefix(pointer, "#")
+	if p != "" && p[0] != '/' {
+		return "", fmt.Errorf("%w: JSON Pointer must start with `/`, but got %q", ErrInvalidFormat, pointer)
+	}
+	for i := 0; i < len(p); i++ {
+		if p[i] == '~' && (i+1 == len(p) || p[i+1] != '0' && p[i+1] != '1') {
+			return "", fmt.Errorf("%w: invalid escape sequence in JSON Pointer %q", ErrInvalidFormat, pointer)
+		}
+	}
+	return Location(p), nil
+}
+
+// LocationOf returns the location of the given validation error, merge conflict or lint issue.
+func LocationOf(err error) (Location, bool) {
+	var ve *validationError
+	if errors.As(err, &ve) {
+		return Location(ve.location), true
+	}
+	var mc *MergeConflict
+	if errors.As(err, &mc) {
+		return mc.Location, true
+	}
+	var issue *LintIssue
+	if errors.As(err, &issue) {
+		return issue.Location, true
+	}
+	return "", false
+}
+
+// String implements fmt.Stringer interface.
+func (l Location) String() string {
+	return string(l)
+}
+
+// JSONPointer returns the location as JSON Pointer, the `#` prefix of the references is removed.
+func (l Location) JSONPointer() string {
+	p := strings.TrimPrefix(string(l), "#")
+	if p != "" && p[0] != '/' {
+		p = "/" + p
+	}
+	return p
+}
+
+// Segments returns the unescaped segments of the location.
+func (l Location) Segments() []string {
+	p := strings.TrimPrefix(l.JSONPointer(), "/")
+	if p == "" {
+		return nil
+	}
+	parts := strings.Split(p, "/")
+	for i := range parts {
+		parts[i] = jsonPointerUnescaper.Replace(parts[i])
+	}
+	return parts
+}
+
+// Parent returns the location of the parent object, the parent of a top-level location is an empty location.
+func (l Location) Parent() Location {
+	i := strings.LastIndexByte(string(l), '/')
+	if i < 0 {
+		return ""
+	}
+	return l[:i]
+}
+
+// Join returns the location of the child object with the given segments.
+func (l Location) Join(segments ...any) Location {
+	return Location(joinLoc(string(l), segments...))
+}
+
+// IsWithin reports whether the location is equal to the given prefix or located inside of it.
+// The locations are compared by the segments, so `/paths/~1pets` is not within `/paths/~1pe`.
+func (l Location) IsWithin(prefix Location) bool {
+	segments, prefixSegments := l.Segments(), prefix.Segments()
+	if len(prefixSegments) > len(segments) {
+		return false
+	}
+	for i, s := range prefixSegments {
+		if segments[i] != s {
+			return false
+		}
+	}
+	return true
+}
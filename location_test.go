@@ -0,0 +1,74 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestLocation(t *testing.T) {
+	loc := openapi.NewLocation("paths", "/pets/{id}", "get", "parameters", "0")
+	require.Equal(t, openapi.Location("/paths/~1pets~1{id}/get/parameters/0"), loc)
+	require.Equal(t, []string{"paths", "/pets/{id}", "get", "parameters", "0"}, loc.Segments())
+	require.Equal(t, openapi.Location("/paths/~1pets~1{id}/get/parameters"), loc.Parent())
+	require.Equal(t, openapi.Location("/paths/~1pets~1{id}/get"), loc.Parent().Parent())
+	require.Equal(t, loc, loc.Parent().Join(0))
+	require.Equal(t, openapi.Location(""), openapi.NewLocation())
+	require.Equal(t, openapi.Location(""), openapi.Location("query").Parent())
+
+	require.True(t, loc.IsWithin("/paths/~1pets~1{id}"))
+	require.True(t, loc.IsWithin(loc))
+	require.True(t, loc.IsWithin(""))
+	require.False(t, loc.IsWithin("/paths/~1pets"))
+	require.False(t, loc.Parent().IsWithin(loc))
+
+	ref := openapi.Location("#/components/schemas/Pet/type")
+	require.Equal(t, "/components/schemas/Pet/type", ref.JSONPointer())
+	require.True(t, ref.IsWithin("/components/schemas"))
+	require.Equal(t, "/query/limit", openapi.Location("query/limit").JSONPointer())
+	require.Equal(t, []string{"query", "limit"}, openapi.Location("query/limit").Segments())
+
+	for _, tt := range []struct {
+		pointer  string
+		expected openapi.Location
+		err      string
+	}{
+		{pointer: "", expected: ""},
+		{pointer: "/a~1b/c~0d", expected: "/a~1b/c~0d"},
+		{pointer: "#/components", expected: "/components"},
+		{pointer: "components", err: "must start with `/`"},
+		{pointer: "/a~2", err: "invalid escape sequence"},
+		{pointer: "/a~", err: "invalid escape sequence"},
+	} {
+		t.Run(tt.pointer, func(t *testing.T) {
+			l, err := openapi.ParseJSONPointer(tt.pointer)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, l)
+		})
+	}
+}
+
+func TestLocationOf(t *testing.T) {
+	var o *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte("openapi: 3.1.1\ninfo:\n  title: foo\npaths: {}\n"), &o))
+	v, err := openapi.NewValidator(o)
+	require.NoError(t, err)
+	err = v.ValidateSpec()
+	require.Error(t, err)
+	loc, ok := openapi.LocationOf(err)
+	require.True(t, ok)
+	require.Equal(t, openapi.Location("/info/version"), loc)
+	require.Equal(t, openapi.Location("/info"), loc.Parent())
+
+	_, ok = openapi.LocationOf(errors.New("foo"))
+	require.False(t, ok)
+}
@@ -1,5 +1,10 @@
 package openapi
 
+import (
+	"mime"
+	"strings"
+)
+
 // MediaType provides schema and examples for the media type identified by its key.
 //
 // https://spec.openapis.org/oas/v3.1.1#media-type-object
@@ -65,16 +70,17 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 		}
 	}
 
-	if validator.opts.doNotValidateExamples {
+	// the schema can be omitted, e.g. for the binary content, unless there are examples to validate
+	if validator.opts.doNotValidateExamples || o.Example == nil && len(o.Examples) == 0 {
 		return errs
 	}
 	if o.Schema == nil {
-		return append(errs, newValidationError(location, "unable to validate examples without schema"))
+		return append(errs, newValidationError(location, "schema is %w to validate examples", ErrRequired))
 	}
 	schemaRef := o.Schema.getLocationOrRef(joinLoc(location, "schema"))
 	if o.Example != nil {
 		if e := validator.ValidateData(schemaRef, o.Example); e != nil {
-			errs = append(errs, newValidationError(joinLoc(location, "example"), e))
+			errs = append(errs, newValidationError(joinLoc(location, "example"), "%w: %w", ErrInvalidData, e))
 		}
 	}
 	if len(o.Examples) > 0 {
@@ -86,7 +92,7 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 			}
 			if value := example.Spec.Value; value != nil {
 				if e := validator.ValidateData(schemaRef, value); e != nil {
-					errs = append(errs, newValidationError(joinLoc(location, "examples", k), e))
+					errs = append(errs, newValidationError(joinLoc(location, "examples", k), "%w: %w", ErrInvalidData, e))
 				}
 			}
 		}
@@ -95,62 +101,46 @@ func (o *MediaType) validateSpec(location string, validator *Validator) []*valid
 	return errs
 }
 
-type MediaTypeBuilder struct {
-	spec *Extendable[MediaType]
+// isJSONMediaType checks if the given media type (without parameters) is a JSON media type.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
 }
 
-func NewMediaTypeBuilder() *MediaTypeBuilder {
-	return &MediaTypeBuilder{
-		spec: NewExtendable[MediaType](&MediaType{}),
+// matchMediaType finds the most specific media type in the given content matching the Content-Type value.
+// The exact match is preferred over the `type/*` and `*/*` ranges,
+// and the media type with the same charset parameter is preferred over the one without charset.
+// The media types with another charset do not match.
+func matchMediaType(content map[string]*Extendable[MediaType], contentType string) (string, *Extendable[MediaType]) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", nil
 	}
-}
-
-func (b *MediaTypeBuilder) Build() *Extendable[MediaType] {
-	return b.spec
-}
-
-func (b *MediaTypeBuilder) Extensions(v map[string]any) *MediaTypeBuilder {
-	b.spec.Extensions = v
-	return b
-}
-
-func (b *MediaTypeBuilder) AddExt(name string, value any) *MediaTypeBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *MediaTypeBuilder) Schema(v *RefOrSpec[Schema]) *MediaTypeBuilder {
-	b.spec.Spec.Schema = v
-	return b
-}
-
-func (b *MediaTypeBuilder) Example(v any) *MediaTypeBuilder {
-	b.spec.Spec.Example = v
-	return b
-}
-
-func (b *MediaTypeBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *MediaTypeBuilder {
-	b.spec.Spec.Examples = v
-	return b
-}
-
-func (b *MediaTypeBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *MediaTypeBuilder {
-	if b.spec.Spec.Examples == nil {
-		b.spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+	var found string
+	for _, k := range sortedKeys(content) {
+		mt, kParams, err := mime.ParseMediaType(k)
+		if err != nil || mt != mediaType {
+			continue
+		}
+		charset, ok := kParams["charset"]
+		switch {
+		case ok && strings.EqualFold(charset, params["charset"]):
+			return k, content[k]
+		case ok && params["charset"] != "":
+			// another charset
+		case found == "" || !ok:
+			found = k
+		}
 	}
-	b.spec.Spec.Examples[name] = value
-	return b
-}
-
-func (b *MediaTypeBuilder) Encoding(v map[string]*Extendable[Encoding]) *MediaTypeBuilder {
-	b.spec.Spec.Encoding = v
-	return b
-}
-
-func (b *MediaTypeBuilder) AddEncoding(name string, value *Extendable[Encoding]) *MediaTypeBuilder {
-	if b.spec.Spec.Encoding == nil {
-		b.spec.Spec.Encoding = make(map[string]*Extendable[Encoding], 1)
+	if found != "" {
+		return found, content[found]
+	}
+	if i := strings.IndexByte(mediaType, '/'); i > 0 {
+		if v, ok := content[mediaType[:i]+"/*"]; ok {
+			return mediaType[:i] + "/*", v
+		}
+	}
+	if v, ok := content["*/*"]; ok {
+		return "*/*", v
 	}
-	b.spec.Spec.Encoding[name] = value
-	return b
+	return "", nil
 }
@@ -0,0 +1,473 @@
+package openapi
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type mergeOptions struct {
+	namespaces []string
+	strategy   MergeStrategy
+}
+
+// MergeOption is a type for merge options.
+type MergeOption func(*mergeOptions)
+
+// MergeStrategy defines how MergeWithOptions resolves the objects defined differently in the merged specs.
+type MergeStrategy int
+
+const (
+	// MergeStrategyError returns all the conflicts as *MergeConflictError, it is the default strategy.
+	MergeStrategyError MergeStrategy = iota
+	// MergeStrategyFirstWins keeps the definitions of the earlier specs and drops the conflicting ones,
+	// including the later operations using the operation IDs already taken by other operations.
+	MergeStrategyFirstWins
+	// MergeStrategyRename renames the conflicting components and operation IDs of the later specs
+	// by adding the index of the spec, e.g. `Item` becomes `Item_1`, and rewrites the references to them,
+	// including the `operationId` of links.
+	// The paths and the operations of the same path can not be renamed, so their conflicts are still returned.
+	MergeStrategyRename
+)
+
+// MergeWithStrategy is a merge option of MergeWithOptions to set the strategy of resolving the conflicts, see MergeStrategy.
+func MergeWithStrategy(strategy MergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.strategy = strategy
+	}
+}
+
+// MergeWithNamespaces is a merge option to prefix the components of each merged spec with a namespace
+// (see ApplyNamespace), so the components with the same names do not collide.
+// The namespaces are applied by position: the first namespace to the first spec and so on;
+// an empty namespace or a missing one keeps the components of the corresponding spec as is.
+func MergeWithNamespaces(namespaces ...string) MergeOption {
+	return func(o *mergeOptions) {
+		o.namespaces = namespaces
+	}
+}
+
+// Merge combines several specs into a new one, the given specs are not modified.
+//
+// The `openapi` version, info, external docs and json schema dialect are taken from the first spec.
+// The paths, webhooks, components, tags, servers and extensions of all specs are united.
+// The top-level security requirements are kept if they are the same in all specs, otherwise they are removed and
+// set to the operations without their own security requirements, so each operation keeps its effective security,
+// see EffectiveSecurity.
+// The operations of the same path are merged by method.
+// The same path operation or component defined more than once is a conflict unless the definitions are
+// structurally equal, so is the same operation ID used by different operations;
+// the conflicts are returned as *MergeConflictError, which matches ErrDuplicate.
+// Use MergeWithOptions to resolve the conflicts using another strategy or to apply the namespaces.
+func Merge(specs ...*Extendable[OpenAPI]) (*Extendable[OpenAPI], error) {
+	return MergeWithOptions(specs)
+}
+
+// MergeWithOptions combines several specs into a new one like Merge, but using the given options,
+// e.g. the conflicts are resolved according to the strategy given by MergeWithStrategy option.
+func MergeWithOptions(specs []*Extendable[OpenAPI], opts ...MergeOption) (*Extendable[OpenAPI], error) {
+	if len(specs) == 0 {
+		return nil, errors.New("no specs to merge")
+	}
+	options := &mergeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	copies := make([]*Extendable[OpenAPI], len(specs))
+	for i, spec := range specs {
+		if spec == nil || spec.Spec == nil {
+			return nil, fmt.Errorf("spec %d is empty", i)
+		}
+		copies[i] = copySpec(spec)
+		if i < len(options.namespaces) {
+			ApplyNamespace(copies[i], options.namespaces[i])
+		}
+	}
+	if !sameSecurity(copies) {
+		for _, s := range copies {
+			pushDownSecurity(s)
+		}
+	}
+
+	result := copies[0]
+	m := &merger{origins: make(map[Location]int), strategy: options.strategy}
+	for i, s := range copies[1:] {
+		m.index = i + 1
+		if m.strategy == MergeStrategyRename {
+			renameConflictingComponents(result, s, m.index)
+		}
+		m.mergeOperationIDs(result, s)
+		m.mergeSpec(result, s)
+	}
+	if len(m.conflicts) > 0 && m.strategy != MergeStrategyFirstWins {
+		slices.SortFunc(m.conflicts, func(a, b *MergeConflict) int {
+			return cmp.Compare(a.Location, b.Location)
+		})
+		return nil, &MergeConflictError{Conflicts: m.conflicts}
+	}
+	return result, nil
+}
+
+// merger holds the state of merging the specs.
+type merger struct {
+	// origins holds the indexes of the specs that added the merged objects, the objects of the first spec are omitted
+	origins   map[Location]int
+	conflicts []*MergeConflict
+	// index is the index of the currently merged spec
+	index    int
+	strategy MergeStrategy
+	// skipped are the locations of the operations of the currently merged spec dropped by MergeStrategyFirstWins
+	skipped map[string]bool
+}
+
+func (m *merger) conflict(kind MergeConflictKind, location string, existing, conflicting any) {
+	m.conflicts = append(m.conflicts, &MergeConflict{
+		Kind:        kind,
+		Location:    Location(location),
+		Sources:     [2]int{m.origin(location), m.index},
+		Existing:    existing,
+		Conflicting: conflicting,
+	})
+}
+
+// origin returns the index of the spec that added the object at the given location.
+func (m *merger) origin(location string) int {
+	// the object could be added as a part of its parent
+	for l := Location(location); l != ""; l = l.Parent() {
+		if i, ok := m.origins[l]; ok {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *merger) added(location string) {
+	m.origins[Location(location)] = m.index
+}
+
+func (m *merger) mergeSpec(dst, src *Extendable[OpenAPI]) {
+	mergeExtensions(&dst.Extensions, src.Extensions)
+
+	if src.Spec.Paths != nil && src.Spec.Paths.Spec != nil {
+		if dst.Spec.Paths == nil || dst.Spec.Paths.Spec == nil {
+			dst.Spec.Paths = NewPaths()
+		}
+		mergeExtensions(&dst.Spec.Paths.Extensions, src.Spec.Paths.Extensions)
+		m.mergePathItems("/paths", &dst.Spec.Paths.Spec.Paths, src.Spec.Paths.Spec.Paths)
+	}
+	m.mergePathItems("/webhooks", &dst.Spec.WebHooks, src.Spec.WebHooks)
+
+	if src.Spec.Components != nil && src.Spec.Components.Spec != nil {
+		if dst.Spec.Components == nil || dst.Spec.Components.Spec == nil {
+			dst.Spec.Components = NewComponents()
+		}
+		mergeExtensions(&dst.Spec.Components.Extensions, src.Spec.Components.Extensions)
+		m.mergeComponents("/components", dst.Spec.Components.Spec, src.Spec.Components.Spec)
+	}
+
+	dst.Spec.Tags = mergeSlice(dst.Spec.Tags, src.Spec.Tags, func(v *Extendable[Tag]) any {
+		if v == nil || v.Spec == nil {
+			return nil
+		}
+		return v.Spec.Name
+	})
+	dst.Spec.Servers = mergeSlice(dst.Spec.Servers, src.Spec.Servers, func(v *Extendable[Server]) any {
+		if v == nil || v.Spec == nil {
+			return nil
+		}
+		return v.Spec.URL
+	})
+}
+
+// sameSecurity reports whether all specs have the same top-level security requirements,
+// the nil and the empty lists are equal.
+func sameSecurity(specs []*Extendable[OpenAPI]) bool {
+	for _, spec := range specs[1:] {
+		a, b := specs[0].Spec.Security, spec.Spec.Security
+		if (len(a) > 0 || len(b) > 0) && !equalSpecs(a, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// pushDownSecurity sets the top-level security requirements of the spec to its operations without
+// their own security requirements and removes the top-level ones, so the operations keep their effective security
+// after merging with the specs using other top-level security requirements.
+func pushDownSecurity(spec *Extendable[OpenAPI]) {
+	security := spec.Spec.Security
+	spec.Spec.Security = nil
+	if len(security) == 0 {
+		return
+	}
+	_ = Walk(spec, func(node *WalkNode) error {
+		if op, ok := node.Value.(*Operation); ok && op.Security == nil {
+			op.Security = slices.Clone(security)
+		}
+		return nil
+	})
+}
+
+// mergeExtensions adds the extensions missing in dst from src.
+func mergeExtensions(dst *map[string]any, src map[string]any) {
+	for k, v := range src {
+		if _, ok := (*dst)[k]; ok {
+			continue
+		}
+		if *dst == nil {
+			*dst = make(map[string]any, len(src))
+		}
+		(*dst)[k] = v
+	}
+}
+
+// mergeSlice appends the items of src having the keys not present in dst.
+func mergeSlice[T any](dst, src []T, key func(T) any) []T {
+	seen := make(map[any]bool, len(dst))
+	for _, v := range dst {
+		seen[key(v)] = true
+	}
+	for _, v := range src {
+		k := key(v)
+		if !seen[k] {
+			seen[k] = true
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+func (m *merger) mergePathItems(location string, dst *map[string]*RefOrSpec[Extendable[PathItem]], src map[string]*RefOrSpec[Extendable[PathItem]]) {
+	for path, srcItem := range src {
+		loc := joinLoc(location, path)
+		dstItem, ok := (*dst)[path]
+		if !ok {
+			if *dst == nil {
+				*dst = make(map[string]*RefOrSpec[Extendable[PathItem]], len(src))
+			}
+			if srcItem.Spec != nil && srcItem.Spec.Spec != nil {
+				for _, method := range pathItemMethods {
+					if m.skipped[joinLoc(loc, strings.ToLower(method))] {
+						srcItem.Spec.Spec.setOperation(method, nil)
+					}
+				}
+			}
+			(*dst)[path] = srcItem
+			m.added(loc)
+			continue
+		}
+		if dstItem.Ref != nil || srcItem.Ref != nil || dstItem.Spec == nil || srcItem.Spec == nil ||
+			dstItem.Spec.Spec == nil || srcItem.Spec.Spec == nil {
+			if !equalSpecs(dstItem, srcItem) {
+				m.conflict(MergeConflictPathItem, loc, dstItem, srcItem)
+			}
+			continue
+		}
+		mergeExtensions(&dstItem.Spec.Extensions, srcItem.Spec.Extensions)
+		d, s := dstItem.Spec.Spec, srcItem.Spec.Spec
+		mergeField(m, joinLoc(loc, "summary"), &d.Summary, s.Summary)
+		mergeField(m, joinLoc(loc, "description"), &d.Description, s.Description)
+		mergeField(m, joinLoc(loc, "servers"), &d.Servers, s.Servers)
+		mergeField(m, joinLoc(loc, "parameters"), &d.Parameters, s.Parameters)
+		for _, method := range pathItemMethods {
+			srcOp := s.operation(method)
+			if srcOp == nil || m.skipped[joinLoc(loc, strings.ToLower(method))] {
+				continue
+			}
+			dstOp := d.operation(method)
+			if dstOp == nil {
+				d.setOperation(method, srcOp)
+				m.added(joinLoc(loc, strings.ToLower(method)))
+				continue
+			}
+			if !equalSpecs(dstOp, srcOp) {
+				m.conflict(MergeConflictOperation, joinLoc(loc, strings.ToLower(method)), dstOp, srcOp)
+			}
+		}
+	}
+}
+
+// mergeField sets dst to src if dst is empty, otherwise reports a conflict if the values differ.
+func mergeField[T any](m *merger, location string, dst *T, src T) {
+	if reflect.ValueOf(src).IsZero() {
+		return
+	}
+	if reflect.ValueOf(*dst).IsZero() {
+		*dst = src
+		m.added(location)
+		return
+	}
+	if !equalSpecs(*dst, src) {
+		m.conflict(MergeConflictPathItem, location, *dst, src)
+	}
+}
+
+// mergeComponents adds the components of src to dst, the component maps are found by reflection.
+func (m *merger) mergeComponents(location string, dst, src *Components) {
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < sv.NumField(); i++ {
+		sf, df := sv.Field(i), dv.Field(i)
+		if sf.Kind() != reflect.Map || sf.Len() == 0 {
+			continue
+		}
+		typ, _, _ := strings.Cut(sv.Type().Field(i).Tag.Get("json"), ",")
+		if df.IsNil() {
+			df.Set(reflect.MakeMapWithSize(df.Type(), sf.Len()))
+		}
+		iter := sf.MapRange()
+		for iter.Next() {
+			loc := joinLoc(location, typ, iter.Key().String())
+			existing := df.MapIndex(iter.Key())
+			if !existing.IsValid() {
+				df.SetMapIndex(iter.Key(), iter.Value())
+				m.added(loc)
+				continue
+			}
+			if !equalSpecs(existing.Interface(), iter.Value().Interface()) {
+				m.conflict(MergeConflictComponent, loc, existing.Interface(), iter.Value().Interface())
+			}
+		}
+	}
+}
+
+// mergeOperationIDs finds the operations of src using the operation IDs of other operations of dst
+// and resolves the conflicts according to the strategy.
+func (m *merger) mergeOperationIDs(dst, src *Extendable[OpenAPI]) {
+	type operation struct {
+		location string
+		op       *Extendable[Operation]
+	}
+	ids := make(map[string]operation)
+	forEachOperation(dst, func(location, _ string, op *Extendable[Operation]) {
+		if op.Spec.OperationID != "" {
+			ids[op.Spec.OperationID] = operation{location: location, op: op}
+		}
+	})
+	srcIDs := make(map[string]bool)
+	forEachOperation(src, func(_, _ string, op *Extendable[Operation]) {
+		srcIDs[op.Spec.OperationID] = true
+	})
+
+	m.skipped = make(map[string]bool)
+	renames := make(map[string]string)
+	forEachOperation(src, func(location, _ string, op *Extendable[Operation]) {
+		id := op.Spec.OperationID
+		existing, ok := ids[id]
+		// the operations of the same path are merged by mergePathItems
+		if id == "" || !ok || existing.location == location {
+			return
+		}
+		switch m.strategy {
+		case MergeStrategyFirstWins:
+			m.skipped[location] = true
+		case MergeStrategyRename:
+			renamed := mergeRename(id, m.index, func(name string) bool {
+				_, ok := ids[name]
+				return ok || srcIDs[name]
+			})
+			op.Spec.OperationID = renamed
+			renames[id] = renamed
+			ids[renamed] = operation{location: location, op: op}
+		default:
+			m.conflicts = append(m.conflicts, &MergeConflict{
+				Kind:        MergeConflictOperationID,
+				Location:    Location(joinLoc(location, "operationId")),
+				Sources:     [2]int{m.origin(existing.location), m.index},
+				Existing:    existing.op,
+				Conflicting: op,
+			})
+		}
+	})
+	if len(renames) == 0 {
+		return
+	}
+	walkSpec(reflect.ValueOf(src), func(v reflect.Value) {
+		if link, ok := v.Interface().(*Link); ok {
+			if renamed, ok := renames[link.OperationID]; ok {
+				link.OperationID = renamed
+			}
+		}
+	})
+}
+
+// renameConflictingComponents renames the components of src defined differently in dst
+// and rewrites the references to them, see MergeStrategyRename.
+func renameConflictingComponents(dst, src *Extendable[OpenAPI], index int) {
+	if dst.Spec.Components == nil || dst.Spec.Components.Spec == nil ||
+		src.Spec.Components == nil || src.Spec.Components.Spec == nil {
+		return
+	}
+	dv, sv := reflect.ValueOf(dst.Spec.Components.Spec).Elem(), reflect.ValueOf(src.Spec.Components.Spec).Elem()
+	renames := make(map[string]map[string]string)
+	for i := 0; i < sv.NumField(); i++ {
+		sf, df := sv.Field(i), dv.Field(i)
+		if sf.Kind() != reflect.Map || sf.Len() == 0 || df.Len() == 0 {
+			continue
+		}
+		typ, _, _ := strings.Cut(sv.Type().Field(i).Tag.Get("json"), ",")
+		used := func(name string) bool {
+			key := reflect.ValueOf(name).Convert(sf.Type().Key())
+			if df.MapIndex(key).IsValid() || sf.MapIndex(key).IsValid() {
+				return true
+			}
+			for _, renamed := range renames[typ] {
+				if renamed == name {
+					return true
+				}
+			}
+			return false
+		}
+		iter := sf.MapRange()
+		for iter.Next() {
+			existing := df.MapIndex(iter.Key())
+			if !existing.IsValid() || equalSpecs(existing.Interface(), iter.Value().Interface()) {
+				continue
+			}
+			if renames[typ] == nil {
+				renames[typ] = make(map[string]string)
+			}
+			name := iter.Key().String()
+			renames[typ][name] = mergeRename(name, index, used)
+		}
+	}
+	if len(renames) == 0 {
+		return
+	}
+	RenameComponents(src, func(typ, name string) string {
+		if renamed, ok := renames[typ][name]; ok {
+			return renamed
+		}
+		return name
+	})
+}
+
+// mergeRename returns the name with the index of the spec added, e.g. `Item_1`,
+// or with a greater number if the name is already used.
+func mergeRename(name string, index int, used func(string) bool) string {
+	for n := index; ; n++ {
+		renamed := name + "_" + strconv.Itoa(n)
+		if !used(renamed) {
+			return renamed
+		}
+	}
+}
+
+// equalSpecs reports whether the given objects are structurally equal, i.e. have the same JSON representation.
+func equalSpecs(a, b any) bool {
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aData, bData)
+}
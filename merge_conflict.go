@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeConflictKind is the kind of object defined differently in the merged specs.
+type MergeConflictKind string
+
+const (
+	// MergeConflictPathItem means that the common fields of a path item (summary, description, servers,
+	// parameters) or a referenced path item differ.
+	MergeConflictPathItem MergeConflictKind = "path item"
+	// MergeConflictOperation means that the same operation of the same path is defined differently.
+	MergeConflictOperation MergeConflictKind = "operation"
+	// MergeConflictComponent means that the same component name is used for structurally different definitions.
+	MergeConflictComponent MergeConflictKind = "component"
+	// MergeConflictOperationID means that the same operation ID is used by different operations.
+	MergeConflictOperationID MergeConflictKind = "operation id"
+)
+
+// MergeConflict describes an object defined differently in two merged specs.
+type MergeConflict struct {
+	// Existing is the definition already present in the merged spec.
+	Existing any
+	// Conflicting is the definition that could not be merged.
+	Conflicting any
+	// Kind is the kind of the conflicting object.
+	Kind MergeConflictKind
+	// Location is the location of the conflicting object in the merged spec, e.g. `/components/schemas/Pet`.
+	Location Location
+	// Sources are the indexes of the merged specs defining the existing and the conflicting objects.
+	Sources [2]int
+}
+
+// Error implements error interface.
+func (c *MergeConflict) Error() string {
+	return fmt.Sprintf("%s: %s: %s is defined differently in specs %d and %d", c.Location, ErrDuplicate, c.Kind, c.Sources[0], c.Sources[1])
+}
+
+// Unwrap returns ErrDuplicate, so the conflict can be checked using errors.Is.
+func (c *MergeConflict) Unwrap() error {
+	return ErrDuplicate
+}
+
+// MergeConflictError is the error returned by Merge function, it holds all the conflicts sorted by location.
+//
+// Example:
+//
+//	var conflicts *openapi.MergeConflictError
+//	if errors.As(err, &conflicts) {
+//		for _, c := range conflicts.Conflicts {
+//			log.Printf("%s %s: spec %d vs spec %d", c.Kind, c.Location, c.Sources[0], c.Sources[1])
+//		}
+//	}
+type MergeConflictError struct {
+	Conflicts []*MergeConflict
+}
+
+// Error implements error interface.
+func (e *MergeConflictError) Error() string {
+	lines := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		lines[i] = c.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the conflicts, so each of them can be checked using errors.Is and errors.As.
+func (e *MergeConflictError) Unwrap() []error {
+	errs := make([]error, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		errs[i] = c
+	}
+	return errs
+}
@@ -0,0 +1,397 @@
+package openapi_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testMergeBillingSpec = `
+openapi: 3.1.1
+info:
+  title: Billing
+  version: 1.0.0
+tags:
+  - name: billing
+servers:
+  - url: https://api.example.com
+paths:
+  /invoices:
+    get:
+      tags: [billing]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        amount:
+          type: number
+`
+
+const testMergeStoreSpec = `
+openapi: 3.1.1
+info:
+  title: Store
+  version: 2.0.0
+tags:
+  - name: store
+  - name: billing
+servers:
+  - url: https://api.example.com
+paths:
+  /invoices:
+    post:
+      responses:
+        '201':
+          description: Created
+  /items:
+    get:
+      tags: [store]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func TestMerge(t *testing.T) {
+	var billing, store *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &billing))
+	require.NoError(t, yaml.Unmarshal([]byte(testMergeStoreSpec), &store))
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := openapi.Merge(billing, store)
+		require.ErrorIs(t, err, openapi.ErrDuplicate)
+		require.ErrorContains(t, err, "/components/schemas/Item: duplicate")
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		merged, err := openapi.MergeWithOptions(
+			[]*openapi.Extendable[openapi.OpenAPI]{billing, store},
+			openapi.MergeWithNamespaces("billing", "store"),
+		)
+		require.NoError(t, err)
+
+		require.Equal(t, "Billing", merged.Spec.Info.Spec.Title)
+		require.Len(t, merged.Spec.Tags, 2)
+		require.Len(t, merged.Spec.Servers, 1)
+		require.Contains(t, merged.Spec.Components.Spec.Schemas, "billing.Item")
+		require.Contains(t, merged.Spec.Components.Spec.Schemas, "store.Item")
+
+		invoices := merged.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec
+		require.NotNil(t, invoices.Get)
+		require.NotNil(t, invoices.Post)
+		items := merged.Spec.Paths.Spec.Paths["/items"].Spec.Spec
+		schema := items.Get.Spec.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema
+		require.Equal(t, "#/components/schemas/store.Item", schema.Ref.Ref)
+
+		validator, err := openapi.NewValidator(merged)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+
+		// the source specs are not modified
+		require.Contains(t, billing.Spec.Components.Spec.Schemas, "Item")
+		require.Contains(t, store.Spec.Components.Spec.Schemas, "Item")
+	})
+
+	t.Run("same definitions", func(t *testing.T) {
+		merged, err := openapi.Merge(billing, billing)
+		require.NoError(t, err)
+		require.Len(t, merged.Spec.Components.Spec.Schemas, 1)
+	})
+
+	t.Run("operation conflict", func(t *testing.T) {
+		var other *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &other))
+		other.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec.Summary = "List invoices"
+		_, err := openapi.MergeWithOptions(
+			[]*openapi.Extendable[openapi.OpenAPI]{billing, other},
+			openapi.MergeWithNamespaces("", ""),
+		)
+		require.ErrorIs(t, err, openapi.ErrDuplicate)
+		require.ErrorContains(t, err, "/paths/~1invoices/get: duplicate")
+	})
+
+	t.Run("conflict report", func(t *testing.T) {
+		var other *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &other))
+		other.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec.Summary = "List invoices"
+		other.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Summary = "Invoices"
+		var base *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &base))
+		base.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Summary = "Billing"
+		_, err := openapi.Merge(base, store, other)
+
+		var conflicts *openapi.MergeConflictError
+		require.ErrorAs(t, err, &conflicts)
+		require.Len(t, conflicts.Conflicts, 3)
+
+		c := conflicts.Conflicts[0]
+		require.Equal(t, openapi.MergeConflictComponent, c.Kind)
+		require.Equal(t, openapi.Location("/components/schemas/Item"), c.Location)
+		require.Equal(t, [2]int{0, 1}, c.Sources)
+		require.Equal(t, billing.Spec.Components.Spec.Schemas["Item"], c.Existing)
+		require.Equal(t, store.Spec.Components.Spec.Schemas["Item"], c.Conflicting)
+
+		c = conflicts.Conflicts[1]
+		require.Equal(t, openapi.MergeConflictOperation, c.Kind)
+		require.Equal(t, openapi.Location("/paths/~1invoices/get"), c.Location)
+		require.Equal(t, [2]int{0, 2}, c.Sources)
+
+		c = conflicts.Conflicts[2]
+		require.Equal(t, openapi.MergeConflictPathItem, c.Kind)
+		require.Equal(t, openapi.Location("/paths/~1invoices/summary"), c.Location)
+		require.Equal(t, [2]int{0, 2}, c.Sources)
+		require.EqualError(t, c, "/paths/~1invoices/summary: duplicate: path item is defined differently in specs 0 and 2")
+
+		loc, ok := openapi.LocationOf(err)
+		require.True(t, ok)
+		require.Equal(t, openapi.Location("/components/schemas/Item"), loc)
+	})
+
+	t.Run("conflict with later spec", func(t *testing.T) {
+		var other *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testMergeStoreSpec), &other))
+		other.Spec.Paths.Spec.Paths["/items"].Spec.Spec.Get.Spec.Summary = "List items"
+		_, err := openapi.MergeWithOptions(
+			[]*openapi.Extendable[openapi.OpenAPI]{billing, store, other},
+			openapi.MergeWithNamespaces("billing", "store", "store"),
+		)
+		var conflicts *openapi.MergeConflictError
+		require.ErrorAs(t, err, &conflicts)
+		require.Len(t, conflicts.Conflicts, 1)
+		require.Equal(t, openapi.Location("/paths/~1items/get"), conflicts.Conflicts[0].Location)
+		require.Equal(t, [2]int{1, 2}, conflicts.Conflicts[0].Sources)
+	})
+
+	t.Run("no specs", func(t *testing.T) {
+		_, err := openapi.Merge()
+		require.Error(t, err)
+	})
+}
+
+const testMergeOrdersSpec = `
+openapi: 3.1.1
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: list
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Item'
+          links:
+            next:
+              operationId: list
+components:
+  schemas:
+    Item:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+func TestMerge_Strategies(t *testing.T) {
+	var billing *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &billing))
+	billing.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec.OperationID = "list"
+	var orders *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testMergeOrdersSpec), &orders))
+	specs := []*openapi.Extendable[openapi.OpenAPI]{billing, orders}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := openapi.MergeWithOptions(specs, openapi.MergeWithStrategy(openapi.MergeStrategyError))
+		var conflicts *openapi.MergeConflictError
+		require.ErrorAs(t, err, &conflicts)
+		require.Len(t, conflicts.Conflicts, 2)
+		require.Equal(t, openapi.MergeConflictComponent, conflicts.Conflicts[0].Kind)
+		c := conflicts.Conflicts[1]
+		require.Equal(t, openapi.MergeConflictOperationID, c.Kind)
+		require.Equal(t, openapi.Location("/paths/~1orders/get/operationId"), c.Location)
+		require.Equal(t, [2]int{0, 1}, c.Sources)
+		require.Equal(t, billing.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get, c.Existing)
+	})
+
+	t.Run("first wins", func(t *testing.T) {
+		merged, err := openapi.MergeWithOptions(specs, openapi.MergeWithStrategy(openapi.MergeStrategyFirstWins))
+		require.NoError(t, err)
+		require.Equal(t, billing.Spec.Components.Spec.Schemas["Item"], merged.Spec.Components.Spec.Schemas["Item"])
+		require.Contains(t, merged.Spec.Paths.Spec.Paths, "/orders")
+		require.Nil(t, merged.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Get)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		merged, err := openapi.MergeWithOptions(specs, openapi.MergeWithStrategy(openapi.MergeStrategyRename))
+		require.NoError(t, err)
+		require.Contains(t, merged.Spec.Components.Spec.Schemas, "Item")
+		require.Contains(t, merged.Spec.Components.Spec.Schemas, "Item_1")
+
+		get := merged.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Get.Spec
+		require.Equal(t, "list_1", get.OperationID)
+		response := get.Responses.Spec.Response["200"].Spec.Spec
+		require.Equal(t, "#/components/schemas/Item_1", response.Content["application/json"].Spec.Schema.Ref.Ref)
+		require.Equal(t, "list_1", response.Links["next"].Spec.Spec.OperationID)
+		require.Equal(t, "list", merged.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec.OperationID)
+
+		validator, err := openapi.NewValidator(merged)
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+
+		// the source specs are not modified
+		require.Equal(t, "list", orders.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Get.Spec.OperationID)
+		require.Contains(t, orders.Spec.Components.Spec.Schemas, "Item")
+	})
+
+	t.Run("rename keeps path conflicts", func(t *testing.T) {
+		var other *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testMergeBillingSpec), &other))
+		other.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec.Summary = "List invoices"
+		_, err := openapi.MergeWithOptions(
+			[]*openapi.Extendable[openapi.OpenAPI]{billing, other},
+			openapi.MergeWithStrategy(openapi.MergeStrategyRename),
+		)
+		require.ErrorIs(t, err, openapi.ErrDuplicate)
+		require.ErrorContains(t, err, "/paths/~1invoices/get: duplicate")
+	})
+}
+
+const testMergeSecuritySpec = `
+openapi: 3.1.1
+info:
+  title: Security
+  version: 1.0.0
+paths:
+  %[1]s:
+    get:
+      responses:
+        '200':
+          description: OK
+    delete:
+      security: []
+      responses:
+        '204':
+          description: Deleted
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+    bearer:
+      type: http
+      scheme: bearer
+`
+
+func TestMerge_Security(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		first, second string
+		topLevel      []string
+		getSecurity   [2][]string
+	}{
+		{
+			name:        "same",
+			first:       "security:\n  - apiKey: []\n",
+			second:      "security:\n  - apiKey: []\n",
+			topLevel:    []string{"apiKey"},
+			getSecurity: [2][]string{nil, nil},
+		},
+		{
+			name:        "different",
+			first:       "security:\n  - apiKey: []\n",
+			second:      "security:\n  - bearer: []\n",
+			getSecurity: [2][]string{{"apiKey"}, {"bearer"}},
+		},
+		{
+			name:        "missing",
+			first:       "security:\n  - apiKey: []\n",
+			getSecurity: [2][]string{{"apiKey"}, nil},
+		},
+		{
+			name:        "empty",
+			first:       "security: []\n",
+			getSecurity: [2][]string{nil, nil},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(testMergeSecuritySpec, "/first")+tt.first), &first))
+			require.NoError(t, yaml.Unmarshal([]byte(fmt.Sprintf(testMergeSecuritySpec, "/second")+tt.second), &second))
+
+			merged, err := openapi.Merge(first, second)
+			require.NoError(t, err)
+
+			var topLevel []string
+			for _, s := range merged.Spec.Security {
+				for name := range s {
+					topLevel = append(topLevel, name)
+				}
+			}
+			require.Equal(t, tt.topLevel, topLevel)
+
+			for i, path := range []string{"/first", "/second"} {
+				item := merged.Spec.Paths.Spec.Paths[path].Spec.Spec
+
+				var names []string
+				for _, s := range item.Get.Spec.Security {
+					for name := range s {
+						names = append(names, name)
+					}
+				}
+				require.Equal(t, tt.getSecurity[i], names, path)
+				require.NotNil(t, item.Delete.Spec.Security, path)
+				require.Empty(t, item.Delete.Spec.Security, path)
+
+				// the effective security of the operations is kept
+				var src *openapi.Extendable[openapi.OpenAPI]
+				if i == 0 {
+					src = first
+				} else {
+					src = second
+				}
+				srcItem := src.Spec.Paths.Spec.Paths[path].Spec.Spec
+				for _, op := range [][2]*openapi.Extendable[openapi.Operation]{
+					{srcItem.Get, item.Get},
+					{srcItem.Delete, item.Delete},
+				} {
+					want, err := openapi.EffectiveSecurity(op[0], src)
+					require.NoError(t, err)
+					got, err := openapi.EffectiveSecurity(op[1], merged)
+					require.NoError(t, err)
+					require.Equal(t, want, got, path)
+				}
+			}
+
+			validator, err := openapi.NewValidator(merged, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateSpec())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package openapi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// MessageID is a stable identifier of a validation message, it is not changed between the releases,
+// so it can be used to handle the errors programmatically or to translate the messages.
+type MessageID string
+
+const (
+	MsgRequired                         MessageID = "required"
+	MsgMutuallyExclusive                MessageID = "mutually_exclusive"
+	MsgUnused                           MessageID = "unused"
+	MsgExtensionNameMustStartWithPrefix MessageID = "extension_name_prefix"
+	MsgOperationNotFound                MessageID = "operation_not_found"
+	MsgResponseNotFound                 MessageID = "response_not_found"
+	MsgUnsupportedMediaType             MessageID = "unsupported_media_type"
+	MsgBodyTooLarge                     MessageID = "body_too_large"
+	MsgJSONTooDeep                      MessageID = "json_too_deep"
+	MsgJSONTooManyElements              MessageID = "json_too_many_elements"
+	MsgRoundTrip                        MessageID = "round_trip"
+	MsgSchemaViolation                  MessageID = "schema_violation"
+	MsgInvalidFormat                    MessageID = "invalid_format"
+	MsgInvalidValue                     MessageID = "invalid_value"
+	MsgOutOfRange                       MessageID = "out_of_range"
+	MsgDuplicate                        MessageID = "duplicate"
+	MsgUnresolvedRef                    MessageID = "unresolved_ref"
+	MsgUnsupportedVersion               MessageID = "unsupported_version"
+	MsgNotAllowed                       MessageID = "not_allowed"
+	MsgInvalidData                      MessageID = "invalid_data"
+	// MsgInvalid is used for the errors without a specific message ID.
+	MsgInvalid MessageID = "invalid"
+)
+
+var messageIDs = []struct {
+	err error
+	id  MessageID
+}{
+	{err: ErrRequired, id: MsgRequired},
+	{err: ErrMutuallyExclusive, id: MsgMutuallyExclusive},
+	{err: ErrUnused, id: MsgUnused},
+	{err: ErrExtensionNameMustStartWithPrefix, id: MsgExtensionNameMustStartWithPrefix},
+	{err: ErrOperationNotFound, id: MsgOperationNotFound},
+	{err: ErrResponseNotFound, id: MsgResponseNotFound},
+	{err: ErrUnsupportedMediaType, id: MsgUnsupportedMediaType},
+	{err: ErrBodyTooLarge, id: MsgBodyTooLarge},
+	{err: ErrJSONTooDeep, id: MsgJSONTooDeep},
+	{err: ErrJSONTooManyElements, id: MsgJSONTooManyElements},
+	{err: ErrRoundTrip, id: MsgRoundTrip},
+}
+
+// categoryMessageIDs are checked after the specific errors and the schema violations.
+var categoryMessageIDs = []struct {
+	err error
+	id  MessageID
+}{
+	{err: ErrInvalidFormat, id: MsgInvalidFormat},
+	{err: ErrInvalidValue, id: MsgInvalidValue},
+	{err: ErrOutOfRange, id: MsgOutOfRange},
+	{err: ErrDuplicate, id: MsgDuplicate},
+	{err: ErrUnresolvedRef, id: MsgUnresolvedRef},
+	{err: ErrUnsupportedVersion, id: MsgUnsupportedVersion},
+	{err: ErrNotAllowed, id: MsgNotAllowed},
+	{err: ErrInvalidData, id: MsgInvalidData},
+}
+
+// MessageIDOf returns the message ID of the given error.
+// The ID of a lint issue is the ID of the rule.
+func MessageIDOf(err error) MessageID {
+	var issue *LintIssue
+	if errors.As(err, &issue) {
+		return MessageID(issue.Rule)
+	}
+	for _, m := range messageIDs {
+		if errors.Is(err, m.err) {
+			return m.id
+		}
+	}
+	var schemaErr *jsonschema.ValidationError
+	if errors.As(err, &schemaErr) {
+		return MsgSchemaViolation
+	}
+	for _, m := range categoryMessageIDs {
+		if errors.Is(err, m.err) {
+			return m.id
+		}
+	}
+	return MsgInvalid
+}
+
+// MessageCatalog is an interface to translate or customize the validation messages.
+type MessageCatalog interface {
+	// Message returns the text of the message with the given ID for the given location and the original error,
+	// ok is false if the catalog does not have the message, then the original error text is used.
+	Message(id MessageID, location Location, err error) (msg string, ok bool)
+}
+
+// MapCatalog is a MessageCatalog with the message templates keyed by the message ID.
+// The templates can contain the `{location}` and `{error}` placeholders,
+// replaced by the location and the text of the original error.
+type MapCatalog map[MessageID]string
+
+// Message implements MessageCatalog interface.
+func (c MapCatalog) Message(id MessageID, location Location, err error) (string, bool) {
+	tmpl, ok := c[id]
+	if !ok {
+		return "", false
+	}
+	return strings.NewReplacer("{location}", string(location), "{error}", err.Error()).Replace(tmpl), true
+}
+
+// Message is a validation message with the stable ID and the location of the problem.
+type Message struct {
+	ID       MessageID
+	Location Location
+	Text     string
+	Err      error
+}
+
+// LocalizeErrors converts the error returned by the validation functions into the list of the messages,
+// translated using the given catalog; nil catalog keeps the original texts.
+func LocalizeErrors(err error, catalog MessageCatalog) []*Message {
+	var messages []*Message
+	var walk func(err error)
+	walk = func(err error) {
+		var ve *validationError
+		switch e := err.(type) {
+		case nil:
+			return
+		case interface{ Unwrap() []error }:
+			for _, sub := range e.Unwrap() {
+				walk(sub)
+			}
+			return
+		case *validationError:
+			ve = e
+		}
+		m := &Message{ID: MessageIDOf(err), Err: err, Text: err.Error()}
+		if ve != nil {
+			m.Location = Location(ve.location)
+			m.Text = ve.err.Error()
+		}
+		if catalog != nil {
+			if text, ok := catalog.Message(m.ID, m.Location, unwrapValidationError(err)); ok {
+				m.Text = text
+			}
+		}
+		messages = append(messages, m)
+	}
+	walk(err)
+	return messages
+}
+
+func unwrapValidationError(err error) error {
+	if ve, ok := err.(*validationError); ok {
+		return ve.err
+	}
+	return err
+}
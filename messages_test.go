@@ -0,0 +1,41 @@
+package openapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestLocalizeErrors(t *testing.T) {
+	validator := newTestRequestValidator(t, testRequestSpec)
+	err := validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/pets?limit=1000", nil))
+	require.Error(t, err)
+
+	catalog := openapi.MapCatalog{
+		openapi.MsgRequired: "{location}: обязательный параметр",
+	}
+	messages := openapi.LocalizeErrors(err, catalog)
+	require.Len(t, messages, 2)
+
+	require.Equal(t, openapi.MsgSchemaViolation, messages[0].ID)
+	require.Equal(t, openapi.Location("query/limit"), messages[0].Location)
+	require.Contains(t, messages[0].Text, "maximum")
+
+	require.Equal(t, openapi.MsgRequired, messages[1].ID)
+	require.Equal(t, openapi.Location("header/X-Request-ID"), messages[1].Location)
+	require.Equal(t, "header/X-Request-ID: обязательный параметр", messages[1].Text)
+	require.ErrorIs(t, messages[1].Err, openapi.ErrRequired)
+
+	messages = openapi.LocalizeErrors(errors.New("foo"), nil)
+	require.Len(t, messages, 1)
+	require.Equal(t, openapi.MsgInvalid, messages[0].ID)
+	require.Equal(t, "foo", messages[0].Text)
+
+	require.Empty(t, openapi.LocalizeErrors(nil, catalog))
+	require.Equal(t, openapi.MsgOperationNotFound, openapi.MessageIDOf(validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodPut, "/", nil))))
+}
@@ -0,0 +1,185 @@
+package openapi
+
+// OperationMetrics are the complexity metrics of an operation.
+type OperationMetrics struct {
+	// Location is the location of the operation, e.g. `/paths/~1pets/get`.
+	Location string `json:"location" yaml:"location"`
+	Method   string `json:"method" yaml:"method"`
+	// Path is the path of the operation or the name of the webhook.
+	Path        string `json:"path" yaml:"path"`
+	OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	// Parameters is the number of the parameters of the operation including the ones of its path item.
+	Parameters int `json:"parameters" yaml:"parameters"`
+	// SchemaDepth is the maximum nesting depth of the schemas of the parameters, request body and responses,
+	// the referenced schemas are resolved and the recursive ones are counted once.
+	// The properties and the items add a level, while the compositions like `allOf` do not.
+	SchemaDepth int `json:"schemaDepth" yaml:"schemaDepth"`
+	// ResponseVariants is the number of the combinations of the status code and the media type of the responses,
+	// a response without content is counted as one variant.
+	ResponseVariants int `json:"responseVariants" yaml:"responseVariants"`
+	// Components is the number of the components used by the operation, directly or transitively.
+	Components int `json:"components" yaml:"components"`
+	// RefComplexity is the cyclomatic complexity of the graph of the references of the operation, `E - N + 2`,
+	// where E and N are the numbers of the references and of the objects reachable from the operation.
+	// It is 1 for a tree of references, each shared or recursive reference adds one.
+	RefComplexity int `json:"refComplexity" yaml:"refComplexity"`
+}
+
+// APIMetrics are the complexity metrics of a spec, e.g. to track the complexity of an API over time.
+type APIMetrics struct {
+	// Operations are the metrics of the operations of the paths and the webhooks sorted by path and method.
+	Operations []*OperationMetrics `json:"operations" yaml:"operations"`
+	// Components is the number of all components.
+	Components int `json:"components" yaml:"components"`
+}
+
+// CollectMetrics returns the complexity metrics of the given spec,
+// the unresolved references are ignored.
+func CollectMetrics(spec *Extendable[OpenAPI]) *APIMetrics {
+	m := &APIMetrics{Operations: []*OperationMetrics{}}
+	if spec == nil || spec.Spec == nil {
+		return m
+	}
+	components := spec.Spec.Components
+	graph := NewDependencyGraph(spec)
+	deps := make(map[string][]string, len(graph.Nodes))
+	for _, e := range graph.Edges {
+		deps[e.From] = append(deps[e.From], e.To)
+	}
+	for _, n := range graph.Nodes {
+		if !n.isOperation() {
+			m.Components++
+		}
+	}
+
+	forEachOperation(spec, func(location, method string, op *Extendable[Operation]) {
+		segments := Location(location).Segments()
+		om := &OperationMetrics{
+			Location:    location,
+			Method:      method,
+			Path:        segments[1],
+			OperationID: op.Spec.OperationID,
+		}
+		var item *RefOrSpec[Extendable[PathItem]]
+		if segments[0] == "webhooks" {
+			item = spec.Spec.WebHooks[segments[1]]
+		} else {
+			item = spec.Spec.Paths.Spec.Paths[segments[1]]
+		}
+		params := make(map[string]*Parameter)
+		for _, list := range [][]*RefOrSpec[Extendable[Parameter]]{item.Spec.Spec.Parameters, op.Spec.Parameters} {
+			for _, ref := range list {
+				if p, err := ref.GetSpec(components); err == nil && p.Spec != nil {
+					params[parameterKey(p.Spec.In, p.Spec.Name)] = p.Spec
+				}
+			}
+		}
+		om.Parameters = len(params)
+
+		depth := func(schemas ...*RefOrSpec[Schema]) {
+			for _, s := range schemas {
+				om.SchemaDepth = max(om.SchemaDepth, schemaDepth(s, components, make(map[*Schema]bool)))
+			}
+		}
+		contentDepth := func(content map[string]*Extendable[MediaType]) {
+			for _, mt := range content {
+				if mt != nil && mt.Spec != nil {
+					depth(mt.Spec.Schema)
+				}
+			}
+		}
+		for _, p := range params {
+			depth(p.Schema)
+			contentDepth(p.Content)
+		}
+		if op.Spec.RequestBody != nil {
+			if body, err := op.Spec.RequestBody.GetSpec(components); err == nil && body.Spec != nil {
+				contentDepth(body.Spec.Content)
+			}
+		}
+		if op.Spec.Responses != nil && op.Spec.Responses.Spec != nil {
+			responses := append(make([]*RefOrSpec[Extendable[Response]], 0, len(op.Spec.Responses.Spec.Response)+1), op.Spec.Responses.Spec.Default)
+			for _, code := range sortedKeys(op.Spec.Responses.Spec.Response) {
+				responses = append(responses, op.Spec.Responses.Spec.Response[code])
+			}
+			for _, ref := range responses {
+				if ref == nil {
+					continue
+				}
+				resp, err := ref.GetSpec(components)
+				if err != nil || resp.Spec == nil {
+					continue
+				}
+				om.ResponseVariants += max(1, len(resp.Spec.Content))
+				contentDepth(resp.Spec.Content)
+				for _, h := range resp.Spec.Headers {
+					if header, err := h.GetSpec(components); err == nil && header.Spec != nil {
+						depth(header.Spec.Schema)
+						contentDepth(header.Spec.Content)
+					}
+				}
+			}
+		}
+
+		// the reference graph reachable from the operation
+		reachable := map[string]bool{location: true}
+		queue := []string{location}
+		var edges int
+		for len(queue) > 0 {
+			from := queue[0]
+			queue = queue[1:]
+			for _, to := range deps[from] {
+				edges++
+				if !reachable[to] {
+					reachable[to] = true
+					queue = append(queue, to)
+				}
+			}
+		}
+		om.Components = len(reachable) - 1
+		om.RefComplexity = edges - len(reachable) + 2
+
+		m.Operations = append(m.Operations, om)
+	})
+	return m
+}
+
+// schemaDepth returns the nesting depth of the given schema, see OperationMetrics.SchemaDepth.
+// The path contains the schemas being measured to stop at the recursive references.
+func schemaDepth(ref *RefOrSpec[Schema], components *Extendable[Components], path map[*Schema]bool) int {
+	if ref == nil {
+		return 0
+	}
+	s, err := ref.GetSpec(components)
+	if err != nil || s == nil || s.Bool != nil || path[s] {
+		return 0
+	}
+	path[s] = true
+	defer delete(path, s)
+
+	// the compositions are applied to the same instance, so they do not add a level
+	applied := append([]*RefOrSpec[Schema]{s.Not, s.If, s.Then, s.Else}, schemaAllOf(s)...)
+	applied = append(append(applied, s.AnyOf...), s.OneOf...)
+	for _, sub := range s.DependentSchemas {
+		applied = append(applied, sub)
+	}
+	var inPlace, nested int
+	for _, sub := range applied {
+		inPlace = max(inPlace, schemaDepth(sub, components, path)-1)
+	}
+	subs := append([]*RefOrSpec[Schema]{s.Contains}, s.PrefixItems...)
+	for _, b := range []*BoolOrSchema{s.Items, s.UnevaluatedItems, s.AdditionalProperties, s.UnevaluatedProperties} {
+		if b != nil {
+			subs = append(subs, b.Schema)
+		}
+	}
+	for _, props := range []map[string]*RefOrSpec[Schema]{s.Properties, s.PatternProperties} {
+		for _, sub := range props {
+			subs = append(subs, sub)
+		}
+	}
+	for _, sub := range subs {
+		nested = max(nested, schemaDepth(sub, components, path))
+	}
+	return 1 + max(inPlace, nested)
+}
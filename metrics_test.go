@@ -0,0 +1,115 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testMetricsSpec = `
+openapi: 3.1.1
+info:
+  title: Metrics
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    put:
+      operationId: updatePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - $ref: '#/components/parameters/Limit'
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '200':
+          description: updated
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            application/xml:
+              schema:
+                allOf:
+                  - $ref: '#/components/schemas/Pet'
+        '404':
+          description: not found
+webhooks:
+  ping:
+    post:
+      responses:
+        default:
+          description: ok
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        tags:
+          type: array
+          items:
+            type: string
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestCollectMetrics(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testMetricsSpec), &spec))
+
+	require.Equal(t, &openapi.APIMetrics{
+		Operations: []*openapi.OperationMetrics{
+			{
+				Location:         "/paths/~1pets~1{id}/put",
+				Method:           "PUT",
+				Path:             "/pets/{id}",
+				OperationID:      "updatePet",
+				Parameters:       2,
+				SchemaDepth:      3,
+				ResponseVariants: 3,
+				Components:       3,
+				RefComplexity:    2,
+			},
+			{
+				Location:         "/webhooks/ping/post",
+				Method:           "POST",
+				Path:             "ping",
+				ResponseVariants: 1,
+				RefComplexity:    1,
+			},
+		},
+		Components: 3,
+	}, openapi.CollectMetrics(spec))
+}
+
+func TestCollectMetrics_Empty(t *testing.T) {
+	require.Equal(t, &openapi.APIMetrics{Operations: []*openapi.OperationMetrics{}}, openapi.CollectMetrics(nil))
+}
@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemMediaType is the media type of the Problem Details, RFC 9457.
+const ProblemMediaType = "application/problem+json"
+
+type middlewareOptions struct {
+	skipUnmatched bool
+	catalog       MessageCatalog
+	onError       func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// MiddlewareOption is a type for the options of Middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+// SkipUnmatched passes the requests not matching any operation of the spec to the next handler
+// instead of rejecting them with the not found status.
+func SkipUnmatched() MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.skipUnmatched = true
+	}
+}
+
+// MiddlewareCatalog sets the catalog to translate the messages of the validation errors, see LocalizeErrors.
+func MiddlewareCatalog(catalog MessageCatalog) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.catalog = catalog
+	}
+}
+
+// OnRequestError sets the function writing the response for the invalid requests,
+// the default one writes ValidationProblem.
+func OnRequestError(f func(w http.ResponseWriter, r *http.Request, err error)) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.onError = f
+	}
+}
+
+// ValidationProblem is the response body for an invalid request in the format of the Problem Details, RFC 9457.
+type ValidationProblem struct {
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// The validation errors of the request.
+	Errors []*ValidationProblemError `json:"errors"`
+}
+
+// ValidationProblemError is a validation error of ValidationProblem.
+type ValidationProblemError struct {
+	ID       MessageID `json:"id"`
+	Location Location  `json:"location,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// Middleware returns the net/http middleware validating the requests using ValidateHTTPRequest:
+// the request is matched to an operation by its path and method, the path, query, header and cookie parameters
+// are decoded according to their style and explode and validated, as well as the request body.
+//
+// The valid requests are passed to the next handler, the body can be read again.
+// The invalid requests are rejected with ValidationProblem and the status depending on the error:
+// 404 if no operation is found (see SkipUnmatched), 413 if the body is too large (see MaxBodySize),
+// 415 if the media type is not supported, and 400 otherwise.
+func Middleware(validator *Validator, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	options := &middlewareOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.onError == nil {
+		options.onError = func(w http.ResponseWriter, _ *http.Request, err error) {
+			WriteValidationProblem(w, err, options.catalog)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := validator.ValidateHTTPRequest(r)
+			switch {
+			case err == nil, options.skipUnmatched && errors.Is(err, ErrOperationNotFound):
+				next.ServeHTTP(w, r)
+			default:
+				options.onError(w, r, err)
+			}
+		})
+	}
+}
+
+// WriteValidationProblem writes ValidationProblem for the given error of the request validation,
+// the messages are translated using the given catalog, nil catalog keeps the original texts.
+func WriteValidationProblem(w http.ResponseWriter, err error, catalog MessageCatalog) {
+	status := requestErrorStatus(err)
+	problem := &ValidationProblem{
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	for _, m := range LocalizeErrors(err, catalog) {
+		problem.Errors = append(problem.Errors, &ValidationProblemError{
+			ID:       m.ID,
+			Location: m.Location,
+			Message:  m.Text,
+		})
+	}
+	w.Header().Set("Content-Type", ProblemMediaType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	// the response is already started, so the error can not be reported
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// requestErrorStatus returns the status code of the response for the given error of the request validation.
+func requestErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrOperationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
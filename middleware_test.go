@@ -0,0 +1,180 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestMiddleware(t *testing.T) {
+	validator := newTestRequestValidator(t, testRequestSpec)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body can be read again by the handler
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	})
+
+	for _, tt := range []struct {
+		name        string
+		opts        []openapi.MiddlewareOption
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+		problem     *openapi.ValidationProblem
+	}{
+		{
+			name:        "valid request",
+			method:      http.MethodPost,
+			target:      "/pets",
+			contentType: "application/json",
+			body:        `{"name": "Rex"}`,
+			status:      http.StatusOK,
+		},
+		{
+			name:        "invalid body",
+			method:      http.MethodPost,
+			target:      "/pets",
+			contentType: "application/json",
+			body:        `{}`,
+			status:      http.StatusBadRequest,
+			problem: &openapi.ValidationProblem{
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []*openapi.ValidationProblemError{
+					{ID: openapi.MsgSchemaViolation, Location: "body"},
+				},
+			},
+		},
+		{
+			name:   "invalid parameters",
+			method: http.MethodGet,
+			target: "/pets?limit=1000",
+			status: http.StatusBadRequest,
+			problem: &openapi.ValidationProblem{
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []*openapi.ValidationProblemError{
+					{ID: openapi.MsgSchemaViolation, Location: "query/limit"},
+					{ID: openapi.MsgRequired, Location: "header/X-Request-ID", Message: "required"},
+				},
+			},
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodPost,
+			target:      "/pets",
+			contentType: "text/plain",
+			body:        "Rex",
+			status:      http.StatusUnsupportedMediaType,
+			problem: &openapi.ValidationProblem{
+				Title:  "Unsupported Media Type",
+				Status: http.StatusUnsupportedMediaType,
+				Errors: []*openapi.ValidationProblemError{
+					{ID: openapi.MsgUnsupportedMediaType, Location: "body", Message: "'text/plain': unsupported media type"},
+				},
+			},
+		},
+		{
+			name:   "unknown operation",
+			method: http.MethodDelete,
+			target: "/pets",
+			status: http.StatusNotFound,
+			problem: &openapi.ValidationProblem{
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Errors: []*openapi.ValidationProblemError{
+					{ID: openapi.MsgOperationNotFound, Message: "DELETE /pets: operation not found"},
+				},
+			},
+		},
+		{
+			name:   "skip unknown operation",
+			opts:   []openapi.MiddlewareOption{openapi.SkipUnmatched()},
+			method: http.MethodDelete,
+			target: "/pets",
+			status: http.StatusOK,
+		},
+		{
+			name:   "catalog",
+			opts:   []openapi.MiddlewareOption{openapi.MiddlewareCatalog(openapi.MapCatalog{openapi.MsgRequired: "{location} is missing"})},
+			method: http.MethodGet,
+			target: "/pets",
+			status: http.StatusBadRequest,
+			problem: &openapi.ValidationProblem{
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []*openapi.ValidationProblemError{
+					{ID: openapi.MsgRequired, Location: "header/X-Request-ID", Message: "header/X-Request-ID is missing"},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			openapi.Middleware(validator, tt.opts...)(next).ServeHTTP(w, r)
+			require.Equal(t, tt.status, w.Code)
+			if tt.problem == nil {
+				require.Equal(t, tt.body, w.Body.String())
+				return
+			}
+			require.Equal(t, openapi.ProblemMediaType, w.Header().Get("Content-Type"))
+			var problem openapi.ValidationProblem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			// the messages of the schema violations are long, so only their presence is checked
+			for i, e := range tt.problem.Errors {
+				if e.Message == "" {
+					require.NotEmpty(t, problem.Errors[i].Message)
+					e.Message = problem.Errors[i].Message
+				}
+			}
+			require.Equal(t, tt.problem, &problem)
+		})
+	}
+}
+
+func TestMiddleware_OnRequestError(t *testing.T) {
+	validator := newTestRequestValidator(t, testRequestSpec)
+	var called bool
+	handler := openapi.Middleware(validator, openapi.OnRequestError(func(w http.ResponseWriter, r *http.Request, err error) {
+		require.ErrorIs(t, err, openapi.ErrRequired)
+		w.WriteHeader(http.StatusTeapot)
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	require.Equal(t, http.StatusTeapot, w.Code)
+	require.False(t, called)
+}
+
+func TestMiddleware_ServerBasePath(t *testing.T) {
+	validator := newTestRequestValidator(t, testBasePathSpec)
+	handler := openapi.Middleware(validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for target, status := range map[string]int{
+		"/v1/pets/42":  http.StatusOK,
+		"/v1/pets/foo": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, status, w.Code, target)
+	}
+}
@@ -0,0 +1,154 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// Handler returns the handler serving the fake responses of the operations of the spec.
+//
+// The response of an operation is the lowest of its successful responses, `2XX`, or the default one with 200 status;
+// the body is the example of the media type, preferably JSON, or the value generated from its schema
+// without the write-only properties.
+// The same operation gets the same response, since the random values are seeded by the seed of the options
+// along with the method and the path template of the operation.
+// The requests matching no operation are rejected with 404 status.
+//
+// Example:
+//
+//	h, err := mock.Handler(spec, mock.Seed(42))
+//	...
+//	http.ListenAndServe(":8080", h)
+func Handler(spec *openapi.Extendable[openapi.OpenAPI], opts ...Option) (http.Handler, error) {
+	router, err := openapi.NewRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	var components *openapi.Extendable[openapi.Components]
+	if spec != nil && spec.Spec != nil {
+		components = spec.Spec.Components
+	}
+	return &handler{
+		router: router,
+		opts:   append([]Option{Components(components), SkipWriteOnly()}, opts...),
+	}, nil
+}
+
+type handler struct {
+	router *openapi.Router
+	opts   []Option
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	match, err := h.router.Match(r.Method, r.URL.EscapedPath())
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	g := New(h.opts...)
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(match.Method + " " + match.Template))
+	g.rnd = rand.New(rand.NewSource(g.opts.seed ^ int64(hash.Sum64())))
+
+	status, contentType, body, err := g.response(match.Operation)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if contentType == "" {
+		w.WriteHeader(status)
+		return
+	}
+	var data []byte
+	if s, ok := body.(string); ok && !isJSON(contentType) {
+		data = []byte(s)
+	} else if data, err = json.Marshal(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// response returns the status, the media type, and the body of the response of the operation,
+// the empty media type means no body.
+func (g *Generator) response(op *openapi.Operation) (int, string, any, error) {
+	if op.Responses == nil || op.Responses.Spec == nil {
+		return 0, "", nil, fmt.Errorf("%w: responses", openapi.ErrRequired)
+	}
+	status, ref := 0, op.Responses.Spec.Default
+	for _, code := range sortedKeys(op.Responses.Spec.Response) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if n, err := strconv.Atoi(code); err == nil {
+			status, ref = n, op.Responses.Spec.Response[code]
+			break
+		}
+		if status == 0 {
+			// the `2XX` range is used if there are no exact codes
+			status, ref = http.StatusOK, op.Responses.Spec.Response[code]
+		}
+	}
+	if ref == nil {
+		return 0, "", nil, fmt.Errorf("%w: successful or default response", openapi.ErrRequired)
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp, err := ref.GetSpec(g.opts.components)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	content := resp.Spec.Content
+	if len(content) == 0 {
+		return status, "", nil, nil
+	}
+	keys := sortedKeys(content)
+	contentType := keys[0]
+	for _, k := range keys {
+		if isJSON(k) {
+			contentType = k
+			break
+		}
+	}
+	mt := content[contentType]
+	if mt == nil || mt.Spec == nil {
+		return status, contentType, nil, nil
+	}
+	if g.opts.useExamples {
+		if mt.Spec.Example != nil {
+			return status, contentType, mt.Spec.Example, nil
+		}
+		for _, name := range sortedKeys(mt.Spec.Examples) {
+			e, err := mt.Spec.Examples[name].GetSpec(g.opts.components)
+			if err == nil && e.Spec != nil && e.Spec.Value != nil {
+				return status, contentType, e.Spec.Value, nil
+			}
+		}
+	}
+	if mt.Spec.Schema == nil {
+		return status, contentType, nil, nil
+	}
+	body, err := g.Generate(mt.Spec.Schema)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return status, contentType, body, nil
+}
+
+// isJSON reports whether the media type is JSON, e.g. `application/json` or `application/problem+json`.
+func isJSON(mediaType string) bool {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
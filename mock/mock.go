@@ -0,0 +1,518 @@
+// Package mock generates the fake payloads from the schemas of an OpenAPI specification,
+// e.g. to serve the responses of the operations which are specified, but not implemented yet, see Handler.
+//
+// The values honor the type, the format, the enum and the const, the pattern, the lengths and the ranges
+// of the schemas, the required properties, the `allOf` schemas merged into one object, and the `oneOf` and `anyOf`
+// schemas with the discriminator property set to the value of the chosen schema.
+// The generation is deterministic: the same schema and the same seed give the same value.
+//
+// Example:
+//
+//	v, err := mock.Generate(spec.Spec.Components.Spec.Schemas["Pet"], mock.Components(spec.Spec.Components), mock.Seed(42))
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+)
+
+// ErrUnsatisfiable is returned if no value matches a schema, e.g. the minimum is greater than the maximum.
+var ErrUnsatisfiable = errors.New("unsatisfiable schema")
+
+// DefaultMaxDepth is the default depth of the nested objects and arrays, see MaxDepth.
+const DefaultMaxDepth = 5
+
+type options struct {
+	seed          int64
+	components    *openapi.Extendable[openapi.Components]
+	useExamples   bool
+	requiredOnly  bool
+	skipReadOnly  bool
+	skipWriteOnly bool
+	maxDepth      int
+}
+
+// Option is a type for the options of Generator.
+type Option func(*options)
+
+// Seed sets the seed of the random values, 0 by default.
+func Seed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// Components sets the components used to resolve the references of the schemas.
+func Components(components *openapi.Extendable[openapi.Components]) Option {
+	return func(o *options) {
+		o.components = components
+	}
+}
+
+// IgnoreExamples generates the values even for the schemas having the examples or the default values,
+// by default the first of the examples, the example, or the default value is used as is.
+func IgnoreExamples() Option {
+	return func(o *options) {
+		o.useExamples = false
+	}
+}
+
+// RequiredOnly generates the required properties of the objects only, by default all properties are generated.
+func RequiredOnly() Option {
+	return func(o *options) {
+		o.requiredOnly = true
+	}
+}
+
+// SkipReadOnly omits the read-only properties, e.g. to generate the request bodies.
+func SkipReadOnly() Option {
+	return func(o *options) {
+		o.skipReadOnly = true
+	}
+}
+
+// SkipWriteOnly omits the write-only properties, e.g. to generate the response bodies.
+func SkipWriteOnly() Option {
+	return func(o *options) {
+		o.skipWriteOnly = true
+	}
+}
+
+// MaxDepth sets the depth of the nested objects and arrays, DefaultMaxDepth by default.
+// The deeper objects get the required properties only and the deeper arrays get the minimum number of items,
+// so the recursive schemas are generated until the optional properties end the recursion.
+func MaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// Generator generates the fake values of the schemas, it is not safe for the concurrent use.
+type Generator struct {
+	opts *options
+	rnd  *rand.Rand
+}
+
+// New creates Generator with the given options.
+func New(opts ...Option) *Generator {
+	o := &options{
+		useExamples: true,
+		maxDepth:    DefaultMaxDepth,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return &Generator{
+		opts: o,
+		rnd:  rand.New(rand.NewSource(o.seed)),
+	}
+}
+
+// Generate generates the value of the given schema using a new Generator with the given options.
+func Generate(schema *openapi.RefOrSpec[openapi.Schema], opts ...Option) (any, error) {
+	return New(opts...).Generate(schema)
+}
+
+// Generate generates the value of the given schema, the value is of the types decoded by encoding/json:
+// map[string]any, []any, string, float64, int64, bool, or nil.
+func (g *Generator) Generate(schema *openapi.RefOrSpec[openapi.Schema]) (any, error) {
+	return g.schema(nil, schema, 0)
+}
+
+// hardDepthLimit stops the recursion of the schemas requiring themselves.
+const hardDepthLimit = 4
+
+func (g *Generator) schema(path []string, ref *openapi.RefOrSpec[openapi.Schema], depth int) (any, error) {
+	if ref == nil {
+		return g.word(), nil
+	}
+	if depth > g.opts.maxDepth*hardDepthLimit {
+		return nil, fmt.Errorf("%s: %w: more than %d nested levels", location(path), ErrUnsatisfiable, depth-1)
+	}
+	s, err := ref.GetSpec(g.opts.components)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location(path), err)
+	}
+	if s.Bool != nil {
+		if !*s.Bool {
+			return nil, fmt.Errorf("%s: %w: false schema", location(path), ErrUnsatisfiable)
+		}
+		return g.word(), nil
+	}
+	if s.Ref != "" || s.DynamicRef != "" {
+		// the sibling keywords, see openapi.KeepRefSiblings
+		if s, err = openapi.ResolveSchema(ref, g.opts.components); err != nil {
+			return nil, fmt.Errorf("%s: %w", location(path), err)
+		}
+	}
+	switch {
+	case s.Const != nil:
+		return s.Const, nil
+	case len(s.Enum) > 0:
+		return s.Enum[g.rnd.Intn(len(s.Enum))], nil
+	case g.opts.useExamples && len(s.Examples) > 0:
+		return s.Examples[0], nil
+	case g.opts.useExamples && s.Example != nil:
+		return s.Example, nil
+	case g.opts.useExamples && s.Default != nil:
+		return s.Default, nil
+	case len(s.AllOf) > 0:
+		return g.allOf(path, s, depth)
+	case len(s.OneOf) > 0:
+		return g.oneOf(path, s, s.OneOf, depth)
+	case len(s.AnyOf) > 0:
+		return g.oneOf(path, s, s.AnyOf, depth)
+	}
+	switch schemaType(s) {
+	case openapi.ObjectType:
+		return g.object(path, s, depth)
+	case openapi.ArrayType:
+		return g.array(path, s, depth)
+	case openapi.IntegerType:
+		return g.integer(path, s)
+	case openapi.NumberType:
+		return g.number(path, s)
+	case openapi.BooleanType:
+		return g.rnd.Intn(2) == 1, nil
+	case openapi.NullType:
+		return nil, nil
+	}
+	return g.string(path, s)
+}
+
+// allOf merges the values of the schemas of `allOf` and the schema itself into one object,
+// the values which are not objects are returned as is.
+func (g *Generator) allOf(path []string, s *openapi.Schema, depth int) (any, error) {
+	merged := make(map[string]any)
+	rest := *s
+	rest.AllOf = nil
+	parts := append(slices.Clip(s.AllOf), openapi.NewRefOrSpec[openapi.Schema](&rest))
+	for i, sub := range parts {
+		v, err := g.schema(append(path, "allOf", strconv.Itoa(i)), sub, depth)
+		if err != nil {
+			return nil, err
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			if i == len(parts)-1 && len(merged) > 0 {
+				// the schema has no keywords of its own
+				break
+			}
+			return v, nil
+		}
+		for k, item := range obj {
+			merged[k] = item
+		}
+	}
+	return merged, nil
+}
+
+// oneOf generates the value of a random schema of the list,
+// the discriminator property is set to the value mapped to the schema or to the name of the referenced schema.
+func (g *Generator) oneOf(path []string, s *openapi.Schema, list []*openapi.RefOrSpec[openapi.Schema], depth int) (any, error) {
+	keyword := "oneOf"
+	if len(s.OneOf) == 0 {
+		keyword = "anyOf"
+	}
+	i := g.rnd.Intn(len(list))
+	v, err := g.schema(append(path, keyword, strconv.Itoa(i)), list[i], depth)
+	if err != nil || s.Discriminator == nil || s.Discriminator.PropertyName == "" {
+		return v, err
+	}
+	obj, ok := v.(map[string]any)
+	if !ok || list[i].Ref == nil {
+		return v, nil
+	}
+	ref := list[i].Ref.Ref
+	value := ref[strings.LastIndex(ref, "/")+1:]
+	for _, k := range sortedKeys(s.Discriminator.Mapping) {
+		if target := s.Discriminator.Mapping[k]; target == ref || target == value {
+			value = k
+			break
+		}
+	}
+	obj[s.Discriminator.PropertyName] = value
+	return obj, nil
+}
+
+func (g *Generator) object(path []string, s *openapi.Schema, depth int) (any, error) {
+	deep := depth >= g.opts.maxDepth
+	obj := make(map[string]any, len(s.Properties))
+	for _, name := range sortedKeys(s.Properties) {
+		required := slices.Contains(s.Required, name)
+		if !required && (deep || g.opts.requiredOnly) {
+			continue
+		}
+		prop := s.Properties[name]
+		if !required && (g.opts.skipReadOnly || g.opts.skipWriteOnly) {
+			p, err := prop.GetSpec(g.opts.components)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", location(append(path, "properties", name)), err)
+			}
+			if (g.opts.skipReadOnly && p.ReadOnly) || (g.opts.skipWriteOnly && p.WriteOnly) {
+				continue
+			}
+		}
+		v, err := g.schema(append(path, "properties", name), prop, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+	// the required properties without the schemas
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			obj[name] = g.word()
+		}
+	}
+	minProperties := 0
+	if s.MinProperties != nil {
+		minProperties = *s.MinProperties
+	}
+	if ap := s.AdditionalProperties; ap != nil && (ap.Allowed || ap.Schema != nil) {
+		if len(s.Properties) == 0 && !deep && minProperties == 0 {
+			minProperties = 1
+		}
+		for i := 1; len(obj) < minProperties; i++ {
+			name := "property" + strconv.Itoa(i)
+			if _, ok := obj[name]; ok {
+				continue
+			}
+			v, err := g.schema(append(path, "additionalProperties"), ap.Schema, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+	}
+	if len(obj) < minProperties {
+		return nil, fmt.Errorf("%s: %w: less than %d properties", location(path), ErrUnsatisfiable, minProperties)
+	}
+	return obj, nil
+}
+
+func (g *Generator) array(path []string, s *openapi.Schema, depth int) (any, error) {
+	minItems, maxItems := 0, 3
+	if s.MinItems != nil {
+		minItems = *s.MinItems
+		maxItems = max(maxItems, minItems)
+	}
+	if s.MaxItems != nil {
+		maxItems = *s.MaxItems
+	}
+	minItems = max(minItems, len(s.PrefixItems))
+	if minItems > maxItems {
+		return nil, fmt.Errorf("%s: %w: minItems is greater than maxItems", location(path), ErrUnsatisfiable)
+	}
+	n := minItems
+	if depth < g.opts.maxDepth && maxItems > minItems {
+		n = max(1, minItems) + g.rnd.Intn(maxItems-max(1, minItems)+1)
+	}
+	var items *openapi.RefOrSpec[openapi.Schema]
+	if s.Items != nil {
+		if s.Items.Schema == nil && !s.Items.Allowed {
+			n = len(s.PrefixItems)
+		}
+		items = s.Items.Schema
+	}
+	unique := s.UniqueItems != nil && *s.UniqueItems
+	arr := make([]any, 0, n)
+	seen := make(map[string]bool, n)
+	for i := 0; len(arr) < n; i++ {
+		schema, itemPath := items, append(path, "items")
+		if len(arr) < len(s.PrefixItems) {
+			schema, itemPath = s.PrefixItems[len(arr)], append(path, "prefixItems", strconv.Itoa(len(arr)))
+		}
+		v, err := g.schema(itemPath, schema, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if unique {
+			key := fmt.Sprintf("%#v", v)
+			if seen[key] {
+				if i > n*10 {
+					if len(arr) >= minItems {
+						break
+					}
+					return nil, fmt.Errorf("%s: %w: not enough unique items", location(path), ErrUnsatisfiable)
+				}
+				continue
+			}
+			seen[key] = true
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (g *Generator) integer(path []string, s *openapi.Schema) (any, error) {
+	lo, hasLo, err := bound(s.Minimum, s.ExclusiveMinimum, true, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location(path), err)
+	}
+	hi, hasHi, err := bound(s.Maximum, s.ExclusiveMaximum, false, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location(path), err)
+	}
+	switch {
+	case !hasLo && !hasHi:
+		lo, hi = 1, 1000
+	case !hasLo && hi >= 1:
+		lo = 1
+	case !hasLo:
+		lo = hi - 1000
+	case !hasHi:
+		hi = lo + 1000
+	}
+	l, h := int64(lo), int64(hi)
+	step := int64(1)
+	if s.MultipleOf != nil {
+		m, err := s.MultipleOf.Float64()
+		if err != nil || m <= 0 || m != math.Trunc(m) {
+			return nil, fmt.Errorf("%s: %w: multipleOf %s for integer", location(path), ErrUnsatisfiable, s.MultipleOf)
+		}
+		step = int64(m)
+		l = ceilDiv(l, step) * step
+	}
+	if l > h {
+		return nil, fmt.Errorf("%s: %w: no integer in range", location(path), ErrUnsatisfiable)
+	}
+	return l + g.rnd.Int63n((h-l)/step+1)*step, nil
+}
+
+func (g *Generator) number(path []string, s *openapi.Schema) (any, error) {
+	lo, hasLo, err := bound(s.Minimum, s.ExclusiveMinimum, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location(path), err)
+	}
+	hi, hasHi, err := bound(s.Maximum, s.ExclusiveMaximum, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location(path), err)
+	}
+	switch {
+	case !hasLo && !hasHi:
+		lo, hi = 0, 1000
+	case !hasLo && hi > 0:
+		lo = 0
+	case !hasLo:
+		lo = hi - 1000
+	case !hasHi:
+		hi = lo + 1000
+	}
+	if lo > hi || (lo == hi && (s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil)) {
+		return nil, fmt.Errorf("%s: %w: minimum is greater than maximum", location(path), ErrUnsatisfiable)
+	}
+	if s.MultipleOf != nil {
+		m, err := s.MultipleOf.Float64()
+		if err != nil || m <= 0 {
+			return nil, fmt.Errorf("%s: %w: multipleOf %s", location(path), ErrUnsatisfiable, s.MultipleOf)
+		}
+		first, last := math.Ceil(lo/m), math.Floor(hi/m)
+		if s.ExclusiveMinimum != nil && first*m <= lo {
+			first++
+		}
+		if s.ExclusiveMaximum != nil && last*m >= hi {
+			last--
+		}
+		if first > last {
+			return nil, fmt.Errorf("%s: %w: no multiple of %s in range", location(path), ErrUnsatisfiable, s.MultipleOf)
+		}
+		return (first + float64(g.rnd.Int63n(int64(last-first)+1))) * m, nil
+	}
+	// the exclusive bounds are never reached
+	v := lo + (hi-lo)*(0.05+0.9*g.rnd.Float64())
+	if rounded := math.Round(v*100) / 100; rounded > lo && rounded < hi {
+		v = rounded
+	}
+	return v, nil
+}
+
+// bound returns the lower or the upper inclusive bound of the given keywords, the stricter one if both are set.
+// The bounds of the integers are rounded inwards and the exclusive integer bounds are moved by one.
+func bound(inclusive, exclusive *openapi.Number, lower, integer bool) (float64, bool, error) {
+	var (
+		v     float64
+		found bool
+	)
+	for _, n := range []*openapi.Number{inclusive, exclusive} {
+		if n == nil {
+			continue
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: %q", openapi.ErrInvalidFormat, n)
+		}
+		if integer {
+			switch {
+			case lower && n == exclusive:
+				f = math.Floor(f) + 1
+			case lower:
+				f = math.Ceil(f)
+			case n == exclusive:
+				f = math.Ceil(f) - 1
+			default:
+				f = math.Floor(f)
+			}
+		}
+		if !found || (lower && f > v) || (!lower && f < v) {
+			v = f
+		}
+		found = true
+	}
+	return v, found, nil
+}
+
+// ceilDiv returns the quotient rounded up.
+func ceilDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a > 0 {
+		q++
+	}
+	return q
+}
+
+// schemaType returns the first not null type of the schema or the type implied by its keywords.
+func schemaType(s *openapi.Schema) string {
+	if s.Type != nil {
+		for _, t := range *s.Type {
+			if t != openapi.NullType {
+				return t
+			}
+		}
+		if len(*s.Type) > 0 {
+			return openapi.NullType
+		}
+	}
+	switch {
+	case len(s.Properties) > 0 || s.AdditionalProperties != nil || len(s.Required) > 0:
+		return openapi.ObjectType
+	case s.Items != nil || len(s.PrefixItems) > 0:
+		return openapi.ArrayType
+	case s.Minimum != nil || s.Maximum != nil || s.ExclusiveMinimum != nil || s.ExclusiveMaximum != nil || s.MultipleOf != nil:
+		return openapi.NumberType
+	}
+	return openapi.StringType
+}
+
+func location(path []string) string {
+	if len(path) == 0 {
+		return "#"
+	}
+	return "#" + openapi.NewLocation(path...).String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,171 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/mock"
+)
+
+const spec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "404":
+          description: Not found
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: '#/components/schemas/Cat'
+    Base:
+      type: object
+      required: [id, kind, name, tags]
+      properties:
+        id:
+          type: string
+          format: uuid
+        kind:
+          type: string
+        name:
+          type: string
+          minLength: 3
+          maxLength: 8
+        code:
+          type: string
+          pattern: '^[A-Z]{3}-\d{2,4}$'
+        born:
+          type: string
+          format: date
+        tags:
+          type: array
+          minItems: 1
+          maxItems: 4
+          uniqueItems: true
+          items:
+            type: string
+            enum: [cute, lazy, loud, small]
+        secret:
+          type: string
+          writeOnly: true
+        parent:
+          $ref: '#/components/schemas/Pet'
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [lives]
+          properties:
+            lives:
+              type: integer
+              minimum: 1
+              exclusiveMaximum: 10
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [weight]
+          properties:
+            weight:
+              type: number
+              exclusiveMinimum: 0
+              maximum: 80
+              multipleOf: 0.5
+`
+
+func TestGenerate(t *testing.T) {
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, openapi.Unmarshal([]byte(spec), &doc))
+	validator, err := openapi.NewValidator(doc)
+	require.NoError(t, err)
+	components := doc.Spec.Components
+	pet := components.Spec.Schemas["Pet"]
+
+	kinds := make(map[any]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		v, err := mock.Generate(pet, mock.Components(components), mock.Seed(seed))
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateData("/components/schemas/Pet", v), "seed %d: %v", seed, v)
+		kinds[v.(map[string]any)["kind"]] = true
+
+		again, err := mock.Generate(pet, mock.Components(components), mock.Seed(seed))
+		require.NoError(t, err)
+		require.Equal(t, v, again, "deterministic")
+	}
+	require.Equal(t, map[any]bool{"cat": true, "Dog": true}, kinds)
+
+	t.Run("required only", func(t *testing.T) {
+		v, err := mock.Generate(components.Spec.Schemas["Base"], mock.Components(components), mock.RequiredOnly())
+		require.NoError(t, err)
+		require.Len(t, v, 4)
+	})
+
+	t.Run("unsatisfiable", func(t *testing.T) {
+		schema := openapi.NewSchemaBuilder().Type(openapi.IntegerType).Minimum(openapi.NewNumber(5)).ExclusiveMaximum(openapi.NewNumber(5)).Build()
+		_, err := mock.Generate(schema)
+		require.ErrorIs(t, err, mock.ErrUnsatisfiable)
+	})
+
+	t.Run("unresolved ref", func(t *testing.T) {
+		_, err := mock.Generate(pet)
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	var doc *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, openapi.Unmarshal([]byte(spec), &doc))
+	h, err := mock.Handler(doc, mock.Seed(42))
+	require.NoError(t, err)
+	validator, err := openapi.NewValidator(doc)
+	require.NoError(t, err)
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := get(http.MethodGet, "/pets/1")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.NoError(t, validator.ValidateResponse(http.MethodGet, "/pets/1", w.Code, "application/json", w.Header(), w.Body.Bytes()))
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.NotContains(t, body, "secret")
+	require.Equal(t, w.Body.String(), get(http.MethodGet, "/pets/2").Body.String(), "deterministic")
+
+	require.Equal(t, http.StatusNoContent, get(http.MethodDelete, "/pets/1").Code)
+	require.Equal(t, http.StatusNotFound, get(http.MethodGet, "/owners").Code)
+}
@@ -0,0 +1,193 @@
+package mock
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sv-tools/openapi"
+)
+
+// words are the words of the generated strings.
+var words = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+	"india", "juliet", "kilo", "lima", "mike", "november", "oscar", "papa",
+}
+
+// maxRepeat is the maximum number of the repetitions of the unbounded quantifiers of the patterns, e.g. `*` or `+`.
+const maxRepeat = 3
+
+// epoch is the base of the generated dates, so the dates do not depend on the current time.
+var epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func (g *Generator) word() string {
+	return words[g.rnd.Intn(len(words))]
+}
+
+func (g *Generator) string(path []string, s *openapi.Schema) (any, error) {
+	if s.Pattern != "" {
+		v, err := g.pattern(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", location(append(path, "pattern")), err)
+		}
+		return v, nil
+	}
+	if v, ok := g.format(s.Format); ok {
+		return v, nil
+	}
+	minLength, maxLength := 0, 0
+	if s.MinLength != nil {
+		minLength = *s.MinLength
+	}
+	if s.MaxLength != nil {
+		maxLength = *s.MaxLength
+		if maxLength < minLength {
+			return nil, fmt.Errorf("%s: %w: minLength is greater than maxLength", location(path), ErrUnsatisfiable)
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(g.word())
+	for sb.Len() < minLength {
+		sb.WriteByte(' ')
+		sb.WriteString(g.word())
+	}
+	v := sb.String()
+	// the lengths are counted in characters, the words are ASCII
+	if s.MaxLength != nil && len(v) > maxLength {
+		v = strings.TrimRight(v[:maxLength], " ")
+		for len(v) < minLength {
+			v += "x"
+		}
+	}
+	return v, nil
+}
+
+// format returns the value of the well-known format.
+func (g *Generator) format(format string) (string, bool) {
+	switch format {
+	case "date-time":
+		return g.time().Format(time.RFC3339), true
+	case "date":
+		return g.time().Format(time.DateOnly), true
+	case "time":
+		return g.time().Format("15:04:05Z07:00"), true
+	case "duration":
+		return "PT" + strconv.Itoa(1+g.rnd.Intn(59)) + "M", true
+	case "email", "idn-email":
+		return g.word() + "@example.com", true
+	case "hostname", "idn-hostname":
+		return g.word() + ".example.com", true
+	case "ipv4":
+		return fmt.Sprintf("192.0.2.%d", 1+g.rnd.Intn(254)), true
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x", 1+g.rnd.Intn(0xfffe)), true
+	case "uri", "iri", "url":
+		return "https://example.com/" + g.word(), true
+	case "uri-reference", "iri-reference":
+		return "/" + g.word(), true
+	case "uri-template":
+		return "https://example.com/" + g.word() + "/{id}", true
+	case "uuid":
+		b := make([]byte, 16)
+		g.rnd.Read(b)
+		// version 4, variant 10
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), true
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(g.word())), true
+	case "json-pointer":
+		return "/" + g.word(), true
+	case "relative-json-pointer":
+		return "0/" + g.word(), true
+	case "regex":
+		return "^" + g.word() + "$", true
+	case "password":
+		return strings.Repeat("*", 8), true
+	}
+	return "", false
+}
+
+// time returns a time of the year after epoch.
+func (g *Generator) time() time.Time {
+	return epoch.Add(time.Duration(g.rnd.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// pattern returns a string matching the regular expression.
+func (g *Generator) pattern(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", openapi.ErrInvalidFormat, err)
+	}
+	var sb strings.Builder
+	if err := g.regexp(&sb, re.Simplify()); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func (g *Generator) regexp(sb *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return fmt.Errorf("%w: pattern matches nothing", ErrUnsatisfiable)
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		sb.WriteRune(g.charClass(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		sb.WriteRune(rune('a' + g.rnd.Intn(26)))
+	case syntax.OpCapture:
+		return g.regexp(sb, re.Sub[0])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			lo, hi = 0, maxRepeat
+		case syntax.OpPlus:
+			lo, hi = 1, maxRepeat
+		case syntax.OpQuest:
+			lo, hi = 0, 1
+		}
+		if hi < 0 {
+			hi = lo + maxRepeat
+		}
+		for n := lo + g.rnd.Intn(hi-lo+1); n > 0; n-- {
+			if err := g.regexp(sb, re.Sub[0]); err != nil {
+				return err
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := g.regexp(sb, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		return g.regexp(sb, re.Sub[g.rnd.Intn(len(re.Sub))])
+	}
+	// the anchors and the word boundaries match the empty string
+	return nil
+}
+
+// charClass returns a rune of the class given as the pairs of the ranges, the printable ASCII runes are preferred.
+func (g *Generator) charClass(ranges []rune) rune {
+	var printable [][2]rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := max(ranges[i], ' '+1), min(ranges[i+1], unicode.MaxASCII-1)
+		if lo <= hi {
+			printable = append(printable, [2]rune{lo, hi})
+		}
+	}
+	if len(printable) == 0 {
+		if len(ranges) == 0 {
+			return 'x'
+		}
+		return ranges[2*g.rnd.Intn(len(ranges)/2)]
+	}
+	r := printable[g.rnd.Intn(len(printable))]
+	return r[0] + rune(g.rnd.Intn(int(r[1]-r[0])+1))
+}
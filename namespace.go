@@ -0,0 +1,179 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+const componentsRefPrefix = "#/components/"
+
+// NamespaceSeparator separates a namespace from a component name, e.g. `billing.Invoice`.
+const NamespaceSeparator = "."
+
+// ApplyNamespace prefixes the names of all components of the given spec with the namespace,
+// so `Invoice` becomes `billing.Invoice`, and rewrites all local references to the renamed components,
+// including discriminator mappings and security requirements.
+// Components already having the prefix are prefixed again, so the function is not idempotent.
+func ApplyNamespace(spec *Extendable[OpenAPI], namespace string) {
+	if namespace == "" {
+		return
+	}
+	prefix := namespace + NamespaceSeparator
+	RenameComponents(spec, func(_, name string) string {
+		return prefix + name
+	})
+}
+
+// RemoveNamespace removes the namespace prefix from the names of all components of the given spec
+// and rewrites all local references to the renamed components.
+// The components without the prefix are kept as is.
+func RemoveNamespace(spec *Extendable[OpenAPI], namespace string) {
+	if namespace == "" {
+		return
+	}
+	prefix := namespace + NamespaceSeparator
+	RenameComponents(spec, func(_, name string) string {
+		return strings.TrimPrefix(name, prefix)
+	})
+}
+
+// RenameComponents renames the components of the given spec using the rename function
+// and rewrites all local references (`#/components/<type>/<name>`) to the renamed components.
+// The rename function receives the type of the component as used in references (e.g. `schemas`) and its name,
+// and returns a new name; returning the same name keeps the component as is.
+//
+// The discriminator mappings using the schema names and the security requirements are updated as well.
+// The function modifies the given spec in place.
+func RenameComponents(spec *Extendable[OpenAPI], rename func(typ, name string) string) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	if c := spec.Spec.Components; c != nil && c.Spec != nil {
+		renameComponentKeys(c.Spec, rename)
+	}
+	walkSpec(reflect.ValueOf(spec), func(v reflect.Value) {
+		switch t := v.Interface().(type) {
+		case *Ref:
+			t.Ref = renameRef(t.Ref, rename)
+		case *Schema:
+			if t.Ref != "" {
+				t.Ref = renameRef(t.Ref, rename)
+			}
+			if t.DynamicRef != "" {
+				t.DynamicRef = renameRef(t.DynamicRef, rename)
+			}
+		case *Discriminator:
+			for k, ref := range t.Mapping {
+				if strings.Contains(ref, "/") {
+					t.Mapping[k] = renameRef(ref, rename)
+				} else {
+					// a bare schema name
+					t.Mapping[k] = rename("schemas", ref)
+				}
+			}
+		case SecurityRequirement:
+			renameMapKeys(reflect.ValueOf(t), func(name string) string {
+				return rename("securitySchemes", name)
+			})
+		}
+	})
+}
+
+// renameComponentKeys renames the keys of all maps of the given Components object.
+func renameComponentKeys(c *Components, rename func(typ, name string) string) {
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.Map || f.IsNil() {
+			continue
+		}
+		typ, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		renamed := reflect.MakeMapWithSize(f.Type(), f.Len())
+		iter := f.MapRange()
+		for iter.Next() {
+			renamed.SetMapIndex(reflect.ValueOf(rename(typ, iter.Key().String())), iter.Value())
+		}
+		f.Set(renamed)
+	}
+}
+
+// renameMapKeys renames the keys of the given map with string keys in place.
+func renameMapKeys(m reflect.Value, rename func(string) string) {
+	keys := m.MapKeys()
+	values := make([]reflect.Value, len(keys))
+	for i, k := range keys {
+		values[i] = m.MapIndex(k)
+		m.SetMapIndex(k, reflect.Value{})
+	}
+	for i, k := range keys {
+		m.SetMapIndex(reflect.ValueOf(rename(k.String())).Convert(m.Type().Key()), values[i])
+	}
+}
+
+// renameRef rewrites the local reference to a component using the rename function.
+// The references to other documents or not to components are returned as is.
+func renameRef(ref string, rename func(typ, name string) string) string {
+	rest, ok := strings.CutPrefix(ref, componentsRefPrefix)
+	if !ok {
+		return ref
+	}
+	typ, rest, ok := strings.Cut(rest, "/")
+	if !ok {
+		return ref
+	}
+	name, tail, hasTail := strings.Cut(rest, "/")
+	name = jsonPointerEscaper.Replace(rename(typ, jsonPointerUnescaper.Replace(name)))
+	ref = componentsRefPrefix + typ + "/" + name
+	if hasTail {
+		ref += "/" + tail
+	}
+	return ref
+}
+
+// walkSpec calls the visit function for every pointer, map and slice value reachable from the given value.
+// The values of `any` type (extensions, examples, defaults, etc.) are not visited, because they hold raw data.
+// Every pointer is visited once, so the shared objects are not processed twice.
+func walkSpec(v reflect.Value, visit func(reflect.Value)) {
+	walkSpecValue(v, visit, make(map[uintptr]map[reflect.Type]bool))
+}
+
+func walkSpecValue(v reflect.Value, visit func(reflect.Value), visited map[uintptr]map[reflect.Type]bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		ptr := v.Pointer()
+		if visited[ptr][v.Type()] {
+			return
+		}
+		if visited[ptr] == nil {
+			visited[ptr] = make(map[reflect.Type]bool, 1)
+		}
+		visited[ptr][v.Type()] = true
+		visit(v)
+		walkSpecValue(v.Elem(), visit, visited)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkSpecValue(v.Field(i), visit, visited)
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		visit(v)
+		iter := v.MapRange()
+		for iter.Next() {
+			walkSpecValue(iter.Value(), visit, visited)
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			walkSpecValue(v.Index(i), visit, visited)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testNamespaceSpec = `
+openapi: 3.1.1
+info:
+  title: Billing
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /invoices:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Invoice'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Invoice:
+      type: object
+      properties:
+        lines:
+          type: array
+          items:
+            $ref: '#/components/schemas/Invoice/properties/line'
+        line:
+          oneOf:
+            - $ref: '#/components/schemas/Line'
+          discriminator:
+            propertyName: kind
+            mapping:
+              line: Line
+              ref: '#/components/schemas/Line'
+    Line:
+      type: object
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+`
+
+func TestApplyNamespace(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testNamespaceSpec), &spec))
+
+	openapi.ApplyNamespace(spec, "billing")
+
+	components := spec.Spec.Components.Spec
+	require.Contains(t, components.Schemas, "billing.Invoice")
+	require.Contains(t, components.Schemas, "billing.Line")
+	require.Contains(t, components.Parameters, "billing.Limit")
+	require.Contains(t, components.SecuritySchemes, "billing.apiKey")
+	require.NotContains(t, components.Schemas, "Invoice")
+
+	op := spec.Spec.Paths.Spec.Paths["/invoices"].Spec.Spec.Get.Spec
+	require.Equal(t, "#/components/parameters/billing.Limit", op.Parameters[0].Ref.Ref)
+	schema := op.Responses.Spec.Response["200"].Spec.Spec.Content["application/json"].Spec.Schema
+	require.Equal(t, "#/components/schemas/billing.Invoice", schema.Spec.Items.Schema.Ref.Ref)
+
+	invoice := components.Schemas["billing.Invoice"].Spec
+	require.Equal(t, "#/components/schemas/billing.Invoice/properties/line", invoice.Properties["lines"].Spec.Items.Schema.Ref.Ref)
+	line := invoice.Properties["line"].Spec
+	require.Equal(t, "#/components/schemas/billing.Line", line.OneOf[0].Ref.Ref)
+	require.Equal(t, map[string]string{
+		"line": "billing.Line",
+		"ref":  "#/components/schemas/billing.Line",
+	}, line.Discriminator.Mapping)
+	require.Equal(t, []openapi.SecurityRequirement{{"billing.apiKey": {}}}, spec.Spec.Security)
+
+	t.Run("remove", func(t *testing.T) {
+		openapi.RemoveNamespace(spec, "billing")
+
+		var expected *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testNamespaceSpec), &expected))
+		expectedData, err := json.Marshal(expected)
+		require.NoError(t, err)
+		actualData, err := json.Marshal(spec)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedData), string(actualData))
+	})
+}
+
+func TestRenameComponents_EscapedName(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Test").Version("1.0.0").Build()).
+		AddComponent("a/b", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+		AddComponent("ref", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/a~1b")).
+		Build()
+
+	openapi.RenameComponents(spec, func(typ, name string) string {
+		require.Equal(t, "schemas", typ)
+		return name + "/v2"
+	})
+
+	require.Contains(t, spec.Spec.Components.Spec.Schemas, "a/b/v2")
+	require.Equal(t, "#/components/schemas/a~1b~1v2", spec.Spec.Components.Spec.Schemas["ref/v2"].Ref.Ref)
+}
@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentLanguageHeader is the name of the response header declaring the languages of the content.
+const ContentLanguageHeader = "Content-Language"
+
+// languageTagRe loosely matches the language tags of BCP 47, e.g. `en` or `zh-Hant-TW`.
+var languageTagRe = regexp.MustCompile(`^[A-Za-z]{1,8}(-[A-Za-z0-9]{1,8})*$`)
+
+// MediaTypeWithCharset returns the media type with the given charset parameter to be used as a key of content maps,
+// e.g. `text/plain; charset=utf-8`.
+func MediaTypeWithCharset(mediaType, charset string) string {
+	return mime.FormatMediaType(mediaType, map[string]string{"charset": charset})
+}
+
+// ContentLanguages declares the languages of the response content by adding `Content-Language` header
+// with the enum of the given language tags, e.g. `en`, `de-AT`.
+func (b *ResponseBuilder) ContentLanguages(tags ...string) *ResponseBuilder {
+	values := make([]any, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+	return b.AddHeader(ContentLanguageHeader, NewHeaderBuilder().
+		Schema(NewSchemaBuilder().Type(StringType).Enum(values...).Build()).
+		Build())
+}
+
+// ContentLanguagesOf returns the languages declared by the enum of the `Content-Language` header of the response,
+// the header name is case-insensitive.
+func ContentLanguagesOf(response *Response, components *Extendable[Components]) ([]string, error) {
+	if response == nil {
+		return nil, nil
+	}
+	for name, ref := range response.Headers {
+		if !strings.EqualFold(name, ContentLanguageHeader) || ref == nil {
+			continue
+		}
+		header, err := ref.GetSpec(components)
+		if err != nil {
+			return nil, err
+		}
+		if header.Spec == nil || header.Spec.Schema == nil {
+			return nil, nil
+		}
+		schema, err := header.Spec.Schema.GetSpec(components)
+		if err != nil {
+			return nil, err
+		}
+		tags := make([]string, 0, len(schema.Enum))
+		for _, v := range schema.Enum {
+			tag, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: language tag must be a string, but got %T", ErrInvalidFormat, v)
+			}
+			tags = append(tags, tag)
+		}
+		return tags, nil
+	}
+	return nil, nil
+}
+
+// validateContentKeys checks that the keys of the content map are valid media types or media type ranges.
+func validateContentKeys(location string, content map[string]*Extendable[MediaType]) []*validationError {
+	var errs []*validationError
+	for _, k := range sortedKeys(content) {
+		if _, _, err := mime.ParseMediaType(k); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, k), "%w: media type: %w", ErrInvalidFormat, err))
+		}
+	}
+	return errs
+}
+
+// validateContentLanguage checks the language tags of the enum of the `Content-Language` response header.
+func validateContentLanguage(location string, header *RefOrSpec[Extendable[Header]], validator *Validator) []*validationError {
+	h, err := header.GetSpec(validator.spec.Spec.Components)
+	if err != nil || h.Spec == nil || h.Spec.Schema == nil {
+		// the errors are reported by the validation of the header
+		return nil
+	}
+	schema, err := h.Spec.Schema.GetSpec(validator.spec.Spec.Components)
+	if err != nil {
+		return nil
+	}
+	var errs []*validationError
+	for i, v := range schema.Enum {
+		if tag, ok := v.(string); !ok || !languageTagRe.MatchString(tag) {
+			errs = append(errs, newValidationError(joinLoc(location, "schema", "enum", i), "%w: must be a language tag, but got '%v'", ErrInvalidFormat, v))
+		}
+	}
+	return errs
+}
+
+// acceptRange is an element of the Accept or Accept-Language header.
+type acceptRange struct {
+	value  string
+	params map[string]string
+	q      float64
+}
+
+// parseAccept parses the comma separated list of the ranges with the optional parameters and the quality values,
+// the invalid elements are skipped.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		value, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			// the language ranges are not media types, e.g. `en` or `*`
+			v, rest, _ := strings.Cut(part, ";")
+			value, params = strings.ToLower(strings.TrimSpace(v)), make(map[string]string)
+			if k, qv, ok := strings.Cut(strings.TrimSpace(rest), "="); ok && strings.EqualFold(strings.TrimSpace(k), "q") {
+				params["q"] = strings.TrimSpace(qv)
+			}
+		}
+		r := acceptRange{value: value, params: params, q: 1}
+		if qv, ok := params["q"]; ok {
+			q, err := strconv.ParseFloat(qv, 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+			r.q = q
+			delete(params, "q")
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// NegotiateContent selects the media type of the content preferred by the given Accept header,
+// proactive negotiation of RFC 9110.
+//
+// The quality of a media type is the quality of the most specific range of the Accept header matching it:
+// the media type with parameters, e.g. `text/plain;charset=utf-8`, then the media type, then `type/*` and `*/*`.
+// The charset parameters must be equal if both the range and the media type declare it.
+// The keys of the content can be the ranges too, e.g. `image/*`, then they match any accepted media type of the range.
+// The media types with zero quality are not acceptable, and the empty Accept header accepts all media types.
+// If several media types have the same quality, the media type is preferred over the ranges, then the first one in sorted order.
+//
+// An empty key and nil are returned if no media type is acceptable.
+func NegotiateContent(content map[string]*Extendable[MediaType], accept string) (string, *Extendable[MediaType]) {
+	ranges := parseAccept(accept)
+	if strings.TrimSpace(accept) == "" {
+		ranges = []acceptRange{{value: "*/*", q: 1}}
+	}
+	var (
+		best            string
+		bestQ           float64
+		bestSpecificity int
+	)
+	for _, key := range sortedKeys(content) {
+		mt, params, err := mime.ParseMediaType(key)
+		if err != nil {
+			continue
+		}
+		q, specificity := -1.0, -1
+		for _, r := range ranges {
+			if s, ok := matchMediaRange(r, mt, params); ok && s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		// the parameters of the keys do not matter, only the ranges are less preferred
+		keySpecificity := min(mediaRangeSpecificity(mt, params), 2)
+		if q > bestQ || q == bestQ && q > 0 && keySpecificity > bestSpecificity {
+			best, bestQ, bestSpecificity = key, q, keySpecificity
+		}
+	}
+	if bestQ <= 0 {
+		return "", nil
+	}
+	return best, content[best]
+}
+
+// matchMediaRange reports whether the range matches the media type and returns the specificity of the range.
+func matchMediaRange(r acceptRange, mediaType string, params map[string]string) (int, bool) {
+	rType, rSubtype, _ := strings.Cut(r.value, "/")
+	mType, mSubtype, _ := strings.Cut(mediaType, "/")
+	if rType != "*" && mType != "*" && rType != mType || rSubtype != "*" && mSubtype != "*" && rSubtype != mSubtype {
+		return 0, false
+	}
+	rCharset, rOk := r.params["charset"]
+	mCharset, mOk := params["charset"]
+	if rOk && mOk && !strings.EqualFold(rCharset, mCharset) {
+		return 0, false
+	}
+	return mediaRangeSpecificity(r.value, r.params), true
+}
+
+// mediaRangeSpecificity returns 0 for `*/*`, 1 for `type/*`, 2 for `type/subtype` and 3 if there are parameters.
+func mediaRangeSpecificity(mediaType string, params map[string]string) int {
+	switch {
+	case mediaType == "*/*":
+		return 0
+	case strings.HasSuffix(mediaType, "/*"):
+		return 1
+	case len(params) > 0:
+		return 3
+	}
+	return 2
+}
+
+// NegotiateLanguage selects the language tag preferred by the given Accept-Language header among the available ones.
+//
+// A language range matches the tags equal to it or starting with it followed by `-`, e.g. `en` matches `en-US`,
+// and `*` matches any tag; the most specific matching range sets the quality of a tag, see RFC 4647 basic filtering.
+// The tags with zero quality are not acceptable, and the empty header accepts all tags.
+// If several tags have the same quality, the first one in the given order is preferred.
+// The ok is false if no tag is acceptable.
+func NegotiateLanguage(available []string, acceptLanguage string) (tag string, ok bool) {
+	ranges := parseAccept(acceptLanguage)
+	if strings.TrimSpace(acceptLanguage) == "" {
+		ranges = []acceptRange{{value: "*", q: 1}}
+	}
+	// the longer ranges are more specific
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return len(ranges[i].value) > len(ranges[j].value)
+	})
+	var bestQ float64
+	for _, t := range available {
+		lower := strings.ToLower(t)
+		for _, r := range ranges {
+			if r.value == "*" || r.value == lower || strings.HasPrefix(lower, r.value+"-") {
+				if r.q > bestQ {
+					tag, bestQ = t, r.q
+				}
+				break
+			}
+		}
+	}
+	return tag, bestQ > 0
+}
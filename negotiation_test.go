@@ -0,0 +1,185 @@
+package openapi_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestNegotiateContent(t *testing.T) {
+	content := map[string]*openapi.Extendable[openapi.MediaType]{
+		"application/json":                                openapi.NewMediaTypeBuilder().Build(),
+		"text/plain; charset=utf-8":                       openapi.NewMediaTypeBuilder().Build(),
+		"text/plain; charset=iso-8859-1":                  openapi.NewMediaTypeBuilder().Build(),
+		"image/*":                                         openapi.NewMediaTypeBuilder().Build(),
+		"application/problem+json":                        openapi.NewMediaTypeBuilder().Build(),
+		openapi.MediaTypeWithCharset("text/csv", "utf-8"): openapi.NewMediaTypeBuilder().Build(),
+	}
+	for _, tt := range []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "", expected: "application/json"},
+		{accept: "*/*", expected: "application/json"},
+		{accept: "application/json", expected: "application/json"},
+		{accept: "text/html, application/json;q=0.5", expected: "application/json"},
+		{accept: "application/*;q=0.2, application/problem+json", expected: "application/problem+json"},
+		{accept: "text/plain;charset=ISO-8859-1", expected: "text/plain; charset=iso-8859-1"},
+		{accept: "text/plain;charset=utf-8;q=0.5, text/plain;charset=iso-8859-1;q=0.4", expected: "text/plain; charset=utf-8"},
+		{accept: "text/csv", expected: "text/csv; charset=utf-8"},
+		{accept: "image/png", expected: "image/*"},
+		{accept: "*/*;q=0.1, application/json;q=0", expected: "application/problem+json"},
+		{accept: "text/html"},
+		{accept: "application/json;q=0, */*;q=0"},
+	} {
+		t.Run(tt.accept, func(t *testing.T) {
+			key, mt := openapi.NegotiateContent(content, tt.accept)
+			require.Equal(t, tt.expected, key)
+			if tt.expected == "" {
+				require.Nil(t, mt)
+			} else {
+				require.Same(t, content[tt.expected], mt)
+			}
+		})
+	}
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	available := []string{"en-US", "de", "de-AT", "fr"}
+	for _, tt := range []struct {
+		accept   string
+		expected string
+	}{
+		{accept: "", expected: "en-US"},
+		{accept: "de", expected: "de"},
+		{accept: "de-AT, de;q=0.9", expected: "de-AT"},
+		{accept: "EN", expected: "en-US"},
+		{accept: "fr;q=0.5, de;q=0.8", expected: "de"},
+		{accept: "*;q=0.1, en;q=0", expected: "de"},
+		{accept: "it"},
+		{accept: "*;q=0"},
+	} {
+		t.Run(tt.accept, func(t *testing.T) {
+			tag, ok := openapi.NegotiateLanguage(available, tt.accept)
+			require.Equal(t, tt.expected, tag)
+			require.Equal(t, tt.expected != "", ok)
+		})
+	}
+}
+
+func TestContentLanguages(t *testing.T) {
+	resp := openapi.NewResponseBuilder().
+		Description("greeting").
+		ContentLanguages("en", "de-AT").
+		AddContent(openapi.MediaTypeWithCharset("text/plain", "utf-8"), openapi.NewMediaTypeBuilder().Build()).
+		Build()
+	tags, err := openapi.ContentLanguagesOf(resp.Spec.Spec, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"en", "de-AT"}, tags)
+	require.Contains(t, resp.Spec.Spec.Content, "text/plain; charset=utf-8")
+
+	tags, err = openapi.ContentLanguagesOf(openapi.NewResponseBuilder().Build().Spec.Spec, nil)
+	require.NoError(t, err)
+	require.Nil(t, tags)
+}
+
+func TestContentNegotiation_ValidateSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		header string
+		key    string
+		err    string
+	}{
+		{
+			name:   "valid",
+			header: "enum: [en, zh-Hant-TW]",
+			key:    "text/plain; charset=utf-8",
+		},
+		{
+			name:   "invalid language tag",
+			header: "enum: [en, 'en_US']",
+			key:    "text/plain",
+			err:    "headers/Content-Language/schema/enum/1: invalid format: must be a language tag",
+		},
+		{
+			name:   "invalid media type",
+			header: "enum: [en]",
+			key:    "text/plain; charset",
+			err:    "invalid format: media type",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestRequestValidator(t, `
+openapi: 3.1.1
+info:
+  title: Negotiation
+  version: 1.0.0
+paths:
+  /greeting:
+    get:
+      responses:
+        '200':
+          description: greeting
+          headers:
+            Content-Language:
+              schema:
+                type: string
+                `+tt.header+`
+          content:
+            '`+tt.key+`':
+              schema:
+                type: string
+`)
+			err := validator.ValidateSpec()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateHTTPRequest_Charset(t *testing.T) {
+	validator := newTestRequestValidator(t, `
+openapi: 3.1.1
+info:
+  title: Negotiation
+  version: 1.0.0
+paths:
+  /notes:
+    post:
+      requestBody:
+        content:
+          application/json; charset=utf-8:
+            schema:
+              type: object
+      responses:
+        '204':
+          description: created
+`)
+	for _, tt := range []struct {
+		contentType string
+		err         string
+	}{
+		{contentType: "application/json"},
+		{contentType: "application/json; charset=UTF-8"},
+		{contentType: "application/json; charset=utf-16", err: "unsupported media type"},
+	} {
+		t.Run(tt.contentType, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/notes", bytes.NewBufferString(`{}`))
+			r.Header.Set("Content-Type", tt.contentType)
+			err := validator.ValidateHTTPRequest(r)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+type normalizeOptions struct {
+	keepTagsOrder        bool
+	keepDuplicateServers bool
+	keepSecurityOrder    bool
+	keepPropertyOrder    bool
+}
+
+// NormalizeOption is a type for normalization options.
+type NormalizeOption func(*normalizeOptions)
+
+// KeepTagsOrder is a normalization option to preserve the declaration order of the tags.
+func KeepTagsOrder() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.keepTagsOrder = true
+	}
+}
+
+// KeepDuplicateServers is a normalization option to keep the servers with the same URL and variables.
+func KeepDuplicateServers() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.keepDuplicateServers = true
+	}
+}
+
+// KeepSecurityOrder is a normalization option to preserve the order of the security requirements and their scopes.
+func KeepSecurityOrder() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.keepSecurityOrder = true
+	}
+}
+
+// KeepPropertyOrder is a normalization option to not store the order of the properties of the schemas
+// in the `x-property-order` extension.
+func KeepPropertyOrder() NormalizeOption {
+	return func(o *normalizeOptions) {
+		o.keepPropertyOrder = true
+	}
+}
+
+// Normalize rewrites the given spec in place into a deterministic form, so the documents generated
+// or merged in different ways can be compared and produce stable diffs:
+//
+//   - the tags are sorted by name, the tags with the same name keep the declaration order;
+//   - the servers of the document, the path items and the operations are deduplicated, the first one wins;
+//   - the security requirements of the document and the operations are sorted by the names of the schemes,
+//     and the scopes of each requirement are sorted;
+//   - the `x-property-order` extension is set to the full list of the properties in the order returned by
+//     Schema.OrderedProperties for each schema having the properties with the `x-order` extension or the extension itself.
+//
+// Each step can be disabled using the options.
+func Normalize(spec *Extendable[OpenAPI], opts ...NormalizeOption) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	options := &normalizeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if !options.keepTagsOrder {
+		slices.SortStableFunc(spec.Spec.Tags, func(a, b *Extendable[Tag]) int {
+			return cmp.Compare(tagName(a), tagName(b))
+		})
+	}
+
+	servers := func(v []*Extendable[Server]) []*Extendable[Server] { return v }
+	if !options.keepDuplicateServers {
+		servers = dedupServers
+	}
+	security := func(v []SecurityRequirement) []SecurityRequirement { return v }
+	if !options.keepSecurityOrder {
+		security = sortSecurityRequirements
+	}
+
+	if !options.keepPropertyOrder {
+		normalizePropertyOrder(spec)
+	}
+
+	spec.Spec.Servers = servers(spec.Spec.Servers)
+	spec.Spec.Security = security(spec.Spec.Security)
+	if spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	for _, item := range spec.Spec.Paths.Spec.Paths {
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			continue
+		}
+		item.Spec.Spec.Servers = servers(item.Spec.Spec.Servers)
+		for _, method := range pathItemMethods {
+			if op := item.Spec.Spec.operation(method); op != nil && op.Spec != nil {
+				op.Spec.Servers = servers(op.Spec.Servers)
+				op.Spec.Security = security(op.Spec.Security)
+			}
+		}
+	}
+}
+
+func tagName(t *Extendable[Tag]) string {
+	if t == nil || t.Spec == nil {
+		return ""
+	}
+	return t.Spec.Name
+}
+
+// dedupServers removes the servers having the same JSON representation as one of the previous servers.
+func dedupServers(servers []*Extendable[Server]) []*Extendable[Server] {
+	seen := make(map[string]bool, len(servers))
+	return slices.DeleteFunc(servers, func(s *Extendable[Server]) bool {
+		data, err := json.Marshal(s)
+		if err != nil {
+			return false
+		}
+		if seen[string(data)] {
+			return true
+		}
+		seen[string(data)] = true
+		return false
+	})
+}
+
+// sortSecurityRequirements sorts the scopes of each requirement and the requirements by the sorted names of the schemes.
+// The empty requirement, which makes the security optional, is kept as is and goes first.
+func sortSecurityRequirements(security []SecurityRequirement) []SecurityRequirement {
+	for _, req := range security {
+		for _, scopes := range req {
+			slices.Sort(scopes)
+		}
+	}
+	slices.SortStableFunc(security, func(a, b SecurityRequirement) int {
+		return cmp.Compare(securityRequirementKey(a), securityRequirementKey(b))
+	})
+	return security
+}
+
+func securityRequirementKey(req SecurityRequirement) string {
+	names := make([]string, 0, len(req))
+	for name := range req {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, "\x00")
+}
@@ -0,0 +1,100 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testNormalizeSpec = `
+openapi: 3.1.1
+info:
+  title: Normalize
+  version: 1.0.0
+tags:
+  - name: users
+  - name: orders
+    description: first
+  - name: orders
+    description: second
+servers:
+  - url: https://a.example.com
+  - url: https://b.example.com
+  - url: https://a.example.com
+security:
+  - oauth: [write, read]
+  - apiKey: []
+  - {}
+paths:
+  /orders:
+    servers:
+      - url: https://c.example.com
+      - url: https://c.example.com
+    get:
+      security:
+        - oauth: [b, a]
+          apiKey: []
+        - basic: []
+      responses:
+        '200':
+          description: OK
+`
+
+func TestNormalize(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     []openapi.NormalizeOption
+		tags     []string
+		servers  []string
+		security []openapi.SecurityRequirement
+		opSec    []openapi.SecurityRequirement
+		pathSrv  int
+	}{
+		{
+			name:     "default",
+			tags:     []string{"orders", "orders", "users"},
+			servers:  []string{"https://a.example.com", "https://b.example.com"},
+			security: []openapi.SecurityRequirement{{}, {"apiKey": {}}, {"oauth": {"read", "write"}}},
+			opSec:    []openapi.SecurityRequirement{{"apiKey": {}, "oauth": {"a", "b"}}, {"basic": {}}},
+			pathSrv:  1,
+		},
+		{
+			name:     "opt out",
+			opts:     []openapi.NormalizeOption{openapi.KeepTagsOrder(), openapi.KeepDuplicateServers(), openapi.KeepSecurityOrder()},
+			tags:     []string{"users", "orders", "orders"},
+			servers:  []string{"https://a.example.com", "https://b.example.com", "https://a.example.com"},
+			security: []openapi.SecurityRequirement{{"oauth": {"write", "read"}}, {"apiKey": {}}, {}},
+			opSec:    []openapi.SecurityRequirement{{"apiKey": {}, "oauth": {"b", "a"}}, {"basic": {}}},
+			pathSrv:  2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(testNormalizeSpec), &spec))
+
+			openapi.Normalize(spec, tt.opts...)
+
+			var tags []string
+			for _, tag := range spec.Spec.Tags {
+				tags = append(tags, tag.Spec.Name)
+			}
+			require.Equal(t, tt.tags, tags)
+			if tt.opts == nil {
+				// stable order of the tags with the same name
+				require.Equal(t, "first", spec.Spec.Tags[0].Spec.Description)
+			}
+			var servers []string
+			for _, s := range spec.Spec.Servers {
+				servers = append(servers, s.Spec.URL)
+			}
+			require.Equal(t, tt.servers, servers)
+			require.Equal(t, tt.security, spec.Spec.Security)
+			item := spec.Spec.Paths.Spec.Paths["/orders"].Spec.Spec
+			require.Len(t, item.Servers, tt.pathSrv)
+			require.Equal(t, tt.opSec, item.Get.Spec.Security)
+		})
+	}
+}
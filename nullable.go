@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"slices"
+	"strings"
+)
+
+// NullableProperty is a property of a component schema which permits null values.
+type NullableProperty struct {
+	// Path is the path of the property in the component, the names of the nested properties are separated by dots
+	// and the items of the arrays are marked by `[]`, e.g. `owner.tags[]`.
+	Path string `json:"path" yaml:"path"`
+	// Location is the location of the schema of the property, e.g. `/components/schemas/Pet/properties/owner`.
+	Location string `json:"location" yaml:"location"`
+	// Keyword is the keyword permitting null: `type`, `enum`, `oneOf` or `anyOf`;
+	// the keyword of the referenced schema is reported for the references.
+	Keyword string `json:"keyword" yaml:"keyword"`
+}
+
+// NullableProperties returns the properties of the component schemas permitting null values,
+// e.g. to review the handling of null values by the clients.
+// The result is grouped by the names of the components, the components without such properties are omitted,
+// and the properties are sorted by path.
+//
+// A schema permits null if it declares it explicitly: `null` in `type` or `enum`,
+// or a branch of `oneOf` or `anyOf` declaring null, including through the references,
+// and neither it nor its `allOf` branches exclude null by `type`, `enum` or `const`.
+// The schemas without any constraints, e.g. `{}`, permit null too, but they are not reported.
+// The properties of the inline schemas are inspected recursively, including the items of the arrays
+// and the branches of the compositions, while the referenced components are reported separately.
+func NullableProperties(spec *Extendable[OpenAPI]) map[string][]*NullableProperty {
+	result := make(map[string][]*NullableProperty)
+	if spec == nil || spec.Spec == nil || spec.Spec.Components == nil || spec.Spec.Components.Spec == nil {
+		return result
+	}
+	components := spec.Spec.Components
+	for _, name := range sortedKeys(components.Spec.Schemas) {
+		var props []*NullableProperty
+		walkNullable(components.Spec.Schemas[name], components, joinLoc("/components/schemas", name), "",
+			func(p *NullableProperty) {
+				props = append(props, p)
+			})
+		if len(props) > 0 {
+			slices.SortStableFunc(props, func(a, b *NullableProperty) int {
+				return strings.Compare(a.Path, b.Path)
+			})
+			result[name] = props
+		}
+	}
+	return result
+}
+
+// walkNullable reports the nullable properties of the inline schema and its inline sub schemas.
+func walkNullable(ref *RefOrSpec[Schema], components *Extendable[Components], location, path string, report func(*NullableProperty)) {
+	if ref == nil || ref.Spec == nil || ref.Spec.Bool != nil {
+		return
+	}
+	s := ref.Spec
+	for _, name := range sortedKeys(s.Properties) {
+		prop := s.Properties[name]
+		propLocation, propPath := joinLoc(location, "properties", name), name
+		if path != "" {
+			propPath = path + "." + name
+		}
+		if keyword := nullableKeyword(prop, components, make(map[*Schema]bool)); keyword != "" {
+			report(&NullableProperty{Path: propPath, Location: propLocation, Keyword: keyword})
+		}
+		walkNullable(prop, components, propLocation, propPath, report)
+	}
+	if s.Items != nil {
+		walkNullable(s.Items.Schema, components, joinLoc(location, "items"), path+"[]", report)
+	}
+	for i, item := range s.PrefixItems {
+		walkNullable(item, components, joinLoc(location, "prefixItems", i), path+"[]", report)
+	}
+	for _, c := range []struct {
+		keyword  string
+		branches []*RefOrSpec[Schema]
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}} {
+		for i, branch := range c.branches {
+			walkNullable(branch, components, joinLoc(location, c.keyword, i), path, report)
+		}
+	}
+}
+
+// nullableKeyword returns the keyword permitting null values by the schema, an empty string if null is not permitted.
+func nullableKeyword(ref *RefOrSpec[Schema], components *Extendable[Components], path map[*Schema]bool) string {
+	keyword, excluded := nullability(ref, components, path)
+	if excluded {
+		return ""
+	}
+	return keyword
+}
+
+// nullability returns the keyword declaring null explicitly and whether null is excluded by the schema.
+// The path contains the schemas being inspected to stop at the recursive references.
+func nullability(ref *RefOrSpec[Schema], components *Extendable[Components], path map[*Schema]bool) (keyword string, excluded bool) {
+	if ref == nil {
+		return "", false
+	}
+	s, err := ref.GetSpec(components)
+	if err != nil || s == nil || path[s] {
+		return "", false
+	}
+	if s.Bool != nil {
+		return "", !*s.Bool
+	}
+	path[s] = true
+	defer delete(path, s)
+
+	if types := schemaTypes(s); len(types) > 0 {
+		if !slices.Contains(types, NullType) {
+			return "", true
+		}
+		keyword = "type"
+	}
+	if s.Const != nil {
+		return "", true
+	}
+	if s.Enum != nil {
+		hasNull := slices.ContainsFunc(s.Enum, func(v any) bool { return v == nil })
+		if !hasNull {
+			return "", true
+		}
+		if keyword == "" {
+			keyword = "enum"
+		}
+	}
+	for _, branch := range schemaAllOf(s) {
+		k, ex := nullability(branch, components, path)
+		if ex {
+			return "", true
+		}
+		if keyword == "" {
+			keyword = k
+		}
+	}
+	for _, c := range []struct {
+		keyword  string
+		branches []*RefOrSpec[Schema]
+	}{{"oneOf", s.OneOf}, {"anyOf", s.AnyOf}} {
+		if len(c.branches) == 0 {
+			continue
+		}
+		declared, allExcluded := false, true
+		for _, branch := range c.branches {
+			k, ex := nullability(branch, components, path)
+			if !ex {
+				allExcluded = false
+				declared = declared || k != ""
+			}
+		}
+		if allExcluded {
+			return "", true
+		}
+		if declared && keyword == "" {
+			keyword = c.keyword
+		}
+	}
+	return keyword, false
+}
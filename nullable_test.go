@@ -0,0 +1,83 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testNullableSpec = `
+openapi: 3.1.1
+info:
+  title: Nullable
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        nickname:
+          type: [string, 'null']
+        status:
+          enum: [active, null]
+        owner:
+          oneOf:
+            - $ref: '#/components/schemas/Owner'
+            - type: 'null'
+        tag:
+          $ref: '#/components/schemas/Tag'
+        color:
+          anyOf:
+            - type: string
+            - type: integer
+        size:
+          allOf:
+            - $ref: '#/components/schemas/Tag'
+            - type: string
+        toys:
+          type: array
+          items:
+            type: object
+            properties:
+              name:
+                type: [string, 'null']
+        any: {}
+    Owner:
+      type: object
+      properties:
+        address:
+          type: object
+          properties:
+            street:
+              anyOf:
+                - type: string
+                - type: 'null'
+    Tag:
+      type: [string, 'null']
+    Code:
+      type: string
+`
+
+func TestNullableProperties(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testNullableSpec), &spec))
+
+	const pet = "/components/schemas/Pet/properties/"
+	require.Equal(t, map[string][]*openapi.NullableProperty{
+		"Owner": {
+			{Path: "address.street", Location: "/components/schemas/Owner/properties/address/properties/street", Keyword: "anyOf"},
+		},
+		"Pet": {
+			{Path: "nickname", Location: pet + "nickname", Keyword: "type"},
+			{Path: "owner", Location: pet + "owner", Keyword: "oneOf"},
+			{Path: "status", Location: pet + "status", Keyword: "enum"},
+			{Path: "tag", Location: pet + "tag", Keyword: "type"},
+			{Path: "toys[].name", Location: pet + "toys/items/properties/name", Keyword: "type"},
+		},
+	}, openapi.NullableProperties(spec))
+}
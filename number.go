@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Number is a JSON number kept as its text, so the big integers, like 9223372036854775807,
+// and the decimal fractions are not rounded by the conversion to float64.
+// It is used by the numeric keywords of Schema: multipleOf, minimum, exclusiveMinimum, maximum, and exclusiveMaximum.
+type Number string
+
+// NewNumber creates a number from the given integer or float value.
+func NewNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](v T) Number {
+	switch t := any(v).(type) {
+	case float32:
+		return Number(strconv.FormatFloat(float64(t), 'g', -1, 32))
+	case float64:
+		return Number(strconv.FormatFloat(t, 'g', -1, 64))
+	}
+	return Number(fmt.Sprint(v))
+}
+
+// IntNumber creates a number from the given integer, e.g. `NewSchemaBuilder().Minimum(IntNumber(0))`.
+func IntNumber(v int64) Number {
+	return Number(strconv.FormatInt(v, 10))
+}
+
+// FloatNumber creates a number from the given float, e.g. `NewSchemaBuilder().MultipleOf(FloatNumber(0.01))`.
+func FloatNumber(v float64) Number {
+	return Number(strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+// ParseNumber validates that the given text is a JSON number and returns it as Number.
+func ParseNumber(s string) (Number, error) {
+	// json.Valid accepts the whitespaces around the value, a JSON number starts with a minus or a digit and ends with a digit
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || s[len(s)-1] < '0' || s[len(s)-1] > '9' || !json.Valid([]byte(s)) {
+		return "", fmt.Errorf("%w: not a number: %q", ErrInvalidFormat, s)
+	}
+	return Number(s), nil
+}
+
+// String implements fmt.Stringer interface.
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 returns the number as an integer, an error is returned if the number is not an integer or is out of range.
+func (n Number) Int64() (int64, error) {
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Float64 returns the number as a float, the value may be rounded.
+func (n Number) Float64() (float64, error) {
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// Rat returns the exact value of the number.
+func (n Number) Rat() (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(n))
+}
+
+// Cmp compares the numbers exactly and returns -1, 0, or +1, the invalid numbers are compared as zeros.
+func (n Number) Cmp(other Number) int {
+	a, ok := n.Rat()
+	if !ok {
+		a = new(big.Rat)
+	}
+	b, ok := other.Rat()
+	if !ok {
+		b = new(big.Rat)
+	}
+	return a.Cmp(b)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (n Number) MarshalJSON() ([]byte, error) {
+	if _, err := ParseNumber(string(n)); err != nil {
+		return nil, err
+	}
+	return []byte(n), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	v, err := ParseNumber(string(data))
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (n Number) MarshalYAML() (any, error) {
+	if _, err := ParseNumber(string(n)); err != nil {
+		return nil, err
+	}
+	return numberNode(string(n)), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (n *Number) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode || (node.ShortTag() != "!!int" && node.ShortTag() != "!!float") {
+		return fmt.Errorf("%w: not a number: %q", ErrInvalidFormat, node.Value)
+	}
+	// YAML allows the forms not valid in JSON, like `0x1F` or `1_000`, they are converted using the decoded value
+	v, err := ParseNumber(node.Value)
+	if err != nil {
+		var d any
+		if err := node.Decode(&d); err != nil {
+			return err
+		}
+		data, err := json.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("%w: not a number: %q", ErrInvalidFormat, node.Value)
+		}
+		v = Number(data)
+	}
+	*n = v
+	return nil
+}
+
+func numberNode(s string) *yaml.Node {
+	// no tag, so the number is written as a plain scalar even if YAML resolves it as float, like big integers
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: s}
+}
+
+// yamlNumbers returns the copy of the given raw value with json.Number values replaced by the YAML number nodes,
+// so the numbers decoded from JSON are not marshaled as strings.
+func yamlNumbers(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		return numberNode(t.String())
+	case []any:
+		if t == nil {
+			return t
+		}
+		items := make([]any, len(t))
+		for i, item := range t {
+			items[i] = yamlNumbers(item)
+		}
+		return items
+	case map[string]any:
+		if t == nil {
+			return t
+		}
+		m := make(map[string]any, len(t))
+		for k, item := range t {
+			m[k] = yamlNumbers(item)
+		}
+		return m
+	}
+	return v
+}
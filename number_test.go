@@ -0,0 +1,123 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestSchema_NumberFidelity(t *testing.T) {
+	const jsonSchema = `{
+		"type": "integer",
+		"minimum": -9223372036854775808,
+		"maximum": 9223372036854775807,
+		"multipleOf": 0.01,
+		"enum": [9007199254740993, 123456789012345678901234567890],
+		"const": 9007199254740993,
+		"default": 9007199254740993
+	}`
+	const yamlSchema = `const: 9007199254740993
+default: 9007199254740993
+enum:
+    - 9007199254740993
+    - 123456789012345678901234567890
+maximum: 9223372036854775807
+minimum: -9223372036854775808
+multipleOf: 0.01
+type: integer
+`
+
+	t.Run("json", func(t *testing.T) {
+		var s openapi.Schema
+		require.NoError(t, json.Unmarshal([]byte(jsonSchema), &s))
+		require.Equal(t, openapi.Number("9223372036854775807"), *s.Maximum)
+		require.Equal(t, openapi.Number("0.01"), *s.MultipleOf)
+		require.Equal(t, json.Number("9007199254740993"), s.Const)
+
+		data, err := json.Marshal(&s)
+		require.NoError(t, err)
+		require.JSONEq(t, jsonSchema, string(data))
+
+		data, err = yaml.Marshal(&s)
+		require.NoError(t, err)
+		require.Equal(t, yamlSchema, string(data))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var s openapi.Schema
+		require.NoError(t, yaml.Unmarshal([]byte(yamlSchema), &s))
+		require.Equal(t, openapi.Number("-9223372036854775808"), *s.Minimum)
+
+		data, err := json.Marshal(&s)
+		require.NoError(t, err)
+		// YAML decodes the integers greater than uint64 as floats
+		require.JSONEq(t, `{
+			"type": "integer",
+			"minimum": -9223372036854775808,
+			"maximum": 9223372036854775807,
+			"multipleOf": 0.01,
+			"enum": [9007199254740993, 1.2345678901234568e+29],
+			"const": 9007199254740993,
+			"default": 9007199254740993
+		}`, string(data))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var s openapi.Schema
+		require.ErrorIs(t, json.Unmarshal([]byte(`{"minimum": "1"}`), &s), openapi.ErrInvalidFormat)
+		require.ErrorIs(t, yaml.Unmarshal([]byte(`minimum: one`), &s), openapi.ErrInvalidFormat)
+		require.NoError(t, yaml.Unmarshal([]byte(`minimum: 0x10`), &s))
+		require.Equal(t, openapi.Number("16"), *s.Minimum)
+		_, err := json.Marshal(openapi.Number("1,5"))
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	})
+}
+
+func TestNumber(t *testing.T) {
+	require.Equal(t, openapi.Number("42"), openapi.NewNumber(42))
+	require.Equal(t, openapi.Number("18446744073709551615"), openapi.NewNumber(uint64(18446744073709551615)))
+	require.Equal(t, openapi.Number("0.1"), openapi.NewNumber(0.1))
+	require.Equal(t, openapi.Number("0.1"), openapi.NewNumber(float32(0.1)))
+	require.Equal(t, openapi.Number("-9223372036854775808"), openapi.IntNumber(-9223372036854775808))
+	require.Equal(t, openapi.Number("0.01"), openapi.FloatNumber(0.01))
+	require.Equal(t, openapi.Number("1e+21"), openapi.FloatNumber(1e21))
+
+	schema := openapi.NewSchemaBuilder().Minimum(openapi.IntNumber(0)).MultipleOf(openapi.FloatNumber(0.5)).Build()
+	require.Equal(t, openapi.Number("0"), *schema.Spec.Minimum)
+	require.Equal(t, openapi.Number("0.5"), *schema.Spec.MultipleOf)
+
+	require.Equal(t, -1, openapi.Number("9223372036854775806").Cmp("9223372036854775807"))
+	require.Equal(t, 0, openapi.Number("1.50").Cmp("1.5"))
+	require.Equal(t, 1, openapi.Number("1e3").Cmp("999.9"))
+
+	i, err := openapi.Number("9223372036854775807").Int64()
+	require.NoError(t, err)
+	require.Equal(t, int64(9223372036854775807), i)
+	_, err = openapi.Number("1.5").Int64()
+	require.Error(t, err)
+	f, err := openapi.Number("1.5").Float64()
+	require.NoError(t, err)
+	require.Equal(t, 1.5, f)
+
+	for _, s := range []string{"", "-", "+1", "01", "1.", "NaN", `"1"`, " 1", "1 ", "1\n", "1_0", "0x10", "Inf"} {
+		_, err := openapi.ParseNumber(s)
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat, s)
+	}
+}
+
+func TestValidator_ValidateData_BigIntegers(t *testing.T) {
+	components := openapi.NewComponents()
+	components.Spec.Add("ID", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Minimum("9223372036854775806").Build())
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Numbers").Version("1.0.0").Build()).
+		Components(components).
+		Build()
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateData("#/components/schemas/ID", json.Number("9223372036854775807")))
+	require.Error(t, validator.ValidateData("#/components/schemas/ID", json.Number("9223372036854775805")))
+}
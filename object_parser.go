@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type parseOptions struct {
+	components *Extendable[Components]
+	tagName    string
+}
+
+// ParseOption is a type for the options of ParseObject.
+type ParseOption func(*parseOptions)
+
+// ParseWithComponents is a parse option to add the schemas of the named struct types into the given components
+// and to reference them using `$ref`, instead of inlining them.
+// It is required to parse the recursive types.
+func ParseWithComponents(components *Extendable[Components]) ParseOption {
+	return func(o *parseOptions) {
+		o.components = components
+	}
+}
+
+// ParseTagName is a parse option to set the name of the struct tag used for the names of the properties,
+// the default is `json`.
+func ParseTagName(name string) ParseOption {
+	return func(o *parseOptions) {
+		o.tagName = name
+	}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ParseObject creates a Schema of the given Go value using reflection, following the rules of encoding/json:
+//
+//   - the names of the properties are taken from the `json` tags, the fields with `json:"-"` and unexported fields are skipped;
+//   - the fields of the embedded structs are promoted;
+//   - the fields without `omitempty` and not pointers are required;
+//   - the slices and arrays are arrays, the maps are objects with additionalProperties, []byte is a base64 string;
+//   - time.Time is a string with date-time format, the types implementing encoding.TextMarshaler are strings.
+//
+// A value of a pointer type or reflect.Type can be used as well, e.g. `(*Pet)(nil)`.
+func ParseObject(obj any, opts ...ParseOption) (*RefOrSpec[Schema], error) {
+	options := &parseOptions{tagName: "json"}
+	for _, opt := range opts {
+		opt(options)
+	}
+	t, ok := obj.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(obj)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("%w: unable to parse nil object", ErrInvalidValue)
+	}
+	p := &objectParser{opts: options, inProgress: make(map[reflect.Type]bool)}
+	return p.parse(t)
+}
+
+// parseComponent parses the given object and stores its schema in the components using the given name.
+// The named struct types used by the object are stored in the components as well.
+func parseComponent(components *Extendable[Components], name string, obj any, opts ...ParseOption) (*RefOrSpec[Schema], error) {
+	options := &parseOptions{tagName: "json"}
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.components = components
+	t, ok := obj.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(obj)
+	}
+	if t == nil {
+		return nil, fmt.Errorf("%w: unable to parse nil object", ErrInvalidValue)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	p := &objectParser{opts: options, inProgress: make(map[reflect.Type]bool), root: t, rootName: name}
+	schema, err := p.parse(t)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Ref == nil {
+		// not a struct
+		components.Spec.Add(name, schema)
+	}
+	return NewRefOrSpec[Schema]("#/components/schemas/" + name), nil
+}
+
+type objectParser struct {
+	opts       *parseOptions
+	inProgress map[reflect.Type]bool
+	// root is the type stored in the components using rootName instead of the name of the type
+	root     reflect.Type
+	rootName string
+}
+
+func (p *objectParser) parse(t reflect.Type) (*RefOrSpec[Schema], error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(StringType), Format: DateTimeFormat}), nil
+	case t == rawMessageType:
+		return NewRefOrSpec[Schema](&Schema{}), nil
+	case t.Kind() != reflect.String && reflect.PointerTo(t).Implements(textMarshalerType):
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(StringType)}), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(BooleanType)}), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		schema := &Schema{Type: NewSingleOrArray(IntegerType)}
+		switch t.Kind() {
+		case reflect.Int32:
+			schema.Format = Int32Format
+		case reflect.Int64:
+			schema.Format = Int64Format
+		}
+		return NewRefOrSpec[Schema](schema), nil
+	case reflect.Float32:
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(NumberType), Format: FloatFormat}), nil
+	case reflect.Float64:
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(NumberType), Format: DoubleFormat}), nil
+	case reflect.String:
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(StringType)}), nil
+	case reflect.Interface:
+		return NewRefOrSpec[Schema](&Schema{}), nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(StringType), ContentEncoding: Base64Encoding}), nil
+		}
+		items, err := p.parse(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := &Schema{Type: NewSingleOrArray(ArrayType), Items: NewBoolOrSchema(items)}
+		if t.Kind() == reflect.Array {
+			l := t.Len()
+			schema.MinItems, schema.MaxItems = &l, &l
+		}
+		return NewRefOrSpec[Schema](schema), nil
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			if !reflect.PointerTo(t.Key()).Implements(textMarshalerType) {
+				return nil, fmt.Errorf("%w: unsupported map key type %s", ErrInvalidValue, t.Key())
+			}
+		}
+		values, err := p.parse(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return NewRefOrSpec[Schema](&Schema{Type: NewSingleOrArray(ObjectType), AdditionalProperties: NewBoolOrSchema(values)}), nil
+	case reflect.Struct:
+		return p.parseStruct(t)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type %s", ErrInvalidValue, t)
+	}
+}
+
+// parseStruct creates the schema of the struct, the named structs are added to the components if they are given.
+func (p *objectParser) parseStruct(t reflect.Type) (*RefOrSpec[Schema], error) {
+	name := t.Name()
+	isRoot := t == p.root && p.rootName != ""
+	if isRoot {
+		name = p.rootName
+	}
+	useComponents := p.opts.components != nil && name != ""
+	if useComponents {
+		ref := NewRefOrSpec[Schema]("#/components/schemas/" + name)
+		if p.inProgress[t] {
+			return ref, nil
+		}
+		if existing, ok := p.opts.components.Spec.Schemas[name]; ok && existing != nil && !isRoot {
+			return ref, nil
+		}
+	} else if p.inProgress[t] {
+		return nil, fmt.Errorf("%w: recursive type %s requires ParseWithComponents option", ErrInvalidValue, t)
+	}
+	p.inProgress[t] = true
+	defer delete(p.inProgress, t)
+
+	schema := &Schema{Type: NewSingleOrArray(ObjectType)}
+	if err := p.addFields(schema, t); err != nil {
+		return nil, err
+	}
+	if !useComponents {
+		return NewRefOrSpec[Schema](schema), nil
+	}
+	p.opts.components.Spec.Add(name, NewRefOrSpec[Schema](schema))
+	return NewRefOrSpec[Schema]("#/components/schemas/" + name), nil
+}
+
+func (p *objectParser) addFields(schema *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get(p.opts.tagName)
+		if tag == "-" {
+			continue
+		}
+		name, tagOpts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := p.addFields(schema, ft); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop, err := p.parse(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		if strings.Contains(","+tagOpts+",", ",string,") && prop.Spec != nil && prop.Spec.Type != nil {
+			prop.Spec.Type = NewSingleOrArray(StringType)
+			prop.Spec.Format = ""
+		}
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*RefOrSpec[Schema])
+		}
+		schema.Properties[name] = prop
+		if f.Type.Kind() != reflect.Pointer && !strings.Contains(","+tagOpts+",", ",omitempty,") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
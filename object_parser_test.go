@@ -0,0 +1,139 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+type testOwner struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+type testBase struct {
+	ID int64 `json:"id"`
+}
+
+type testPet struct {
+	testBase
+	Name     string            `json:"name"`
+	Tag      *string           `json:"tag,omitempty"`
+	Owner    *testOwner        `json:"owner,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Meta     map[string]any    `json:"meta,omitempty"`
+	Created  time.Time         `json:"created"`
+	Weight   float64           `json:"weight,string"`
+	Data     []byte            `json:"data,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Labels   map[string]string `json:"-"`
+	internal string
+}
+
+type testNode struct {
+	Value    int         `json:"value"`
+	Children []*testNode `json:"children,omitempty"`
+}
+
+func TestParseObject(t *testing.T) {
+	t.Run("inline", func(t *testing.T) {
+		schema, err := openapi.ParseObject(testPet{})
+		require.NoError(t, err)
+		data, err := json.Marshal(schema)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "format": "int64"},
+				"name": {"type": "string"},
+				"tag": {"type": "string"},
+				"owner": {
+					"type": "object",
+					"properties": {"name": {"type": "string"}, "email": {"type": "string"}},
+					"required": ["name"]
+				},
+				"tags": {"type": "array", "items": {"type": "string"}},
+				"meta": {"type": "object", "additionalProperties": {}},
+				"created": {"type": "string", "format": "date-time"},
+				"weight": {"type": "string"},
+				"data": {"type": "string", "contentEncoding": "base64"},
+				"raw": {}
+			},
+			"required": ["id", "name", "created", "weight"]
+		}`, string(data))
+	})
+
+	t.Run("pointer and scalar", func(t *testing.T) {
+		schema, err := openapi.ParseObject((*int32)(nil))
+		require.NoError(t, err)
+		require.Equal(t, openapi.Int32Format, schema.Spec.Format)
+	})
+
+	t.Run("recursive without components", func(t *testing.T) {
+		_, err := openapi.ParseObject(testNode{})
+		require.ErrorIs(t, err, openapi.ErrInvalidValue)
+		require.ErrorContains(t, err, "requires ParseWithComponents")
+	})
+
+	t.Run("with components", func(t *testing.T) {
+		components := openapi.NewComponents()
+		schema, err := openapi.ParseObject(&testNode{}, openapi.ParseWithComponents(components))
+		require.NoError(t, err)
+		require.Equal(t, "#/components/schemas/testNode", schema.Ref.Ref)
+		node := components.Spec.Schemas["testNode"]
+		require.NotNil(t, node)
+		require.Equal(t, "#/components/schemas/testNode", node.Spec.Properties["children"].Spec.Items.Schema.Ref.Ref)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := openapi.ParseObject(struct {
+			C chan int `json:"c"`
+		}{})
+		require.ErrorContains(t, err, "unsupported type chan int")
+		_, err = openapi.ParseObject(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestBuilder_SchemaFor(t *testing.T) {
+	op := openapi.NewOperationBuilder().
+		OperationID("addPet").
+		JSONRequestFrom(testOwner{}).
+		JSONResponseFrom("201", testOwner{}).
+		JSONResponseFrom("default", map[string]string{}).
+		Build()
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Pets").Version("1.0.0").Build()).
+		AddSchemaFor("Pet", testPet{}).
+		AddSchemaFor("Tree", testNode{}).
+		AddSchemaFor("IDs", []int{}).
+		AddPath("/owners", openapi.NewPathItemBuilder().Post(op).Build()).
+		Build()
+
+	schemas := spec.Spec.Components.Spec.Schemas
+	require.Contains(t, schemas, "Pet")
+	require.Contains(t, schemas, "testOwner")
+	require.Contains(t, schemas, "Tree")
+	require.Equal(t, "#/components/schemas/Tree", schemas["Tree"].Spec.Properties["children"].Spec.Items.Schema.Ref.Ref)
+	require.Equal(t, "Created", op.Spec.Responses.Spec.Response["201"].Spec.Spec.Description)
+
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.NoError(t, validator.ValidateData("/components/schemas/Pet", map[string]any{
+		"id": 1, "name": "Rex", "created": "2024-01-01T00:00:00Z", "weight": "1.5",
+		"owner": map[string]any{"name": "Tom"},
+	}))
+	require.Error(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"id": 1}))
+	require.NoError(t, validator.ValidateData("/components/schemas/Tree", map[string]any{
+		"value": 1, "children": []any{map[string]any{"value": 2}},
+	}))
+
+	require.Panics(t, func() {
+		openapi.NewOpenAPIBuilder().AddSchemaFor("Func", func() {})
+	})
+}
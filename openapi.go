@@ -76,7 +76,7 @@ func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validat
 		errs = append(errs, newValidationError(joinLoc(location, "openapi"), ErrRequired))
 	} else {
 		if !strings.HasPrefix(o.OpenAPI, "3.1.") {
-			errs = append(errs, newValidationError(joinLoc(location, "openapi"), fmt.Errorf("unsupported version: %s", o.OpenAPI)))
+			errs = append(errs, newValidationError(joinLoc(location, "openapi"), fmt.Errorf("%w: %s", ErrUnsupportedVersion, o.OpenAPI)))
 		}
 	}
 	if o.Info == nil {
@@ -144,16 +144,12 @@ func (o *OpenAPI) validateSpec(location string, validator *Validator) []*validat
 
 	for k, v := range validator.linkToOperationID {
 		if !validator.visited[joinLoc("operations", v)] {
-			errs = append(errs, newValidationError(k, "'%s' not found", v))
+			errs = append(errs, newValidationError(k, "%w to operation '%s'", ErrUnresolvedRef, v))
 		}
 	}
 	return errs
 }
 
-type OpenAPIBuilder struct {
-	spec *Extendable[OpenAPI]
-}
-
 func NewOpenAPIBuilder() *OpenAPIBuilder {
 	return &OpenAPIBuilder{spec: NewExtendable(&OpenAPI{
 		OpenAPI:           "3.1.1",
@@ -161,35 +157,6 @@ func NewOpenAPIBuilder() *OpenAPIBuilder {
 	})}
 }
 
-func (b *OpenAPIBuilder) Build() *Extendable[OpenAPI] {
-	return b.spec
-}
-
-func (b *OpenAPIBuilder) Extensions(v map[string]any) *OpenAPIBuilder {
-	b.spec.Extensions = v
-	return b
-}
-
-func (b *OpenAPIBuilder) AddExt(name string, value any) *OpenAPIBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *OpenAPIBuilder) OpenAPI(openAPI string) *OpenAPIBuilder {
-	b.spec.Spec.OpenAPI = openAPI
-	return b
-}
-
-func (b *OpenAPIBuilder) Info(info *Extendable[Info]) *OpenAPIBuilder {
-	b.spec.Spec.Info = info
-	return b
-}
-
-func (b *OpenAPIBuilder) Components(components *Extendable[Components]) *OpenAPIBuilder {
-	b.spec.Spec.Components = components
-	return b
-}
-
 func (b *OpenAPIBuilder) AddComponent(name string, component any) *OpenAPIBuilder {
 	if b.spec.Spec.Components == nil {
 		b.spec.Spec.Components = NewComponents()
@@ -198,16 +165,6 @@ func (b *OpenAPIBuilder) AddComponent(name string, component any) *OpenAPIBuilde
 	return b
 }
 
-func (b *OpenAPIBuilder) ExternalDocs(externalDocs *Extendable[ExternalDocs]) *OpenAPIBuilder {
-	b.spec.Spec.ExternalDocs = externalDocs
-	return b
-}
-
-func (b *OpenAPIBuilder) Paths(paths *Extendable[Paths]) *OpenAPIBuilder {
-	b.spec.Spec.Paths = paths
-	return b
-}
-
 func (b *OpenAPIBuilder) AddPath(path string, item *RefOrSpec[Extendable[PathItem]]) *OpenAPIBuilder {
 	if b.spec.Spec.Paths == nil {
 		b.spec.Spec.Paths = NewPaths()
@@ -216,11 +173,6 @@ func (b *OpenAPIBuilder) AddPath(path string, item *RefOrSpec[Extendable[PathIte
 	return b
 }
 
-func (b *OpenAPIBuilder) WebHooks(webHooks Webhooks) *OpenAPIBuilder {
-	b.spec.Spec.WebHooks = webHooks
-	return b
-}
-
 func (b *OpenAPIBuilder) AddWebHook(name string, path *RefOrSpec[Extendable[PathItem]]) *OpenAPIBuilder {
 	if b.spec.Spec.WebHooks == nil {
 		b.spec.Spec.WebHooks = NewWebhooks()
@@ -229,37 +181,15 @@ func (b *OpenAPIBuilder) AddWebHook(name string, path *RefOrSpec[Extendable[Path
 	return b
 }
 
-func (b *OpenAPIBuilder) JsonSchemaDialect(jsonSchemaDialect string) *OpenAPIBuilder {
-	b.spec.Spec.JsonSchemaDialect = jsonSchemaDialect
-	return b
-}
-
-func (b *OpenAPIBuilder) Security(security ...SecurityRequirement) *OpenAPIBuilder {
-	b.spec.Spec.Security = security
-	return b
-}
-
-func (b *OpenAPIBuilder) AddSecurity(v ...SecurityRequirement) *OpenAPIBuilder {
-	b.spec.Spec.Security = append(b.spec.Spec.Security, v...)
-	return b
-}
-
-func (b *OpenAPIBuilder) Tags(tags ...*Extendable[Tag]) *OpenAPIBuilder {
-	b.spec.Spec.Tags = tags
-	return b
-}
-
-func (b *OpenAPIBuilder) AddTags(tags ...*Extendable[Tag]) *OpenAPIBuilder {
-	b.spec.Spec.Tags = append(b.spec.Spec.Tags, tags...)
-	return b
-}
-
-func (b *OpenAPIBuilder) Servers(servers ...*Extendable[Server]) *OpenAPIBuilder {
-	b.spec.Spec.Servers = servers
-	return b
-}
-
-func (b *OpenAPIBuilder) AddServers(servers ...*Extendable[Server]) *OpenAPIBuilder {
-	b.spec.Spec.Servers = append(b.spec.Spec.Servers, servers...)
+// AddSchemaFor parses the given object using ParseObject and adds its schema into the components using the given name.
+// The named struct types used by the object are added into the components as well and referenced using `$ref`.
+// The function panics if the object cannot be parsed, e.g. it contains a channel.
+func (b *OpenAPIBuilder) AddSchemaFor(name string, obj any, opts ...ParseOption) *OpenAPIBuilder {
+	if b.spec.Spec.Components == nil {
+		b.spec.Spec.Components = NewComponents()
+	}
+	if _, err := parseComponent(b.spec.Spec.Components, name, obj, opts...); err != nil {
+		panic(fmt.Sprintf("parsing schema %q failed: %v", name, err))
+	}
 	return b
 }
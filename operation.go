@@ -1,6 +1,9 @@
 package openapi
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -106,7 +109,7 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 	if o.OperationID != "" {
 		id := joinLoc("operations", o.OperationID)
 		if validator.visited[id] {
-			errs = append(errs, newValidationError(joinLoc(location, "operationId"), "'%s' is not unique", o.OperationID))
+			errs = append(errs, newValidationError(joinLoc(location, "operationId"), "%w operationId '%s'", ErrDuplicate, o.OperationID))
 		} else {
 			validator.visited[id] = true
 		}
@@ -117,11 +120,11 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 		errs = append(errs, o.RequestBody.validateSpec(nextLoc, validator)...)
 		switch {
 		case !validator.opts.allowRequestBodyForGet && strings.HasSuffix(location, "get"):
-			errs = append(errs, newValidationError(location, "not allowed for get"))
+			errs = append(errs, newValidationError(location, "%w for get", ErrNotAllowed))
 		case !validator.opts.allowRequestBodyForDelete && strings.HasSuffix(location, "delete"):
-			errs = append(errs, newValidationError(nextLoc, "not allowed for delete"))
+			errs = append(errs, newValidationError(nextLoc, "%w for delete", ErrNotAllowed))
 		case !validator.opts.allowRequestBodyForHead && strings.HasSuffix(location, "head"):
-			errs = append(errs, newValidationError(nextLoc, "not allowed for head"))
+			errs = append(errs, newValidationError(nextLoc, "%w for head", ErrNotAllowed))
 		}
 	}
 	if o.Responses != nil {
@@ -143,7 +146,7 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 	if o.Tags != nil {
 		for i, t := range o.Tags {
 			if !validator.opts.allowUndefinedTagsInOperation && !validator.visited[joinLoc("tags", t)] {
-				errs = append(errs, newValidationError(joinLoc(location, "tags", i), "'%s' not found", t))
+				errs = append(errs, newValidationError(joinLoc(location, "tags", i), "%w to tag '%s'", ErrUnresolvedRef, t))
 
 			}
 			validator.visited[joinLoc("tags", t, "used")] = true
@@ -163,109 +166,49 @@ func (o *Operation) validateSpec(location string, validator *Validator) []*valid
 	return errs
 }
 
-type OperationBuilder struct {
-	spec *Extendable[Operation]
-}
-
-func NewOperationBuilder() *OperationBuilder {
-	return &OperationBuilder{
-		spec: NewExtendable[Operation](&Operation{}),
-	}
-}
-
-func (b *OperationBuilder) Build() *Extendable[Operation] {
-	return b.spec
-}
-
-func (b *OperationBuilder) Extensions(v map[string]any) *OperationBuilder {
-	b.spec.Extensions = v
+// JSONRequestFrom sets the required request body with `application/json` content and the schema parsed
+// from the given object using ParseObject.
+// The function panics if the object cannot be parsed, e.g. it contains a channel.
+func (b *OperationBuilder) JSONRequestFrom(obj any, opts ...ParseOption) *OperationBuilder {
+	schema := mustParseObject(obj, opts...)
+	b.spec.Spec.RequestBody = NewRequestBodyBuilder().
+		Required(true).
+		AddContent("application/json", NewMediaTypeBuilder().Schema(schema).Build()).
+		Build()
 	return b
 }
 
-func (b *OperationBuilder) AddExt(name string, value any) *OperationBuilder {
-	b.spec.AddExt(name, value)
-	return b
-}
-
-func (b *OperationBuilder) RequestBody(v *RefOrSpec[Extendable[RequestBody]]) *OperationBuilder {
-	b.spec.Spec.RequestBody = v
-	return b
-}
-
-func (b *OperationBuilder) Callbacks(v map[string]*RefOrSpec[Extendable[Callback]]) *OperationBuilder {
-	b.spec.Spec.Callbacks = v
-	return b
-}
-
-func (b *OperationBuilder) AddCallback(name string, value *RefOrSpec[Extendable[Callback]]) *OperationBuilder {
-	if b.spec.Spec.Callbacks == nil {
-		b.spec.Spec.Callbacks = make(map[string]*RefOrSpec[Extendable[Callback]], 1)
+// JSONResponseFrom adds the response for the given code with `application/json` content and the schema parsed
+// from the given object using ParseObject.
+// The function panics if the object cannot be parsed, e.g. it contains a channel.
+func (b *OperationBuilder) JSONResponseFrom(code string, obj any, opts ...ParseOption) *OperationBuilder {
+	schema := mustParseObject(obj, opts...)
+	description := code + " response"
+	if i, err := strconv.Atoi(code); err == nil && http.StatusText(i) != "" {
+		description = http.StatusText(i)
 	}
-	b.spec.Spec.Callbacks[name] = value
-	return b
-}
-
-func (b *OperationBuilder) ExternalDocs(v *Extendable[ExternalDocs]) *OperationBuilder {
-	b.spec.Spec.ExternalDocs = v
-	return b
-}
-
-func (b *OperationBuilder) OperationID(v string) *OperationBuilder {
-	b.spec.Spec.OperationID = v
-	return b
-}
-
-func (b *OperationBuilder) Summary(v string) *OperationBuilder {
-	b.spec.Spec.Summary = v
-	return b
-}
-
-func (b *OperationBuilder) Description(v string) *OperationBuilder {
-	b.spec.Spec.Description = v
-	return b
-}
-
-func (b *OperationBuilder) Parameters(v ...*RefOrSpec[Extendable[Parameter]]) *OperationBuilder {
-	b.spec.Spec.Parameters = v
-	return b
-}
-
-func (b *OperationBuilder) AddParameters(v ...*RefOrSpec[Extendable[Parameter]]) *OperationBuilder {
-	b.spec.Spec.Parameters = append(b.spec.Spec.Parameters, v...)
-	return b
-}
-
-func (b *OperationBuilder) Tags(v ...string) *OperationBuilder {
-	b.spec.Spec.Tags = v
-	return b
-}
-
-func (b *OperationBuilder) AddTags(v ...string) *OperationBuilder {
-	b.spec.Spec.Tags = append(b.spec.Spec.Tags, v...)
-	return b
-}
-
-func (b *OperationBuilder) Security(v ...SecurityRequirement) *OperationBuilder {
-	b.spec.Spec.Security = v
-	return b
-}
-
-func (b *OperationBuilder) AddSecurity(v ...SecurityRequirement) *OperationBuilder {
-	b.spec.Spec.Security = append(b.spec.Spec.Security, v...)
-	return b
-}
-
-func (b *OperationBuilder) Servers(v ...*Extendable[Server]) *OperationBuilder {
-	b.spec.Spec.Servers = v
-	return b
-}
-
-func (b *OperationBuilder) AddServers(v ...*Extendable[Server]) *OperationBuilder {
-	b.spec.Spec.Servers = append(b.spec.Spec.Servers, v...)
+	response := NewResponseBuilder().
+		Description(description).
+		AddContent("application/json", NewMediaTypeBuilder().Schema(schema).Build()).
+		Build()
+	if b.spec.Spec.Responses == nil {
+		b.spec.Spec.Responses = NewExtendable(&Responses{})
+	}
+	if code == "default" {
+		b.spec.Spec.Responses.Spec.Default = response
+		return b
+	}
+	if b.spec.Spec.Responses.Spec.Response == nil {
+		b.spec.Spec.Responses.Spec.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+	}
+	b.spec.Spec.Responses.Spec.Response[code] = response
 	return b
 }
 
-func (b *OperationBuilder) Deprecated(v bool) *OperationBuilder {
-	b.spec.Spec.Deprecated = v
-	return b
+func mustParseObject(obj any, opts ...ParseOption) *RefOrSpec[Schema] {
+	schema, err := ParseObject(obj, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("parsing schema for %T failed: %v", obj, err))
+	}
+	return schema
 }
@@ -0,0 +1,347 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OverlayVersion is the version of the Overlay Specification supported by ParseOverlay and ApplyOverlay.
+const OverlayVersion = "1.0.0"
+
+var overlayVersionRe = regexp.MustCompile(`^1\.0\.\d+$`)
+
+// Overlay is a document with the ordered list of the actions updating or removing the parts of an OpenAPI spec,
+// e.g. to maintain the environment-specific changes, like the public documentation without the internal operations,
+// on top of a base spec.
+//
+// https://spec.openapis.org/overlay/v1.0.0.html
+//
+// Example:
+//
+//	overlay: 1.0.0
+//	info:
+//	  title: Public API
+//	  version: 1.0.0
+//	actions:
+//	  - target: $.paths.*[?@.x-internal == true]
+//	    remove: true
+//	  - target: $.info
+//	    update:
+//	      description: The public API of the pet store.
+type Overlay struct {
+	// REQUIRED.
+	// The version of the Overlay Specification that the document uses.
+	Overlay string `json:"overlay" yaml:"overlay"`
+	// REQUIRED.
+	// Provides metadata about the Overlay.
+	Info *Extendable[OverlayInfo] `json:"info" yaml:"info"`
+	// The URL of the spec the overlay is designed for.
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// REQUIRED.
+	// The ordered list of the actions to be applied to the target document, it MUST contain at least one action.
+	Actions []*Extendable[OverlayAction] `json:"actions" yaml:"actions"`
+}
+
+func (o *Overlay) validateSpec(location string, validator *Validator) []*validationError {
+	var errs []*validationError
+	switch {
+	case o.Overlay == "":
+		errs = append(errs, newValidationError(joinLoc(location, "overlay"), ErrRequired))
+	case !overlayVersionRe.MatchString(o.Overlay):
+		errs = append(errs, newValidationError(joinLoc(location, "overlay"), fmt.Errorf("%w: %s", ErrUnsupportedVersion, o.Overlay)))
+	}
+	if o.Info == nil {
+		errs = append(errs, newValidationError(joinLoc(location, "info"), ErrRequired))
+	} else {
+		errs = append(errs, o.Info.validateSpec(joinLoc(location, "info"), validator)...)
+	}
+	if len(o.Actions) == 0 {
+		errs = append(errs, newValidationError(joinLoc(location, "actions"), ErrRequired))
+	}
+	for i, action := range o.Actions {
+		if action == nil {
+			errs = append(errs, newValidationError(joinLoc(location, "actions", i), ErrRequired))
+			continue
+		}
+		errs = append(errs, action.validateSpec(joinLoc(location, "actions", i), validator)...)
+	}
+	return errs
+}
+
+// OverlayInfo provides metadata about the Overlay.
+type OverlayInfo struct {
+	// REQUIRED.
+	// A human readable description of the purpose of the overlay.
+	Title string `json:"title" yaml:"title"`
+	// REQUIRED.
+	// The version identifier of the overlay document.
+	Version string `json:"version" yaml:"version"`
+}
+
+func (o *OverlayInfo) validateSpec(location string, _ *Validator) []*validationError {
+	var errs []*validationError
+	if o.Title == "" {
+		errs = append(errs, newValidationError(joinLoc(location, "title"), ErrRequired))
+	}
+	if o.Version == "" {
+		errs = append(errs, newValidationError(joinLoc(location, "version"), ErrRequired))
+	}
+	return errs
+}
+
+// OverlayAction updates or removes the nodes of the document selected by the JSONPath query.
+//
+// The update is merged into the selected objects: the properties of the objects are merged recursively,
+// other values, including the arrays, are replaced; the update is appended to the selected arrays.
+// If remove is true, the selected nodes are removed from their parents and the update is ignored.
+type OverlayAction struct {
+	// REQUIRED.
+	// A JSONPath query, RFC 9535, selecting the nodes of the document to update or remove.
+	// The dot notation allows `-` in the names, e.g. `$.info.x-logo`.
+	Target string `json:"target" yaml:"target"`
+	// A description of the action.
+	// CommonMark syntax MAY be used for rich text representation.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// The object to merge into the selected objects or the value to append to the selected arrays.
+	Update any `json:"update,omitempty" yaml:"update,omitempty"`
+	// Removes the selected nodes from their parents.
+	Remove bool `json:"remove,omitempty" yaml:"remove,omitempty"`
+}
+
+func (o *OverlayAction) validateSpec(location string, _ *Validator) []*validationError {
+	var errs []*validationError
+	if o.Target == "" {
+		errs = append(errs, newValidationError(joinLoc(location, "target"), ErrRequired))
+	} else if _, err := parseJSONPath(o.Target); err != nil {
+		errs = append(errs, newValidationError(joinLoc(location, "target"), err))
+	}
+	if !o.Remove && o.Update == nil {
+		errs = append(errs, newValidationError(joinLoc(location, "update||remove"), ErrRequired))
+	}
+	return errs
+}
+
+// ParseOverlay parses the overlay document in YAML or JSON format and validates it, see ValidateOverlay.
+func ParseOverlay(data []byte) (*Extendable[Overlay], error) {
+	var overlay *Extendable[Overlay]
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&overlay); err != nil {
+		return nil, fmt.Errorf("%w of overlay: %w", ErrInvalidFormat, err)
+	}
+	if err := ValidateOverlay(overlay); err != nil {
+		return nil, err
+	}
+	return overlay, nil
+}
+
+// ValidateOverlay validates the overlay document: the required fields, the version, and the JSONPath queries.
+func ValidateOverlay(overlay *Extendable[Overlay]) error {
+	if overlay == nil || overlay.Spec == nil {
+		return newValidationError("", ErrRequired)
+	}
+	validator := &Validator{opts: &validationOptions{}}
+	errs := overlay.validateSpec("", validator)
+	joinErrors := make([]error, len(errs))
+	for i := range errs {
+		joinErrors[i] = errs[i]
+	}
+	return errors.Join(joinErrors...)
+}
+
+// ApplyOverlay applies the actions of the overlay to a copy of the spec in their order and returns the updated spec,
+// the given spec is not changed.
+// The actions selecting no nodes are skipped.
+// An error is returned if the overlay is invalid, see ValidateOverlay,
+// an update can not be applied to the selected node, or the updated spec can not be unmarshaled.
+func ApplyOverlay(overlay *Extendable[Overlay], spec *Extendable[OpenAPI]) (*Extendable[OpenAPI], error) {
+	if err := ValidateOverlay(overlay); err != nil {
+		return nil, err
+	}
+	doc, err := marshalToJSONValue(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := keepEmptySecurity(spec, doc); err != nil {
+		return nil, err
+	}
+	for i, action := range overlay.Spec.Actions {
+		loc := joinLoc("/actions", i)
+		query, err := parseJSONPath(action.Spec.Target)
+		if err != nil {
+			return nil, newValidationError(joinLoc(loc, "target"), err)
+		}
+		nodes := query.query(doc)
+		if action.Spec.Remove {
+			removeJSONPathNodes(doc, nodes)
+			continue
+		}
+		update := normalizeYAMLValue(action.Spec.Update)
+		for _, n := range nodes {
+			if doc, err = updateJSONPathNode(doc, n, update); err != nil {
+				return nil, newValidationError(joinLoc(loc, "update"), err)
+			}
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec failed: %w", err)
+	}
+	var newSpec *Extendable[OpenAPI]
+	if err := json.Unmarshal(data, &newSpec); err != nil {
+		return nil, fmt.Errorf("unmarshaling spec failed: %w", err)
+	}
+	return newSpec, nil
+}
+
+// keepEmptySecurity sets the empty security requirements of the spec and its operations in the JSON document,
+// because they are omitted by marshaling, but the empty list of an operation removes the top-level security.
+func keepEmptySecurity(spec *Extendable[OpenAPI], doc any) error {
+	return Walk(spec, func(node *WalkNode) error {
+		var security []SecurityRequirement
+		switch t := node.Value.(type) {
+		case *OpenAPI:
+			security = t.Security
+		case *Operation:
+			security = t.Security
+		default:
+			return nil
+		}
+		if security == nil || len(security) > 0 {
+			return nil
+		}
+		var path []any
+		for _, segment := range node.Location.Segments() {
+			path = append(path, segment)
+		}
+		if obj, ok := jsonPathValue(doc, path); ok {
+			if m, ok := obj.(map[string]any); ok {
+				m["security"] = []any{}
+			}
+		}
+		return nil
+	})
+}
+
+// updateJSONPathNode merges the update into the object or appends it to the array, and returns the updated document.
+func updateJSONPathNode(doc any, n *jsonPathNode, update any) (any, error) {
+	switch t := n.value.(type) {
+	case map[string]any:
+		u, ok := update.(map[string]any)
+		if !ok {
+			return doc, fmt.Errorf("%w: object expected to update object at %s", ErrInvalidValue, NewLocation().Join(n.path...))
+		}
+		mergeJSONObjects(t, u)
+		return doc, nil
+	case []any:
+		return setJSONPathValue(doc, n.path, append(slices.Clip(t), copyJSONValue(update))), nil
+	}
+	return doc, fmt.Errorf("%w: object or array expected to update, got %T at %s", ErrInvalidValue, n.value, NewLocation().Join(n.path...))
+}
+
+// mergeJSONObjects merges the properties of the source object into the destination object recursively,
+// the values other than the objects are replaced.
+func mergeJSONObjects(dst, src map[string]any) {
+	for k, v := range src {
+		if s, ok := v.(map[string]any); ok {
+			if d, ok := dst[k].(map[string]any); ok {
+				mergeJSONObjects(d, s)
+				continue
+			}
+		}
+		dst[k] = copyJSONValue(v)
+	}
+}
+
+// setJSONPathValue sets the value at the given path and returns the updated document.
+func setJSONPathValue(doc any, path []any, value any) any {
+	if len(path) == 0 {
+		return value
+	}
+	switch t := doc.(type) {
+	case map[string]any:
+		k := path[0].(string)
+		t[k] = setJSONPathValue(t[k], path[1:], value)
+	case []any:
+		i := path[0].(int)
+		t[i] = setJSONPathValue(t[i], path[1:], value)
+	}
+	return doc
+}
+
+// removeJSONPathNodes removes the nodes from their parents,
+// the elements of the arrays are removed starting from the last one, so the indexes of the others are kept.
+func removeJSONPathNodes(doc any, nodes []*jsonPathNode) {
+	slices.SortFunc(nodes, func(a, b *jsonPathNode) int {
+		return -compareJSONPaths(a.path, b.path)
+	})
+	for _, n := range nodes {
+		if len(n.path) == 0 {
+			// the root can not be removed
+			continue
+		}
+		parentPath, key := n.path[:len(n.path)-1], n.path[len(n.path)-1]
+		parent, ok := jsonPathValue(doc, parentPath)
+		if !ok {
+			continue
+		}
+		switch t := parent.(type) {
+		case map[string]any:
+			delete(t, key.(string))
+		case []any:
+			i := key.(int)
+			if i < len(t) {
+				setJSONPathValue(doc, parentPath, slices.Delete(slices.Clone(t), i, i+1))
+			}
+		}
+	}
+}
+
+// jsonPathValue returns the value at the given path, ok is false if there is no such value.
+func jsonPathValue(doc any, path []any) (any, bool) {
+	for _, key := range path {
+		switch t := doc.(type) {
+		case map[string]any:
+			v, ok := t[key.(string)]
+			if !ok {
+				return nil, false
+			}
+			doc = v
+		case []any:
+			i := key.(int)
+			if i >= len(t) {
+				return nil, false
+			}
+			doc = t[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+func compareJSONPaths(a, b []any) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		switch x := a[i].(type) {
+		case int:
+			if y, ok := b[i].(int); ok && x != y {
+				if x < y {
+					return -1
+				}
+				return 1
+			}
+		case string:
+			if y, ok := b[i].(string); ok && x != y {
+				if x < y {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,300 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testOverlayBaseSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: debug
+          in: query
+          x-internal: true
+          schema:
+            type: boolean
+      responses:
+        '200':
+          description: OK
+    delete:
+      operationId: deletePets
+      tags: [admin]
+      x-internal: true
+      responses:
+        '204':
+          description: Deleted
+  /admin:
+    x-internal: true
+    get:
+      operationId: admin
+      tags: [admin]
+      responses:
+        '200':
+          description: OK
+`
+
+const testOverlay = `
+overlay: 1.0.0
+info:
+  title: Public API
+  version: 1.0.0
+actions:
+  - target: $.paths.*[?@.x-internal == true]
+    description: Remove the internal operations
+    remove: true
+  - target: $.paths[?@.x-internal]
+    remove: true
+  - target: $..parameters[?@.x-internal == true]
+    remove: true
+  - target: $.tags[?@.name == 'admin']
+    remove: true
+  - target: $.info
+    update:
+      description: The public API.
+      x-audience: public
+  - target: $.tags
+    update:
+      name: public
+  - target: $.paths.*.get.tags
+    update: public
+  - target: $.paths['/pets'].get.responses
+    update:
+      '200':
+        content:
+          application/json:
+            schema:
+              type: array
+`
+
+func TestApplyOverlay(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testOverlayBaseSpec), &spec))
+	overlay, err := openapi.ParseOverlay([]byte(testOverlay))
+	require.NoError(t, err)
+	require.Equal(t, "Public API", overlay.Spec.Info.Spec.Title)
+	require.Len(t, overlay.Spec.Actions, 8)
+
+	updated, err := openapi.ApplyOverlay(overlay, spec)
+	require.NoError(t, err)
+
+	// the given spec is not changed
+	require.NotNil(t, spec.Spec.Paths.Spec.Paths["/admin"])
+	require.Len(t, spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get.Spec.Parameters, 2)
+
+	require.Equal(t, "The public API.", updated.Spec.Info.Spec.Description)
+	require.Equal(t, "public", updated.Spec.Info.GetExt("audience"))
+	require.Equal(t, "Pets", updated.Spec.Info.Spec.Title)
+
+	paths := updated.Spec.Paths.Spec.Paths
+	require.NotContains(t, paths, "/admin")
+	pets := paths["/pets"].Spec.Spec
+	require.Nil(t, pets.Delete)
+	require.NotNil(t, pets.Get)
+	require.Len(t, pets.Get.Spec.Parameters, 1)
+	require.Equal(t, "limit", pets.Get.Spec.Parameters[0].Spec.Spec.Name)
+	require.Equal(t, []string{"pets", "public"}, pets.Get.Spec.Tags)
+
+	ok := pets.Get.Spec.Responses.Spec.Response["200"].Spec
+	require.Equal(t, "OK", ok.Spec.Description)
+	require.Contains(t, ok.Spec.Content, "application/json")
+
+	tags := make([]string, len(updated.Spec.Tags))
+	for i, tag := range updated.Spec.Tags {
+		tags[i] = tag.Spec.Name
+	}
+	require.Equal(t, []string{"pets", "public"}, tags)
+
+	validator, err := openapi.NewValidator(updated)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+}
+
+func TestApplyOverlay_EmptySecurity(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(`
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      security: []
+      responses:
+        '200':
+          description: OK
+    post:
+      responses:
+        '201':
+          description: Created
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+`), &spec))
+	overlay, err := openapi.ParseOverlay([]byte(`
+overlay: 1.0.0
+info:
+  title: Docs
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: The public API.
+`))
+	require.NoError(t, err)
+
+	updated, err := openapi.ApplyOverlay(overlay, spec)
+	require.NoError(t, err)
+	require.Equal(t, "The public API.", updated.Spec.Info.Spec.Description)
+
+	pets := updated.Spec.Paths.Spec.Paths["/pets"].Spec.Spec
+	require.NotNil(t, pets.Get.Spec.Security)
+	require.Empty(t, pets.Get.Spec.Security)
+	require.Nil(t, pets.Post.Spec.Security)
+
+	security, err := openapi.EffectiveSecurity(pets.Get, updated)
+	require.NoError(t, err)
+	require.Empty(t, security)
+	security, err = openapi.EffectiveSecurity(pets.Post, updated)
+	require.NoError(t, err)
+	require.Len(t, security, 1)
+}
+
+func TestApplyOverlay_Errors(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Pets").Version("1.0.0").Build()).
+		Build()
+
+	overlay, err := openapi.ParseOverlay([]byte(`
+overlay: 1.0.0
+info: {title: Broken, version: 1.0.0}
+actions:
+  - target: $.info.title
+    update: {a: b}
+`))
+	require.NoError(t, err)
+	_, err = openapi.ApplyOverlay(overlay, spec)
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	require.ErrorContains(t, err, "/actions/0/update")
+
+	overlay, err = openapi.ParseOverlay([]byte(`
+overlay: 1.0.0
+info: {title: Nothing, version: 1.0.0}
+actions:
+  - target: $.paths.*.get
+    remove: true
+`))
+	require.NoError(t, err)
+	updated, err := openapi.ApplyOverlay(overlay, spec)
+	require.NoError(t, err)
+	require.Equal(t, "Pets", updated.Spec.Info.Spec.Title)
+}
+
+func TestValidateOverlay(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+		err  error
+		loc  string
+	}{
+		{name: "no version", data: `{"info": {"title": "a", "version": "1"}, "actions": [{"target": "$", "remove": true}]}`, err: openapi.ErrRequired, loc: "/overlay"},
+		{name: "unsupported version", data: `{"overlay": "2.0.0", "info": {"title": "a", "version": "1"}, "actions": [{"target": "$", "remove": true}]}`, err: openapi.ErrUnsupportedVersion, loc: "/overlay"},
+		{name: "no info", data: `{"overlay": "1.0.0", "actions": [{"target": "$", "remove": true}]}`, err: openapi.ErrRequired, loc: "/info"},
+		{name: "no title", data: `{"overlay": "1.0.0", "info": {"version": "1"}, "actions": [{"target": "$", "remove": true}]}`, err: openapi.ErrRequired, loc: "/info/title"},
+		{name: "no actions", data: `{"overlay": "1.0.0", "info": {"title": "a", "version": "1"}, "actions": []}`, err: openapi.ErrRequired, loc: "/actions"},
+		{name: "no target", data: `{"overlay": "1.0.0", "info": {"title": "a", "version": "1"}, "actions": [{"remove": true}]}`, err: openapi.ErrRequired, loc: "/actions/0/target"},
+		{name: "invalid target", data: `{"overlay": "1.0.0", "info": {"title": "a", "version": "1"}, "actions": [{"target": "paths", "remove": true}]}`, err: openapi.ErrInvalidFormat, loc: "/actions/0/target"},
+		{name: "no update", data: `{"overlay": "1.0.0", "info": {"title": "a", "version": "1"}, "actions": [{"target": "$"}]}`, err: openapi.ErrRequired, loc: "/actions/0/update||remove"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var overlay *openapi.Extendable[openapi.Overlay]
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &overlay))
+			err := openapi.ValidateOverlay(overlay)
+			require.ErrorIs(t, err, tt.err)
+			require.ErrorContains(t, err, tt.loc)
+
+			_, err = openapi.ParseOverlay([]byte(tt.data))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+	_, err := openapi.ParseOverlay([]byte(`[`))
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+}
+
+func TestApplyOverlay_JSONPath(t *testing.T) {
+	const base = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - {name: a, x-order: 1}
+  - {name: b, x-order: 2}
+  - {name: c, x-order: 3}
+  - {name: "d'e", x-order: 4}
+paths: {}
+`
+	for _, tt := range []struct {
+		target string
+		tags   []string
+	}{
+		{target: "$.tags[0]", tags: []string{"b", "c", "d'e"}},
+		{target: "$.tags[-1]", tags: []string{"a", "b", "c"}},
+		{target: "$.tags[0,2]", tags: []string{"b", "d'e"}},
+		{target: "$.tags[*]", tags: []string{}},
+		{target: "$.tags.*", tags: []string{}},
+		{target: "$.tags[?@.x-order > 2]", tags: []string{"a", "b"}},
+		{target: "$.tags[?@.x-order >= 2 && @.x-order <= 3]", tags: []string{"a", "d'e"}},
+		{target: "$.tags[?@.name == 'a' || @.name == \"c\"]", tags: []string{"b", "d'e"}},
+		{target: "$.tags[?!(@.name == 'a')]", tags: []string{"a"}},
+		{target: "$.tags[?@.name == 'd\\'e']", tags: []string{"a", "b", "c"}},
+		{target: "$.tags[?@.x-order == $.tags[1].x-order]", tags: []string{"a", "c", "d'e"}},
+		{target: "$.tags[?@.description]", tags: []string{"a", "b", "c", "d'e"}},
+		{target: "$..[?@.name == 'b']", tags: []string{"a", "c", "d'e"}},
+		{target: "$['tags'][1]", tags: []string{"a", "c", "d'e"}},
+	} {
+		t.Run(tt.target, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(base), &spec))
+			overlay := openapi.NewExtendable(&openapi.Overlay{
+				Overlay: openapi.OverlayVersion,
+				Info:    openapi.NewExtendable(&openapi.OverlayInfo{Title: "Tags", Version: "1.0.0"}),
+				Actions: []*openapi.Extendable[openapi.OverlayAction]{
+					openapi.NewExtendable(&openapi.OverlayAction{Target: tt.target, Remove: true}),
+				},
+			})
+			updated, err := openapi.ApplyOverlay(overlay, spec)
+			require.NoError(t, err)
+			tags := make([]string, len(updated.Spec.Tags))
+			for i, tag := range updated.Spec.Tags {
+				tags[i] = tag.Spec.Name
+			}
+			require.Equal(t, tt.tags, tags)
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// LinkHeader is the name of the response header holding the web links of RFC 8288,
+// e.g. the links to the other pages of a collection.
+const LinkHeader = "Link"
+
+// LinkHeaderFormat is the format of the string schemas holding the value of `Link` header, RFC 8288.
+// The format is registered in the compilers of the Validator and asserted if the format assertions are enabled,
+// see UpdateCompiler option.
+const LinkHeaderFormat = "link-header"
+
+// The relation types of the links to the pages of a collection.
+const (
+	RelFirst = "first"
+	RelPrev  = "prev"
+	RelNext  = "next"
+	RelLast  = "last"
+)
+
+// WebLink is a link of `Link` header.
+//
+// https://www.rfc-editor.org/rfc/rfc8288
+//
+// Example:
+//
+//	<https://api.example.com/pets?page=2>; rel="next"
+type WebLink struct {
+	// The target URI reference of the link.
+	URI string
+	// The relation types of the link, the value of `rel` parameter split by spaces.
+	Rel []string
+	// The other parameters of the link, the names are lower-cased.
+	Params map[string]string
+}
+
+// String implements fmt.Stringer interface, the link is formatted as a value of `Link` header.
+func (l *WebLink) String() string {
+	var sb strings.Builder
+	sb.WriteString("<" + l.URI + ">")
+	if len(l.Rel) > 0 {
+		sb.WriteString(`; rel="` + strings.Join(l.Rel, " ") + `"`)
+	}
+	for _, name := range sortedKeys(l.Params) {
+		sb.WriteString("; " + name + `="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(l.Params[name]) + `"`)
+	}
+	return sb.String()
+}
+
+// FormatLinkHeader returns the value of `Link` header with the given links.
+func FormatLinkHeader(links ...*WebLink) string {
+	values := make([]string, len(links))
+	for i, l := range links {
+		values[i] = l.String()
+	}
+	return strings.Join(values, ", ")
+}
+
+// ParseLinkHeader parses the value of `Link` header, an error wrapping ErrInvalidFormat is returned
+// if the value does not follow the syntax of RFC 8288.
+func ParseLinkHeader(value string) ([]*WebLink, error) {
+	var links []*WebLink
+	p := &linkHeaderParser{s: value}
+	for {
+		p.skipSpaces()
+		if p.done() {
+			break
+		}
+		link, err := p.parseLink()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid Link header at position %d: %w", ErrInvalidFormat, p.i, err)
+		}
+		links = append(links, link)
+		p.skipSpaces()
+		if p.done() {
+			break
+		}
+		if p.s[p.i] != ',' {
+			return nil, fmt.Errorf("%w: invalid Link header at position %d: expected ','", ErrInvalidFormat, p.i)
+		}
+		p.i++
+	}
+	return links, nil
+}
+
+type linkHeaderParser struct {
+	s string
+	i int
+}
+
+func (p *linkHeaderParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *linkHeaderParser) skipSpaces() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *linkHeaderParser) parseLink() (*WebLink, error) {
+	if p.s[p.i] != '<' {
+		return nil, errors.New("expected '<'")
+	}
+	end := strings.IndexByte(p.s[p.i:], '>')
+	if end < 0 {
+		return nil, errors.New("expected '>'")
+	}
+	link := &WebLink{URI: p.s[p.i+1 : p.i+end]}
+	p.i += end + 1
+	for {
+		p.skipSpaces()
+		if p.done() || p.s[p.i] != ';' {
+			return link, nil
+		}
+		p.i++
+		p.skipSpaces()
+		name := strings.ToLower(p.token())
+		if name == "" {
+			return nil, errors.New("expected parameter name")
+		}
+		var value string
+		p.skipSpaces()
+		if !p.done() && p.s[p.i] == '=' {
+			p.i++
+			p.skipSpaces()
+			var err error
+			if value, err = p.value(); err != nil {
+				return nil, err
+			}
+		}
+		// the first occurrence of the parameter is used, RFC 8288, section 3
+		if name == "rel" {
+			if link.Rel == nil {
+				link.Rel = strings.Fields(value)
+			}
+			continue
+		}
+		if _, ok := link.Params[name]; ok {
+			continue
+		}
+		if link.Params == nil {
+			link.Params = make(map[string]string, 1)
+		}
+		link.Params[name] = value
+	}
+}
+
+// token reads the token of RFC 9110, section 5.6.2.
+func (p *linkHeaderParser) token() string {
+	start := p.i
+	for !p.done() && (isAlphaNum(p.s[p.i]) || strings.IndexByte("!#$%&'*+-.^_`|~", p.s[p.i]) >= 0) {
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// value reads the token or the quoted string.
+func (p *linkHeaderParser) value() (string, error) {
+	if p.done() || p.s[p.i] != '"' {
+		if v := p.token(); v != "" {
+			return v, nil
+		}
+		return "", errors.New("expected parameter value")
+	}
+	var sb strings.Builder
+	for p.i++; !p.done(); p.i++ {
+		switch c := p.s[p.i]; c {
+		case '"':
+			p.i++
+			return sb.String(), nil
+		case '\\':
+			p.i++
+			if p.done() {
+				return "", errors.New("unterminated quoted string")
+			}
+			sb.WriteByte(p.s[p.i])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", errors.New("unterminated quoted string")
+}
+
+func isAlphaNum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// registerFormats registers the custom formats of the package in the compiler.
+func registerFormats(c *jsonschema.Compiler) {
+	c.RegisterFormat(&jsonschema.Format{
+		Name: LinkHeaderFormat,
+		Validate: func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				return nil
+			}
+			_, err := ParseLinkHeader(s)
+			return err
+		},
+	})
+}
+
+// PaginationLinks declares the `Link` header of the response with the links to the pages of the collection,
+// the first, previous, next and last ones.
+func (b *ResponseBuilder) PaginationLinks() *ResponseBuilder {
+	return b.AddHeader(LinkHeader, NewHeaderBuilder().
+		Description("The links to the first, previous, next and last pages of the collection, RFC 8288").
+		Schema(NewSchemaBuilder().
+			Type(StringType).
+			Format(LinkHeaderFormat).
+			Examples(FormatLinkHeader(
+				&WebLink{URI: "https://api.example.com/items?page=2", Rel: []string{RelNext}},
+				&WebLink{URI: "https://api.example.com/items?page=9", Rel: []string{RelLast}},
+			)).
+			Build()).
+		Build())
+}
+
+// PaginationLinksRuleID is the ID of PaginationLinksRule.
+const PaginationLinksRuleID = "pagination-links"
+
+// PaginationLinksRule is a governance rule checking that the list endpoints, the GET operations
+// with the successful responses returning arrays, declare `Link` header to navigate the pages,
+// and that the declared `Link` headers are strings.
+// The GET operations switching the protocol, see IsUpgradeOperation, are not required to declare `Link` header.
+//
+// The rule is disabled by default, it can be enabled using RuleSeverity option.
+type PaginationLinksRule struct{}
+
+// ID implements Rule interface.
+func (r *PaginationLinksRule) ID() string {
+	return PaginationLinksRuleID
+}
+
+// Description implements Rule interface.
+func (r *PaginationLinksRule) Description() string {
+	return "successful responses of GET operations returning arrays must declare Link header of string type"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *PaginationLinksRule) DefaultSeverity() Severity {
+	return SeverityOff
+}
+
+// Check implements Rule interface.
+func (r *PaginationLinksRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	var components *Extendable[Components]
+	if spec != nil && spec.Spec != nil {
+		components = spec.Spec.Components
+	}
+	forEachOperation(spec, func(location, method string, op *Extendable[Operation]) {
+		if op.Spec.Responses == nil || op.Spec.Responses.Spec == nil {
+			return
+		}
+		responses := op.Spec.Responses.Spec.Response
+		for _, code := range sortedKeys(responses) {
+			response, err := responses[code].GetSpec(components)
+			if err != nil || response.Spec == nil {
+				continue
+			}
+			loc := joinLoc(location, "responses", code)
+			var declared bool
+			for _, name := range sortedKeys(response.Spec.Headers) {
+				if !strings.EqualFold(name, LinkHeader) {
+					continue
+				}
+				declared = true
+				header, err := response.Spec.Headers[name].GetSpec(components)
+				if err != nil || header.Spec == nil || header.Spec.Schema == nil {
+					continue
+				}
+				schema, err := header.Spec.Schema.GetSpec(components)
+				if err == nil && !slices.Contains(schemaTypes(schema), StringType) {
+					report(joinLoc(loc, "headers", name, "schema"), LinkHeader+" header must be of string type")
+				}
+			}
+			if !declared && method == http.MethodGet && strings.HasPrefix(code, "2") && !IsUpgradeOperation(op.Spec) &&
+				returnsArray(response.Spec, components) {
+				report(loc, "list response of GET operation does not declare "+LinkHeader+" header")
+			}
+		}
+	})
+}
+
+// returnsArray reports whether the schema of any media type of the response is an array.
+func returnsArray(response *Response, components *Extendable[Components]) bool {
+	for _, mt := range response.Content {
+		if mt == nil || mt.Spec == nil || mt.Spec.Schema == nil {
+			continue
+		}
+		schema, err := mt.Spec.Schema.GetSpec(components)
+		if err == nil && slices.Contains(schemaTypes(schema), ArrayType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		value    string
+		expected []*openapi.WebLink
+		err      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "pagination links",
+			value: `<https://api.example.com/pets?page=2&size=10>; rel="next", <https://api.example.com/pets?page=9>;rel=last`,
+			expected: []*openapi.WebLink{
+				{URI: "https://api.example.com/pets?page=2&size=10", Rel: []string{openapi.RelNext}},
+				{URI: "https://api.example.com/pets?page=9", Rel: []string{openapi.RelLast}},
+			},
+		},
+		{
+			name:  "parameters",
+			value: `</a,b>; rel="prev first"; Title="Page \"1\""; rel=ignored; hreflang=en; title=ignored; crossorigin`,
+			expected: []*openapi.WebLink{
+				{
+					URI:    "/a,b",
+					Rel:    []string{openapi.RelPrev, openapi.RelFirst},
+					Params: map[string]string{"title": `Page "1"`, "hreflang": "en", "crossorigin": ""},
+				},
+			},
+		},
+		{
+			name:  "missing brackets",
+			value: `https://api.example.com; rel="next"`,
+			err:   "position 0: expected '<'",
+		},
+		{
+			name:  "missing closing bracket",
+			value: `<https://api.example.com; rel="next"`,
+			err:   "position 0: expected '>'",
+		},
+		{
+			name:  "missing separator",
+			value: `</a>; rel=next </b>`,
+			err:   "position 15: expected ','",
+		},
+		{
+			name:  "missing parameter name",
+			value: `</a>; =next`,
+			err:   "expected parameter name",
+		},
+		{
+			name:  "unterminated quoted string",
+			value: `</a>; rel="next`,
+			err:   "unterminated quoted string",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := openapi.ParseLinkHeader(tt.value)
+			if tt.err != "" {
+				require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, links)
+		})
+	}
+}
+
+func TestFormatLinkHeader(t *testing.T) {
+	links := []*openapi.WebLink{
+		{URI: "/pets?page=1", Rel: []string{openapi.RelFirst, openapi.RelPrev}},
+		{URI: "/pets?page=3", Rel: []string{openapi.RelNext}, Params: map[string]string{"title": `"3"`}},
+	}
+	value := openapi.FormatLinkHeader(links...)
+	require.Equal(t, `</pets?page=1>; rel="first prev", </pets?page=3>; rel="next"; title="\"3\""`, value)
+	parsed, err := openapi.ParseLinkHeader(value)
+	require.NoError(t, err)
+	require.Equal(t, links, parsed)
+}
+
+func TestResponseBuilder_PaginationLinks(t *testing.T) {
+	pets := openapi.NewSchemaBuilder().
+		Type(openapi.ArrayType).
+		Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build())).
+		Build()
+	op := openapi.NewOperationBuilder().Build()
+	op.Spec.Responses = openapi.NewExtendable(&openapi.Responses{
+		Response: map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]]{
+			"200": openapi.NewResponseBuilder().
+				Description("pets").
+				AddContent("application/json", openapi.NewMediaTypeBuilder().Schema(pets).Build()).
+				PaginationLinks().
+				Build(),
+		},
+	})
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Pagination").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Get(op).Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec,
+		openapi.UpdateCompiler(func(c *jsonschema.Compiler) {
+			c.AssertFormat()
+		}),
+		openapi.RuleSeverity(openapi.PaginationLinksRuleID, openapi.SeverityWarning),
+	)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+	require.Empty(t, validator.Lint())
+
+	const location = "/paths/~1pets/get/responses/200/headers/Link/schema"
+	require.NoError(t, validator.ValidateData(location, `</pets?page=2>; rel="next"`))
+	require.ErrorContains(t, validator.ValidateData(location, `/pets?page=2; rel="next"`), "is not valid link-header")
+
+	// the format is not asserted by default
+	validator, err = openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateData(location, `/pets?page=2; rel="next"`))
+}
+
+const testPaginationLinksRuleSpec = `
+openapi: 3.1.1
+info:
+  title: Pagination
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        '200':
+          description: pet
+          content:
+            application/json:
+              schema:
+                type: object
+    put:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+        '201':
+          description: pet
+          headers:
+            link:
+              schema:
+                type: integer
+`
+
+func TestPaginationLinksRule(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		spec     string
+		expected []string
+	}{
+		{
+			name: "missing or invalid headers",
+			spec: testPaginationLinksRuleSpec,
+			expected: []string{
+				"/paths/~1pets/get/responses/200: list response of GET operation does not declare Link header",
+				"/paths/~1pets~1{id}/put/responses/201/headers/link/schema: Link header must be of string type",
+			},
+		},
+		{
+			name: "declared header",
+			spec: `
+openapi: 3.1.1
+info:
+  title: Pagination
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          headers:
+            link:
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                type: array
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(tt.spec), &spec))
+			validator, err := openapi.NewValidator(spec, openapi.RuleSeverity(openapi.PaginationLinksRuleID, openapi.SeverityWarning))
+			require.NoError(t, err)
+			var messages []string
+			for _, issue := range validator.Lint() {
+				messages = append(messages, issue.Location.String()+": "+issue.Message)
+			}
+			require.Equal(t, tt.expected, messages)
+		})
+	}
+}
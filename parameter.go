@@ -155,7 +155,8 @@ type Parameter struct {
 	// For other types of parameters this property has no effect.
 	// When style is form, the default value is true.
 	// For all other styles, the default value is false.
-	Explode bool `json:"explode,omitempty" yaml:"explode,omitempty"`
+	// The nil value means the default one, so the explicit false value is kept.
+	Explode *bool `json:"explode,omitempty" yaml:"explode,omitempty"`
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by [RFC3986]
 	//   :/?#[]@!$&'()*+,;=
 	// to be included without percent-encoding.
@@ -188,7 +189,7 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 
 	if l := len(o.Content); l > 0 {
 		if l != 1 {
-			errs = append(errs, newValidationError(joinLoc(location, "content"), "invalid number of items, expected only one, but got '%d'", l))
+			errs = append(errs, newValidationError(joinLoc(location, "content"), "%w: invalid number of items, expected only one, but got '%d'", ErrOutOfRange, l))
 		}
 		for k, v := range o.Content {
 			errs = append(errs, v.validateSpec(joinLoc(location, "content", k), validator)...)
@@ -203,49 +204,49 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 	case "":
 		errs = append(errs, newValidationError(joinLoc(location, "in"), ErrRequired))
 	default:
-		errs = append(errs, newValidationError(joinLoc(location, "in"), "invalid value, expected one of [%s, %s, %s, %s], but got '%s'", InQuery, InHeader, InPath, InCookie, o.In))
+		errs = append(errs, newValidationError(joinLoc(location, "in"), "%w, expected one of [%s, %s, %s, %s], but got '%s'", ErrInvalidValue, InQuery, InHeader, InPath, InCookie, o.In))
 	}
 
 	switch o.Style {
 	case "":
 	case StyleMatrix, StyleLabel:
 		if o.In != InPath {
-			errs = append(errs, newValidationError(joinLoc(location, "style"), "only allowed when `in` is '%s'", InPath))
+			errs = append(errs, newValidationError(joinLoc(location, "style"), "%w unless `in` is '%s'", ErrNotAllowed, InPath))
 		}
 	case StyleForm:
 		if o.In != InQuery && o.In != InCookie {
-			errs = append(errs, newValidationError(joinLoc(location, "style"), "only allowed when `in` is '%s' or '%s' ", InQuery, InCookie))
+			errs = append(errs, newValidationError(joinLoc(location, "style"), "%w unless `in` is '%s' or '%s' ", ErrNotAllowed, InQuery, InCookie))
 		}
 	case StyleSimple:
 		if o.In != InPath && o.In != InHeader {
-			errs = append(errs, newValidationError(joinLoc(location, "style"), "only allowed when `in` is '%s' or '%s' ", InPath, InHeader))
+			errs = append(errs, newValidationError(joinLoc(location, "style"), "%w unless `in` is '%s' or '%s' ", ErrNotAllowed, InPath, InHeader))
 		}
 	case StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject:
 		if o.In != InQuery {
-			errs = append(errs, newValidationError(joinLoc(location, "style"), "only allowed when `in` is '%s'", InQuery))
+			errs = append(errs, newValidationError(joinLoc(location, "style"), "%w unless `in` is '%s'", ErrNotAllowed, InQuery))
 		}
 	default:
-		errs = append(errs, newValidationError(joinLoc(location, "style"), "invalid value, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", StyleMatrix, StyleLabel, StyleForm, StyleSimple, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject, o.Style))
+		errs = append(errs, newValidationError(joinLoc(location, "style"), "%w, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", ErrInvalidValue, StyleMatrix, StyleLabel, StyleForm, StyleSimple, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject, o.Style))
 	}
 
 	if o.Name == "" {
 		errs = append(errs, newValidationError(joinLoc(location, "name"), ErrRequired))
 	} else if o.In == InPath && !PathNamePattern.MatchString(o.Name) {
-		errs = append(errs, newValidationError(joinLoc(location, "name"), "must match pattern '%s', but got '%s'", PathNamePattern, o.Name))
+		errs = append(errs, newValidationError(joinLoc(location, "name"), "%w: must match pattern '%s', but got '%s'", ErrInvalidFormat, PathNamePattern, o.Name))
 	} else if !o.AllowReserved && o.In == InQuery && strings.ContainsAny(o.Name, ReservedCharacters) {
-		errs = append(errs, newValidationError(joinLoc(location, "name"), "'%s' contains reserved characters: '%s'", o.Name, ReservedCharacters))
+		errs = append(errs, newValidationError(joinLoc(location, "name"), "%w: '%s' contains reserved characters: '%s'", ErrInvalidFormat, o.Name, ReservedCharacters))
 	}
 
 	if o.AllowReserved && o.In != InQuery {
-		errs = append(errs, newValidationError(joinLoc(location, "allowReserved"), "only allowed when `in` is '%s'", InQuery))
+		errs = append(errs, newValidationError(joinLoc(location, "allowReserved"), "%w unless `in` is '%s'", ErrNotAllowed, InQuery))
 	}
 
 	if o.AllowEmptyValue && o.In != InQuery {
-		errs = append(errs, newValidationError(joinLoc(location, "allowEmptyValue"), "only allowed when `in` is '%s'", InQuery))
+		errs = append(errs, newValidationError(joinLoc(location, "allowEmptyValue"), "%w unless `in` is '%s'", ErrNotAllowed, InQuery))
 	}
 
 	if !o.Required && o.In == InPath {
-		errs = append(errs, newValidationError(joinLoc(location, "required"), "must be `true` when `in` is '%s'", InPath))
+		errs = append(errs, newValidationError(joinLoc(location, "required"), "%w: must be `true` when `in` is '%s'", ErrInvalidValue, InPath))
 	}
 
 	if validator.opts.doNotValidateExamples {
@@ -263,7 +264,7 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 	if schemaRef != "'" {
 		if o.Example != nil {
 			if e := validator.ValidateData(joinLoc(location, "schema"), o.Example); e != nil {
-				errs = append(errs, newValidationError(joinLoc(location, "example"), e))
+				errs = append(errs, newValidationError(joinLoc(location, "example"), "%w: %w", ErrInvalidData, e))
 			}
 		}
 		if len(o.Examples) > 0 {
@@ -275,118 +276,13 @@ func (o *Parameter) validateSpec(location string, validator *Validator) []*valid
 				}
 				if value := example.Spec.Value; value != nil {
 					if e := validator.ValidateData(joinLoc(location, "schema"), value); e != nil {
-						errs = append(errs, newValidationError(joinLoc(location, "examples", k), e))
+						errs = append(errs, newValidationError(joinLoc(location, "examples", k), "%w: %w", ErrInvalidData, e))
 					}
 				}
 			}
 		}
 	} else {
-		errs = append(errs, newValidationError(location, "unable to validate examples without schema or content"))
+		errs = append(errs, newValidationError(location, "schema or content is %w to validate examples", ErrRequired))
 	}
 	return errs
 }
-
-type ParameterBuilder struct {
-	spec *RefOrSpec[Extendable[Parameter]]
-}
-
-func NewParameterBuilder() *ParameterBuilder {
-	return &ParameterBuilder{
-		spec: NewRefOrExtSpec[Parameter](&Parameter{}),
-	}
-}
-
-func (b *ParameterBuilder) Build() *RefOrSpec[Extendable[Parameter]] {
-	return b.spec
-}
-
-func (b *ParameterBuilder) Extensions(v map[string]any) *ParameterBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *ParameterBuilder) AddExt(name string, value any) *ParameterBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *ParameterBuilder) Example(v any) *ParameterBuilder {
-	b.spec.Spec.Spec.Example = v
-	return b
-}
-
-func (b *ParameterBuilder) Content(v map[string]*Extendable[MediaType]) *ParameterBuilder {
-	b.spec.Spec.Spec.Content = v
-	return b
-}
-
-func (b *ParameterBuilder) AddContent(name string, value *Extendable[MediaType]) *ParameterBuilder {
-	if b.spec.Spec.Spec.Content == nil {
-		b.spec.Spec.Spec.Content = make(map[string]*Extendable[MediaType], 1)
-	}
-	b.spec.Spec.Spec.Content[name] = value
-	return b
-}
-
-func (b *ParameterBuilder) Examples(v map[string]*RefOrSpec[Extendable[Example]]) *ParameterBuilder {
-	b.spec.Spec.Spec.Examples = v
-	return b
-}
-
-func (b *ParameterBuilder) AddExample(name string, value *RefOrSpec[Extendable[Example]]) *ParameterBuilder {
-	if b.spec.Spec.Spec.Examples == nil {
-		b.spec.Spec.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
-	}
-	b.spec.Spec.Spec.Examples[name] = value
-	return b
-}
-
-func (b *ParameterBuilder) Schema(v *RefOrSpec[Schema]) *ParameterBuilder {
-	b.spec.Spec.Spec.Schema = v
-	return b
-}
-
-func (b *ParameterBuilder) In(v string) *ParameterBuilder {
-	b.spec.Spec.Spec.In = v
-	return b
-}
-
-func (b *ParameterBuilder) Description(v string) *ParameterBuilder {
-	b.spec.Spec.Spec.Description = v
-	return b
-}
-
-func (b *ParameterBuilder) Style(v string) *ParameterBuilder {
-	b.spec.Spec.Spec.Style = v
-	return b
-}
-
-func (b *ParameterBuilder) Name(v string) *ParameterBuilder {
-	b.spec.Spec.Spec.Name = v
-	return b
-}
-
-func (b *ParameterBuilder) Explode(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.Explode = v
-	return b
-}
-
-func (b *ParameterBuilder) AllowReserved(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.AllowReserved = v
-	return b
-}
-
-func (b *ParameterBuilder) AllowEmptyValue(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.AllowEmptyValue = v
-	return b
-}
-
-func (b *ParameterBuilder) Deprecated(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.Deprecated = v
-	return b
-}
-
-func (b *ParameterBuilder) Required(v bool) *ParameterBuilder {
-	b.spec.Spec.Spec.Required = v
-	return b
-}
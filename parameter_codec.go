@@ -0,0 +1,768 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// getStyle returns the style of the parameter or the default style for its location.
+func (o *Parameter) getStyle() string {
+	if o.Style != "" {
+		return o.Style
+	}
+	switch o.In {
+	case InQuery, InCookie:
+		return StyleForm
+	default:
+		return StyleSimple
+	}
+}
+
+// isExploded reports whether the array and object values are exploded,
+// the explode field is true by default for the form style only.
+func (o *Parameter) isExploded() bool {
+	if o.Explode == nil {
+		return o.getStyle() == StyleForm
+	}
+	return *o.Explode
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isReserved(c byte) bool {
+	return strings.IndexByte(ReservedCharacters, c) >= 0
+}
+
+// escapeValue percent-encodes all characters except the unreserved ones and, if allowReserved is true, the reserved ones.
+func escapeValue(s string, allowReserved bool) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) || allowReserved && isReserved(c) {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hex[c>>4])
+		b.WriteByte(hex[c&15])
+	}
+	return b.String()
+}
+
+// unescapeUnreserved decodes the percent-encoded characters except the reserved ones,
+// which are kept as is, because they are allowed to be used without encoding and so their encoded form is a data.
+// The `+` sign is kept as is as well.
+func unescapeUnreserved(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+2 >= len(s) {
+			return "", url.EscapeError(s[i:])
+		}
+		c, err := strconv.ParseUint(s[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", url.EscapeError(s[i : i+3])
+		}
+		if isReserved(byte(c)) {
+			b.WriteString(s[i : i+3])
+		} else {
+			b.WriteByte(byte(c))
+		}
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// unescaper returns the function decoding the raw value of the parameter depending on its location.
+func (o *Parameter) unescaper() func(string) (string, error) {
+	switch o.In {
+	case InPath:
+		return url.PathUnescape
+	case InQuery:
+		if o.AllowReserved {
+			return unescapeUnreserved
+		}
+		return url.QueryUnescape
+	case InCookie:
+		// the cookie values are not required to be percent-encoded, so keep the invalid sequences as is
+		return func(s string) (string, error) {
+			if u, err := url.PathUnescape(s); err == nil {
+				return u, nil
+			}
+			return s, nil
+		}
+	default:
+		return func(s string) (string, error) { return s, nil }
+	}
+}
+
+// schemaType returns the main (not null) type of the schema.
+// If the type is not set, it is detected by the keywords: `properties` means object and `items` means array.
+func schemaType(schema *Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Type != nil {
+		for _, t := range *schema.Type {
+			if t != NullType {
+				return t
+			}
+		}
+	}
+	switch {
+	case len(schema.Properties) > 0:
+		return ObjectType
+	case schema.Items != nil:
+		return ArrayType
+	}
+	return ""
+}
+
+// decodeScalar converts the raw string to the JSON value of the given type.
+// If the value cannot be converted, it is returned as is, so the schema validation reports a meaningful error.
+func decodeScalar(raw, typ string) any {
+	switch typ {
+	case IntegerType, NumberType:
+		// the JSON number grammar is used, strconv.ParseFloat accepts `1_0`, `0x10`, `NaN`, `Inf`, etc.
+		if n, err := ParseNumber(raw); err == nil {
+			return json.Number(n)
+		}
+	case BooleanType:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case NullType:
+		if raw == "" || raw == "null" {
+			return nil
+		}
+	}
+	return raw
+}
+
+// decode converts the raw values of the parameter into the JSON value to be validated against the schema.
+//
+// The values must be taken from a request as is, without decoding, because the delimiters of the style
+// are distinguished from the percent-encoded data: for instance, `a,b%2Cc` is decoded to `["a", "b,c"]`.
+// The query parameters with `allowReserved: true` keep the percent-encoded reserved characters.
+//
+// For the parameters defined using `content` the raw value is parsed according to the media type,
+// e.g. JSON object in a query parameter. For the parameters defined using `schema` the values are split
+// according to the style of the parameter and converted to the types declared by the schema.
+// The array and object headers sent as several header fields are combined into one comma separated list.
+// The form values (all query parameters or all cookies depending on the location) are needed to decode
+// exploded objects of form style, where every property is a separate query parameter or cookie.
+func (o *Parameter) decode(values []string, form map[string][]string, components *Extendable[Components]) (any, error) {
+	unescape := o.unescaper()
+	if len(o.Content) > 0 {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		raw, err := unescape(values[0])
+		if err != nil {
+			return nil, err
+		}
+		for k := range o.Content {
+			return decodeContent(k, raw)
+		}
+	}
+
+	var schema *Schema
+	if o.Schema != nil {
+		var err error
+		schema, err = o.Schema.GetSpec(components)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if o.getStyle() == StyleDeepObject {
+		return decodeDeepObject(o.Name, schema, form, unescape, components)
+	}
+	typ := schemaType(schema)
+	if o.In == InHeader && (typ == ArrayType || typ == ObjectType) && len(values) > 0 {
+		values = []string{joinHeaderValues(values)}
+	}
+	if typ == ObjectType && len(values) == 1 && !o.isExploded() {
+		values = []string{strings.Join(o.splitValue(values[0]), ",")}
+	}
+	if style := o.getStyle(); (style == StyleMatrix || style == StyleLabel) && len(values) == 1 {
+		var err error
+		if values, err = o.unwrapPathStyle(values[0], typ); err != nil {
+			return nil, err
+		}
+	}
+	switch typ {
+	case ArrayType:
+		itemType := itemsType(schema, components)
+		var raw []string
+		if len(values) > 1 {
+			raw = values
+		} else if len(values) == 1 {
+			raw = o.splitValue(values[0])
+		}
+		items := make([]any, len(raw))
+		for i, v := range raw {
+			s, err := unescape(v)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = decodeScalar(s, itemType)
+		}
+		return items, nil
+	case ObjectType:
+		return decodeObject(o, schema, values, form, unescape, components)
+	default:
+		if len(values) == 0 {
+			return nil, nil
+		}
+		s, err := unescape(values[0])
+		if err != nil {
+			return nil, err
+		}
+		return decodeScalar(s, typ), nil
+	}
+}
+
+// splitValue splits the raw value of not exploded array or object by the delimiter of the style:
+// the space of spaceDelimited style (`%20`, `+` or ` `), the pipe of pipeDelimited style (`|` or `%7C`),
+// and the comma of other styles.
+func (o *Parameter) splitValue(raw string) []string {
+	switch o.getStyle() {
+	case StyleSpaceDelimited:
+		return strings.Split(strings.NewReplacer("+", "%20", " ", "%20").Replace(raw), "%20")
+	case StylePipeDelimited:
+		return strings.Split(strings.NewReplacer("%7C", "|", "%7c", "|").Replace(raw), "|")
+	}
+	return strings.Split(raw, ",")
+}
+
+// joinHeaderValues combines the values of the header fields with the same name into a comma separated list,
+// the optional whitespaces around the elements are removed, e.g. `a, b` and `c` become `a,b,c`.
+//
+// https://www.rfc-editor.org/rfc/rfc9110#section-5.6.1
+func joinHeaderValues(values []string) string {
+	var parts []string
+	for _, v := range values {
+		for _, p := range strings.Split(v, ",") {
+			parts = append(parts, strings.TrimSpace(p))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// unwrapPathStyle converts the raw value of matrix or label style into the raw values of simple style:
+// the exploded arrays are returned as several values and the exploded objects as `key=value` pairs
+// separated by comma, e.g. `;id=3;id=4` becomes `3`, `4` and `.a=1.b=2` becomes `a=1,b=2`.
+func (o *Parameter) unwrapPathStyle(raw, typ string) ([]string, error) {
+	explode := o.isExploded() && (typ == ArrayType || typ == ObjectType)
+	style := o.getStyle()
+	prefix := "."
+	if style == StyleMatrix {
+		prefix = ";"
+	}
+	rest, ok := strings.CutPrefix(raw, prefix)
+	if !ok {
+		return nil, fmt.Errorf("%s style value must start with %q, but got %q", style, prefix, raw)
+	}
+	if style == StyleLabel {
+		if !explode {
+			return []string{rest}, nil
+		}
+		parts := strings.Split(rest, ".")
+		if typ == ObjectType {
+			return []string{strings.Join(parts, ",")}, nil
+		}
+		return parts, nil
+	}
+
+	pairs := strings.Split(rest, ";")
+	if explode && typ == ObjectType {
+		return []string{strings.Join(pairs, ",")}, nil
+	}
+	if !explode && len(pairs) > 1 {
+		return nil, fmt.Errorf("%s style value of not exploded parameter must have one name, but got %q", style, raw)
+	}
+	values := make([]string, len(pairs))
+	for i, pair := range pairs {
+		k, v, _ := strings.Cut(pair, "=")
+		if name, err := url.PathUnescape(k); err != nil || name != o.Name {
+			return nil, fmt.Errorf("%s style value must be named %q, but got %q", style, o.Name, k)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// decodeContent parses the raw value according to the given media type.
+// Only JSON media types are parsed, the value of other media types is returned as is.
+func decodeContent(mediaType string, raw string) (any, error) {
+	if mt, _, err := mime.ParseMediaType(mediaType); err == nil && isJSONMediaType(mt) {
+		v, err := jsonschema.UnmarshalJSON(strings.NewReader(raw))
+		if err != nil {
+			return nil, fmt.Errorf("parsing value as %q failed: %w", mediaType, err)
+		}
+		return v, nil
+	}
+	return raw, nil
+}
+
+func itemsType(schema *Schema, components *Extendable[Components]) string {
+	if schema.Items == nil || schema.Items.Schema == nil {
+		return ""
+	}
+	items, err := schema.Items.Schema.GetSpec(components)
+	if err != nil {
+		return ""
+	}
+	return schemaType(items)
+}
+
+func propertyType(schema *Schema, name string, components *Extendable[Components]) string {
+	prop, ok := schema.Properties[name]
+	if !ok || prop == nil {
+		return ""
+	}
+	spec, err := prop.GetSpec(components)
+	if err != nil {
+		return ""
+	}
+	return schemaType(spec)
+}
+
+// decodeObject decodes an object parameter.
+// The non-exploded form is a comma separated list of keys and values (`a,1,b,2`),
+// the exploded one is a list of `key=value` pairs (`a=1,b=2`) or, for the form style,
+// the separate query parameters or cookies named by the properties.
+func decodeObject(
+	param *Parameter,
+	schema *Schema,
+	values []string,
+	form map[string][]string,
+	unescape func(string) (string, error),
+	components *Extendable[Components],
+) (map[string]any, error) {
+	obj := make(map[string]any)
+	if len(values) == 0 {
+		if param.getStyle() == StyleForm {
+			for name := range schema.Properties {
+				if v, ok := form[name]; ok && len(v) > 0 {
+					s, err := unescape(v[0])
+					if err != nil {
+						return nil, err
+					}
+					obj[name] = decodeScalar(s, propertyType(schema, name, components))
+				}
+			}
+		}
+		return obj, nil
+	}
+	parts := strings.Split(values[0], ",")
+	add := func(k, v string) error {
+		key, err := unescape(k)
+		if err != nil {
+			return err
+		}
+		value, err := unescape(v)
+		if err != nil {
+			return err
+		}
+		obj[key] = decodeScalar(value, propertyType(schema, key, components))
+		return nil
+	}
+	if strings.Contains(parts[0], "=") {
+		for _, p := range parts {
+			k, v, _ := strings.Cut(p, "=")
+			if err := add(k, v); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+	for i := 0; i+1 < len(parts); i += 2 {
+		if err := add(parts[i], parts[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// decodeDeepObject decodes the object serialized using deepObject style, e.g. `filter[name]=a&filter[age]=5`.
+// The nested objects are supported as well: `filter[address][city]=Berlin`.
+// The repeated keys are decoded as an array.
+func decodeDeepObject(
+	name string,
+	schema *Schema,
+	form map[string][]string,
+	unescape func(string) (string, error),
+	components *Extendable[Components],
+) (map[string]any, error) {
+	root := make(map[string]any)
+	prefix := name + "["
+	// the keys are sorted to report the ambiguous keys consistently
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values := form[key]
+		path, err := parseDeepObjectKey(key[len(name):])
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", key, err)
+		}
+		node := root
+		for _, p := range path[:len(path)-1] {
+			switch next := node[p].(type) {
+			case nil:
+				m := make(map[string]any)
+				node[p] = m
+				node = m
+			case map[string]any:
+				node = next
+			default:
+				return nil, fmt.Errorf("%q: ambiguous key, %q is used as a value and as an object", key, p)
+			}
+		}
+		last := path[len(path)-1]
+		if _, ok := node[last]; ok {
+			return nil, fmt.Errorf("%q: ambiguous key, %q is used as a value and as an object", key, last)
+		}
+		raw := make([]string, len(values))
+		for i, v := range values {
+			if raw[i], err = unescape(v); err != nil {
+				return nil, err
+			}
+		}
+		node[last] = raw
+	}
+	return convertDeepObject(root, schema, components), nil
+}
+
+// parseDeepObjectKey splits the key like `[a][b]` into the list of names.
+func parseDeepObjectKey(key string) ([]string, error) {
+	var path []string
+	for key != "" {
+		if key[0] != '[' {
+			return nil, errors.New("invalid deepObject key")
+		}
+		end := strings.IndexByte(key, ']')
+		if end < 0 {
+			return nil, errors.New("invalid deepObject key")
+		}
+		path = append(path, key[1:end])
+		key = key[end+1:]
+	}
+	return path, nil
+}
+
+// convertDeepObject converts the raw values of the decoded deepObject tree to the types declared by the schema.
+func convertDeepObject(node map[string]any, schema *Schema, components *Extendable[Components]) map[string]any {
+	obj := make(map[string]any, len(node))
+	for k, v := range node {
+		var sub *Schema
+		if schema != nil {
+			if prop, ok := schema.Properties[k]; ok && prop != nil {
+				sub, _ = prop.GetSpec(components)
+			} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+				sub, _ = schema.AdditionalProperties.Schema.GetSpec(components)
+			}
+		}
+		switch t := v.(type) {
+		case map[string]any:
+			obj[k] = convertDeepObject(t, sub, components)
+		case []string:
+			typ := schemaType(sub)
+			if typ == ArrayType || len(t) > 1 {
+				itemType := ""
+				if sub != nil {
+					itemType = itemsType(sub, components)
+				}
+				items := make([]any, len(t))
+				for i, s := range t {
+					items[i] = decodeScalar(s, itemType)
+				}
+				obj[k] = items
+			} else {
+				obj[k] = decodeScalar(t[0], typ)
+			}
+		}
+	}
+	return obj
+}
+
+// parseRawQuery parses the query string into the map of decoded names and raw (not decoded) values.
+func parseRawQuery(query string) map[string][]string {
+	m := make(map[string][]string)
+	for query != "" {
+		var pair string
+		pair, query, _ = strings.Cut(query, "&")
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(k); err == nil {
+			k = name
+		}
+		m[k] = append(m[k], v)
+	}
+	return m
+}
+
+// toJSONValue converts the given value into the generic JSON representation (map[string]any, []any, etc.).
+func toJSONValue(value any) (any, error) {
+	switch value.(type) {
+	case nil, string, bool, json.Number, float64:
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling value failed: %w", err)
+	}
+	v, err := jsonschema.UnmarshalJSON(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling value failed: %w", err)
+	}
+	return v, nil
+}
+
+func formatScalar(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case json.Number:
+		return t.String(), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported nested value of type %T", v)
+	}
+}
+
+// EncodeParameter serializes the value according to the style and the location of the parameter.
+//
+// The result for the query and cookie parameters includes the name of the parameter, e.g. `id=1&id=2`
+// for query or `id=1; id=2` for cookie, the result for the path and header parameters is the value only, e.g. `1,2`.
+// The values of the query parameters are percent-encoded, except the reserved characters if `allowReserved` is true.
+// The supported styles are form, simple, matrix, label, spaceDelimited, pipeDelimited and deepObject.
+// The not exploded arrays and objects of spaceDelimited and pipeDelimited styles are delimited by `%20` and `|`,
+// e.g. `id=1%202` and `id=1|2`, the exploded ones are serialized like the form style.
+func EncodeParameter(param *Parameter, value any) (string, error) {
+	v, err := toJSONValue(value)
+	if err != nil {
+		return "", err
+	}
+	style := param.getStyle()
+	switch style {
+	case StyleForm, StyleSimple, StyleMatrix, StyleLabel, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject:
+	default:
+		return "", fmt.Errorf("unsupported style %q", style)
+	}
+	formLike := style == StyleForm || style == StyleSpaceDelimited || style == StylePipeDelimited
+	delimiter := ","
+	switch style {
+	case StyleSpaceDelimited:
+		delimiter = "%20"
+	case StylePipeDelimited:
+		delimiter = "|"
+	}
+	escape := func(s string) string {
+		switch param.In {
+		case InHeader:
+			return s
+		case InQuery:
+			return escapeValue(s, param.AllowReserved)
+		default:
+			return escapeValue(s, false)
+		}
+	}
+	separator := "&"
+	if param.In == InCookie {
+		separator = "; "
+	}
+	if style == StyleDeepObject {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s style supports objects only, but got %T", style, v)
+		}
+		var parts []string
+		if err := encodeDeepObject(escapeValue(param.Name, false), obj, escape, &parts); err != nil {
+			return "", err
+		}
+		return strings.Join(parts, "&"), nil
+	}
+	named := func(s string) string {
+		switch style {
+		case StyleForm, StyleSpaceDelimited, StylePipeDelimited:
+			return escapeValue(param.Name, false) + "=" + s
+		case StyleMatrix:
+			if s == "" {
+				return ";" + escapeValue(param.Name, false)
+			}
+			return ";" + escapeValue(param.Name, false) + "=" + s
+		case StyleLabel:
+			return "." + s
+		}
+		return s
+	}
+
+	switch t := v.(type) {
+	case []any:
+		parts := make([]string, len(t))
+		for i, item := range t {
+			s, err := formatScalar(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = escape(s)
+		}
+		switch {
+		case formLike && param.isExploded():
+			for i := range parts {
+				parts[i] = named(parts[i])
+			}
+			return strings.Join(parts, separator), nil
+		case style == StyleMatrix && param.isExploded():
+			for i := range parts {
+				parts[i] = named(parts[i])
+			}
+			return strings.Join(parts, ""), nil
+		case style == StyleLabel && param.isExploded():
+			return "." + strings.Join(parts, "."), nil
+		}
+		return named(strings.Join(parts, delimiter)), nil
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(t))
+		for _, k := range keys {
+			s, err := formatScalar(t[k])
+			if err != nil {
+				return "", err
+			}
+			if param.isExploded() {
+				parts = append(parts, escape(k)+"="+escape(s))
+			} else {
+				parts = append(parts, escape(k), escape(s))
+			}
+		}
+		switch {
+		case formLike && param.isExploded():
+			return strings.Join(parts, separator), nil
+		case style == StyleMatrix && param.isExploded():
+			return ";" + strings.Join(parts, ";"), nil
+		case style == StyleLabel && param.isExploded():
+			return "." + strings.Join(parts, "."), nil
+		default:
+			return named(strings.Join(parts, delimiter)), nil
+		}
+	default:
+		s, err := formatScalar(t)
+		if err != nil {
+			return "", err
+		}
+		return named(escape(s)), nil
+	}
+}
+
+// encodeDeepObject serializes the object using deepObject style, the nested objects are serialized as `a[b][c]=v`.
+func encodeDeepObject(prefix string, obj map[string]any, escape func(string) string, parts *[]string) error {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := prefix + "[" + escapeValue(k, false) + "]"
+		switch t := obj[k].(type) {
+		case map[string]any:
+			if err := encodeDeepObject(key, t, escape, parts); err != nil {
+				return err
+			}
+		case []any:
+			for _, item := range t {
+				s, err := formatScalar(item)
+				if err != nil {
+					return err
+				}
+				*parts = append(*parts, key+"="+escape(s))
+			}
+		default:
+			s, err := formatScalar(t)
+			if err != nil {
+				return err
+			}
+			*parts = append(*parts, key+"="+escape(s))
+		}
+	}
+	return nil
+}
+
+// DecodeParameter deserializes the raw value of the parameter serialized according to its style and location,
+// it is the opposite of EncodeParameter.
+//
+// The raw value of the query and cookie parameters includes the name of the parameter, e.g. `id=1&id=2`
+// for query or `id=1; id=2` for cookie, other query parameters and cookies are ignored;
+// the raw value of the path and header parameters is the value only, e.g. `1,2`.
+// The values are converted to the types declared by the schema, which must not be a reference,
+// the value of the parameters defined using `content` is parsed according to the media type.
+// Nil is returned if the query or cookie parameter is absent.
+// The percent-encoded delimiters are treated as the delimiters, e.g. `%20` of spaceDelimited style,
+// so the items containing the delimiters can not be decoded.
+func DecodeParameter(param *Parameter, raw string) (any, error) {
+	var (
+		values []string
+		form   map[string][]string
+	)
+	switch param.In {
+	case InQuery:
+		form = parseRawQuery(raw)
+		values = form[param.Name]
+	case InCookie:
+		form = make(map[string][]string)
+		for _, pair := range strings.Split(raw, ";") {
+			k, v, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			if k != "" {
+				form[k] = append(form[k], v)
+			}
+		}
+		values = form[param.Name]
+	default:
+		values = []string{raw}
+	}
+	value, err := param.decode(values, form, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decoding parameter %q failed: %w", param.Name, err)
+	}
+	if obj, ok := value.(map[string]any); len(values) == 0 && (!ok || len(obj) == 0) {
+		return nil, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,674 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testAllowReservedSpec = `
+openapi: 3.1.1
+info:
+  title: Codec
+  version: 1.0.0
+paths:
+  /search:
+    get:
+      parameters:
+        - name: plain
+          in: query
+          schema:
+            type: string
+            enum: ["a b/c"]
+        - name: reserved
+          in: query
+          allowReserved: true
+          schema:
+            type: string
+            enum: ["a+b/c", "%2F"]
+        - name: list
+          in: query
+          style: form
+          schema:
+            type: array
+            items:
+              type: string
+              enum: ["a", "b,c"]
+        - name: reservedList
+          in: query
+          allowReserved: true
+          schema:
+            type: array
+            items:
+              type: string
+              enum: ["/a", "b%2Cc"]
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+          schema:
+            type: object
+            properties:
+              name:
+                type: string
+              age:
+                type: integer
+                minimum: 0
+              tags:
+                type: array
+                items:
+                  type: string
+              address:
+                type: object
+                properties:
+                  zip:
+                    type: integer
+            additionalProperties: false
+      responses:
+        '200':
+          description: OK
+`
+
+func TestValidator_ValidateHTTPRequest_AllowReserved(t *testing.T) {
+	validator := newTestRequestValidator(t, testAllowReservedSpec)
+
+	for _, tt := range []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "plus is space without allowReserved",
+			target: "/search?plain=a+b%2Fc",
+		},
+		{
+			name:   "plus is kept with allowReserved",
+			target: "/search?reserved=a+b/c",
+		},
+		{
+			name:   "encoded reserved character is kept with allowReserved",
+			target: "/search?reserved=%2F",
+		},
+		{
+			name:   "encoded reserved character is decoded without allowReserved",
+			target: "/search?plain=a%20b%2Fc",
+		},
+		{
+			name:   "encoded delimiter is data",
+			target: "/search?list=a,b%2Cc",
+		},
+		{
+			name:   "delimiter splits values",
+			target: "/search?list=a,b,c",
+			err:    "query/list",
+		},
+		{
+			name:   "encoded delimiter with allowReserved",
+			target: "/search?reservedList=/a,b%2Cc",
+		},
+		{
+			name:   "deep object",
+			target: "/search?filter[name]=a%20b&filter[age]=5&filter[tags]=x&filter[tags]=y&filter[address][zip]=10115",
+		},
+		{
+			name:   "deep object encoded brackets",
+			target: "/search?filter%5Bname%5D=a&filter%5Baddress%5D%5Bzip%5D=1",
+		},
+		{
+			name:   "deep object invalid property",
+			target: "/search?filter[age]=-1",
+			err:    "query/filter",
+		},
+		{
+			name:   "deep object nested invalid property",
+			target: "/search?filter[address][zip]=abc",
+			err:    "query/filter",
+		},
+		{
+			name:   "deep object unknown property",
+			target: "/search?filter[foo]=bar",
+			err:    "query/filter",
+		},
+		{
+			name:   "deep object ambiguous key",
+			target: "/search?filter[address]=1&filter[address][zip]=2",
+			err:    "query/filter: invalid format: \"filter[address][zip]\": ambiguous key",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEncodeParameter(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		param    *openapi.Parameter
+		value    any
+		expected string
+		err      string
+	}{
+		{
+			name:     "query primitive",
+			param:    &openapi.Parameter{Name: "q", In: openapi.InQuery},
+			value:    "a b/c",
+			expected: "q=a%20b%2Fc",
+		},
+		{
+			name:     "query primitive allow reserved",
+			param:    &openapi.Parameter{Name: "q", In: openapi.InQuery, AllowReserved: true},
+			value:    "a b/c+d",
+			expected: "q=a%20b/c+d",
+		},
+		{
+			name:     "query array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery},
+			value:    []int{1, 2},
+			expected: "id=1&id=2",
+		},
+		{
+			name:     "query array with encoded delimiter",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery},
+			value:    []string{"a,b", "c"},
+			expected: "id=a%2Cb&id=c",
+		},
+		{
+			name:     "query array not exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery, Explode: explode(false)},
+			value:    []int{1, 2},
+			expected: "id=1,2",
+		},
+		{
+			name:     "query object not exploded",
+			param:    &openapi.Parameter{Name: "obj", In: openapi.InQuery, Style: openapi.StyleForm, Explode: explode(false)},
+			value:    map[string]any{"b": 2, "a": "x"},
+			expected: "obj=a,x,b,2",
+		},
+		{
+			name:     "query object",
+			param:    &openapi.Parameter{Name: "obj", In: openapi.InQuery},
+			value:    map[string]any{"b": 2, "a": "x y"},
+			expected: "a=x%20y&b=2",
+		},
+		{
+			name:     "path array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath},
+			value:    []any{"a/b", 2},
+			expected: "a%2Fb,2",
+		},
+		{
+			name:     "path object exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Explode: explode(true)},
+			value:    map[string]int{"a": 1, "b": 2},
+			expected: "a=1,b=2",
+		},
+		{
+			name:     "matrix primitive",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix},
+			value:    5,
+			expected: ";id=5",
+		},
+		{
+			name:     "matrix empty",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix},
+			value:    "",
+			expected: ";id",
+		},
+		{
+			name:     "matrix array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix},
+			value:    []int{3, 4},
+			expected: ";id=3,4",
+		},
+		{
+			name:     "matrix array exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix, Explode: explode(true)},
+			value:    []int{3, 4},
+			expected: ";id=3;id=4",
+		},
+		{
+			name:     "matrix object",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix},
+			value:    map[string]any{"role": "admin", "name": "Alex"},
+			expected: ";id=name,Alex,role,admin",
+		},
+		{
+			name:     "matrix object exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix, Explode: explode(true)},
+			value:    map[string]any{"role": "admin", "name": "Alex"},
+			expected: ";name=Alex;role=admin",
+		},
+		{
+			name:     "label primitive",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleLabel},
+			value:    "a b",
+			expected: ".a%20b",
+		},
+		{
+			name:     "label array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleLabel},
+			value:    []int{3, 4},
+			expected: ".3,4",
+		},
+		{
+			name:     "label array exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleLabel, Explode: explode(true)},
+			value:    []int{3, 4},
+			expected: ".3.4",
+		},
+		{
+			name:     "label object exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleLabel, Explode: explode(true)},
+			value:    map[string]any{"role": "admin", "name": "Alex"},
+			expected: ".name=Alex.role=admin",
+		},
+		{
+			name:     "header object",
+			param:    &openapi.Parameter{Name: "X-Obj", In: openapi.InHeader},
+			value:    map[string]any{"a": "1 2"},
+			expected: "a,1 2",
+		},
+		{
+			name:     "cookie array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InCookie},
+			value:    []int{1, 2},
+			expected: "id=1; id=2",
+		},
+		{
+			name:     "cookie object",
+			param:    &openapi.Parameter{Name: "prefs", In: openapi.InCookie},
+			value:    map[string]any{"theme": "dark", "size": 10},
+			expected: "size=10; theme=dark",
+		},
+		{
+			name:     "deep object",
+			param:    &openapi.Parameter{Name: "filter", In: openapi.InQuery, Style: openapi.StyleDeepObject},
+			value:    map[string]any{"name": "a b", "age": 5, "tags": []string{"x", "y"}},
+			expected: "filter[age]=5&filter[name]=a%20b&filter[tags]=x&filter[tags]=y",
+		},
+		{
+			name:     "deep object nested",
+			param:    &openapi.Parameter{Name: "filter", In: openapi.InQuery, Style: openapi.StyleDeepObject},
+			value:    map[string]any{"address": map[string]any{"city": "Berlin", "zip": 10115}},
+			expected: "filter[address][city]=Berlin&filter[address][zip]=10115",
+		},
+		{
+			name:     "space delimited array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited},
+			value:    []any{"a b", 2},
+			expected: "id=a%20b%202",
+		},
+		{
+			name:     "space delimited object",
+			param:    &openapi.Parameter{Name: "color", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited},
+			value:    map[string]any{"R": 100, "G": 200},
+			expected: "color=G%20200%20R%20100",
+		},
+		{
+			name:     "pipe delimited array",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: openapi.StylePipeDelimited},
+			value:    []any{"a|b", 2},
+			expected: "id=a%7Cb|2",
+		},
+		{
+			name:     "pipe delimited array exploded",
+			param:    &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: openapi.StylePipeDelimited, Explode: explode(true)},
+			value:    []int{1, 2},
+			expected: "id=1&id=2",
+		},
+		{
+			name:  "unsupported style",
+			param: &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: "tabDelimited"},
+			value: 1,
+			err:   `unsupported style "tabDelimited"`,
+		},
+		{
+			name:  "deep object not an object",
+			param: &openapi.Parameter{Name: "filter", In: openapi.InQuery, Style: openapi.StyleDeepObject},
+			value: []int{1},
+			err:   "deepObject style supports objects only",
+		},
+		{
+			name:  "nested value",
+			param: &openapi.Parameter{Name: "id", In: openapi.InPath},
+			value: []any{[]any{1}},
+			err:   "unsupported nested value",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := openapi.EncodeParameter(tt.param, tt.value)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, s)
+		})
+	}
+}
+
+const testPathStylesSpec = `
+openapi: 3.1.1
+info:
+  title: Codec
+  version: 1.0.0
+paths:
+  /matrix/{id}/{ids}/{obj}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          style: matrix
+          schema:
+            type: integer
+        - name: ids
+          in: path
+          required: true
+          style: matrix
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: obj
+          in: path
+          required: true
+          style: matrix
+          explode: true
+          schema:
+            $ref: '#/components/schemas/Object'
+      responses:
+        '200':
+          description: OK
+  /label/{id}/{ids}/{obj}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          style: label
+          schema:
+            type: integer
+        - name: ids
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: obj
+          in: path
+          required: true
+          style: label
+          schema:
+            $ref: '#/components/schemas/Object'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Object:
+      type: object
+      properties:
+        a:
+          type: integer
+        b:
+          type: string
+      additionalProperties: false
+`
+
+func TestValidator_ValidateHTTPRequest_PathStyles(t *testing.T) {
+	validator := newTestRequestValidator(t, testPathStylesSpec)
+
+	for _, tt := range []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{
+			name:   "matrix",
+			target: "/matrix/;id=5/;ids=1,2/;a=1;b=x%2Cy",
+		},
+		{
+			name:   "matrix invalid primitive",
+			target: "/matrix/;id=x/;ids=1/;a=1",
+			err:    "path/id: invalid data",
+		},
+		{
+			name:   "matrix invalid item",
+			target: "/matrix/;id=5/;ids=1,x/;a=1",
+			err:    "path/ids: invalid data",
+		},
+		{
+			name:   "matrix invalid property",
+			target: "/matrix/;id=5/;ids=1/;a=x",
+			err:    "path/obj: invalid data",
+		},
+		{
+			name:   "matrix without prefix",
+			target: "/matrix/5/;ids=1/;a=1",
+			err:    `path/id: invalid format: matrix style value must start with ";"`,
+		},
+		{
+			name:   "matrix wrong name",
+			target: "/matrix/;key=5/;ids=1/;a=1",
+			err:    `path/id: invalid format: matrix style value must be named "id"`,
+		},
+		{
+			name:   "matrix not exploded repeated",
+			target: "/matrix/;id=5/;ids=1;ids=2/;a=1",
+			err:    "path/ids: invalid format",
+		},
+		{
+			name:   "label",
+			target: "/label/.5/.1.2/.a,1,b,x",
+		},
+		{
+			name:   "label invalid item",
+			target: "/label/.5/.1.x/.a,1",
+			err:    "path/ids: invalid data",
+		},
+		{
+			name:   "label without prefix",
+			target: "/label/5/.1/.a,1",
+			err:    `path/id: invalid format: label style value must start with "."`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDecodeParameter(t *testing.T) {
+	integers := openapi.NewSchemaBuilder().Type(openapi.ArrayType).
+		Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build())).
+		Build()
+	strs := openapi.NewSchemaBuilder().Type(openapi.ArrayType).
+		Items(openapi.NewBoolOrSchema(openapi.NewSchemaBuilder().Type(openapi.StringType).Build())).
+		Build()
+	object := openapi.NewSchemaBuilder().Type(openapi.ObjectType).
+		AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+		AddProperty("age", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		Build()
+	for _, tt := range []struct {
+		name  string
+		param *openapi.Parameter
+		value any
+	}{
+		{
+			name:  "path primitive",
+			param: &openapi.Parameter{Name: "id", In: openapi.InPath, Schema: openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()},
+			value: int64(42),
+		},
+		{
+			name:  "path array",
+			param: &openapi.Parameter{Name: "id", In: openapi.InPath, Schema: strs},
+			value: []any{"a/b", "c"},
+		},
+		{
+			name:  "matrix object exploded",
+			param: &openapi.Parameter{Name: "obj", In: openapi.InPath, Style: openapi.StyleMatrix, Explode: explode(true), Schema: object},
+			value: map[string]any{"name": "Alex", "age": int64(30)},
+		},
+		{
+			name:  "label array exploded",
+			param: &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleLabel, Explode: explode(true), Schema: integers},
+			value: []any{int64(3), int64(4)},
+		},
+		{
+			name:  "query array",
+			param: &openapi.Parameter{Name: "id", In: openapi.InQuery, Schema: integers},
+			value: []any{int64(1), int64(2)},
+		},
+		{
+			name:  "query object",
+			param: &openapi.Parameter{Name: "obj", In: openapi.InQuery, Schema: object},
+			value: map[string]any{"name": "a b", "age": int64(5)},
+		},
+		{
+			name:  "space delimited array",
+			param: &openapi.Parameter{Name: "tags", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited, Schema: strs},
+			value: []any{"a,b", "c"},
+		},
+		{
+			name:  "space delimited object",
+			param: &openapi.Parameter{Name: "obj", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited, Schema: object},
+			value: map[string]any{"name": "Alex", "age": int64(30)},
+		},
+		{
+			name:  "pipe delimited array",
+			param: &openapi.Parameter{Name: "tags", In: openapi.InQuery, Style: openapi.StylePipeDelimited, Schema: strs},
+			value: []any{"a,b", "c d"},
+		},
+		{
+			name:  "pipe delimited array exploded",
+			param: &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: openapi.StylePipeDelimited, Explode: explode(true), Schema: integers},
+			value: []any{int64(1), int64(2)},
+		},
+		{
+			name:  "deep object",
+			param: &openapi.Parameter{Name: "filter", In: openapi.InQuery, Style: openapi.StyleDeepObject, Schema: object},
+			value: map[string]any{"name": "a b", "age": int64(5)},
+		},
+		{
+			name:  "query array not exploded",
+			param: &openapi.Parameter{Name: "id", In: openapi.InQuery, Explode: explode(false), Schema: integers},
+			value: []any{int64(1), int64(2)},
+		},
+		{
+			name:  "query object not exploded",
+			param: &openapi.Parameter{Name: "obj", In: openapi.InQuery, Explode: explode(false), Schema: object},
+			value: map[string]any{"name": "Alex", "age": int64(30)},
+		},
+		{
+			name:  "cookie object not exploded",
+			param: &openapi.Parameter{Name: "obj", In: openapi.InCookie, Explode: explode(false), Schema: object},
+			value: map[string]any{"name": "Alex", "age": int64(30)},
+		},
+		{
+			name:  "header array",
+			param: &openapi.Parameter{Name: "X-Id", In: openapi.InHeader, Schema: integers},
+			value: []any{int64(1), int64(2)},
+		},
+		{
+			name:  "cookie array",
+			param: &openapi.Parameter{Name: "id", In: openapi.InCookie, Schema: integers},
+			value: []any{int64(1), int64(2)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := openapi.EncodeParameter(tt.param, tt.value)
+			require.NoError(t, err)
+			value, err := openapi.DecodeParameter(tt.param, raw)
+			require.NoError(t, err)
+			require.Equal(t, tt.value, normalizeNumbers(value), raw)
+		})
+	}
+
+	t.Run("other query parameters", func(t *testing.T) {
+		param := &openapi.Parameter{Name: "id", In: openapi.InQuery, Style: openapi.StyleSpaceDelimited, Schema: integers}
+		value, err := openapi.DecodeParameter(param, "limit=5&id=1+2&id2=3")
+		require.NoError(t, err)
+		require.Equal(t, []any{int64(1), int64(2)}, normalizeNumbers(value))
+
+		value, err = openapi.DecodeParameter(param, "limit=5")
+		require.NoError(t, err)
+		require.Nil(t, value)
+	})
+
+	t.Run("explode false in spec", func(t *testing.T) {
+		var param *openapi.Parameter
+		require.NoError(t, yaml.Unmarshal([]byte(`
+name: obj
+in: query
+explode: false
+schema:
+  type: object
+  properties:
+    name:
+      type: string
+`), &param))
+		require.NotNil(t, param.Explode)
+		value, err := openapi.DecodeParameter(param, "obj=name,Alex&name=Bob")
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"name": "Alex"}, value)
+	})
+
+	t.Run("content", func(t *testing.T) {
+		param := &openapi.Parameter{Name: "filter", In: openapi.InQuery, Content: map[string]*openapi.Extendable[openapi.MediaType]{
+			"application/json": openapi.NewMediaTypeBuilder().Build(),
+		}}
+		value, err := openapi.DecodeParameter(param, "filter=%7B%22a%22%3A1%7D")
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"a": int64(1)}, normalizeNumbers(value))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		param := &openapi.Parameter{Name: "id", In: openapi.InPath, Style: openapi.StyleMatrix}
+		_, err := openapi.DecodeParameter(param, "id=1")
+		require.ErrorContains(t, err, `decoding parameter "id" failed`)
+	})
+}
+
+// normalizeNumbers converts the decoded JSON numbers to int64 for the comparison.
+func normalizeNumbers(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+	case []any:
+		for i := range t {
+			t[i] = normalizeNumbers(t[i])
+		}
+	case map[string]any:
+		for k := range t {
+			t[k] = normalizeNumbers(t[k])
+		}
+	}
+	return v
+}
+
+func explode(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,210 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The media types of the patch documents validated against the schema of the patched resource.
+const (
+	// MergePatchMediaType is the media type of JSON Merge Patch, RFC 7396.
+	MergePatchMediaType = "application/merge-patch+json"
+	// JSONPatchMediaType is the media type of JSON Patch, RFC 6902.
+	JSONPatchMediaType = "application/json-patch+json"
+)
+
+// validatePatch validates the request body of the patch media type against the schema of the patched resource,
+// which is the schema of the media type in the spec; ok is false for other media types.
+//
+// A merge patch is an object with the properties of the resource, which are all optional:
+// the objects are merged recursively, the null values remove the properties, except the required ones,
+// and other values replace the properties, so they are validated against the schemas of the properties.
+//
+// A JSON patch is a list of operations: `add`, `remove`, `replace`, `move`, `copy` and `test`,
+// with the `path` and `from` pointers resolvable in the schema of the resource,
+// the required properties can not be removed or moved, and the values must be valid for their paths.
+func (v *Validator) validatePatch(mediaType, location string, schema *RefOrSpec[Schema], value any) ([]*validationError, bool) {
+	const loc = "body"
+	switch mediaType {
+	case MergePatchMediaType:
+		return v.validateMergePatch(loc, location, schema, value), true
+	case JSONPatchMediaType:
+		return v.validateJSONPatch(loc, location, schema, value), true
+	}
+	return nil, false
+}
+
+func (v *Validator) validateMergePatch(loc, location string, schema *RefOrSpec[Schema], value any) []*validationError {
+	patch, ok := value.(map[string]any)
+	if !ok {
+		// the patch replaces the value completely
+		if err := v.ValidateData(location, value); err != nil {
+			return []*validationError{newValidationError(loc, "%w: %w", ErrInvalidData, err)}
+		}
+		return nil
+	}
+	var errs []*validationError
+	for _, name := range sortedKeys(patch) {
+		propLoc := joinLoc(loc, name)
+		prop, err := v.patchProperty(location, schema, name)
+		switch {
+		case err != nil:
+			errs = append(errs, newValidationError(propLoc, err))
+		case patch[name] == nil:
+			if prop.required {
+				errs = append(errs, newValidationError(propLoc, "%w: required property can not be removed", ErrNotAllowed))
+			}
+		case prop.schema != nil:
+			errs = append(errs, v.validateMergePatch(propLoc, prop.location, prop.schema, patch[name])...)
+		}
+	}
+	return errs
+}
+
+var jsonPatchOperations = []string{"add", "remove", "replace", "move", "copy", "test"}
+
+func (v *Validator) validateJSONPatch(loc, location string, schema *RefOrSpec[Schema], value any) []*validationError {
+	ops, ok := value.([]any)
+	if !ok {
+		return []*validationError{newValidationError(loc, "%w: JSON patch must be an array of operations", ErrInvalidData)}
+	}
+	var errs []*validationError
+	for i, item := range ops {
+		opLoc := joinLoc(loc, i)
+		op, ok := item.(map[string]any)
+		if !ok {
+			errs = append(errs, newValidationError(opLoc, "%w: operation must be an object", ErrInvalidData))
+			continue
+		}
+		name, _ := op["op"].(string)
+		if !slices.Contains(jsonPatchOperations, name) {
+			errs = append(errs, newValidationError(joinLoc(opLoc, "op"), "%w: expected one of [%s], but got '%v'",
+				ErrInvalidData, strings.Join(jsonPatchOperations, ", "), op["op"]))
+			continue
+		}
+		target, err := v.resolvePatchPointer(location, schema, op["path"])
+		if err != nil {
+			errs = append(errs, newValidationError(joinLoc(opLoc, "path"), err))
+			continue
+		}
+		switch name {
+		case "remove":
+			if target.required {
+				errs = append(errs, newValidationError(joinLoc(opLoc, "path"), "%w: required property can not be removed", ErrNotAllowed))
+			}
+		case "move", "copy":
+			from, err := v.resolvePatchPointer(location, schema, op["from"])
+			if err != nil {
+				errs = append(errs, newValidationError(joinLoc(opLoc, "from"), err))
+				continue
+			}
+			if name == "move" && from.required {
+				errs = append(errs, newValidationError(joinLoc(opLoc, "from"), "%w: required property can not be moved", ErrNotAllowed))
+			}
+		default:
+			value, ok := op["value"]
+			if !ok {
+				errs = append(errs, newValidationError(joinLoc(opLoc, "value"), ErrRequired))
+				continue
+			}
+			if target.schema == nil {
+				continue
+			}
+			if err := v.ValidateData(target.location, value); err != nil {
+				errs = append(errs, newValidationError(joinLoc(opLoc, "value"), "%w: %w", ErrInvalidData, err))
+			}
+		}
+	}
+	return errs
+}
+
+// patchTarget is the schema of a value of the patched resource.
+type patchTarget struct {
+	// schema is nil if the schema is unknown, e.g. it is a branch of `oneOf`, so the value is not validated
+	schema   *RefOrSpec[Schema]
+	location string
+	// required means that the value is a required property of its parent
+	required bool
+}
+
+// resolvePatchPointer resolves the JSON pointer of a JSON patch operation in the schema of the resource.
+func (v *Validator) resolvePatchPointer(location string, schema *RefOrSpec[Schema], pointer any) (*patchTarget, error) {
+	p, ok := pointer.(string)
+	if !ok || p != "" && !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("%w: must be a JSON pointer, but got '%v'", ErrInvalidData, pointer)
+	}
+	target := &patchTarget{schema: schema, location: location}
+	for _, segment := range Location(p).Segments() {
+		if target.schema == nil {
+			return target, nil
+		}
+		s, err := target.schema.GetSpec(v.spec.Spec.Components)
+		if err != nil {
+			return nil, err
+		}
+		if s.Items != nil || len(s.PrefixItems) > 0 {
+			i, err := strconv.Atoi(segment)
+			switch {
+			case segment == "-" || err == nil && i >= len(s.PrefixItems):
+				target = &patchTarget{location: joinLoc(target.location, "items")}
+				if s.Items != nil {
+					target.schema = s.Items.Schema
+				}
+			case err == nil && i >= 0:
+				target = &patchTarget{schema: s.PrefixItems[i], location: joinLoc(target.location, "prefixItems", i)}
+			default:
+				return nil, fmt.Errorf("%w: '%s' is not an index of the array", ErrInvalidData, segment)
+			}
+			if target.schema != nil {
+				target.location = target.schema.getLocationOrRef(target.location)
+			}
+			continue
+		}
+		if target, err = v.patchProperty(target.location, target.schema, segment); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+// patchProperty returns the schema of the property of the object, looking into `allOf` branches and `$ref` too.
+// An error is returned if the property is not allowed by `additionalProperties: false`.
+func (v *Validator) patchProperty(location string, schema *RefOrSpec[Schema], name string) (*patchTarget, error) {
+	target := &patchTarget{}
+	var additional *BoolOrSchema
+	var additionalLoc string
+	visited := make(map[*Schema]bool)
+	var visit func(location string, ref *RefOrSpec[Schema])
+	visit = func(location string, ref *RefOrSpec[Schema]) {
+		s, err := ref.GetSpec(v.spec.Spec.Components)
+		if err != nil || s == nil || visited[s] {
+			return
+		}
+		visited[s] = true
+		target.required = target.required || slices.Contains(s.Required, name)
+		if prop, ok := s.Properties[name]; ok && target.schema == nil {
+			target.schema, target.location = prop, prop.getLocationOrRef(joinLoc(location, "properties", name))
+		}
+		if s.AdditionalProperties != nil && additional == nil {
+			additional, additionalLoc = s.AdditionalProperties, joinLoc(location, "additionalProperties")
+		}
+		for i, branch := range s.AllOf {
+			visit(branch.getLocationOrRef(joinLoc(location, "allOf", i)), branch)
+		}
+		if s.Ref != "" {
+			visit(s.Ref, NewRefOrSpec[Schema](s.Ref))
+		}
+	}
+	visit(location, schema)
+	switch {
+	case target.schema != nil:
+	case additional == nil:
+	case additional.Schema != nil:
+		target.schema, target.location = additional.Schema, additional.Schema.getLocationOrRef(additionalLoc)
+	case !additional.Allowed:
+		return nil, fmt.Errorf("%w: property '%s' is not defined", ErrNotAllowed, name)
+	}
+	return target, nil
+}
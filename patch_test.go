@@ -0,0 +1,175 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testPatchSpec = `
+openapi: 3.1.1
+info:
+  title: Patch
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    patch:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        content:
+          application/merge-patch+json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/json-patch+json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: patched
+components:
+  schemas:
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+      type: object
+      properties:
+        age:
+          type: integer
+          minimum: 0
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+            email:
+              type: string
+          required: [name]
+        tags:
+          type: array
+          items:
+            type: string
+      additionalProperties: false
+    Named:
+      type: object
+      properties:
+        name:
+          type: string
+      required: [name]
+`
+
+func TestValidator_ValidateHTTPRequest_Patch(t *testing.T) {
+	validator := newTestRequestValidator(t, testPatchSpec)
+
+	for _, tt := range []struct {
+		name        string
+		contentType string
+		body        string
+		err         []string
+	}{
+		{
+			name:        "merge patch with some properties",
+			contentType: openapi.MergePatchMediaType,
+			body:        `{"age": 3, "owner": {"email": "a@b.c"}, "tags": ["dog"]}`,
+		},
+		{
+			name:        "merge patch removes optional property",
+			contentType: openapi.MergePatchMediaType,
+			body:        `{"age": null, "owner": {"email": null}}`,
+		},
+		{
+			name:        "merge patch removes required property",
+			contentType: openapi.MergePatchMediaType,
+			body:        `{"name": null, "owner": {"name": null}}`,
+			err: []string{
+				"body/name: not allowed: required property can not be removed",
+				"body/owner/name: not allowed: required property can not be removed",
+			},
+		},
+		{
+			name:        "merge patch with invalid value",
+			contentType: openapi.MergePatchMediaType,
+			body:        `{"age": -1, "tags": "dog"}`,
+			err:         []string{"body/age: invalid data", "body/tags: invalid data"},
+		},
+		{
+			name:        "merge patch with unknown property",
+			contentType: openapi.MergePatchMediaType,
+			body:        `{"color": "black"}`,
+			err:         []string{"body/color: not allowed: property 'color' is not defined"},
+		},
+		{
+			name:        "merge patch is not an object",
+			contentType: openapi.MergePatchMediaType,
+			body:        `[]`,
+			err:         []string{"body: invalid data"},
+		},
+		{
+			name:        "json patch",
+			contentType: openapi.JSONPatchMediaType,
+			body: `[
+				{"op": "replace", "path": "/name", "value": "Rex"},
+				{"op": "add", "path": "/tags/-", "value": "dog"},
+				{"op": "remove", "path": "/owner/email"},
+				{"op": "copy", "from": "/name", "path": "/owner/name"},
+				{"op": "test", "path": "/age", "value": 3}
+			]`,
+		},
+		{
+			name:        "json patch with invalid operations",
+			contentType: openapi.JSONPatchMediaType,
+			body: `[
+				{"op": "update", "path": "/name"},
+				{"op": "add", "path": "name", "value": "Rex"},
+				{"op": "add", "path": "/color", "value": "black"},
+				{"op": "replace", "path": "/age"},
+				{"op": "replace", "path": "/tags/0", "value": 1},
+				{"op": "remove", "path": "/owner/name"},
+				{"op": "move", "from": "/name", "path": "/owner/name"},
+				{"op": "add", "path": "/tags/x", "value": "dog"},
+				42
+			]`,
+			err: []string{
+				"body/0/op: invalid data: expected one of [add, remove, replace, move, copy, test], but got 'update'",
+				"body/1/path: invalid data: must be a JSON pointer, but got 'name'",
+				"body/2/path: not allowed: property 'color' is not defined",
+				"body/3/value: required",
+				"body/4/value: invalid data",
+				"body/5/path: not allowed: required property can not be removed",
+				"body/6/from: not allowed: required property can not be moved",
+				"body/7/path: invalid data: 'x' is not an index of the array",
+				"body/8: invalid data: operation must be an object",
+			},
+		},
+		{
+			name:        "json patch is not an array",
+			contentType: openapi.JSONPatchMediaType,
+			body:        `{"op": "remove", "path": "/age"}`,
+			err:         []string{"body: invalid data: JSON patch must be an array of operations"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/pets/1", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			err := validator.ValidateHTTPRequest(r)
+			if len(tt.err) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, e := range tt.err {
+				require.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
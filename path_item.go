@@ -1,5 +1,10 @@
 package openapi
 
+import (
+	"net/http"
+	"strings"
+)
+
 // PathItem describes the operations available on a single path.
 // A Path Item MAY be empty, due to ACL constraints.
 // The path itself is still exposed to the documentation viewer but they will not know which operations and parameters are available.
@@ -108,96 +113,62 @@ func (o *PathItem) validateSpec(location string, validator *Validator) []*valida
 	return errs
 }
 
-type PathItemBuilder struct {
-	spec *RefOrSpec[Extendable[PathItem]]
-}
-
-func NewPathItemBuilder() *PathItemBuilder {
-	return &PathItemBuilder{
-		spec: NewRefOrExtSpec[PathItem](&PathItem{}),
+// operation returns the operation for the given HTTP method or nil.
+func (o *PathItem) operation(method string) *Extendable[Operation] {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return o.Get
+	case http.MethodPut:
+		return o.Put
+	case http.MethodPost:
+		return o.Post
+	case http.MethodDelete:
+		return o.Delete
+	case http.MethodOptions:
+		return o.Options
+	case http.MethodHead:
+		return o.Head
+	case http.MethodPatch:
+		return o.Patch
+	case http.MethodTrace:
+		return o.Trace
 	}
-}
-
-func (b *PathItemBuilder) Build() *RefOrSpec[Extendable[PathItem]] {
-	return b.spec
-}
-
-func (b *PathItemBuilder) Extensions(v map[string]any) *PathItemBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *PathItemBuilder) AddExt(name string, value any) *PathItemBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *PathItemBuilder) Summary(v string) *PathItemBuilder {
-	b.spec.Spec.Spec.Summary = v
-	return b
-}
-
-func (b *PathItemBuilder) Description(v string) *PathItemBuilder {
-	b.spec.Spec.Spec.Description = v
-	return b
-}
-
-func (b *PathItemBuilder) Get(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Get = v
-	return b
-}
-
-func (b *PathItemBuilder) Put(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Put = v
-	return b
-}
-
-func (b *PathItemBuilder) Post(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Post = v
-	return b
-}
-
-func (b *PathItemBuilder) Delete(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Delete = v
-	return b
-}
-
-func (b *PathItemBuilder) Options(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Options = v
-	return b
-}
-
-func (b *PathItemBuilder) Head(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Head = v
-	return b
-}
-
-func (b *PathItemBuilder) Patch(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Patch = v
-	return b
-}
-
-func (b *PathItemBuilder) Trace(v *Extendable[Operation]) *PathItemBuilder {
-	b.spec.Spec.Spec.Trace = v
-	return b
-}
-
-func (b *PathItemBuilder) Servers(v ...*Extendable[Server]) *PathItemBuilder {
-	b.spec.Spec.Spec.Servers = v
-	return b
-}
-
-func (b *PathItemBuilder) AddServers(v ...*Extendable[Server]) *PathItemBuilder {
-	b.spec.Spec.Spec.Servers = append(b.spec.Spec.Spec.Servers, v...)
-	return b
-}
-
-func (b *PathItemBuilder) Parameters(v ...*RefOrSpec[Extendable[Parameter]]) *PathItemBuilder {
-	b.spec.Spec.Spec.Parameters = v
-	return b
-}
-
-func (b *PathItemBuilder) AddParameters(v ...*RefOrSpec[Extendable[Parameter]]) *PathItemBuilder {
-	b.spec.Spec.Spec.Parameters = append(b.spec.Spec.Spec.Parameters, v...)
-	return b
+	return nil
+}
+
+// pathItemMethods is the list of the methods supported by PathItem in the order of its fields.
+var pathItemMethods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodPatch,
+	http.MethodTrace,
+}
+
+// setOperation sets the operation for the given HTTP method and reports whether the method is supported.
+func (o *PathItem) setOperation(method string, op *Extendable[Operation]) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		o.Get = op
+	case http.MethodPut:
+		o.Put = op
+	case http.MethodPost:
+		o.Post = op
+	case http.MethodDelete:
+		o.Delete = op
+	case http.MethodOptions:
+		o.Options = op
+	case http.MethodHead:
+		o.Head = op
+	case http.MethodPatch:
+		o.Patch = op
+	case http.MethodTrace:
+		o.Trace = op
+	default:
+		return false
+	}
+	return true
 }
@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -63,10 +64,10 @@ func (o *Paths) validateSpec(location string, validator *Validator) []*validatio
 	var errs []*validationError
 	for k, v := range o.Paths {
 		if !strings.HasPrefix(k, "/") {
-			errs = append(errs, newValidationError(joinLoc(location, k), "path must start with a forward slash (`/`)"))
+			errs = append(errs, newValidationError(joinLoc(location, k), "%w: path must start with a forward slash (`/`)", ErrInvalidFormat))
 		}
 		if v == nil {
-			errs = append(errs, newValidationError(joinLoc(location, k), "path item cannot be empty"))
+			errs = append(errs, newValidationError(joinLoc(location, k), "path item is %w", ErrRequired))
 		} else {
 			errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
 		}
@@ -88,3 +89,65 @@ func (o *Paths) Add(path string, item *RefOrSpec[Extendable[PathItem]]) *Paths {
 func NewPaths() *Extendable[Paths] {
 	return NewExtendable[Paths](&Paths{})
 }
+
+// sortPathTemplates sorts the paths in the order of matching:
+// the concrete paths are matched before their templated counterparts,
+// and the templates with more literal segments are preferred.
+func sortPathTemplates(templates []string) {
+	sort.Slice(templates, func(i, j int) bool {
+		ni, nj := strings.Count(templates[i], "{"), strings.Count(templates[j], "{")
+		if ni != nj {
+			return ni < nj
+		}
+		return templates[i] < templates[j]
+	})
+}
+
+// matchPathTemplate checks if the given path matches the templated path and returns the values of path parameters.
+//
+// Example:
+//
+//	matchPathTemplate("/pets/{petId}", "/pets/42") // map[string]string{"petId": "42"}, true
+//	matchPathTemplate("/files/{name}.{ext}", "/files/report.pdf") // map[string]string{"name": "report", "ext": "pdf"}, true
+func matchPathTemplate(template, path string) (map[string]string, bool) {
+	tParts := strings.Split(strings.Trim(template, "/"), "/")
+	pParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(tParts) != len(pParts) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, t := range tParts {
+		if !matchPathSegment(t, pParts[i], params) {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// matchPathSegment checks if the segment of the path matches the segment of the templated path
+// with any number of the path parameters and adds their values to params.
+// The values must not be empty and are matched greedily,
+// so `{name}.{ext}` matches `archive.tar.gz` with name `archive.tar` and ext `gz`.
+func matchPathSegment(template, segment string, params map[string]string) bool {
+	start := strings.IndexByte(template, '{')
+	if start < 0 {
+		return template == segment
+	}
+	end := strings.IndexByte(template[start:], '}')
+	if end < 0 {
+		return false
+	}
+	end += start
+	prefix, name, rest := template[:start], template[start+1:end], template[end+1:]
+	if !strings.HasPrefix(segment, prefix) {
+		return false
+	}
+	segment = segment[len(prefix):]
+	for i := len(segment); i > 0; i-- {
+		if matchPathSegment(rest, segment[i:], params) {
+			params[name] = segment[:i]
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+const pathsRefPrefix = "#/paths/"
+
+// AddPathPrefix prepends the base path to all paths of the given spec, e.g. `/pets` becomes `/api/v1/pets`
+// for the `/api/v1` prefix, and rewrites the local references and link operation references to the paths.
+// The trailing slash of the prefix is ignored.
+func AddPathPrefix(spec *Extendable[OpenAPI], prefix string) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	renamePaths(spec, func(path string) string {
+		return prefix + path
+	})
+}
+
+// StripPathPrefix removes the base path from all paths of the given spec, e.g. `/api/v1/pets` becomes `/pets`
+// for the `/api/v1` prefix, and rewrites the local references and link operation references to the paths.
+// The prefix is matched by the path segments, so `/api` is not a prefix of `/apis`;
+// the path equal to the prefix becomes `/`.
+// An error is returned if any path does not start with the prefix, the spec is not modified in this case.
+func StripPathPrefix(spec *Extendable[OpenAPI], prefix string) error {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" || spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return nil
+	}
+	for path := range spec.Spec.Paths.Spec.Paths {
+		if _, ok := cutPathPrefix(path, prefix); !ok {
+			return newValidationError(joinLoc("/paths", path), "%w: path does not start with the prefix %q", ErrInvalidValue, prefix)
+		}
+	}
+	renamePaths(spec, func(path string) string {
+		p, _ := cutPathPrefix(path, prefix)
+		return p
+	})
+	return nil
+}
+
+// CommonPathPrefix returns the longest sequence of the leading path segments shared by all paths of the given spec,
+// or an empty string if there is none.
+// The path templates are not part of the prefix, so it can be safely stripped.
+func CommonPathPrefix(spec *Extendable[OpenAPI]) string {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return ""
+	}
+	var common []string
+	first := true
+	for path := range spec.Spec.Paths.Spec.Paths {
+		// the last segment is the endpoint itself, even if it is shared by all paths
+		segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+		segments = segments[:len(segments)-1]
+		if first {
+			common = segments
+			first = false
+		}
+		n := 0
+		for n < len(common) && n < len(segments) && common[n] == segments[n] && !strings.Contains(segments[n], "{") {
+			n++
+		}
+		common = common[:n]
+	}
+	if len(common) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(common, "/")
+}
+
+// SetServers replaces the servers of the given spec and removes the alternative servers of all paths and operations,
+// so the given servers are used across the whole document, e.g. when the service is mounted behind a gateway.
+func SetServers(spec *Extendable[OpenAPI], servers ...*Extendable[Server]) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	spec.Spec.Servers = servers
+	if spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	for _, item := range spec.Spec.Paths.Spec.Paths {
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			continue
+		}
+		item.Spec.Spec.Servers = nil
+		for _, method := range pathItemMethods {
+			if op := item.Spec.Spec.operation(method); op != nil && op.Spec != nil {
+				op.Spec.Servers = nil
+			}
+		}
+	}
+}
+
+// cutPathPrefix returns the path without the prefix matched by the segments.
+func cutPathPrefix(path, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, prefix)
+	switch {
+	case !ok:
+		return "", false
+	case rest == "":
+		return "/", true
+	case rest[0] != '/':
+		return "", false
+	}
+	return rest, true
+}
+
+// renamePaths renames the paths of the given spec and rewrites the local references to them,
+// including the operation references of the links.
+func renamePaths(spec *Extendable[OpenAPI], rename func(path string) string) {
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return
+	}
+	paths := spec.Spec.Paths.Spec.Paths
+	renamed := make(map[string]*RefOrSpec[Extendable[PathItem]], len(paths))
+	names := make(map[string]string, len(paths))
+	for path, item := range paths {
+		names[path] = rename(path)
+		renamed[names[path]] = item
+	}
+	spec.Spec.Paths.Spec.Paths = renamed
+
+	renameRef := func(ref string) string {
+		rest, ok := strings.CutPrefix(ref, pathsRefPrefix)
+		if !ok {
+			return ref
+		}
+		path, tail, hasTail := strings.Cut(rest, "/")
+		newPath, ok := names[jsonPointerUnescaper.Replace(path)]
+		if !ok {
+			// a broken reference is kept as is
+			return ref
+		}
+		ref = pathsRefPrefix + jsonPointerEscaper.Replace(newPath)
+		if hasTail {
+			ref += "/" + tail
+		}
+		return ref
+	}
+	walkSpec(reflect.ValueOf(spec), func(v reflect.Value) {
+		switch t := v.Interface().(type) {
+		case *Ref:
+			t.Ref = renameRef(t.Ref)
+		case *Link:
+			t.OperationRef = renameRef(t.OperationRef)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testPathsRewriteSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://pets.internal
+paths:
+  /v1/pets:
+    servers:
+      - url: https://pets.internal/v1
+    get:
+      servers:
+        - url: https://pets.internal/v1/get
+      responses:
+        '201':
+          description: OK
+          links:
+            pet:
+              operationRef: '#/paths/~1v1~1pets~1{id}/get'
+  /v1/pets/{id}:
+    get:
+      responses:
+        '200':
+          $ref: '#/paths/~1v1~1pets/get/responses/201'
+`
+
+func TestAddPathPrefix(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testPathsRewriteSpec), &spec))
+	openapi.AddPathPrefix(spec, "api/")
+
+	paths := spec.Spec.Paths.Spec.Paths
+	require.Len(t, paths, 2)
+	require.Contains(t, paths, "/api/v1/pets")
+	require.Contains(t, paths, "/api/v1/pets/{id}")
+	require.Equal(t, "#/paths/~1api~1v1~1pets/get/responses/201", paths["/api/v1/pets/{id}"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"].Ref.Ref)
+	link := paths["/api/v1/pets"].Spec.Spec.Get.Spec.Responses.Spec.Response["201"].Spec.Spec.Links["pet"].Spec.Spec
+	require.Equal(t, "#/paths/~1api~1v1~1pets~1{id}/get", link.OperationRef)
+}
+
+func TestStripPathPrefix(t *testing.T) {
+	t.Run("common prefix", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testPathsRewriteSpec), &spec))
+		prefix := openapi.CommonPathPrefix(spec)
+		require.Equal(t, "/v1", prefix)
+		require.NoError(t, openapi.StripPathPrefix(spec, prefix))
+
+		paths := spec.Spec.Paths.Spec.Paths
+		require.Contains(t, paths, "/pets")
+		require.Contains(t, paths, "/pets/{id}")
+		require.Equal(t, "#/paths/~1pets/get/responses/201", paths["/pets/{id}"].Spec.Spec.Get.Spec.Responses.Spec.Response["200"].Ref.Ref)
+	})
+
+	t.Run("prefix equal to path", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testPathsRewriteSpec), &spec))
+		require.NoError(t, openapi.StripPathPrefix(spec, "/v1/pets"))
+		require.Contains(t, spec.Spec.Paths.Spec.Paths, "/")
+		require.Contains(t, spec.Spec.Paths.Spec.Paths, "/{id}")
+	})
+
+	t.Run("not a segment", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testPathsRewriteSpec), &spec))
+		err := openapi.StripPathPrefix(spec, "/v1/pe")
+		require.ErrorIs(t, err, openapi.ErrInvalidValue)
+		require.Contains(t, spec.Spec.Paths.Spec.Paths, "/v1/pets")
+	})
+}
+
+func TestCommonPathPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		paths    []string
+		expected string
+	}{
+		{name: "no paths"},
+		{name: "single path", paths: []string{"/api/pets"}, expected: "/api"},
+		{name: "root", paths: []string{"/pets", "/users"}},
+		{name: "partial segment", paths: []string{"/api/pets", "/apis/pets"}},
+		{name: "template", paths: []string{"/{tenant}/pets", "/{tenant}/users"}},
+		{name: "nested", paths: []string{"/api/v1/pets", "/api/v1/users/{id}", "/api/v1/users"}, expected: "/api/v1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b := openapi.NewOpenAPIBuilder()
+			for _, p := range tt.paths {
+				b.AddPath(p, openapi.NewPathItemBuilder().Build())
+			}
+			require.Equal(t, tt.expected, openapi.CommonPathPrefix(b.Build()))
+		})
+	}
+}
+
+func TestSetServers(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testPathsRewriteSpec), &spec))
+	openapi.SetServers(spec, openapi.NewServerBuilder().URL("https://gateway.example.com/pets").Build())
+
+	require.Len(t, spec.Spec.Servers, 1)
+	require.Equal(t, "https://gateway.example.com/pets", spec.Spec.Servers[0].Spec.URL)
+	item := spec.Spec.Paths.Spec.Paths["/v1/pets"].Spec.Spec
+	require.Nil(t, item.Servers)
+	require.Nil(t, item.Get.Spec.Servers)
+}
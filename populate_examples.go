@@ -0,0 +1,292 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// maxExampleDepth is the depth of the nested objects of the synthesized examples,
+// the deeper objects get the required properties only, so the recursive schemas end.
+const maxExampleDepth = 3
+
+// PopulateExamples sets the example of every media type, parameter, and header having the schema,
+// but neither `example` nor `examples`, so the documentation renderers show the examples everywhere.
+//
+// The example is the first of the examples, the example, the default, the const or the first of the enum values
+// of the schema, otherwise it is synthesized from the schema: all properties of the objects, one item of the arrays
+// (or minItems), the minimums of the numbers, and the strings of the formats, the patterns, and the lengths;
+// the first schema of `oneOf` and `anyOf` is used along with the discriminator value, and `allOf` schemas are merged.
+//
+// An error is returned if a referenced schema can not be resolved.
+func PopulateExamples(spec *Extendable[OpenAPI]) error {
+	if spec == nil || spec.Spec == nil {
+		return nil
+	}
+	components := spec.Spec.Components
+	return Walk(spec, func(node *WalkNode) error {
+		var (
+			schema  *RefOrSpec[Schema]
+			example *any
+		)
+		switch v := node.Value.(type) {
+		case *MediaType:
+			if v.Example == nil && len(v.Examples) == 0 {
+				schema, example = v.Schema, &v.Example
+			}
+		case *Parameter:
+			if v.Example == nil && len(v.Examples) == 0 {
+				schema, example = v.Schema, &v.Example
+			}
+		case *Header:
+			if v.Example == nil && len(v.Examples) == 0 {
+				schema, example = v.Schema, &v.Example
+			}
+		case *Schema:
+			// the examples are not set inside of the schemas
+			return ErrSkipChildren
+		default:
+			return nil
+		}
+		if schema == nil {
+			return nil
+		}
+		value, err := synthesizeExample(schema, components, 0)
+		if err != nil {
+			return fmt.Errorf("%s: %w", node.Location, err)
+		}
+		*example = value
+		return nil
+	})
+}
+
+// synthesizeExample returns the example of the schema, see PopulateExamples.
+func synthesizeExample(ref *RefOrSpec[Schema], components *Extendable[Components], depth int) (any, error) {
+	if ref == nil || depth > maxExampleDepth*2 {
+		return nil, nil
+	}
+	s, err := ResolveSchema(ref, components)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := schemaSample(s); ok {
+		return v, nil
+	}
+	if s.Example != nil {
+		return s.Example, nil
+	}
+	switch {
+	case len(s.AllOf) > 0:
+		merged := make(map[string]any)
+		rest := *s
+		rest.AllOf = nil
+		for _, sub := range append(slices.Clip(s.AllOf), NewRefOrSpec[Schema](&rest)) {
+			v, err := synthesizeExample(sub, components, depth)
+			if err != nil {
+				return nil, err
+			}
+			obj, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			for k, item := range obj {
+				merged[k] = item
+			}
+		}
+		return merged, nil
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		first := append(slices.Clip(s.OneOf), s.AnyOf...)[0]
+		v, err := synthesizeExample(first, components, depth)
+		if err != nil {
+			return nil, err
+		}
+		if obj, ok := v.(map[string]any); ok && s.Discriminator != nil && first.Ref != nil {
+			obj[s.Discriminator.PropertyName] = discriminatorValue(s.Discriminator, first.Ref.Ref)
+		}
+		return v, nil
+	}
+	switch schemaType(s) {
+	case ObjectType:
+		obj := make(map[string]any, len(s.Properties))
+		for _, name := range sortedKeys(s.Properties) {
+			if depth >= maxExampleDepth && !slices.Contains(s.Required, name) {
+				continue
+			}
+			v, err := synthesizeExample(s.Properties[name], components, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj[name] = v
+		}
+		return obj, nil
+	case ArrayType:
+		n := 1
+		if s.MinItems != nil {
+			n = *s.MinItems
+		}
+		if s.MaxItems != nil {
+			n = min(n, *s.MaxItems)
+		}
+		var items *RefOrSpec[Schema]
+		if s.Items != nil {
+			items = s.Items.Schema
+		}
+		arr := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := synthesizeExample(items, components, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case IntegerType:
+		return numberExample(s, true), nil
+	case NumberType:
+		return numberExample(s, false), nil
+	case BooleanType:
+		return true, nil
+	case NullType:
+		return nil, nil
+	}
+	return stringExample(s), nil
+}
+
+// discriminatorValue returns the value of the discriminator property for the referenced schema:
+// the key of the mapping or the name of the schema.
+func discriminatorValue(d *Discriminator, ref string) string {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	for _, k := range sortedKeys(d.Mapping) {
+		if v := d.Mapping[k]; v == ref || v == name {
+			return k
+		}
+	}
+	return name
+}
+
+// numberExample returns the minimum, the exclusive minimum moved up, or the maximum if it is below 1;
+// 1 by default. The value is rounded up to the multipleOf.
+func numberExample(s *Schema, integer bool) any {
+	v := 1.0
+	switch {
+	case s.Minimum != nil:
+		v, _ = s.Minimum.Float64()
+	case s.ExclusiveMinimum != nil:
+		v, _ = s.ExclusiveMinimum.Float64()
+		if integer || s.Maximum == nil {
+			v = math.Floor(v) + 1
+		} else if hi, _ := s.Maximum.Float64(); hi > v {
+			v = (v + hi) / 2
+		}
+	case s.Maximum != nil:
+		if hi, _ := s.Maximum.Float64(); hi < v {
+			v = hi
+		}
+	case s.ExclusiveMaximum != nil:
+		if hi, _ := s.ExclusiveMaximum.Float64(); hi <= v {
+			v = math.Ceil(hi) - 1
+		}
+	}
+	if s.MultipleOf != nil {
+		if m, err := s.MultipleOf.Float64(); err == nil && m > 0 {
+			v = math.Ceil(v/m) * m
+		}
+	}
+	if integer {
+		return int64(math.Ceil(v))
+	}
+	return v
+}
+
+// formatExamples are the examples of the well-known formats.
+var formatExamples = map[string]string{
+	"date-time":     "2024-01-01T00:00:00Z",
+	"date":          "2024-01-01",
+	"time":          "00:00:00Z",
+	"duration":      "PT1H",
+	"email":         "user@example.com",
+	"idn-email":     "user@example.com",
+	"hostname":      "example.com",
+	"idn-hostname":  "example.com",
+	"ipv4":          "192.0.2.1",
+	"ipv6":          "2001:db8::1",
+	"uri":           "https://example.com",
+	"iri":           "https://example.com",
+	"uri-reference": "/example",
+	"iri-reference": "/example",
+	"uri-template":  "https://example.com/{id}",
+	"uuid":          "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"byte":          "c3RyaW5n",
+	"json-pointer":  "/example",
+	"regex":         "^example$",
+	"password":      "********",
+}
+
+// stringExample returns the example of the format, a string matching the pattern, or `string` of the lengths.
+func stringExample(s *Schema) string {
+	if v, ok := formatExamples[s.Format]; ok {
+		return v
+	}
+	if s.Pattern != "" {
+		if re, err := syntax.Parse(s.Pattern, syntax.Perl); err == nil {
+			var sb strings.Builder
+			patternExample(&sb, re.Simplify())
+			if ok, _ := regexp.MatchString(s.Pattern, sb.String()); ok {
+				return sb.String()
+			}
+		}
+	}
+	v := "string"
+	if s.MinLength != nil && len(v) < *s.MinLength {
+		v += strings.Repeat("s", *s.MinLength-len(v))
+	}
+	if s.MaxLength != nil && len(v) > *s.MaxLength {
+		v = v[:*s.MaxLength]
+	}
+	return v
+}
+
+// patternExample writes the shortest string matching the regular expression:
+// the first alternatives, `a` or the first characters of the classes, and the minimum repetitions.
+func patternExample(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		// the ranges are the pairs of the first and the last runes
+		r := 'a'
+		if len(re.Rune) > 1 && !classContains(re.Rune, r) {
+			r = re.Rune[0]
+		}
+		sb.WriteRune(r)
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte('a')
+	case syntax.OpCapture:
+		patternExample(sb, re.Sub[0])
+	case syntax.OpPlus:
+		patternExample(sb, re.Sub[0])
+	case syntax.OpRepeat:
+		for i := 0; i < re.Min; i++ {
+			patternExample(sb, re.Sub[0])
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			patternExample(sb, sub)
+		}
+	case syntax.OpAlternate:
+		patternExample(sb, re.Sub[0])
+	}
+}
+
+// classContains reports whether the rune is in the ranges of the character class.
+func classContains(ranges []rune, r rune) bool {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if ranges[i] <= r && r <= ranges[i+1] {
+			return true
+		}
+	}
+	return false
+}
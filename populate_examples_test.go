@@ -0,0 +1,108 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestPopulateExamples(t *testing.T) {
+	const data = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 1
+    get:
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [name, kind]
+        - name: trace
+          in: header
+          example: abc
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+                exclusiveMinimum: 0
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: '#/components/schemas/Cat'
+    Cat:
+      allOf:
+        - type: object
+          properties:
+            id:
+              type: string
+              format: uuid
+            code:
+              type: string
+              pattern: '^[A-Z]{3}-\d{2}$'
+            name:
+              type: string
+              default: Tom
+            parent:
+              $ref: '#/components/schemas/Cat'
+        - type: object
+          required: [weight]
+          properties:
+            weight:
+              type: number
+              minimum: 0.5
+`
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, openapi.Unmarshal([]byte(data), &spec))
+	require.NoError(t, openapi.PopulateExamples(spec))
+
+	item := spec.Spec.Paths.Spec.Paths["/pets/{id}"].Spec.Spec
+	require.Equal(t, int64(1), item.Parameters[0].Spec.Spec.Example)
+	get := item.Get.Spec
+	require.Equal(t, []any{"name"}, get.Parameters[0].Spec.Spec.Example)
+	require.Equal(t, "abc", get.Parameters[1].Spec.Spec.Example, "the existing example is kept")
+
+	resp := get.Responses.Spec.Response["200"].Spec.Spec
+	require.Equal(t, int64(1), resp.Headers["X-Rate-Limit"].Spec.Spec.Example)
+	pet, ok := resp.Content["application/json"].Spec.Example.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "cat", pet["kind"])
+	require.Equal(t, "3fa85f64-5717-4562-b3fc-2c963f66afa6", pet["id"])
+	require.Equal(t, "AAA-00", pet["code"])
+	require.Equal(t, "Tom", pet["name"])
+	require.Equal(t, 0.5, pet["weight"])
+	require.Contains(t, pet, "parent")
+
+	// the examples are valid
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+}
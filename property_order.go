@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+const (
+	// PropertyOrderExt is the name of the schema extension holding the list of the property names
+	// in the order of their presentation, e.g. in the generated documentation.
+	//
+	// Example:
+	//
+	//	type: object
+	//	x-property-order: [id, name, tags]
+	PropertyOrderExt = "x-property-order"
+	// OrderExt is the name of the property extension holding the position of the property among
+	// the properties of its parent schema, the properties with lower positions go first.
+	//
+	// Example:
+	//
+	//	properties:
+	//	  id:
+	//	    type: integer
+	//	    x-order: 1
+	OrderExt = "x-order"
+)
+
+// PropertyOrder sets the order of the properties of the schema, see PropertyOrderExt.
+func (b *SchemaBulder) PropertyOrder(names ...string) *SchemaBulder {
+	b.spec.Spec.AddExt(PropertyOrderExt, names)
+	return b
+}
+
+// OrderedProperties returns the names of the properties of the schema in the order of their presentation:
+// first the properties listed in the `x-property-order` extension of the schema,
+// then the properties having the `x-order` extension sorted by it,
+// and then the rest of the properties sorted by name.
+func (o *Schema) OrderedProperties() []string {
+	listed := make(map[string]int)
+	for i, name := range propertyOrderList(o.Extensions[PropertyOrderExt]) {
+		if _, ok := o.Properties[name]; ok {
+			if _, dup := listed[name]; !dup {
+				listed[name] = i
+			}
+		}
+	}
+	names := sortedKeys(o.Properties)
+	slices.SortStableFunc(names, func(a, b string) int {
+		ai, aListed := listed[a]
+		bi, bListed := listed[b]
+		switch {
+		case aListed && bListed:
+			return cmp.Compare(ai, bi)
+		case aListed:
+			return -1
+		case bListed:
+			return 1
+		}
+		ao, aOrdered := propertyPosition(o.Properties[a])
+		bo, bOrdered := propertyPosition(o.Properties[b])
+		switch {
+		case aOrdered && bOrdered:
+			return cmp.Compare(ao, bo)
+		case aOrdered:
+			return -1
+		case bOrdered:
+			return 1
+		}
+		return 0
+	})
+	return names
+}
+
+// propertyOrderList returns the names from the value of the `x-property-order` extension.
+func propertyOrderList(v any) []string {
+	switch names := extensionValue(v).(type) {
+	case []string:
+		return names
+	case []any:
+		list := make([]string, 0, len(names))
+		for _, n := range names {
+			if s, ok := n.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// propertyPosition returns the value of the `x-order` extension of the property.
+func propertyPosition(prop *RefOrSpec[Schema]) (float64, bool) {
+	if prop == nil || prop.Spec == nil {
+		return 0, false
+	}
+	var (
+		pos float64
+		err error
+	)
+	switch v := extensionValue(prop.Spec.Extensions[OrderExt]).(type) {
+	case int:
+		pos = float64(v)
+	case int64:
+		pos = float64(v)
+	case uint64:
+		pos = float64(v)
+	case float64:
+		pos = v
+	case json.Number:
+		pos, err = v.Float64()
+	case string:
+		pos, err = strconv.ParseFloat(v, 64)
+	default:
+		return 0, false
+	}
+	if err != nil || math.IsNaN(pos) {
+		return 0, false
+	}
+	return pos, true
+}
+
+// normalizePropertyOrder sets the `x-property-order` extension of all schemas of the spec
+// having the properties with the `x-order` extension or the extension itself,
+// so the order of the properties is stored explicitly and includes all properties.
+func normalizePropertyOrder(spec *Extendable[OpenAPI]) {
+	walkSpec(reflect.ValueOf(spec), func(v reflect.Value) {
+		schema, ok := v.Interface().(*Schema)
+		if !ok || len(schema.Properties) == 0 {
+			return
+		}
+		_, hasOrder := schema.Extensions[PropertyOrderExt]
+		if !hasOrder {
+			for _, prop := range schema.Properties {
+				if _, hasOrder = propertyPosition(prop); hasOrder {
+					break
+				}
+			}
+		}
+		if hasOrder {
+			schema.AddExt(PropertyOrderExt, schema.OrderedProperties())
+		}
+	})
+}
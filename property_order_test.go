@@ -0,0 +1,123 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestSchema_OrderedProperties(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		schema   string
+		expected []string
+	}{
+		{
+			name:     "alphabetical",
+			schema:   `{"properties": {"name": {}, "id": {}, "age": {}}}`,
+			expected: []string{"age", "id", "name"},
+		},
+		{
+			name:     "x-order",
+			schema:   `{"properties": {"name": {"x-order": 2}, "id": {"x-order": 1}, "age": {}, "city": {}}}`,
+			expected: []string{"id", "name", "age", "city"},
+		},
+		{
+			name:     "x-property-order",
+			schema:   `{"x-property-order": ["name", "unknown", "id", "name"], "properties": {"name": {}, "id": {}, "age": {"x-order": 1}, "city": {}}}`,
+			expected: []string{"name", "id", "age", "city"},
+		},
+		{
+			name:     "invalid x-order",
+			schema:   `{"properties": {"name": {"x-order": "first"}, "id": {"x-order": "1"}, "age": {}}}`,
+			expected: []string{"id", "age", "name"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema openapi.Schema
+			require.NoError(t, json.Unmarshal([]byte(tt.schema), &schema))
+			require.Equal(t, tt.expected, schema.OrderedProperties())
+		})
+	}
+}
+
+func TestSchemaBulder_PropertyOrder(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().
+		Type(openapi.ObjectType).
+		AddProperty("id", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+		AddProperty("age", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		PropertyOrder("name", "id").
+		Build()
+	require.Equal(t, []string{"name", "id", "age"}, schema.Spec.OrderedProperties())
+}
+
+const testPropertyOrderSpec = `
+openapi: 3.1.1
+info:
+  title: Property Order
+  version: 1.0.0
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+          x-order: 2
+        id:
+          type: integer
+          x-order: 1
+        address:
+          type: object
+          x-property-order: [street]
+          properties:
+            city:
+              type: string
+            street:
+              type: string
+    Plain:
+      type: object
+      properties:
+        b:
+          type: string
+        a:
+          type: string
+`
+
+func TestNormalize_PropertyOrder(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		opts    []openapi.NormalizeOption
+		user    any
+		address any
+	}{
+		{
+			name:    "default",
+			user:    []string{"id", "name", "address"},
+			address: []string{"street", "city"},
+		},
+		{
+			name:    "opt out",
+			opts:    []openapi.NormalizeOption{openapi.KeepPropertyOrder()},
+			address: []any{"street"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(testPropertyOrderSpec), &spec))
+
+			openapi.Normalize(spec, tt.opts...)
+
+			schemas := spec.Spec.Components.Spec.Schemas
+			user := schemas["User"].Spec
+			require.Equal(t, tt.user, user.GetExt(openapi.PropertyOrderExt))
+			require.Equal(t, tt.address, user.Properties["address"].Spec.GetExt(openapi.PropertyOrderExt))
+			require.Nil(t, schemas["Plain"].Spec.GetExt(openapi.PropertyOrderExt))
+		})
+	}
+}
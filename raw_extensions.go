@@ -0,0 +1,350 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type unmarshalOptions struct {
+	keepRawExtensions bool
+	keepRefSiblings   bool
+	maxNestingDepth   int
+	maxExtensions     int
+	maxStringLength   int
+}
+
+// UnmarshalOption is a type for the options of Unmarshal function.
+type UnmarshalOption func(*unmarshalOptions)
+
+// KeepRawExtensions is an unmarshal option to keep the values of the extensions as they are in the source:
+// json.RawMessage for JSON and *yaml.Node for YAML, so the numbers do not lose their precision,
+// the keys of the objects keep their order, and the unknown vendor data is marshaled back as is.
+//
+// The values are decoded on demand by GetExt and DecodeExt methods.
+func KeepRawExtensions() UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.keepRawExtensions = true
+	}
+}
+
+// Unmarshal decodes the given JSON or YAML data into v, usually *Extendable[OpenAPI], using the given options.
+//
+// The data is checked against the limits before decoding, so the pathological documents are rejected
+// with ErrLimitExceeded early, see MaxNestingDepth, MaxExtensionsPerObject, and MaxStringLength options.
+func Unmarshal(data []byte, v any, opts ...UnmarshalOption) error {
+	options := unmarshalOptions{
+		maxNestingDepth: DefaultMaxNestingDepth,
+		maxExtensions:   DefaultMaxExtensionsPerObject,
+		maxStringLength: DefaultMaxStringLength,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	var tree rawTree
+	if isJSON(data) {
+		if err := options.checkJSONUnmarshalLimits(data); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			return err
+		}
+		tree = &jsonTree{raw: data}
+	} else {
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		if options.hasLimits() {
+			if err := options.checkYAMLUnmarshalLimits(&node, make(locPath, 0, 16), 0); err != nil {
+				return err
+			}
+		}
+		if err := node.Decode(v); err != nil {
+			return err
+		}
+		if len(node.Content) == 0 {
+			return nil
+		}
+		tree = &yamlTree{node: node.Content[0]}
+	}
+	if !options.keepRawExtensions && !options.keepRefSiblings {
+		return nil
+	}
+	var err error
+	walkRawTree(reflect.ValueOf(v), tree, func(v reflect.Value, tree rawTree) {
+		if options.keepRefSiblings && err == nil {
+			if ref, ok := v.Interface().(*RefOrSpec[Schema]); ok {
+				err = setRefSiblings(ref, tree)
+			}
+		}
+		if options.keepRawExtensions {
+			setRawExtensions(v, tree)
+		}
+	})
+	return err
+}
+
+// extensionHolder is implemented by the objects having extensions, Extendable and Schema.
+type extensionHolder interface {
+	extensions() map[string]any
+}
+
+func (o *Extendable[T]) extensions() map[string]any {
+	return o.Extensions
+}
+
+func (o *Schema) extensions() map[string]any {
+	return o.Extensions
+}
+
+var extensionHolderType = reflect.TypeOf((*extensionHolder)(nil)).Elem()
+
+// setRawExtensions replaces the values of the extensions of the given object with the raw values of the source.
+func setRawExtensions(v reflect.Value, tree rawTree) {
+	if !v.Type().Implements(extensionHolderType) {
+		return
+	}
+	exts := v.Interface().(extensionHolder).extensions()
+	for name := range exts {
+		if raw, ok := tree.field(name); ok {
+			exts[name] = raw.value()
+		}
+	}
+}
+
+// walkRawTree walks the object along with the source tree and calls the visit function for every pointer
+// before its value is walked, so the visit function can replace the value.
+func walkRawTree(v reflect.Value, tree rawTree, visit func(reflect.Value, rawTree)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		visit(v, tree)
+		walkRawTree(v.Elem(), tree, visit)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				// the fields without names are inlined by the custom marshalers, e.g. Spec of Extendable or Paths
+				walkRawTree(v.Field(i), tree, visit)
+			} else if sub, ok := tree.field(name); ok {
+				walkRawTree(v.Field(i), sub, visit)
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if sub, ok := tree.field(iter.Key().String()); ok {
+				walkRawTree(iter.Value(), sub, visit)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if sub, ok := tree.index(i); ok {
+				walkRawTree(v.Index(i), sub, visit)
+			}
+		}
+	}
+}
+
+// rawTree is a value of the source of a spec.
+type rawTree interface {
+	field(name string) (rawTree, bool)
+	index(i int) (rawTree, bool)
+	// value returns the raw value: json.RawMessage or *yaml.Node.
+	value() any
+	// keys returns the names of the fields of an object.
+	keys() []string
+	// decode decodes the raw value into the given pointer.
+	decode(v any) error
+}
+
+type jsonTree struct {
+	raw   json.RawMessage
+	props map[string]json.RawMessage
+	items []json.RawMessage
+}
+
+func (t *jsonTree) field(name string) (rawTree, bool) {
+	if t.props == nil {
+		if err := json.Unmarshal(t.raw, &t.props); err != nil {
+			return nil, false
+		}
+	}
+	raw, ok := t.props[name]
+	if !ok {
+		return nil, false
+	}
+	return &jsonTree{raw: raw}, true
+}
+
+func (t *jsonTree) index(i int) (rawTree, bool) {
+	if t.items == nil {
+		if err := json.Unmarshal(t.raw, &t.items); err != nil {
+			return nil, false
+		}
+	}
+	if i >= len(t.items) {
+		return nil, false
+	}
+	return &jsonTree{raw: t.items[i]}, true
+}
+
+func (t *jsonTree) value() any {
+	return t.raw
+}
+
+func (t *jsonTree) keys() []string {
+	if t.props == nil {
+		if err := json.Unmarshal(t.raw, &t.props); err != nil {
+			return nil
+		}
+	}
+	return sortedKeys(t.props)
+}
+
+func (t *jsonTree) decode(v any) error {
+	return json.Unmarshal(t.raw, v)
+}
+
+type yamlTree struct {
+	node *yaml.Node
+}
+
+func (t *yamlTree) resolved() *yaml.Node {
+	n := t.node
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func (t *yamlTree) field(name string) (rawTree, bool) {
+	n := t.resolved()
+	if n.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == name {
+			return &yamlTree{node: n.Content[i+1]}, true
+		}
+	}
+	return nil, false
+}
+
+func (t *yamlTree) index(i int) (rawTree, bool) {
+	n := t.resolved()
+	if n.Kind != yaml.SequenceNode || i >= len(n.Content) {
+		return nil, false
+	}
+	return &yamlTree{node: n.Content[i]}, true
+}
+
+func (t *yamlTree) value() any {
+	return t.node
+}
+
+func (t *yamlTree) keys() []string {
+	n := t.resolved()
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keys = append(keys, n.Content[i].Value)
+	}
+	return keys
+}
+
+func (t *yamlTree) decode(v any) error {
+	return t.node.Decode(v)
+}
+
+// extensionValue decodes the raw value of an extension, the other values are returned as is.
+func extensionValue(v any) any {
+	switch t := v.(type) {
+	case json.RawMessage:
+		var d any
+		if err := json.Unmarshal(t, &d); err != nil {
+			return v
+		}
+		return d
+	case *yaml.Node:
+		var d any
+		if err := t.Decode(&d); err != nil {
+			return v
+		}
+		return d
+	}
+	return v
+}
+
+// decodeExtension decodes the value of an extension into the given pointer.
+func decodeExtension(value any, v any) error {
+	switch t := value.(type) {
+	case json.RawMessage:
+		return json.Unmarshal(t, v)
+	case *yaml.Node:
+		return t.Decode(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// marshalableExtensions converts the raw values of the extensions into the values marshaled by JSON or YAML.
+func marshalableExtensions(exts map[string]any, toYAML bool) (map[string]any, error) {
+	var converted map[string]any
+	for name, value := range exts {
+		var v any
+		switch t := value.(type) {
+		case json.RawMessage:
+			if !toYAML {
+				continue
+			}
+			dec := json.NewDecoder(strings.NewReader(string(t)))
+			dec.UseNumber()
+			if err := dec.Decode(&v); err != nil {
+				return nil, fmt.Errorf("%s: %w", strconv.Quote(name), err)
+			}
+			v = yamlNumbers(v)
+		case *yaml.Node:
+			if toYAML {
+				continue
+			}
+			if err := t.Decode(&v); err != nil {
+				return nil, fmt.Errorf("%s: %w", strconv.Quote(name), err)
+			}
+			v = normalizeYAMLValue(v)
+		default:
+			continue
+		}
+		if converted == nil {
+			converted = make(map[string]any, len(exts))
+			for k, item := range exts {
+				converted[k] = item
+			}
+		}
+		converted[name] = v
+	}
+	if converted == nil {
+		return exts, nil
+	}
+	return converted, nil
+}
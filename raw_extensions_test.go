@@ -0,0 +1,107 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestUnmarshal_KeepRawExtensions(t *testing.T) {
+	const jsonSpec = `{
+  "openapi": "3.1.1",
+  "info": {"title": "Raw", "version": "1.0.0", "x-big": 12345678901234567890123, "x-obj": {"b": 1, "a": [2.50]}},
+  "paths": {
+    "/pets": {
+      "get": {
+        "x-timeout": "2s",
+        "responses": {"200": {"description": "OK", "x-resp": 1.0}}
+      }
+    }
+  },
+  "components": {"schemas": {"Pet": {"type": "object", "x-vendor": {"z": 1, "y": 2}}}}
+}`
+
+	t.Run("json", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.Unmarshal([]byte(jsonSpec), &spec, openapi.KeepRawExtensions()))
+		info := spec.Spec.Info
+		require.Equal(t, json.RawMessage(`12345678901234567890123`), info.Extensions["x-big"])
+		require.Equal(t, map[string]any{"b": float64(1), "a": []any{2.5}}, info.GetExt("obj"))
+		var obj struct {
+			A []json.Number `json:"a"`
+		}
+		ok, err := info.DecodeExt("x-obj", &obj)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []json.Number{"2.50"}, obj.A)
+		ok, err = info.DecodeExt("x-missing", &obj)
+		require.NoError(t, err)
+		require.False(t, ok)
+		var s string
+		_, err = info.DecodeExt("x-big", &s)
+		require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+
+		op := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Get
+		d, ok, err := openapi.TimeoutOf(op)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, 2*time.Second, d)
+
+		data, err := json.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"x-big":12345678901234567890123`)
+		require.Contains(t, string(data), `"x-obj":{"b":1,"a":[2.50]}`)
+		require.Contains(t, string(data), `"x-resp":1.0`)
+		require.Contains(t, string(data), `"x-vendor":{"z":1,"y":2}`)
+
+		data, err = yaml.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "x-big: 12345678901234567890123\n")
+		require.Contains(t, string(data), "x-resp: 1.0\n")
+
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		require.NoError(t, validator.ValidateSpec())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		const yamlSpec = `openapi: 3.1.1
+info:
+    title: Raw
+    version: 1.0.0
+    x-obj:
+        b: 1
+        a: 0x10
+paths: {}
+`
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.Unmarshal([]byte(yamlSpec), &spec, openapi.KeepRawExtensions()))
+		require.IsType(t, &yaml.Node{}, spec.Spec.Info.Extensions["x-obj"])
+		require.Equal(t, map[string]any{"b": 1, "a": 16}, spec.Spec.Info.GetExt("x-obj"))
+
+		data, err := yaml.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "    x-obj:\n        b: 1\n        a: 0x10\n")
+
+		data, err = json.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"x-obj":{"a":16,"b":1}`)
+	})
+
+	t.Run("without option", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.Unmarshal([]byte(jsonSpec), &spec))
+		require.Equal(t, 1.2345678901234568e+22, spec.Spec.Info.Extensions["x-big"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.Error(t, openapi.Unmarshal([]byte(`{"openapi": 1`), &spec, openapi.KeepRawExtensions()))
+		require.Error(t, openapi.Unmarshal([]byte("openapi: [\n"), &spec, openapi.KeepRawExtensions()))
+	})
+}
@@ -95,53 +95,62 @@ func (o *RefOrSpec[T]) getSpec(c *Extendable[Components], visited visitedObjects
 	case o.Spec != nil:
 		return o.Spec, nil
 	case o.Ref == nil:
-		return nil, fmt.Errorf("spect not found; all visited refs: %s", visited)
+		return nil, fmt.Errorf("%w: spect not found; all visited refs: %s", ErrUnresolvedRef, visited)
 	case visited[o.Ref.Ref]:
-		return nil, fmt.Errorf("cycle ref %q detected; all visited refs: %s", o.Ref.Ref, visited)
+		return nil, fmt.Errorf("%w: cycle ref %q detected; all visited refs: %s", ErrUnresolvedRef, o.Ref.Ref, visited)
 	case !strings.HasPrefix(o.Ref.Ref, "#/components/"):
 		// TODO: support loading by url
-		return nil, fmt.Errorf("loading outside of components is not implemented for the ref %q; all visited refs: %s", o.Ref.Ref, visited)
+		return nil, fmt.Errorf("%w: loading outside of components is not implemented for the ref %q; all visited refs: %s", ErrUnresolvedRef, o.Ref.Ref, visited)
 	case c == nil:
-		return nil, fmt.Errorf("components is required, but got nil; all visited refs: %s", visited)
+		return nil, fmt.Errorf("%w: components is required, but got nil; all visited refs: %s", ErrUnresolvedRef, visited)
 	}
 	visited[o.Ref.Ref] = true
 
-	parts := strings.SplitN(o.Ref.Ref[13:], "/", 2)
+	ref, err := componentByRef(c, o.Ref.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("%w; all visited refs: %s", err, visited)
+	}
+	obj, ok := ref.(*RefOrSpec[T])
+	if !ok {
+		return nil, fmt.Errorf("%w: expected spec of type %T, but got %T; all visited refs: %s", ErrUnresolvedRef, RefOrSpec[T]{}, ref, visited)
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("%w: %q not found; all visited refs: %s", ErrUnresolvedRef, o.Ref.Ref, visited)
+	}
+	if obj.Spec != nil {
+		return obj.Spec, nil
+	}
+	return obj.getSpec(c, visited)
+}
+
+// componentByRef returns the component referenced by the given local ref, e.g. `#/components/schemas/Pet`.
+func componentByRef(c *Extendable[Components], ref string) (any, error) {
+	parts := strings.SplitN(strings.TrimPrefix(ref, "#/components/"), "/", 2)
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("incorrect ref %q; all visited refs: %s", o.Ref.Ref, visited)
+		return nil, fmt.Errorf("%w: incorrect ref %q", ErrUnresolvedRef, ref)
 	}
 	objName := parts[1]
-	var ref any
 	switch parts[0] {
 	case "schemas":
-		ref = c.Spec.Schemas[objName]
+		return c.Spec.Schemas[objName], nil
 	case "responses":
-		ref = c.Spec.Responses[objName]
+		return c.Spec.Responses[objName], nil
 	case "parameters":
-		ref = c.Spec.Parameters[objName]
+		return c.Spec.Parameters[objName], nil
 	case "examples":
-		ref = c.Spec.Examples[objName]
+		return c.Spec.Examples[objName], nil
 	case "requestBodies":
-		ref = c.Spec.RequestBodies[objName]
+		return c.Spec.RequestBodies[objName], nil
 	case "headers":
-		ref = c.Spec.Headers[objName]
+		return c.Spec.Headers[objName], nil
 	case "links":
-		ref = c.Spec.Links[objName]
+		return c.Spec.Links[objName], nil
 	case "callbacks":
-		ref = c.Spec.Callbacks[objName]
+		return c.Spec.Callbacks[objName], nil
 	case "paths":
-		ref = c.Spec.Paths[objName]
-	default:
-		return nil, fmt.Errorf("unexpected component %q; all visited refs: %s", ref, visited)
-	}
-	obj, ok := ref.(*RefOrSpec[T])
-	if !ok {
-		return nil, fmt.Errorf("expected spec of type %T, but got %T; all visited refs: %s", RefOrSpec[T]{}, ref, visited)
+		return c.Spec.Paths[objName], nil
 	}
-	if obj.Spec != nil {
-		return obj.Spec, nil
-	}
-	return obj.getSpec(c, visited)
+	return nil, fmt.Errorf("%w: unexpected component %q", ErrUnresolvedRef, parts[0])
 }
 
 // MarshalJSON implements json.Marshaler interface.
@@ -203,8 +212,10 @@ func (o *RefOrSpec[T]) validateSpec(location string, validator *Validator) []*va
 		if spec, ok := any(o.Spec).(validatable); ok {
 			errs = append(errs, spec.validateSpec(location, validator)...)
 		} else {
-			errs = append(errs, newValidationError(location, fmt.Errorf("unsupported spec type: %T", o.Spec)))
+			errs = append(errs, newValidationError(location, fmt.Errorf("%w: unsupported spec type: %T", ErrInvalidValue, o.Spec)))
 		}
+	} else if validator.documents != nil && !strings.HasPrefix(o.Ref.Ref, "#") {
+		errs = append(errs, validateDocumentRef[T](location, o.Ref.Ref, validator)...)
 	} else {
 		// do not validate already visited refs
 		if validator.visited[o.Ref.Ref] {
@@ -0,0 +1,79 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+)
+
+// KeepRefSiblings is an unmarshal option to keep the keywords next to `$ref` of the schemas.
+//
+// In OpenAPI v3.1 and JSON Schema 2020-12 `$ref` is an applicator and the adjacent keywords are applied too,
+// e.g. `{"$ref": "#/components/schemas/Name", "maxLength": 10}` limits the length of the referenced string.
+// By default, RefOrSpec keeps the reference only (along with `summary` and `description`),
+// while with the option such schema is decoded into RefOrSpec.Spec with the reference in Schema.Ref field,
+// so the sibling keywords are marshaled back and used by the validator.
+//
+// Use ResolveSchema function to get the schema applying both the reference and the sibling keywords.
+func KeepRefSiblings() UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.keepRefSiblings = true
+	}
+}
+
+// refKeywords are the fields of Ref object.
+var refKeywords = []string{"$ref", "summary", "description"}
+
+// setRefSiblings decodes the given reference as a schema if the source has any keywords other than the fields of Ref.
+func setRefSiblings(o *RefOrSpec[Schema], tree rawTree) error {
+	if o.Ref == nil {
+		return nil
+	}
+	if !slices.ContainsFunc(tree.keys(), func(k string) bool {
+		return !slices.Contains(refKeywords, k)
+	}) {
+		return nil
+	}
+	var spec Schema
+	if err := tree.decode(&spec); err != nil {
+		return fmt.Errorf("%T: %w", o.Spec, err)
+	}
+	o.Ref, o.Spec = nil, &spec
+	return nil
+}
+
+// ResolveSchema returns the schema of the given reference or the schema itself.
+// If the schema has `$ref` keyword along with the sibling keywords (see KeepRefSiblings) or `$dynamicRef` keyword,
+// then the returned schema is a copy with the referenced schemas moved to the beginning of `allOf` list,
+// so both the referenced schemas and the sibling keywords are applied.
+//
+// The `$dynamicRef` is resolved statically: the plain name fragment is resolved to the `$dynamicAnchor`
+// of the schema components, the components are checked in the order of their names.
+func ResolveSchema(schema *RefOrSpec[Schema], components *Extendable[Components]) (*Schema, error) {
+	spec, err := schema.GetSpec(components)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Ref == "" && spec.DynamicRef == "" {
+		return spec, nil
+	}
+	var targets []*RefOrSpec[Schema]
+	if spec.Ref != "" {
+		target := NewRefOrSpec[Schema](spec.Ref)
+		if _, err := target.GetSpec(components); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	if spec.DynamicRef != "" {
+		target, err := resolveDynamicRef(spec.DynamicRef, components)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	resolved := *spec
+	resolved.Ref = ""
+	resolved.DynamicRef = ""
+	resolved.AllOf = append(targets, spec.AllOf...)
+	return &resolved, nil
+}
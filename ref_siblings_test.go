@@ -0,0 +1,113 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testRefSiblingsSpec = `
+openapi: 3.1.1
+info:
+  title: Ref siblings
+  version: 1.0.0
+components:
+  schemas:
+    Name:
+      type: string
+    Pet:
+      type: object
+      properties:
+        name:
+          $ref: '#/components/schemas/Name'
+          maxLength: 5
+        nick:
+          $ref: '#/components/schemas/Name'
+          description: The nick name
+`
+
+func TestUnmarshal_KeepRefSiblings(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, openapi.Unmarshal([]byte(testRefSiblingsSpec), &spec))
+		name := spec.Spec.Components.Spec.Schemas["Pet"].Spec.Properties["name"]
+		require.Equal(t, &openapi.Ref{Ref: "#/components/schemas/Name"}, name.Ref)
+		require.Nil(t, name.Spec)
+	})
+
+	for _, tt := range []struct {
+		name string
+		data func(t *testing.T) []byte
+	}{
+		{
+			name: "yaml",
+			data: func(*testing.T) []byte {
+				return []byte(testRefSiblingsSpec)
+			},
+		},
+		{
+			name: "json",
+			data: func(t *testing.T) []byte {
+				var v any
+				require.NoError(t, yaml.Unmarshal([]byte(testRefSiblingsSpec), &v))
+				data, err := json.Marshal(v)
+				require.NoError(t, err)
+				return data
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, openapi.Unmarshal(tt.data(t), &spec, openapi.KeepRefSiblings()))
+			components := spec.Spec.Components
+			pet := components.Spec.Schemas["Pet"].Spec
+
+			name := pet.Properties["name"]
+			require.Nil(t, name.Ref)
+			require.Equal(t, "#/components/schemas/Name", name.Spec.Ref)
+			require.Equal(t, 5, *name.Spec.MaxLength)
+			// only Ref fields, so kept as is
+			require.Equal(t, &openapi.Ref{Ref: "#/components/schemas/Name", Description: "The nick name"}, pet.Properties["nick"].Ref)
+
+			data, err := json.Marshal(name)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"$ref": "#/components/schemas/Name", "maxLength": 5}`, string(data))
+
+			resolved, err := openapi.ResolveSchema(name, components)
+			require.NoError(t, err)
+			require.Empty(t, resolved.Ref)
+			require.Equal(t, 5, *resolved.MaxLength)
+			require.Equal(t, []*openapi.RefOrSpec[openapi.Schema]{openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Name")}, resolved.AllOf)
+			require.Equal(t, "#/components/schemas/Name", name.Spec.Ref, "the original schema must not be changed")
+
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateSpec())
+			require.NoError(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"name": "Rex"}))
+			require.Error(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"name": "Rexxxxx"}))
+			require.Error(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"name": 42}))
+
+			sub := openapi.NewSchemaBuilder().Type(openapi.StringType).MaxLength(3).Build()
+			require.NoError(t, openapi.CheckSubschema(sub, name, components))
+			sub = openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()
+			require.ErrorIs(t, openapi.CheckSubschema(sub, name, components), openapi.ErrIncompatible)
+		})
+	}
+
+	t.Run("unresolved", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		data := []byte(`{"openapi": "3.1.1", "info": {"title": "t", "version": "1"}, "components": {"schemas": {"Pet": {"$ref": "#/components/schemas/Missing", "type": "object"}}}}`)
+		require.NoError(t, openapi.Unmarshal(data, &spec, openapi.KeepRefSiblings()))
+		validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+		require.NoError(t, err)
+		err = validator.ValidateSpec()
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+		require.ErrorContains(t, err, "/components/schemas/Pet/$ref")
+		_, err = openapi.ResolveSchema(spec.Spec.Components.Spec.Schemas["Pet"], spec.Spec.Components)
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	})
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotRegistered is returned when a spec is looked up by a name not registered by MustRegister.
+var ErrNotRegistered = errors.New("not registered")
+
+// registered is the package-level registry of the specs registered by MustRegister.
+var registered = struct {
+	mu       sync.RWMutex
+	data     map[string][]byte
+	registry *Registry
+}{
+	data: make(map[string][]byte),
+}
+
+func init() {
+	registered.registry = NewRegistry(loadRegistered, 0)
+}
+
+func loadRegistered(_ context.Context, name string) (*Extendable[OpenAPI], error) {
+	registered.mu.RLock()
+	data, ok := registered.data[name]
+	registered.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotRegistered
+	}
+	var spec *Extendable[OpenAPI]
+	if err := Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// MustRegister registers the YAML or JSON spec under the given name in the package-level registry,
+// e.g. the specs embedded by `go:embed`, so they can be accessed by name from any package.
+//
+// The spec is parsed on the first lookup and its validator is kept by the registry, see Registry;
+// the identical specs registered under several names share the compiled schemas.
+// The function is meant to be called at initialization, it panics if the name is already registered.
+//
+// Example:
+//
+//	//go:embed petstore.yaml
+//	var petstore []byte
+//
+//	func init() {
+//		openapi.MustRegister("petstore", petstore)
+//	}
+//
+//	...
+//	validator, err := openapi.RegisteredValidator("petstore")
+func MustRegister(name string, data []byte) {
+	registered.mu.Lock()
+	defer registered.mu.Unlock()
+	if _, ok := registered.data[name]; ok {
+		panic(fmt.Sprintf("registering spec %q failed: %v", name, ErrDuplicate))
+	}
+	registered.data[name] = data
+}
+
+// RegisteredValidator returns the validator of the spec registered by MustRegister under the given name,
+// the validator is created with the default options once and shared by all callers.
+func RegisteredValidator(name string) (*Validator, error) {
+	registered.mu.RLock()
+	_, ok := registered.data[name]
+	registered.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("spec %q: %w", name, ErrNotRegistered)
+	}
+	return registered.registry.Get(context.Background(), name)
+}
+
+// RegisteredSpec returns the spec registered by MustRegister under the given name.
+// The spec is shared by all callers, so it must not be modified.
+func RegisteredSpec(name string) (*Extendable[OpenAPI], error) {
+	v, err := RegisteredValidator(name)
+	if err != nil {
+		return nil, err
+	}
+	return v.spec, nil
+}
+
+// RegisteredNames returns the sorted names of the specs registered by MustRegister.
+func RegisteredNames() []string {
+	registered.mu.RLock()
+	defer registered.mu.RUnlock()
+	return sortedKeys(registered.data)
+}
@@ -0,0 +1,53 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestMustRegister(t *testing.T) {
+	const spec = `
+openapi: 3.1.1
+info:
+  title: Registered
+  version: 1.0.0
+components:
+  schemas:
+    ID:
+      type: integer
+`
+	openapi.MustRegister("test-registered", []byte(spec))
+	openapi.MustRegister("test-registered-copy", []byte(spec))
+	openapi.MustRegister("test-registered-broken", []byte("openapi: ["))
+	require.PanicsWithValue(t, `registering spec "test-registered" failed: duplicate`, func() {
+		openapi.MustRegister("test-registered", []byte(spec))
+	})
+	require.Subset(t, openapi.RegisteredNames(), []string{"test-registered", "test-registered-broken", "test-registered-copy"})
+
+	s, err := openapi.RegisteredSpec("test-registered")
+	require.NoError(t, err)
+	require.Equal(t, "Registered", s.Spec.Info.Spec.Title)
+
+	v, err := openapi.RegisteredValidator("test-registered")
+	require.NoError(t, err)
+	require.NoError(t, v.ValidateData("/components/schemas/ID", 1))
+	require.Error(t, v.ValidateData("/components/schemas/ID", "one"))
+
+	again, err := openapi.RegisteredValidator("test-registered")
+	require.NoError(t, err)
+	require.Same(t, v, again)
+
+	copied, err := openapi.RegisteredValidator("test-registered-copy")
+	require.NoError(t, err)
+	require.NotSame(t, v, copied)
+	require.NoError(t, copied.ValidateData("/components/schemas/ID", 1))
+
+	_, err = openapi.RegisteredValidator("test-registered-broken")
+	require.ErrorContains(t, err, `loading spec "test-registered-broken" failed`)
+
+	_, err = openapi.RegisteredSpec("test-unknown")
+	require.ErrorIs(t, err, openapi.ErrNotRegistered)
+}
@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// RegistryLoader is a function to load the specification with the given ID, it is used by Registry.
+type RegistryLoader func(ctx context.Context, id string) (*Extendable[OpenAPI], error)
+
+// Registry manages the validators of many specifications keyed by an ID, e.g. a tenant or a spec ID.
+//
+// The specifications are loaded lazily on the first request, and the least recently used validators
+// are evicted if the number of the validators exceeds the capacity.
+// The validators of the identical specifications share the compiled schemas.
+// The specifications are always marshaled completely to be compared, so LazySpecMarshaling option has no effect.
+type Registry struct {
+	mu       sync.Mutex
+	loader   RegistryLoader
+	opts     []ValidationOption
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+	// caches holds the compiled schemas shared by the identical specifications, keyed by the fingerprint
+	caches map[string]*sharedSchemaCache
+}
+
+type registryEntry struct {
+	id          string
+	ready       chan struct{}
+	validator   *Validator
+	err         error
+	fingerprint string
+	// acquired means that the entry holds a reference to the shared schema cache
+	acquired bool
+}
+
+type sharedSchemaCache struct {
+	cache *schemaCache
+	refs  int
+}
+
+// NewRegistry creates a Registry, which loads the specifications using the given loader and keeps
+// at most capacity validators created with the given options; zero or negative capacity means no limit.
+func NewRegistry(loader RegistryLoader, capacity int, opts ...ValidationOption) *Registry {
+	return &Registry{
+		loader:   loader,
+		opts:     opts,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		caches:   make(map[string]*sharedSchemaCache),
+	}
+}
+
+// Get returns the validator of the specification with the given ID, loading it if needed.
+// The concurrent calls for the same ID load the specification once.
+// The failed loads are not cached.
+func (r *Registry) Get(ctx context.Context, id string) (*Validator, error) {
+	r.mu.Lock()
+	if el, ok := r.entries[id]; ok {
+		r.lru.MoveToFront(el)
+		r.mu.Unlock()
+		e := el.Value.(*registryEntry)
+		select {
+		case <-e.ready:
+			return e.validator, e.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	e := &registryEntry{id: id, ready: make(chan struct{})}
+	r.entries[id] = r.lru.PushFront(e)
+	r.evict()
+	r.mu.Unlock()
+
+	e.validator, e.fingerprint, e.err = r.load(ctx, id)
+	close(e.ready)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	el, ok := r.entries[id]
+	switch {
+	case !ok || el.Value != e:
+		// evicted or removed while loading
+	case e.err != nil:
+		r.lru.Remove(el)
+		delete(r.entries, id)
+	default:
+		r.acquire(e)
+	}
+	return e.validator, e.err
+}
+
+// Remove removes the validator of the specification with the given ID, so it will be loaded again by the next Get call.
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.entries[id]; ok {
+		r.remove(el)
+	}
+}
+
+// Len returns the number of the validators in the registry.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+func (r *Registry) load(ctx context.Context, id string) (*Validator, string, error) {
+	spec, err := r.loader(ctx, id)
+	if err != nil {
+		return nil, "", fmt.Errorf("loading spec %q failed: %w", id, err)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling spec %q failed: %w", id, err)
+	}
+
+	options := &validationOptions{}
+	for _, opt := range r.opts {
+		opt(options)
+	}
+	documents, err := newDocumentSet(options)
+	if err != nil {
+		return nil, "", err
+	}
+	fingerprint, err := registryFingerprint(data, documents)
+	if err != nil {
+		return nil, "", fmt.Errorf("spec %q: %w", id, err)
+	}
+	validator := &Validator{
+		spec:      spec,
+		opts:      options,
+		documents: documents,
+	}
+
+	r.mu.Lock()
+	shared, ok := r.caches[fingerprint]
+	r.mu.Unlock()
+	if ok {
+		validator.cache.Store(shared.cache)
+		return validator, fingerprint, nil
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("unmarshaling spec %q failed: %w", id, err)
+	}
+	c, err := newSchemaCache(doc, options.updateCompiler)
+	if err != nil {
+		return nil, "", err
+	}
+	validator.cache.Store(c)
+	return validator, fingerprint, nil
+}
+
+// registryFingerprint returns the hash of the spec along with the documents given by Documents option,
+// since the compiled schemas of the spec depend on the referenced documents.
+func registryFingerprint(data []byte, documents *documentSet) (string, error) {
+	h := sha256.New()
+	h.Write(data)
+	if documents != nil {
+		for _, name := range sortedKeys(documents.json) {
+			doc, err := json.Marshal(documents.json[name])
+			if err != nil {
+				return "", fmt.Errorf("marshaling document %q failed: %w", name, err)
+			}
+			// the zero bytes separate the names and the documents, they can not be a part of JSON
+			h.Write([]byte{0})
+			h.Write([]byte(name))
+			h.Write([]byte{0})
+			h.Write(doc)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// acquire registers the schema cache of the loaded entry to be shared with the identical specifications.
+func (r *Registry) acquire(e *registryEntry) {
+	e.acquired = true
+	if shared, ok := r.caches[e.fingerprint]; ok {
+		shared.refs++
+		return
+	}
+	r.caches[e.fingerprint] = &sharedSchemaCache{cache: e.validator.cache.Load(), refs: 1}
+}
+
+func (r *Registry) evict() {
+	for r.capacity > 0 && r.lru.Len() > r.capacity {
+		r.remove(r.lru.Back())
+	}
+}
+
+func (r *Registry) remove(el *list.Element) {
+	e := r.lru.Remove(el).(*registryEntry)
+	delete(r.entries, e.id)
+	if !e.acquired {
+		return
+	}
+	if shared, ok := r.caches[e.fingerprint]; ok {
+		shared.refs--
+		if shared.refs <= 0 {
+			delete(r.caches, e.fingerprint)
+		}
+	}
+}
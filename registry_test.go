@@ -0,0 +1,97 @@
+package openapi_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestRegistry(t *testing.T) {
+	var loads atomic.Int32
+	loader := func(_ context.Context, id string) (*openapi.Extendable[openapi.OpenAPI], error) {
+		loads.Add(1)
+		if id == "broken" {
+			return nil, errors.New("not found")
+		}
+		typ := openapi.IntegerType
+		if id == "string" {
+			typ = openapi.StringType
+		}
+		return openapi.NewOpenAPIBuilder().
+			AddComponent("ID", openapi.NewSchemaBuilder().Type(typ).Build()).
+			Build(), nil
+	}
+	registry := openapi.NewRegistry(loader, 2)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := registry.Get(ctx, "a")
+			require.NoError(t, err)
+			require.NoError(t, v.ValidateData("/components/schemas/ID", 1))
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, loads.Load())
+
+	b, err := registry.Get(ctx, "b")
+	require.NoError(t, err)
+	require.Error(t, b.ValidateData("/components/schemas/ID", "foo"))
+	require.Equal(t, 2, registry.Len())
+
+	s, err := registry.Get(ctx, "string")
+	require.NoError(t, err)
+	require.NoError(t, s.ValidateData("/components/schemas/ID", "foo"))
+	require.Equal(t, 2, registry.Len())
+	require.EqualValues(t, 3, loads.Load())
+
+	// "a" has been evicted as the least recently used
+	_, err = registry.Get(ctx, "b")
+	require.NoError(t, err)
+	_, err = registry.Get(ctx, "a")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, loads.Load())
+
+	registry.Remove("a")
+	require.Equal(t, 1, registry.Len())
+
+	_, err = registry.Get(ctx, "broken")
+	require.ErrorContains(t, err, `loading spec "broken" failed: not found`)
+	_, err = registry.Get(ctx, "broken")
+	require.Error(t, err)
+	require.EqualValues(t, 6, loads.Load())
+	require.Equal(t, 1, registry.Len())
+}
+
+func TestRegistry_Documents(t *testing.T) {
+	var pets, owner *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsPets), &pets))
+	require.NoError(t, yaml.Unmarshal([]byte(testDocumentsOwner), &owner))
+	registry := openapi.NewRegistry(func(context.Context, string) (*openapi.Extendable[openapi.OpenAPI], error) {
+		var root *openapi.Extendable[openapi.OpenAPI]
+		err := yaml.Unmarshal([]byte(testDocumentsRoot), &root)
+		return root, err
+	}, 2, openapi.Documents(map[string]*openapi.Extendable[openapi.OpenAPI]{
+		"schemas/pets.yaml":   pets,
+		"./common/owner.yaml": owner,
+	}))
+	const location = "/paths/~1pets/get/responses/200/content/application~1json/schema"
+
+	for _, id := range []string{"a", "b"} {
+		v, err := registry.Get(context.Background(), id)
+		require.NoError(t, err)
+		require.NoError(t, v.ValidateSpec())
+		require.NoError(t, v.ValidateData(location, []any{map[string]any{"name": "Rex", "owner": map[string]any{"id": 1}}}))
+		require.ErrorContains(t, v.ValidateData(location, []any{map[string]any{"name": "Rex", "owner": map[string]any{"id": "1"}}}), "/0/owner/id")
+	}
+}
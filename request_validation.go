@@ -0,0 +1,314 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	ErrOperationNotFound    = errors.New("operation not found")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// operationMatch is an operation found for a request with the raw values of the path parameters.
+type operationMatch struct {
+	pathItem   *Extendable[PathItem]
+	operation  *Extendable[Operation]
+	pathParams map[string]string
+	// JSON Pointer of the operation in the specification, e.g. `/paths/~1pets/get`
+	location string
+}
+
+// findOperation finds the operation for the given method and escaped path using the router of the validator,
+// so the base paths of the servers are removed from the path, see Router.
+func (v *Validator) findOperation(method, path string) (*operationMatch, error) {
+	router, err := v.pathRouter()
+	if err != nil {
+		return nil, err
+	}
+	route, op, params, ok := router.match(method, path)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrOperationNotFound)
+	}
+	return &operationMatch{
+		pathItem:   route.ext,
+		operation:  op,
+		pathParams: params,
+		location:   joinLoc(route.ref.getLocationOrRef(joinLoc("/paths", route.template)), strings.ToLower(method)),
+	}, nil
+}
+
+// pathRouter returns the router of the spec, it is created once on the first use,
+// so the validators used only for the spec validation do not resolve the paths.
+// The router is kept along with the compiled schemas, so it is created again after Refresh or Invalidate.
+func (v *Validator) pathRouter() (*Router, error) {
+	r := v.cache.Load().router.Load()
+	r.once.Do(func() {
+		r.router, r.err = NewRouter(v.spec)
+	})
+	return r.router, r.err
+}
+
+// matchPathItem returns the operation of the path item with the given template, nil if there is no such operation.
+func (v *Validator) matchPathItem(method, template string, params map[string]string) (*operationMatch, error) {
+	spec := v.spec.Spec
+	item := spec.Paths.Spec.Paths[template]
+	pathItem, err := item.GetSpec(spec.Components)
+	if err != nil {
+		return nil, fmt.Errorf("path %q: %w", template, err)
+	}
+	op := pathItem.Spec.operation(method)
+	if op == nil {
+		return nil, nil
+	}
+	return &operationMatch{
+		pathItem:   pathItem,
+		operation:  op,
+		pathParams: params,
+		location:   joinLoc(item.getLocationOrRef(joinLoc("/paths", template)), strings.ToLower(method)),
+	}, nil
+}
+
+// matchRequest finds the operation for the given HTTP request using the route adapter, if set, or the path of the request.
+func (v *Validator) matchRequest(r *http.Request) (*operationMatch, error) {
+	if v.opts.routeAdapter != nil {
+		if route, ok := v.opts.routeAdapter.Route(r); ok {
+			match, err := v.findRouteOperation(r.Method, route)
+			if err != nil || match != nil {
+				return match, err
+			}
+		}
+	}
+	return v.findOperation(r.Method, r.URL.EscapedPath())
+}
+
+type operationParameter struct {
+	spec     *Parameter
+	location string
+}
+
+// parameters returns the list of the parameters of the path item and the operation.
+// The operation parameters override the path item parameters with the same name and location.
+func (m *operationMatch) parameters(components *Extendable[Components]) ([]*operationParameter, error) {
+	var params []*operationParameter
+	index := make(map[paramKey]int)
+	add := func(location string, list []*RefOrSpec[Extendable[Parameter]]) error {
+		for i, p := range list {
+			spec, err := p.GetSpec(components)
+			if err != nil {
+				return err
+			}
+			param := &operationParameter{
+				spec:     spec.Spec,
+				location: p.getLocationOrRef(joinLoc(location, "parameters", i)),
+			}
+			key := paramKey{name: spec.Spec.Name, in: spec.Spec.In}
+			if spec.Spec.In == InHeader {
+				key.name = http.CanonicalHeaderKey(key.name)
+			}
+			if j, ok := index[key]; ok {
+				params[j] = param
+			} else {
+				index[key] = len(params)
+				params = append(params, param)
+			}
+		}
+		return nil
+	}
+	pathItemLoc := Location(m.location).Parent().String()
+	if err := add(pathItemLoc, m.pathItem.Spec.Parameters); err != nil {
+		return nil, err
+	}
+	if err := add(m.location, m.operation.Spec.Parameters); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// schemaLocation returns the location of the schema of the parameter to be used by ValidateData.
+func (p *operationParameter) schemaLocation() string {
+	if p.spec.Schema != nil {
+		return p.spec.Schema.getLocationOrRef(joinLoc(p.location, "schema"))
+	}
+	for k, v := range p.spec.Content {
+		if v.Spec.Schema != nil {
+			return v.Spec.Schema.getLocationOrRef(joinLoc(p.location, "content", k, "schema"))
+		}
+	}
+	return ""
+}
+
+// requestValues holds the raw (not decoded) values of all parameters of a request.
+type requestValues struct {
+	header  http.Header
+	path    map[string]string
+	query   map[string][]string
+	cookies map[string][]string
+}
+
+func newRequestValues(r *http.Request, pathParams map[string]string) *requestValues {
+	cookies := make(map[string][]string)
+	for _, c := range r.Cookies() {
+		cookies[c.Name] = append(cookies[c.Name], c.Value)
+	}
+	return &requestValues{
+		header:  r.Header,
+		path:    pathParams,
+		query:   parseRawQuery(r.URL.RawQuery),
+		cookies: cookies,
+	}
+}
+
+// get returns the raw values of the parameter and the form values
+// used to decode the exploded objects of form style (query or cookies).
+func (rv *requestValues) get(p *Parameter) ([]string, map[string][]string) {
+	switch p.In {
+	case InPath:
+		if v, ok := rv.path[p.Name]; ok {
+			return []string{v}, nil
+		}
+	case InQuery:
+		return rv.query[p.Name], rv.query
+	case InHeader:
+		return rv.header.Values(p.Name), nil
+	case InCookie:
+		return rv.cookies[p.Name], rv.cookies
+	}
+	return nil, nil
+}
+
+// ValidateHTTPRequest finds the operation matching the given HTTP request and validates
+// the path, query, header and cookie parameters and the request body against the specification.
+//
+// The parameters defined using `schema` are decoded according to their style and the declared types,
+// the parameters defined using `content` are parsed according to the media type, e.g. a JSON object in a query parameter.
+// The cookie parameters use the form style: the exploded arrays are sent as several cookies with the same name
+// and the exploded objects as a cookie per property.
+// The request body is validated if its media type is JSON, the body is restored, so it can be read again.
+// The size of the body and the complexity of the JSON can be limited using MaxBodySize, MaxJSONDepth,
+// and MaxJSONElements options.
+// The operation is found using the route matched by a router, if the WithRouteAdapter option is set.
+func (v *Validator) ValidateHTTPRequest(r *http.Request) error {
+	match, err := v.matchRequest(r)
+	if err != nil {
+		return err
+	}
+	return v.validateRequest(r, match)
+}
+
+// validateRequest validates the parameters and the body of the request against the found operation.
+func (v *Validator) validateRequest(r *http.Request, match *operationMatch) error {
+	components := v.spec.Spec.Components
+
+	params, err := match.parameters(components)
+	if err != nil {
+		return err
+	}
+	var errs []*validationError
+	rv := newRequestValues(r, match.pathParams)
+	for _, p := range params {
+		// the Accept, Content-Type and Authorization headers are ignored by the specification
+		if p.spec.In == InHeader {
+			switch http.CanonicalHeaderKey(p.spec.Name) {
+			case "Accept", "Content-Type", "Authorization":
+				continue
+			}
+		}
+		loc := joinLoc(p.spec.In, p.spec.Name)
+		values, form := rv.get(p.spec)
+		value, err := p.spec.decode(values, form, components)
+		if err != nil {
+			errs = append(errs, newValidationError(loc, "%w: %w", ErrInvalidFormat, err))
+			continue
+		}
+		if obj, ok := value.(map[string]any); len(values) == 0 && (!ok || len(obj) == 0) {
+			if p.spec.Required {
+				errs = append(errs, newValidationError(loc, ErrRequired))
+			}
+			continue
+		}
+		if schemaLoc := p.schemaLocation(); schemaLoc != "" {
+			if err := v.ValidateData(schemaLoc, value); err != nil {
+				errs = append(errs, newValidationError(loc, "%w: %w", ErrInvalidData, err))
+			}
+		}
+	}
+
+	errs = append(errs, v.validateRequestBody(r, match)...)
+
+	if len(errs) > 0 {
+		joinErrors := make([]error, len(errs))
+		for i := range errs {
+			joinErrors[i] = errs[i]
+		}
+		return errors.Join(joinErrors...)
+	}
+	return nil
+}
+
+func (v *Validator) validateRequestBody(r *http.Request, match *operationMatch) []*validationError {
+	const loc = "body"
+	if match.operation.Spec.RequestBody == nil {
+		return nil
+	}
+	body, err := match.operation.Spec.RequestBody.GetSpec(v.spec.Spec.Components)
+	if err != nil {
+		return []*validationError{newValidationError(loc, err)}
+	}
+
+	var data []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		data, err = v.readBody(r.Body, r.ContentLength)
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		if errors.Is(err, ErrBodyTooLarge) {
+			return []*validationError{newValidationError(loc, err)}
+		}
+		if err != nil {
+			return []*validationError{newValidationError(loc, fmt.Errorf("%w: reading body failed: %w", ErrInvalidData, err))}
+		}
+	}
+	if len(data) == 0 {
+		if body.Spec.Required {
+			return []*validationError{newValidationError(loc, ErrRequired)}
+		}
+		return nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, content := matchMediaType(body.Spec.Content, contentType)
+	if content == nil {
+		return []*validationError{newValidationError(loc, "'%s': %w", contentType, ErrUnsupportedMediaType)}
+	}
+	if content.Spec.Schema == nil {
+		return nil
+	}
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isJSONMediaType(mt) {
+		// only JSON bodies are validated
+		return nil
+	}
+	if err := v.checkJSONLimits(data); err != nil {
+		return []*validationError{newValidationError(loc, err)}
+	}
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []*validationError{newValidationError(loc, fmt.Errorf("%w: parsing body failed: %w", ErrInvalidFormat, err))}
+	}
+	bodyLoc := match.operation.Spec.RequestBody.getLocationOrRef(joinLoc(match.location, "requestBody"))
+	schemaLoc := content.Spec.Schema.getLocationOrRef(joinLoc(bodyLoc, "content", mediaType, "schema"))
+	if errs, ok := v.validatePatch(mt, schemaLoc, content.Spec.Schema, value); ok {
+		return errs
+	}
+	if err := v.ValidateData(schemaLoc, value); err != nil {
+		return []*validationError{newValidationError(loc, "%w: %w", ErrInvalidData, err)}
+	}
+	return nil
+}
@@ -0,0 +1,450 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testRequestSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  kind:
+                    type: string
+                required: [kind]
+        - $ref: '#/components/parameters/RequestID'
+      responses:
+        '200':
+          description: OK
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      responses:
+        '200':
+          description: OK
+  /pets/mine:
+    get:
+      responses:
+        '200':
+          description: OK
+  /session:
+    get:
+      parameters:
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+            minLength: 3
+        - name: ids
+          in: cookie
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: prefs
+          in: cookie
+          schema:
+            type: object
+            properties:
+              theme:
+                type: string
+                enum: [dark, light]
+              size:
+                type: integer
+      responses:
+        '200':
+          description: OK
+components:
+  parameters:
+    RequestID:
+      name: X-Request-ID
+      in: header
+      required: true
+      schema:
+        type: string
+        format: uuid
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+      required: [name]
+`
+
+func newTestRequestValidator(t *testing.T, spec string) *openapi.Validator {
+	t.Helper()
+	var o *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &o))
+	v, err := openapi.NewValidator(o)
+	require.NoError(t, err)
+	return v
+}
+
+func TestValidator_ValidateHTTPRequest(t *testing.T) {
+	validator := newTestRequestValidator(t, testRequestSpec)
+
+	const requestID = "6f1c1f3a-8b7a-4a51-9a8d-2f1e0c6b7d11"
+	for _, tt := range []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		headers map[string]string
+		err     string
+	}{
+		{
+			name:    "query params",
+			method:  http.MethodGet,
+			target:  "/pets?limit=10&tags=a&tags=b&filter=" + url.QueryEscape(`{"kind":"dog"}`),
+			headers: map[string]string{"X-Request-ID": requestID},
+		},
+		{
+			name:    "query param out of range",
+			method:  http.MethodGet,
+			target:  "/pets?limit=1000",
+			headers: map[string]string{"X-Request-ID": requestID},
+			err:     "query/limit",
+		},
+		{
+			name:    "content param invalid",
+			method:  http.MethodGet,
+			target:  "/pets?filter=" + url.QueryEscape(`{"name":"dog"}`),
+			headers: map[string]string{"X-Request-ID": requestID},
+			err:     "query/filter",
+		},
+		{
+			name:    "content param not json",
+			method:  http.MethodGet,
+			target:  "/pets?filter=dog",
+			headers: map[string]string{"X-Request-ID": requestID},
+			err:     `query/filter: invalid format: parsing value as "application/json" failed`,
+		},
+		{
+			name:   "required header",
+			method: http.MethodGet,
+			target: "/pets",
+			err:    "header/X-Request-ID: required",
+		},
+		{
+			name:   "path param",
+			method: http.MethodGet,
+			target: "/pets/42",
+		},
+		{
+			name:   "path param invalid",
+			method: http.MethodGet,
+			target: "/pets/foo",
+			err:    "path/petId",
+		},
+		{
+			name:   "concrete path first",
+			method: http.MethodGet,
+			target: "/pets/mine",
+		},
+		{
+			name:    "body",
+			method:  http.MethodPost,
+			target:  "/pets",
+			body:    `{"name": "Rex"}`,
+			headers: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		},
+		{
+			name:    "body invalid",
+			method:  http.MethodPost,
+			target:  "/pets",
+			body:    `{"tag": "dog"}`,
+			headers: map[string]string{"Content-Type": "application/json"},
+			err:     "body",
+		},
+		{
+			name:   "body required",
+			method: http.MethodPost,
+			target: "/pets",
+			err:    "body: required",
+		},
+		{
+			name:    "body unsupported media type",
+			method:  http.MethodPost,
+			target:  "/pets",
+			body:    `name=Rex`,
+			headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			err:     "unsupported media type",
+		},
+		{
+			name:    "cookies",
+			method:  http.MethodGet,
+			target:  "/session",
+			headers: map[string]string{"Cookie": "session=abc; ids=1; ids=2; theme=dark; size=10"},
+		},
+		{
+			name:    "cookies not exploded",
+			method:  http.MethodGet,
+			target:  "/session",
+			headers: map[string]string{"Cookie": "session=abc; ids=1,2"},
+		},
+		{
+			name:    "cookie array invalid",
+			method:  http.MethodGet,
+			target:  "/session",
+			headers: map[string]string{"Cookie": "session=abc; ids=1; ids=foo"},
+			err:     "cookie/ids",
+		},
+		{
+			name:    "cookie object invalid",
+			method:  http.MethodGet,
+			target:  "/session",
+			headers: map[string]string{"Cookie": "session=abc; theme=blue"},
+			err:     "cookie/prefs",
+		},
+		{
+			name:    "cookie too short",
+			method:  http.MethodGet,
+			target:  "/session",
+			headers: map[string]string{"Cookie": "session=a%20"},
+			err:     "cookie/session",
+		},
+		{
+			name:   "cookie required",
+			method: http.MethodGet,
+			target: "/session",
+			err:    "cookie/session: required",
+		},
+		{
+			name:   "operation not found",
+			method: http.MethodDelete,
+			target: "/pets",
+			err:    "operation not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			err := validator.ValidateHTTPRequest(r)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateHTTPRequest_BodyLimits(t *testing.T) {
+	var o *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testRequestSpec), &o))
+
+	for _, tt := range []struct {
+		name          string
+		opts          []openapi.ValidationOption
+		body          string
+		contentLength int64
+		err           error
+	}{
+		{
+			name: "no limits",
+			body: `{"name": "Rex", "tags": [[["a"]]]}`,
+		},
+		{
+			name: "within limits",
+			opts: []openapi.ValidationOption{openapi.MaxBodySize(100), openapi.MaxJSONDepth(4), openapi.MaxJSONElements(6)},
+			body: `{"name": "Rex", "tags": [[["a"]]]}`,
+		},
+		{
+			name: "body too large",
+			opts: []openapi.ValidationOption{openapi.MaxBodySize(10)},
+			body: `{"name": "Rex"}`,
+			err:  openapi.ErrBodyTooLarge,
+		},
+		{
+			name:          "content length too large",
+			opts:          []openapi.ValidationOption{openapi.MaxBodySize(10)},
+			body:          `{}`,
+			contentLength: 1000,
+			err:           openapi.ErrBodyTooLarge,
+		},
+		{
+			name: "too deep",
+			opts: []openapi.ValidationOption{openapi.MaxJSONDepth(3)},
+			body: `{"name": "Rex", "tags": [[["a"]]]}`,
+			err:  openapi.ErrJSONTooDeep,
+		},
+		{
+			name: "too many elements",
+			opts: []openapi.ValidationOption{openapi.MaxJSONElements(4)},
+			body: `{"name": "Rex", "tags": [1, 2, 3]}`,
+			err:  openapi.ErrJSONTooManyElements,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(o, tt.opts...)
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			if tt.contentLength > 0 {
+				r.ContentLength = tt.contentLength
+			}
+			err = validator.ValidateHTTPRequest(r)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+const testBasePathSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+`
+
+func TestValidator_ValidateHTTPRequest_ServerBasePath(t *testing.T) {
+	validator := newTestRequestValidator(t, testBasePathSpec)
+
+	for _, tt := range []struct {
+		name   string
+		target string
+		err    string
+	}{
+		{name: "base path", target: "/v1/pets/42"},
+		{name: "base path invalid param", target: "/v1/pets/foo", err: "path/petId"},
+		{name: "no base path", target: "/pets/42", err: "operation not found"},
+		{name: "other base path", target: "/v2/pets/42", err: "operation not found"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidator_ValidateHTTPRequest_Numbers(t *testing.T) {
+	validator := newTestRequestValidator(t, `
+openapi: 3.1.1
+info:
+  title: Numbers
+  version: 1.0.0
+paths:
+  /items:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: ratio
+          in: query
+          schema:
+            type: number
+      responses:
+        '200':
+          description: OK
+`)
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: "limit=10"},
+		{query: "limit=-1"},
+		{query: "limit=0"},
+		{query: "ratio=1.5"},
+		{query: "ratio=-0.5e3"},
+		{query: "ratio=1E-2"},
+		{query: "limit=1_0", err: "query/limit"},
+		{query: "limit=0x10", err: "query/limit"},
+		{query: "limit=%2B1", err: "query/limit"},
+		{query: "limit=010", err: "query/limit"},
+		{query: "limit=%201", err: "query/limit"},
+		{query: "limit=1%20", err: "query/limit"},
+		{query: "ratio=NaN", err: "query/ratio"},
+		{query: "ratio=Inf", err: "query/ratio"},
+		{query: "ratio=-Infinity", err: "query/ratio"},
+		{query: "ratio=.5", err: "query/ratio"},
+		{query: "ratio=1.", err: "query/ratio"},
+		{query: "ratio=0x1p-2", err: "query/ratio"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			err := validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil))
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var ErrResponseNotFound = errors.New("response not found")
+
+// ValidateResponse finds the response of the operation matching the given method and escaped path
+// and validates the headers and the body of the response against the specification,
+// e.g. to check the responses of the handlers in the integration tests.
+//
+// The response is found by the status code, then by the range of the status code, like `5XX`,
+// and then the `default` response is used.
+// The headers are decoded according to their declared types like the header parameters of the requests,
+// `Content-Type` header is ignored as required by the specification.
+// The body is validated if its media type is JSON, the empty body is not validated.
+// The size of the body and the complexity of the JSON can be limited using MaxBodySize, MaxJSONDepth,
+// and MaxJSONElements options.
+func (v *Validator) ValidateResponse(method, path string, status int, contentType string, headers http.Header, body []byte) error {
+	match, err := v.findOperation(method, path)
+	if err != nil {
+		return err
+	}
+	components := v.spec.Spec.Components
+	code, ref := operationResponse(match.operation.Spec.Responses, status)
+	if ref == nil {
+		return fmt.Errorf("%s %s: %d: %w", method, path, status, ErrResponseNotFound)
+	}
+	response, err := ref.GetSpec(components)
+	if err != nil {
+		return err
+	}
+	location := ref.getLocationOrRef(joinLoc(match.location, "responses", code))
+
+	var errs []*validationError
+	for _, name := range sortedKeys(response.Spec.Headers) {
+		if http.CanonicalHeaderKey(name) == "Content-Type" {
+			continue
+		}
+		errs = append(errs, v.validateResponseHeader(location, name, response.Spec.Headers[name], headers)...)
+	}
+	errs = append(errs, v.validateResponseBody(location, response.Spec, contentType, body)...)
+
+	if len(errs) > 0 {
+		joinErrors := make([]error, len(errs))
+		for i := range errs {
+			joinErrors[i] = errs[i]
+		}
+		return errors.Join(joinErrors...)
+	}
+	return nil
+}
+
+// operationResponse returns the response and its key for the given status code, the range response,
+// or the default response.
+func operationResponse(responses *Extendable[Responses], status int) (string, *RefOrSpec[Extendable[Response]]) {
+	if responses == nil || responses.Spec == nil {
+		return "", nil
+	}
+	code := strconv.Itoa(status)
+	if r, ok := responses.Spec.Response[code]; ok {
+		return code, r
+	}
+	if len(code) == 3 {
+		if r, ok := responses.Spec.Response[code[:1]+"XX"]; ok {
+			return code[:1] + "XX", r
+		}
+	}
+	return "default", responses.Spec.Default
+}
+
+func (v *Validator) validateResponseHeader(
+	location, name string,
+	ref *RefOrSpec[Extendable[Header]],
+	headers http.Header,
+) []*validationError {
+	loc := joinLoc(InHeader, name)
+	header, err := ref.GetSpec(v.spec.Spec.Components)
+	if err != nil {
+		return []*validationError{newValidationError(loc, err)}
+	}
+	// the header is decoded as the header parameter with the same definition
+	p := &operationParameter{
+		spec: &Parameter{
+			Name:     name,
+			In:       InHeader,
+			Style:    header.Spec.Style,
+			Explode:  header.Spec.Explode,
+			Required: header.Spec.Required,
+			Schema:   header.Spec.Schema,
+			Content:  header.Spec.Content,
+		},
+		location: ref.getLocationOrRef(joinLoc(location, "headers", name)),
+	}
+	values := headers.Values(name)
+	if len(values) == 0 {
+		if header.Spec.Required {
+			return []*validationError{newValidationError(loc, ErrRequired)}
+		}
+		return nil
+	}
+	value, err := p.spec.decode(values, nil, v.spec.Spec.Components)
+	if err != nil {
+		return []*validationError{newValidationError(loc, "%w: %w", ErrInvalidFormat, err)}
+	}
+	if schemaLoc := p.schemaLocation(); schemaLoc != "" {
+		if err := v.ValidateData(schemaLoc, value); err != nil {
+			return []*validationError{newValidationError(loc, "%w: %w", ErrInvalidData, err)}
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateResponseBody(location string, response *Response, contentType string, data []byte) []*validationError {
+	const loc = "body"
+	if len(data) == 0 {
+		return nil
+	}
+	if _, err := v.readBody(bytes.NewReader(data), int64(len(data))); err != nil {
+		return []*validationError{newValidationError(loc, err)}
+	}
+	mediaType, content := matchMediaType(response.Content, contentType)
+	if content == nil {
+		return []*validationError{newValidationError(loc, "'%s': %w", contentType, ErrUnsupportedMediaType)}
+	}
+	if content.Spec.Schema == nil {
+		return nil
+	}
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !isJSONMediaType(mt) {
+		// only JSON bodies are validated
+		return nil
+	}
+	if err := v.checkJSONLimits(data); err != nil {
+		return []*validationError{newValidationError(loc, err)}
+	}
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []*validationError{newValidationError(loc, fmt.Errorf("%w: parsing body failed: %w", ErrInvalidFormat, err))}
+	}
+	schemaLoc := content.Spec.Schema.getLocationOrRef(joinLoc(location, "content", mediaType, "schema"))
+	if err := v.ValidateData(schemaLoc, value); err != nil {
+		return []*validationError{newValidationError(loc, "%w: %w", ErrInvalidData, err)}
+	}
+	return nil
+}
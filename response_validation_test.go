@@ -0,0 +1,235 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testResponseSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Total-Count:
+              required: true
+              schema:
+                type: integer
+                minimum: 0
+            Content-Type:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        5XX:
+          description: Server error
+          content:
+            application/problem+json:
+              schema:
+                $ref: '#/components/schemas/Problem'
+        default:
+          $ref: '#/components/responses/Error'
+  /pets/{id}:
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Problem:
+      type: object
+      required: [status]
+      properties:
+        status:
+          type: integer
+  responses:
+    Error:
+      description: Error
+      headers:
+        Retry-After:
+          schema:
+            type: integer
+      content:
+        text/plain:
+          schema:
+            type: string
+`
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	validator := newTestRequestValidator(t, testResponseSpec)
+
+	for _, tt := range []struct {
+		name        string
+		method      string
+		path        string
+		status      int
+		contentType string
+		headers     map[string]string
+		body        string
+		err         string
+	}{
+		{
+			name:        "valid",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			headers:     map[string]string{"X-Total-Count": "1"},
+			body:        `[{"name": "Rex"}]`,
+		},
+		{
+			name:        "required header",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `[]`,
+			err:         "header/X-Total-Count: required",
+		},
+		{
+			name:        "invalid header",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			headers:     map[string]string{"X-Total-Count": "-1"},
+			body:        `[]`,
+			err:         "header/X-Total-Count: invalid data",
+		},
+		{
+			name:        "invalid body",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			headers:     map[string]string{"X-Total-Count": "1"},
+			body:        `[{"age": 1}]`,
+			err:         "body: invalid data",
+		},
+		{
+			name:        "not json body",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			headers:     map[string]string{"X-Total-Count": "1"},
+			body:        `[`,
+			err:         "body: invalid format",
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusOK,
+			contentType: "text/plain",
+			headers:     map[string]string{"X-Total-Count": "1"},
+			body:        `Rex`,
+			err:         "'text/plain': unsupported media type",
+		},
+		{
+			name:        "range",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusServiceUnavailable,
+			contentType: "application/problem+json",
+			body:        `{"status": "503"}`,
+			err:         "body: invalid data",
+		},
+		{
+			name:        "default",
+			method:      http.MethodGet,
+			path:        "/pets",
+			status:      http.StatusNotFound,
+			contentType: "text/plain",
+			headers:     map[string]string{"Retry-After": "soon"},
+			body:        `not found`,
+			err:         "header/Retry-After: invalid data",
+		},
+		{
+			name:   "empty body",
+			method: http.MethodDelete,
+			path:   "/pets/42",
+			status: http.StatusNoContent,
+		},
+		{
+			name:   "response not found",
+			method: http.MethodDelete,
+			path:   "/pets/42",
+			status: http.StatusOK,
+			err:    "DELETE /pets/42: 200: response not found",
+		},
+		{
+			name:   "operation not found",
+			method: http.MethodPost,
+			path:   "/pets/42",
+			status: http.StatusOK,
+			err:    "operation not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := make(http.Header)
+			for k, v := range tt.headers {
+				headers.Set(k, v)
+			}
+			err := validator.ValidateResponse(tt.method, tt.path, tt.status, tt.contentType, headers, []byte(tt.body))
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	err := validator.ValidateResponse(http.MethodDelete, "/pets/42", http.StatusOK, "", nil, nil)
+	require.ErrorIs(t, err, openapi.ErrResponseNotFound)
+	require.Equal(t, openapi.MsgResponseNotFound, openapi.MessageIDOf(err))
+}
+
+func TestValidator_ValidateResponse_Limits(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testResponseSpec), &spec))
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	limited, err := openapi.NewValidator(spec, openapi.MaxBodySize(8))
+	require.NoError(t, err)
+
+	headers := http.Header{"X-Total-Count": []string{"1"}}
+	body := []byte(`[{"name": "Rex"}]`)
+	require.NoError(t, validator.ValidateResponse(http.MethodGet, "/pets", http.StatusOK, "application/json", headers, body))
+	err = limited.ValidateResponse(http.MethodGet, "/pets", http.StatusOK, "application/json", headers, body)
+	require.ErrorIs(t, err, openapi.ErrBodyTooLarge)
+}
+
+func TestValidator_ValidateResponse_ServerBasePath(t *testing.T) {
+	validator := newTestRequestValidator(t, testBasePathSpec)
+
+	err := validator.ValidateResponse(http.MethodGet, "/v1/pets/42", http.StatusOK, "application/json", nil, []byte(`{"name": "Rex"}`))
+	require.NoError(t, err)
+
+	err = validator.ValidateResponse(http.MethodGet, "/v1/pets/42", http.StatusOK, "application/json", nil, []byte(`{}`))
+	require.ErrorContains(t, err, "body: invalid data")
+
+	err = validator.ValidateResponse(http.MethodGet, "/pets/42", http.StatusOK, "application/json", nil, []byte(`{"name": "Rex"}`))
+	require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+}
@@ -92,19 +92,15 @@ func (o *Responses) UnmarshalJSON(data []byte) error {
 
 // MarshalYAML implements yaml.Marshaler interface.
 func (o *Responses) MarshalYAML() (any, error) {
-	var raw map[string]any
-	data, err := yaml.Marshal(&o.Response)
-	if err != nil {
-		return nil, err
+	if len(o.Response) == 0 && o.Default == nil {
+		return nil, nil
 	}
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		return nil, err
+	// the responses are not converted into raw values to keep their raw extensions
+	raw := make(map[string]any, len(o.Response)+1)
+	for code, response := range o.Response {
+		raw[code] = response
 	}
-
 	if o.Default != nil {
-		if raw == nil {
-			raw = make(map[string]any, 1)
-		}
 		raw["default"] = o.Default
 	}
 	return raw, nil
@@ -140,51 +136,9 @@ func (o *Responses) validateSpec(location string, validator *Validator) []*valid
 	}
 	for k, v := range o.Response {
 		if !ResponseCodePattern.MatchString(k) {
-			errs = append(errs, newValidationError(joinLoc(location, k), "must match pattern '%s', but got '%s'", ResponseCodePattern, k))
+			errs = append(errs, newValidationError(joinLoc(location, k), "%w: must match pattern '%s', but got '%s'", ErrInvalidFormat, ResponseCodePattern, k))
 		}
 		errs = append(errs, v.validateSpec(joinLoc(location, k), validator)...)
 	}
 	return errs
 }
-
-type ResponsesBuilder struct {
-	spec *RefOrSpec[Extendable[Responses]]
-}
-
-func NewResponsesBuilder() *ResponsesBuilder {
-	return &ResponsesBuilder{
-		spec: NewRefOrExtSpec[Responses](&Responses{}),
-	}
-}
-
-func (b *ResponsesBuilder) Build() *RefOrSpec[Extendable[Responses]] {
-	return b.spec
-}
-
-func (b *ResponsesBuilder) Extensions(v map[string]any) *ResponsesBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *ResponsesBuilder) AddExt(name string, value any) *ResponsesBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *ResponsesBuilder) Default(v *RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
-	b.spec.Spec.Spec.Default = v
-	return b
-}
-
-func (b *ResponsesBuilder) Response(v map[string]*RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
-	b.spec.Spec.Spec.Response = v
-	return b
-}
-
-func (b *ResponsesBuilder) AddResponse(key string, value *RefOrSpec[Extendable[Response]]) *ResponsesBuilder {
-	if b.spec.Spec.Spec.Response == nil {
-		b.spec.Spec.Spec.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
-	}
-	b.spec.Spec.Spec.Response[key] = value
-	return b
-}
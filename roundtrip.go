@@ -0,0 +1,146 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrRoundTrip is returned by RoundTripCheck if the document is not preserved.
+var ErrRoundTrip = errors.New("not preserved after round-trip")
+
+// RoundTripCheck unmarshals the given JSON or YAML document into the OpenAPI object, marshals it back
+// using the same format and structurally compares the result with the original data.
+//
+// The function returns an error for each location that has been changed, for instance, a dropped unknown field.
+// It can be used to assert that the library preserves a specification without any loss.
+func RoundTripCheck(data []byte) error {
+	var (
+		spec    *Extendable[OpenAPI]
+		newData []byte
+		orig    any
+		result  any
+	)
+	if isJSON(data) {
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return fmt.Errorf("unmarshaling JSON failed: %w", err)
+		}
+		var err error
+		newData, err = json.Marshal(&spec)
+		if err != nil {
+			return fmt.Errorf("marshaling JSON failed: %w", err)
+		}
+		if err := json.Unmarshal(data, &orig); err != nil {
+			return fmt.Errorf("unmarshaling original JSON failed: %w", err)
+		}
+		if err := json.Unmarshal(newData, &result); err != nil {
+			return fmt.Errorf("unmarshaling new JSON failed: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return fmt.Errorf("unmarshaling YAML failed: %w", err)
+		}
+		var err error
+		newData, err = yaml.Marshal(&spec)
+		if err != nil {
+			return fmt.Errorf("marshaling YAML failed: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &orig); err != nil {
+			return fmt.Errorf("unmarshaling original YAML failed: %w", err)
+		}
+		if err := yaml.Unmarshal(newData, &result); err != nil {
+			return fmt.Errorf("unmarshaling new YAML failed: %w", err)
+		}
+		orig = normalizeYAMLValue(orig)
+		result = normalizeYAMLValue(result)
+	}
+
+	if errs := compareValues("", orig, result); len(errs) > 0 {
+		joinErrors := make([]error, len(errs))
+		for i := range errs {
+			joinErrors[i] = errs[i]
+		}
+		return errors.Join(joinErrors...)
+	}
+	return nil
+}
+
+func isJSON(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '{' || data[0] == '[')
+}
+
+// normalizeYAMLValue converts the maps with non-string keys (e.g. response codes) into map[string]any.
+func normalizeYAMLValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			t[k] = normalizeYAMLValue(val)
+		}
+		return t
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[fmt.Sprintf("%v", k)] = normalizeYAMLValue(val)
+		}
+		return m
+	case []any:
+		for i, val := range t {
+			t[i] = normalizeYAMLValue(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+func compareValues(location string, orig, result any) []*validationError {
+	origMap, ok1 := orig.(map[string]any)
+	resultMap, ok2 := result.(map[string]any)
+	if ok1 && ok2 {
+		keys := make([]string, 0, len(origMap))
+		for k := range origMap {
+			keys = append(keys, k)
+		}
+		for k := range resultMap {
+			if _, ok := origMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		var errs []*validationError
+		for _, k := range keys {
+			o, inOrig := origMap[k]
+			r, inResult := resultMap[k]
+			switch {
+			case !inResult:
+				errs = append(errs, newValidationError(joinLoc(location, k), "removed: %w", ErrRoundTrip))
+			case !inOrig:
+				errs = append(errs, newValidationError(joinLoc(location, k), "added: %w", ErrRoundTrip))
+			default:
+				errs = append(errs, compareValues(joinLoc(location, k), o, r)...)
+			}
+		}
+		return errs
+	}
+
+	origList, ok1 := orig.([]any)
+	resultList, ok2 := result.([]any)
+	if ok1 && ok2 && len(origList) == len(resultList) {
+		var errs []*validationError
+		for i := range origList {
+			errs = append(errs, compareValues(joinLoc(location, i), origList[i], resultList[i])...)
+		}
+		return errs
+	}
+
+	if !reflect.DeepEqual(orig, result) {
+		return []*validationError{newValidationError(location, "changed from '%v' to '%v': %w", orig, result, ErrRoundTrip)}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package openapi_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestRoundTripCheck(t *testing.T) {
+	info, err := os.ReadDir("testdata")
+	require.NoError(t, err)
+
+	for _, f := range info {
+		if f.IsDir() {
+			continue
+		}
+		name := f.Name()
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(path.Join("testdata", name))
+			require.NoError(t, err)
+			require.NoError(t, openapi.RoundTripCheck(data))
+		})
+	}
+
+	for _, tt := range []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "json unknown field",
+			data: `{"openapi": "3.1.1", "info": {"title": "foo", "version": "1.0.0", "foo": "bar"}}`,
+			err:  "/info/foo: removed: not preserved after round-trip",
+		},
+		{
+			name: "yaml unknown field",
+			data: "openapi: 3.1.1\ninfo:\n  title: foo\n  version: 1.0.0\nfoo: bar\n",
+			err:  "/foo: removed: not preserved after round-trip",
+		},
+		{
+			name: "invalid json",
+			data: `{"openapi": 3}`,
+			err:  "unmarshaling JSON failed",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := openapi.RoundTripCheck([]byte(tt.data))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
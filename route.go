@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Route is the route of a request matched by a router.
+type Route struct {
+	// Pattern is the path template of the route in the syntax of the router,
+	// e.g. `/pets/{id}` for chi or `/pets/:id` for gin and echo, see RoutePattern.
+	// The pattern may include the prefix of a route group, which is not a part of the paths of the spec.
+	Pattern string
+	// Params are the values of the path parameters extracted by the router.
+	Params map[string]string
+}
+
+// RouteAdapter extracts the route matched by a router from the request,
+// so the validator uses the route instead of matching the path of the request.
+type RouteAdapter interface {
+	// Route returns the route of the request, ok is false if the request has not been routed.
+	Route(r *http.Request) (route *Route, ok bool)
+}
+
+// RouteAdapterFunc is a function implementing RouteAdapter interface.
+//
+// Example for chi router:
+//
+//	openapi.RouteAdapterFunc(func(r *http.Request) (*openapi.Route, bool) {
+//		rc := chi.RouteContext(r.Context())
+//		if rc == nil {
+//			return nil, false
+//		}
+//		params := make(map[string]string, len(rc.URLParams.Keys))
+//		for i, k := range rc.URLParams.Keys {
+//			params[k] = rc.URLParams.Values[i]
+//		}
+//		return &openapi.Route{Pattern: rc.RoutePattern(), Params: params}, true
+//	})
+type RouteAdapterFunc func(r *http.Request) (*Route, bool)
+
+// Route implements RouteAdapter interface.
+func (f RouteAdapterFunc) Route(r *http.Request) (*Route, bool) {
+	return f(r)
+}
+
+// WithRouteAdapter is a validation option to use the routes matched by a router in ValidateHTTPRequest method,
+// e.g. when the validation is mounted as a middleware of a route group.
+func WithRouteAdapter(adapter RouteAdapter) ValidationOption {
+	return func(v *validationOptions) {
+		v.routeAdapter = adapter
+	}
+}
+
+// RoutePattern converts the path template of a router into the path template of OpenAPI:
+// the `:name` and `*name` segments of gin, echo, and httprouter become `{name}`,
+// and the regular expressions of chi and gorilla/mux, like `{id:[0-9]+}`, are removed.
+func RoutePattern(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, s := range segments {
+		switch {
+		case strings.HasPrefix(s, ":") || (strings.HasPrefix(s, "*") && len(s) > 1):
+			segments[i] = "{" + s[1:] + "}"
+		case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+			if name, _, found := strings.Cut(s[1:len(s)-1], ":"); found {
+				segments[i] = "{" + name + "}"
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ValidateRoute validates the given HTTP request like ValidateHTTPRequest does,
+// but the operation is found by the pattern of the route matched by a router
+// and the values of the path parameters are taken from the route.
+// The pattern is matched against the paths of the spec as is and then without the leading segments,
+// so the prefixes of the route groups are ignored.
+// If no path of the spec matches the pattern, the path of the request is matched instead.
+func (v *Validator) ValidateRoute(r *http.Request, route *Route) error {
+	match, err := v.findRouteOperation(r.Method, route)
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return v.ValidateHTTPRequest(r)
+	}
+	return v.validateRequest(r, match)
+}
+
+// findRouteOperation finds the operation for the given method and the route, nil is returned if the route is unknown.
+func (v *Validator) findRouteOperation(method string, route *Route) (*operationMatch, error) {
+	spec := v.spec.Spec
+	if route == nil || spec.Paths == nil || spec.Paths.Spec == nil {
+		return nil, nil
+	}
+	template := RoutePattern(route.Pattern)
+	for {
+		if _, ok := spec.Paths.Spec.Paths[template]; ok {
+			match, err := v.matchPathItem(method, template, route.Params)
+			if err == nil && match == nil {
+				err = fmt.Errorf("%s %s: %w", method, template, ErrOperationNotFound)
+			}
+			return match, err
+		}
+		i := strings.IndexByte(strings.TrimPrefix(template, "/"), '/')
+		if i < 0 {
+			return nil, nil
+		}
+		template = template[i+1:]
+	}
+}
@@ -0,0 +1,106 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestRoutePattern(t *testing.T) {
+	for _, tt := range []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: "/pets/{id}", expected: "/pets/{id}"},
+		{pattern: "/pets/{id:[0-9]+}/toys", expected: "/pets/{id}/toys"},
+		{pattern: "/pets/:id", expected: "/pets/{id}"},
+		{pattern: "/files/*path", expected: "/files/{path}"},
+		{pattern: "/files/*", expected: "/files/*"},
+		{pattern: "/pets", expected: "/pets"},
+	} {
+		t.Run(tt.pattern, func(t *testing.T) {
+			require.Equal(t, tt.expected, openapi.RoutePattern(tt.pattern))
+		})
+	}
+}
+
+func TestValidator_ValidateRoute(t *testing.T) {
+	v := newTestRequestValidator(t, testRequestSpec)
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		route  *openapi.Route
+		err    error
+	}{
+		{
+			name:   "chi",
+			method: http.MethodGet,
+			path:   "/pets/12",
+			route:  &openapi.Route{Pattern: "/pets/{petId:[0-9]+}", Params: map[string]string{"petId": "12"}},
+		},
+		{
+			name:   "group prefix",
+			method: http.MethodGet,
+			path:   "/api/v1/pets/12",
+			route:  &openapi.Route{Pattern: "/api/v1/pets/:petId", Params: map[string]string{"petId": "12"}},
+		},
+		{
+			name:   "invalid param",
+			method: http.MethodGet,
+			path:   "/api/v1/pets/abc",
+			route:  &openapi.Route{Pattern: "/api/v1/pets/:petId", Params: map[string]string{"petId": "abc"}},
+			err:    openapi.ErrInvalidData,
+		},
+		{
+			name:   "unknown method",
+			method: http.MethodDelete,
+			path:   "/pets/12",
+			route:  &openapi.Route{Pattern: "/pets/:petId", Params: map[string]string{"petId": "12"}},
+			err:    openapi.ErrOperationNotFound,
+		},
+		{
+			name:   "unknown route",
+			method: http.MethodGet,
+			path:   "/pets/mine",
+			route:  &openapi.Route{Pattern: "/other"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			err := v.ValidateRoute(r, tt.route)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWithRouteAdapter(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testRequestSpec), &spec))
+	v, err := openapi.NewValidator(spec, openapi.WithRouteAdapter(openapi.RouteAdapterFunc(func(r *http.Request) (*openapi.Route, bool) {
+		id := r.Header.Get("X-Pet-ID")
+		if id == "" {
+			return nil, false
+		}
+		return &openapi.Route{Pattern: "/api/pets/:petId", Params: map[string]string{"petId": id}}, true
+	})))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/pets/42", nil)
+	require.ErrorIs(t, v.ValidateHTTPRequest(r), openapi.ErrOperationNotFound)
+
+	r.Header.Set("X-Pet-ID", "42")
+	require.NoError(t, v.ValidateHTTPRequest(r))
+
+	r.Header.Set("X-Pet-ID", "x")
+	require.ErrorIs(t, v.ValidateHTTPRequest(r), openapi.ErrInvalidData)
+}
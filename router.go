@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Router matches the concrete request paths, like `/pets/42`, against the templated paths of the spec,
+// like `/pets/{petId}`, to find the operations.
+//
+// The concrete paths are matched before their templated counterparts,
+// and the templates with more literal segments are preferred.
+// The base paths of the servers, like `/v1` of `https://api.example.com/v1`, are removed from the request paths;
+// the servers of a path item override the servers of the spec, the servers of the operations are not used.
+// The variables of the servers are resolved using their default values.
+type Router struct {
+	routes []*routerPath
+}
+
+type routerPath struct {
+	template  string
+	item      *PathItem
+	basePaths []string
+	// ref and ext are the path item as it is in the spec, they are used by Validator to locate the operation
+	ref *RefOrSpec[Extendable[PathItem]]
+	ext *Extendable[PathItem]
+}
+
+// RouteMatch is an operation found by Router.
+type RouteMatch struct {
+	// Template is the templated path of the spec, e.g. `/pets/{petId}`.
+	Template string
+	// Method is the HTTP method of the operation in upper case.
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+	// Params are the raw (escaped) values of the path parameters by their names.
+	Params map[string]string
+}
+
+// NewRouter returns the router for the paths of the given spec.
+// An error is returned if a path item can not be resolved or a server URL is invalid.
+func NewRouter(spec *Extendable[OpenAPI]) (*Router, error) {
+	r := &Router{}
+	if spec == nil || spec.Spec == nil || spec.Spec.Paths == nil || spec.Spec.Paths.Spec == nil {
+		return r, nil
+	}
+	basePaths, err := serverBasePaths(spec.Spec.Servers)
+	if err != nil {
+		return nil, err
+	}
+	paths := spec.Spec.Paths.Spec.Paths
+	templates := make([]string, 0, len(paths))
+	for k := range paths {
+		templates = append(templates, k)
+	}
+	sortPathTemplates(templates)
+	for _, t := range templates {
+		item, err := paths[t].GetSpec(spec.Spec.Components)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", t, err)
+		}
+		route := &routerPath{template: t, item: item.Spec, basePaths: basePaths, ref: paths[t], ext: item}
+		if len(item.Spec.Servers) > 0 {
+			if route.basePaths, err = serverBasePaths(item.Spec.Servers); err != nil {
+				return nil, fmt.Errorf("path %q: %w", t, err)
+			}
+		}
+		r.routes = append(r.routes, route)
+	}
+	return r, nil
+}
+
+// Match finds the operation for the given method and escaped request path,
+// an error wrapping ErrOperationNotFound is returned if there is no such operation.
+func (r *Router) Match(method, path string) (*RouteMatch, error) {
+	route, op, params, ok := r.match(method, path)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrOperationNotFound)
+	}
+	return &RouteMatch{
+		Template:  route.template,
+		Method:    strings.ToUpper(method),
+		PathItem:  route.item,
+		Operation: op.Spec,
+		Params:    params,
+	}, nil
+}
+
+// match returns the route, the operation and the raw values of the path parameters, ok is false if nothing matches.
+func (r *Router) match(method, path string) (*routerPath, *Extendable[Operation], map[string]string, bool) {
+	for _, route := range r.routes {
+		op := route.item.operation(method)
+		if op == nil || op.Spec == nil {
+			continue
+		}
+		for _, base := range route.basePaths {
+			rest, ok := trimBasePath(path, base)
+			if !ok {
+				continue
+			}
+			if params, ok := matchPathTemplate(route.template, rest); ok {
+				return route, op, params, true
+			}
+		}
+	}
+	return nil, nil, nil, false
+}
+
+// serverBasePaths returns the paths of the URLs of the given servers without the trailing slash,
+// the root path is returned if there are no servers.
+func serverBasePaths(servers []*Extendable[Server]) ([]string, error) {
+	var paths []string
+	for _, s := range servers {
+		if s == nil || s.Spec == nil {
+			continue
+		}
+		raw, err := s.Spec.ResolveURL(nil)
+		if err != nil {
+			return nil, err
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: server URL %q: %w", ErrInvalidFormat, raw, err)
+		}
+		paths = append(paths, strings.TrimSuffix(u.EscapedPath(), "/"))
+	}
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+	return paths, nil
+}
+
+// trimBasePath removes the base path from the request path, ok is false if the path is not under the base path.
+func trimBasePath(path, base string) (string, bool) {
+	if base == "" {
+		return path, true
+	}
+	rest, ok := strings.CutPrefix(path, base)
+	if !ok || rest != "" && rest[0] != '/' {
+		return "", false
+	}
+	if rest == "" {
+		rest = "/"
+	}
+	return rest, true
+}
@@ -0,0 +1,149 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testRouterSpec = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/{version}/
+    variables:
+      region:
+        default: eu
+      version:
+        default: v1
+  - url: /api
+paths:
+  /:
+    get:
+      operationId: root
+  /pets:
+    get:
+      operationId: listPets
+  /pets/mine:
+    get:
+      operationId: myPets
+  /pets/{petId}:
+    get:
+      operationId: getPet
+    delete:
+      operationId: deletePet
+  /pets/{petId}/photos/{photoId}.png:
+    get:
+      operationId: getPhoto
+  /files/{name}.{ext}:
+    get:
+      operationId: getFile
+  /health:
+    servers:
+      - url: https://status.example.com
+    get:
+      operationId: health
+`
+
+func TestRouter_Match(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testRouterSpec), &spec))
+	router, err := openapi.NewRouter(spec)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		method   string
+		path     string
+		template string
+		op       string
+		params   map[string]string
+	}{
+		{name: "root", method: http.MethodGet, path: "/v1", template: "/", op: "root", params: map[string]string{}},
+		{name: "root with slash", method: http.MethodGet, path: "/api/", template: "/", op: "root", params: map[string]string{}},
+		{name: "list", method: http.MethodGet, path: "/v1/pets", template: "/pets", op: "listPets", params: map[string]string{}},
+		{name: "second server", method: http.MethodGet, path: "/api/pets", template: "/pets", op: "listPets", params: map[string]string{}},
+		{name: "concrete first", method: http.MethodGet, path: "/v1/pets/mine", template: "/pets/mine", op: "myPets", params: map[string]string{}},
+		{
+			name:     "templated",
+			method:   "delete",
+			path:     "/v1/pets/42",
+			template: "/pets/{petId}",
+			op:       "deletePet",
+			params:   map[string]string{"petId": "42"},
+		},
+		{
+			name:     "raw values",
+			method:   http.MethodGet,
+			path:     "/v1/pets/a%2Fb/photos/1.png",
+			template: "/pets/{petId}/photos/{photoId}.png",
+			op:       "getPhoto",
+			params:   map[string]string{"petId": "a%2Fb", "photoId": "1"},
+		},
+		{
+			name:     "several parameters in segment",
+			method:   http.MethodGet,
+			path:     "/v1/files/report.pdf",
+			template: "/files/{name}.{ext}",
+			op:       "getFile",
+			params:   map[string]string{"name": "report", "ext": "pdf"},
+		},
+		{
+			name:     "greedy parameters",
+			method:   http.MethodGet,
+			path:     "/v1/files/archive.tar.gz",
+			template: "/files/{name}.{ext}",
+			op:       "getFile",
+			params:   map[string]string{"name": "archive.tar", "ext": "gz"},
+		},
+		{name: "path item servers", method: http.MethodGet, path: "/health", template: "/health", op: "health", params: map[string]string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := router.Match(tt.method, tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.template, m.Template)
+			require.Equal(t, tt.op, m.Operation.OperationID)
+			require.Equal(t, tt.params, m.Params)
+			require.NotNil(t, m.PathItem)
+		})
+	}
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "no base path", method: http.MethodGet, path: "/pets"},
+		{name: "partial base path", method: http.MethodGet, path: "/v1pets"},
+		{name: "unknown method", method: http.MethodPost, path: "/v1/pets/42"},
+		{name: "path item servers override", method: http.MethodGet, path: "/v1/health"},
+		{name: "too long", method: http.MethodGet, path: "/v1/pets/42/photos"},
+		{name: "missing separator", method: http.MethodGet, path: "/v1/files/report"},
+		{name: "empty parameter", method: http.MethodGet, path: "/v1/files/.pdf"},
+		{name: "empty last parameter", method: http.MethodGet, path: "/v1/files/report."},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := router.Match(tt.method, tt.path)
+			require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+		})
+	}
+}
+
+func TestNewRouter_Errors(t *testing.T) {
+	router, err := openapi.NewRouter(openapi.NewOpenAPIBuilder().Build())
+	require.NoError(t, err)
+	_, err = router.Match(http.MethodGet, "/")
+	require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+
+	spec := openapi.NewOpenAPIBuilder().
+		AddPath("/pets", openapi.NewRefOrSpec[openapi.Extendable[openapi.PathItem]]("#/components/pathItems/Pets")).
+		Build()
+	_, err = openapi.NewRouter(spec)
+	require.ErrorContains(t, err, `path "/pets"`)
+}
@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleConfig is the configuration of the lint rules, see LoadRuleConfig.
+//
+// Example:
+//
+//	rules:
+//	  sunset-in-future: error
+//	  mutating-operations-require-auth:
+//	    severity: warning
+//	    options:
+//	      exemptPaths: [/login]
+//	overrides:
+//	  - paths: [/internal/*]
+//	    rules:
+//	      mutating-operations-require-auth: off
+type RuleConfig struct {
+	// Rules are the configurations of the rules by their IDs.
+	Rules map[string]*RuleSettings `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Overrides change the severities of the rules for the issues located in the given paths,
+	// the later overrides take precedence.
+	Overrides []*RuleOverride `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// RuleSettings is the configuration of a lint rule, it can be given as a severity only, e.g. `warning`.
+type RuleSettings struct {
+	// Severity is the severity of the rule, the default severity of the rule is used if nil.
+	Severity *Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Options are the options of the rule, the rule must implement ConfigurableRule interface.
+	Options map[string]any `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface.
+func (o *RuleSettings) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Severity = new(Severity)
+		return node.Decode(o.Severity)
+	}
+	type alias RuleSettings
+	return node.Decode((*alias)(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (o *RuleSettings) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '"' {
+		o.Severity = new(Severity)
+		return json.Unmarshal(data, o.Severity)
+	}
+	type alias RuleSettings
+	return json.Unmarshal(data, (*alias)(o))
+}
+
+// RuleOverride changes the severities of the rules for the given paths.
+type RuleOverride struct {
+	// Paths are the paths or the patterns of the paths, see path.Match.
+	Paths []string `json:"paths" yaml:"paths"`
+	// Rules are the severities of the rules by their IDs.
+	Rules map[string]Severity `json:"rules" yaml:"rules"`
+}
+
+// LoadRuleConfig reads the YAML (or JSON) configuration of the lint rules, see RuleConfig,
+// and returns the validation options enabling, disabling, and configuring the rules accordingly.
+// The given custom rules are configured as well as the built-in ones and added to the validator.
+//
+// An error wrapping ErrInvalidValue is returned for an unknown rule or for the options of a rule
+// not implementing ConfigurableRule interface.
+func LoadRuleConfig(r io.Reader, custom ...Rule) ([]ValidationOption, error) {
+	var cfg RuleConfig
+	if err := yaml.NewDecoder(r).Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w of rule config: %w", ErrInvalidFormat, err)
+	}
+	return cfg.ValidationOptions(custom...)
+}
+
+// ValidationOptions returns the validation options for the configuration, see LoadRuleConfig.
+func (o *RuleConfig) ValidationOptions(custom ...Rule) ([]ValidationOption, error) {
+	rules := make(map[string]Rule)
+	for _, rule := range append(BuiltinRules(), custom...) {
+		rules[rule.ID()] = rule
+	}
+	opts := []ValidationOption{WithRules(custom...)}
+	var errs []error
+	for _, id := range sortedKeys(o.Rules) {
+		location := joinLoc("/rules", id)
+		rule, ok := rules[id]
+		if !ok {
+			errs = append(errs, newValidationError(location, "%w: unknown rule", ErrInvalidValue))
+			continue
+		}
+		settings := o.Rules[id]
+		if settings == nil {
+			continue
+		}
+		if settings.Options != nil {
+			r, ok := rule.(ConfigurableRule)
+			if !ok {
+				errs = append(errs, newValidationError(joinLoc(location, "options"), "%w: rule has no options", ErrInvalidValue))
+				continue
+			}
+			if err := r.Configure(settings.Options); err != nil {
+				errs = append(errs, newValidationError(joinLoc(location, "options"), err))
+				continue
+			}
+			opts = append(opts, WithRules(r))
+		}
+		if settings.Severity != nil {
+			opts = append(opts, RuleSeverity(id, *settings.Severity))
+		}
+	}
+	for i, override := range o.Overrides {
+		location := joinLoc("/overrides", i)
+		if len(override.Paths) == 0 {
+			errs = append(errs, newValidationError(joinLoc(location, "paths"), ErrRequired))
+			continue
+		}
+		for _, id := range sortedKeys(override.Rules) {
+			if _, ok := rules[id]; !ok {
+				errs = append(errs, newValidationError(joinLoc(location, "rules", id), "%w: unknown rule", ErrInvalidValue))
+				continue
+			}
+			opts = append(opts, RuleSeverityForPaths(id, override.Rules[id], override.Paths...))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// decodeRuleOptions decodes the options of a rule into the given struct with json tags,
+// an error is returned for an unknown option or for an invalid value.
+func decodeRuleOptions(options map[string]any, v any) error {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return fmt.Errorf("%w of rule options: %w", ErrInvalidFormat, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w of rule options: %w", ErrInvalidValue, err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestLoadRuleConfig(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testLintSpec), &spec))
+
+	for _, tt := range []struct {
+		name   string
+		config string
+		issues []string
+	}{
+		{
+			name:   "empty",
+			config: "",
+			issues: []string{"hint /paths/~1pets/get/summary"},
+		},
+		{
+			name: "severity only",
+			config: `
+rules:
+  mutating-operations-require-auth: error
+  operation-summary: off
+`,
+			issues: []string{
+				"error /paths/~1pets/delete",
+				"error /paths/~1pets~1{id}/put/security/1",
+				"error /paths/~1public~1feedback/post",
+			},
+		},
+		{
+			name: "options and overrides",
+			config: `
+rules:
+  mutating-operations-require-auth:
+    severity: warning
+    options:
+      exemptPaths: [/public/*]
+  operation-summary:
+    severity: error
+overrides:
+  - paths: ['/pets/*']
+    rules:
+      mutating-operations-require-auth: off
+  - paths: [/pets]
+    rules:
+      operation-summary: hint
+`,
+			issues: []string{
+				"warning /paths/~1pets/delete",
+				"hint /paths/~1pets/get/summary",
+			},
+		},
+		{
+			name: "enabled for paths only",
+			config: `
+{"overrides": [{"paths": ["/public/feedback"], "rules": {"mutating-operations-require-auth": "error"}}]}
+`,
+			issues: []string{
+				"hint /paths/~1pets/get/summary",
+				"error /paths/~1public~1feedback/post",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := openapi.LoadRuleConfig(strings.NewReader(tt.config), testDescriptionRule{})
+			require.NoError(t, err)
+			validator, err := openapi.NewValidator(spec, opts...)
+			require.NoError(t, err)
+			var issues []string
+			for _, issue := range validator.Lint() {
+				if issue.Rule == "operation-summary" || issue.Rule == openapi.MutatingOperationsRequireAuthRuleID {
+					issues = append(issues, issue.Severity.String()+" "+issue.Location.String())
+				}
+			}
+			require.Equal(t, tt.issues, issues)
+		})
+	}
+}
+
+func TestLoadRuleConfig_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "invalid yaml",
+			config: "rules: [",
+			err:    "invalid format of rule config",
+		},
+		{
+			name:   "invalid severity",
+			config: "rules: {sunset-in-future: fatal}",
+			err:    "severity 'fatal'",
+		},
+		{
+			name:   "unknown rule",
+			config: "rules: {unknown: error}",
+			err:    "/rules/unknown: invalid value: unknown rule",
+		},
+		{
+			name:   "rule without options",
+			config: "rules: {sunset-in-future: {options: {now: 1}}}",
+			err:    "/rules/sunset-in-future/options: invalid value: rule has no options",
+		},
+		{
+			name:   "unknown option",
+			config: "rules: {mutating-operations-require-auth: {options: {paths: []}}}",
+			err:    `/rules/mutating-operations-require-auth/options: invalid value of rule options: json: unknown field "paths"`,
+		},
+		{
+			name:   "override without paths",
+			config: "overrides: [{rules: {sunset-in-future: off}}]",
+			err:    "/overrides/0/paths: required",
+		},
+		{
+			name:   "override of unknown rule",
+			config: "overrides: [{paths: [/pets], rules: {unknown: off}}]",
+			err:    "/overrides/0/rules/unknown: invalid value: unknown rule",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := openapi.LoadRuleConfig(strings.NewReader(tt.config))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package openapi
+
+// RuleOption describes a configurable option of a lint rule.
+type RuleOption struct {
+	// Name is the name of the option in the configuration, e.g. `exemptPaths`.
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Schema is the JSON Schema of the value of the option, including its default value.
+	Schema *RefOrSpec[Schema] `json:"schema" yaml:"schema"`
+}
+
+// ConfigurableRule is a lint rule describing its configurable options, see DescribeRules and LoadRuleConfig.
+type ConfigurableRule interface {
+	Rule
+	// Options returns the configurable options of the rule.
+	Options() []RuleOption
+	// Configure sets the options of the rule by their names.
+	Configure(options map[string]any) error
+}
+
+// RuleDoc is the machine-readable documentation of a lint rule.
+type RuleDoc struct {
+	ID              string       `json:"id" yaml:"id"`
+	Description     string       `json:"description" yaml:"description"`
+	DefaultSeverity Severity     `json:"defaultSeverity" yaml:"defaultSeverity"`
+	Options         []RuleOption `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// DescribeRules returns the documentation of the given rules in the same order,
+// e.g. to generate the docs or the schema of the configuration files of the rules:
+//
+//	docs := openapi.DescribeRules(openapi.BuiltinRules()...)
+//	data, err := json.MarshalIndent(docs, "", "  ")
+func DescribeRules(rules ...Rule) []*RuleDoc {
+	docs := make([]*RuleDoc, len(rules))
+	for i, rule := range rules {
+		docs[i] = &RuleDoc{
+			ID:              rule.ID(),
+			Description:     rule.Description(),
+			DefaultSeverity: rule.DefaultSeverity(),
+		}
+		if r, ok := rule.(ConfigurableRule); ok {
+			docs[i].Options = r.Options()
+		}
+	}
+	return docs
+}
@@ -0,0 +1,107 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestDescribeRules(t *testing.T) {
+	docs := openapi.DescribeRules(append(openapi.BuiltinRules(), testDescriptionRule{})...)
+	data, err := json.Marshal(docs)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{
+			"id": "mutating-operations-require-auth",
+			"description": "POST, PUT, PATCH and DELETE operations must require authentication",
+			"defaultSeverity": "off",
+			"options": [
+				{
+					"name": "exemptPaths",
+					"description": "the paths or the patterns of the paths, like `+"`/public/*`"+`, not checked by the rule",
+					"schema": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"type": "array",
+						"items": {"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "string"},
+						"default": []
+					}
+				}
+			]
+		},
+		{
+			"id": "sunset-in-future",
+			"description": "the sunset dates of the operations must be in the future",
+			"defaultSeverity": "warning"
+		},
+		{
+			"id": "binary-format",
+			"description": "the binary content must be described by `+"`contentMediaType`"+` instead of `+"`type: string, format: binary`"+`",
+			"defaultSeverity": "warning"
+		},
+		{
+			"id": "header-style",
+			"description": "the headers must be serializable using simple style: arrays and objects of primitive values only",
+			"defaultSeverity": "warning"
+		},
+		{
+			"id": "scenario-completeness",
+			"description": "the named examples of an operation must form complete scenarios: an example in each media type of the request body and of exactly one response",
+			"defaultSeverity": "off"
+		},
+		{
+			"id": "conditional-requests",
+			"description": "successful responses of GET operations must expose ETag header, PUT, PATCH and DELETE operations must require If-Match header",
+			"defaultSeverity": "off",
+			"options": [
+				{
+					"name": "exemptPaths",
+					"description": "the paths or the patterns of the paths, like `+"`/search/*`"+`, not checked by the rule",
+					"schema": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"type": "array",
+						"items": {"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "string"},
+						"default": []
+					}
+				}
+			]
+		},
+		{
+			"id": "pagination-links",
+			"description": "successful responses of GET operations returning arrays must declare Link header of string type",
+			"defaultSeverity": "off"
+		},
+		{
+			"id": "idempotency-key",
+			"description": "POST and PATCH operations must declare Idempotency-Key header of string type and uuid format",
+			"defaultSeverity": "off",
+			"options": [
+				{
+					"name": "exemptPaths",
+					"description": "the paths or the patterns of the paths, like `+"`/search/*`"+`, not checked by the rule",
+					"schema": {
+						"$schema": "https://json-schema.org/draft/2020-12/schema",
+						"type": "array",
+						"items": {"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "string"},
+						"default": []
+					}
+				}
+			]
+		},
+		{
+			"id": "operation-summary",
+			"description": "operations must have a summary",
+			"defaultSeverity": "hint"
+		}
+	]`, string(data))
+
+	var parsed []*openapi.RuleDoc
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	require.Equal(t, openapi.SeverityWarning, parsed[1].DefaultSeverity)
+
+	_, err = json.Marshal(openapi.Severity(10))
+	require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	require.Error(t, json.Unmarshal([]byte(`"fatal"`), new(openapi.Severity)))
+}
@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The common names of the scenarios, see Scenario.
+const (
+	ScenarioSuccess         = "success"
+	ScenarioValidationError = "validation-error"
+	ScenarioNotFound        = "not-found"
+)
+
+// Scenario is a named use case of an operation: the examples with the same name
+// in the request body and in the responses of the operation, e.g. `success` or `not-found`.
+// A complete scenario has an example in each media type of the request body, if any,
+// and in each media type of exactly one response.
+type Scenario struct {
+	Name string
+	// Request are the examples of the request body by media type.
+	Request map[string]*RefOrSpec[Extendable[Example]]
+	// Responses are the examples of the responses by status code and media type.
+	Responses map[string]map[string]*RefOrSpec[Extendable[Example]]
+}
+
+// Status returns the status code of the response of the scenario,
+// an empty string if the scenario has no response examples or has the examples in several responses.
+func (s *Scenario) Status() string {
+	if len(s.Responses) != 1 {
+		return ""
+	}
+	for status := range s.Responses {
+		return status
+	}
+	return ""
+}
+
+// Scenarios returns the scenarios of the operation sorted by name, see Scenario.
+// The referenced request body and responses are resolved using the given components.
+func Scenarios(op *Operation, components *Extendable[Components]) []*Scenario {
+	if op == nil {
+		return nil
+	}
+	scenarios := make(map[string]*Scenario)
+	get := func(name string) *Scenario {
+		s, ok := scenarios[name]
+		if !ok {
+			s = &Scenario{Name: name}
+			scenarios[name] = s
+		}
+		return s
+	}
+	if body := operationRequestBody(op, components); body != nil {
+		for mt, content := range body.Content {
+			if content == nil || content.Spec == nil {
+				continue
+			}
+			for name, example := range content.Spec.Examples {
+				s := get(name)
+				if s.Request == nil {
+					s.Request = make(map[string]*RefOrSpec[Extendable[Example]])
+				}
+				s.Request[mt] = example
+			}
+		}
+	}
+	for status, resp := range operationResponses(op, components) {
+		for mt, content := range resp.Content {
+			if content == nil || content.Spec == nil {
+				continue
+			}
+			for name, example := range content.Spec.Examples {
+				s := get(name)
+				if s.Responses == nil {
+					s.Responses = make(map[string]map[string]*RefOrSpec[Extendable[Example]])
+				}
+				if s.Responses[status] == nil {
+					s.Responses[status] = make(map[string]*RefOrSpec[Extendable[Example]])
+				}
+				s.Responses[status][mt] = example
+			}
+		}
+	}
+	list := make([]*Scenario, 0, len(scenarios))
+	for _, name := range sortedKeys(scenarios) {
+		list = append(list, scenarios[name])
+	}
+	return list
+}
+
+// operationRequestBody returns the resolved request body of the operation, nil if there is no request body.
+func operationRequestBody(op *Operation, components *Extendable[Components]) *RequestBody {
+	if op.RequestBody == nil {
+		return nil
+	}
+	body, err := op.RequestBody.GetSpec(components)
+	if err != nil {
+		return nil
+	}
+	return body.Spec
+}
+
+// operationResponses returns the resolved responses of the operation by status code, including `default`.
+func operationResponses(op *Operation, components *Extendable[Components]) map[string]*Response {
+	if op.Responses == nil || op.Responses.Spec == nil {
+		return nil
+	}
+	responses := make(map[string]*Response, len(op.Responses.Spec.Response)+1)
+	add := func(status string, ref *RefOrSpec[Extendable[Response]]) {
+		if ref == nil {
+			return
+		}
+		if resp, err := ref.GetSpec(components); err == nil && resp.Spec != nil {
+			responses[status] = resp.Spec
+		}
+	}
+	add("default", op.Responses.Spec.Default)
+	for status, ref := range op.Responses.Spec.Response {
+		add(status, ref)
+	}
+	return responses
+}
+
+// AddScenario adds the examples of the scenario with the given name, see Scenario:
+// the request example to each media type of the request body and the response example
+// to each media type of the response with the given status code.
+// The request body and the response must be set before and be inline objects, not references;
+// the nil examples are not added.
+func (b *OperationBuilder) AddScenario(name string, request *RefOrSpec[Extendable[Example]], status string, response *RefOrSpec[Extendable[Example]]) *OperationBuilder {
+	op := b.spec.Spec
+	if request != nil && op.RequestBody != nil && op.RequestBody.Spec != nil && op.RequestBody.Spec.Spec != nil {
+		addScenarioExample(op.RequestBody.Spec.Spec.Content, name, request)
+	}
+	if response != nil && op.Responses != nil && op.Responses.Spec != nil {
+		resp := op.Responses.Spec.Response[status]
+		if status == "default" {
+			resp = op.Responses.Spec.Default
+		}
+		if resp != nil && resp.Spec != nil && resp.Spec.Spec != nil {
+			addScenarioExample(resp.Spec.Spec.Content, name, response)
+		}
+	}
+	return b
+}
+
+func addScenarioExample(content map[string]*Extendable[MediaType], name string, example *RefOrSpec[Extendable[Example]]) {
+	for _, mt := range content {
+		if mt == nil || mt.Spec == nil {
+			continue
+		}
+		if mt.Spec.Examples == nil {
+			mt.Spec.Examples = make(map[string]*RefOrSpec[Extendable[Example]], 1)
+		}
+		mt.Spec.Examples[name] = example
+	}
+}
+
+// ScenarioCompletenessRuleID is the ID of ScenarioCompletenessRule.
+const ScenarioCompletenessRuleID = "scenario-completeness"
+
+// ScenarioCompletenessRule is a lint rule checking that the scenarios of the operations are complete, see Scenario:
+// each scenario has an example in each media type of the request body and of exactly one response.
+//
+// The rule is disabled by default, it can be enabled using RuleSeverity option.
+type ScenarioCompletenessRule struct{}
+
+// ID implements Rule interface.
+func (r *ScenarioCompletenessRule) ID() string {
+	return ScenarioCompletenessRuleID
+}
+
+// Description implements Rule interface.
+func (r *ScenarioCompletenessRule) Description() string {
+	return "the named examples of an operation must form complete scenarios: " +
+		"an example in each media type of the request body and of exactly one response"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *ScenarioCompletenessRule) DefaultSeverity() Severity {
+	return SeverityOff
+}
+
+// Check implements Rule interface.
+func (r *ScenarioCompletenessRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	components := spec.Spec.Components
+	forEachOperation(spec, func(location, _ string, op *Extendable[Operation]) {
+		body := operationRequestBody(op.Spec, components)
+		responses := operationResponses(op.Spec, components)
+		for _, s := range Scenarios(op.Spec, components) {
+			if body != nil {
+				for _, mt := range sortedKeys(body.Content) {
+					if _, ok := s.Request[mt]; !ok {
+						report(joinLoc(location, "requestBody", "content", mt, "examples"),
+							fmt.Sprintf("scenario '%s' has no request example", s.Name))
+					}
+				}
+			}
+			if len(s.Responses) == 0 {
+				report(joinLoc(location, "responses"), fmt.Sprintf("scenario '%s' has no response example", s.Name))
+				continue
+			}
+			if len(s.Responses) > 1 {
+				report(joinLoc(location, "responses"), fmt.Sprintf("scenario '%s' has examples in several responses: %s",
+					s.Name, strings.Join(sortedKeys(s.Responses), ", ")))
+			}
+			for _, status := range sortedKeys(s.Responses) {
+				for _, mt := range sortedKeys(responses[status].Content) {
+					if _, ok := s.Responses[status][mt]; !ok {
+						report(joinLoc(location, "responses", status, "content", mt, "examples"),
+							fmt.Sprintf("scenario '%s' has no response example", s.Name))
+					}
+				}
+			}
+		}
+	})
+}
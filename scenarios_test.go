@@ -0,0 +1,131 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestScenarios(t *testing.T) {
+	pet := openapi.NewSchemaBuilder().
+		Type(openapi.ObjectType).
+		AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).MinLength(1).Build()).
+		Build()
+	jsonContent := func() *openapi.Extendable[openapi.MediaType] {
+		return openapi.NewMediaTypeBuilder().Schema(pet).Build()
+	}
+	op := openapi.NewOperationBuilder().
+		RequestBody(openapi.NewRequestBodyBuilder().
+			AddContent("application/json", jsonContent()).
+			AddContent("application/yaml", jsonContent()).
+			Build())
+	op.Build().Spec.Responses = openapi.NewExtendable(&openapi.Responses{
+		Response: map[string]*openapi.RefOrSpec[openapi.Extendable[openapi.Response]]{
+			"201": openapi.NewResponseBuilder().Description("created").AddContent("application/json", jsonContent()).Build(),
+			"400": openapi.NewResponseBuilder().Description("invalid").AddContent("application/json", jsonContent()).Build(),
+		},
+	})
+	valid := openapi.NewExampleBuilder().Value(map[string]any{"name": "Rex"}).Build()
+	invalid := openapi.NewExampleBuilder().Value(map[string]any{"name": ""}).Build()
+	op.AddScenario(openapi.ScenarioSuccess, valid, "201", valid).
+		AddScenario(openapi.ScenarioValidationError, invalid, "400", invalid)
+
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Scenarios").Version("1.0.0").Build()).
+		AddPath("/pets", openapi.NewPathItemBuilder().Post(op.Build()).Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec, openapi.RuleSeverity(openapi.ScenarioCompletenessRuleID, openapi.SeverityWarning))
+	require.NoError(t, err)
+	require.Empty(t, validator.Lint())
+
+	post := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Post
+	scenarios := openapi.Scenarios(post.Spec, nil)
+	require.Len(t, scenarios, 2)
+	require.Equal(t, openapi.ScenarioSuccess, scenarios[0].Name)
+	require.Equal(t, "201", scenarios[0].Status())
+	require.Len(t, scenarios[0].Request, 2)
+	require.Equal(t, openapi.ScenarioValidationError, scenarios[1].Name)
+	require.Equal(t, "400", scenarios[1].Status())
+	require.Equal(t, map[string]any{"name": ""}, scenarios[1].Responses["400"]["application/json"].Spec.Spec.Value)
+}
+
+const testScenarioCompletenessRuleSpec = `
+openapi: 3.1.1
+info:
+  title: Scenarios
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            examples:
+              success:
+                value:
+                  name: Rex
+              validation-error:
+                value:
+                  name: ''
+          application/yaml:
+            examples:
+              validation-error:
+                value:
+                  name: ''
+      responses:
+        '201':
+          description: created
+          content:
+            application/json:
+              examples:
+                success:
+                  value:
+                    name: Rex
+        '400':
+          description: invalid
+          content:
+            application/json:
+              examples:
+                success:
+                  value:
+                    name: Tom
+            application/problem+json: {}
+`
+
+func TestScenarioCompletenessRule(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		spec     string
+		expected []string
+	}{
+		{
+			name: "incomplete scenarios",
+			spec: testScenarioCompletenessRuleSpec,
+			expected: []string{
+				"/paths/~1pets/post/requestBody/content/application~1yaml/examples: scenario 'success' has no request example",
+				"/paths/~1pets/post/responses: scenario 'success' has examples in several responses: 201, 400",
+				"/paths/~1pets/post/responses: scenario 'validation-error' has no response example",
+				"/paths/~1pets/post/responses/400/content/application~1problem+json/examples: scenario 'success' has no response example",
+			},
+		},
+		{
+			name: "no scenarios",
+			spec: testRequestSpec,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(tt.spec), &spec))
+			validator, err := openapi.NewValidator(spec, openapi.RuleSeverity(openapi.ScenarioCompletenessRuleID, openapi.SeverityWarning))
+			require.NoError(t, err)
+			var messages []string
+			for _, issue := range validator.Lint() {
+				messages = append(messages, issue.Location.String()+": "+issue.Message)
+			}
+			require.Equal(t, tt.expected, messages)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -47,11 +48,17 @@ type Schema struct {
 	//
 	// https://json-schema.org/understanding-json-schema/structuring#id
 	ID string `json:"$id,omitempty" yaml:"$id,omitempty"`
+	// The $ref keyword references a schema applied along with the other keywords of the schema.
+	// It is set for the references with the sibling keywords only, see KeepRefSiblings;
+	// the plain references are kept by RefOrSpec.Ref.
+	//
+	// https://json-schema.org/understanding-json-schema/structuring#dollarref
+	Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
 	// https://json-schema.org/understanding-json-schema/structuring#dollardefs
 	Defs          map[string]*RefOrSpec[Schema] `json:"$defs,omitempty" yaml:"$defs,omitempty"`
 	DynamicRef    string                        `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
 	Vocabulary    map[string]bool               `json:"$vocabulary,omitempty" yaml:"$vocabulary,omitempty"`
-	DynamicAnchor string                        `json:"$dynamicAnchor,omitempty" yaml:"dynamicAnchor,omitempty"`
+	DynamicAnchor string                        `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
 	// https://json-schema.org/understanding-json-schema/reference/type#type-specific-keywords
 	Type *SingleOrArray[string] `json:"type,omitempty" yaml:"type,omitempty"`
 
@@ -74,7 +81,7 @@ type Schema struct {
 	// The const keyword is used to restrict a value to a single value.
 	//
 	// https://json-schema.org/understanding-json-schema/reference/const
-	Const string `json:"const,omitempty" yaml:"const,omitempty"`
+	Const any `json:"const,omitempty" yaml:"const,omitempty"`
 	// The $comment keyword is strictly intended for adding comments to a schema.
 	// Its value must always be a string.
 	// Unlike the annotations title, description, and examples, JSON schema implementations aren’t allowed
@@ -183,15 +190,15 @@ type Schema struct {
 	// It may be set to any positive number.
 	//
 	// https://json-schema.org/understanding-json-schema/reference/numeric.html#multiples
-	MultipleOf *int `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	MultipleOf *Number `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 	// x ≥ minimum
-	Minimum *int `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Minimum *Number `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 	// x > exclusiveMinimum
-	ExclusiveMinimum *int `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMinimum *Number `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 	// x ≤ maximum
-	Maximum *int `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Maximum *Number `json:"maximum,omitempty" yaml:"maximum,omitempty"`
 	// x < exclusiveMaximum
-	ExclusiveMaximum *int `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	ExclusiveMaximum *Number `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 
 	// *** String Type Fields ***
 	//
@@ -313,6 +320,12 @@ type Schema struct {
 	Example any `json:"example,omitempty" yaml:"example,omitempty"`
 
 	Extensions map[string]any `json:"-" yaml:"-"`
+
+	// Bool is set for the boolean schemas: `true` allows any value and `false` allows no value,
+	// the other fields of a boolean schema are ignored.
+	//
+	// https://json-schema.org/draft/2020-12/json-schema-core#name-boolean-json-schemas
+	Bool *bool `json:"-" yaml:"-"`
 }
 
 // AddExt sets the extension and returns the current object (self|this).
@@ -326,6 +339,8 @@ func (o *Schema) AddExt(name string, value any) *Schema {
 	return o
 }
 
+// GetExt returns the extension value by name, the raw values (see KeepRawExtensions) are decoded.
+// The `x-` prefix will be added automatically to given name.
 func (o *Schema) GetExt(name string) any {
 	if o.Extensions == nil {
 		return nil
@@ -333,7 +348,24 @@ func (o *Schema) GetExt(name string) any {
 	if !strings.HasPrefix(name, ExtensionPrefix) {
 		name = ExtensionPrefix + name
 	}
-	return o.Extensions[name]
+	return extensionValue(o.Extensions[name])
+}
+
+// DecodeExt decodes the extension value into the given pointer, like json.Unmarshal does,
+// and reports whether the extension exists.
+// The `x-` prefix will be added automatically to given name.
+func (o *Schema) DecodeExt(name string, v any) (bool, error) {
+	if !strings.HasPrefix(name, ExtensionPrefix) {
+		name = ExtensionPrefix + name
+	}
+	value, ok := o.Extensions[name]
+	if !ok {
+		return false, nil
+	}
+	if err := decodeExtension(value, v); err != nil {
+		return true, fmt.Errorf("%w of %s: %w", ErrInvalidFormat, name, err)
+	}
+	return true, nil
 }
 
 // returns the list of public fields for given tag and ignores `-` names
@@ -377,8 +409,15 @@ type intSchema Schema // needed to avoid recursion in marshal/unmarshal
 
 // MarshalJSON implements json.Marshaler interface.
 func (o *Schema) MarshalJSON() ([]byte, error) {
+	if o.Bool != nil {
+		return json.Marshal(*o.Bool)
+	}
 	var raw map[string]json.RawMessage
-	exts, err := json.Marshal(&o.Extensions)
+	extensions, err := marshalableExtensions(o.Extensions, false)
+	if err != nil {
+		return nil, fmt.Errorf("%T.Extensions.%w", o, err)
+	}
+	exts, err := json.Marshal(&extensions)
 	if err != nil {
 		return nil, fmt.Errorf("%T.Extensions: %w", o, err)
 	}
@@ -402,6 +441,11 @@ func (o *Schema) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (o *Schema) UnmarshalJSON(data []byte) error {
+	if v := string(bytes.TrimSpace(data)); v == "true" || v == "false" {
+		b := v == "true"
+		*o = Schema{Bool: &b}
+		return nil
+	}
 	var raw map[string]json.RawMessage
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return fmt.Errorf("%T: %w", o, err)
@@ -423,7 +467,10 @@ func (o *Schema) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%T(raw): %w", o, err)
 	}
 	var s intSchema
-	if err := json.Unmarshal(fields, &s); err != nil {
+	// the numbers of enum, const, default, and examples are kept as json.Number to not lose their precision
+	dec := json.NewDecoder(bytes.NewReader(fields))
+	dec.UseNumber()
+	if err := dec.Decode(&s); err != nil {
 		return fmt.Errorf("%T: %w", o, err)
 	}
 	s.Extensions = exts
@@ -433,8 +480,16 @@ func (o *Schema) UnmarshalJSON(data []byte) error {
 
 // MarshalYAML implements yaml.Marshaler interface.
 func (o *Schema) MarshalYAML() (any, error) {
-	var raw map[string]any
-	exts, err := yaml.Marshal(&o.Extensions)
+	if o.Bool != nil {
+		return *o.Bool, nil
+	}
+	// the nodes are used to not lose the precision of the numbers
+	var raw map[string]yaml.Node
+	extensions, err := marshalableExtensions(o.Extensions, true)
+	if err != nil {
+		return nil, fmt.Errorf("%T.Extensions.%w", o, err)
+	}
+	exts, err := yaml.Marshal(&extensions)
 	if err != nil {
 		return nil, fmt.Errorf("%T.Extensions: %w", o, err)
 	}
@@ -442,6 +497,10 @@ func (o *Schema) MarshalYAML() (any, error) {
 		return nil, fmt.Errorf("%T(raw extensions): %w", o, err)
 	}
 	s := intSchema(*o)
+	// the numbers decoded from JSON must be marshaled as numbers, not strings
+	s.Default, s.Const, s.Example = yamlNumbers(s.Default), yamlNumbers(s.Const), yamlNumbers(s.Example)
+	s.Enum, _ = yamlNumbers(s.Enum).([]any)
+	s.Examples, _ = yamlNumbers(s.Examples).([]any)
 	fields, err := yaml.Marshal(&s)
 	if err != nil {
 		return nil, fmt.Errorf("%T: %w", o, err)
@@ -454,6 +513,14 @@ func (o *Schema) MarshalYAML() (any, error) {
 
 // UnmarshalYAML implements yaml.Unmarshaler interface.
 func (o *Schema) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool" {
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return fmt.Errorf("%T: %w", o, err)
+		}
+		*o = Schema{Bool: &b}
+		return nil
+	}
 	var raw map[string]any
 	if err := node.Decode(&raw); err != nil {
 		return fmt.Errorf("%T: %w", o, err)
@@ -482,6 +549,11 @@ func (o *Schema) UnmarshalYAML(node *yaml.Node) error {
 func (o *Schema) validateSpec(location string, validator *Validator) []*validationError {
 	var errs []*validationError
 
+	if o.Ref != "" {
+		errs = append(errs, NewRefOrSpec[Schema](o.Ref).validateSpec(joinLoc(location, "$ref"), validator)...)
+	}
+	errs = append(errs, o.validateDynamicKeywords(location, validator)...)
+	errs = append(errs, o.validateVocabulary(location)...)
 	if o.Discriminator != nil {
 		errs = append(errs, o.Discriminator.validateSpec(joinLoc(location, "discriminator"), validator)...)
 	}
@@ -494,7 +566,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 	if o.Example != nil {
 		if !validator.opts.doNotValidateExamples {
 			if e := validator.ValidateData(location, o.Example); e != nil {
-				errs = append(errs, newValidationError(joinLoc(location, "example"), e))
+				errs = append(errs, newValidationError(joinLoc(location, "example"), "%w: %w", ErrInvalidData, e))
 			}
 		}
 	}
@@ -520,8 +592,10 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 	}
 
 	// JsonSchemaCore
-	if o.Schema != "" && o.Schema != Draft202012 {
-		errs = append(errs, newValidationError(joinLoc(location, "schema"), "must be '%s', but got '%s'", Draft202012, o.Schema))
+	if o.Schema != "" {
+		if _, ok := draftOf(o.Schema); !ok {
+			errs = append(errs, newValidationError(joinLoc(location, "schema"), "%w: must be a JSON Schema draft or an OpenAPI dialect, but got '%s'", ErrUnsupportedVersion, o.Schema))
+		}
 	}
 	if len(o.Defs) > 0 {
 		for k, v := range o.Defs {
@@ -535,14 +609,14 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 			switch v := (*o.Type)[0]; v {
 			case StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType:
 			default:
-				errs = append(errs, newValidationError(joinLoc(location, "type"), "invalid value, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType, v))
+				errs = append(errs, newValidationError(joinLoc(location, "type"), "%w, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", ErrInvalidValue, StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType, v))
 			}
 		default:
 			for i, v := range *o.Type {
 				switch v {
 				case StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType:
 				default:
-					errs = append(errs, newValidationError(joinLoc(location, "type", i), "invalid value, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType, v))
+					errs = append(errs, newValidationError(joinLoc(location, "type", i), "%w, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", ErrInvalidValue, StringType, NumberType, IntegerType, BooleanType, ObjectType, ArrayType, NullType, v))
 				}
 			}
 		}
@@ -556,7 +630,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 		switch o.ContentEncoding {
 		case SevenBitEncoding, EightBitEncoding, BinaryEncoding, QuotedPrintableEncoding, Base16Encoding, Base32Encoding, Base64Encoding:
 		default:
-			errs = append(errs, newValidationError(joinLoc(location, "contentEncoding"), "invalid value, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", SevenBitEncoding, EightBitEncoding, BinaryEncoding, QuotedPrintableEncoding, Base16Encoding, Base32Encoding, Base64Encoding, o.ContentEncoding))
+			errs = append(errs, newValidationError(joinLoc(location, "contentEncoding"), "%w, expected one of [%s, %s, %s, %s, %s, %s, %s], but got '%s'", ErrInvalidValue, SevenBitEncoding, EightBitEncoding, BinaryEncoding, QuotedPrintableEncoding, Base16Encoding, Base32Encoding, Base64Encoding, o.ContentEncoding))
 		}
 	}
 
@@ -564,7 +638,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 	if o.Default != nil {
 		if !validator.opts.doNotValidateDefaultValues {
 			if e := validator.ValidateData(location, o.Default); e != nil {
-				errs = append(errs, newValidationError(joinLoc(location, "default"), e))
+				errs = append(errs, newValidationError(joinLoc(location, "default"), "%w: %w", ErrInvalidData, e))
 			}
 		}
 		if len(o.Enum) > 0 {
@@ -576,7 +650,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 				}
 			}
 			if !found {
-				errs = append(errs, newValidationError(joinLoc(location, "default"), "invalid value, expected one of enum values: %v", o.Enum))
+				errs = append(errs, newValidationError(joinLoc(location, "default"), "%w, expected one of enum values: %v", ErrInvalidValue, o.Enum))
 			}
 		}
 	}
@@ -584,7 +658,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 	if len(o.Examples) > 0 && !validator.opts.doNotValidateExamples {
 		for k, v := range o.Examples {
 			if e := validator.ValidateData(location, v); e != nil {
-				errs = append(errs, newValidationError(joinLoc(location, "examples", k), e))
+				errs = append(errs, newValidationError(joinLoc(location, "examples", k), "%w: %w", ErrInvalidData, e))
 			}
 		}
 	}
@@ -597,12 +671,12 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 					errs = append(errs, o.Items.validateSpec(joinLoc(location, "items"), validator)...)
 				}
 				if o.MinItems != nil && *o.MinItems < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "minItems"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "minItems"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
 				if o.MaxItems != nil && *o.MaxItems < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "maxItems"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "maxItems"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 					if o.MinItems != nil && *o.MaxItems < *o.MinItems {
-						errs = append(errs, newValidationError(joinLoc(location, "maxItems"), "must be greater than or equal to minItems"))
+						errs = append(errs, newValidationError(joinLoc(location, "maxItems"), "%w: must be greater than or equal to minItems", ErrOutOfRange))
 					}
 				}
 				if o.UnevaluatedItems != nil {
@@ -612,12 +686,12 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 					errs = append(errs, o.Contains.validateSpec(joinLoc(location, "contains"), validator)...)
 				}
 				if o.MinContains != nil && *o.MinContains < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "minContains"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "minContains"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
 				if o.MaxContains != nil && *o.MaxContains < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "maxContains"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "maxContains"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 					if o.MinContains != nil && *o.MaxContains < *o.MinContains {
-						errs = append(errs, newValidationError(joinLoc(location, "maxContains"), "must be greater than or equal to minContains"))
+						errs = append(errs, newValidationError(joinLoc(location, "maxContains"), "%w: must be greater than or equal to minContains", ErrOutOfRange))
 					}
 				}
 				if len(o.PrefixItems) > 0 {
@@ -635,7 +709,7 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 					for k, v := range o.PatternProperties {
 						errs = append(errs, v.validateSpec(joinLoc(location, "patternProperties", k), validator)...)
 						if _, err := regexp.Compile(k); err != nil {
-							errs = append(errs, newValidationError(joinLoc(location, "patternProperties", k), err))
+							errs = append(errs, newValidationError(joinLoc(location, "patternProperties", k), "%w: %w", ErrInvalidFormat, err))
 						}
 					}
 				}
@@ -649,41 +723,41 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 					errs = append(errs, o.PropertyNames.validateSpec(joinLoc(location, "propertyNames"), validator)...)
 				}
 				if o.MinProperties != nil && *o.MinProperties < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "minProperties"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "minProperties"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
 				if o.MaxProperties != nil && *o.MaxProperties < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "maxProperties"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "maxProperties"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 					if o.MinProperties != nil && *o.MaxProperties < *o.MinProperties {
-						errs = append(errs, newValidationError(joinLoc(location, "maxProperties"), "must be greater than or equal to minProperties"))
+						errs = append(errs, newValidationError(joinLoc(location, "maxProperties"), "%w: must be greater than or equal to minProperties", ErrOutOfRange))
 					}
 				}
 				if len(o.Required) > 0 {
 					for i, v := range o.Required {
 						if _, ok := o.Properties[v]; !ok {
-							errs = append(errs, newValidationError(joinLoc(location, "required", i), "must be a property in properties"))
+							errs = append(errs, newValidationError(joinLoc(location, "required", i), "%w: must be a property in properties", ErrInvalidValue))
 						}
 					}
 				}
 			case NumberType, IntegerType: // JsonSchemaTypeNumber
-				if o.MultipleOf != nil && *o.MultipleOf <= 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "multipleOf"), "must be greater than 0"))
+				if o.MultipleOf != nil && o.MultipleOf.Cmp("0") <= 0 {
+					errs = append(errs, newValidationError(joinLoc(location, "multipleOf"), "%w: must be greater than 0", ErrOutOfRange))
 				}
-				if o.Minimum != nil && *o.Minimum < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "minimum"), "must be greater than or equal to 0"))
+				if o.Minimum != nil && o.Minimum.Cmp("0") < 0 {
+					errs = append(errs, newValidationError(joinLoc(location, "minimum"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
-				if o.Maximum != nil && *o.Maximum < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "maximum"), "must be greater than or equal to 0"))
-					if o.Minimum != nil && *o.Maximum < *o.Minimum {
-						errs = append(errs, newValidationError(joinLoc(location, "maximum"), "must be greater than or equal to minimum"))
+				if o.Maximum != nil && o.Maximum.Cmp("0") < 0 {
+					errs = append(errs, newValidationError(joinLoc(location, "maximum"), "%w: must be greater than or equal to 0", ErrOutOfRange))
+					if o.Minimum != nil && o.Maximum.Cmp(*o.Minimum) < 0 {
+						errs = append(errs, newValidationError(joinLoc(location, "maximum"), "%w: must be greater than or equal to minimum", ErrOutOfRange))
 					}
 				}
-				if o.ExclusiveMinimum != nil && *o.ExclusiveMinimum < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "exclusiveMinimum"), "must be greater than or equal to 0"))
+				if o.ExclusiveMinimum != nil && o.ExclusiveMinimum.Cmp("0") < 0 {
+					errs = append(errs, newValidationError(joinLoc(location, "exclusiveMinimum"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
-				if o.ExclusiveMaximum != nil && *o.ExclusiveMaximum < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "exclusiveMaximum"), "must be greater than or equal to 0"))
-					if o.ExclusiveMinimum != nil && *o.ExclusiveMaximum < *o.ExclusiveMinimum {
-						errs = append(errs, newValidationError(joinLoc(location, "exclusiveMaximum"), "must be greater than or equal to exclusiveMinimum"))
+				if o.ExclusiveMaximum != nil && o.ExclusiveMaximum.Cmp("0") < 0 {
+					errs = append(errs, newValidationError(joinLoc(location, "exclusiveMaximum"), "%w: must be greater than or equal to 0", ErrOutOfRange))
+					if o.ExclusiveMinimum != nil && o.ExclusiveMaximum.Cmp(*o.ExclusiveMinimum) < 0 {
+						errs = append(errs, newValidationError(joinLoc(location, "exclusiveMaximum"), "%w: must be greater than or equal to exclusiveMinimum", ErrOutOfRange))
 					}
 				}
 				if o.Minimum != nil && o.ExclusiveMinimum != nil {
@@ -694,17 +768,17 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 				}
 			case StringType: // JsonSchemaTypeString
 				if o.MinLength != nil && *o.MinLength < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "minLength"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "minLength"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 				}
 				if o.MaxLength != nil && *o.MaxLength < 0 {
-					errs = append(errs, newValidationError(joinLoc(location, "maxLength"), "must be greater than or equal to 0"))
+					errs = append(errs, newValidationError(joinLoc(location, "maxLength"), "%w: must be greater than or equal to 0", ErrOutOfRange))
 					if o.MinLength != nil && *o.MaxLength < *o.MinLength {
-						errs = append(errs, newValidationError(joinLoc(location, "maxLength"), "must be greater than or equal to minLength"))
+						errs = append(errs, newValidationError(joinLoc(location, "maxLength"), "%w: must be greater than or equal to minLength", ErrOutOfRange))
 					}
 				}
 				if o.Pattern != "" {
 					if _, err := regexp.Compile(o.Pattern); err != nil {
-						errs = append(errs, newValidationError(joinLoc(location, "pattern"), err))
+						errs = append(errs, newValidationError(joinLoc(location, "pattern"), "%w: %w", ErrInvalidFormat, err))
 					}
 				}
 			}
@@ -713,8 +787,9 @@ func (o *Schema) validateSpec(location string, validator *Validator) []*validati
 	return errs
 }
 
-type SchemaBulder struct {
-	spec *RefOrSpec[Schema]
+// NewBoolSchema creates a boolean schema: `true` allows any value and `false` allows no value.
+func NewBoolSchema(v bool) *RefOrSpec[Schema] {
+	return NewRefOrSpec[Schema](&Schema{Bool: &v})
 }
 
 func NewSchemaBuilder() *SchemaBulder {
@@ -725,66 +800,6 @@ func NewSchemaBuilder() *SchemaBulder {
 	}
 }
 
-func (b *SchemaBulder) Build() *RefOrSpec[Schema] {
-	return b.spec
-}
-
-func (b *SchemaBulder) Extensions(v map[string]any) *SchemaBulder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *SchemaBulder) AddExt(name string, value any) *SchemaBulder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *SchemaBulder) Schema(v string) *SchemaBulder {
-	b.spec.Spec.Schema = v
-	return b
-}
-
-func (b *SchemaBulder) ID(v string) *SchemaBulder {
-	b.spec.Spec.ID = v
-	return b
-}
-
-func (b *SchemaBulder) Defs(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Defs = v
-	return b
-}
-
-func (b *SchemaBulder) AddDef(name string, value *RefOrSpec[Schema]) *SchemaBulder {
-	if b.spec.Spec.Defs == nil {
-		b.spec.Spec.Defs = make(map[string]*RefOrSpec[Schema], 1)
-	}
-	b.spec.Spec.Defs[name] = value
-	return b
-}
-
-func (b *SchemaBulder) DynamicRef(v string) *SchemaBulder {
-	b.spec.Spec.DynamicRef = v
-	return b
-}
-
-func (b *SchemaBulder) Vocabulary(v map[string]bool) *SchemaBulder {
-	b.spec.Spec.Vocabulary = v
-	return b
-}
-
-func (b *SchemaBulder) AddVocabulary(name string, value bool) *SchemaBulder {
-	if b.spec.Spec.Vocabulary == nil {
-		b.spec.Spec.Vocabulary = make(map[string]bool, 1)
-	}
-	b.spec.Spec.Vocabulary[name] = value
-	return b
-}
-
-func (b *SchemaBulder) DynamicAnchor(v string) *SchemaBulder {
-	b.spec.Spec.DynamicAnchor = v
-	return b
-}
-
 func (b *SchemaBulder) Type(v ...string) *SchemaBulder {
 	b.spec.Spec.Type = NewSingleOrArray[string](v...)
 	return b
@@ -798,330 +813,3 @@ func (b *SchemaBulder) AddType(v ...string) *SchemaBulder {
 	}
 	return b
 }
-
-func (b *SchemaBulder) Default(v any) *SchemaBulder {
-	b.spec.Spec.Default = v
-	return b
-}
-
-func (b *SchemaBulder) Title(v string) *SchemaBulder {
-	b.spec.Spec.Title = v
-	return b
-}
-
-func (b *SchemaBulder) Description(v string) *SchemaBulder {
-	b.spec.Spec.Description = v
-	return b
-}
-
-func (b *SchemaBulder) Const(v string) *SchemaBulder {
-	b.spec.Spec.Const = v
-	return b
-}
-
-func (b *SchemaBulder) Comment(v string) *SchemaBulder {
-	b.spec.Spec.Comment = v
-	return b
-}
-
-func (b *SchemaBulder) Enum(v ...any) *SchemaBulder {
-	b.spec.Spec.Enum = v
-	return b
-}
-
-func (b *SchemaBulder) AddEnum(v ...any) *SchemaBulder {
-	b.spec.Spec.Enum = append(b.spec.Spec.Enum, v...)
-	return b
-}
-
-func (b *SchemaBulder) Examples(v ...any) *SchemaBulder {
-	b.spec.Spec.Examples = v
-	return b
-}
-
-func (b *SchemaBulder) AddExamples(v ...any) *SchemaBulder {
-	b.spec.Spec.Examples = append(b.spec.Spec.Examples, v...)
-	return b
-}
-
-func (b *SchemaBulder) ReadOnly(v bool) *SchemaBulder {
-	b.spec.Spec.ReadOnly = v
-	return b
-}
-
-func (b *SchemaBulder) WriteOnly(v bool) *SchemaBulder {
-	b.spec.Spec.WriteOnly = v
-	return b
-}
-
-func (b *SchemaBulder) Deprecated(v bool) *SchemaBulder {
-	b.spec.Spec.Deprecated = v
-	return b
-}
-
-func (b *SchemaBulder) ContentSchema(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.ContentSchema = v
-	return b
-}
-
-func (b *SchemaBulder) ContentMediaType(v string) *SchemaBulder {
-	b.spec.Spec.ContentMediaType = v
-	return b
-}
-
-func (b *SchemaBulder) ContentEncoding(v string) *SchemaBulder {
-	b.spec.Spec.ContentEncoding = v
-	return b
-}
-
-func (b *SchemaBulder) Not(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Not = v
-	return b
-}
-
-func (b *SchemaBulder) AllOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.AllOf = v
-	return b
-}
-
-func (b *SchemaBulder) AddAllOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.AllOf = append(b.spec.Spec.AllOf, v...)
-	return b
-}
-
-func (b *SchemaBulder) AnyOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.AnyOf = v
-	return b
-}
-
-func (b *SchemaBulder) AddAnyOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.AnyOf = append(b.spec.Spec.AnyOf, v...)
-	return b
-}
-
-func (b *SchemaBulder) OneOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.OneOf = v
-	return b
-}
-
-func (b *SchemaBulder) AddOneOf(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.OneOf = append(b.spec.Spec.OneOf, v...)
-	return b
-}
-
-func (b *SchemaBulder) DependentRequired(v map[string][]string) *SchemaBulder {
-	b.spec.Spec.DependentRequired = v
-	return b
-}
-
-func (b *SchemaBulder) AddDependentRequired(name string, value ...string) *SchemaBulder {
-	if b.spec.Spec.DependentRequired == nil {
-		b.spec.Spec.DependentRequired = make(map[string][]string, 1)
-	}
-	b.spec.Spec.DependentRequired[name] = append(b.spec.Spec.DependentRequired[name], value...)
-	return b
-}
-
-func (b *SchemaBulder) DependentSchemas(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.DependentSchemas = v
-	return b
-}
-
-func (b *SchemaBulder) AddDependentSchema(name string, value *RefOrSpec[Schema]) *SchemaBulder {
-	if b.spec.Spec.DependentSchemas == nil {
-		b.spec.Spec.DependentSchemas = make(map[string]*RefOrSpec[Schema], 1)
-	}
-	b.spec.Spec.DependentSchemas[name] = value
-	return b
-}
-
-func (b *SchemaBulder) If(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.If = v
-	return b
-}
-
-func (b *SchemaBulder) Then(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Then = v
-	return b
-}
-
-func (b *SchemaBulder) Else(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Else = v
-	return b
-}
-
-func (b *SchemaBulder) MultipleOf(v int) *SchemaBulder {
-	b.spec.Spec.MultipleOf = &v
-	return b
-}
-
-func (b *SchemaBulder) Minimum(v int) *SchemaBulder {
-	b.spec.Spec.Minimum = &v
-	return b
-}
-
-func (b *SchemaBulder) ExclusiveMinimum(v int) *SchemaBulder {
-	b.spec.Spec.ExclusiveMinimum = &v
-	return b
-}
-
-func (b *SchemaBulder) Maximum(v int) *SchemaBulder {
-	b.spec.Spec.Maximum = &v
-	return b
-}
-
-func (b *SchemaBulder) ExclusiveMaximum(v int) *SchemaBulder {
-	b.spec.Spec.ExclusiveMaximum = &v
-	return b
-}
-
-func (b *SchemaBulder) MinLength(v int) *SchemaBulder {
-	b.spec.Spec.MinLength = &v
-	return b
-}
-
-func (b *SchemaBulder) MaxLength(v int) *SchemaBulder {
-	b.spec.Spec.MaxLength = &v
-	return b
-}
-
-func (b *SchemaBulder) Pattern(v string) *SchemaBulder {
-	b.spec.Spec.Pattern = v
-	return b
-}
-
-func (b *SchemaBulder) Format(v string) *SchemaBulder {
-	b.spec.Spec.Format = v
-	return b
-}
-
-func (b *SchemaBulder) Items(v *BoolOrSchema) *SchemaBulder {
-	b.spec.Spec.Items = v
-	return b
-}
-
-func (b *SchemaBulder) MaxItems(v int) *SchemaBulder {
-	b.spec.Spec.MaxItems = &v
-	return b
-}
-
-func (b *SchemaBulder) UnevaluatedItems(v *BoolOrSchema) *SchemaBulder {
-	b.spec.Spec.UnevaluatedItems = v
-	return b
-}
-
-func (b *SchemaBulder) Contains(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Contains = v
-	return b
-}
-
-func (b *SchemaBulder) MinContains(v int) *SchemaBulder {
-	b.spec.Spec.MinContains = &v
-	return b
-}
-
-func (b *SchemaBulder) MaxContains(v int) *SchemaBulder {
-	b.spec.Spec.MaxContains = &v
-	return b
-}
-
-func (b *SchemaBulder) MinItems(v int) *SchemaBulder {
-	b.spec.Spec.MinItems = &v
-	return b
-}
-
-func (b *SchemaBulder) UniqueItems(v bool) *SchemaBulder {
-	b.spec.Spec.UniqueItems = &v
-	return b
-}
-
-func (b *SchemaBulder) PrefixItems(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.PrefixItems = v
-	return b
-}
-
-func (b *SchemaBulder) AddPrefixItems(v ...*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.PrefixItems = append(b.spec.Spec.PrefixItems, v...)
-	return b
-}
-
-func (b *SchemaBulder) Properties(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.Properties = v
-	return b
-}
-
-func (b *SchemaBulder) AddProperty(name string, value *RefOrSpec[Schema]) *SchemaBulder {
-	if b.spec.Spec.Properties == nil {
-		b.spec.Spec.Properties = make(map[string]*RefOrSpec[Schema], 1)
-	}
-	b.spec.Spec.Properties[name] = value
-	return b
-}
-
-func (b *SchemaBulder) PatternProperties(v map[string]*RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.PatternProperties = v
-	return b
-}
-
-func (b *SchemaBulder) AddPatternProperty(name string, value *RefOrSpec[Schema]) *SchemaBulder {
-	if b.spec.Spec.PatternProperties == nil {
-		b.spec.Spec.PatternProperties = make(map[string]*RefOrSpec[Schema], 1)
-	}
-	b.spec.Spec.PatternProperties[name] = value
-	return b
-}
-
-func (b *SchemaBulder) AdditionalProperties(v *BoolOrSchema) *SchemaBulder {
-	b.spec.Spec.AdditionalProperties = v
-	return b
-}
-
-func (b *SchemaBulder) UnevaluatedProperties(v *BoolOrSchema) *SchemaBulder {
-	b.spec.Spec.UnevaluatedProperties = v
-	return b
-}
-
-func (b *SchemaBulder) PropertyNames(v *RefOrSpec[Schema]) *SchemaBulder {
-	b.spec.Spec.PropertyNames = v
-	return b
-}
-
-func (b *SchemaBulder) MinProperties(v int) *SchemaBulder {
-	b.spec.Spec.MinProperties = &v
-	return b
-}
-
-func (b *SchemaBulder) MaxProperties(v int) *SchemaBulder {
-	b.spec.Spec.MaxProperties = &v
-	return b
-}
-
-func (b *SchemaBulder) Required(v ...string) *SchemaBulder {
-	b.spec.Spec.Required = v
-	return b
-}
-
-func (b *SchemaBulder) AddRequired(v ...string) *SchemaBulder {
-	b.spec.Spec.Required = append(b.spec.Spec.Required, v...)
-	return b
-}
-
-func (b *SchemaBulder) Discriminator(v *Discriminator) *SchemaBulder {
-	b.spec.Spec.Discriminator = v
-	return b
-}
-
-func (b *SchemaBulder) XML(v *Extendable[XML]) *SchemaBulder {
-	b.spec.Spec.XML = v
-	return b
-}
-
-func (b *SchemaBulder) ExternalDocs(v *Extendable[ExternalDocs]) *SchemaBulder {
-	b.spec.Spec.ExternalDocs = v
-	return b
-}
-
-func (b *SchemaBulder) Example(v any) *SchemaBulder {
-	b.spec.Spec.Example = v
-	return b
-}
@@ -0,0 +1,229 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"time"
+)
+
+type inferOptions struct {
+	doNotDetectFormats bool
+	doNotInferRequired bool
+}
+
+// InferOption is a type for schema inference options.
+type InferOption func(*inferOptions)
+
+// DoNotDetectFormats is an inference option to skip detection of string formats like `date-time` or `uuid`.
+func DoNotDetectFormats() InferOption {
+	return func(o *inferOptions) {
+		o.doNotDetectFormats = true
+	}
+}
+
+// DoNotInferRequired is an inference option to keep all properties optional.
+// By default, a property is required if it is present in all object samples.
+func DoNotInferRequired() InferOption {
+	return func(o *inferOptions) {
+		o.doNotInferRequired = true
+	}
+}
+
+// InferSchema derives a Schema from one or more example payloads.
+//
+// The samples are merged: the types are combined, the properties of all objects are collected,
+// and a property is marked as required only if it is present in every object sample.
+// A string format (date-time, date, time, uuid, email, ipv4, ipv6, uri) is set only if all string samples have it.
+//
+// A sample can be a []byte or json.RawMessage containing JSON, or any value supported by json.Marshal.
+func InferSchema(samples []any, opts ...InferOption) (*RefOrSpec[Schema], error) {
+	options := &inferOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	inferrer := newSchemaInferrer(options)
+	for i, sample := range samples {
+		var data []byte
+		switch v := sample.(type) {
+		case []byte:
+			data = v
+		case json.RawMessage:
+			data = v
+		default:
+			var err error
+			data, err = json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("marshaling sample %d failed: %w", i, err)
+			}
+		}
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("unmarshaling sample %d failed: %w", i, err)
+		}
+		inferrer.add(value)
+	}
+	return inferrer.schema(), nil
+}
+
+// schemaInferrer accumulates the JSON samples and derives a Schema describing all of them.
+type schemaInferrer struct {
+	opts       *inferOptions
+	types      map[string]bool
+	formats    map[string]int
+	properties map[string]*schemaInferrer
+	// number of object samples where a property has been seen
+	propertySeen map[string]int
+	objects      int
+	strings      int
+	items        *schemaInferrer
+}
+
+func newSchemaInferrer(opts *inferOptions) *schemaInferrer {
+	if opts == nil {
+		opts = &inferOptions{}
+	}
+	return &schemaInferrer{
+		opts:  opts,
+		types: make(map[string]bool),
+	}
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// detectFormat returns the format of the given string or an empty string.
+func detectFormat(s string) string {
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return DateTimeFormat
+	}
+	if _, err := time.Parse(time.DateOnly, s); err == nil {
+		return DateFormat
+	}
+	if _, err := time.Parse("15:04:05Z07:00", s); err == nil {
+		return TimeFormat
+	}
+	if uuidPattern.MatchString(s) {
+		return UUIDFormat
+	}
+	if ip := net.ParseIP(s); ip != nil {
+		if ip.To4() != nil {
+			return IPv4Format
+		}
+		return IPv6Format
+	}
+	if a, err := mail.ParseAddress(s); err == nil && a.Address == s {
+		return EmailFormat
+	}
+	if u, err := url.ParseRequestURI(s); err == nil && u.Scheme != "" && u.Host != "" {
+		return URIFormat
+	}
+	return ""
+}
+
+// add merges the given JSON value (as produced by json.Unmarshal into `any`) into the inferrer.
+func (i *schemaInferrer) add(value any) {
+	switch v := value.(type) {
+	case nil:
+		i.types[NullType] = true
+	case bool:
+		i.types[BooleanType] = true
+	case string:
+		i.types[StringType] = true
+		i.strings++
+		if !i.opts.doNotDetectFormats {
+			if f := detectFormat(v); f != "" {
+				if i.formats == nil {
+					i.formats = make(map[string]int, 1)
+				}
+				i.formats[f]++
+			}
+		}
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			i.types[IntegerType] = true
+		} else {
+			i.types[NumberType] = true
+		}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			i.types[IntegerType] = true
+		} else {
+			i.types[NumberType] = true
+		}
+	case []any:
+		i.types[ArrayType] = true
+		if i.items == nil {
+			i.items = newSchemaInferrer(i.opts)
+		}
+		for _, item := range v {
+			i.items.add(item)
+		}
+	case map[string]any:
+		i.types[ObjectType] = true
+		if i.properties == nil {
+			i.properties = make(map[string]*schemaInferrer, len(v))
+			i.propertySeen = make(map[string]int, len(v))
+		}
+		i.objects++
+		for name, prop := range v {
+			p, ok := i.properties[name]
+			if !ok {
+				p = newSchemaInferrer(i.opts)
+				i.properties[name] = p
+			}
+			p.add(prop)
+			i.propertySeen[name]++
+		}
+	}
+}
+
+// schema builds the Schema from all added samples.
+func (i *schemaInferrer) schema() *RefOrSpec[Schema] {
+	b := NewSchemaBuilder()
+	types := make([]string, 0, len(i.types))
+	for t := range i.types {
+		// integer is a subset of number
+		if t == IntegerType && i.types[NumberType] {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	if len(types) > 0 {
+		b.Type(types...)
+	}
+	for f, n := range i.formats {
+		if n == i.strings {
+			b.Format(f)
+		}
+	}
+	if i.items != nil {
+		b.Items(NewBoolOrSchema(i.items.subSchema()))
+	}
+	if len(i.properties) > 0 {
+		names := make([]string, 0, len(i.properties))
+		for name, p := range i.properties {
+			b.AddProperty(name, p.subSchema())
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !i.opts.doNotInferRequired && i.propertySeen[name] == i.objects {
+				b.AddRequired(name)
+			}
+		}
+	}
+	return b.Build()
+}
+
+// subSchema returns the schema without `$schema` keyword to be used as a nested schema.
+func (i *schemaInferrer) subSchema() *RefOrSpec[Schema] {
+	s := i.schema()
+	s.Spec.Schema = ""
+	return s
+}
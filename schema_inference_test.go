@@ -0,0 +1,84 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestInferSchema(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		samples  []any
+		opts     []openapi.InferOption
+		expected string
+	}{
+		{
+			name:     "primitive",
+			samples:  []any{42},
+			expected: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "integer"}`,
+		},
+		{
+			name:     "integer and number",
+			samples:  []any{[]byte(`1`), []byte(`1.5`)},
+			expected: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "number"}`,
+		},
+		{
+			name: "merged objects",
+			samples: []any{
+				json.RawMessage(`{"id": "6f1c1f3a-8b7a-4a51-9a8d-2f1e0c6b7d11", "created": "2024-01-02T15:04:05Z", "name": "foo"}`),
+				map[string]any{"id": "0a2f4c38-3b5e-4f0a-8d0f-93a5e5d0e6c2", "created": "2024-02-03T10:00:00+02:00", "tags": []string{"a"}},
+			},
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"id": {"type": "string", "format": "uuid"},
+					"created": {"type": "string", "format": "date-time"},
+					"name": {"type": "string"},
+					"tags": {"type": "array", "items": {"type": "string"}}
+				},
+				"required": ["created", "id"]
+			}`,
+		},
+		{
+			name: "mixed formats",
+			samples: []any{
+				"2024-01-02",
+				"foo@example.com",
+			},
+			expected: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": "string"}`,
+		},
+		{
+			name:     "nullable",
+			samples:  []any{nil, "foo"},
+			expected: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "type": ["null", "string"]}`,
+		},
+		{
+			name: "no formats and no required",
+			samples: []any{
+				map[string]any{"created": "2024-01-02T15:04:05Z"},
+			},
+			opts: []openapi.InferOption{openapi.DoNotDetectFormats(), openapi.DoNotInferRequired()},
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {"created": {"type": "string"}}
+			}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := openapi.InferSchema(tt.samples, tt.opts...)
+			require.NoError(t, err)
+			data, err := json.Marshal(schema)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(data))
+		})
+	}
+
+	_, err := openapi.InferSchema([]any{[]byte(`{`)})
+	require.ErrorContains(t, err, "unmarshaling sample 0 failed")
+}
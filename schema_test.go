@@ -94,3 +94,92 @@ func TestSchema_AddExt(t *testing.T) {
 		})
 	}
 }
+
+const testBoolSchemasSpec = `
+openapi: 3.1.1
+info:
+  title: Boolean schemas
+  version: 1.0.0
+components:
+  schemas:
+    Any: true
+    Nothing: false
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        tags: true
+        legacy: false
+    NotNothing:
+      not: false
+    AllOfAny:
+      allOf:
+        - true
+        - type: string
+`
+
+func TestSchema_Bool(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testBoolSchemasSpec), &spec))
+	schemas := spec.Spec.Components.Spec.Schemas
+	require.Equal(t, openapi.NewBoolSchema(true), schemas["Any"])
+	require.Equal(t, openapi.NewBoolSchema(false), schemas["Nothing"])
+	require.Equal(t, openapi.NewBoolSchema(false), schemas["Pet"].Spec.Properties["legacy"])
+	require.Equal(t, openapi.NewBoolSchema(false), schemas["NotNothing"].Spec.Not)
+	require.Equal(t, openapi.NewBoolSchema(true), schemas["AllOfAny"].Spec.AllOf[0])
+
+	t.Run("round trip", func(t *testing.T) {
+		data, err := json.Marshal(spec)
+		require.NoError(t, err)
+		var fromJSON *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, json.Unmarshal(data, &fromJSON))
+		require.Equal(t, spec, fromJSON)
+
+		data, err = yaml.Marshal(spec)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "Any: true\n")
+		var fromYAML *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal(data, &fromYAML))
+		require.Equal(t, spec, fromYAML)
+
+		var null *openapi.Schema
+		require.NoError(t, json.Unmarshal([]byte(`null`), &null))
+		require.Nil(t, null)
+	})
+
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	for _, tt := range []struct {
+		location string
+		data     any
+		valid    bool
+	}{
+		{location: "/components/schemas/Any", data: map[string]any{"foo": 1}, valid: true},
+		{location: "/components/schemas/Nothing", data: "foo", valid: false},
+		{location: "/components/schemas/NotNothing", data: 42, valid: true},
+		{location: "/components/schemas/AllOfAny", data: "foo", valid: true},
+		{location: "/components/schemas/AllOfAny", data: 42, valid: false},
+		{location: "/components/schemas/Pet", data: map[string]any{"name": "foo", "tags": []any{1}}, valid: true},
+		{location: "/components/schemas/Pet", data: map[string]any{"name": "foo", "legacy": true}, valid: false},
+	} {
+		t.Run(tt.location, func(t *testing.T) {
+			err := validator.ValidateData(tt.location, tt.data)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("subschema", func(t *testing.T) {
+		str := openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+		require.NoError(t, openapi.CheckSubschema(str, openapi.NewBoolSchema(true), nil))
+		require.NoError(t, openapi.CheckSubschema(openapi.NewBoolSchema(false), str, nil))
+		require.ErrorIs(t, openapi.CheckSubschema(str, openapi.NewBoolSchema(false), nil), openapi.ErrIncompatible)
+		require.ErrorIs(t, openapi.CheckSubschema(openapi.NewBoolSchema(true), str, nil), openapi.ErrIncompatible)
+	})
+}
@@ -0,0 +1,212 @@
+package openapi
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// The positions of the usages of the schemas, see SchemaUse.
+const (
+	UsageRequest  = "request"
+	UsageResponse = "response"
+)
+
+// SchemaUse is a usage of a component schema by an operation.
+type SchemaUse struct {
+	// Operation is the location of the operation, e.g. `/paths/~1pets/get`.
+	Operation string `json:"operation" yaml:"operation"`
+	Method    string `json:"method" yaml:"method"`
+	// Path is the path of the operation or the name of the webhook.
+	Path string `json:"path" yaml:"path"`
+	// Position is UsageRequest for the parameters and the request body, or UsageResponse for the responses and their headers.
+	Position string `json:"position" yaml:"position"`
+	// Required means that the value of the schema is always present at the position:
+	// the parameter, request body or header is required, and so are all properties on the way to the schema,
+	// while the items of the arrays and the compositions keep the requiredness of their parent.
+	Required bool `json:"required" yaml:"required"`
+}
+
+// SchemaUsage are the usages of a component schema, e.g. to assess the impact of its change.
+type SchemaUsage struct {
+	// Name is the name of the component schema.
+	Name string `json:"name" yaml:"name"`
+	// Uses are the unique usages of the schema sorted by operation, position and requiredness,
+	// including the usages through other component schemas; empty if the schema is not used by any operation.
+	Uses []*SchemaUse `json:"uses" yaml:"uses"`
+}
+
+// RequestCount returns the number of the usages in the requests.
+func (u *SchemaUsage) RequestCount() int {
+	return u.count(UsageRequest)
+}
+
+// ResponseCount returns the number of the usages in the responses.
+func (u *SchemaUsage) ResponseCount() int {
+	return u.count(UsageResponse)
+}
+
+func (u *SchemaUsage) count(position string) int {
+	var n int
+	for _, use := range u.Uses {
+		if use.Position == position {
+			n++
+		}
+	}
+	return n
+}
+
+// AnalyzeSchemaUsage returns the usages of all component schemas of the given spec sorted by name,
+// the operations of the paths and the webhooks are analyzed.
+// A schema is used by an operation if it is referenced by the schemas of the operation, directly or through
+// other component schemas; the unresolved references are ignored.
+func AnalyzeSchemaUsage(spec *Extendable[OpenAPI]) []*SchemaUsage {
+	if spec == nil || spec.Spec == nil {
+		return nil
+	}
+	components := spec.Spec.Components
+	usages := make(map[string]*SchemaUsage)
+	seen := make(map[string]map[SchemaUse]bool)
+	if components != nil && components.Spec != nil {
+		for name := range components.Spec.Schemas {
+			usages[name] = &SchemaUsage{Name: name, Uses: []*SchemaUse{}}
+			seen[name] = make(map[SchemaUse]bool)
+		}
+	}
+
+	forEachOperation(spec, func(location, method string, op *Extendable[Operation]) {
+		segments := Location(location).Segments()
+		u := &schemaUsageWalker{
+			components: components,
+			path:       make(map[string]bool),
+			use: func(name, position string, required bool) {
+				if usages[name] == nil {
+					return
+				}
+				use := SchemaUse{Operation: location, Method: method, Path: segments[1], Position: position, Required: required}
+				if !seen[name][use] {
+					seen[name][use] = true
+					usages[name].Uses = append(usages[name].Uses, &use)
+				}
+			},
+		}
+		var item *RefOrSpec[Extendable[PathItem]]
+		if segments[0] == "webhooks" {
+			item = spec.Spec.WebHooks[segments[1]]
+		} else {
+			item = spec.Spec.Paths.Spec.Paths[segments[1]]
+		}
+		for _, list := range [][]*RefOrSpec[Extendable[Parameter]]{item.Spec.Spec.Parameters, op.Spec.Parameters} {
+			for _, ref := range list {
+				if p, err := ref.GetSpec(components); err == nil && p.Spec != nil {
+					u.walk(p.Spec.Schema, UsageRequest, p.Spec.Required)
+					u.walkContent(p.Spec.Content, UsageRequest, p.Spec.Required)
+				}
+			}
+		}
+		if body := operationRequestBody(op.Spec, components); body != nil {
+			u.walkContent(body.Content, UsageRequest, body.Required)
+		}
+		for _, resp := range operationResponses(op.Spec, components) {
+			u.walkContent(resp.Content, UsageResponse, true)
+			for _, ref := range resp.Headers {
+				if h, err := ref.GetSpec(components); err == nil && h.Spec != nil {
+					u.walk(h.Spec.Schema, UsageResponse, h.Spec.Required)
+					u.walkContent(h.Spec.Content, UsageResponse, h.Spec.Required)
+				}
+			}
+		}
+	})
+
+	list := make([]*SchemaUsage, 0, len(usages))
+	for _, name := range sortedKeys(usages) {
+		usage := usages[name]
+		slices.SortFunc(usage.Uses, func(a, b *SchemaUse) int {
+			if c := cmp.Compare(a.Operation, b.Operation); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.Position, b.Position); c != 0 {
+				return c
+			}
+			// the required usages first
+			if a.Required != b.Required && a.Required {
+				return -1
+			}
+			if a.Required != b.Required {
+				return 1
+			}
+			return 0
+		})
+		list = append(list, usage)
+	}
+	return list
+}
+
+type schemaUsageWalker struct {
+	components *Extendable[Components]
+	// path contains the names of the component schemas being walked to stop at the recursive references
+	path map[string]bool
+	use  func(name, position string, required bool)
+}
+
+func (u *schemaUsageWalker) walkContent(content map[string]*Extendable[MediaType], position string, required bool) {
+	for _, mt := range content {
+		if mt != nil && mt.Spec != nil {
+			u.walk(mt.Spec.Schema, position, required)
+		}
+	}
+}
+
+func (u *schemaUsageWalker) walk(ref *RefOrSpec[Schema], position string, required bool) {
+	if ref == nil {
+		return
+	}
+	if ref.Ref != nil {
+		name, ok := strings.CutPrefix(ref.Ref.Ref, "#/components/schemas/")
+		if !ok {
+			return
+		}
+		name = jsonPointerUnescaper.Replace(name)
+		u.use(name, position, required)
+		if u.path[name] || u.components == nil || u.components.Spec == nil {
+			return
+		}
+		u.path[name] = true
+		defer delete(u.path, name)
+		// the component can be a reference to another component
+		u.walk(u.components.Spec.Schemas[name], position, required)
+		return
+	}
+	s := ref.Spec
+	if s == nil || s.Bool != nil {
+		return
+	}
+
+	// the compositions and the items keep the requiredness of the parent
+	subs := append([]*RefOrSpec[Schema]{s.Not, s.If, s.Then, s.Else, s.Contains}, schemaAllOf(s)...)
+	subs = append(append(append(subs, s.AnyOf...), s.OneOf...), s.PrefixItems...)
+	if s.Items != nil {
+		subs = append(subs, s.Items.Schema)
+	}
+	for _, sub := range subs {
+		u.walk(sub, position, required)
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		u.walk(s.Properties[name], position, required && slices.Contains(s.Required, name))
+	}
+	optional := make([]*RefOrSpec[Schema], 0, len(s.PatternProperties)+len(s.DependentSchemas)+3)
+	for _, name := range sortedKeys(s.PatternProperties) {
+		optional = append(optional, s.PatternProperties[name])
+	}
+	for _, name := range sortedKeys(s.DependentSchemas) {
+		optional = append(optional, s.DependentSchemas[name])
+	}
+	for _, b := range []*BoolOrSchema{s.AdditionalProperties, s.UnevaluatedProperties, s.UnevaluatedItems} {
+		if b != nil {
+			optional = append(optional, b.Schema)
+		}
+	}
+	for _, sub := range optional {
+		u.walk(sub, position, false)
+	}
+}
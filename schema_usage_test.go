@@ -0,0 +1,134 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testSchemaUsageSpec = `
+openapi: 3.1.1
+info:
+  title: Usage
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: tag
+          in: query
+          schema:
+            $ref: '#/components/schemas/Tag'
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+          headers:
+            X-Tag:
+              required: true
+              schema:
+                $ref: '#/components/schemas/Tag'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        tag:
+          $ref: '#/components/schemas/Tag'
+        owner:
+          $ref: '#/components/schemas/Owner'
+        parent:
+          $ref: '#/components/schemas/Pet'
+      required: [name, owner]
+    Owner:
+      $ref: '#/components/schemas/Person'
+    Person:
+      type: object
+      additionalProperties:
+        $ref: '#/components/schemas/Tag'
+    Tag:
+      type: string
+    Unused:
+      type: string
+`
+
+func TestAnalyzeSchemaUsage(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testSchemaUsageSpec), &spec))
+
+	const (
+		get  = "/paths/~1pets/get"
+		post = "/paths/~1pets/post"
+	)
+	use := func(op, position string, required bool) *openapi.SchemaUse {
+		method := "GET"
+		if op == post {
+			method = "POST"
+		}
+		return &openapi.SchemaUse{Operation: op, Method: method, Path: "/pets", Position: position, Required: required}
+	}
+
+	usages := openapi.AnalyzeSchemaUsage(spec)
+	require.Equal(t, []*openapi.SchemaUsage{
+		{
+			Name: "Owner",
+			Uses: []*openapi.SchemaUse{
+				use(get, openapi.UsageResponse, true),
+				use(post, openapi.UsageRequest, true),
+			},
+		},
+		{
+			Name: "Person",
+			Uses: []*openapi.SchemaUse{
+				use(get, openapi.UsageResponse, true),
+				use(post, openapi.UsageRequest, true),
+			},
+		},
+		{
+			Name: "Pet",
+			Uses: []*openapi.SchemaUse{
+				use(get, openapi.UsageResponse, true),
+				use(get, openapi.UsageResponse, false),
+				use(post, openapi.UsageRequest, true),
+				use(post, openapi.UsageRequest, false),
+			},
+		},
+		{
+			Name: "Tag",
+			Uses: []*openapi.SchemaUse{
+				use(get, openapi.UsageRequest, false),
+				use(get, openapi.UsageResponse, false),
+				use(post, openapi.UsageRequest, false),
+				use(post, openapi.UsageResponse, true),
+			},
+		},
+		{
+			Name: "Unused",
+			Uses: []*openapi.SchemaUse{},
+		},
+	}, usages)
+
+	require.Equal(t, 2, usages[3].RequestCount())
+	require.Equal(t, 2, usages[3].ResponseCount())
+	require.Zero(t, usages[4].RequestCount())
+}
@@ -1,5 +1,7 @@
 package openapi
 
+import "fmt"
+
 // SecurityRequirement is the lists of the required security schemes to execute this operation.
 // The name used for each property MUST correspond to a security scheme declared in the Security Schemes under the Components Object.
 // Security Requirement Objects that contain multiple schemes require that all schemes MUST be satisfied for a request to be authorized.
@@ -39,3 +41,71 @@ func (b *SecurityRequirementBuilder) Add(name string, scopes ...string) *Securit
 	b.spec[name] = append(b.spec[name], scopes...)
 	return b
 }
+
+// SecuritySchemeRequirement is a security scheme required by an operation with the scopes.
+type SecuritySchemeRequirement struct {
+	Scheme *SecurityScheme
+	Name   string
+	Scopes []string
+}
+
+// SecurityAlternative is a set of the security schemes that all MUST be satisfied to authorize a request.
+// An empty alternative means that the anonymous access is allowed.
+type SecurityAlternative []*SecuritySchemeRequirement
+
+// EffectiveSecurity returns the security alternatives applied to the given operation of the spec,
+// only one of them needs to be satisfied to authorize a request.
+//
+// The security requirements of the operation override the top-level ones;
+// an empty, but not nil, list of the operation removes the top-level requirements.
+// The nil result means that the operation does not require any security.
+// The schemes of each alternative are sorted by name and resolved using the components of the spec,
+// an error wrapping ErrUnresolvedRef is returned if a scheme is not found.
+func EffectiveSecurity(op *Extendable[Operation], spec *Extendable[OpenAPI]) ([]SecurityAlternative, error) {
+	var security []SecurityRequirement
+	if spec != nil && spec.Spec != nil {
+		security = spec.Spec.Security
+	}
+	if op != nil && op.Spec != nil && op.Spec.Security != nil {
+		security = op.Spec.Security
+	}
+	if len(security) == 0 {
+		return nil, nil
+	}
+	var components *Extendable[Components]
+	if spec != nil && spec.Spec != nil {
+		components = spec.Spec.Components
+	}
+
+	alternatives := make([]SecurityAlternative, len(security))
+	for i, req := range security {
+		alternative := make(SecurityAlternative, 0, len(req))
+		for _, name := range sortedKeys(req) {
+			scheme, err := lookupSecurityScheme(components, name)
+			if err != nil {
+				return nil, err
+			}
+			alternative = append(alternative, &SecuritySchemeRequirement{
+				Name:   name,
+				Scheme: scheme,
+				Scopes: req[name],
+			})
+		}
+		alternatives[i] = alternative
+	}
+	return alternatives, nil
+}
+
+func lookupSecurityScheme(components *Extendable[Components], name string) (*SecurityScheme, error) {
+	if components == nil || components.Spec == nil || components.Spec.SecuritySchemes[name] == nil {
+		return nil, fmt.Errorf("%w: security scheme '%s' not found", ErrUnresolvedRef, name)
+	}
+	scheme, err := components.Spec.SecuritySchemes[name].GetSpec(components)
+	if err != nil {
+		return nil, err
+	}
+	if scheme.Spec == nil {
+		return nil, fmt.Errorf("%w: security scheme '%s' is empty", ErrUnresolvedRef, name)
+	}
+	return scheme.Spec, nil
+}
@@ -0,0 +1,111 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testEffectiveSecuritySpec = `
+openapi: 3.1.1
+info:
+  title: Security
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      security:
+        - oauth: [write]
+          apiKey: []
+        - {}
+      responses:
+        '200':
+          description: OK
+    delete:
+      security: []
+      responses:
+        '200':
+          description: OK
+    put:
+      security:
+        - unknown: []
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            write: Write access
+`
+
+func TestEffectiveSecurity(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testEffectiveSecuritySpec), &spec))
+	item := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec
+	apiKey := spec.Spec.Components.Spec.SecuritySchemes["apiKey"].Spec.Spec
+	oauth := spec.Spec.Components.Spec.SecuritySchemes["oauth"].Spec.Spec
+
+	for _, tt := range []struct {
+		name     string
+		op       *openapi.Extendable[openapi.Operation]
+		expected []openapi.SecurityAlternative
+		err      string
+	}{
+		{
+			name: "global",
+			op:   item.Get,
+			expected: []openapi.SecurityAlternative{
+				{{Name: "apiKey", Scheme: apiKey, Scopes: []string{}}},
+			},
+		},
+		{
+			name: "override",
+			op:   item.Post,
+			expected: []openapi.SecurityAlternative{
+				{
+					{Name: "apiKey", Scheme: apiKey, Scopes: []string{}},
+					{Name: "oauth", Scheme: oauth, Scopes: []string{"write"}},
+				},
+				{},
+			},
+		},
+		{
+			name: "removed",
+			op:   item.Delete,
+		},
+		{
+			name: "unknown scheme",
+			op:   item.Put,
+			err:  "unresolved reference: security scheme 'unknown' not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			alternatives, err := openapi.EffectiveSecurity(tt.op, spec)
+			if tt.err != "" {
+				require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, alternatives)
+		})
+	}
+}
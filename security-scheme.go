@@ -90,7 +90,7 @@ func (o *SecurityScheme) validateSpec(location string, validator *Validator) []*
 				switch o.In {
 				case InQuery, InHeader, InCookie:
 				default:
-					errs = append(errs, newValidationError(joinLoc(location, "in"), "invalid value, expected one of [%s, %s, %s], but got '%s'", InQuery, InHeader, InCookie, o.In))
+					errs = append(errs, newValidationError(joinLoc(location, "in"), "%w, expected one of [%s, %s, %s], but got '%s'", ErrInvalidValue, InQuery, InHeader, InCookie, o.In))
 				}
 			}
 		case TypeHTTP:
@@ -109,72 +109,8 @@ func (o *SecurityScheme) validateSpec(location string, validator *Validator) []*
 			}
 		case TypeMutualTLS:
 		default:
-			errs = append(errs, newValidationError(joinLoc(location, "type"), "invalid value, expected one of [%s, %s, %s, %s, %s], but got '%s'", TypeApiKey, TypeHTTP, TypeMutualTLS, TypeOAuth2, TypeOpenIDConnect, o.Type))
+			errs = append(errs, newValidationError(joinLoc(location, "type"), "%w, expected one of [%s, %s, %s, %s, %s], but got '%s'", ErrInvalidValue, TypeApiKey, TypeHTTP, TypeMutualTLS, TypeOAuth2, TypeOpenIDConnect, o.Type))
 		}
 	}
 	return errs
 }
-
-type SecuritySchemeBuilder struct {
-	spec *RefOrSpec[Extendable[SecurityScheme]]
-}
-
-func NewSecuritySchemeBuilder() *SecuritySchemeBuilder {
-	return &SecuritySchemeBuilder{
-		spec: NewRefOrExtSpec[SecurityScheme](&SecurityScheme{}),
-	}
-}
-
-func (b *SecuritySchemeBuilder) Build() *RefOrSpec[Extendable[SecurityScheme]] {
-	return b.spec
-}
-
-func (b *SecuritySchemeBuilder) Extensions(v map[string]any) *SecuritySchemeBuilder {
-	b.spec.Spec.Extensions = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) AddExt(name string, value any) *SecuritySchemeBuilder {
-	b.spec.Spec.AddExt(name, value)
-	return b
-}
-
-func (b *SecuritySchemeBuilder) Type(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.Type = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) Description(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.Description = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) Name(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.Name = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) In(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.In = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) Scheme(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.Scheme = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) BearerFormat(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.BearerFormat = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) Flows(v *Extendable[OAuthFlows]) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.Flows = v
-	return b
-}
-
-func (b *SecuritySchemeBuilder) OpenIDConnectURL(v string) *SecuritySchemeBuilder {
-	b.spec.Spec.Spec.OpenIDConnectURL = v
-	return b
-}
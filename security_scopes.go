@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// ScopeCatalog holds the sorted lists of the scopes by the names of the security schemes.
+type ScopeCatalog map[string][]string
+
+func (c ScopeCatalog) add(scheme string, scopes ...string) {
+	for _, scope := range scopes {
+		if i, found := slices.BinarySearch(c[scheme], scope); !found {
+			c[scheme] = slices.Insert(c[scheme], i, scope)
+		}
+	}
+}
+
+// Has reports whether the scope of the given scheme is in the catalog.
+func (c ScopeCatalog) Has(scheme, scope string) bool {
+	_, found := slices.BinarySearch(c[scheme], scope)
+	return found
+}
+
+// UsedScopes returns the scopes used by the security requirements of the document and all operations.
+func UsedScopes(spec *Extendable[OpenAPI]) ScopeCatalog {
+	catalog := make(ScopeCatalog)
+	forEachSecurityRequirement(spec, func(_ string, req SecurityRequirement) {
+		for scheme, scopes := range req {
+			catalog.add(scheme, scopes...)
+		}
+	})
+	return catalog
+}
+
+// DeclaredScopes returns the scopes declared in the flows of the OAuth2 security schemes of the components.
+// The schemes of other types do not declare the scopes, so they are not included.
+func DeclaredScopes(spec *Extendable[OpenAPI]) ScopeCatalog {
+	catalog := make(ScopeCatalog)
+	for name, flows := range oauthFlows(spec) {
+		catalog[name] = []string{}
+		for _, flow := range flows {
+			catalog.add(name, sortedKeys(flow.Spec.Scopes)...)
+		}
+	}
+	return catalog
+}
+
+// CheckScopes compares the scopes used by the security requirements with the scopes declared in the OAuth2 flows.
+// An error wrapping ErrUnresolvedRef is reported for each used but undeclared scope,
+// and an error wrapping ErrUnused is reported for each declared scope that is never used.
+// The schemes of other types than OAuth2 are ignored.
+func CheckScopes(spec *Extendable[OpenAPI]) error {
+	declared := DeclaredScopes(spec)
+	used := make(ScopeCatalog)
+	var errs []error
+	forEachSecurityRequirement(spec, func(location string, req SecurityRequirement) {
+		for _, scheme := range sortedKeys(req) {
+			if _, ok := declared[scheme]; !ok {
+				continue
+			}
+			for i, scope := range req[scheme] {
+				used.add(scheme, scope)
+				if !declared.Has(scheme, scope) {
+					errs = append(errs, newValidationError(joinLoc(location, scheme, i), "%w: scope '%s' is not declared in the flows of '%s'", ErrUnresolvedRef, scope, scheme))
+				}
+			}
+		}
+	})
+	allFlows := oauthFlows(spec)
+	for _, name := range sortedKeys(declared) {
+		flows := allFlows[name]
+		for _, flowName := range sortedKeys(flows) {
+			for _, scope := range sortedKeys(flows[flowName].Spec.Scopes) {
+				if !used.Has(name, scope) {
+					errs = append(errs, newValidationError(joinLoc("/components/securitySchemes", name, "flows", flowName, "scopes", scope), "%w scope", ErrUnused))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// oauthFlows returns the defined flows of the OAuth2 security schemes by the names of the schemes and the flows.
+func oauthFlows(spec *Extendable[OpenAPI]) map[string]map[string]*Extendable[OAuthFlow] {
+	result := make(map[string]map[string]*Extendable[OAuthFlow])
+	if spec == nil || spec.Spec == nil || spec.Spec.Components == nil || spec.Spec.Components.Spec == nil {
+		return result
+	}
+	for name, ref := range spec.Spec.Components.Spec.SecuritySchemes {
+		scheme, err := ref.GetSpec(spec.Spec.Components)
+		if err != nil || scheme.Spec == nil || scheme.Spec.Type != TypeOAuth2 || scheme.Spec.Flows == nil || scheme.Spec.Flows.Spec == nil {
+			continue
+		}
+		flows := make(map[string]*Extendable[OAuthFlow], 4)
+		for flowName, flow := range map[string]*Extendable[OAuthFlow]{
+			"implicit":          scheme.Spec.Flows.Spec.Implicit,
+			"password":          scheme.Spec.Flows.Spec.Password,
+			"clientCredentials": scheme.Spec.Flows.Spec.ClientCredentials,
+			"authorizationCode": scheme.Spec.Flows.Spec.AuthorizationCode,
+		} {
+			if flow != nil && flow.Spec != nil {
+				flows[flowName] = flow
+			}
+		}
+		result[name] = flows
+	}
+	return result
+}
+
+// forEachSecurityRequirement calls the function for each security requirement of the document and all operations
+// of the paths and webhooks with the location of the requirement.
+func forEachSecurityRequirement(spec *Extendable[OpenAPI], f func(location string, req SecurityRequirement)) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	for i, req := range spec.Spec.Security {
+		f(joinLoc("/security", i), req)
+	}
+	forEachOperation(spec, func(location string, _ string, op *Extendable[Operation]) {
+		for i, req := range op.Spec.Security {
+			f(joinLoc(location, "security", i), req)
+		}
+	})
+}
+
+// forEachOperation calls the function for each operation of the paths and webhooks in the sorted order
+// with the location of the operation and its method.
+func forEachOperation(spec *Extendable[OpenAPI], f func(location, method string, op *Extendable[Operation])) {
+	if spec == nil || spec.Spec == nil {
+		return
+	}
+	visit := func(location string, items map[string]*RefOrSpec[Extendable[PathItem]]) {
+		for _, path := range sortedKeys(items) {
+			item := items[path]
+			if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+				continue
+			}
+			for _, method := range pathItemMethods {
+				if op := item.Spec.Spec.operation(method); op != nil && op.Spec != nil {
+					f(joinLoc(location, path, strings.ToLower(method)), method, op)
+				}
+			}
+		}
+	}
+	if spec.Spec.Paths != nil && spec.Spec.Paths.Spec != nil {
+		visit("/paths", spec.Spec.Paths.Spec.Paths)
+	}
+	visit("/webhooks", spec.Spec.WebHooks)
+}
+
+// sortedKeys returns the sorted keys of the given map.
+func sortedKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
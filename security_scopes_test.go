@@ -0,0 +1,77 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testScopesSpec = `
+openapi: 3.1.1
+info:
+  title: Scopes
+  version: 1.0.0
+security:
+  - oauth: [read]
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+    post:
+      security:
+        - oauth: [write, admin]
+          apiKey: [any]
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/auth
+          scopes:
+            read: Read access
+            write: Write access
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            read: Read access
+            delete: Delete access
+`
+
+func TestScopes(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testScopesSpec), &spec))
+
+	require.Equal(t, openapi.ScopeCatalog{
+		"oauth":  {"admin", "read", "write"},
+		"apiKey": {"any"},
+	}, openapi.UsedScopes(spec))
+
+	declared := openapi.DeclaredScopes(spec)
+	require.Equal(t, openapi.ScopeCatalog{"oauth": {"delete", "read", "write"}}, declared)
+	require.True(t, declared.Has("oauth", "read"))
+	require.False(t, declared.Has("oauth", "admin"))
+
+	err := openapi.CheckScopes(spec)
+	require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+	require.ErrorIs(t, err, openapi.ErrUnused)
+	require.EqualError(t, err, "/paths/~1pets/post/security/0/oauth/1: unresolved reference: scope 'admin' is not declared in the flows of 'oauth'\n"+
+		"/components/securitySchemes/oauth/flows/clientCredentials/scopes/delete: unused scope")
+
+	t.Run("consistent", func(t *testing.T) {
+		spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec.Post.Spec.Security = []openapi.SecurityRequirement{{"oauth": {"write", "delete"}}}
+		require.NoError(t, openapi.CheckScopes(spec))
+	})
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is a position in the source of a spec, the line and the column start with 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// String implements fmt.Stringer interface.
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// SourceMap maps the locations of the objects of a spec to their positions in the YAML or JSON source.
+type SourceMap struct {
+	positions map[string]Position
+}
+
+// NewSourceMap parses the given YAML or JSON source of a spec and creates its source map.
+func NewSourceMap(data []byte) (*SourceMap, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w of source: %w", ErrInvalidFormat, err)
+	}
+	m := &SourceMap{positions: make(map[string]Position)}
+	if len(doc.Content) > 0 {
+		m.add("", doc.Content[0], doc.Content[0], 0)
+	}
+	return m, nil
+}
+
+// maxSourceMapDepth limits the depth of the walk, so the recursive aliases do not hang it.
+const maxSourceMapDepth = 1000
+
+// add records the position of the object with the given location, the position of a property is the one of its key.
+func (m *SourceMap) add(location string, pos, node *yaml.Node, depth int) {
+	if depth > maxSourceMapDepth {
+		return
+	}
+	if _, ok := m.positions[location]; !ok {
+		m.positions[location] = Position{Line: pos.Line, Column: pos.Column}
+	}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			m.add(joinLoc(location, key.Value), key, value, depth+1)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			m.add(joinLoc(location, i), item, item, depth+1)
+		}
+	}
+}
+
+// Position returns the position of the object with the given location,
+// or the position of its closest parent if the object is not in the source, e.g. a missing required property.
+// The locations of the objects loaded by a reference, like `#/components/schemas/Pet/type`, are supported.
+// The ok is false if the source map is nil or empty.
+func (m *SourceMap) Position(location Location) (pos Position, ok bool) {
+	if m == nil {
+		return Position{}, false
+	}
+	l := Location(location.JSONPointer())
+	for {
+		if pos, ok = m.positions[string(l)]; ok || l == "" {
+			return pos, ok
+		}
+		l = l.Parent()
+	}
+}
@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SplitByTags produces one sub-document per tag of the given spec, e.g. for per-team documentation.
+//
+// A sub-document contains the operations having the tag, the path items without such operations are removed.
+// The components are pruned to the ones used by the remaining operations, directly or transitively,
+// so the shared components are duplicated in every sub-document using them and each sub-document is self-contained.
+// The tags list of a sub-document contains the tags of the remaining operations only;
+// the operations without tags are not included anywhere.
+// The given spec is not modified.
+func SplitByTags(spec *Extendable[OpenAPI]) (map[string]*Extendable[OpenAPI], error) {
+	if spec == nil || spec.Spec == nil {
+		return nil, nil
+	}
+	tags := collectOperationTags(spec)
+	docs := make(map[string]*Extendable[OpenAPI], len(tags))
+	for _, tag := range tags {
+		doc := copySpec(spec)
+		hasTag := func(op *Extendable[Operation]) bool {
+			return op != nil && op.Spec != nil && slices.Contains(op.Spec.Tags, tag)
+		}
+		if doc.Spec.Paths != nil && doc.Spec.Paths.Spec != nil {
+			filterPathItems(doc.Spec.Paths.Spec.Paths, hasTag)
+		}
+		filterPathItems(doc.Spec.WebHooks, hasTag)
+		used := collectOperationTags(doc)
+		doc.Spec.Tags = slices.DeleteFunc(doc.Spec.Tags, func(t *Extendable[Tag]) bool {
+			if t == nil || t.Spec == nil {
+				return true
+			}
+			_, found := slices.BinarySearch(used, t.Spec.Name)
+			return !found
+		})
+		if len(doc.Spec.Tags) == 0 {
+			doc.Spec.Tags = nil
+		}
+		pruneComponents(doc)
+		docs[tag] = doc
+	}
+	return docs, nil
+}
+
+// collectOperationTags returns the sorted list of the tags used by the operations of the paths and webhooks.
+func collectOperationTags(spec *Extendable[OpenAPI]) []string {
+	var tags []string
+	collect := func(items map[string]*RefOrSpec[Extendable[PathItem]]) {
+		for _, item := range items {
+			if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+				continue
+			}
+			for _, method := range pathItemMethods {
+				if op := item.Spec.Spec.operation(method); op != nil && op.Spec != nil {
+					tags = append(tags, op.Spec.Tags...)
+				}
+			}
+		}
+	}
+	if spec.Spec.Paths != nil && spec.Spec.Paths.Spec != nil {
+		collect(spec.Spec.Paths.Spec.Paths)
+	}
+	collect(spec.Spec.WebHooks)
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// filterPathItems removes the operations not matching the keep function and the path items without operations.
+// The referenced path items are removed, since the operations behind them can not be filtered.
+func filterPathItems(items map[string]*RefOrSpec[Extendable[PathItem]], keep func(*Extendable[Operation]) bool) {
+	for path, item := range items {
+		if item == nil || item.Spec == nil || item.Spec.Spec == nil {
+			delete(items, path)
+			continue
+		}
+		var found bool
+		for _, method := range pathItemMethods {
+			op := item.Spec.Spec.operation(method)
+			if op == nil {
+				continue
+			}
+			if keep(op) {
+				found = true
+			} else {
+				item.Spec.Spec.setOperation(method, nil)
+			}
+		}
+		if !found {
+			delete(items, path)
+		}
+	}
+}
+
+// pruneComponents removes the components not used by the rest of the given spec, directly or transitively.
+func pruneComponents(spec *Extendable[OpenAPI]) {
+	if spec.Spec.Components == nil || spec.Spec.Components.Spec == nil {
+		return
+	}
+	c := spec.Spec.Components
+	fields := componentMaps(c.Spec)
+
+	used := make(map[string]map[string]bool, len(fields))
+	var queue []reflect.Value
+	use := func(typ, name string) {
+		f, ok := fields[typ]
+		if !ok || used[typ][name] {
+			return
+		}
+		v := f.MapIndex(reflect.ValueOf(name))
+		if !v.IsValid() {
+			return
+		}
+		if used[typ] == nil {
+			used[typ] = make(map[string]bool)
+		}
+		used[typ][name] = true
+		queue = append(queue, v)
+	}
+	visited := make(map[uintptr]map[reflect.Type]bool)
+	visit := componentRefsVisitor(c, use)
+
+	// walk everything except the components, then the used components until no new ones are found
+	spec.Spec.Components = nil
+	walkSpecValue(reflect.ValueOf(spec), visit, visited)
+	spec.Spec.Components = c
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		walkSpecValue(v, visit, visited)
+	}
+
+	var hasComponents bool
+	for typ, f := range fields {
+		for _, k := range f.MapKeys() {
+			if !used[typ][k.String()] {
+				f.SetMapIndex(k, reflect.Value{})
+			}
+		}
+		if f.Len() == 0 {
+			f.Set(reflect.Zero(f.Type()))
+		} else {
+			hasComponents = true
+		}
+	}
+	if !hasComponents && len(c.Extensions) == 0 {
+		spec.Spec.Components = nil
+	}
+}
+
+// componentRefsVisitor returns the visitor for walkSpec calling the use function
+// for each component referenced by the visited objects: by `$ref`, `$dynamicRef`, discriminator mapping,
+// or security requirement.
+func componentRefsVisitor(c *Extendable[Components], use func(typ, name string)) func(reflect.Value) {
+	useRef := func(ref string) {
+		if rest, ok := strings.CutPrefix(ref, componentsRefPrefix); ok {
+			typ, rest, _ := strings.Cut(rest, "/")
+			name, _, _ := strings.Cut(rest, "/")
+			use(typ, jsonPointerUnescaper.Replace(name))
+		}
+	}
+	return func(v reflect.Value) {
+		switch t := v.Interface().(type) {
+		case *Ref:
+			useRef(t.Ref)
+		case *Schema:
+			useRef(t.Ref)
+			useRef(t.DynamicRef)
+			if name, ok := strings.CutPrefix(t.DynamicRef, "#"); ok && !strings.HasPrefix(name, "/") {
+				if component, _ := findDynamicAnchor(c, name); component != "" {
+					use("schemas", component)
+				}
+			}
+		case *Discriminator:
+			for _, ref := range t.Mapping {
+				if !strings.Contains(ref, "/") {
+					use("schemas", ref)
+				} else {
+					useRef(ref)
+				}
+			}
+		case SecurityRequirement:
+			for name := range t {
+				use("securitySchemes", name)
+			}
+		}
+	}
+}
+
+// componentMaps returns the settable maps of the components by their types, e.g. `schemas`.
+func componentMaps(c *Components) map[string]reflect.Value {
+	components := reflect.ValueOf(c).Elem()
+	fields := make(map[string]reflect.Value, components.NumField())
+	for i := 0; i < components.NumField(); i++ {
+		if f := components.Field(i); f.Kind() == reflect.Map {
+			typ, _, _ := strings.Cut(components.Type().Field(i).Tag.Get("json"), ",")
+			fields[typ] = f
+		}
+	}
+	return fields
+}
@@ -0,0 +1,122 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testSplitSpec = `
+openapi: 3.1.1
+info:
+  title: Shop
+  version: 1.0.0
+tags:
+  - name: orders
+  - name: users
+paths:
+  /orders:
+    get:
+      tags: [orders]
+      security:
+        - token: []
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Order'
+    post:
+      tags: [orders, users]
+      responses:
+        '201':
+          $ref: '#/components/responses/Created'
+  /users:
+    get:
+      tags: [users]
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /health:
+    get:
+      responses:
+        '200':
+          description: OK
+components:
+  responses:
+    Created:
+      description: Created
+  schemas:
+    Order:
+      type: object
+      properties:
+        user:
+          $ref: '#/components/schemas/User'
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+    Unused:
+      type: string
+  securitySchemes:
+    token:
+      type: http
+      scheme: bearer
+`
+
+func TestSplitByTags(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testSplitSpec), &spec))
+
+	docs, err := openapi.SplitByTags(spec)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	orders := docs["orders"]
+	require.NotNil(t, orders)
+	require.Len(t, orders.Spec.Paths.Spec.Paths, 1)
+	item := orders.Spec.Paths.Spec.Paths["/orders"].Spec.Spec
+	require.NotNil(t, item.Get)
+	require.NotNil(t, item.Post)
+	require.Len(t, orders.Spec.Tags, 2)
+	components := orders.Spec.Components.Spec
+	require.Len(t, components.Schemas, 2)
+	require.Contains(t, components.Schemas, "Order")
+	require.Contains(t, components.Schemas, "User")
+	require.Contains(t, components.Responses, "Created")
+	require.Contains(t, components.SecuritySchemes, "token")
+
+	users := docs["users"]
+	require.NotNil(t, users)
+	require.Len(t, users.Spec.Paths.Spec.Paths, 2)
+	require.Nil(t, users.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Get)
+	require.NotNil(t, users.Spec.Paths.Spec.Paths["/orders"].Spec.Spec.Post)
+	components = users.Spec.Components.Spec
+	require.Len(t, components.Schemas, 1)
+	require.Contains(t, components.Schemas, "User")
+	require.Nil(t, components.SecuritySchemes)
+	require.Len(t, users.Spec.Tags, 2)
+
+	for tag, doc := range docs {
+		t.Run(tag, func(t *testing.T) {
+			validator, err := openapi.NewValidator(doc)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateSpec())
+		})
+	}
+
+	// the source spec is not modified
+	require.Len(t, spec.Spec.Paths.Spec.Paths, 3)
+	require.Len(t, spec.Spec.Components.Spec.Schemas, 3)
+}
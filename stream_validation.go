@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ValidateJSONStream validates the JSON document read from the given reader against the schema
+// located at the given location without materializing the entire value when possible.
+//
+// If the document is a top-level array and the schema constrains it only by `type`, `items`, `prefixItems`,
+// `minItems`, and `maxItems` keywords, the elements are parsed and validated one by one,
+// so the memory usage is bounded by the size of the largest element.
+// Otherwise, the document is parsed completely and validated using ValidateData.
+// The MaxJSONDepth and MaxJSONElements options are applied to the whole document in both cases,
+// the streamed elements are checked before being parsed.
+//
+// The location should be in form of JSON Pointer.
+func (v *Validator) ValidateJSONStream(location string, r io.Reader) error {
+	schema, err := v.compileSchema(location)
+	if err != nil {
+		return err
+	}
+	br := bufio.NewReader(r)
+	if c, err := peekNonSpace(br); err != nil || c != '[' || !isStreamableArraySchema(schema) {
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return fmt.Errorf("reading JSON failed: %w", err)
+		}
+		if err := v.checkJSONLimits(data); err != nil {
+			return newValidationError("", err)
+		}
+		value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("parsing JSON failed: %w", err)
+		}
+		if err := schema.Validate(value); err != nil {
+			return v.dataError(err)
+		}
+		return nil
+	}
+
+	dec := json.NewDecoder(br)
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("parsing JSON failed: %w", err)
+	}
+	limiter := v.newJSONLimiter()
+	// the top-level array itself
+	if err := limiter.check([]byte("[]"), 0); err != nil {
+		return newValidationError("", err)
+	}
+	var (
+		errs []error
+		n    int
+	)
+	for ; dec.More(); n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("parsing JSON failed: %w", err)
+		}
+		if err := limiter.check(raw, 1); err != nil {
+			return newValidationError(joinLoc("", n), err)
+		}
+		item, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+		if err != nil {
+			return fmt.Errorf("parsing JSON failed: %w", err)
+		}
+		for s := schema; s != nil; s = s.Ref {
+			itemSchema := s.Items2020
+			if n < len(s.PrefixItems) {
+				itemSchema = s.PrefixItems[n]
+			}
+			if itemSchema == nil {
+				continue
+			}
+			if err := itemSchema.Validate(item); err != nil {
+				errs = append(errs, newValidationError(joinLoc("", n), "%w: %w", ErrInvalidData, v.dataError(err)))
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("parsing JSON failed: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("parsing JSON failed: unexpected data after top-level value")
+	}
+
+	for s := schema; s != nil; s = s.Ref {
+		if s.Types != nil && !s.Types.IsEmpty() && !slices.Contains(s.Types.ToStrings(), "array") {
+			errs = append(errs, newValidationError("", "%w: got array, want %s", ErrInvalidData, s.Types))
+		}
+		if s.MinItems != nil && n < *s.MinItems {
+			errs = append(errs, newValidationError("", "%w: minItems: got %d, want %d", ErrOutOfRange, n, *s.MinItems))
+		}
+		if s.MaxItems != nil && n > *s.MaxItems {
+			errs = append(errs, newValidationError("", "%w: maxItems: got %d, want %d", ErrOutOfRange, n, *s.MaxItems))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// peekNonSpace skips the leading whitespaces and returns the first significant byte without consuming it.
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = r.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// isStreamableArraySchema checks if the schema and the schemas referenced by it
+// constrain an array only by the keywords that can be checked element by element.
+func isStreamableArraySchema(s *jsonschema.Schema) bool {
+	for ; s != nil; s = s.Ref {
+		if s.Bool != nil || s.RecursiveRef != nil || s.DynamicRef != nil ||
+			s.Enum != nil || s.Const != nil || s.Not != nil || s.If != nil ||
+			len(s.AllOf) > 0 || len(s.AnyOf) > 0 || len(s.OneOf) > 0 ||
+			s.UniqueItems || s.Contains != nil || s.UnevaluatedItems != nil ||
+			s.Items != nil || s.AdditionalItems != nil || len(s.Extensions) > 0 {
+			return false
+		}
+	}
+	return true
+}
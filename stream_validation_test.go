@@ -0,0 +1,167 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testStreamSpec = `
+openapi: 3.1.1
+info:
+  title: Stream
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+      required: [name]
+    Pets:
+      type: array
+      maxItems: 3
+      items:
+        $ref: '#/components/schemas/Pet'
+    PetsRef:
+      $ref: '#/components/schemas/Pets'
+    UniquePets:
+      type: array
+      uniqueItems: true
+      items:
+        $ref: '#/components/schemas/Pet'
+`
+
+func TestValidator_ValidateJSONStream(t *testing.T) {
+	validator := newTestRequestValidator(t, testStreamSpec)
+
+	for _, tt := range []struct {
+		name     string
+		location string
+		data     string
+		err      string
+	}{
+		{
+			name:     "array",
+			location: "/components/schemas/Pets",
+			data:     ` [{"name": "Rex"}, {"name": "Tom"}]`,
+		},
+		{
+			name:     "empty array",
+			location: "/components/schemas/Pets",
+			data:     `[]`,
+		},
+		{
+			name:     "invalid element",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "Rex"}, {"tag": "cat"}]`,
+			err:      "/1: ",
+		},
+		{
+			name:     "too many elements",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "d"}]`,
+			err:      "maxItems: got 4, want 3",
+		},
+		{
+			name:     "referenced schema",
+			location: "/components/schemas/PetsRef",
+			data:     `[{"name": "Rex"}, {"tag": "cat"}]`,
+			err:      "/1: ",
+		},
+		{
+			name:     "not an array",
+			location: "/components/schemas/Pets",
+			data:     `{"name": "Rex"}`,
+			err:      "got object, want array",
+		},
+		{
+			name:     "not streamable schema",
+			location: "/components/schemas/UniquePets",
+			data:     `[{"name": "Rex"}, {"name": "Rex"}]`,
+			err:      "items at 0 and 1 are equal",
+		},
+		{
+			name:     "object",
+			location: "/components/schemas/Pet",
+			data:     `{"name": "Rex"}`,
+		},
+		{
+			name:     "broken array",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "Rex"},`,
+			err:      "parsing JSON failed",
+		},
+		{
+			name:     "trailing data",
+			location: "/components/schemas/Pets",
+			data:     `[] []`,
+			err:      "unexpected data after top-level value",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateJSONStream(tt.location, strings.NewReader(tt.data))
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateJSONStream_Limits(t *testing.T) {
+	validator := newTestRequestValidator(t, testStreamSpec)
+	validator, err := validator.Clone(openapi.MaxJSONDepth(2), openapi.MaxJSONElements(7))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name     string
+		location string
+		data     string
+		err      error
+	}{
+		{
+			name:     "within limits",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "a"}, {"name": "b"}, {"name": "c"}]`,
+		},
+		{
+			name:     "streamed too deep",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "a", "tags": ["x"]}]`,
+			err:      openapi.ErrJSONTooDeep,
+		},
+		{
+			name:     "streamed too many elements",
+			location: "/components/schemas/Pets",
+			data:     `[{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "d"}]`,
+			err:      openapi.ErrJSONTooManyElements,
+		},
+		{
+			name:     "buffered too deep",
+			location: "/components/schemas/UniquePets",
+			data:     `[{"name": "a", "tags": ["x"]}]`,
+			err:      openapi.ErrJSONTooDeep,
+		},
+		{
+			name:     "buffered too many elements",
+			location: "/components/schemas/UniquePets",
+			data:     `[{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "d"}]`,
+			err:      openapi.ErrJSONTooManyElements,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateJSONStream(tt.location, strings.NewReader(tt.data))
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ItemSchemaExt is the name of the extension of a Media Type Object holding the schema of each item
+// of the sequential media types, e.g. the events of `text/event-stream` or the lines of `application/jsonl`,
+// like `itemSchema` field of OpenAPI v3.2.
+//
+// Example:
+//
+//	text/event-stream:
+//	  x-item-schema:
+//	    $ref: '#/components/schemas/Event'
+const ItemSchemaExt = "x-item-schema"
+
+// The sequential media types supported by ValidateStream.
+const (
+	EventStreamMediaType    = "text/event-stream"
+	JSONLinesMediaType      = "application/jsonl"
+	NDJSONMediaType         = "application/x-ndjson"
+	JSONSeqMediaType        = "application/json-seq"
+	MultipartMixedMediaType = "multipart/mixed"
+)
+
+// ItemSchema sets the schema of each item of a sequential media type, see ItemSchemaExt.
+func (b *MediaTypeBuilder) ItemSchema(v *RefOrSpec[Schema]) *MediaTypeBuilder {
+	b.spec.AddExt(ItemSchemaExt, v)
+	return b
+}
+
+// AddStreamContent adds the content of the given sequential media type, e.g. `text/event-stream`,
+// with the given schema of each item, see ItemSchemaExt.
+func (b *ResponseBuilder) AddStreamContent(mediaType string, itemSchema *RefOrSpec[Schema]) *ResponseBuilder {
+	return b.AddContent(mediaType, NewMediaTypeBuilder().ItemSchema(itemSchema).Build())
+}
+
+// ItemSchemaOf returns the schema of each item of the given media type.
+// The ok is false if the schema is not set, and an error is returned if the value is not a schema.
+func ItemSchemaOf(o *Extendable[MediaType]) (schema *RefOrSpec[Schema], ok bool, err error) {
+	if o == nil {
+		return nil, false, nil
+	}
+	v, ok := o.Extensions[ItemSchemaExt]
+	if !ok {
+		return nil, false, nil
+	}
+	schema, err = parseItemSchema(v)
+	return schema, true, err
+}
+
+func parseItemSchema(v any) (*RefOrSpec[Schema], error) {
+	if s, ok := v.(*RefOrSpec[Schema]); ok {
+		return s, nil
+	}
+	var schema *RefOrSpec[Schema]
+	if err := decodeExtension(v, &schema); err != nil {
+		return nil, fmt.Errorf("%w of %s, expected a schema: %w", ErrInvalidFormat, ItemSchemaExt, err)
+	}
+	if schema == nil {
+		return nil, fmt.Errorf("%w of %s, expected a schema, but got null", ErrInvalidFormat, ItemSchemaExt)
+	}
+	return schema, nil
+}
+
+// ValidateStream validates each item of the sequential content read from the given reader
+// against the item schema (see ItemSchemaExt) of the media type located at the given location,
+// e.g. `/paths/~1events/get/responses/200/content/text~1event-stream`.
+//
+// The content type selects the format of the stream:
+//   - `text/event-stream`: the data of each event, the data lines are joined by a new line;
+//   - `application/jsonl` and `application/x-ndjson`: each non-empty line;
+//   - `application/json-seq`: each record separated by the RS character;
+//   - `multipart/*`: the body of each part, the boundary is taken from the content type.
+//
+// An item is validated as JSON value if it can be parsed as JSON, otherwise as a string.
+// The locations of the errors are the indexes of the items.
+func (v *Validator) ValidateStream(location string, contentType string, r io.Reader) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w of content type %q: %w", ErrInvalidFormat, contentType, err)
+	}
+	schema, err := v.compileSchema(joinLoc(location, ItemSchemaExt))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	n := 0
+	validate := func(data []byte) {
+		if err := schema.Validate(streamItemValue(data)); err != nil {
+			errs = append(errs, newValidationError(joinLoc("", n), "%w: %w", ErrInvalidData, v.dataError(err)))
+		}
+		n++
+	}
+	switch {
+	case mediaType == EventStreamMediaType:
+		err = readEventStream(r, validate)
+	case mediaType == JSONLinesMediaType || mediaType == NDJSONMediaType:
+		err = readSeparated(r, '\n', validate)
+	case mediaType == JSONSeqMediaType:
+		err = readSeparated(r, 0x1e, validate)
+	case strings.HasPrefix(mediaType, "multipart/"):
+		err = readMultipart(r, params["boundary"], validate)
+	default:
+		return fmt.Errorf("%w: %q is not a sequential media type", ErrInvalidValue, mediaType)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s stream failed: %w", mediaType, err)
+	}
+	return errors.Join(errs...)
+}
+
+// streamItemValue parses the item as JSON, the invalid JSON is returned as a string.
+func streamItemValue(data []byte) any {
+	value, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return string(data)
+	}
+	return value
+}
+
+// readEventStream calls the given function with the data of each event of the Server-Sent Events stream.
+//
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+func readEventStream(r io.Reader, event func([]byte)) error {
+	scanner := bufio.NewScanner(r)
+	var (
+		data    []byte
+		hasData bool
+	)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			if hasData {
+				event(data)
+			}
+			data, hasData = nil, false
+			continue
+		}
+		name, value, _ := strings.Cut(line, ":")
+		if name != "data" {
+			// the comments and other fields
+			continue
+		}
+		if hasData {
+			data = append(data, '\n')
+		}
+		data = append(data, strings.TrimPrefix(value, " ")...)
+		hasData = true
+	}
+	// the incomplete event at the end of the stream is discarded
+	return scanner.Err()
+}
+
+// readSeparated calls the given function with each non-blank record separated by the given character.
+func readSeparated(r io.Reader, sep byte, record func([]byte)) error {
+	br := bufio.NewReader(r)
+	for {
+		data, err := br.ReadBytes(sep)
+		if data = bytes.TrimSpace(bytes.TrimSuffix(data, []byte{sep})); len(data) > 0 {
+			record(data)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readMultipart calls the given function with the body of each part.
+func readMultipart(r io.Reader, boundary string, part func([]byte)) error {
+	if boundary == "" {
+		return fmt.Errorf("%w: boundary", ErrRequired)
+	}
+	mr := multipart.NewReader(r, boundary)
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(p)
+		if err != nil {
+			return err
+		}
+		part(data)
+	}
+}
@@ -0,0 +1,195 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testStreamingSpec = `
+openapi: 3.1.1
+info:
+  title: Streaming
+  version: 1.0.0
+paths:
+  /events:
+    get:
+      responses:
+        '200':
+          description: events
+          content:
+            text/event-stream:
+              x-item-schema:
+                $ref: '#/components/schemas/Event'
+            application/jsonl:
+              x-item-schema:
+                $ref: '#/components/schemas/Event'
+            application/json-seq:
+              x-item-schema:
+                $ref: '#/components/schemas/Event'
+            multipart/mixed:
+              x-item-schema:
+                $ref: '#/components/schemas/Event'
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        id:
+          type: integer
+      required: [id]
+`
+
+func TestValidator_ValidateStream(t *testing.T) {
+	validator := newTestRequestValidator(t, testStreamingSpec)
+	const content = "/paths/~1events/get/responses/200/content/"
+
+	for _, tt := range []struct {
+		name        string
+		location    string
+		contentType string
+		data        string
+		err         string
+	}{
+		{
+			name:        "event stream",
+			location:    content + "text~1event-stream",
+			contentType: "text/event-stream",
+			data:        ": comment\nevent: update\ndata: {\"id\": 1}\n\ndata: {\"id\":\ndata: 2}\n\n",
+		},
+		{
+			name:        "invalid event",
+			location:    content + "text~1event-stream",
+			contentType: "text/event-stream",
+			data:        "data: {\"id\": 1}\n\ndata: {\"name\": \"a\"}\n\n",
+			err:         "/1: invalid data",
+		},
+		{
+			name:        "not JSON event",
+			location:    content + "text~1event-stream",
+			contentType: "text/event-stream",
+			data:        "data: hello\r\n\r\n",
+			err:         "/0: invalid data",
+		},
+		{
+			name:        "incomplete event is discarded",
+			location:    content + "text~1event-stream",
+			contentType: "text/event-stream",
+			data:        "data: {\"id\": 1}\n\ndata: {}",
+		},
+		{
+			name:        "json lines",
+			location:    content + "application~1jsonl",
+			contentType: "application/jsonl",
+			data:        "{\"id\": 1}\n\n{\"id\": 2}",
+		},
+		{
+			name:        "invalid json line",
+			location:    content + "application~1jsonl",
+			contentType: "application/x-ndjson",
+			data:        "{\"id\": 1}\n{\"id\": \"2\"}\n",
+			err:         "/1: invalid data",
+		},
+		{
+			name:        "json seq",
+			location:    content + "application~1json-seq",
+			contentType: "application/json-seq",
+			data:        "\x1e{\"id\": 1}\n\x1e{}\n",
+			err:         "/1: invalid data",
+		},
+		{
+			name:        "multipart",
+			location:    content + "multipart~1mixed",
+			contentType: "multipart/mixed; boundary=b",
+			data: "--b\r\nContent-Type: application/json\r\n\r\n{\"id\": 1}\r\n" +
+				"--b\r\nContent-Type: application/json\r\n\r\n{\"id\": true}\r\n--b--\r\n",
+			err: "/1: invalid data",
+		},
+		{
+			name:        "multipart without boundary",
+			location:    content + "multipart~1mixed",
+			contentType: "multipart/mixed",
+			err:         "boundary",
+		},
+		{
+			name:        "not sequential media type",
+			location:    content + "text~1event-stream",
+			contentType: "application/json",
+			err:         "is not a sequential media type",
+		},
+		{
+			name:        "no item schema",
+			location:    "/paths/~1events/get/responses/200",
+			contentType: "text/event-stream",
+			err:         "x-item-schema",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateStream(tt.location, tt.contentType, strings.NewReader(tt.data))
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestItemSchema(t *testing.T) {
+	event := openapi.NewSchemaBuilder().Type(openapi.ObjectType).Build()
+	resp := openapi.NewResponseBuilder().
+		Description("events").
+		AddStreamContent(openapi.EventStreamMediaType, event).
+		Build()
+
+	schema, ok, err := openapi.ItemSchemaOf(resp.Spec.Spec.Content[openapi.EventStreamMediaType])
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, event, schema)
+
+	_, ok, err = openapi.ItemSchemaOf(openapi.NewMediaTypeBuilder().Build())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = openapi.ItemSchemaOf(openapi.NewMediaTypeBuilder().AddExt(openapi.ItemSchemaExt, 42).Build())
+	require.True(t, ok)
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+}
+
+func TestItemSchema_ValidateSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{
+			name:   "valid",
+			schema: "$ref: '#/components/schemas/Event'",
+		},
+		{
+			name:   "unresolved ref",
+			schema: "$ref: '#/components/schemas/Missing'",
+			err:    "x-item-schema",
+		},
+		{
+			name:   "not a schema",
+			schema: "42",
+			err:    "invalid format of x-item-schema",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := strings.Replace(testStreamingSpec, "x-item-schema:\n                $ref: '#/components/schemas/Event'",
+				"x-item-schema:\n                "+strings.ReplaceAll(tt.schema, "\n", "\n                "), 1)
+			validator := newTestRequestValidator(t, spec)
+			err := validator.ValidateSpec()
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrIncompatible is wrapped by the errors reporting that a schema or an operation is not compatible with another one.
+var ErrIncompatible = errors.New("incompatible")
+
+// CheckSubschema checks that every value valid against the sub schema is valid against the super schema,
+// so the values produced for the sub schema are accepted by the consumers of the super schema.
+// The references of both schemas are resolved using the given components.
+//
+// The check is structural and conservative: the types, enums, constants, required and declared properties,
+// items, formats, patterns, and numeric, length, and size limits are compared,
+// so an error wrapping ErrIncompatible is returned for a constraint that cannot be proven to be satisfied.
+// Only the properties declared by both schemas are compared, and the properties not declared by the super schema
+// are rejected only if its additionalProperties is false.
+func CheckSubschema(sub, super *RefOrSpec[Schema], components *Extendable[Components]) error {
+	c := newSubschemaChecker(components, components)
+	c.check("", sub, super)
+	return errors.Join(c.errs...)
+}
+
+type subschemaChecker struct {
+	subComponents   *Extendable[Components]
+	superComponents *Extendable[Components]
+	visited         map[[2]*Schema]bool
+	errs            []error
+}
+
+func newSubschemaChecker(subComponents, superComponents *Extendable[Components]) *subschemaChecker {
+	return &subschemaChecker{
+		subComponents:   subComponents,
+		superComponents: superComponents,
+		visited:         make(map[[2]*Schema]bool),
+	}
+}
+
+func (c *subschemaChecker) report(location string, format string, args ...any) {
+	c.errs = append(c.errs, newValidationError(location, "%w: "+format, append([]any{ErrIncompatible}, args...)...))
+}
+
+func (c *subschemaChecker) check(location string, subRef, superRef *RefOrSpec[Schema]) {
+	if superRef == nil {
+		return
+	}
+	super, err := superRef.GetSpec(c.superComponents)
+	if err != nil {
+		c.errs = append(c.errs, newValidationError(location, err))
+		return
+	}
+	if subRef == nil {
+		subRef = NewSchemaBuilder().Build()
+	}
+	sub, err := subRef.GetSpec(c.subComponents)
+	if err != nil {
+		c.errs = append(c.errs, newValidationError(location, err))
+		return
+	}
+	key := [2]*Schema{sub, super}
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+
+	switch {
+	case sub.Bool != nil && !*sub.Bool, super.Bool != nil && *super.Bool:
+		// no value is valid against the sub schema or any value is valid against the super schema
+		return
+	case super.Bool != nil:
+		c.report(location, "no value is allowed")
+		return
+	}
+
+	// the compositions are checked first, since they can make the rest of the checks redundant
+	switch {
+	case len(sub.AnyOf) > 0 || len(sub.OneOf) > 0:
+		for i, s := range sub.AnyOf {
+			c.check(joinLoc(location, "anyOf", i), s, superRef)
+		}
+		for i, s := range sub.OneOf {
+			c.check(joinLoc(location, "oneOf", i), s, superRef)
+		}
+		return
+	case len(super.AnyOf) > 0 || len(super.OneOf) > 0:
+		for _, s := range append(slices.Clip(super.AnyOf), super.OneOf...) {
+			branch := newSubschemaChecker(c.subComponents, c.superComponents)
+			branch.check(location, subRef, s)
+			if len(branch.errs) == 0 {
+				return
+			}
+		}
+		c.report(location, "does not match any of the alternatives")
+		return
+	}
+	// the sibling keywords of $ref are applied along with the referenced schema, like allOf
+	for _, s := range schemaAllOf(super) {
+		c.check(location, subRef, s)
+	}
+	if subAllOf := schemaAllOf(sub); len(subAllOf) > 0 {
+		// the value valid against all schemas is valid against any of them
+		for _, s := range subAllOf {
+			branch := newSubschemaChecker(c.subComponents, c.superComponents)
+			branch.check(location, s, superRef)
+			if len(branch.errs) == 0 {
+				return
+			}
+		}
+	}
+
+	c.checkTypes(location, sub, super)
+	c.checkValues(location, sub, super)
+	c.checkLimits(location, sub, super)
+	if super.Items != nil && super.Items.Schema != nil {
+		var items *RefOrSpec[Schema]
+		if sub.Items != nil {
+			items = sub.Items.Schema
+		}
+		c.check(joinLoc(location, "items"), items, super.Items.Schema)
+	}
+	c.checkProperties(location, sub, super)
+}
+
+// schemaAllOf returns the allOf schemas including the schema referenced by $ref keyword.
+func schemaAllOf(s *Schema) []*RefOrSpec[Schema] {
+	if s.Ref == "" {
+		return s.AllOf
+	}
+	return append([]*RefOrSpec[Schema]{NewRefOrSpec[Schema](s.Ref)}, s.AllOf...)
+}
+
+func schemaTypes(s *Schema) []string {
+	if s.Type == nil {
+		return nil
+	}
+	return *s.Type
+}
+
+func (c *subschemaChecker) checkTypes(location string, sub, super *Schema) {
+	superTypes := schemaTypes(super)
+	if len(superTypes) == 0 {
+		return
+	}
+	subTypes := schemaTypes(sub)
+	if len(subTypes) == 0 {
+		c.report(joinLoc(location, "type"), "any type is allowed, but expected %v", superTypes)
+		return
+	}
+	for _, t := range subTypes {
+		if slices.Contains(superTypes, t) || (t == IntegerType && slices.Contains(superTypes, NumberType)) {
+			continue
+		}
+		c.report(joinLoc(location, "type"), "type '%s' is not one of %v", t, superTypes)
+	}
+}
+
+func jsonValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func (c *subschemaChecker) checkValues(location string, sub, super *Schema) {
+	if super.Const != nil && (sub.Const == nil || jsonValue(sub.Const) != jsonValue(super.Const)) {
+		c.report(joinLoc(location, "const"), "expected constant %s", jsonValue(super.Const))
+	}
+	if len(super.Enum) == 0 {
+		return
+	}
+	allowed := make(map[string]bool, len(super.Enum))
+	for _, v := range super.Enum {
+		allowed[jsonValue(v)] = true
+	}
+	switch {
+	case sub.Const != nil:
+		if !allowed[jsonValue(sub.Const)] {
+			c.report(joinLoc(location, "const"), "value %s is not one of the allowed values", jsonValue(sub.Const))
+		}
+	case len(sub.Enum) == 0:
+		c.report(joinLoc(location, "enum"), "any value is allowed, but expected one of %s", jsonValue(super.Enum))
+	default:
+		for i, v := range sub.Enum {
+			if !allowed[jsonValue(v)] {
+				c.report(joinLoc(location, "enum", i), "value %s is not one of the allowed values", jsonValue(v))
+			}
+		}
+	}
+}
+
+func (c *subschemaChecker) checkLimits(location string, sub, super *Schema) {
+	if super.Format != "" && sub.Format != super.Format {
+		c.report(joinLoc(location, "format"), "expected format '%s'", super.Format)
+	}
+	if super.Pattern != "" && sub.Pattern != super.Pattern {
+		c.report(joinLoc(location, "pattern"), "expected pattern '%s'", super.Pattern)
+	}
+	for _, limit := range []struct {
+		name       string
+		sub, super *Number
+		lower      bool
+	}{
+		{name: "minimum", sub: sub.Minimum, super: super.Minimum, lower: true},
+		{name: "exclusiveMinimum", sub: sub.ExclusiveMinimum, super: super.ExclusiveMinimum, lower: true},
+		{name: "maximum", sub: sub.Maximum, super: super.Maximum},
+		{name: "exclusiveMaximum", sub: sub.ExclusiveMaximum, super: super.ExclusiveMaximum},
+	} {
+		switch {
+		case limit.super == nil:
+		case limit.sub == nil:
+			c.report(joinLoc(location, limit.name), "no limit, but expected %s", *limit.super)
+		case limit.lower && limit.sub.Cmp(*limit.super) < 0:
+			c.report(joinLoc(location, limit.name), "%s is less than %s", *limit.sub, *limit.super)
+		case !limit.lower && limit.sub.Cmp(*limit.super) > 0:
+			c.report(joinLoc(location, limit.name), "%s is greater than %s", *limit.sub, *limit.super)
+		}
+	}
+	for _, limit := range []struct {
+		name       string
+		sub, super *int
+		lower      bool
+	}{
+		{name: "minLength", sub: sub.MinLength, super: super.MinLength, lower: true},
+		{name: "maxLength", sub: sub.MaxLength, super: super.MaxLength},
+		{name: "minItems", sub: sub.MinItems, super: super.MinItems, lower: true},
+		{name: "maxItems", sub: sub.MaxItems, super: super.MaxItems},
+		{name: "minProperties", sub: sub.MinProperties, super: super.MinProperties, lower: true},
+		{name: "maxProperties", sub: sub.MaxProperties, super: super.MaxProperties},
+	} {
+		switch {
+		case limit.super == nil:
+		case limit.sub == nil:
+			c.report(joinLoc(location, limit.name), "no limit, but expected %d", *limit.super)
+		case limit.lower && *limit.sub < *limit.super:
+			c.report(joinLoc(location, limit.name), "%d is less than %d", *limit.sub, *limit.super)
+		case !limit.lower && *limit.sub > *limit.super:
+			c.report(joinLoc(location, limit.name), "%d is greater than %d", *limit.sub, *limit.super)
+		}
+	}
+}
+
+func (c *subschemaChecker) checkProperties(location string, sub, super *Schema) {
+	for _, name := range super.Required {
+		if !slices.Contains(sub.Required, name) {
+			c.report(joinLoc(location, "required"), "property '%s' is not required", name)
+		}
+	}
+	for _, name := range sortedKeys(super.Properties) {
+		if prop, ok := sub.Properties[name]; ok {
+			c.check(joinLoc(location, "properties", name), prop, super.Properties[name])
+		}
+	}
+	if super.AdditionalProperties == nil || super.AdditionalProperties.Allowed {
+		return
+	}
+	if sub.AdditionalProperties == nil || sub.AdditionalProperties.Allowed {
+		c.report(joinLoc(location, "additionalProperties"), "additional properties are allowed")
+	}
+	for _, name := range sortedKeys(sub.Properties) {
+		if _, ok := super.Properties[name]; !ok {
+			c.report(joinLoc(location, "properties", name), "property is not allowed")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SunsetExt is the name of the extension holding the date and time, in RFC 3339 format,
+// after which the deprecated operation is going to be removed.
+//
+// Example:
+//
+//	delete:
+//	  deprecated: true
+//	  x-sunset: 2030-01-01T00:00:00Z
+const SunsetExt = "x-sunset"
+
+// Sunset marks the operation as deprecated and sets the date and time of its removal.
+func (b *OperationBuilder) Sunset(t time.Time) *OperationBuilder {
+	SetSunset(b.spec, t)
+	return b
+}
+
+// SetSunset marks the given operation as deprecated and sets the date and time of its removal.
+func SetSunset(op *Extendable[Operation], t time.Time) {
+	op.Spec.Deprecated = true
+	op.AddExt(SunsetExt, t.UTC().Format(time.RFC3339))
+}
+
+// SunsetOf returns the date and time of the removal of the given object, usually an operation.
+// The ok is false if the sunset is not set, and an error is returned if its value is not in RFC 3339 format.
+func SunsetOf[T any](o *Extendable[T]) (t time.Time, ok bool, err error) {
+	if o == nil {
+		return time.Time{}, false, nil
+	}
+	v, ok := o.Extensions[SunsetExt]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	t, err = parseSunset(v)
+	return t, true, err
+}
+
+func parseSunset(v any) (time.Time, error) {
+	switch s := extensionValue(v).(type) {
+	case time.Time:
+		// yaml decodes the unquoted timestamps
+		return s, nil
+	case string:
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w of %s, expected RFC 3339 date and time: %w", ErrInvalidFormat, SunsetExt, err)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("%w of %s, expected RFC 3339 date and time, but got %T", ErrInvalidFormat, SunsetExt, v)
+	}
+}
+
+// DeprecationHeaders returns the `Deprecation` and `Sunset` (RFC 8594) response headers for the given operation,
+// or nil if the operation is not deprecated.
+// The Deprecation header is `true`, since the date of the deprecation is unknown,
+// and the Sunset header is set only if the sunset of the operation is set and valid.
+func DeprecationHeaders(op *Extendable[Operation]) http.Header {
+	if op == nil || op.Spec == nil || !op.Spec.Deprecated {
+		return nil
+	}
+	h := make(http.Header, 2)
+	h.Set("Deprecation", "true")
+	if t, ok, err := SunsetOf(op); ok && err == nil {
+		h.Set("Sunset", t.UTC().Format(http.TimeFormat))
+	}
+	return h
+}
+
+// SunsetInFutureRuleID is the ID of SunsetInFutureRule.
+const SunsetInFutureRuleID = "sunset-in-future"
+
+// SunsetInFutureRule is a lint rule checking that the sunset dates of the operations are in the future,
+// so the operations past their sunset are removed from the spec.
+type SunsetInFutureRule struct {
+	// Now returns the current time, time.Now is used if nil.
+	Now func() time.Time
+}
+
+// ID implements Rule interface.
+func (r *SunsetInFutureRule) ID() string {
+	return SunsetInFutureRuleID
+}
+
+// Description implements Rule interface.
+func (r *SunsetInFutureRule) Description() string {
+	return "the sunset dates of the operations must be in the future"
+}
+
+// DefaultSeverity implements Rule interface.
+func (r *SunsetInFutureRule) DefaultSeverity() Severity {
+	return SeverityWarning
+}
+
+// Check implements Rule interface.
+func (r *SunsetInFutureRule) Check(spec *Extendable[OpenAPI], report func(location string, message string)) {
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	forEachOperation(spec, func(location, method string, op *Extendable[Operation]) {
+		// the invalid values are reported by the validation of the spec
+		if t, ok, err := SunsetOf(op); ok && err == nil && !t.After(now()) {
+			report(joinLoc(location, SunsetExt), fmt.Sprintf("%s operation is past its sunset %s", method, t.Format(time.RFC3339)))
+		}
+	})
+}
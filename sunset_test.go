@@ -0,0 +1,82 @@
+package openapi_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testSunsetSpec = `
+openapi: 3.1.1
+info:
+  title: Sunset
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      deprecated: true
+      x-sunset: 2030-01-01T00:00:00Z
+      responses:
+        '200':
+          description: OK
+    delete:
+      deprecated: true
+      x-sunset: "2020-01-01T00:00:00Z"
+      responses:
+        '200':
+          description: OK
+    post:
+      deprecated: true
+      x-sunset: next year
+      responses:
+        '200':
+          description: OK
+`
+
+func TestSunset(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testSunsetSpec), &spec))
+	item := spec.Spec.Paths.Spec.Paths["/pets"].Spec.Spec
+
+	sunset, ok, err := openapi.SunsetOf(item.Get)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), sunset.UTC())
+
+	_, ok, err = openapi.SunsetOf(item.Post)
+	require.True(t, ok)
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+
+	validator, err := openapi.NewValidator(spec, openapi.WithRules(&openapi.SunsetInFutureRule{
+		Now: func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}))
+	require.NoError(t, err)
+	err = validator.ValidateSpec()
+	require.ErrorIs(t, err, openapi.ErrInvalidFormat)
+	require.ErrorContains(t, err, "/paths/~1pets/post/x-sunset: invalid format of x-sunset")
+
+	issues := validator.Lint()
+	require.Len(t, issues, 1)
+	require.Equal(t, openapi.Location("/paths/~1pets/delete/x-sunset"), issues[0].Location)
+	require.Equal(t, openapi.SeverityWarning, issues[0].Severity)
+	require.Equal(t, "DELETE operation is past its sunset 2020-01-01T00:00:00Z", issues[0].Message)
+}
+
+func TestDeprecationHeaders(t *testing.T) {
+	sunset := time.Date(2030, 1, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	op := openapi.NewOperationBuilder().Sunset(sunset).Build()
+	require.True(t, op.Spec.Deprecated)
+	require.Equal(t, "2030-01-01T11:00:00Z", op.GetExt(openapi.SunsetExt))
+	require.Equal(t, http.Header{
+		"Deprecation": {"true"},
+		"Sunset":      {"Tue, 01 Jan 2030 11:00:00 GMT"},
+	}, openapi.DeprecationHeaders(op))
+
+	require.Equal(t, http.Header{"Deprecation": {"true"}}, openapi.DeprecationHeaders(openapi.NewOperationBuilder().Deprecated(true).Build()))
+	require.Nil(t, openapi.DeprecationHeaders(openapi.NewOperationBuilder().Build()))
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrLimitExceeded is returned by Unmarshal function if the data exceeds one of the unmarshal limits,
+// see MaxNestingDepth, MaxExtensionsPerObject, and MaxStringLength options.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// The default limits of Unmarshal function, they are far above the values of the real specs.
+const (
+	DefaultMaxNestingDepth        = 256
+	DefaultMaxExtensionsPerObject = 256
+	DefaultMaxStringLength        = 1 << 20
+)
+
+// MaxNestingDepth is an unmarshal option to limit the nesting depth of the objects and arrays of the data,
+// DefaultMaxNestingDepth by default; a non-positive value disables the limit.
+func MaxNestingDepth(n int) UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.maxNestingDepth = n
+	}
+}
+
+// MaxExtensionsPerObject is an unmarshal option to limit the number of the extensions (the keys with `x-` prefix)
+// of any object of the data, DefaultMaxExtensionsPerObject by default; a non-positive value disables the limit.
+func MaxExtensionsPerObject(n int) UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.maxExtensions = n
+	}
+}
+
+// MaxStringLength is an unmarshal option to limit the length in bytes of the strings of the data,
+// both the keys and the values, DefaultMaxStringLength by default; a non-positive value disables the limit.
+func MaxStringLength(n int) UnmarshalOption {
+	return func(o *unmarshalOptions) {
+		o.maxStringLength = n
+	}
+}
+
+func (o *unmarshalOptions) hasLimits() bool {
+	return o.maxNestingDepth > 0 || o.maxExtensions > 0 || o.maxStringLength > 0
+}
+
+func (o *unmarshalOptions) checkDepth(path locPath, depth int) error {
+	if o.maxNestingDepth > 0 && depth > o.maxNestingDepth {
+		return fmt.Errorf("%w: nesting depth is more than %d at %q", ErrLimitExceeded, o.maxNestingDepth, path.String())
+	}
+	return nil
+}
+
+func (o *unmarshalOptions) checkExtensions(path locPath, n int) error {
+	if o.maxExtensions > 0 && n > o.maxExtensions {
+		return fmt.Errorf("%w: more than %d extensions at %q", ErrLimitExceeded, o.maxExtensions, path.String())
+	}
+	return nil
+}
+
+func (o *unmarshalOptions) checkString(path locPath, s string) error {
+	if o.maxStringLength > 0 && len(s) > o.maxStringLength {
+		return fmt.Errorf("%w: string is longer than %d bytes at %q", ErrLimitExceeded, o.maxStringLength, path.String())
+	}
+	return nil
+}
+
+// checkJSONUnmarshalLimits scans the JSON data token by token, without materializing the value,
+// and checks it against the unmarshal limits.
+func (o *unmarshalOptions) checkJSONUnmarshalLimits(data []byte) error {
+	if !o.hasLimits() {
+		return nil
+	}
+	type container struct {
+		object     bool
+		isKey      bool
+		index      int
+		extensions int
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	// path has a segment per open container, the key or the index of its current value
+	var (
+		stack []*container
+		path  = make(locPath, 0, 16)
+	)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			// io.EOF or a syntax error reported by the parser
+			return nil
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			path = path[:len(stack)]
+			continue
+		}
+		var top *container
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		switch {
+		case top != nil && top.object && top.isKey:
+			key := tok.(string)
+			top.isKey = false
+			if err := o.checkString(path[:len(path)-1], key); err != nil {
+				return err
+			}
+			if strings.HasPrefix(key, ExtensionPrefix) {
+				top.extensions++
+				if err := o.checkExtensions(path[:len(path)-1], top.extensions); err != nil {
+					return err
+				}
+			}
+			path[len(path)-1] = key
+			continue
+		case top != nil && top.object:
+			top.isKey = true
+		case top != nil:
+			path[len(path)-1] = strconv.Itoa(top.index)
+			top.index++
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			if err := o.checkDepth(path, len(stack)+1); err != nil {
+				return err
+			}
+			stack = append(stack, &container{object: t == '{', isKey: t == '{'})
+			path = append(path, "")
+		case string:
+			if err := o.checkString(path, t); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkYAMLUnmarshalLimits checks the parsed YAML node against the unmarshal limits.
+// The aliases are not followed, since their anchors are checked where they are defined.
+func (o *unmarshalOptions) checkYAMLUnmarshalLimits(node *yaml.Node, path locPath, depth int) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			if err := o.checkYAMLUnmarshalLimits(n, path, depth); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		depth++
+		if err := o.checkDepth(path, depth); err != nil {
+			return err
+		}
+		var extensions int
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if err := o.checkString(path, key); err != nil {
+				return err
+			}
+			if strings.HasPrefix(key, ExtensionPrefix) {
+				extensions++
+				if err := o.checkExtensions(path, extensions); err != nil {
+					return err
+				}
+			}
+			if err := o.checkYAMLUnmarshalLimits(node.Content[i+1], append(path, key), depth); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		depth++
+		if err := o.checkDepth(path, depth); err != nil {
+			return err
+		}
+		for i, n := range node.Content {
+			if err := o.checkYAMLUnmarshalLimits(n, append(path, strconv.Itoa(i)), depth); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		return o.checkString(path, node.Value)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package openapi_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestUnmarshal_Limits(t *testing.T) {
+	deepJSON := `{"openapi": "3.1.1", "x-deep": ` + strings.Repeat("[", 10) + strings.Repeat("]", 10) + `}`
+	deepYAML := "openapi: 3.1.1\nx-deep: " + strings.Repeat("[", 10) + strings.Repeat("]", 10) + "\n"
+	var exts []string
+	for i := 0; i < 5; i++ {
+		exts = append(exts, fmt.Sprintf(`"x-%d": %d`, i, i))
+	}
+	manyExtsJSON := `{"openapi": "3.1.1", "info": {"title": "t", ` + strings.Join(exts, ", ") + `}}`
+	manyExtsYAML := "openapi: 3.1.1\ninfo:\n  title: t\n  " + strings.ReplaceAll(strings.Join(exts, "\n  "), `"`, "") + "\n"
+	longJSON := `{"openapi": "3.1.1", "info": {"title": "` + strings.Repeat("a", 20) + `"}}`
+	longYAML := "openapi: 3.1.1\ninfo:\n  title: " + strings.Repeat("a", 20) + "\n"
+
+	for _, tt := range []struct {
+		name     string
+		data     string
+		opts     []openapi.UnmarshalOption
+		location string
+	}{
+		{name: "depth json", data: deepJSON, opts: []openapi.UnmarshalOption{openapi.MaxNestingDepth(5)}, location: "/x-deep/0/0/0/0"},
+		{name: "depth yaml", data: deepYAML, opts: []openapi.UnmarshalOption{openapi.MaxNestingDepth(5)}, location: "/x-deep/0/0/0/0"},
+		{name: "extensions json", data: manyExtsJSON, opts: []openapi.UnmarshalOption{openapi.MaxExtensionsPerObject(4)}, location: "/info"},
+		{name: "extensions yaml", data: manyExtsYAML, opts: []openapi.UnmarshalOption{openapi.MaxExtensionsPerObject(4)}, location: "/info"},
+		{name: "string json", data: longJSON, opts: []openapi.UnmarshalOption{openapi.MaxStringLength(10)}, location: "/info/title"},
+		{name: "string yaml", data: longYAML, opts: []openapi.UnmarshalOption{openapi.MaxStringLength(10)}, location: "/info/title"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			err := openapi.Unmarshal([]byte(tt.data), &spec, tt.opts...)
+			require.ErrorIs(t, err, openapi.ErrLimitExceeded)
+			require.ErrorContains(t, err, fmt.Sprintf("%q", tt.location))
+
+			// the default limits are far above
+			require.NoError(t, openapi.Unmarshal([]byte(tt.data), &spec))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		data := `{"openapi": "3.1.1", "x-deep": ` + strings.Repeat("[", openapi.DefaultMaxNestingDepth) + strings.Repeat("]", openapi.DefaultMaxNestingDepth) + `}`
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.ErrorIs(t, openapi.Unmarshal([]byte(data), &spec), openapi.ErrLimitExceeded)
+		require.NoError(t, openapi.Unmarshal([]byte(data), &spec, openapi.MaxNestingDepth(0)))
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/mail"
 	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
@@ -47,19 +51,78 @@ func newValidationError(location string, err any, args ...any) *validationError
 	}
 }
 
-var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
 
 func joinLoc(base string, parts ...any) string {
 	if len(parts) == 0 {
 		return base
 	}
 
-	elems := append(make([]string, 0, len(parts)+1), base)
+	// the location is built by the validation of every object, so it is allocated once
+	var b strings.Builder
+	b.Grow(len(base) + len(parts)*16)
+	b.WriteString(base)
 	for _, v := range parts {
-		elems = append(elems, jsonPointerEscaper.Replace(fmt.Sprintf("%v", v)))
+		b.WriteByte('/')
+		switch t := v.(type) {
+		case string:
+			writeLocSegment(&b, t)
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		default:
+			writeLocSegment(&b, fmt.Sprintf("%v", v))
+		}
 	}
+	return b.String()
+}
+
+// writeLocSegment writes the segment of a location escaped as a JSON Pointer token.
+func writeLocSegment(b *strings.Builder, segment string) {
+	if strings.ContainsAny(segment, "~/") {
+		segment = jsonPointerEscaper.Replace(segment)
+	}
+	b.WriteString(segment)
+}
+
+// locPath is the stack of the segments of a location used by the walkers of the specs,
+// the location is rendered only when an issue is reported, so the clean specs are walked without building the strings.
+type locPath []string
+
+// String returns the location as JSON Pointer.
+func (p locPath) String() string {
+	var b strings.Builder
+	for _, segment := range p {
+		b.WriteByte('/')
+		writeLocSegment(&b, segment)
+	}
+	return b.String()
+}
+
+// join returns the location of the given segments under the path.
+func (p locPath) join(segments ...string) string {
+	return append(p[:len(p):len(p)], segments...).String()
+}
+
+// keysPool keeps the scratch slices of the keys sorted by the walkers of the JSON representation of the spec.
+var keysPool = sync.Pool{New: func() any { return new([]string) }}
+
+// pooledSortedKeys returns the sorted keys of the map in a pooled slice, it must be released by releaseKeys.
+func pooledSortedKeys(m map[string]any) *[]string {
+	keys := keysPool.Get().(*[]string)
+	for k := range m {
+		*keys = append(*keys, k)
+	}
+	slices.Sort(*keys)
+	return keys
+}
 
-	return strings.Join(elems, "/")
+func releaseKeys(keys *[]string) {
+	clear(*keys)
+	*keys = (*keys)[:0]
+	keysPool.Put(keys)
 }
 
 func (e *validationError) Error() string {
@@ -70,10 +133,19 @@ func (e *validationError) Unwrap() error {
 	return e.err
 }
 
+// The sentinel errors wrapped by the validation errors, so the failure category can be checked using errors.Is.
 var (
-	ErrRequired          = errors.New("required")
-	ErrMutuallyExclusive = errors.New("mutually exclusive")
-	ErrUnused            = errors.New("unused")
+	ErrRequired           = errors.New("required")
+	ErrMutuallyExclusive  = errors.New("mutually exclusive")
+	ErrUnused             = errors.New("unused")
+	ErrInvalidFormat      = errors.New("invalid format")
+	ErrInvalidValue       = errors.New("invalid value")
+	ErrOutOfRange         = errors.New("out of range")
+	ErrDuplicate          = errors.New("duplicate")
+	ErrUnresolvedRef      = errors.New("unresolved reference")
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	ErrNotAllowed         = errors.New("not allowed")
+	ErrInvalidData        = errors.New("invalid data")
 )
 
 func checkURL(value string) error {
@@ -81,7 +153,21 @@ func checkURL(value string) error {
 		return nil
 	}
 	if _, err := url.Parse(value); err != nil {
-		return fmt.Errorf("invalid URL: %w", err)
+		return fmt.Errorf("%w of URL: %w", ErrInvalidFormat, err)
+	}
+	return nil
+}
+
+func checkAbsoluteURL(value string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%w of URL: %w", ErrInvalidFormat, err)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("%w of URL: %q must be absolute", ErrInvalidFormat, value)
 	}
 	return nil
 }
@@ -91,22 +177,34 @@ func checkEmail(value string) error {
 		return nil
 	}
 	if _, err := mail.ParseAddress(value); err != nil {
-		return fmt.Errorf("invalid email: %w", err)
+		return fmt.Errorf("%w of email: %w", ErrInvalidFormat, err)
 	}
 	return nil
 }
 
 // Validator is a struct for validating the OpenAPI specification and a data.
+//
+// The methods validating the data, e.g. ValidateData, ValidateDataAsJSON, ValidateHTTPRequest,
+// are safe for concurrent use by multiple goroutines, including the validators created by Clone,
+// which share the compiled schemas; a schema is compiled once on first use.
+// ValidateSpec must not be called by several goroutines at the same time.
 type Validator struct {
-	spec *Extendable[OpenAPI]
-
-	compiler *jsonschema.Compiler
-	schemas  sync.Map
-	mu       sync.Mutex
+	spec  *Extendable[OpenAPI]
+	cache atomic.Pointer[schemaCache]
+	// lazyMu guards the registration of new members of the spec in the lazy mode
+	lazyMu sync.Mutex
 
 	opts              *validationOptions
 	visited           visitedObjects
 	linkToOperationID map[string]string
+	dynamicAnchors    map[string]bool
+
+	// documents are the files of the spec split into several files, see Documents option
+	documents *documentSet
+	// document is the path of the document validated by the validator, empty for the spec itself
+	document string
+	// documentRefs are the refs to the components of the documents visited by ValidateSpec, shared by all documents
+	documentRefs visitedObjects
 }
 
 const specPrefix = "http://spec"
@@ -114,48 +212,241 @@ const specPrefix = "http://spec"
 // NewValidator creates an instance of Validator struct.
 //
 // The function creates new jsonschema comppiler and adds the given spec to the compiler.
+// If the LazySpecMarshaling option is given, the spec is marshaled and added to the compiler on demand.
 func NewValidator(spec *Extendable[OpenAPI], opts ...ValidationOption) (*Validator, error) {
 	options := &validationOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
+	documents, err := newDocumentSet(options)
+	if err != nil {
+		return nil, err
+	}
 	validator := &Validator{
-		spec:    spec,
-		schemas: sync.Map{},
-		opts:    options,
+		spec:      spec,
+		opts:      options,
+		documents: documents,
 	}
-	data, err := json.Marshal(spec)
+	if options.lazySpecMarshaling {
+		c, err := newSchemaCache(map[string]any{}, options.updateCompiler)
+		if err != nil {
+			return nil, err
+		}
+		c.lazy = true
+		validator.cache.Store(c)
+		return validator, nil
+	}
+	doc, err := marshalToJSONValue(spec)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling spec failed: %w", err)
+		return nil, err
 	}
-	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	c, err := newSchemaCache(doc, options.updateCompiler)
+	if err != nil {
+		return nil, err
+	}
+	validator.cache.Store(c)
+	return validator, nil
+}
+
+// Clone creates a copy of the validator with the given options added to the options of the original validator.
+//
+// The spec is not marshaled again and, unless the options changing the compiler (UpdateCompiler, ValidateContent,
+// or Documents) are given, the compiled schemas are shared between the validators,
+// so it is much cheaper than NewValidator for creating validators per test or per tenant.
+// The spec must not be changed after the validator has been created.
+func (v *Validator) Clone(opts ...ValidationOption) (*Validator, error) {
+	options := *v.opts
+	options.updateCompiler = slices.Clip(options.updateCompiler)
+	options.rules = slices.Clip(options.rules)
+	options.ruleSeverities = slices.Clip(options.ruleSeverities)
+	options.documents = maps.Clone(options.documents)
+	// given are the options passed to Clone only, to find out whether the compiled schemas can be shared
+	var given validationOptions
+	for _, opt := range opts {
+		opt(&given)
+		opt(&options)
+	}
+	validator := &Validator{
+		spec:      v.spec,
+		opts:      &options,
+		documents: v.documents,
+	}
+	if len(given.documents) > 0 {
+		documents, err := newDocumentSet(&options)
+		if err != nil {
+			return nil, err
+		}
+		validator.documents = documents
+	}
+	c := v.cache.Load()
+	if len(given.updateCompiler) > 0 || len(given.documents) > 0 {
+		newCache, err := newSchemaCache(c.doc, options.updateCompiler)
+		if err != nil {
+			return nil, err
+		}
+		newCache.lazy = c.lazy
+		c = newCache
+	}
+	validator.cache.Store(c)
+	return validator, nil
+}
+
+// schemaCache is a compiler with the registered spec and the schemas compiled by it.
+// It can be safely shared between several validators.
+type schemaCache struct {
+	compiler *jsonschema.Compiler
+	schemas  sync.Map
+	// mu guards the compilers, which are not safe for concurrent use
+	mu sync.Mutex
+
+	// calls are the compilations in progress by the keys of the schemas, guarded by callsMu
+	calls   map[string]*schemaCall
+	callsMu sync.Mutex
+
+	// dialects are the compilers of the schemas written for the drafts other than 2020-12, guarded by mu
+	dialects       map[*jsonschema.Draft]*jsonschema.Compiler
+	updateCompiler []func(*jsonschema.Compiler)
+
+	// doc is the JSON representation of the spec registered in the compiler
+	doc any
+	// lazy means that the doc contains only the top-level members of the spec requested so far
+	lazy bool
+	// router matches the requests to the operations of the registered spec, see pathRouter
+	router atomic.Pointer[specRouter]
+}
+
+// specRouter holds the router of the spec created once on the first use.
+type specRouter struct {
+	once   sync.Once
+	router *Router
+	err    error
+}
+
+func newSchemaCache(doc any, updateCompiler []func(*jsonschema.Compiler)) (*schemaCache, error) {
 	compiler := jsonschema.NewCompiler()
 	compiler.DefaultDraft(jsonschema.Draft2020)
+	registerFormats(compiler)
 	if err := compiler.AddResource(specPrefix, doc); err != nil {
 		return nil, fmt.Errorf("adding spec to compiler failed: %w", err)
 	}
-	for _, f := range validator.opts.updateCompiler {
+	for _, f := range updateCompiler {
 		f(compiler)
 	}
-	validator.compiler = compiler
-	return validator, nil
+	c := &schemaCache{compiler: compiler, doc: doc, updateCompiler: updateCompiler}
+	c.router.Store(&specRouter{})
+	return c, nil
+}
+
+// schemaCacheFor returns the schema cache containing the given location.
+//
+// In the lazy mode, the top-level member of the spec containing the location (and the components,
+// which are the target of most references) is marshaled and a new compiler is created with all
+// the members registered so far; the already compiled schemas are kept.
+func (v *Validator) schemaCacheFor(location string) (*schemaCache, error) {
+	c := v.cache.Load()
+	member, _, _ := strings.Cut(strings.TrimPrefix(location, "#/"), "/")
+	// the documents are registered completely
+	if !c.lazy || v.document != "" || c.hasMember(member) && c.hasMember("components") {
+		return c, nil
+	}
+
+	v.lazyMu.Lock()
+	defer v.lazyMu.Unlock()
+	c = v.cache.Load()
+	if c.hasMember(member) && c.hasMember("components") {
+		return c, nil
+	}
+	doc := maps.Clone(c.doc.(map[string]any))
+	for _, m := range []string{"components", member} {
+		if _, ok := doc[m]; ok {
+			continue
+		}
+		value, err := v.marshalSpecMember(m)
+		if err != nil {
+			return nil, err
+		}
+		doc[m] = value
+	}
+	newCache, err := newSchemaCache(doc, v.opts.updateCompiler)
+	if err != nil {
+		return nil, err
+	}
+	newCache.lazy = true
+	// the spec is not changed, so the router is kept
+	newCache.router.Store(c.router.Load())
+	c.schemas.Range(func(k, s any) bool {
+		newCache.schemas.Store(k, s)
+		return true
+	})
+	v.cache.Store(newCache)
+	return newCache, nil
+}
+
+// marshalToJSONValue converts the given part of the spec into the JSON representation used by the jsonschema compiler.
+func marshalToJSONValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec failed: %w", err)
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling spec failed: %w", err)
+	}
+	return doc, nil
+}
+
+func (c *schemaCache) hasMember(member string) bool {
+	_, ok := c.doc.(map[string]any)[member]
+	return ok
+}
+
+// marshalSpecMember returns the JSON representation of the given top-level member of the spec.
+// The absent members are returned as nil values.
+func (v *Validator) marshalSpecMember(member string) (any, error) {
+	if v.spec == nil || v.spec.Spec == nil {
+		return nil, nil
+	}
+	var value any
+	switch member {
+	case "components":
+		value = v.spec.Spec.Components
+	case "paths":
+		value = v.spec.Spec.Paths
+	case "webhooks":
+		value = v.spec.Spec.WebHooks
+	default:
+		value = v.spec
+	}
+	doc, err := marshalToJSONValue(value)
+	if err != nil {
+		return nil, err
+	}
+	if value == v.spec {
+		if m, ok := doc.(map[string]any); ok {
+			return m[member], nil
+		}
+		return nil, nil
+	}
+	return doc, nil
 }
 
 // ValidateSpec validates the specification.
+// The issues of the enabled lint rules with SeverityError are returned as well, see Lint method.
 func (v *Validator) ValidateSpec() error {
 	// clear visited objects
 	v.visited = make(visitedObjects)
 	v.linkToOperationID = make(map[string]string)
+	v.dynamicAnchors = nil
+	v.documentRefs = make(visitedObjects)
 
-	if errs := v.spec.validateSpec("", v); len(errs) > 0 {
-		joinErrors := make([]error, len(errs))
-		for i := range errs {
-			joinErrors[i] = errs[i]
-		}
-		return errors.Join(joinErrors...)
+	errs := v.spec.validateSpec("", v)
+	errs = append(errs, v.validateDocuments()...)
+	joinErrors := make([]error, len(errs))
+	for i := range errs {
+		joinErrors[i] = errs[i]
 	}
-
-	return nil
+	joinErrors = append(joinErrors, v.lintErrors()...)
+	return errors.Join(joinErrors...)
 }
 
 // ValidateData validates the given value against the schema located at the given location.
@@ -164,32 +455,9 @@ func (v *Validator) ValidateSpec() error {
 // The value can be a struct, a string containing JSON, or any other types.
 // If the value is a struct, it will be marshaled and unmarshaled to JSON.
 func (v *Validator) ValidateData(location string, value any) error {
-	var schema *jsonschema.Schema
-	if s, ok := v.schemas.Load(location); ok {
-		schema = s.(*jsonschema.Schema)
-	} else {
-		var err error
-		// use lambda to simplify the mutex unlocking code after the schema is compiled
-		schema, err = func() (*jsonschema.Schema, error) {
-			v.mu.Lock()
-			defer v.mu.Unlock()
-			if s, ok := v.schemas.Load(location); ok {
-				return s.(*jsonschema.Schema), nil
-			} else {
-				if !strings.HasPrefix(location, "#") {
-					location = "#" + location
-				}
-				schema, err := v.compiler.Compile(specPrefix + location)
-				if err != nil {
-					return nil, fmt.Errorf("compiling spec for given location %q failed: %w", location, err)
-				}
-				v.schemas.Store(location, schema)
-				return schema, nil
-			}
-		}()
-		if err != nil {
-			return err
-		}
+	schema, err := v.compileSchema(location)
+	if err != nil {
+		return err
 	}
 
 	switch getKind(value) {
@@ -205,15 +473,99 @@ func (v *Validator) ValidateData(location string, value any) error {
 			return fmt.Errorf("unmarshaling value failed: %w", err)
 		}
 	case reflect.String:
-		if v.opts.validateDataAsJSON {
+		// json.Number is a number, not a string
+		if str, ok := value.(string); ok && v.opts.validateDataAsJSON {
 			// check if the value is already a JSON, if not keep it as is.
-			s, err := jsonschema.UnmarshalJSON(strings.NewReader(value.(string)))
+			s, err := jsonschema.UnmarshalJSON(strings.NewReader(str))
 			if err == nil {
 				value = s
 			}
 		}
 	}
-	return schema.Validate(value)
+	if err := schema.Validate(value); err != nil {
+		return v.dataError(err)
+	}
+	return nil
+}
+
+// compileSchema compiles the schema located at the given location and caches it.
+func (v *Validator) compileSchema(location string) (*jsonschema.Schema, error) {
+	if !strings.HasPrefix(location, "#") {
+		location = "#" + location
+	}
+	c, err := v.schemaCacheFor(location)
+	if err != nil {
+		return nil, err
+	}
+	// the validators of the documents share the cache with the validator of the spec
+	return c.load(v.document+location, func() (*jsonschema.Schema, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		doc, url := c.doc, specPrefix
+		if v.document != "" {
+			doc, url = v.documents.json[v.document], documentURL(v.document)
+		}
+		compiler, err := c.compilerFor(v.draftAt(doc, location))
+		if err != nil {
+			return nil, err
+		}
+		schema, err := compiler.Compile(url + location)
+		if err != nil {
+			return nil, fmt.Errorf("compiling spec for given location %q failed: %w", location, err)
+		}
+		return schema, nil
+	})
+}
+
+// errSchemaCompilationPanicked is returned to the callers waiting for a compilation that panicked.
+var errSchemaCompilationPanicked = errors.New("compiling schema panicked")
+
+// schemaCall is a compilation of a schema in progress, the concurrent callers for the same schema wait for it.
+type schemaCall struct {
+	done   chan struct{}
+	schema *jsonschema.Schema
+	err    error
+}
+
+// load returns the compiled schema by the key or compiles it using the compile function.
+//
+// The schema is compiled once even if it is requested by several goroutines at the same time,
+// the others wait for the result without holding any lock, so the callers of the compiled schemas are not blocked.
+// The errors are not cached, so the next call compiles the schema again.
+func (c *schemaCache) load(key string, compile func() (*jsonschema.Schema, error)) (*jsonschema.Schema, error) {
+	if s, ok := c.schemas.Load(key); ok {
+		return s.(*jsonschema.Schema), nil
+	}
+	c.callsMu.Lock()
+	// the schema could be stored by a call finished after the first check
+	if s, ok := c.schemas.Load(key); ok {
+		c.callsMu.Unlock()
+		return s.(*jsonschema.Schema), nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.callsMu.Unlock()
+		<-call.done
+		return call.schema, call.err
+	}
+	call := &schemaCall{done: make(chan struct{})}
+	if c.calls == nil {
+		c.calls = make(map[string]*schemaCall)
+	}
+	c.calls[key] = call
+	c.callsMu.Unlock()
+
+	defer func() {
+		if call.err == nil && call.schema != nil {
+			c.schemas.Store(key, call.schema)
+		}
+		c.callsMu.Lock()
+		delete(c.calls, key)
+		c.callsMu.Unlock()
+		close(call.done)
+	}()
+	call.err = errSchemaCompilationPanicked
+	call.schema, call.err = compile()
+	return call.schema, call.err
 }
 
 // ValidateDataAsJSON marshal and unmarshals the given value to JSON and
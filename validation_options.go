@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/santhosh-tekuri/jsonschema/v6"
+import (
+	"path"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
 
 type validationOptions struct {
 	allowExtensionNameWithoutPrefix bool
@@ -13,6 +17,16 @@ type validationOptions struct {
 	doNotValidateDefaultValues      bool
 	validateDataAsJSON              bool
 	updateCompiler                  []func(*jsonschema.Compiler)
+	maxBodySize                     int64
+	maxJSONDepth                    int
+	maxJSONElements                 int
+	lazySpecMarshaling              bool
+	rules                           []Rule
+	ruleSeverities                  []ruleSeverity
+	routeAdapter                    RouteAdapter
+	documents                       map[string]*Extendable[OpenAPI]
+	flattenViolations               bool
+	maxViolations                   int
 }
 
 // ValidationOption is a type for validation options.
@@ -86,3 +100,66 @@ func UpdateCompiler(f func(*jsonschema.Compiler)) ValidationOption {
 		v.updateCompiler = append(v.updateCompiler, f)
 	}
 }
+
+// Documents is a validation option to validate a spec split into several files without bundling them.
+// The documents are the files referenced by the spec keyed by their paths relative to the file of the spec,
+// e.g. `schemas/pets.yaml`; each document must be a valid OpenAPI document, usually with the components only.
+//
+// The references to the documents, e.g. `schemas/pets.yaml#/components/schemas/Pet`, are resolved relative to
+// the referencing file, and the documents are validated by ValidateSpec method as well,
+// the locations of their errors are prefixed with their paths, e.g. `schemas/pets.yaml#/components/schemas/Pet`.
+// A component of a document is used if it is referenced by any file.
+// The spec itself must be added to the documents if the documents reference it.
+func Documents(docs map[string]*Extendable[OpenAPI]) ValidationOption {
+	return func(v *validationOptions) {
+		if v.documents == nil {
+			v.documents = make(map[string]*Extendable[OpenAPI], len(docs))
+		}
+		for name, doc := range docs {
+			v.documents[path.Clean(name)] = doc
+		}
+	}
+}
+
+// ValidateContent is a validation option to validate the string-encoded content of the data:
+// the strings are decoded according to `contentEncoding` (e.g. `base64`) and `contentMediaType` (e.g. `application/json`),
+// and the decoded value is validated against `contentSchema`.
+// By default, these keywords are annotations only.
+func ValidateContent() ValidationOption {
+	return UpdateCompiler(func(c *jsonschema.Compiler) {
+		c.AssertContent()
+	})
+}
+
+// MaxBodySize is a validation option to limit the size of a body in bytes, which is read for validation.
+// The bodies exceeding the limit are rejected with ErrBodyTooLarge without being parsed.
+func MaxBodySize(n int64) ValidationOption {
+	return func(v *validationOptions) {
+		v.maxBodySize = n
+	}
+}
+
+// MaxJSONDepth is a validation option to limit the nesting depth of the objects and arrays in a JSON body.
+// The bodies exceeding the limit are rejected with ErrJSONTooDeep.
+func MaxJSONDepth(n int) ValidationOption {
+	return func(v *validationOptions) {
+		v.maxJSONDepth = n
+	}
+}
+
+// MaxJSONElements is a validation option to limit the total number of values (objects, arrays, and scalars)
+// in a JSON body. The bodies exceeding the limit are rejected with ErrJSONTooManyElements.
+func MaxJSONElements(n int) ValidationOption {
+	return func(v *validationOptions) {
+		v.maxJSONElements = n
+	}
+}
+
+// LazySpecMarshaling is a validation option to marshal the spec and register it in the jsonschema compiler on demand.
+// Only the top-level members of the spec containing the locations given to ValidateData are marshaled,
+// which greatly reduces the cost of NewValidator for huge specs used mostly for the spec validation.
+func LazySpecMarshaling() ValidationOption {
+	return func(v *validationOptions) {
+		v.lazySpecMarshaling = true
+	}
+}
@@ -1,11 +1,14 @@
 package openapi_test
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"os"
 	"path"
+	"sync"
 	"testing"
 
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
@@ -46,6 +49,15 @@ func TestValidator_ValidateSpec(t *testing.T) {
 			)
 			require.NoError(t, err)
 			require.NoError(t, v.ValidateSpec())
+
+			v, err = openapi.NewValidator(
+				o,
+				openapi.AllowUndefinedTagsInOperation(),
+				openapi.ValidateStringDataAsJSON(),
+				openapi.LazySpecMarshaling(),
+			)
+			require.NoError(t, err)
+			require.NoError(t, v.ValidateSpec())
 		})
 	}
 }
@@ -342,3 +354,219 @@ func TestValidator_ValidateData(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_Clone(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "petstore.json"))
+	require.NoError(t, err)
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &spec))
+	spec.Spec.Components.Spec.Schemas["ID"] = openapi.NewSchemaBuilder().Type(openapi.StringType).Format(openapi.UUIDFormat).Build()
+	validator, err := openapi.NewValidator(&spec)
+	require.NoError(t, err)
+
+	const pet = `{"id": 123, "name": "foo"}`
+	require.Error(t, validator.ValidateData("/components/schemas/Pet", pet))
+	require.NoError(t, validator.ValidateData("/components/schemas/ID", "foo"))
+
+	clone, err := validator.Clone(openapi.ValidateStringDataAsJSON())
+	require.NoError(t, err)
+	require.NoError(t, clone.ValidateData("/components/schemas/Pet", pet))
+	require.NoError(t, clone.ValidateData("/components/schemas/ID", "foo"))
+	// the original validator is not changed
+	require.Error(t, validator.ValidateData("/components/schemas/Pet", pet))
+
+	clone, err = validator.Clone(openapi.UpdateCompiler(func(c *jsonschema.Compiler) {
+		c.AssertFormat()
+	}))
+	require.NoError(t, err)
+	require.ErrorContains(t, clone.ValidateData("/components/schemas/ID", "foo"), "is not valid uuid")
+	require.NoError(t, validator.ValidateData("/components/schemas/ID", "foo"))
+}
+
+func TestValidator_ValidateData_Concurrent(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "petstore.json"))
+	require.NoError(t, err)
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &spec))
+
+	const route = "/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema"
+	locations := []string{"/components/schemas/Pet", "#/components/schemas/Pet", route, "/components/schemas/Fake"}
+	valid := map[string]any{"id": 1, "name": "foo"}
+	invalid := map[string]any{"id": "1", "name": "foo"}
+
+	for _, tt := range []struct {
+		name string
+		opts []openapi.ValidationOption
+	}{
+		{name: "eager"},
+		{name: "lazy", opts: []openapi.ValidationOption{openapi.LazySpecMarshaling()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(&spec, tt.opts...)
+			require.NoError(t, err)
+			clone, err := validator.Clone(openapi.ValidateStringDataAsJSON())
+			require.NoError(t, err)
+
+			// the goroutines start at the same time, so the first compilations of the schemas overlap
+			const goroutines = 16
+			start := make(chan struct{})
+			errs := make([][]error, goroutines)
+			var wg sync.WaitGroup
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					v := validator
+					if i%2 == 1 {
+						v = clone
+					}
+					<-start
+					for j := 0; j < 10; j++ {
+						loc := locations[(i+j)%len(locations)]
+						errs[i] = append(errs[i], v.ValidateData(loc, valid), v.ValidateData(loc, invalid))
+					}
+				}(i)
+			}
+			close(start)
+			wg.Wait()
+
+			for i := range errs {
+				for j := 0; j < len(errs[i]); j += 2 {
+					loc := locations[(i+j/2)%len(locations)]
+					switch loc {
+					case "/components/schemas/Fake":
+						require.ErrorContains(t, errs[i][j], "not found")
+						require.ErrorContains(t, errs[i][j+1], "not found")
+					default:
+						require.NoError(t, errs[i][j], loc)
+						require.ErrorContains(t, errs[i][j+1], "got string, want integer", loc)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLazySpecMarshaling(t *testing.T) {
+	data, err := os.ReadFile(path.Join("testdata", "petstore.json"))
+	require.NoError(t, err)
+	var spec openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, json.Unmarshal(data, &spec))
+	validator, err := openapi.NewValidator(&spec, openapi.LazySpecMarshaling(), openapi.AllowUndefinedTagsInOperation())
+	require.NoError(t, err)
+
+	require.NoError(t, validator.ValidateSpec())
+	require.NoError(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"id": 1, "name": "foo"}))
+	require.ErrorContains(t, validator.ValidateData("#/components/schemas/Pet", map[string]any{"id": "1", "name": "foo"}), "got string, want integer")
+	const route = "/paths/~1pets~1{petId}/get/responses/200/content/application~1json/schema"
+	require.ErrorContains(t, validator.ValidateData(route, map[string]any{"id": "1", "name": "foo"}), "got string, want integer")
+	require.ErrorContains(t, validator.ValidateData("/components/schemas/Fake", map[string]any{}), "not found")
+
+	clone, err := validator.Clone(openapi.UpdateCompiler(func(c *jsonschema.Compiler) {
+		c.AssertFormat()
+	}))
+	require.NoError(t, err)
+	require.NoError(t, clone.ValidateData(route, map[string]any{"id": 1, "name": "foo"}))
+}
+
+func TestValidator_ValidateSpec_SentinelErrors(t *testing.T) {
+	const spec = `
+openapi: 4.0.0
+info:
+  title: Test
+  version: 1.0.0
+  termsOfService: "http://[::1"
+paths:
+  pets:
+    get:
+      operationId: getPets
+      tags: [unknown]
+      parameters:
+        - $ref: '#/components/parameters/Missing'
+        - name: id
+          in: query
+          style: simple
+          schema:
+            type: foo
+      responses:
+        '200':
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: getPets
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            minLength: -1
+      responses:
+        '200':
+          description: OK
+`
+	var o *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &o))
+	v, err := openapi.NewValidator(o)
+	require.NoError(t, err)
+	err = v.ValidateSpec()
+	for _, sentinel := range []error{
+		openapi.ErrUnsupportedVersion,
+		openapi.ErrInvalidFormat,
+		openapi.ErrInvalidValue,
+		openapi.ErrOutOfRange,
+		openapi.ErrDuplicate,
+		openapi.ErrUnresolvedRef,
+		openapi.ErrNotAllowed,
+	} {
+		require.ErrorIs(t, err, sentinel)
+	}
+	for _, msg := range openapi.LocalizeErrors(err, nil) {
+		require.NotEqual(t, openapi.MsgInvalid, msg.ID, msg.Location.String()+": "+msg.Text)
+	}
+}
+
+func TestValidateContent(t *testing.T) {
+	components := openapi.NewComponents()
+	components.Spec.Add("Token", openapi.NewSchemaBuilder().
+		Type(openapi.StringType).
+		ContentEncoding("base64").
+		ContentMediaType("application/json").
+		ContentSchema(openapi.NewSchemaBuilder().
+			Type(openapi.ObjectType).
+			AddProperty("sub", openapi.NewSchemaBuilder().Type(openapi.StringType).Build()).
+			Required("sub").
+			Build()).
+		Build())
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Content").Version("1.0.0").Build()).
+		Components(components).
+		Build()
+
+	for _, tt := range []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "valid", data: base64.StdEncoding.EncodeToString([]byte(`{"sub": "alice"}`)), valid: true},
+		{name: "not base64", data: "not base64!", valid: false},
+		{name: "not json", data: base64.StdEncoding.EncodeToString([]byte(`alice`)), valid: false},
+		{name: "invalid payload", data: base64.StdEncoding.EncodeToString([]byte(`{"sub": 42}`)), valid: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			// the content keywords are annotations by default
+			require.NoError(t, validator.ValidateData("/components/schemas/Token", tt.data))
+
+			validator, err = validator.Clone(openapi.ValidateContent())
+			require.NoError(t, err)
+			err = validator.ValidateData("/components/schemas/Token", tt.data)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
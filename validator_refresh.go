@@ -0,0 +1,294 @@
+package openapi
+
+import (
+	"maps"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Refresh updates the validator after the spec has been changed programmatically, e.g. by the builders.
+//
+// The spec (or, in the lazy mode, the top-level members registered so far) is marshaled again and,
+// if anything has been changed, re-registered in a new compiler; only the compiled schemas
+// depending on the changed parts of the spec are dropped, see Invalidate.
+// If nothing has been changed, the compiled schemas are kept.
+// The router matching the requests to the operations is created again on the next request.
+// The validators created by Clone are not affected.
+func (v *Validator) Refresh() error {
+	v.lazyMu.Lock()
+	defer v.lazyMu.Unlock()
+
+	c := v.cache.Load()
+	var doc any
+	if c.lazy {
+		m := maps.Clone(c.doc.(map[string]any))
+		for member := range m {
+			value, err := v.marshalSpecMember(member)
+			if err != nil {
+				return err
+			}
+			m[member] = value
+		}
+		doc = m
+	} else {
+		var err error
+		if doc, err = marshalToJSONValue(v.spec); err != nil {
+			return err
+		}
+	}
+	if reflect.DeepEqual(doc, c.doc) {
+		if c.lazy {
+			// the paths used by the router could be not registered yet
+			c.router.Store(&specRouter{})
+		}
+		return nil
+	}
+	return v.replaceDoc(doc, c.lazy)
+}
+
+// Invalidate updates the validator after the objects located at the given locations have been changed programmatically.
+//
+// Only the changed objects are marshaled again: a component (e.g. `/components/schemas/Pet`), a path item
+// (e.g. `/paths/~1pets`), or, for other locations, the top-level member of the spec containing the location.
+// The locations should be in form of JSON Pointer.
+// The compiled schemas located within the changed parts of the spec, or referring to them directly or indirectly,
+// are dropped, the others are kept; the router matching the requests to the operations is created again on the next request.
+// The validators created by Clone are not affected.
+func (v *Validator) Invalidate(locations ...string) error {
+	v.lazyMu.Lock()
+	defer v.lazyMu.Unlock()
+
+	c := v.cache.Load()
+	doc, _ := c.doc.(map[string]any)
+	doc = maps.Clone(doc)
+	if doc == nil {
+		doc = make(map[string]any)
+	}
+	for _, location := range locations {
+		parts := Location(location).Segments()
+		if len(parts) == 0 {
+			parts = []string{""}
+		}
+		if _, ok := doc[parts[0]]; !ok && c.lazy {
+			// the member will be marshaled on demand
+			continue
+		}
+		if err := v.updateDoc(doc, parts); err != nil {
+			return err
+		}
+	}
+	return v.replaceDoc(doc, c.lazy)
+}
+
+// updateDoc marshals the object of the spec located at the given path and puts it into the doc.
+func (v *Validator) updateDoc(doc map[string]any, parts []string) error {
+	var (
+		path  []string
+		value any
+	)
+	switch {
+	case v.spec == nil || v.spec.Spec == nil:
+	case parts[0] == "components" && len(parts) > 2 && v.spec.Spec.Components != nil:
+		var known bool
+		if value, known = componentValue(v.spec.Spec.Components.Spec, parts[1], parts[2]); known {
+			path = parts[:3]
+		}
+	case parts[0] == "paths" && len(parts) > 1 && v.spec.Spec.Paths != nil:
+		path = parts[:2]
+		if item, ok := v.spec.Spec.Paths.Spec.Paths[parts[1]]; ok {
+			value = item
+		}
+	}
+	if path == nil {
+		member, err := v.marshalSpecMember(parts[0])
+		if err != nil {
+			return err
+		}
+		setJSONValue(doc, parts[:1], member, member != nil)
+		return nil
+	}
+	if value == nil {
+		setJSONValue(doc, path, nil, false)
+		return nil
+	}
+	data, err := marshalToJSONValue(value)
+	if err != nil {
+		return err
+	}
+	setJSONValue(doc, path, data, true)
+	return nil
+}
+
+// componentValue returns the component of the given type and name, known is false for unknown types.
+func componentValue(c *Components, typ, name string) (value any, known bool) {
+	switch typ {
+	case "schemas":
+		return lookupComponent(c.Schemas, name), true
+	case "responses":
+		return lookupComponent(c.Responses, name), true
+	case "parameters":
+		return lookupComponent(c.Parameters, name), true
+	case "examples":
+		return lookupComponent(c.Examples, name), true
+	case "requestBodies":
+		return lookupComponent(c.RequestBodies, name), true
+	case "headers":
+		return lookupComponent(c.Headers, name), true
+	case "securitySchemes":
+		return lookupComponent(c.SecuritySchemes, name), true
+	case "links":
+		return lookupComponent(c.Links, name), true
+	case "callbacks":
+		return lookupComponent(c.Callbacks, name), true
+	case "paths":
+		return lookupComponent(c.Paths, name), true
+	}
+	return nil, false
+}
+
+func lookupComponent[T any](m map[string]*T, name string) any {
+	if v, ok := m[name]; ok && v != nil {
+		return v
+	}
+	return nil
+}
+
+// setJSONValue sets or deletes the value at the given path cloning the maps on the way,
+// so the doc registered in the current compiler is not modified.
+func setJSONValue(doc map[string]any, path []string, value any, present bool) {
+	for _, p := range path[:len(path)-1] {
+		next, _ := doc[p].(map[string]any)
+		next = maps.Clone(next)
+		if next == nil {
+			if !present {
+				return
+			}
+			next = make(map[string]any)
+		}
+		doc[p] = next
+		doc = next
+	}
+	last := path[len(path)-1]
+	if present {
+		doc[last] = value
+	} else {
+		delete(doc, last)
+	}
+}
+
+// replaceDoc registers the doc in a new compiler, the compiled schemas not depending on the changes are kept.
+func (v *Validator) replaceDoc(doc any, lazy bool) error {
+	old := v.cache.Load()
+	c, err := newSchemaCache(doc, v.opts.updateCompiler)
+	if err != nil {
+		return err
+	}
+	c.lazy = lazy
+	changed := changedLocations(old.doc, doc, "", nil)
+	old.schemas.Range(func(k, s any) bool {
+		if !schemaDependsOn(s.(*jsonschema.Schema), changed, make(map[*jsonschema.Schema]bool)) {
+			c.schemas.Store(k, s)
+		}
+		return true
+	})
+	v.cache.Store(c)
+	return nil
+}
+
+// changedLocations appends the locations of the values added, removed, or changed in the new doc to the given list.
+func changedLocations(oldDoc, newDoc any, loc Location, changed []Location) []Location {
+	oldMap, oldOK := oldDoc.(map[string]any)
+	newMap, newOK := newDoc.(map[string]any)
+	if !oldOK || !newOK {
+		if !reflect.DeepEqual(oldDoc, newDoc) {
+			changed = append(changed, loc)
+		}
+		return changed
+	}
+	for k, oldValue := range oldMap {
+		if newValue, ok := newMap[k]; ok {
+			changed = changedLocations(oldValue, newValue, loc.Join(k), changed)
+		} else {
+			changed = append(changed, loc.Join(k))
+		}
+	}
+	for k := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			changed = append(changed, loc.Join(k))
+		}
+	}
+	return changed
+}
+
+// schemaDependsOn reports whether the compiled schema or any schema reachable from it, e.g. by a reference,
+// contains one of the given locations of the spec or is located within one of them.
+// The schemas with the keywords of the custom vocabularies are always treated as dependent,
+// since their subschemas are unknown.
+func schemaDependsOn(s *jsonschema.Schema, changed []Location, visited map[*jsonschema.Schema]bool) bool {
+	if s == nil || visited[s] {
+		return false
+	}
+	visited[s] = true
+	if len(s.Extensions) > 0 {
+		return true
+	}
+	if fragment, ok := strings.CutPrefix(s.Location, specPrefix+"#"); ok {
+		pointer, err := url.PathUnescape(fragment)
+		if err != nil {
+			return true
+		}
+		loc := Location(pointer)
+		for _, c := range changed {
+			if loc.IsWithin(c) || c.IsWithin(loc) {
+				return true
+			}
+		}
+	}
+	for _, sub := range subschemas(s) {
+		if schemaDependsOn(sub, changed, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// subschemas returns the schemas referred by the given compiled schema, including the resolved references.
+func subschemas(s *jsonschema.Schema) []*jsonschema.Schema {
+	res := []*jsonschema.Schema{
+		s.Ref, s.RecursiveRef, s.Not, s.If, s.Then, s.Else, s.PropertyNames, s.UnevaluatedProperties,
+		s.Contains, s.Items2020, s.UnevaluatedItems, s.ContentSchema,
+	}
+	if s.DynamicRef != nil {
+		res = append(res, s.DynamicRef.Ref)
+	}
+	res = append(res, s.AllOf...)
+	res = append(res, s.AnyOf...)
+	res = append(res, s.OneOf...)
+	res = append(res, s.PrefixItems...)
+	for _, sub := range s.Properties {
+		res = append(res, sub)
+	}
+	for _, sub := range s.PatternProperties {
+		res = append(res, sub)
+	}
+	for _, sub := range s.DependentSchemas {
+		res = append(res, sub)
+	}
+	for _, v := range s.Dependencies {
+		if sub, ok := v.(*jsonschema.Schema); ok {
+			res = append(res, sub)
+		}
+	}
+	for _, v := range []any{s.AdditionalProperties, s.AdditionalItems, s.Items} {
+		switch v := v.(type) {
+		case *jsonschema.Schema:
+			res = append(res, v)
+		case []*jsonschema.Schema:
+			res = append(res, v...)
+		}
+	}
+	return res
+}
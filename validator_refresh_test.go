@@ -0,0 +1,212 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestValidator_Refresh(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		opts    []openapi.ValidationOption
+		refresh func(v *openapi.Validator) error
+	}{
+		{
+			name:    "refresh",
+			refresh: (*openapi.Validator).Refresh,
+		},
+		{
+			name: "invalidate component",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("/components/schemas/ID")
+			},
+		},
+		{
+			name: "invalidate member",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("#/components")
+			},
+		},
+		{
+			name:    "lazy refresh",
+			opts:    []openapi.ValidationOption{openapi.LazySpecMarshaling()},
+			refresh: (*openapi.Validator).Refresh,
+		},
+		{
+			name: "lazy invalidate",
+			opts: []openapi.ValidationOption{openapi.LazySpecMarshaling()},
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("/components/schemas/ID")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := openapi.NewOpenAPIBuilder().
+				Components(openapi.NewComponents()).
+				Build()
+			spec.Spec.Components.Spec.Add("ID", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build())
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateData("/components/schemas/ID", 1))
+			require.Error(t, validator.ValidateData("/components/schemas/ID", "foo"))
+
+			spec.Spec.Components.Spec.Add("ID", openapi.NewSchemaBuilder().Type(openapi.StringType).Build())
+			// the compiled schema is stale
+			require.Error(t, validator.ValidateData("/components/schemas/ID", "foo"))
+
+			require.NoError(t, tt.refresh(validator))
+			require.NoError(t, validator.ValidateData("/components/schemas/ID", "foo"))
+			require.Error(t, validator.ValidateData("/components/schemas/ID", 1))
+		})
+	}
+}
+
+func TestValidator_Refresh_Router(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		opts    []openapi.ValidationOption
+		refresh func(v *openapi.Validator) error
+	}{
+		{
+			name:    "refresh",
+			refresh: (*openapi.Validator).Refresh,
+		},
+		{
+			name: "invalidate path",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("/paths/~1b")
+			},
+		},
+		{
+			name: "invalidate member",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("#/paths")
+			},
+		},
+		{
+			name:    "lazy refresh",
+			opts:    []openapi.ValidationOption{openapi.LazySpecMarshaling()},
+			refresh: (*openapi.Validator).Refresh,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(`
+openapi: 3.1.1
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      operationId: getA
+`), &spec))
+			validator, err := openapi.NewValidator(spec, tt.opts...)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/a", nil)))
+			require.ErrorIs(t, validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/b", nil)), openapi.ErrOperationNotFound)
+
+			var b *openapi.RefOrSpec[openapi.Extendable[openapi.PathItem]]
+			require.NoError(t, yaml.Unmarshal([]byte("get:\n  operationId: getB\n"), &b))
+			spec.Spec.Paths.Spec.Paths["/b"] = b
+			require.NoError(t, tt.refresh(validator))
+			require.NoError(t, validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/a", nil)))
+			require.NoError(t, validator.ValidateHTTPRequest(httptest.NewRequest(http.MethodGet, "/b", nil)))
+		})
+	}
+}
+
+// countingLoader counts the loads of the external schemas, which are loaded once per compilation.
+type countingLoader struct {
+	loads atomic.Int32
+}
+
+func (l *countingLoader) Load(string) (any, error) {
+	l.loads.Add(1)
+	return map[string]any{"type": "string"}, nil
+}
+
+func TestValidator_Refresh_KeepsUnchangedSchemas(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		opts    []openapi.ValidationOption
+		refresh func(v *openapi.Validator) error
+	}{
+		{
+			name:    "refresh",
+			refresh: (*openapi.Validator).Refresh,
+		},
+		{
+			name: "invalidate component",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("/components/schemas/ID")
+			},
+		},
+		{
+			name: "invalidate member",
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("#/components")
+			},
+		},
+		{
+			name: "lazy invalidate",
+			opts: []openapi.ValidationOption{openapi.LazySpecMarshaling()},
+			refresh: func(v *openapi.Validator) error {
+				return v.Invalidate("/components/schemas/ID")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(`
+openapi: 3.1.1
+info:
+  title: Test
+  version: 1.0.0
+components:
+  schemas:
+    ID:
+      type: integer
+    Pet:
+      type: object
+      properties:
+        id:
+          $ref: "#/components/schemas/ID"
+    Name:
+      $ref: "http://example.com/name.json"
+`), &spec))
+			loader := &countingLoader{}
+			opts := append([]openapi.ValidationOption{openapi.UpdateCompiler(func(c *jsonschema.Compiler) {
+				c.UseLoader(loader)
+			})}, tt.opts...)
+			validator, err := openapi.NewValidator(spec, opts...)
+			require.NoError(t, err)
+			require.NoError(t, validator.ValidateData("/components/schemas/Name", "Tom"))
+			require.NoError(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"id": 1}))
+			require.EqualValues(t, 1, loader.loads.Load())
+
+			spec.Spec.Components.Spec.Schemas["ID"] = openapi.NewSchemaBuilder().Type(openapi.StringType).Build()
+			require.NoError(t, tt.refresh(validator))
+
+			// the schema referring to the changed one is compiled again
+			require.NoError(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"id": "a"}))
+			require.Error(t, validator.ValidateData("/components/schemas/Pet", map[string]any{"id": 1}))
+			// the unchanged schema is not compiled again, so the external schema is not loaded again
+			require.NoError(t, validator.ValidateData("/components/schemas/Name", "Tom"))
+			require.EqualValues(t, 1, loader.loads.Load())
+
+			spec.Spec.Components.Spec.Schemas["Name"] = openapi.NewSchemaBuilder().Ref("http://example.com/other.json").Build()
+			require.NoError(t, validator.Invalidate("/components/schemas/Name"))
+			require.NoError(t, validator.ValidateData("/components/schemas/Name", "Tom"))
+			require.EqualValues(t, 2, loader.loads.Load())
+		})
+	}
+}
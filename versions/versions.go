@@ -0,0 +1,236 @@
+// Package versions hosts several major versions of the same API, like `v1` and `v2`,
+// e.g. to serve them by a gateway: the requests are dispatched to the versions by the base paths or a header,
+// and the changes between the versions are computed by the diff package.
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/diff"
+)
+
+// ErrUnknownVersion is returned if a request or a name does not match any version.
+var ErrUnknownVersion = errors.New("unknown version")
+
+// Version is a major version of the API.
+type Version struct {
+	// Name is the name of the version, e.g. `v1`, it is matched against the value of the version header.
+	Name string
+	// BasePath is the path prefix of the version, e.g. `/v1`;
+	// the path of the URL of the first server of the spec is used if it is empty.
+	BasePath string
+	// Spec is the spec of the version.
+	Spec *openapi.Extendable[openapi.OpenAPI]
+	// Handler serves the requests of the version, the request is passed as is.
+	Handler http.Handler
+
+	router *openapi.Router
+}
+
+type options struct {
+	header         string
+	defaultVersion string
+}
+
+// Option is a type for the options of New function.
+type Option func(*options)
+
+// ByHeader dispatches the requests having the header with the given name, e.g. `API-Version`,
+// to the version with the name equal to the value of the header, ignoring the case;
+// the header takes precedence over the base paths.
+func ByHeader(name string) Option {
+	return func(o *options) {
+		o.header = name
+	}
+}
+
+// DefaultVersion sets the version serving the requests matching no other version,
+// by default such requests are rejected with 404 status.
+func DefaultVersion(name string) Option {
+	return func(o *options) {
+		o.defaultVersion = name
+	}
+}
+
+// Versions is the set of the versions of an API and the combined router of them.
+//
+// Example:
+//
+//	api, err := versions.New([]*versions.Version{
+//		{Name: "v1", BasePath: "/v1", Spec: v1, Handler: v1Handler},
+//		{Name: "v2", BasePath: "/v2", Spec: v2, Handler: v2Handler},
+//	}, versions.ByHeader("API-Version"))
+//	...
+//	http.ListenAndServe(":8080", api)
+type Versions struct {
+	versions       []*Version
+	byName         map[string]*Version
+	header         string
+	defaultVersion *Version
+}
+
+// New creates the set of the given versions; an error is returned if the names of the versions are empty or duplicated,
+// a spec can not be routed, or the default version is unknown.
+func New(versions []*Version, opts ...Option) (*Versions, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	s := &Versions{
+		versions: versions,
+		byName:   make(map[string]*Version, len(versions)),
+		header:   o.header,
+	}
+	for i, v := range versions {
+		if v.Name == "" {
+			return nil, fmt.Errorf("version #%d: name: %w", i, openapi.ErrRequired)
+		}
+		key := strings.ToLower(v.Name)
+		if _, ok := s.byName[key]; ok {
+			return nil, fmt.Errorf("version %q: %w", v.Name, openapi.ErrDuplicate)
+		}
+		s.byName[key] = v
+		router, err := openapi.NewRouter(v.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("version %q: %w", v.Name, err)
+		}
+		v.router = router
+		if v.BasePath == "" {
+			if v.BasePath, err = serverBasePath(v.Spec); err != nil {
+				return nil, fmt.Errorf("version %q: %w", v.Name, err)
+			}
+		}
+		v.BasePath = strings.TrimSuffix(v.BasePath, "/")
+	}
+	if o.defaultVersion != "" {
+		s.defaultVersion = s.byName[strings.ToLower(o.defaultVersion)]
+		if s.defaultVersion == nil {
+			return nil, fmt.Errorf("default version %q: %w", o.defaultVersion, ErrUnknownVersion)
+		}
+	}
+	return s, nil
+}
+
+// Get returns the version by its name, ignoring the case.
+func (s *Versions) Get(name string) (*Version, error) {
+	if v, ok := s.byName[strings.ToLower(name)]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("version %q: %w", name, ErrUnknownVersion)
+}
+
+// Versions returns all versions in the order they were given to New function.
+func (s *Versions) Versions() []*Version {
+	return s.versions
+}
+
+// Version returns the version of the request: the one named by the header, if ByHeader option is used,
+// then the one with the longest base path matching the path of the request, and then the default version.
+func (s *Versions) Version(r *http.Request) (*Version, error) {
+	if s.header != "" {
+		if name := strings.TrimSpace(r.Header.Get(s.header)); name != "" {
+			return s.Get(name)
+		}
+	}
+	path := r.URL.EscapedPath()
+	var found *Version
+	for _, v := range s.versions {
+		if !hasBasePath(path, v.BasePath) {
+			continue
+		}
+		if found == nil || len(v.BasePath) > len(found.BasePath) {
+			found = v
+		}
+	}
+	if found == nil {
+		found = s.defaultVersion
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%s %s: %w", r.Method, path, ErrUnknownVersion)
+	}
+	return found, nil
+}
+
+// Match finds the version of the request and the operation of its spec, see openapi.Router;
+// the path is matched as is and then without the base path of the version.
+func (s *Versions) Match(r *http.Request) (*Version, *openapi.RouteMatch, error) {
+	v, err := s.Version(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := r.URL.EscapedPath()
+	match, err := v.router.Match(r.Method, path)
+	if err != nil && v.BasePath != "" {
+		// the base path is not a part of the servers of the spec
+		if rest := strings.TrimPrefix(path, v.BasePath); rest != "" {
+			match, err = v.router.Match(r.Method, rest)
+		} else {
+			match, err = v.router.Match(r.Method, "/")
+		}
+	}
+	if err != nil {
+		return v, nil, fmt.Errorf("version %q: %w", v.Name, err)
+	}
+	return v, match, nil
+}
+
+// ServeHTTP implements http.Handler interface, it dispatches the request to the handler of its version,
+// which can be taken from the context of the request using FromContext function.
+// The requests matching no version are rejected with 404 status, so as the versions without the handlers.
+func (s *Versions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v, err := s.Version(r)
+	if err != nil || v.Handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	v.Handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), versionKey{}, v)))
+}
+
+// Diff returns the changes between the specs of the given versions, see diff.Diff.
+func (s *Versions) Diff(from, to string) (diff.ChangeSet, error) {
+	oldVersion, err := s.Get(from)
+	if err != nil {
+		return nil, err
+	}
+	newVersion, err := s.Get(to)
+	if err != nil {
+		return nil, err
+	}
+	return diff.Diff(oldVersion.Spec, newVersion.Spec)
+}
+
+type versionKey struct{}
+
+// FromContext returns the version of the request dispatched by Versions, nil if there is no version.
+func FromContext(ctx context.Context) *Version {
+	v, _ := ctx.Value(versionKey{}).(*Version)
+	return v
+}
+
+// serverBasePath returns the path of the URL of the first server of the spec, the variables use their default values.
+func serverBasePath(spec *openapi.Extendable[openapi.OpenAPI]) (string, error) {
+	if spec == nil || spec.Spec == nil || len(spec.Spec.Servers) == 0 || spec.Spec.Servers[0].Spec == nil {
+		return "", nil
+	}
+	raw, err := spec.Spec.Servers[0].Spec.ResolveURL(nil)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: server URL %q: %w", openapi.ErrInvalidFormat, raw, err)
+	}
+	return u.EscapedPath(), nil
+}
+
+// hasBasePath reports whether the path is under the base path, the empty base path matches any path.
+func hasBasePath(path, base string) bool {
+	rest, ok := strings.CutPrefix(path, base)
+	return ok && (rest == "" || rest[0] == '/' || base == "")
+}
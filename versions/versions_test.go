@@ -0,0 +1,144 @@
+package versions_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+	"github.com/sv-tools/openapi/versions"
+)
+
+const (
+	testV1 = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+  /pets/{id}:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+	testV2 = `
+openapi: 3.1.1
+info:
+  title: Pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+`
+)
+
+func newSpec(t *testing.T, data string) *openapi.Extendable[openapi.OpenAPI] {
+	t.Helper()
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(data), &spec))
+	return spec
+}
+
+func newVersions(t *testing.T, opts ...versions.Option) *versions.Versions {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, versions.FromContext(r.Context()).Name)
+	})
+	api, err := versions.New([]*versions.Version{
+		{Name: "v1", Spec: newSpec(t, testV1), Handler: handler},
+		{Name: "v2", BasePath: "/v2/", Spec: newSpec(t, testV2), Handler: handler},
+	}, opts...)
+	require.NoError(t, err)
+	return api
+}
+
+func TestVersions_ServeHTTP(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		path     string
+		header   string
+		opts     []versions.Option
+		status   int
+		expected string
+	}{
+		{name: "server base path", path: "/v1/pets", status: http.StatusOK, expected: "v1"},
+		{name: "base path", path: "/v2/pets", status: http.StatusOK, expected: "v2"},
+		{name: "header", path: "/pets", header: "V2", opts: []versions.Option{versions.ByHeader("API-Version")}, status: http.StatusOK, expected: "v2"},
+		{name: "header over path", path: "/v1/pets", header: "v2", opts: []versions.Option{versions.ByHeader("API-Version")}, status: http.StatusOK, expected: "v2"},
+		{name: "unknown header", path: "/v1/pets", header: "v3", opts: []versions.Option{versions.ByHeader("API-Version")}, status: http.StatusNotFound},
+		{name: "default", path: "/pets", opts: []versions.Option{versions.DefaultVersion("v2")}, status: http.StatusOK, expected: "v2"},
+		{name: "not found", path: "/v10/pets", status: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set("API-Version", tt.header)
+			}
+			w := httptest.NewRecorder()
+			newVersions(t, tt.opts...).ServeHTTP(w, r)
+			require.Equal(t, tt.status, w.Code)
+			if tt.expected != "" {
+				require.Equal(t, tt.expected, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestVersions_Match(t *testing.T) {
+	api := newVersions(t)
+
+	v, match, err := api.Match(httptest.NewRequest(http.MethodGet, "/v1/pets/42", nil))
+	require.NoError(t, err)
+	require.Equal(t, "v1", v.Name)
+	require.Equal(t, "/pets/{id}", match.Template)
+	require.Equal(t, map[string]string{"id": "42"}, match.Params)
+
+	v, match, err = api.Match(httptest.NewRequest(http.MethodGet, "/v2/pets", nil))
+	require.NoError(t, err)
+	require.Equal(t, "v2", v.Name)
+	require.Equal(t, "/pets", match.Template)
+
+	_, _, err = api.Match(httptest.NewRequest(http.MethodGet, "/v2/pets/42", nil))
+	require.ErrorIs(t, err, openapi.ErrOperationNotFound)
+
+	_, _, err = api.Match(httptest.NewRequest(http.MethodGet, "/pets", nil))
+	require.ErrorIs(t, err, versions.ErrUnknownVersion)
+}
+
+func TestVersions_Diff(t *testing.T) {
+	api := newVersions(t)
+
+	changes, err := api.Diff("v1", "v2")
+	require.NoError(t, err)
+	require.Len(t, changes.BreakingChanges(), 1)
+	require.Equal(t, `/paths/~1pets~1{id}: path "/pets/{id}" removed (breaking)`, changes[0].String())
+
+	_, err = api.Diff("v1", "v3")
+	require.ErrorIs(t, err, versions.ErrUnknownVersion)
+}
+
+func TestNew(t *testing.T) {
+	_, err := versions.New([]*versions.Version{{Name: "v1"}, {Name: "V1"}})
+	require.ErrorIs(t, err, openapi.ErrDuplicate)
+
+	_, err = versions.New([]*versions.Version{{}})
+	require.ErrorIs(t, err, openapi.ErrRequired)
+
+	_, err = versions.New([]*versions.Version{{Name: "v1"}}, versions.DefaultVersion("v2"))
+	require.ErrorIs(t, err, versions.ErrUnknownVersion)
+}
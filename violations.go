@@ -0,0 +1,234 @@
+package openapi
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Violation is a failed keyword of a schema for a value of the validated data,
+// e.g. to return the list of the invalid fields by an API.
+type Violation struct {
+	// Location is the JSON Pointer of the invalid value in the validated data, e.g. `/pets/0/name`,
+	// it is empty for the root value.
+	Location Location `json:"location"`
+	// Keyword is the failed keyword of the schema, e.g. `minimum` or `required`.
+	Keyword string `json:"keyword,omitempty"`
+	// Expected is the value required by the keyword, e.g. the minimum, the types, or the missing properties.
+	Expected any `json:"expected,omitempty"`
+	// Actual is the invalid value or its part checked by the keyword, e.g. the type or the length of the value.
+	Actual any `json:"actual,omitempty"`
+	// Message is the description of the violation, see ErrorMessageExt to override it.
+	Message string `json:"message"`
+
+	// schemaURL is the absolute location of the failed schema
+	schemaURL string
+}
+
+// String implements fmt.Stringer interface.
+func (v *Violation) String() string {
+	if v.Location == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s", v.Location, v.Message)
+}
+
+// ViolationsError is the error of ValidateData with the flattened violations, see MaxViolations option.
+// It wraps the original error of the schema validation, so MessageIDOf and errors.As work as usual.
+type ViolationsError struct {
+	// Violations are the violations in the order of the validation, up to the maximum.
+	Violations []*Violation
+	// Total is the number of all violations, it is greater than the length of Violations if the list is capped.
+	Total int
+	err   error
+}
+
+// Error implements error interface.
+func (e *ViolationsError) Error() string {
+	items := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		items[i] = v.String()
+	}
+	msg := strings.Join(items, "; ")
+	if more := e.Total - len(e.Violations); more > 0 {
+		msg += fmt.Sprintf("; and %d more", more)
+	}
+	return msg
+}
+
+// Unwrap returns the original error of the schema validation.
+func (e *ViolationsError) Unwrap() error {
+	return e.err
+}
+
+// MaxViolations is a validation option to flatten the errors of ValidateData into ViolationsError,
+// the list of the violations capped at the given maximum; zero or negative maximum means no limit.
+// The errors of the parameters and the bodies of the requests and the responses wrap ViolationsError as well.
+func MaxViolations(n int) ValidationOption {
+	return func(v *validationOptions) {
+		v.flattenViolations = true
+		v.maxViolations = n
+	}
+}
+
+// Violations returns the flattened list of the violations of the schema validation error,
+// or nil if the error does not wrap jsonschema.ValidationError.
+// The violations are the failed keywords, the leaves of the error tree,
+// except `anyOf` and `oneOf` keywords reported as one violation each, since their subschemas are the alternatives.
+// If the maximum is positive, at most the given number of the violations is returned.
+func Violations(err error, maxViolations int) []*Violation {
+	var violations []*Violation
+	collectViolations(err, func(v *Violation) bool {
+		violations = append(violations, v)
+		return maxViolations <= 0 || len(violations) < maxViolations
+	})
+	return violations
+}
+
+// dataError returns ViolationsError for the error of the schema validation if MaxViolations option is set
+// or any message is overridden using ErrorMessageExt extension, otherwise the error is returned as is.
+func (v *Validator) dataError(err error) error {
+	maxViolations := -1
+	if v.opts.flattenViolations {
+		maxViolations = v.opts.maxViolations
+	}
+	e := &ViolationsError{err: err}
+	var overridden bool
+	collectViolations(err, func(violation *Violation) bool {
+		if msg, ok := v.errorMessage(violation); ok {
+			violation.Message = msg
+			overridden = true
+		}
+		if maxViolations <= 0 || len(e.Violations) < maxViolations {
+			e.Violations = append(e.Violations, violation)
+		}
+		e.Total++
+		return true
+	})
+	if e.Total == 0 || !v.opts.flattenViolations && !overridden {
+		return err
+	}
+	return e
+}
+
+var violationPrinter = message.NewPrinter(language.English)
+
+// collectViolations calls the given function for each violation of the error until it returns false.
+// The violations of ViolationsError are used as is.
+func collectViolations(err error, f func(*Violation) bool) bool {
+	switch t := err.(type) {
+	case *ViolationsError:
+		for _, v := range t.Violations {
+			if !f(v) {
+				return false
+			}
+		}
+	case *jsonschema.ValidationError:
+		return walkViolations(t, f)
+	case interface{ Unwrap() []error }:
+		// the joined errors, e.g. of the request validation
+		for _, err := range t.Unwrap() {
+			if !collectViolations(err, f) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return collectViolations(t.Unwrap(), f)
+	}
+	return true
+}
+
+func walkViolations(e *jsonschema.ValidationError, f func(*Violation) bool) bool {
+	switch e.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+		return f(newViolation(e))
+	}
+	if len(e.Causes) == 0 {
+		return f(newViolation(e))
+	}
+	for _, c := range e.Causes {
+		if !walkViolations(c, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func newViolation(e *jsonschema.ValidationError) *Violation {
+	v := &Violation{
+		Location:  Location("").Join(anySlice(e.InstanceLocation)...),
+		Message:   e.ErrorKind.LocalizedString(violationPrinter),
+		schemaURL: e.SchemaURL,
+	}
+	if path := e.ErrorKind.KeywordPath(); len(path) > 0 {
+		v.Keyword = path[0]
+	}
+	switch k := e.ErrorKind.(type) {
+	case *kind.Type:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Enum:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Const:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Format:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Pattern:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Required:
+		v.Expected = k.Missing
+	case *kind.DependentRequired:
+		v.Expected = k.Missing
+	case *kind.Dependency:
+		v.Expected = k.Missing
+	case *kind.AdditionalProperties:
+		v.Actual = k.Properties
+	case *kind.MinLength:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.MaxLength:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.MinItems:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.MaxItems:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.MinProperties:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.MaxProperties:
+		v.Expected, v.Actual = k.Want, k.Got
+	case *kind.Minimum:
+		v.Expected, v.Actual = ratValue(k.Want), ratValue(k.Got)
+	case *kind.Maximum:
+		v.Expected, v.Actual = ratValue(k.Want), ratValue(k.Got)
+	case *kind.ExclusiveMinimum:
+		v.Expected, v.Actual = ratValue(k.Want), ratValue(k.Got)
+	case *kind.ExclusiveMaximum:
+		v.Expected, v.Actual = ratValue(k.Want), ratValue(k.Got)
+	case *kind.MultipleOf:
+		v.Expected, v.Actual = ratValue(k.Want), ratValue(k.Got)
+	}
+	return v
+}
+
+// ratValue converts the number to int64 if it is an integer or to float64 otherwise.
+func ratValue(r *big.Rat) any {
+	if r == nil {
+		return nil
+	}
+	if r.IsInt() && r.Num().IsInt64() {
+		return r.Num().Int64()
+	}
+	f, _ := r.Float64()
+	return f
+}
+
+func anySlice[T any](s []T) []any {
+	res := make([]any, len(s))
+	for i, v := range s {
+		res[i] = v
+	}
+	return res
+}
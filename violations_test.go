@@ -0,0 +1,126 @@
+package openapi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testViolationsSpec = `
+openapi: 3.1.1
+info:
+  title: Violations
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      properties:
+        name:
+          type: string
+          minLength: 2
+        age:
+          type: integer
+          minimum: 0
+        kind:
+          type: string
+          enum: [cat, dog]
+        tag:
+          oneOf:
+            - type: string
+            - type: integer
+`
+
+const testInvalidPet = `{"name": "R", "age": -1.5, "kind": "fish", "tag": true}`
+
+func TestViolations(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testViolationsSpec), &spec))
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.ValidateStringDataAsJSON())
+	require.NoError(t, err)
+	err = validator.ValidateData("#/components/schemas/Pet", testInvalidPet)
+	require.Error(t, err)
+
+	violations := openapi.Violations(err, 0)
+	byLocation := make(map[openapi.Location]*openapi.Violation, len(violations))
+	for _, v := range violations {
+		byLocation[v.Location] = v
+	}
+	require.Len(t, byLocation, 4)
+
+	name := byLocation["/name"]
+	require.Equal(t, "minLength", name.Keyword)
+	require.Equal(t, 2, name.Expected)
+	require.Equal(t, 1, name.Actual)
+	require.NotEmpty(t, name.Message)
+
+	age := byLocation["/age"]
+	require.Contains(t, []string{"type", "minimum"}, age.Keyword)
+
+	kind := byLocation["/kind"]
+	require.Equal(t, "enum", kind.Keyword)
+	require.Equal(t, []any{"cat", "dog"}, kind.Expected)
+	require.Equal(t, "fish", kind.Actual)
+
+	// the alternatives of oneOf are reported as one violation
+	require.Equal(t, "oneOf", byLocation["/tag"].Keyword)
+
+	require.Len(t, openapi.Violations(err, 2), 2)
+	require.Nil(t, openapi.Violations(errors.New("other"), 0))
+	require.Nil(t, openapi.Violations(nil, 0))
+
+	err = validator.ValidateData("#/components/schemas/Pet", `{}`)
+	violations = openapi.Violations(err, 0)
+	require.Len(t, violations, 1)
+	require.Empty(t, violations[0].Location)
+	require.Equal(t, "required", violations[0].Keyword)
+	require.Equal(t, []string{"name", "kind"}, violations[0].Expected)
+	require.Nil(t, violations[0].Actual)
+}
+
+func TestMaxViolations(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testViolationsSpec), &spec))
+	validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents(), openapi.ValidateStringDataAsJSON(), openapi.MaxViolations(2))
+	require.NoError(t, err)
+	err = validator.ValidateData("#/components/schemas/Pet", testInvalidPet)
+
+	var violationsErr *openapi.ViolationsError
+	require.ErrorAs(t, err, &violationsErr)
+	require.Len(t, violationsErr.Violations, 2)
+	require.Equal(t, 4, violationsErr.Total)
+	require.True(t, strings.HasSuffix(err.Error(), "; and 2 more"), err.Error())
+	require.Equal(t, openapi.MsgSchemaViolation, openapi.MessageIDOf(err))
+
+	require.NoError(t, validator.ValidateData("#/components/schemas/Pet", `{"name": "Rex", "kind": "dog"}`))
+}
+
+func TestMaxViolations_Request(t *testing.T) {
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, yaml.Unmarshal([]byte(testRequestSpec), &spec))
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	limited, err := openapi.NewValidator(spec, openapi.MaxViolations(0))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name": 1}`))
+	r.Header.Set("Content-Type", "application/json")
+	err = validator.ValidateHTTPRequest(r)
+	require.Error(t, err)
+	require.NotEmpty(t, openapi.Violations(err, 0))
+
+	r = httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name": 1}`))
+	r.Header.Set("Content-Type", "application/json")
+	err = limited.ValidateHTTPRequest(r)
+	var violationsErr *openapi.ViolationsError
+	require.ErrorAs(t, err, &violationsErr)
+	require.Equal(t, openapi.Location("/name"), violationsErr.Violations[0].Location)
+}
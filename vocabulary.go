@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// The vocabularies of JSON Schema draft 2020-12 and OpenAPI v3.1 used by `$vocabulary` keyword.
+//
+// https://json-schema.org/draft/2020-12/json-schema-core#name-json-schema-core-vocabulary
+const (
+	VocabularyCore             = "https://json-schema.org/draft/2020-12/vocab/core"
+	VocabularyApplicator       = "https://json-schema.org/draft/2020-12/vocab/applicator"
+	VocabularyUnevaluated      = "https://json-schema.org/draft/2020-12/vocab/unevaluated"
+	VocabularyValidation       = "https://json-schema.org/draft/2020-12/vocab/validation"
+	VocabularyMetaData         = "https://json-schema.org/draft/2020-12/vocab/meta-data"
+	VocabularyFormatAnnotation = "https://json-schema.org/draft/2020-12/vocab/format-annotation"
+	VocabularyFormatAssertion  = "https://json-schema.org/draft/2020-12/vocab/format-assertion"
+	VocabularyContent          = "https://json-schema.org/draft/2020-12/vocab/content"
+	VocabularyOASBase          = "https://spec.openapis.org/oas/3.1/vocab/base"
+)
+
+var vocabularyKeywords = map[string][]string{
+	VocabularyCore:             {"$schema", "$id", "$ref", "$anchor", "$dynamicRef", "$dynamicAnchor", "$vocabulary", "$comment", "$defs"},
+	VocabularyApplicator:       {"prefixItems", "items", "contains", "additionalProperties", "properties", "patternProperties", "dependentSchemas", "propertyNames", "if", "then", "else", "allOf", "anyOf", "oneOf", "not"},
+	VocabularyUnevaluated:      {"unevaluatedItems", "unevaluatedProperties"},
+	VocabularyValidation:       {"type", "const", "enum", "multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "maxContains", "minContains", "maxProperties", "minProperties", "required", "dependentRequired"},
+	VocabularyMetaData:         {"title", "description", "default", "deprecated", "readOnly", "writeOnly", "examples"},
+	VocabularyFormatAnnotation: {"format"},
+	VocabularyContent:          {"contentEncoding", "contentMediaType", "contentSchema"},
+	VocabularyOASBase:          {"discriminator", "xml", "externalDocs", "example"},
+}
+
+// keywordVocabularies maps the keywords to their vocabularies,
+// the `format` keyword is mapped to the annotation vocabulary, but the assertion one is accepted as well.
+var keywordVocabularies = func() map[string]string {
+	m := make(map[string]string)
+	for vocab, keywords := range vocabularyKeywords {
+		for _, k := range keywords {
+			m[k] = vocab
+		}
+	}
+	return m
+}()
+
+// VocabularyOf returns the vocabulary of the given keyword or an empty string for the unknown keywords.
+func VocabularyOf(keyword string) string {
+	return keywordVocabularies[keyword]
+}
+
+// Keywords returns the sorted list of the keywords used by the schema itself, without its subschemas.
+// The unknown keywords are included, the extensions (`x-` prefix) are not.
+func (o *Schema) Keywords() []string {
+	return schemaKeywords(schemaJSONValue(o))
+}
+
+// Vocabularies returns the sorted list of the known vocabularies the keywords of the schema
+// and its inline subschemas rely on, e.g. to check whether the schema can be used with another dialect.
+func (o *Schema) Vocabularies() []string {
+	used := make(map[string]bool)
+	walkSchemaJSON(schemaJSONValue(o), "", func(_ string, obj map[string]any) {
+		for _, k := range schemaKeywords(obj) {
+			if vocab := VocabularyOf(k); vocab != "" {
+				used[vocab] = true
+			}
+		}
+	})
+	return sortedKeys(used)
+}
+
+// schemaJSONValue returns the JSON representation of the schema, the errors are ignored.
+func schemaJSONValue(o *Schema) any {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+func schemaKeywords(v any) []string {
+	obj, _ := v.(map[string]any)
+	keywords := make([]string, 0, len(obj))
+	for k := range obj {
+		if !strings.HasPrefix(k, ExtensionPrefix) {
+			keywords = append(keywords, k)
+		}
+	}
+	slices.Sort(keywords)
+	return keywords
+}
+
+var (
+	// subschemaKeywords are the keywords having a schema as the value.
+	subschemaKeywords = []string{"items", "contains", "additionalProperties", "propertyNames", "if", "then", "else", "not", "unevaluatedItems", "unevaluatedProperties", "contentSchema"}
+	// subschemaListKeywords are the keywords having a list of schemas as the value.
+	subschemaListKeywords = []string{"prefixItems", "allOf", "anyOf", "oneOf"}
+	// subschemaMapKeywords are the keywords having a map of schemas as the value.
+	subschemaMapKeywords = []string{"properties", "patternProperties", "dependentSchemas", "$defs"}
+)
+
+// walkSchemaJSON calls the visit function for the JSON representation of the schema and its inline subschemas,
+// the boolean schemas are skipped.
+func walkSchemaJSON(v any, location string, visit func(location string, obj map[string]any)) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	visit(location, obj)
+	for _, k := range subschemaKeywords {
+		if s, ok := obj[k]; ok {
+			walkSchemaJSON(s, joinLoc(location, k), visit)
+		}
+	}
+	for _, k := range subschemaListKeywords {
+		list, _ := obj[k].([]any)
+		for i, s := range list {
+			walkSchemaJSON(s, joinLoc(location, k, i), visit)
+		}
+	}
+	for _, k := range subschemaMapKeywords {
+		m, _ := obj[k].(map[string]any)
+		for _, name := range sortedKeys(m) {
+			walkSchemaJSON(m[name], joinLoc(location, k, name), visit)
+		}
+	}
+}
+
+// validateVocabulary checks that the known keywords of the schema and its inline subschemas
+// belong to the vocabularies required by the `$vocabulary` keyword.
+func (o *Schema) validateVocabulary(location string) []*validationError {
+	if len(o.Vocabulary) == 0 {
+		return nil
+	}
+	var errs []*validationError
+	for _, vocab := range sortedKeys(o.Vocabulary) {
+		if err := checkAbsoluteURL(vocab); err != nil {
+			errs = append(errs, newValidationError(joinLoc(location, "$vocabulary", vocab), err))
+		}
+	}
+	if required, ok := o.Vocabulary[VocabularyCore]; ok && !required {
+		errs = append(errs, newValidationError(joinLoc(location, "$vocabulary", VocabularyCore), "%w: the core vocabulary must be required", ErrInvalidValue))
+	}
+	walkSchemaJSON(schemaJSONValue(o), location, func(loc string, obj map[string]any) {
+		for _, k := range schemaKeywords(obj) {
+			vocab := VocabularyOf(k)
+			switch {
+			case vocab == "", vocab == VocabularyCore, o.Vocabulary[vocab]:
+			case vocab == VocabularyFormatAnnotation && o.Vocabulary[VocabularyFormatAssertion]:
+			default:
+				errs = append(errs, newValidationError(joinLoc(loc, k), "%w: the keyword belongs to %s vocabulary, which is not required by $vocabulary", ErrNotAllowed, vocab))
+			}
+		}
+	})
+	return errs
+}
@@ -0,0 +1,95 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestSchema_Keywords(t *testing.T) {
+	schema := openapi.NewSchemaBuilder().
+		Type(openapi.ObjectType).
+		Title("Pet").
+		AddExt("x-internal", true).
+		AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Format("uuid").Build()).
+		Build()
+	require.Equal(t, []string{"$schema", "properties", "title", "type"}, schema.Spec.Keywords())
+	require.Equal(t, []string{
+		openapi.VocabularyApplicator,
+		openapi.VocabularyCore,
+		openapi.VocabularyFormatAnnotation,
+		openapi.VocabularyMetaData,
+		openapi.VocabularyValidation,
+	}, schema.Spec.Vocabularies())
+	require.Equal(t, openapi.VocabularyOASBase, openapi.VocabularyOf("discriminator"))
+	require.Empty(t, openapi.VocabularyOf("unknown"))
+}
+
+func TestSchema_ValidateSpec_Vocabulary(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		vocabulary map[string]bool
+		errs       []string
+	}{
+		{
+			name: "all required",
+			vocabulary: map[string]bool{
+				openapi.VocabularyCore:             true,
+				openapi.VocabularyApplicator:       true,
+				openapi.VocabularyValidation:       true,
+				openapi.VocabularyMetaData:         true,
+				openapi.VocabularyFormatAnnotation: true,
+			},
+		},
+		{
+			name: "format assertion",
+			vocabulary: map[string]bool{
+				openapi.VocabularyApplicator:      true,
+				openapi.VocabularyValidation:      true,
+				openapi.VocabularyMetaData:        true,
+				openapi.VocabularyFormatAssertion: true,
+			},
+		},
+		{
+			name: "optional and missing",
+			vocabulary: map[string]bool{
+				openapi.VocabularyCore:       false,
+				openapi.VocabularyApplicator: true,
+				openapi.VocabularyValidation: true,
+				openapi.VocabularyMetaData:   false,
+			},
+			errs: []string{
+				"/components/schemas/Pet/$vocabulary/https:~1~1json-schema.org~1draft~12020-12~1vocab~1core: invalid value: the core vocabulary must be required",
+				"/components/schemas/Pet/title: not allowed: the keyword belongs to https://json-schema.org/draft/2020-12/vocab/meta-data vocabulary",
+				"/components/schemas/Pet/properties/name/format: not allowed: the keyword belongs to https://json-schema.org/draft/2020-12/vocab/format-annotation vocabulary",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			components := openapi.NewComponents()
+			components.Spec.Add("Pet", openapi.NewSchemaBuilder().
+				Vocabulary(tt.vocabulary).
+				Type(openapi.ObjectType).
+				Title("Pet").
+				AddProperty("name", openapi.NewSchemaBuilder().Type(openapi.StringType).Format("uuid").Build()).
+				Build())
+			spec := openapi.NewOpenAPIBuilder().
+				Info(openapi.NewInfoBuilder().Title("Vocabularies").Version("1.0.0").Build()).
+				Components(components).
+				Build()
+			validator, err := openapi.NewValidator(spec, openapi.AllowUnusedComponents())
+			require.NoError(t, err)
+			err = validator.ValidateSpec()
+			if len(tt.errs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, openapi.ErrNotAllowed)
+			for _, e := range tt.errs {
+				require.ErrorContains(t, err, e)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrSkipChildren is returned by WalkFunc to skip the children of the visited object.
+var ErrSkipChildren = errors.New("skip children")
+
+// WalkNode is an object of the spec visited by Walk.
+type WalkNode struct {
+	// Value is the pointer to the object, e.g. *Info, *Operation, *Parameter, *Schema, or *Ref for the references.
+	//
+	// The object can be modified in place or replaced by setting the Value to another pointer of the same type,
+	// then the new object is set to the spec and its children are walked.
+	Value any
+	// Extensions points to the extensions of the object, so they can be added or removed;
+	// it is nil for the objects having no extensions, e.g. *Ref.
+	Extensions *map[string]any
+	// Location is the location of the object in the spec as JSON Pointer, e.g. `/paths/~1pets/get`.
+	Location Location
+}
+
+// WalkFunc is the function called by Walk for each visited object.
+//
+// Returning ErrSkipChildren skips the children of the object, any other error stops the walk and is returned by Walk.
+type WalkFunc func(node *WalkNode) error
+
+// Walk visits all objects of the spec in depth-first order, starting from the spec itself.
+// The children are visited in the order of the fields and the sorted keys of the maps.
+//
+// The objects are visited as they are defined, the references are not followed;
+// an object reachable by several pointers, e.g. created by the builders, is visited once.
+// The values of `any` type (extensions, examples, defaults, etc.) hold raw data and are not visited.
+//
+// Example:
+//
+//	// remove the internal extensions
+//	err := openapi.Walk(spec, func(node *openapi.WalkNode) error {
+//		if node.Extensions != nil {
+//			delete(*node.Extensions, "x-internal")
+//		}
+//		return nil
+//	})
+func Walk(spec *Extendable[OpenAPI], fn WalkFunc) error {
+	if spec == nil {
+		return nil
+	}
+	w := &walker{fn: fn, visited: make(map[walkKey]bool)}
+	return spec.walk(w, "")
+}
+
+// walkable is implemented by the wrappers of the objects, which are not visited as the objects themselves.
+type walkable interface {
+	walk(w *walker, location string) error
+}
+
+func (o *Extendable[T]) walk(w *walker, location string) error {
+	if o.Spec == nil {
+		return nil
+	}
+	return w.visit(reflect.ValueOf(&o.Spec).Elem(), &o.Extensions, location)
+}
+
+func (o *RefOrSpec[T]) walk(w *walker, location string) error {
+	if o.Ref != nil {
+		return w.visit(reflect.ValueOf(&o.Ref).Elem(), nil, location)
+	}
+	if o.Spec == nil {
+		return nil
+	}
+	if inner, ok := any(o.Spec).(walkable); ok {
+		return inner.walk(w, location)
+	}
+	return w.visit(reflect.ValueOf(&o.Spec).Elem(), nil, location)
+}
+
+type walkKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// walker holds the state of the walk over a spec.
+type walker struct {
+	fn      WalkFunc
+	visited map[walkKey]bool
+}
+
+// visit calls the walk function for the object given as settable pointer and walks its children.
+// If the extensions are not given, the Extensions field of the object is used, e.g. for Schema.
+func (w *walker) visit(v reflect.Value, extensions *map[string]any, location string) error {
+	if v.IsNil() {
+		return nil
+	}
+	key := walkKey{typ: v.Type(), ptr: v.Pointer()}
+	if w.visited[key] {
+		return nil
+	}
+	w.visited[key] = true
+
+	node := &WalkNode{Value: v.Interface(), Extensions: extensions, Location: Location(location)}
+	if node.Extensions == nil {
+		if f := v.Elem().FieldByName("Extensions"); f.IsValid() {
+			node.Extensions, _ = f.Addr().Interface().(*map[string]any)
+		}
+	}
+	err := w.fn(node)
+	if err != nil && !errors.Is(err, ErrSkipChildren) {
+		return err
+	}
+	if node.Value != v.Interface() {
+		replacement := reflect.ValueOf(node.Value)
+		if node.Value == nil || replacement.Type() != v.Type() || replacement.IsNil() {
+			return fmt.Errorf("%s: %w: %s expected to replace the object, but got %T", location, ErrInvalidValue, v.Type(), node.Value)
+		}
+		v.Set(replacement)
+		w.visited[walkKey{typ: v.Type(), ptr: v.Pointer()}] = true
+	}
+	if err != nil {
+		return nil
+	}
+	return w.walkFields(v.Elem(), location)
+}
+
+// walkFields walks the fields of the struct, the fields without names in JSON, e.g. the paths of Paths object,
+// are inlined into the location of the struct.
+func (w *walker) walkFields(v reflect.Value, location string) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		loc := location
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+			loc = joinLoc(location, name)
+		}
+		if err := w.walkValue(v.Field(i), loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *walker) walkValue(v reflect.Value, location string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if inner, ok := v.Interface().(walkable); ok {
+			return inner.walk(w, location)
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			return w.walkValue(v.Elem(), location)
+		}
+		if v.Type() == reflect.TypeOf((*BoolOrSchema)(nil)) {
+			// the wrapper of the schema
+			return w.walkFields(v.Elem(), location)
+		}
+		return w.visit(v, nil, location)
+	case reflect.Struct:
+		return w.walkFields(v, location)
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, k := range keys {
+			// the map values are not settable, so a copy is walked and set back if the object is replaced
+			item := reflect.New(v.Type().Elem()).Elem()
+			item.Set(v.MapIndex(k))
+			if err := w.walkValue(item, joinLoc(location, k.String())); err != nil {
+				return err
+			}
+			if item.Kind() == reflect.Pointer && item.Pointer() != v.MapIndex(k).Pointer() {
+				v.SetMapIndex(k, item)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walkValue(v.Index(i), joinLoc(location, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
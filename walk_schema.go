@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SchemaNode is a schema visited by WalkSchema.
+type SchemaNode struct {
+	// Schema is the visited schema, the references are resolved, so it is never a reference itself,
+	// but it can have `$ref` keyword along with the sibling keywords, see KeepRefSiblings.
+	Schema *Schema
+	// Ref is the reference the schema is reached by, e.g. `#/components/schemas/Pet`, empty for the inline schemas.
+	Ref string
+	// Location is the location of the schema relative to the root schema as JSON Pointer of the keywords,
+	// e.g. `/properties/tags/items`; the referenced schemas are located at their references.
+	Location Location
+	// Parent is the node of the schema containing the visited one, nil for the root schema.
+	Parent *SchemaNode
+}
+
+// SchemaWalkFunc is the function called by WalkSchema for each visited schema.
+//
+// Returning ErrSkipChildren skips the subschemas of the schema, any other error stops the walk and is returned by WalkSchema.
+type SchemaWalkFunc func(node *SchemaNode) error
+
+// WalkSchema visits the given schema and all its subschemas in depth-first order:
+// `$ref`, `$defs`, `$dynamicRef`, `contentSchema`, the applicators (`not`, `allOf`, `anyOf`, `oneOf`,
+// `dependentSchemas`, `if`, `then`, `else`), and the subschemas of the arrays and the objects,
+// in the order of the fields of Schema and the sorted keys of the maps.
+//
+// The references are resolved using the given components, each of them once;
+// every schema is visited once, even if it is reachable by several references,
+// so the recursive schemas do not cause the loops.
+// An error wrapping ErrUnresolvedRef is returned if a reference can not be resolved.
+//
+// Example:
+//
+//	// collect the formats used by the schema
+//	formats := make(map[string]bool)
+//	err := openapi.WalkSchema(schema, spec.Spec.Components, func(node *openapi.SchemaNode) error {
+//		if node.Schema.Format != "" {
+//			formats[node.Schema.Format] = true
+//		}
+//		return nil
+//	})
+func WalkSchema(root *RefOrSpec[Schema], components *Extendable[Components], fn SchemaWalkFunc) error {
+	w := &schemaWalker{
+		fn:         fn,
+		components: components,
+		refs:       make(map[string]*Schema),
+		visited:    make(map[*Schema]bool),
+	}
+	return w.walkRef(root, nil, "")
+}
+
+type schemaWalker struct {
+	fn         SchemaWalkFunc
+	components *Extendable[Components]
+	// refs are the resolved references
+	refs    map[string]*Schema
+	visited map[*Schema]bool
+}
+
+func (w *schemaWalker) walkRef(o *RefOrSpec[Schema], parent *SchemaNode, location string) error {
+	switch {
+	case o == nil:
+		return nil
+	case o.Ref != nil:
+		return w.walkRefString(o.Ref.Ref, parent, location)
+	default:
+		return w.walk(o.Spec, "", parent, location)
+	}
+}
+
+func (w *schemaWalker) walkRefString(ref string, parent *SchemaNode, location string) error {
+	schema, ok := w.refs[ref]
+	if !ok {
+		var err error
+		schema, err = NewRefOrSpec[Schema](ref).GetSpec(w.components)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		w.refs[ref] = schema
+	}
+	return w.walk(schema, ref, parent, location)
+}
+
+func (w *schemaWalker) walk(schema *Schema, ref string, parent *SchemaNode, location string) error {
+	if schema == nil || w.visited[schema] {
+		return nil
+	}
+	w.visited[schema] = true
+	node := &SchemaNode{Schema: schema, Ref: ref, Location: Location(location), Parent: parent}
+	if err := w.fn(node); err != nil {
+		if errors.Is(err, ErrSkipChildren) {
+			return nil
+		}
+		return err
+	}
+
+	if schema.Ref != "" {
+		if err := w.walkRefString(schema.Ref, node, joinLoc(location, "$ref")); err != nil {
+			return err
+		}
+	}
+	if err := w.walkMap(schema.Defs, node, location, "$defs"); err != nil {
+		return err
+	}
+	if schema.DynamicRef != "" {
+		target, err := resolveDynamicRef(schema.DynamicRef, w.components)
+		if err != nil {
+			return fmt.Errorf("%s: %w", joinLoc(location, "$dynamicRef"), err)
+		}
+		if err := w.walkRef(target, node, joinLoc(location, "$dynamicRef")); err != nil {
+			return err
+		}
+	}
+	for _, sub := range []struct {
+		keyword string
+		schema  *RefOrSpec[Schema]
+	}{
+		{"contentSchema", schema.ContentSchema},
+		{"not", schema.Not},
+	} {
+		if err := w.walkRef(sub.schema, node, joinLoc(location, sub.keyword)); err != nil {
+			return err
+		}
+	}
+	for _, list := range []struct {
+		keyword string
+		schemas []*RefOrSpec[Schema]
+	}{
+		{"allOf", schema.AllOf},
+		{"anyOf", schema.AnyOf},
+		{"oneOf", schema.OneOf},
+	} {
+		for i, sub := range list.schemas {
+			if err := w.walkRef(sub, node, joinLoc(location, list.keyword, i)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.walkMap(schema.DependentSchemas, node, location, "dependentSchemas"); err != nil {
+		return err
+	}
+	for _, sub := range []struct {
+		keyword string
+		schema  *RefOrSpec[Schema]
+	}{
+		{"if", schema.If},
+		{"then", schema.Then},
+		{"else", schema.Else},
+		{"items", boolOrSchema(schema.Items)},
+		{"unevaluatedItems", boolOrSchema(schema.UnevaluatedItems)},
+		{"contains", schema.Contains},
+	} {
+		if err := w.walkRef(sub.schema, node, joinLoc(location, sub.keyword)); err != nil {
+			return err
+		}
+	}
+	for i, sub := range schema.PrefixItems {
+		if err := w.walkRef(sub, node, joinLoc(location, "prefixItems", i)); err != nil {
+			return err
+		}
+	}
+	if err := w.walkMap(schema.Properties, node, location, "properties"); err != nil {
+		return err
+	}
+	if err := w.walkMap(schema.PatternProperties, node, location, "patternProperties"); err != nil {
+		return err
+	}
+	for _, sub := range []struct {
+		keyword string
+		schema  *RefOrSpec[Schema]
+	}{
+		{"additionalProperties", boolOrSchema(schema.AdditionalProperties)},
+		{"unevaluatedProperties", boolOrSchema(schema.UnevaluatedProperties)},
+		{"propertyNames", schema.PropertyNames},
+	} {
+		if err := w.walkRef(sub.schema, node, joinLoc(location, sub.keyword)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *schemaWalker) walkMap(schemas map[string]*RefOrSpec[Schema], parent *SchemaNode, location, keyword string) error {
+	for _, name := range sortedKeys(schemas) {
+		if err := w.walkRef(schemas[name], parent, joinLoc(location, keyword, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boolOrSchema returns the schema of the keyword, nil for the boolean values.
+func boolOrSchema(o *BoolOrSchema) *RefOrSpec[Schema] {
+	if o == nil {
+		return nil
+	}
+	return o.Schema
+}
@@ -0,0 +1,112 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestWalkSchema(t *testing.T) {
+	const data = `
+openapi: 3.1.1
+info:
+  title: Walk
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Pet'
+        owner:
+          $ref: '#/components/schemas/Owner'
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+      oneOf:
+        - $ref: '#/components/schemas/Owner'
+      if:
+        required: [name]
+      then:
+        $defs:
+          Local:
+            type: integer
+      additionalProperties: false
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Tag:
+      type: string
+`
+	var spec *openapi.Extendable[openapi.OpenAPI]
+	require.NoError(t, openapi.Unmarshal([]byte(data), &spec))
+	components := spec.Spec.Components
+	root := openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Pet")
+
+	t.Run("all", func(t *testing.T) {
+		var visited []string
+		err := openapi.WalkSchema(root, components, func(node *openapi.SchemaNode) error {
+			visited = append(visited, node.Location.String()+" "+node.Ref)
+			if node.Parent == nil {
+				require.Empty(t, node.Location)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			" #/components/schemas/Pet",
+			"/oneOf/0 #/components/schemas/Owner",
+			"/oneOf/0/properties/pets ",
+			"/if ",
+			"/then ",
+			"/then/$defs/Local ",
+			"/properties/name ",
+			"/properties/tags ",
+			"/properties/tags/items #/components/schemas/Tag",
+		}, visited)
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var visited []string
+		err := openapi.WalkSchema(root, components, func(node *openapi.SchemaNode) error {
+			visited = append(visited, node.Location.String())
+			if node.Ref == "#/components/schemas/Owner" {
+				return openapi.ErrSkipChildren
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotContains(t, visited, "/oneOf/0/properties/pets")
+		// the owner is visited once, so its properties are not visited via the pet's owner property either
+		require.NotContains(t, visited, "/properties/owner")
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		stop := errors.New("stop")
+		var count int
+		err := openapi.WalkSchema(root, components, func(node *openapi.SchemaNode) error {
+			count++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("unresolved", func(t *testing.T) {
+		schema := openapi.NewSchemaBuilder().AddProperty("bad", openapi.NewRefOrSpec[openapi.Schema]("#/components/schemas/Missing")).Build()
+		err := openapi.WalkSchema(schema, components, func(*openapi.SchemaNode) error { return nil })
+		require.ErrorIs(t, err, openapi.ErrUnresolvedRef)
+		require.ErrorContains(t, err, "/properties/bad")
+	})
+}
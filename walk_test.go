@@ -0,0 +1,147 @@
+package openapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+const testWalkSpec = `
+openapi: 3.1.1
+info:
+  title: Walk
+  version: 1.0.0
+  x-internal: true
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      x-internal: true
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      x-internal: true
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+      additionalProperties:
+        type: integer
+`
+
+func TestWalk(t *testing.T) {
+	t.Run("locations", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testWalkSpec), &spec))
+		var schemas, refs []openapi.Location
+		require.NoError(t, openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			switch node.Value.(type) {
+			case *openapi.Schema:
+				schemas = append(schemas, node.Location)
+			case *openapi.Ref:
+				refs = append(refs, node.Location)
+			}
+			return nil
+		}))
+		require.Equal(t, []openapi.Location{
+			"/components/schemas/Pet",
+			"/components/schemas/Pet/properties/name",
+			"/components/schemas/Pet/properties/tags",
+			"/components/schemas/Pet/properties/tags/items",
+			"/components/schemas/Pet/additionalProperties",
+			"/paths/~1pets~1{id}/parameters/0/schema",
+		}, schemas)
+		require.Equal(t, []openapi.Location{
+			"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema",
+		}, refs)
+	})
+
+	t.Run("extensions", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testWalkSpec), &spec))
+		require.NoError(t, openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			if node.Extensions != nil {
+				delete(*node.Extensions, "x-internal")
+			}
+			return nil
+		}))
+		require.Empty(t, spec.Spec.Info.Extensions)
+		require.Empty(t, spec.Spec.Paths.Spec.Paths["/pets/{id}"].Spec.Spec.Get.Extensions)
+		require.Empty(t, spec.Spec.Components.Spec.Schemas["Pet"].Spec.Extensions)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testWalkSpec), &spec))
+		require.NoError(t, openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			if s, ok := node.Value.(*openapi.Schema); ok && s.Type != nil && (*s.Type)[0] == openapi.StringType {
+				node.Value = openapi.NewSchemaBuilder().Type(openapi.StringType).MinLength(1).Build().Spec
+			}
+			return nil
+		}))
+		pet := spec.Spec.Components.Spec.Schemas["Pet"].Spec
+		require.Equal(t, 1, *pet.Properties["name"].Spec.MinLength)
+		require.Equal(t, 1, *pet.Properties["tags"].Spec.Items.Schema.Spec.MinLength)
+		require.Equal(t, 1, *spec.Spec.Paths.Spec.Paths["/pets/{id}"].Spec.Spec.Parameters[0].Spec.Spec.Schema.Spec.MinLength)
+
+		err := openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			if _, ok := node.Value.(*openapi.Info); ok {
+				node.Value = &openapi.Contact{}
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, openapi.ErrInvalidValue)
+		require.ErrorContains(t, err, "/info: invalid value")
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testWalkSpec), &spec))
+		var locations []openapi.Location
+		require.NoError(t, openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			locations = append(locations, node.Location)
+			if _, ok := node.Value.(*openapi.Components); ok {
+				return openapi.ErrSkipChildren
+			}
+			return nil
+		}))
+		require.Contains(t, locations, openapi.Location("/components"))
+		require.NotContains(t, locations, openapi.Location("/components/schemas/Pet"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var spec *openapi.Extendable[openapi.OpenAPI]
+		require.NoError(t, yaml.Unmarshal([]byte(testWalkSpec), &spec))
+		errStop := errors.New("stop")
+		var last openapi.Location
+		err := openapi.Walk(spec, func(node *openapi.WalkNode) error {
+			last = node.Location
+			if _, ok := node.Value.(*openapi.PathItem); ok {
+				return errStop
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, openapi.Location("/paths/~1pets~1{id}"), last)
+	})
+}
@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotModified can be returned by a Loader to indicate that the specification has not been changed.
+var ErrNotModified = errors.New("not modified")
+
+// Loader is a function to load a specification, it is used by Document.Watch.
+type Loader func(ctx context.Context) (*Extendable[OpenAPI], error)
+
+type watchOptions struct {
+	interval time.Duration
+	onReload func(spec *Extendable[OpenAPI])
+	onError  func(err error)
+}
+
+// WatchOption is a type for the options of Document.Watch.
+type WatchOption func(*watchOptions)
+
+// WatchInterval sets the interval between the loads of the specification, the default is 10 seconds.
+func WatchInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.interval = d
+	}
+}
+
+// OnReload sets a callback called after a new specification has been swapped into the Document.
+func OnReload(f func(spec *Extendable[OpenAPI])) WatchOption {
+	return func(o *watchOptions) {
+		o.onReload = f
+	}
+}
+
+// OnReloadError sets a callback called if loading or validation of a new specification failed.
+// The Document keeps the current specification in this case.
+func OnReloadError(f func(err error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = f
+	}
+}
+
+// Watch periodically loads the specification using the given loader, validates it,
+// and atomically swaps it into the Document.
+// The loader can return ErrNotModified to skip the reload.
+//
+// The function blocks until the context is canceled and returns the context error,
+// or returns an error wrapping ErrInvalidValue immediately if the interval is not positive.
+func (d *Document) Watch(ctx context.Context, loader Loader, opts ...WatchOption) error {
+	options := &watchOptions{
+		interval: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.interval <= 0 {
+		return fmt.Errorf("%w: watch interval must be positive, but got %s", ErrInvalidValue, options.interval)
+	}
+	ticker := time.NewTicker(options.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			d.reload(ctx, loader, options)
+		}
+	}
+}
+
+func (d *Document) reload(ctx context.Context, loader Loader, options *watchOptions) {
+	spec, err := loader(ctx)
+	if errors.Is(err, ErrNotModified) {
+		return
+	}
+	if err == nil {
+		_, err = d.Swap(spec)
+	}
+	if err != nil {
+		if options.onError != nil {
+			options.onError(err)
+		}
+		return
+	}
+	if options.onReload != nil {
+		options.onReload(spec)
+	}
+}
+
+// FileLoader returns a Loader reading the specification in JSON or YAML format from the given file.
+// The loader returns ErrNotModified if neither modification time nor size of the file has been changed since the last load.
+func FileLoader(name string) Loader {
+	var (
+		mu      sync.Mutex
+		modTime time.Time
+		size    int64 = -1
+	)
+	return func(ctx context.Context) (*Extendable[OpenAPI], error) {
+		mu.Lock()
+		defer mu.Unlock()
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		if info.ModTime().Equal(modTime) && info.Size() == size {
+			return nil, ErrNotModified
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var spec *Extendable[OpenAPI]
+		if isJSON(data) {
+			err = json.Unmarshal(data, &spec)
+		} else {
+			err = yaml.Unmarshal(data, &spec)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q failed: %w", name, err)
+		}
+		modTime, size = info.ModTime(), info.Size()
+		return spec, nil
+	}
+}
@@ -0,0 +1,85 @@
+package openapi_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sv-tools/openapi"
+)
+
+// writeFileAtomically writes the file using rename, so the watcher never reads a partially written file.
+func writeFileAtomically(t *testing.T, name, data string) {
+	t.Helper()
+	tmp := name + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(data), 0o600))
+	require.NoError(t, os.Rename(tmp, name))
+}
+
+func TestDocument_Watch(t *testing.T) {
+	name := path.Join(t.TempDir(), "spec.yaml")
+	writeFileAtomically(t, name, "openapi: 3.1.1\ninfo:\n  title: v1\n  version: 1.0.0\npaths: {}\n")
+
+	loader := openapi.FileLoader(name)
+	initial, err := loader(context.Background())
+	require.NoError(t, err)
+	_, err = loader(context.Background())
+	require.ErrorIs(t, err, openapi.ErrNotModified)
+
+	doc, err := openapi.NewDocument(initial)
+	require.NoError(t, err)
+
+	reloaded := make(chan string, 10)
+	failed := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- doc.Watch(ctx, loader,
+			openapi.WatchInterval(10*time.Millisecond),
+			openapi.OnReload(func(spec *openapi.Extendable[openapi.OpenAPI]) {
+				select {
+				case reloaded <- spec.Spec.Info.Spec.Title:
+				default:
+				}
+			}),
+			openapi.OnReloadError(func(err error) {
+				select {
+				case failed <- err:
+				default:
+				}
+			}),
+		)
+	}()
+
+	// invalid spec is not swapped
+	writeFileAtomically(t, name, "openapi: 3.1.1\ninfo:\n  title: v2\npaths: {}\n")
+	require.ErrorContains(t, <-failed, "version")
+	require.Equal(t, "v1", doc.Spec().Spec.Info.Spec.Title)
+
+	writeFileAtomically(t, name, "openapi: 3.1.1\ninfo:\n  title: v3\n  version: 1.0.0\npaths: {}\n")
+	require.Equal(t, "v3", <-reloaded)
+	require.Equal(t, "v3", doc.Spec().Spec.Info.Spec.Title)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestDocument_Watch_InvalidInterval(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Test").Version("1.0.0").Build()).
+		AddComponent("ID", openapi.NewSchemaBuilder().Type(openapi.IntegerType).Build()).
+		Build()
+	doc, err := openapi.NewDocument(spec, openapi.AllowUnusedComponents())
+	require.NoError(t, err)
+	loader := func(context.Context) (*openapi.Extendable[openapi.OpenAPI], error) {
+		return nil, openapi.ErrNotModified
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := doc.Watch(context.Background(), loader, openapi.WatchInterval(interval))
+		require.ErrorIs(t, err, openapi.ErrInvalidValue)
+	}
+}
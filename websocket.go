@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The headers of the protocol upgrade, RFC 9110, and of the WebSocket handshake, RFC 6455.
+const (
+	// UpgradeHeader is the name of the header holding the protocols to switch to, e.g. `websocket`.
+	UpgradeHeader = "Upgrade"
+	// ConnectionHeader is the name of the header holding the connection options, `Upgrade` for the upgrade requests.
+	ConnectionHeader = "Connection"
+	// SecWebSocketKeyHeader is the name of the request header holding the nonce of the WebSocket handshake.
+	SecWebSocketKeyHeader = "Sec-WebSocket-Key"
+	// SecWebSocketVersionHeader is the name of the request header holding the version of the WebSocket protocol.
+	SecWebSocketVersionHeader = "Sec-WebSocket-Version"
+	// SecWebSocketAcceptHeader is the name of the response header confirming the WebSocket handshake.
+	SecWebSocketAcceptHeader = "Sec-WebSocket-Accept"
+	// SecWebSocketProtocolHeader is the name of the header holding the subprotocols of the WebSocket connection.
+	SecWebSocketProtocolHeader = "Sec-WebSocket-Protocol"
+)
+
+// WebSocketUpgrade documents the operation, usually GET, as the WebSocket handshake:
+// it adds the required `Upgrade`, `Connection`, `Sec-WebSocket-Key` and `Sec-WebSocket-Version` header parameters
+// and `101 Switching Protocols` response with `Upgrade`, `Connection` and `Sec-WebSocket-Accept` headers,
+// unless the response is already declared.
+// If the subprotocols are given, the optional `Sec-WebSocket-Protocol` header parameter is added
+// and the response header selects one of them.
+func (b *OperationBuilder) WebSocketUpgrade(subprotocols ...string) *OperationBuilder {
+	b.AddParameters(
+		NewParameterBuilder().
+			Name(UpgradeHeader).
+			In(InHeader).
+			Description("The protocol to switch to").
+			Required(true).
+			Schema(upgradeSchema()).
+			Build(),
+		NewParameterBuilder().
+			Name(ConnectionHeader).
+			In(InHeader).
+			Description("The connection options including the upgrade").
+			Required(true).
+			Schema(connectionSchema()).
+			Build(),
+		NewParameterBuilder().
+			Name(SecWebSocketKeyHeader).
+			In(InHeader).
+			Description("The base64-encoded random nonce of the handshake").
+			Required(true).
+			Schema(NewSchemaBuilder().Type(StringType).Build()).
+			Build(),
+		NewParameterBuilder().
+			Name(SecWebSocketVersionHeader).
+			In(InHeader).
+			Description("The version of the WebSocket protocol").
+			Required(true).
+			Schema(NewSchemaBuilder().Type(StringType).Enum("13").Build()).
+			Build(),
+	)
+
+	response := NewResponseBuilder().
+		Description(http.StatusText(http.StatusSwitchingProtocols)).
+		AddHeader(UpgradeHeader, NewHeaderBuilder().Required(true).Schema(upgradeSchema()).Build()).
+		AddHeader(ConnectionHeader, NewHeaderBuilder().Required(true).Schema(connectionSchema()).Build()).
+		AddHeader(SecWebSocketAcceptHeader, NewHeaderBuilder().
+			Description("The hash of the nonce confirming the handshake").
+			Required(true).
+			Schema(NewSchemaBuilder().Type(StringType).Build()).
+			Build())
+	if len(subprotocols) > 0 {
+		enum := make([]any, len(subprotocols))
+		for i, p := range subprotocols {
+			enum[i] = p
+		}
+		b.AddParameters(NewParameterBuilder().
+			Name(SecWebSocketProtocolHeader).
+			In(InHeader).
+			Description("The comma separated list of the requested subprotocols: " + strings.Join(subprotocols, ", ")).
+			Schema(NewSchemaBuilder().Type(StringType).Build()).
+			Build())
+		response.AddHeader(SecWebSocketProtocolHeader, NewHeaderBuilder().
+			Description("The selected subprotocol").
+			Schema(NewSchemaBuilder().Type(StringType).Enum(enum...).Build()).
+			Build())
+	}
+
+	if b.spec.Spec.Responses == nil {
+		b.spec.Spec.Responses = NewExtendable(&Responses{})
+	}
+	responses := b.spec.Spec.Responses.Spec
+	if responses.Response == nil {
+		responses.Response = make(map[string]*RefOrSpec[Extendable[Response]], 1)
+	}
+	code := strconv.Itoa(http.StatusSwitchingProtocols)
+	if _, ok := responses.Response[code]; !ok {
+		responses.Response[code] = response.Build()
+	}
+	return b
+}
+
+// upgradeSchema returns the schema of `Upgrade` header switching to WebSocket protocol, case-insensitive.
+func upgradeSchema() *RefOrSpec[Schema] {
+	return NewSchemaBuilder().Type(StringType).Pattern(`(?i)^websocket$`).Examples("websocket").Build()
+}
+
+// connectionSchema returns the schema of `Connection` header containing `Upgrade` option, case-insensitive.
+func connectionSchema() *RefOrSpec[Schema] {
+	return NewSchemaBuilder().Type(StringType).Pattern(`(?i)(^|[\s,])upgrade($|[\s,])`).Examples("Upgrade").Build()
+}
+
+// IsUpgradeOperation reports whether the operation switches the protocol, e.g. the WebSocket handshake,
+// i.e. it declares `101 Switching Protocols` response.
+// Such operations are skipped by the rules checking the conventions of the regular GET operations,
+// like ConditionalRequestsRule and PaginationLinksRule.
+func IsUpgradeOperation(op *Operation) bool {
+	if op == nil || op.Responses == nil || op.Responses.Spec == nil {
+		return false
+	}
+	_, ok := op.Responses.Spec.Response[strconv.Itoa(http.StatusSwitchingProtocols)]
+	return ok
+}
@@ -0,0 +1,158 @@
+package openapi_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sv-tools/openapi"
+)
+
+func TestOperationBuilder_WebSocketUpgrade(t *testing.T) {
+	spec := openapi.NewOpenAPIBuilder().
+		Info(openapi.NewInfoBuilder().Title("Chat").Version("1.0.0").Build()).
+		AddPath("/ws", openapi.NewPathItemBuilder().
+			// the clients not supporting WebSocket get the list of the messages
+			Get(openapi.NewOperationBuilder().
+				WebSocketUpgrade("chat", "superchat").
+				JSONResponseFrom("200", []string{}).
+				Build()).
+			Build()).
+		Build()
+	validator, err := openapi.NewValidator(spec)
+	require.NoError(t, err)
+	require.NoError(t, validator.ValidateSpec())
+
+	op := spec.Spec.Paths.Spec.Paths["/ws"].Spec.Spec.Get.Spec
+	require.True(t, openapi.IsUpgradeOperation(op))
+	require.False(t, openapi.IsUpgradeOperation(openapi.NewOperationBuilder().JSONResponseFrom("200", []string{}).Build().Spec))
+	require.Len(t, op.Parameters, 5)
+	response := op.Responses.Spec.Response["101"].Spec.Spec
+	require.Equal(t, "Switching Protocols", response.Description)
+	require.Len(t, response.Headers, 4)
+
+	for _, tt := range []struct {
+		name    string
+		headers map[string]string
+		err     string
+	}{
+		{
+			name: "handshake",
+			headers: map[string]string{
+				"Upgrade":                "WebSocket",
+				"Connection":             "keep-alive, Upgrade",
+				"Sec-WebSocket-Key":      "dGhlIHNhbXBsZSBub25jZQ==",
+				"Sec-WebSocket-Version":  "13",
+				"Sec-WebSocket-Protocol": "chat",
+			},
+		},
+		{
+			name: "not upgrade",
+			headers: map[string]string{
+				"Upgrade":               "websocket",
+				"Connection":            "keep-alive",
+				"Sec-WebSocket-Key":     "dGhlIHNhbXBsZSBub25jZQ==",
+				"Sec-WebSocket-Version": "13",
+			},
+			err: "header/Connection",
+		},
+		{
+			name: "version",
+			headers: map[string]string{
+				"Upgrade":               "websocket",
+				"Connection":            "Upgrade",
+				"Sec-WebSocket-Key":     "dGhlIHNhbXBsZSBub25jZQ==",
+				"Sec-WebSocket-Version": "8",
+			},
+			err: "header/Sec-WebSocket-Version",
+		},
+		{
+			name: "required",
+			err:  "header/Upgrade: required",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			err := validator.ValidateHTTPRequest(r)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	headers := http.Header{
+		"Upgrade":                []string{"websocket"},
+		"Connection":             []string{"Upgrade"},
+		"Sec-Websocket-Accept":   []string{"s3pPLMBiTxaQ9kYGzzhZRbK+xOo="},
+		"Sec-Websocket-Protocol": []string{"chat"},
+	}
+	require.NoError(t, validator.ValidateResponse(http.MethodGet, "/ws", http.StatusSwitchingProtocols, "", headers, nil))
+	headers.Set("Sec-WebSocket-Protocol", "json")
+	require.ErrorContains(t, validator.ValidateResponse(http.MethodGet, "/ws", http.StatusSwitchingProtocols, "", headers, nil),
+		"header/Sec-WebSocket-Protocol")
+}
+
+// testUpgradeRuleSpec has the operation switching to WebSocket, which is not a regular list endpoint.
+const testUpgradeRuleSpec = `
+openapi: 3.1.1
+info:
+  title: Chat
+  version: 1.0.0
+paths:
+  /ws:
+    get:
+      responses:
+        '101':
+          description: Switching Protocols
+        '200':
+          description: messages
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+`
+
+func TestIsUpgradeOperation_Rules(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		spec     string
+		expected []string
+	}{
+		{
+			name: "upgrade",
+			spec: testUpgradeRuleSpec,
+		},
+		{
+			// the same operation without the upgrade is a regular list endpoint
+			name:     "no upgrade",
+			spec:     strings.Replace(testUpgradeRuleSpec, "'101'", "'304'", 1),
+			expected: []string{openapi.ConditionalRequestsRuleID, openapi.PaginationLinksRuleID},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec *openapi.Extendable[openapi.OpenAPI]
+			require.NoError(t, yaml.Unmarshal([]byte(tt.spec), &spec))
+			validator, err := openapi.NewValidator(spec,
+				openapi.RuleSeverity(openapi.ConditionalRequestsRuleID, openapi.SeverityWarning),
+				openapi.RuleSeverity(openapi.PaginationLinksRuleID, openapi.SeverityWarning),
+			)
+			require.NoError(t, err)
+			var rules []string
+			for _, issue := range validator.Lint() {
+				rules = append(rules, issue.Rule)
+			}
+			require.ElementsMatch(t, tt.expected, rules)
+		})
+	}
+}